@@ -7,14 +7,25 @@ import (
 	"github.com/portfolio/bff-gateway/internal/config"
 	"github.com/portfolio/bff-gateway/internal/grpc"
 	"github.com/portfolio/bff-gateway/internal/router"
+	"github.com/portfolio/shared/grpctls"
+	"github.com/portfolio/shared/jwt"
+	"github.com/portfolio/shared/logging"
 )
 
 func main() {
+	logging.Setup("bff-gateway")
+
 	// Load configuration
 	cfg := config.Load()
 
 	// Initialize gRPC clients
 	clientManager, err := grpc.NewClientManager(
+		grpctls.Config{
+			Enabled:  cfg.GRPCTLSEnabled,
+			CertFile: cfg.GRPCTLSCertFile,
+			KeyFile:  cfg.GRPCTLSKeyFile,
+			CAFile:   cfg.GRPCTLSCAFile,
+		},
 		cfg.AuthServiceURL,
 		cfg.ProjectServiceURL,
 		cfg.TaskServiceURL,
@@ -26,8 +37,18 @@ func main() {
 	}
 	defer clientManager.Close()
 
+	// Tokens are only validated here, never issued
+	tokens, err := jwt.NewTokenServiceFromConfig(jwt.Config{
+		Algorithm:     cfg.JWTAlgorithm,
+		Secret:        cfg.JWTSecret,
+		PublicKeyFile: cfg.JWTPublicKeyFile,
+	}, 0)
+	if err != nil {
+		log.Fatalf("Failed to set up token validation: %v", err)
+	}
+
 	// Setup router
-	r := router.SetupRouter(cfg.JWTSecret, clientManager)
+	r := router.SetupRouter(cfg, clientManager, tokens)
 
 	// Start server
 	addr := fmt.Sprintf(":%d", cfg.HTTPPort)
@@ -4,8 +4,11 @@ go 1.21
 
 require (
 	github.com/gin-gonic/gin v1.9.1
+	github.com/go-playground/validator/v10 v10.15.5
 	github.com/portfolio/proto v0.0.0
 	github.com/portfolio/shared v0.0.0
+	github.com/prometheus/client_golang v1.19.1
+	golang.org/x/sync v0.8.0
 	google.golang.org/grpc v1.64.0
 	google.golang.org/protobuf v1.34.0
 )
@@ -0,0 +1,99 @@
+package apierror
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Body describes an error; Code is the gRPC code name, e.g. NOT_FOUND
+type Body struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Response is the envelope of every error response: {"error": {"code", "message"}}
+type Response struct {
+	Error Body `json:"error"`
+}
+
+type mapping struct {
+	httpStatus int
+	name       string
+}
+
+// mappings covers every gRPC code; anything missing is treated as Internal
+var mappings = map[codes.Code]mapping{
+	codes.Canceled:           {499, "CANCELLED"}, // client closed request
+	codes.Unknown:            {http.StatusInternalServerError, "INTERNAL"},
+	codes.InvalidArgument:    {http.StatusBadRequest, "INVALID_ARGUMENT"},
+	codes.DeadlineExceeded:   {http.StatusGatewayTimeout, "DEADLINE_EXCEEDED"},
+	codes.NotFound:           {http.StatusNotFound, "NOT_FOUND"},
+	codes.AlreadyExists:      {http.StatusConflict, "ALREADY_EXISTS"},
+	codes.PermissionDenied:   {http.StatusForbidden, "PERMISSION_DENIED"},
+	codes.ResourceExhausted:  {http.StatusTooManyRequests, "RESOURCE_EXHAUSTED"},
+	codes.FailedPrecondition: {http.StatusConflict, "FAILED_PRECONDITION"},
+	codes.Aborted:            {http.StatusConflict, "ABORTED"},
+	codes.OutOfRange:         {http.StatusBadRequest, "OUT_OF_RANGE"},
+	codes.Unimplemented:      {http.StatusNotImplemented, "UNIMPLEMENTED"},
+	codes.Internal:           {http.StatusInternalServerError, "INTERNAL"},
+	codes.Unavailable:        {http.StatusServiceUnavailable, "UNAVAILABLE"},
+	codes.DataLoss:           {http.StatusInternalServerError, "INTERNAL"},
+	codes.Unauthenticated:    {http.StatusUnauthorized, "UNAUTHENTICATED"},
+}
+
+func lookup(code codes.Code) mapping {
+	if m, ok := mappings[code]; ok {
+		return m
+	}
+	return mappings[codes.Internal]
+}
+
+// HTTPStatus returns the HTTP status for a gRPC code
+func HTTPStatus(code codes.Code) int {
+	return lookup(code).httpStatus
+}
+
+// Respond aborts the request with the HTTP status for code and the given message
+func Respond(c *gin.Context, code codes.Code, message string) {
+	m := lookup(code)
+	c.AbortWithStatusJSON(m.httpStatus, Response{Error: Body{Code: m.name, Message: message}})
+}
+
+// RespondGRPC aborts the request with the HTTP status matching a backend
+// error. Client errors keep the backend message; for server errors the
+// message is replaced by the generic status text and the original error is
+// attached to the context for the request log.
+func RespondGRPC(c *gin.Context, err error) {
+	st := status.Convert(err)
+	m := lookup(st.Code())
+	message := st.Message()
+	if m.httpStatus >= http.StatusInternalServerError {
+		_ = c.Error(err)
+		message = http.StatusText(m.httpStatus)
+	}
+	c.AbortWithStatusJSON(m.httpStatus, Response{Error: Body{Code: m.name, Message: message}})
+}
+
+// HandlerFunc is a gin handler that returns its error instead of writing it
+type HandlerFunc func(c *gin.Context) error
+
+// Handle adapts h to gin. A returned error is rendered with RespondGRPC, so
+// gRPC errors from the backend can be returned as they are and validation
+// failures as status.Error(codes.InvalidArgument, ...). An error returned
+// after the handler already wrote a response is only attached to the context.
+func Handle(h HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h(c)
+		if err == nil {
+			return
+		}
+		if c.Writer.Written() {
+			_ = c.Error(err)
+			return
+		}
+		RespondGRPC(c, err)
+	}
+}
@@ -0,0 +1,202 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want int
+	}{
+		{codes.Canceled, 499},
+		{codes.Unknown, http.StatusInternalServerError},
+		{codes.InvalidArgument, http.StatusBadRequest},
+		{codes.DeadlineExceeded, http.StatusGatewayTimeout},
+		{codes.NotFound, http.StatusNotFound},
+		{codes.AlreadyExists, http.StatusConflict},
+		{codes.PermissionDenied, http.StatusForbidden},
+		{codes.ResourceExhausted, http.StatusTooManyRequests},
+		{codes.FailedPrecondition, http.StatusConflict},
+		{codes.Aborted, http.StatusConflict},
+		{codes.OutOfRange, http.StatusBadRequest},
+		{codes.Unimplemented, http.StatusNotImplemented},
+		{codes.Internal, http.StatusInternalServerError},
+		{codes.Unavailable, http.StatusServiceUnavailable},
+		{codes.DataLoss, http.StatusInternalServerError},
+		{codes.Unauthenticated, http.StatusUnauthorized},
+		{codes.Code(99), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.code); got != tt.want {
+			t.Errorf("HTTPStatus(%s) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
+
+func serve(handler gin.HandlerFunc) (*httptest.ResponseRecorder, *gin.Context) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	handler(c)
+	return w, c
+}
+
+func decode(t *testing.T, w *httptest.ResponseRecorder) Body {
+	t.Helper()
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON body %q: %v", w.Body.String(), err)
+	}
+	return resp.Error
+}
+
+func TestRespond(t *testing.T) {
+	w, c := serve(func(c *gin.Context) {
+		Respond(c, codes.InvalidArgument, "name is required")
+	})
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+	if !c.IsAborted() {
+		t.Error("context was not aborted")
+	}
+	body := decode(t, w)
+	if body.Code != "INVALID_ARGUMENT" || body.Message != "name is required" {
+		t.Errorf("body = %+v, want INVALID_ARGUMENT / name is required", body)
+	}
+}
+
+func TestRespondGRPC_ClientError(t *testing.T) {
+	w, c := serve(func(c *gin.Context) {
+		RespondGRPC(c, status.Error(codes.NotFound, "task not found"))
+	})
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+	body := decode(t, w)
+	if body.Code != "NOT_FOUND" || body.Message != "task not found" {
+		t.Errorf("body = %+v, want NOT_FOUND / task not found", body)
+	}
+	if len(c.Errors) != 0 {
+		t.Errorf("client errors should not be recorded, got %v", c.Errors)
+	}
+}
+
+func TestRespondGRPC_HidesInternalErrors(t *testing.T) {
+	w, c := serve(func(c *gin.Context) {
+		RespondGRPC(c, status.Error(codes.Internal, "pq: relation \"tasks\" does not exist"))
+	})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	body := decode(t, w)
+	if body.Code != "INTERNAL" || body.Message != "Internal Server Error" {
+		t.Errorf("body = %+v, want INTERNAL / Internal Server Error", body)
+	}
+	if len(c.Errors) != 1 {
+		t.Errorf("expected the original error on the context, got %v", c.Errors)
+	}
+}
+
+func TestRespondGRPC_NonStatusError(t *testing.T) {
+	w, _ := serve(func(c *gin.Context) {
+		RespondGRPC(c, errors.New("connection reset"))
+	})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	if body := decode(t, w); body.Message != "Internal Server Error" {
+		t.Errorf("message = %q, want the generic status text", body.Message)
+	}
+}
+
+func TestRespondGRPC_Unavailable(t *testing.T) {
+	w, _ := serve(func(c *gin.Context) {
+		RespondGRPC(c, status.Error(codes.Unavailable, "dial tcp 10.0.0.3:50053: connection refused"))
+	})
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	body := decode(t, w)
+	if body.Code != "UNAVAILABLE" || body.Message != "Service Unavailable" {
+		t.Errorf("body = %+v, want UNAVAILABLE / Service Unavailable", body)
+	}
+}
+
+func TestHandle(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+		wantMsg    string
+	}{
+		{"not found", status.Error(codes.NotFound, "file not found"), http.StatusNotFound, "NOT_FOUND", "file not found"},
+		{"invalid argument", status.Error(codes.InvalidArgument, "Invalid ID"), http.StatusBadRequest, "INVALID_ARGUMENT", "Invalid ID"},
+		{"permission denied", status.Error(codes.PermissionDenied, "not yours"), http.StatusForbidden, "PERMISSION_DENIED", "not yours"},
+		{"failed precondition", status.Error(codes.FailedPrecondition, "project has open tasks"), http.StatusConflict, "FAILED_PRECONDITION", "project has open tasks"},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "token expired"), http.StatusUnauthorized, "UNAUTHENTICATED", "token expired"},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), http.StatusServiceUnavailable, "UNAVAILABLE", "Service Unavailable"},
+		{"internal", status.Error(codes.Internal, "pq: syntax error"), http.StatusInternalServerError, "INTERNAL", "Internal Server Error"},
+		{"plain error", errors.New("boom"), http.StatusInternalServerError, "INTERNAL", "Internal Server Error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, c := serve(Handle(func(c *gin.Context) error { return tt.err }))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if !c.IsAborted() {
+				t.Error("context was not aborted")
+			}
+			body := decode(t, w)
+			if body.Code != tt.wantCode || body.Message != tt.wantMsg {
+				t.Errorf("body = %+v, want %s / %s", body, tt.wantCode, tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestHandle_NoError(t *testing.T) {
+	w, c := serve(Handle(func(c *gin.Context) error {
+		c.JSON(http.StatusCreated, gin.H{"id": 1})
+		return nil
+	}))
+
+	if w.Code != http.StatusCreated || c.IsAborted() || len(c.Errors) != 0 {
+		t.Errorf("status = %d, aborted = %v, errors = %v; want the handler's 201 untouched", w.Code, c.IsAborted(), c.Errors)
+	}
+}
+
+func TestHandle_AlreadyWritten(t *testing.T) {
+	w, c := serve(Handle(func(c *gin.Context) error {
+		c.JSON(http.StatusOK, gin.H{"partial": true})
+		return status.Error(codes.Internal, "failed after writing")
+	}))
+
+	if w.Code != http.StatusOK || strings.Contains(w.Body.String(), "error") {
+		t.Errorf("response = %d %s, want the handler's response kept", w.Code, w.Body.String())
+	}
+	if len(c.Errors) != 1 {
+		t.Errorf("expected the error on the context for the request log, got %v", c.Errors)
+	}
+}
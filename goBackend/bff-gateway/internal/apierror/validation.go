@@ -0,0 +1,182 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/grpc/codes"
+)
+
+// ValidationFailed is the code of a request whose fields failed validation
+const ValidationFailed = "VALIDATION_FAILED"
+
+// ValidationResponse is the error envelope for invalid fields:
+// {"error": {"code": "VALIDATION_FAILED", "message", "fields": {"email": "must be a valid email"}}}
+type ValidationResponse struct {
+	Error ValidationBody `json:"error"`
+}
+
+// ValidationBody is a Body with a message per invalid field, keyed by the
+// field's name in the request
+type ValidationBody struct {
+	Body
+	Fields map[string]string `json:"fields"`
+}
+
+func init() {
+	// Report fields by their request names rather than the Go field names
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// requestFieldName is the name a struct field is bound from: its json, form
+// or uri tag, else the Go name
+func requestFieldName(f reflect.StructField) string {
+	for _, tag := range []string{"json", "form", "uri"} {
+		name, _, _ := strings.Cut(f.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return f.Name
+}
+
+// RespondBinding aborts the request with the error from binding its body,
+// query or URI. Validation failures and values of the wrong type are listed
+// per field; anything else, like malformed JSON, is a plain INVALID_ARGUMENT.
+func RespondBinding(c *gin.Context, err error) {
+	fields := FieldErrors(err)
+	if fields == nil {
+		var syntaxErr *json.SyntaxError
+		switch {
+		case errors.Is(err, io.EOF):
+			Respond(c, codes.InvalidArgument, "request body is empty")
+			return
+		case errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+			Respond(c, codes.InvalidArgument, "request body is not valid JSON")
+			return
+		}
+		Respond(c, codes.InvalidArgument, err.Error())
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusBadRequest, ValidationResponse{Error: ValidationBody{
+		Body:   Body{Code: ValidationFailed, Message: "request has invalid fields"},
+		Fields: fields,
+	}})
+}
+
+// FieldErrors maps the fields a binding error is about to human readable
+// messages, or returns nil when it isn't about specific fields
+func FieldErrors(err error) map[string]string {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make(map[string]string, len(invalid))
+		for _, fe := range invalid {
+			fields[fieldPath(fe)] = fieldMessage(fe)
+		}
+		return fields
+	}
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return map[string]string{typeErr.Field: "must be " + kindName(typeErr.Type.Kind())}
+	}
+	return nil
+}
+
+// fieldPath is the field's path below the bound struct, e.g. items[0].name
+func fieldPath(fe validator.FieldError) string {
+	_, path, found := strings.Cut(fe.Namespace(), ".")
+	if !found || path == "" {
+		return fe.Field()
+	}
+	return path
+}
+
+// fieldMessage translates a failed validator tag into a message
+func fieldMessage(fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "required_without":
+		return "is required when " + snakeCase(fe.Param()) + " is not given"
+	case "excluded_with":
+		return "must not be given together with " + snakeCase(fe.Param())
+	case "email":
+		return "must be a valid email"
+	case "url", "http_url":
+		return "must be a valid URL"
+	case "oneof":
+		return "must be one of " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "min", "gte":
+		return "must " + sizeBound(fe, "at least")
+	case "max", "lte":
+		return "must " + sizeBound(fe, "at most")
+	case "len":
+		return "must " + sizeBound(fe, "exactly")
+	case "gt":
+		return "must be greater than " + fe.Param()
+	case "lt":
+		return "must be less than " + fe.Param()
+	}
+	return fmt.Sprintf("failed the %s check", fe.Tag())
+}
+
+// sizeBound phrases a length or value bound for the field's kind
+func sizeBound(fe validator.FieldError, bound string) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("be %s %s characters long", bound, fe.Param())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("have %s %s items", bound, fe.Param())
+	}
+	return fmt.Sprintf("be %s %s", bound, fe.Param())
+}
+
+// kindName describes the JSON type a Go kind is decoded from
+func kindName(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "true or false"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a whole number"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "a list"
+	}
+	return "an object"
+}
+
+// snakeCase turns a Go field name a tag refers to, like MediaFileID, into
+// its request name, media_file_id
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prevLower := unicode.IsLower(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
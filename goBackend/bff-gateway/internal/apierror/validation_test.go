@@ -0,0 +1,97 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type validationRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Status      string   `json:"status" binding:"omitempty,oneof=active archived"`
+	Tags        []string `json:"tags" binding:"max=2"`
+	Priority    int      `json:"priority" binding:"min=1"`
+	FileURL     string   `json:"file_url" binding:"required_without=MediaFileID"`
+	MediaFileID int64    `json:"media_file_id"`
+}
+
+func bindAndRespond(body string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	var req validationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		RespondBinding(c, err)
+	}
+	return w
+}
+
+func TestRespondBinding_Fields(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want map[string]string
+	}{
+		{
+			name: "several fields",
+			body: `{"status": "done", "tags": ["a", "b", "c"]}`,
+			want: map[string]string{
+				"name":     "is required",
+				"status":   "must be one of active, archived",
+				"tags":     "must have at most 2 items",
+				"priority": "must be at least 1",
+				"file_url": "is required when media_file_id is not given",
+			},
+		},
+		{
+			name: "wrong type",
+			body: `{"name": "x", "priority": "high"}`,
+			want: map[string]string{"priority": "must be a whole number"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := bindAndRespond(tt.body)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", w.Code)
+			}
+			var resp ValidationResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("invalid JSON body %q: %v", w.Body.String(), err)
+			}
+			if resp.Error.Code != ValidationFailed || !reflect.DeepEqual(resp.Error.Fields, tt.want) {
+				t.Errorf("error = %+v, want %s with fields %v", resp.Error, ValidationFailed, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondBinding_MalformedJSON(t *testing.T) {
+	w := bindAndRespond(`{"name": `)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", w.Code)
+	}
+	if body := decode(t, w); body.Code != "INVALID_ARGUMENT" || body.Message != "request body is not valid JSON" {
+		t.Errorf("body = %+v, want INVALID_ARGUMENT / request body is not valid JSON", body)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"MediaFileID": "media_file_id",
+		"FileURL":     "file_url",
+		"ID":          "id",
+		"StartDate":   "start_date",
+	} {
+		if got := snakeCase(in); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package config
 
 import (
-	"fmt"
+	"log/slog"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -20,24 +22,70 @@ type Config struct {
 	AnalyticsServiceURL string
 	MediaServiceURL     string
 
-	// JWT
-	JWTSecret string
+	// TLS for the backend connections; the certificate and key are only
+	// needed when the services require client certificates
+	GRPCTLSEnabled  bool
+	GRPCTLSCertFile string
+	GRPCTLSKeyFile  string
+	GRPCTLSCAFile   string
+
+	// JWT; with RS256 only the auth service's public key is needed
+	JWTAlgorithm     string
+	JWTSecret        string
+	JWTPublicKeyFile string
+
+	// Ask the auth service whether each token has been revoked
+	CheckTokenRevocation bool
+
+	// Timeouts for calls to the backend services
+	RequestTimeout time.Duration
+	UploadTimeout  time.Duration
+
+	// How long role permissions from the auth service are cached
+	RolePermissionsTTL time.Duration
+
+	// Feed pages hold DefaultPageSize items unless a request asks for
+	// another size, up to MaxPageSize; other lists are paged by the services
+	DefaultPageSize int
+	MaxPageSize     int
+
+	// CORS; empty lists keep the development defaults
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	// Load environment variables from .env file
 	if err := godotenv.Load(); err != nil {
-		fmt.Println("Failed to load environment variables")
+		slog.Debug("no .env file loaded", "error", err)
 	}
 	return &Config{
-		HTTPPort:            getEnvInt("HTTP_PORT", 8080),
-		AuthServiceURL:      getEnv("AUTH_SERVICE_URL", "localhost:50051"),
-		ProjectServiceURL:   getEnv("PROJECT_SERVICE_URL", "localhost:50052"),
-		TaskServiceURL:      getEnv("TASK_SERVICE_URL", "localhost:50053"),
-		AnalyticsServiceURL: getEnv("ANALYTICS_SERVICE_URL", "localhost:50054"),
-		MediaServiceURL:     getEnv("MEDIA_SERVICE_URL", "localhost:50055"),
-		JWTSecret:           getEnv("JWT_SECRET", "development-secret-key"),
+		HTTPPort:             getEnvInt("HTTP_PORT", 8080),
+		AuthServiceURL:       getEnv("AUTH_SERVICE_URL", "localhost:50051"),
+		ProjectServiceURL:    getEnv("PROJECT_SERVICE_URL", "localhost:50052"),
+		TaskServiceURL:       getEnv("TASK_SERVICE_URL", "localhost:50053"),
+		AnalyticsServiceURL:  getEnv("ANALYTICS_SERVICE_URL", "localhost:50054"),
+		MediaServiceURL:      getEnv("MEDIA_SERVICE_URL", "localhost:50055"),
+		GRPCTLSEnabled:       getEnvBool("GRPC_TLS_ENABLED", false),
+		GRPCTLSCertFile:      getEnv("GRPC_TLS_CERT_FILE", ""),
+		GRPCTLSKeyFile:       getEnv("GRPC_TLS_KEY_FILE", ""),
+		GRPCTLSCAFile:        getEnv("GRPC_TLS_CA_FILE", ""),
+		JWTAlgorithm:         getEnv("JWT_ALGORITHM", "HS256"),
+		JWTSecret:            getEnv("JWT_SECRET", "development-secret-key"),
+		JWTPublicKeyFile:     getEnv("JWT_PUBLIC_KEY_FILE", ""),
+		CheckTokenRevocation: getEnvBool("CHECK_TOKEN_REVOCATION", true),
+		RequestTimeout:       getEnvDuration("REQUEST_TIMEOUT", 5*time.Second),
+		UploadTimeout:        getEnvDuration("UPLOAD_TIMEOUT", time.Minute),
+		RolePermissionsTTL:   getEnvDuration("ROLE_PERMISSIONS_TTL", time.Minute),
+		DefaultPageSize:      getEnvInt("DEFAULT_PAGE_SIZE", 20),
+		MaxPageSize:          getEnvInt("MAX_PAGE_SIZE", 100),
+		AllowedOrigins:       getEnvList("ALLOWED_ORIGINS"),
+		AllowedMethods:       getEnvList("ALLOWED_METHODS"),
+		AllowedHeaders:       getEnvList("ALLOWED_HEADERS"),
+		ExposedHeaders:       getEnvList("EXPOSED_HEADERS"),
 	}
 }
 
@@ -56,3 +104,32 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList reads a comma-separated list, skipping empty entries
+func getEnvList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
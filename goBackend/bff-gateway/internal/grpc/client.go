@@ -2,11 +2,13 @@ package grpc
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/portfolio/shared/grpctls"
+	"github.com/portfolio/shared/middleware"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // ClientManager manages gRPC client connections
@@ -18,14 +20,25 @@ type ClientManager struct {
 	mediaConn     *grpc.ClientConn
 }
 
-// NewClientManager creates a new ClientManager
-func NewClientManager(authURL, projectURL, taskURL, analyticsURL, mediaURL string) (*ClientManager, error) {
+// NewClientManager creates a new ClientManager. Connections are plaintext
+// unless tlsCfg enables TLS; with a client certificate they also work
+// against services that require mutual TLS.
+func NewClientManager(tlsCfg grpctls.Config, authURL, projectURL, taskURL, analyticsURL, mediaURL string) (*ClientManager, error) {
+	creds, err := grpctls.DialOption(tlsCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up gRPC TLS: %w", err)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	opts := []grpc.DialOption{
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		creds,
 		grpc.WithBlock(),
+		// Services read the authenticated user from metadata instead of
+		// trusting user ids in request bodies
+		grpc.WithChainUnaryInterceptor(middleware.IdentityClientInterceptor()),
+		grpc.WithChainStreamInterceptor(middleware.IdentityStreamClientInterceptor()),
 	}
 
 	// Connect to Auth Service
@@ -0,0 +1,16 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/portfolio/shared/grpctls"
+)
+
+func TestNewClientManager_InvalidTLS(t *testing.T) {
+	// A bad TLS setup fails before any service is dialed
+	cfg := grpctls.Config{Enabled: true, CAFile: "missing-ca.crt"}
+	if m, err := NewClientManager(cfg, "auth", "project", "task", "analytics", "media"); err == nil {
+		m.Close()
+		t.Fatal("NewClientManager() expected error for a missing CA file")
+	}
+}
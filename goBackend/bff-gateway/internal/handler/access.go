@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	authpb "github.com/portfolio/proto/auth"
+	"google.golang.org/grpc"
+)
+
+// Project access levels granted through the auth service
+const (
+	AccessRead  = "read"
+	AccessWrite = "write"
+	AccessAdmin = "admin"
+)
+
+// ProjectAccessChecker checks a user's access to projects via the auth service
+type ProjectAccessChecker struct {
+	authClient authpb.AuthServiceClient
+}
+
+// NewProjectAccessChecker creates a new ProjectAccessChecker
+func NewProjectAccessChecker(conn *grpc.ClientConn) *ProjectAccessChecker {
+	return &ProjectAccessChecker{
+		authClient: authpb.NewAuthServiceClient(conn),
+	}
+}
+
+// HasAccess reports whether userID holds at least level on projectID
+func (a *ProjectAccessChecker) HasAccess(ctx context.Context, userID, projectID int64, level string) (bool, error) {
+	resp, err := a.authClient.CheckAccess(ctx, &authpb.CheckAccessRequest{
+		UserId:    userID,
+		ProjectId: projectID,
+		MinLevel:  level,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Allowed, nil
+}
+
+// AccessibleProjects lists the projects userID holds any access level on
+func (a *ProjectAccessChecker) AccessibleProjects(ctx context.Context, userID int64) ([]int64, error) {
+	resp, err := a.authClient.GetUserProjectAccess(ctx, &authpb.GetUserProjectAccessRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(resp.Accesses))
+	for _, access := range resp.Accesses {
+		ids = append(ids, access.ProjectId)
+	}
+	return ids, nil
+}
+
+// currentUserID returns the id of the authenticated user set by AuthMiddleware
+func currentUserID(c *gin.Context) int64 {
+	userIDVal, _ := c.Get("user_id")
+	if v, ok := userIDVal.(float64); ok {
+		return int64(v)
+	} else if v, ok := userIDVal.(int64); ok {
+		return v
+	}
+	return 0
+}
+
+// isAdmin reports whether the authenticated user has the admin role,
+// which grants access to every project
+func isAdmin(c *gin.Context) bool {
+	return c.GetString("role") == "admin"
+}
@@ -1,26 +1,32 @@
 package handler
 
 import (
-	"context"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
 	pb "github.com/portfolio/proto/analytics"
+	projectpb "github.com/portfolio/proto/project"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // AnalyticsHandler handles analytics endpoints
 type AnalyticsHandler struct {
 	analyticsClient pb.AnalyticsServiceClient
+	projectClient   projectpb.ProjectServiceClient
+	access          *ProjectAccessChecker
 }
 
 // NewAnalyticsHandler creates a new AnalyticsHandler
-func NewAnalyticsHandler(conn *grpc.ClientConn) *AnalyticsHandler {
+func NewAnalyticsHandler(conn, authConn, projectConn *grpc.ClientConn) *AnalyticsHandler {
 	return &AnalyticsHandler{
 		analyticsClient: pb.NewAnalyticsServiceClient(conn),
+		projectClient:   projectpb.NewProjectServiceClient(projectConn),
+		access:          NewProjectAccessChecker(authConn),
 	}
 }
 
@@ -41,35 +47,66 @@ func (h *AnalyticsHandler) RecordProjectView(c *gin.Context) {
 	projectIDStr := c.Param("id")
 	projectID, err := strconv.ParseInt(projectIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Project ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
 		return
 	}
 
-	userIDVal, _ := c.Get("user_id")
-	var userID int64
-	if v, ok := userIDVal.(float64); ok {
-		userID = int64(v)
-	} else if v, ok := userIDVal.(int64); ok {
-		userID = v
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// The analytics service takes the viewer from the caller in the metadata
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	_, err = h.analyticsClient.RecordProjectView(ctx, &pb.RecordProjectViewRequest{
 		ProjectId: projectID,
-		UserId:    userID,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":    "Record project view endpoint",
 		"project_id": projectID,
-		"user_id":    userID,
+		"user_id":    c.GetInt64("user_id"),
+	})
+}
+
+// BatchRecordProjectViews records views a client collected and flushes in
+// one request. Repeats and invalid entries are skipped, not rejected.
+// POST /api/analytics/views/batch
+func (h *AnalyticsHandler) BatchRecordProjectViews(c *gin.Context) {
+	var req struct {
+		Views []struct {
+			ProjectID int64      `json:"project_id"`
+			UserID    int64      `json:"user_id"` // admins only; defaults to the caller
+			ViewedAt  *time.Time `json:"viewed_at"`
+		} `json:"views" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	entries := make([]*pb.ProjectViewEntry, len(req.Views))
+	for i, v := range req.Views {
+		entries[i] = &pb.ProjectViewEntry{ProjectId: v.ProjectID, UserId: v.UserID}
+		if v.ViewedAt != nil {
+			entries[i].ViewedAt = timestamppb.New(*v.ViewedAt)
+		}
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.analyticsClient.BatchRecordProjectViews(ctx, &pb.BatchRecordProjectViewsRequest{Views: entries})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"recorded": resp.Recorded,
+		"skipped":  resp.Skipped,
 	})
 }
 
@@ -79,28 +116,38 @@ func (h *AnalyticsHandler) GetProjectViews(c *gin.Context) {
 	projectIDStr := c.Param("id")
 	projectID, err := strconv.ParseInt(projectIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Project ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
 		return
 	}
 
 	startDate := c.Query("start_date")
 	endDate := c.Query("end_date")
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.analyticsClient.GetProjectViews(ctx, &pb.GetProjectViewsRequest{
 		ProjectId: projectID,
 		StartDate: parseTimeOrNil(startDate),
 		EndDate:   parseTimeOrNil(endDate),
+		Page:      page,
+		Limit:     limit,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	views := resp.Views
+	if views == nil {
+		views = []*pb.ProjectView{}
+	}
+	respondPaginated(c, views, resp.TotalViews, page, resp.Limit)
 }
 
 // RecordTaskActivity records a task activity
@@ -109,7 +156,7 @@ func (h *AnalyticsHandler) RecordTaskActivity(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
 		return
 	}
 
@@ -125,11 +172,11 @@ func (h *AnalyticsHandler) RecordTaskActivity(c *gin.Context) {
 		Action string `json:"action" binding:"required"` // created, updated, completed
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	_, err = h.analyticsClient.RecordTaskActivity(ctx, &pb.RecordTaskActivityRequest{
@@ -139,7 +186,7 @@ func (h *AnalyticsHandler) RecordTaskActivity(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -157,23 +204,34 @@ func (h *AnalyticsHandler) GetTaskActivities(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+
+	page, limit, ok := parsePagination(c)
+	if !ok {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.analyticsClient.GetTaskActivities(ctx, &pb.GetTaskActivitiesRequest{
 		TaskId: taskID,
+		Page:   page,
+		Limit:  limit,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Activities)
+	activities := resp.Activities
+	if activities == nil {
+		activities = []*pb.TaskActivity{}
+	}
+	respondPaginated(c, activities, resp.Total, page, resp.Limit)
 }
 
 // GetProjectStats returns project statistics
@@ -182,11 +240,11 @@ func (h *AnalyticsHandler) GetProjectStats(c *gin.Context) {
 	projectIDStr := c.Param("id")
 	projectID, err := strconv.ParseInt(projectIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Project ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.analyticsClient.GetProjectStats(ctx, &pb.GetProjectStatsRequest{
@@ -194,37 +252,281 @@ func (h *AnalyticsHandler) GetProjectStats(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, resp.Stats)
 }
 
-// GetDashboardStats returns dashboard statistics
+// defaultCycleTimeRange is how far back cycle time looks without a start_date
+const defaultCycleTimeRange = 30 * 24 * time.Hour
+
+// CycleTimeResponse is how long a project's tasks took from creation to
+// completion. The lead times are null when no task was completed in the range.
+type CycleTimeResponse struct {
+	ProjectID              int64     `json:"project_id"`
+	StartDate              time.Time `json:"start_date"`
+	EndDate                time.Time `json:"end_date"`
+	CompletedTasks         int32     `json:"completed_tasks"`
+	AverageLeadTimeSeconds *float64  `json:"average_lead_time_seconds"`
+	MedianLeadTimeSeconds  *float64  `json:"median_lead_time_seconds"`
+}
+
+// parseTimeParam parses an optional RFC 3339 query parameter, falling back
+// to def when it is absent
+func parseTimeParam(c *gin.Context, name string, def time.Time) (time.Time, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return def, true
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, name+" must be an RFC 3339 time")
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// GetCycleTime returns the average and median lead time of the project's
+// tasks completed in the range, the last 30 days by default
+// GET /api/analytics/projects/:id/cycle-time?start_date=&end_date=
+func (h *AnalyticsHandler) GetCycleTime(c *gin.Context) {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
+		return
+	}
+	endDate, ok := parseTimeParam(c, "end_date", time.Now())
+	if !ok {
+		return
+	}
+	startDate, ok := parseTimeParam(c, "start_date", endDate.Add(-defaultCycleTimeRange))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.analyticsClient.GetCycleTime(ctx, &pb.GetCycleTimeRequest{
+		ProjectId: projectID,
+		Since:     timestamppb.New(startDate),
+		Until:     timestamppb.New(endDate),
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	cycle := CycleTimeResponse{
+		ProjectID:      projectID,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		CompletedTasks: resp.CompletedTasks,
+	}
+	if resp.CompletedTasks > 0 {
+		cycle.AverageLeadTimeSeconds = &resp.AverageLeadTimeSeconds
+		cycle.MedianLeadTimeSeconds = &resp.MedianLeadTimeSeconds
+	}
+	c.JSON(http.StatusOK, cycle)
+}
+
+// GetDashboardStats returns dashboard statistics over the projects the user
+// can access; admins see every project
 // GET /api/analytics/dashboard
 func (h *AnalyticsHandler) GetDashboardStats(c *gin.Context) {
-	userIDVal, exists := c.Get("user_id")
-	var userID int64
-	if exists {
-		if v, ok := userIDVal.(float64); ok {
-			userID = int64(v)
-		} else if v, ok := userIDVal.(int64); ok {
-			userID = v
+	userID := currentUserID(c)
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	req := &pb.GetDashboardStatsRequest{UserId: userID, AllProjects: isAdmin(c)}
+	if !req.AllProjects {
+		projectIDs, err := h.access.AccessibleProjects(ctx, userID)
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		req.ProjectIds = projectIDs
+	}
+
+	resp, err := h.analyticsClient.GetDashboardStats(ctx, req)
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// Trending ranges are given in hours or days, e.g. 24h or 7d
+const (
+	defaultTrendRange = "7d"
+	maxTrendRange     = 90 * 24 * time.Hour
+)
+
+// parseTrendRange parses the range query parameter
+func parseTrendRange(c *gin.Context) (time.Duration, bool) {
+	raw := c.DefaultQuery("range", defaultTrendRange)
+	units := map[string]time.Duration{"h": time.Hour, "d": 24 * time.Hour}
+	var d time.Duration
+	if len(raw) > 1 {
+		n, err := strconv.Atoi(raw[:len(raw)-1])
+		if unit, ok := units[raw[len(raw)-1:]]; ok && err == nil {
+			d = time.Duration(n) * unit
 		}
 	}
+	if d <= 0 || d > maxTrendRange {
+		apierror.Respond(c, codes.InvalidArgument, "range must be a number of hours or days up to 90d, e.g. 24h or 7d")
+		return 0, false
+	}
+	return d, true
+}
+
+// TrendingProjectResponse is a ranked project with its name
+type TrendingProjectResponse struct {
+	ProjectID int64   `json:"project_id"`
+	Name      string  `json:"name"`
+	Views     int32   `json:"views"`
+	Score     float64 `json:"score"`
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+// GetTrendingProjects returns the most viewed projects the user can access
+// over the range, ranked by views weighted by recency or, with sort=views,
+// by view count
+// GET /api/analytics/trending?range=7d&limit=10
+func (h *AnalyticsHandler) GetTrendingProjects(c *gin.Context) {
+	span, ok := parseTrendRange(c)
+	if !ok {
+		return
+	}
+	limit, ok := parsePageParam(c, "limit", 10)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
-	resp, err := h.analyticsClient.GetDashboardStats(ctx, &pb.GetDashboardStatsRequest{
-		UserId: userID,
-	})
+	req := &pb.GetTrendingProjectsRequest{
+		Since:       timestamppb.New(time.Now().Add(-span)),
+		Limit:       limit,
+		AllProjects: isAdmin(c),
+		Sort:        c.Query("sort"),
+	}
+	if !req.AllProjects {
+		projectIDs, err := h.access.AccessibleProjects(ctx, currentUserID(c))
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		req.ProjectIds = projectIDs
+	}
 
+	resp, err := h.analyticsClient.GetTrendingProjects(ctx, req)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp)
+	trending := []TrendingProjectResponse{}
+	if len(resp.Projects) > 0 {
+		ids := make([]int64, len(resp.Projects))
+		for i, p := range resp.Projects {
+			ids[i] = p.ProjectId
+		}
+		projects, err := h.projectClient.BatchGetProjects(ctx, &projectpb.BatchGetProjectsRequest{Ids: ids})
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		names := make(map[int64]string, len(projects.Projects))
+		for _, p := range projects.Projects {
+			names[p.Id] = p.Name
+		}
+		// Views of a deleted project outlive it until the cascade runs
+		for _, p := range resp.Projects {
+			name, ok := names[p.ProjectId]
+			if !ok {
+				continue
+			}
+			trending = append(trending, TrendingProjectResponse{
+				ProjectID: p.ProjectId,
+				Name:      name,
+				Views:     p.Views,
+				Score:     p.Score,
+			})
+		}
+	}
+	respondList(c, trending, len(trending))
+}
+
+// RecentlyViewedProjectResponse is a project with the user's latest view of it
+type RecentlyViewedProjectResponse struct {
+	ProjectID int64     `json:"project_id"`
+	Name      string    `json:"name"`
+	ViewedAt  time.Time `json:"viewed_at"`
+}
+
+// GetRecentlyViewedProjects returns the projects the current user viewed and
+// can still access, each once at its latest view, most recent first
+// GET /api/analytics/recently-viewed?limit=20
+func (h *AnalyticsHandler) GetRecentlyViewedProjects(c *gin.Context) {
+	limit, ok := parsePageParam(c, "limit", 0)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	req := &pb.GetRecentlyViewedProjectsRequest{
+		UserId:      currentUserID(c),
+		Limit:       limit,
+		AllProjects: isAdmin(c),
+	}
+	if !req.AllProjects {
+		projectIDs, err := h.access.AccessibleProjects(ctx, req.UserId)
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		req.ProjectIds = projectIDs
+	}
+
+	resp, err := h.analyticsClient.GetRecentlyViewedProjects(ctx, req)
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	recent := []RecentlyViewedProjectResponse{}
+	if len(resp.Views) > 0 {
+		ids := make([]int64, len(resp.Views))
+		for i, v := range resp.Views {
+			ids[i] = v.ProjectId
+		}
+		projects, err := h.projectClient.BatchGetProjects(ctx, &projectpb.BatchGetProjectsRequest{Ids: ids})
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		names := make(map[int64]string, len(projects.Projects))
+		for _, p := range projects.Projects {
+			names[p.Id] = p.Name
+		}
+		for _, v := range resp.Views {
+			name, ok := names[v.ProjectId]
+			if !ok {
+				continue
+			}
+			recent = append(recent, RecentlyViewedProjectResponse{
+				ProjectID: v.ProjectId,
+				Name:      name,
+				ViewedAt:  v.ViewedAt.AsTime(),
+			})
+		}
+	}
+	respondList(c, recent, len(recent))
 }
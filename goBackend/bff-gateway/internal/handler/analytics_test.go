@@ -0,0 +1,440 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	pb "github.com/portfolio/proto/analytics"
+	authpb "github.com/portfolio/proto/auth"
+	projectpb "github.com/portfolio/proto/project"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAnalyticsClient stubs the analytics service; unimplemented methods panic
+type fakeAnalyticsClient struct {
+	pb.AnalyticsServiceClient
+	viewsReq      *pb.GetProjectViewsRequest
+	batchReq      *pb.BatchRecordProjectViewsRequest
+	trendingReq   *pb.GetTrendingProjectsRequest
+	recentReq     *pb.GetRecentlyViewedProjectsRequest
+	activitiesReq *pb.GetTaskActivitiesRequest
+	dashboardReq  *pb.GetDashboardStatsRequest
+	cycleTimeReq  *pb.GetCycleTimeRequest
+	// err, when set, is returned by every call
+	err error
+}
+
+func (f *fakeAnalyticsClient) GetProjectStats(ctx context.Context, in *pb.GetProjectStatsRequest, opts ...grpc.CallOption) (*pb.ProjectStatsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &pb.ProjectStatsResponse{Stats: &pb.ProjectStats{ProjectId: in.ProjectId, TotalTasks: 4, CompletedTasks: 1, ProgressPercent: 25}}, nil
+}
+
+func (f *fakeAnalyticsClient) GetProjectViews(ctx context.Context, in *pb.GetProjectViewsRequest, opts ...grpc.CallOption) (*pb.ProjectViewsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.viewsReq = in
+	return &pb.ProjectViewsResponse{
+		Views:      []*pb.ProjectView{{Id: 11, ProjectId: in.ProjectId}, {Id: 12, ProjectId: in.ProjectId}},
+		TotalViews: 45,
+		Limit:      int32(pagination.Sizes{}.Limit(int(in.Limit))),
+	}, nil
+}
+
+func (f *fakeAnalyticsClient) BatchRecordProjectViews(ctx context.Context, in *pb.BatchRecordProjectViewsRequest, opts ...grpc.CallOption) (*pb.BatchRecordProjectViewsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.batchReq = in
+	return &pb.BatchRecordProjectViewsResponse{Recorded: int32(len(in.Views) - 1), Skipped: 1}, nil
+}
+
+func (f *fakeAnalyticsClient) GetTrendingProjects(ctx context.Context, in *pb.GetTrendingProjectsRequest, opts ...grpc.CallOption) (*pb.TrendingProjectsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.trendingReq = in
+	return &pb.TrendingProjectsResponse{Projects: []*pb.ProjectTrend{
+		{ProjectId: 3, Views: 4, Score: 3.5},
+		{ProjectId: 9, Views: 6, Score: 2},
+		{ProjectId: 1, Views: 9, Score: 1.5},
+	}}, nil
+}
+
+func (f *fakeAnalyticsClient) GetRecentlyViewedProjects(ctx context.Context, in *pb.GetRecentlyViewedProjectsRequest, opts ...grpc.CallOption) (*pb.RecentlyViewedProjectsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.recentReq = in
+	viewed := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	return &pb.RecentlyViewedProjectsResponse{Views: []*pb.ProjectView{
+		{Id: 31, ProjectId: 3, UserId: in.UserId, ViewedAt: timestamppb.New(viewed)},
+		{Id: 27, ProjectId: 9, UserId: in.UserId, ViewedAt: timestamppb.New(viewed.Add(-time.Hour))},
+		{Id: 12, ProjectId: 1, UserId: in.UserId, ViewedAt: timestamppb.New(viewed.Add(-2 * time.Hour))},
+	}}, nil
+}
+
+func (f *fakeAnalyticsClient) GetTaskActivities(ctx context.Context, in *pb.GetTaskActivitiesRequest, opts ...grpc.CallOption) (*pb.TaskActivitiesResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.activitiesReq = in
+	return &pb.TaskActivitiesResponse{Limit: int32(pagination.Sizes{}.Limit(int(in.Limit)))}, nil
+}
+
+func (f *fakeAnalyticsClient) GetCycleTime(ctx context.Context, in *pb.GetCycleTimeRequest, opts ...grpc.CallOption) (*pb.CycleTimeResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.cycleTimeReq = in
+	// Project 2 has no tasks completed
+	if in.ProjectId == 2 {
+		return &pb.CycleTimeResponse{ProjectId: 2}, nil
+	}
+	return &pb.CycleTimeResponse{ProjectId: in.ProjectId, CompletedTasks: 4, AverageLeadTimeSeconds: 43200, MedianLeadTimeSeconds: 28800}, nil
+}
+
+func (f *fakeAnalyticsClient) GetDashboardStats(ctx context.Context, in *pb.GetDashboardStatsRequest, opts ...grpc.CallOption) (*pb.DashboardStatsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.dashboardReq = in
+	return &pb.DashboardStatsResponse{TotalProjects: int32(len(in.ProjectIds))}, nil
+}
+
+type envelope struct {
+	Data       []map[string]interface{} `json:"data"`
+	Total      int32                    `json:"total"`
+	Page       int32                    `json:"page"`
+	Limit      int32                    `json:"limit"`
+	TotalPages int32                    `json:"total_pages"`
+}
+
+func TestAnalyticsHandler_PaginationEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		url       string
+		wantData  int
+		wantTotal int32
+		wantPage  int32
+		wantLimit int32
+		wantPages int32
+	}{
+		{"Views", "/projects/3/views?page=2&limit=10", 2, 45, 2, 10, 5},
+		{"Views default paging", "/projects/3/views", 2, 45, 1, 20, 3},
+		{"Limit the service lowered", "/projects/3/views?limit=500", 2, 45, 1, 100, 1},
+		{"Empty activities", "/tasks/7/activities?page=3", 0, 0, 3, 20, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeAnalyticsClient{}
+			h := &AnalyticsHandler{analyticsClient: client}
+			r := gin.New()
+			r.GET("/projects/:id/views", h.GetProjectViews)
+			r.GET("/tasks/:id/activities", h.GetTaskActivities)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+
+			var body envelope
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			if body.Data == nil {
+				t.Error("data must be an array, got null")
+			}
+			if len(body.Data) != tt.wantData || body.Total != tt.wantTotal || body.Page != tt.wantPage ||
+				body.Limit != tt.wantLimit || body.TotalPages != tt.wantPages {
+				t.Errorf("envelope = %+v, want %d items, total %d, page %d, limit %d, pages %d",
+					body, tt.wantData, tt.wantTotal, tt.wantPage, tt.wantLimit, tt.wantPages)
+			}
+			if got := w.Header().Get("X-Total-Count"); got != strconv.Itoa(int(tt.wantTotal)) {
+				t.Errorf("X-Total-Count = %q, want %d", got, tt.wantTotal)
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_ForwardsPaging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeAnalyticsClient{}
+	h := &AnalyticsHandler{analyticsClient: client}
+	r := gin.New()
+	r.GET("/tasks/:id/activities", h.GetTaskActivities)
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/tasks/7/activities?page=4&limit=5", nil))
+	if req := client.activitiesReq; req.TaskId != 7 || req.Page != 4 || req.Limit != 5 {
+		t.Errorf("request = %+v, want task 7 page 4 limit 5", req)
+	}
+}
+
+func TestAnalyticsHandler_DashboardScopedToAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessRead},
+		{UserId: 1, ProjectId: 11, AccessLevel: AccessWrite},
+		{UserId: 2, ProjectId: 20, AccessLevel: AccessAdmin},
+	}
+
+	tests := []struct {
+		name    string
+		userID  int64
+		role    string
+		wantIDs []int64
+		wantAll bool
+	}{
+		{"user with two projects", 1, "user", []int64{10, 11}, false},
+		{"user with another project", 2, "user", []int64{20}, false},
+		{"user without access", 3, "user", nil, false},
+		{"admin", 3, "admin", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeAnalyticsClient{}
+			h := &AnalyticsHandler{
+				analyticsClient: client,
+				access:          &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", tt.role)
+			})
+			r.GET("/analytics/dashboard", h.GetDashboardStats)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/analytics/dashboard", nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+
+			req := client.dashboardReq
+			if req.UserId != tt.userID || req.AllProjects != tt.wantAll || len(req.ProjectIds) != len(tt.wantIDs) {
+				t.Fatalf("request = %v, want user %d, all %v, projects %v", req, tt.userID, tt.wantAll, tt.wantIDs)
+			}
+			for i, id := range tt.wantIDs {
+				if req.ProjectIds[i] != id {
+					t.Errorf("project ids = %v, want %v", req.ProjectIds, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_BatchRecordProjectViews(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeAnalyticsClient{}
+	h := &AnalyticsHandler{analyticsClient: client}
+	r := gin.New()
+	r.POST("/views/batch", h.BatchRecordProjectViews)
+
+	body := `{"views":[{"project_id":3,"viewed_at":"2024-03-01T09:30:00Z"},{"project_id":3},{"project_id":4,"user_id":8}]}`
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/views/batch", strings.NewReader(body)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	views := client.batchReq.GetViews()
+	if len(views) != 3 {
+		t.Fatalf("forwarded %d views, want 3", len(views))
+	}
+	if got := views[0].ViewedAt.AsTime(); !got.Equal(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)) {
+		t.Errorf("viewed_at = %v", got)
+	}
+	if views[1].ViewedAt != nil || views[2].UserId != 8 {
+		t.Errorf("views = %v, want no time on the second and user 8 on the third", views)
+	}
+	var resp struct{ Recorded, Skipped int32 }
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Recorded != 2 || resp.Skipped != 1 {
+		t.Errorf("response = %s, want 2 recorded and 1 skipped", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/views/batch", strings.NewReader(`{}`)))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("missing views status = %d, want 400", w.Code)
+	}
+}
+
+func TestAnalyticsHandler_GetTrendingProjects(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeAnalyticsClient{}
+	projects := &fakeProjectClient{projects: map[int64]*projectpb.Project{
+		1: {Id: 1, Name: "Alpha"},
+		3: {Id: 3, Name: "Gamma"},
+	}}
+	h := &AnalyticsHandler{analyticsClient: client, projectClient: projects}
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("role", "admin") })
+	r.GET("/analytics/trending", h.GetTrendingProjects)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/analytics/trending?range=7d&limit=5&sort=views", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+
+	req := client.trendingReq
+	if age := time.Since(req.Since.AsTime()); age < 7*24*time.Hour || age > 7*24*time.Hour+time.Minute {
+		t.Errorf("since is %v ago, want 7 days", age)
+	}
+	if req.Limit != 5 || req.Sort != "views" || !req.AllProjects {
+		t.Errorf("request = %v, want limit 5 sorted by views over all projects", req)
+	}
+
+	// Project 9 no longer exists, so it is left out
+	var body struct {
+		Data []TrendingProjectResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := []TrendingProjectResponse{
+		{ProjectID: 3, Name: "Gamma", Views: 4, Score: 3.5},
+		{ProjectID: 1, Name: "Alpha", Views: 9, Score: 1.5},
+	}
+	if !reflect.DeepEqual(body.Data, want) {
+		t.Errorf("data = %+v, want %+v", body.Data, want)
+	}
+}
+
+func TestAnalyticsHandler_GetTrendingProjects_InvalidRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, rng := range []string{"7", "d", "0d", "-1h", "2w", "91d"} {
+		t.Run(rng, func(t *testing.T) {
+			h := &AnalyticsHandler{analyticsClient: &fakeAnalyticsClient{}}
+			r := gin.New()
+			r.GET("/analytics/trending", h.GetTrendingProjects)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/analytics/trending?range="+rng, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400", w.Code)
+			}
+		})
+	}
+}
+
+func TestAnalyticsHandler_GetRecentlyViewedProjects(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeAnalyticsClient{}
+	projects := &fakeProjectClient{projects: map[int64]*projectpb.Project{
+		1: {Id: 1, Name: "Alpha"},
+		3: {Id: 3, Name: "Gamma"},
+	}}
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 5, ProjectId: 1, AccessLevel: AccessRead},
+		{UserId: 5, ProjectId: 3, AccessLevel: AccessWrite},
+		{UserId: 5, ProjectId: 9, AccessLevel: AccessRead},
+	}
+	h := &AnalyticsHandler{
+		analyticsClient: client,
+		projectClient:   projects,
+		access:          &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+	}
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("user_id", float64(5)) })
+	r.GET("/analytics/recently-viewed", h.GetRecentlyViewedProjects)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/analytics/recently-viewed?limit=5", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	// Only the projects the user can access are listed
+	if req := client.recentReq; req.UserId != 5 || req.Limit != 5 || req.AllProjects || !reflect.DeepEqual(req.ProjectIds, []int64{1, 3, 9}) {
+		t.Errorf("request = %v, want user 5, limit 5 and projects 1, 3, 9", req)
+	}
+
+	// Project 9 no longer exists, so it is left out
+	var body struct {
+		Data []RecentlyViewedProjectResponse `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	viewed := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	want := []RecentlyViewedProjectResponse{
+		{ProjectID: 3, Name: "Gamma", ViewedAt: viewed},
+		{ProjectID: 1, Name: "Alpha", ViewedAt: viewed.Add(-2 * time.Hour)},
+	}
+	if !reflect.DeepEqual(body.Data, want) {
+		t.Errorf("data = %+v, want %+v", body.Data, want)
+	}
+}
+
+func TestAnalyticsHandler_GetCycleTime(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeAnalyticsClient{}
+	r := gin.New()
+	r.GET("/analytics/projects/:id/cycle-time", (&AnalyticsHandler{analyticsClient: client}).GetCycleTime)
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/analytics/projects/5/cycle-time?start_date=2024-06-01T00:00:00Z&end_date=2024-07-01T00:00:00Z")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if got := client.cycleTimeReq; got.ProjectId != 5 || !got.Since.AsTime().Equal(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)) ||
+		!got.Until.AsTime().Equal(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("request = %v, want project 5 over June", got)
+	}
+	var body map[string]interface{}
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body["completed_tasks"] != 4.0 || body["average_lead_time_seconds"] != 43200.0 || body["median_lead_time_seconds"] != 28800.0 {
+		t.Errorf("body = %v, want 4 tasks averaging 12h with an 8h median", body)
+	}
+
+	// Without completed tasks the lead times are null rather than zero
+	w = get("/analytics/projects/2/cycle-time")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	body = nil
+	json.Unmarshal(w.Body.Bytes(), &body)
+	if body["completed_tasks"] != 0.0 || body["average_lead_time_seconds"] != nil || body["median_lead_time_seconds"] != nil {
+		t.Errorf("body = %v, want no lead times", body)
+	}
+	req := client.cycleTimeReq
+	if span := req.Until.AsTime().Sub(req.Since.AsTime()); span != defaultCycleTimeRange {
+		t.Errorf("default range = %v, want %v", span, defaultCycleTimeRange)
+	}
+
+	for _, path := range []string{
+		"/analytics/projects/abc/cycle-time",
+		"/analytics/projects/5/cycle-time?start_date=yesterday",
+	} {
+		if w := get(path); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: status = %d, want 400", path, w.Code)
+		}
+	}
+}
@@ -3,11 +3,16 @@ package handler
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
 	pb "github.com/portfolio/proto/auth"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 )
 
 // AuthHandler handles authentication endpoints
@@ -56,11 +61,11 @@ type AuthResponse struct {
 func (h *AuthHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.authClient.Register(ctx, &pb.RegisterRequest{
@@ -71,7 +76,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -91,11 +96,11 @@ func (h *AuthHandler) Register(c *gin.Context) {
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.authClient.Login(ctx, &pb.LoginRequest{
@@ -104,7 +109,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid credentials"})
+		apierror.Respond(c, codes.Unauthenticated, "Invalid credentials")
 		return
 	}
 
@@ -119,6 +124,22 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	})
 }
 
+// Logout revokes the caller's token
+// POST /api/auth/logout
+func (h *AuthHandler) Logout(c *gin.Context) {
+	token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if _, err := h.authClient.Logout(ctx, &pb.LogoutRequest{Token: token}); err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
 // GetProfile returns current user's profile
 // GET /api/auth/profile
 func (h *AuthHandler) GetProfile(c *gin.Context) {
@@ -129,7 +150,7 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	// Example of calling service to get fresh data:
 	userID, exists := c.Get("user_id")
 	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		apierror.Respond(c, codes.Unauthenticated, "Unauthorized")
 		return
 	}
 
@@ -161,11 +182,11 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 		Token string `json:"token" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.authClient.ValidateToken(ctx, &pb.ValidateTokenRequest{
@@ -173,7 +194,7 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+		apierror.Respond(c, codes.Unauthenticated, "Invalid token")
 		return
 	}
 
@@ -183,10 +204,34 @@ func (h *AuthHandler) ValidateToken(c *gin.Context) {
 	})
 }
 
-// ListUsers returns list of users (admin only)
+// ListUsers returns a page of users (admin only)
+// GET /api/users
 func (h *AuthHandler) ListUsers(c *gin.Context) {
-	// Placeholder
-	c.JSON(http.StatusOK, gin.H{"message": "List users implementation pending"})
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.authClient.ListUsers(ctx, &pb.ListUsersRequest{Page: page, Limit: limit})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	users := make([]UserResponse, len(resp.Users))
+	for i, u := range resp.Users {
+		users[i] = UserResponse{
+			ID:        u.Id,
+			Username:  u.Username,
+			Email:     u.Email,
+			Role:      u.Role,
+			CreatedAt: u.CreatedAt.AsTime().Format(time.RFC3339),
+		}
+	}
+	respondPaginated(c, users, resp.Total, page, resp.Limit)
 }
 
 // GetUser returns a user by ID
@@ -206,3 +251,120 @@ func (h *AuthHandler) DeleteUser(c *gin.Context) {
 	// Placeholder
 	c.JSON(http.StatusOK, gin.H{"message": "Delete user implementation pending"})
 }
+
+// UserProjectResponse represents a project the user has access to
+type UserProjectResponse struct {
+	ProjectID   int64  `json:"project_id"`
+	AccessLevel string `json:"access_level"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+}
+
+// ListUserProjects returns a page of the projects a user has access to,
+// with their names and status. Without an :id it lists the caller's own.
+// GET /api/auth/projects
+// GET /api/users/:id/projects
+func (h *AuthHandler) ListUserProjects(c *gin.Context) {
+	userID := currentUserID(c)
+	if v := c.Param("id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			apierror.Respond(c, codes.InvalidArgument, "Invalid ID")
+			return
+		}
+		userID = id
+	}
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.authClient.ListUserProjects(ctx, &pb.ListUserProjectsRequest{UserId: userID, Page: page, Limit: limit})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	projects := make([]UserProjectResponse, len(resp.Projects))
+	for i, p := range resp.Projects {
+		projects[i] = UserProjectResponse{
+			ProjectID:   p.ProjectId,
+			AccessLevel: p.AccessLevel,
+			Name:        p.Name,
+			Description: p.Description,
+			Status:      p.Status,
+		}
+	}
+	respondPaginated(c, projects, resp.Total, page, resp.Limit)
+}
+
+// AuditEntryResponse represents an audit log entry
+type AuditEntryResponse struct {
+	ID        int64  `json:"id"`
+	ActorID   int64  `json:"actor_id"`
+	Action    string `json:"action"`
+	Target    string `json:"target"`
+	Details   string `json:"details"`
+	IP        string `json:"ip"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GetAuditLog returns a page of the audit log, newest first. The auth
+// service only allows admins, so the caller's token is forwarded.
+// GET /api/audit-log?actor_id=1&action=role_change
+func (h *AuthHandler) GetAuditLog(c *gin.Context) {
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	var actorID int64
+	if v := c.Query("actor_id"); v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id <= 0 {
+			apierror.Respond(c, codes.InvalidArgument, "actor_id must be a positive integer")
+			return
+		}
+		actorID = id
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.authClient.GetAuditLog(withAuthorization(ctx, c), &pb.GetAuditLogRequest{
+		Page:    page,
+		Limit:   limit,
+		ActorId: actorID,
+		Action:  c.Query("action"),
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	entries := make([]AuditEntryResponse, len(resp.Entries))
+	for i, e := range resp.Entries {
+		entries[i] = AuditEntryResponse{
+			ID:        e.Id,
+			ActorID:   e.ActorId,
+			Action:    e.Action,
+			Target:    e.Target,
+			Details:   e.Details,
+			IP:        e.Ip,
+			CreatedAt: e.CreatedAt.AsTime().Format(time.RFC3339),
+		}
+	}
+	respondPaginated(c, entries, resp.Total, page, resp.Limit)
+}
+
+// withAuthorization forwards the request's bearer token to the auth service
+// for calls that check who is making them
+func withAuthorization(ctx context.Context, c *gin.Context) context.Context {
+	if header := c.GetHeader("Authorization"); header != "" {
+		return metadata.AppendToOutgoingContext(ctx, "authorization", header)
+	}
+	return ctx
+}
@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	pb "github.com/portfolio/proto/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeAuditClient stubs the auth service's audit log; unimplemented methods panic
+type fakeAuditClient struct {
+	pb.AuthServiceClient
+	req       *pb.GetAuditLogRequest
+	gotAuth   string
+	gotClient string
+}
+
+func (f *fakeAuditClient) GetAuditLog(ctx context.Context, in *pb.GetAuditLogRequest, opts ...grpc.CallOption) (*pb.GetAuditLogResponse, error) {
+	f.req = in
+	md, _ := metadata.FromOutgoingContext(ctx)
+	if v := md.Get("authorization"); len(v) > 0 {
+		f.gotAuth = v[0]
+	}
+	if v := md.Get("x-client-ip"); len(v) > 0 {
+		f.gotClient = v[0]
+	}
+	if f.gotAuth != "Bearer admin-token" {
+		return nil, status.Error(codes.PermissionDenied, "only admins can read the audit log")
+	}
+	return &pb.GetAuditLogResponse{
+		Entries: []*pb.AuditEntry{{
+			Id: 7, ActorId: 1, Action: "role_change", Target: "user:5", Details: "user -> admin",
+			Ip: "203.0.113.7", CreatedAt: timestamppb.New(time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+		}},
+		Total: 1,
+	}, nil
+}
+
+func TestAuthHandler_GetAuditLog(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		query      string
+		token      string
+		wantStatus int
+	}{
+		{"Admin", "?actor_id=1&action=role_change&limit=5", "admin-token", http.StatusOK},
+		{"Not admin", "", "user-token", http.StatusForbidden},
+		{"Invalid actor", "?actor_id=abc", "admin-token", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeAuditClient{}
+			h := &AuthHandler{authClient: client}
+			r := gin.New()
+			r.GET("/audit-log", h.GetAuditLog)
+
+			req := httptest.NewRequest(http.MethodGet, "/audit-log"+tt.query, nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			req.RemoteAddr = "198.51.100.4:5000"
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if client.req.ActorId != 1 || client.req.Action != "role_change" || client.req.Limit != 5 {
+				t.Errorf("request = %+v, want actor 1, action role_change, limit 5", client.req)
+			}
+			if client.gotClient != "198.51.100.4" {
+				t.Errorf("forwarded client IP = %q, want 198.51.100.4", client.gotClient)
+			}
+			var body envelope
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Total != 1 {
+				t.Errorf("total = %d, want 1", body.Total)
+			}
+		})
+	}
+}
+
+// fakeUserProjectsClient stubs ListUserProjects; unimplemented methods panic
+type fakeUserProjectsClient struct {
+	pb.AuthServiceClient
+	req *pb.ListUserProjectsRequest
+}
+
+func (f *fakeUserProjectsClient) ListUserProjects(ctx context.Context, in *pb.ListUserProjectsRequest, opts ...grpc.CallOption) (*pb.ListUserProjectsResponse, error) {
+	f.req = in
+	return &pb.ListUserProjectsResponse{
+		Projects: []*pb.UserProject{{ProjectId: 10, AccessLevel: "admin", Name: "Website", Status: "active"}},
+		Total:    3,
+	}, nil
+}
+
+func TestAuthHandler_ListUserProjects(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantUser   int64
+	}{
+		{"Own projects", "/auth/projects?page=2&limit=1", http.StatusOK, 7},
+		{"Another user", "/users/5/projects?page=2&limit=1", http.StatusOK, 5},
+		{"Invalid ID", "/users/abc/projects", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeUserProjectsClient{}
+			h := &AuthHandler{authClient: client}
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set("user_id", float64(7)) })
+			r.GET("/auth/projects", h.ListUserProjects)
+			r.GET("/users/:id/projects", h.ListUserProjects)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if client.req.UserId != tt.wantUser || client.req.Page != 2 || client.req.Limit != 1 {
+				t.Errorf("request = %+v, want user %d page 2 limit 1", client.req, tt.wantUser)
+			}
+			var body envelope
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if body.Total != 3 || len(body.Data) != 1 || body.Data[0]["name"] != "Website" {
+				t.Errorf("body = %+v, want total 3 and the Website project", body)
+			}
+		})
+	}
+}
+
+func TestAuthHandler_Register_InvalidFields(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	// The backend is never reached, so an empty fake suffices
+	h := &AuthHandler{authClient: &fakeAuditClient{}}
+	r := gin.New()
+	r.POST("/register", h.Register)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(`{"email": "not-an-email", "password": "abc"}`)))
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400: %s", w.Code, w.Body.String())
+	}
+	var body apierror.ValidationResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	want := map[string]string{
+		"username": "is required",
+		"email":    "must be a valid email",
+		"password": "must be at least 6 characters long",
+	}
+	if body.Error.Code != apierror.ValidationFailed || !reflect.DeepEqual(body.Error.Fields, want) {
+		t.Errorf("error = %+v, want %s with fields %v", body.Error, apierror.ValidationFailed, want)
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	pb "github.com/portfolio/proto/task"
+	"google.golang.org/grpc/codes"
+)
+
+// eventHeartbeat is how often an idle event stream sends a comment so that
+// proxies don't close it
+var eventHeartbeat = 25 * time.Second
+
+// ProjectEvents streams task changes in a project as server-sent events.
+// Each event is named after its type (task.created, task.updated or
+// task.deleted) and carries the task event as JSON. The stream ends when the
+// task service drops it, after which clients should reload and reconnect.
+// GET /api/projects/:id/events
+func (h *TaskHandler) ProjectEvents(c *gin.Context) {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
+		return
+	}
+
+	if !isAdmin(c) {
+		ctx, cancel := requestContext(c)
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), projectID, AccessRead)
+		cancel()
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		if !allowed {
+			apierror.Respond(c, codes.PermissionDenied, "Access to this project denied")
+			return
+		}
+	}
+
+	ctx, cancel := streamContext(c)
+	defer cancel()
+	stream, err := h.taskClient.WatchProjectTasks(ctx, &pb.WatchProjectTasksRequest{ProjectId: projectID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	// Recv blocks, so it runs apart from the writer; cancelling ctx when the
+	// client goes away ends it
+	events := make(chan *pb.TaskEvent)
+	go func() {
+		defer close(events)
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(eventHeartbeat)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return false
+			}
+			c.SSEvent(event.Type, event)
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
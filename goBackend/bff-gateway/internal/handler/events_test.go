@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	authpb "github.com/portfolio/proto/auth"
+	pb "github.com/portfolio/proto/task"
+	"google.golang.org/grpc"
+)
+
+// fakeEventStream serves events pushed onto a channel
+type fakeEventStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *pb.TaskEvent
+}
+
+func (s *fakeEventStream) Recv() (*pb.TaskEvent, error) {
+	select {
+	case event, ok := <-s.events:
+		if !ok {
+			return nil, io.EOF
+		}
+		return event, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+// fakeWatchClient hands out one event stream and records the watched project
+type fakeWatchClient struct {
+	pb.TaskServiceClient
+	events  chan *pb.TaskEvent
+	watched chan int64
+}
+
+func (f *fakeWatchClient) WatchProjectTasks(ctx context.Context, in *pb.WatchProjectTasksRequest, opts ...grpc.CallOption) (pb.TaskService_WatchProjectTasksClient, error) {
+	f.watched <- in.ProjectId
+	return &fakeEventStream{ctx: ctx, events: f.events}, nil
+}
+
+func TestProjectEvents_StreamsTaskUpdates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeWatchClient{events: make(chan *pb.TaskEvent), watched: make(chan int64, 1)}
+	h := &TaskHandler{
+		taskClient: client,
+		access: &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: []*authpb.UserProjectAccess{
+			{UserId: 1, ProjectId: 10, AccessLevel: AccessRead},
+		}}},
+	}
+	r := gin.New()
+	r.GET("/projects/:id/events", func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Set("role", "user")
+	}, h.ProjectEvents)
+	server := httptest.NewServer(r)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/projects/10/events")
+	if err != nil {
+		t.Fatalf("GET events error = %v", err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Fatalf("Content-Type = %q, want text/event-stream", ct)
+	}
+	if got := <-client.watched; got != 10 {
+		t.Fatalf("watched project %d, want 10", got)
+	}
+
+	client.events <- &pb.TaskEvent{Type: "task.updated", ProjectId: 10, TaskId: 3, Task: &pb.Task{Id: 3, ProjectId: 10, Title: "Ship it"}}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	var name, data string
+	timeout := time.After(5 * time.Second)
+	for data == "" {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("stream ended before the event arrived")
+			}
+			if v, ok := strings.CutPrefix(line, "event:"); ok {
+				name = v
+			}
+			if v, ok := strings.CutPrefix(line, "data:"); ok {
+				data = v
+			}
+		case <-timeout:
+			t.Fatal("timed out waiting for the event")
+		}
+	}
+	if name != "task.updated" {
+		t.Errorf("event name = %q, want task.updated", name)
+	}
+	var event pb.TaskEvent
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		t.Fatalf("event data is not JSON: %v (%s)", err, data)
+	}
+	if event.TaskId != 3 || event.Task == nil || event.Task.Title != "Ship it" {
+		t.Errorf("event = %+v, want task 3 titled Ship it", &event)
+	}
+
+	// The SSE stream ends when the task service ends its stream
+	close(client.events)
+	for range lines {
+	}
+}
+
+func TestProjectEvents_RequiresProjectAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeWatchClient{watched: make(chan int64, 1)}
+	h := &TaskHandler{taskClient: client, access: &ProjectAccessChecker{authClient: &fakeAuthClient{}}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/projects/10/events", nil)
+	c.Params = gin.Params{{Key: "id", Value: "10"}}
+	c.Set("user_id", int64(2))
+	h.ProjectEvents(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+	if len(client.watched) != 0 {
+		t.Error("project was watched without access")
+	}
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	analyticspb "github.com/portfolio/proto/analytics"
+	pb "github.com/portfolio/proto/task"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Column headers of the CSV exports
+var (
+	taskCSVHeader = []string{"id", "title", "description", "status", "priority", "assigned_to", "due_date", "progress", "tags", "created_at", "updated_at"}
+	viewCSVHeader = []string{"id", "project_id", "user_id", "viewed_at"}
+)
+
+// startCSV sets the download headers and returns a writer for the body
+func startCSV(c *gin.Context, filename string) *csv.Writer {
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Status(http.StatusOK)
+	return csv.NewWriter(c.Writer)
+}
+
+// csvText neutralizes user-entered text that a spreadsheet would evaluate as
+// a formula, by prefixing it with a quote
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// csvID formats an optional reference, leaving unset ones empty
+func csvID(id int64) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatInt(id, 10)
+}
+
+// csvTime formats an optional timestamp as RFC 3339 in UTC
+func csvTime(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().UTC().Format(time.RFC3339)
+}
+
+func taskCSVRow(t *pb.Task) []string {
+	tags := make([]string, len(t.Tags))
+	for i, tag := range t.Tags {
+		tags[i] = csvText(tag.Name)
+	}
+	return []string{
+		strconv.FormatInt(t.Id, 10),
+		csvText(t.Title),
+		csvText(t.Description),
+		csvText(t.Status),
+		strconv.Itoa(int(t.Priority)),
+		csvID(t.AssignedTo),
+		csvTime(t.DueDate),
+		strconv.FormatFloat(t.Progress, 'f', 2, 64),
+		strings.Join(tags, ";"),
+		csvTime(t.CreatedAt),
+		csvTime(t.UpdatedAt),
+	}
+}
+
+func viewCSVRow(v *analyticspb.ProjectView) []string {
+	return []string{
+		strconv.FormatInt(v.Id, 10),
+		strconv.FormatInt(v.ProjectId, 10),
+		csvID(v.UserId),
+		csvTime(v.ViewedAt),
+	}
+}
+
+// ExportTasksCSV downloads all of a project's tasks as a spreadsheet
+// GET /api/projects/:id/tasks.csv
+func (h *TaskHandler) ExportTasksCSV(c *gin.Context) {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
+		return
+	}
+
+	if !isAdmin(c) {
+		ctx, cancel := requestContext(c)
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), projectID, AccessRead)
+		cancel()
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		if !allowed {
+			apierror.Respond(c, codes.PermissionDenied, "Access to this project denied")
+			return
+		}
+	}
+
+	ctx, cancel := streamContext(c)
+	defer cancel()
+	stream, err := h.taskClient.StreamTasks(ctx, &pb.StreamTasksRequest{ProjectId: projectID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+	batch, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	w := startCSV(c, fmt.Sprintf("project-%d-tasks.csv", projectID))
+	w.Write(taskCSVHeader)
+	for err == nil {
+		for _, task := range batch.Tasks {
+			w.Write(taskCSVRow(task))
+		}
+		w.Flush()
+		if w.Error() != nil {
+			return
+		}
+		c.Writer.Flush()
+		batch, err = stream.Recv()
+	}
+	w.Flush()
+	if err != io.EOF {
+		slog.ErrorContext(ctx, "task CSV export ended early", "project_id", projectID, "error", err)
+	}
+}
+
+// ExportProjectViewsCSV downloads a project's views as a spreadsheet. It
+// takes the same start_date and end_date filters as GetProjectViews.
+// GET /api/analytics/projects/:id/views.csv
+func (h *AnalyticsHandler) ExportProjectViewsCSV(c *gin.Context) {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
+		return
+	}
+
+	ctx, cancel := streamContext(c)
+	defer cancel()
+	req := &analyticspb.GetProjectViewsRequest{
+		ProjectId: projectID,
+		StartDate: parseTimeOrNil(c.Query("start_date")),
+		EndDate:   parseTimeOrNil(c.Query("end_date")),
+		Page:      1,
+		// Lowered to the service's maximum page size
+		Limit: math.MaxInt32,
+	}
+	resp, err := h.analyticsClient.GetProjectViews(ctx, req)
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+	req.Limit = resp.Limit
+
+	w := startCSV(c, fmt.Sprintf("project-%d-views.csv", projectID))
+	w.Write(viewCSVHeader)
+	written := 0
+	for {
+		for _, view := range resp.Views {
+			w.Write(viewCSVRow(view))
+		}
+		written += len(resp.Views)
+		w.Flush()
+		if w.Error() != nil {
+			return
+		}
+		c.Writer.Flush()
+		if len(resp.Views) < int(req.Limit) || written >= int(resp.TotalViews) {
+			return
+		}
+
+		req.Page++
+		if resp, err = h.analyticsClient.GetProjectViews(ctx, req); err != nil {
+			slog.ErrorContext(ctx, "view CSV export ended early", "project_id", projectID, "error", err)
+			return
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	analyticspb "github.com/portfolio/proto/analytics"
+	pb "github.com/portfolio/proto/task"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeCSVTaskClient streams fixed task batches
+type fakeCSVTaskClient struct {
+	pb.TaskServiceClient
+	batches []*pb.TaskBatch
+}
+
+func (f *fakeCSVTaskClient) StreamTasks(ctx context.Context, in *pb.StreamTasksRequest, opts ...grpc.CallOption) (pb.TaskService_StreamTasksClient, error) {
+	return &fakeBatchStream{batches: f.batches}, nil
+}
+
+// fakeViewPager pages through total views, clamping the page size like the
+// analytics service
+type fakeViewPager struct {
+	analyticspb.AnalyticsServiceClient
+	total int
+	pages int
+}
+
+func (f *fakeViewPager) GetProjectViews(ctx context.Context, in *analyticspb.GetProjectViewsRequest, opts ...grpc.CallOption) (*analyticspb.ProjectViewsResponse, error) {
+	f.pages++
+	limit := pagination.Sizes{}.Limit(int(in.Limit))
+	resp := &analyticspb.ProjectViewsResponse{TotalViews: int32(f.total), Limit: int32(limit)}
+	start := int(in.Page-1)*limit + 1
+	for id := start; id < start+limit && id <= f.total; id++ {
+		view := &analyticspb.ProjectView{
+			Id:        int64(id),
+			ProjectId: in.ProjectId,
+			ViewedAt:  timestamppb.New(time.Date(2024, 3, 1, 9, 30, 0, 0, time.UTC)),
+		}
+		if id%2 == 0 {
+			view.UserId = 5
+		}
+		resp.Views = append(resp.Views, view)
+	}
+	return resp, nil
+}
+
+func readCSV(t *testing.T, w *httptest.ResponseRecorder) [][]string {
+	t.Helper()
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cd := w.Header().Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment; filename=") {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("body is not valid CSV: %v", err)
+	}
+	return records
+}
+
+func TestExportTasksCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	due := time.Date(2024, 5, 1, 17, 0, 0, 0, time.UTC)
+	created := time.Date(2024, 4, 2, 8, 0, 0, 0, time.UTC)
+	client := &fakeCSVTaskClient{batches: []*pb.TaskBatch{
+		{Tasks: []*pb.Task{{
+			Id: 1, Title: "Write spec", Description: "Covers, commas", Status: "InProgress", Priority: 2,
+			AssignedTo: 9, DueDate: timestamppb.New(due), Progress: 0.5,
+			Tags:      []*pb.Tag{{Name: "docs"}, {Name: "q2"}},
+			CreatedAt: timestamppb.New(created), UpdatedAt: timestamppb.New(created),
+		}}},
+		{Tasks: []*pb.Task{{Id: 2, Title: "Unplanned", Status: "Todo"}}},
+	}}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/projects/7/tasks.csv", nil)
+	c.Params = gin.Params{{Key: "id", Value: "7"}}
+	c.Set("role", "admin")
+	(&TaskHandler{taskClient: client}).ExportTasksCSV(c)
+
+	records := readCSV(t, w)
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want header and 2 rows", len(records))
+	}
+	if !reflect.DeepEqual(records[0], taskCSVHeader) {
+		t.Errorf("header = %v", records[0])
+	}
+	want := []string{"1", "Write spec", "Covers, commas", "InProgress", "2", "9", "2024-05-01T17:00:00Z", "0.50", "docs;q2", "2024-04-02T08:00:00Z", "2024-04-02T08:00:00Z"}
+	if !reflect.DeepEqual(records[1], want) {
+		t.Errorf("row = %v, want %v", records[1], want)
+	}
+	// No assignee or due date leaves the cells empty
+	if records[2][5] != "" || records[2][6] != "" {
+		t.Errorf("assigned_to, due_date = %q, %q, want empty", records[2][5], records[2][6])
+	}
+}
+
+func TestCSVText(t *testing.T) {
+	tests := map[string]string{
+		"=SUM(A1:A9)": "'=SUM(A1:A9)",
+		"+1":          "'+1",
+		"-2":          "'-2",
+		"@cmd":        "'@cmd",
+		"\tindented":  "'\tindented",
+		"\rreturn":    "'\rreturn",
+		"Write spec":  "Write spec",
+		"a=b":         "a=b",
+		"":            "",
+	}
+	for in, want := range tests {
+		if got := csvText(in); got != want {
+			t.Errorf("csvText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestExportTasksCSV_InvalidProject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/projects/abc/tasks.csv", nil)
+	c.Params = gin.Params{{Key: "id", Value: "abc"}}
+	(&TaskHandler{taskClient: &fakeCSVTaskClient{}}).ExportTasksCSV(c)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
+
+func TestExportProjectViewsCSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeViewPager{total: 250}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/analytics/projects/7/views.csv", nil)
+	c.Params = gin.Params{{Key: "id", Value: "7"}}
+	(&AnalyticsHandler{analyticsClient: client}).ExportProjectViewsCSV(c)
+
+	records := readCSV(t, w)
+	if !reflect.DeepEqual(records[0], viewCSVHeader) {
+		t.Errorf("header = %v", records[0])
+	}
+	if len(records) != 251 {
+		t.Errorf("got %d rows, want 250", len(records)-1)
+	}
+	if client.pages != 3 {
+		t.Errorf("fetched %d pages, want 3", client.pages)
+	}
+	if want := []string{"1", "7", "", "2024-03-01T09:30:00Z"}; !reflect.DeepEqual(records[1], want) {
+		t.Errorf("row = %v, want %v", records[1], want)
+	}
+	if records[2][2] != "5" {
+		t.Errorf("user_id = %q, want 5", records[2][2])
+	}
+}
@@ -0,0 +1,184 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	analyticspb "github.com/portfolio/proto/analytics"
+	taskpb "github.com/portfolio/proto/task"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// Feed item types and the sources they come from
+const (
+	FeedItemActivity = "activity"
+	FeedItemComment  = "comment"
+)
+
+// FeedItem is one entry in a user's activity feed
+type FeedItem struct {
+	Type      string    `json:"type"`
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"task_id"`
+	Action    string    `json:"action,omitempty"`  // activities: created, updated, completed
+	Comment   string    `json:"comment,omitempty"` // comments
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FeedResponse is a page of the feed. FailedSources names the sources that
+// could not be loaded, whose items are missing from the page.
+type FeedResponse struct {
+	PaginatedResponse
+	FailedSources []string `json:"failed_sources,omitempty"`
+}
+
+// FeedHandler serves a user's feed merged from the analytics and task services
+type FeedHandler struct {
+	analyticsClient analyticspb.AnalyticsServiceClient
+	taskClient      taskpb.TaskServiceClient
+	pages           pagination.Sizes
+}
+
+// NewFeedHandler creates a new FeedHandler serving pages of the given sizes.
+// Each page is merged from the newest items of both sources, so the feed
+// goes back as far as the maximum page size.
+func NewFeedHandler(analyticsConn, taskConn *grpc.ClientConn, pages pagination.Sizes) *FeedHandler {
+	return &FeedHandler{
+		analyticsClient: analyticspb.NewAnalyticsServiceClient(analyticsConn),
+		taskClient:      taskpb.NewTaskServiceClient(taskConn),
+		pages:           pages,
+	}
+}
+
+// maxItems is how far back the feed goes
+func (h *FeedHandler) maxItems() int32 {
+	return int32(h.pages.Limit(h.pages.Max))
+}
+
+// mergeFeed combines activities and comments newest first. Items at the same
+// time keep a stable order: activities before comments, then by ID.
+func mergeFeed(activities []*analyticspb.TaskActivity, comments []*taskpb.Comment) []FeedItem {
+	items := make([]FeedItem, 0, len(activities)+len(comments))
+	for _, a := range activities {
+		items = append(items, FeedItem{
+			Type:      FeedItemActivity,
+			ID:        a.Id,
+			TaskID:    a.TaskId,
+			Action:    a.Action,
+			CreatedAt: a.CreatedAt.AsTime(),
+		})
+	}
+	for _, cm := range comments {
+		items = append(items, FeedItem{
+			Type:      FeedItemComment,
+			ID:        cm.Id,
+			TaskID:    cm.TaskId,
+			Comment:   cm.Comment,
+			CreatedAt: cm.CreatedAt.AsTime(),
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		if a.Type != b.Type {
+			return a.Type == FeedItemActivity
+		}
+		return a.ID > b.ID
+	})
+	return items
+}
+
+// GetFeed returns a page of the current user's task activities and comments,
+// newest first. Both are fetched concurrently; if one source fails the page
+// is built from the other and the failure named in failed_sources.
+// GET /api/feed
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	// The feed is paged here rather than by a service
+	limit = int32(h.pages.Limit(int(limit)))
+	depth := page * limit
+	if depth > h.maxItems() {
+		apierror.Respond(c, codes.InvalidArgument, fmt.Sprintf("the feed only goes back %d items", h.maxItems()))
+		return
+	}
+	userID := currentUserID(c)
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	var (
+		wg                      sync.WaitGroup
+		activities              *analyticspb.TaskActivitiesResponse
+		comments                *taskpb.ListCommentsResponse
+		activityErr, commentErr error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		activities, activityErr = h.analyticsClient.GetTaskActivities(ctx, &analyticspb.GetTaskActivitiesRequest{
+			UserId: userID,
+			Page:   1,
+			Limit:  depth,
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		comments, commentErr = h.taskClient.ListComments(ctx, &taskpb.ListCommentsRequest{
+			UserId: userID,
+			Page:   1,
+			Limit:  depth,
+		})
+	}()
+	wg.Wait()
+
+	if activityErr != nil && commentErr != nil {
+		apierror.RespondGRPC(c, activityErr)
+		return
+	}
+
+	var failed []string
+	if activityErr != nil {
+		slog.WarnContext(ctx, "feed without activities", "user_id", userID, "error", activityErr)
+		failed = append(failed, FeedItemActivity)
+		activities = &analyticspb.TaskActivitiesResponse{}
+	}
+	if commentErr != nil {
+		slog.WarnContext(ctx, "feed without comments", "user_id", userID, "error", commentErr)
+		failed = append(failed, FeedItemComment)
+		comments = &taskpb.ListCommentsResponse{}
+	}
+	total := activities.Total + comments.Total
+	if total > h.maxItems() {
+		total = h.maxItems()
+	}
+
+	items := mergeFeed(activities.Activities, comments.Comments)
+	start := int(depth - limit)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := int(depth)
+	if end > len(items) {
+		end = len(items)
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(int(total)))
+	c.JSON(http.StatusOK, FeedResponse{
+		PaginatedResponse: newPaginatedResponse(items[start:end], total, page, limit),
+		FailedSources:     failed,
+	})
+}
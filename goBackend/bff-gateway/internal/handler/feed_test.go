@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	analyticspb "github.com/portfolio/proto/analytics"
+	taskpb "github.com/portfolio/proto/task"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var feedBase = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// at is a timestamp minutes after feedBase
+func at(minutes int) *timestamppb.Timestamp {
+	return timestamppb.New(feedBase.Add(time.Duration(minutes) * time.Minute))
+}
+
+// fakeFeedActivities serves a user's activities, newest first
+type fakeFeedActivities struct {
+	analyticspb.AnalyticsServiceClient
+	activities []*analyticspb.TaskActivity
+	err        error
+	req        *analyticspb.GetTaskActivitiesRequest
+}
+
+func (f *fakeFeedActivities) GetTaskActivities(ctx context.Context, in *analyticspb.GetTaskActivitiesRequest, opts ...grpc.CallOption) (*analyticspb.TaskActivitiesResponse, error) {
+	f.req = in
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &analyticspb.TaskActivitiesResponse{Activities: f.activities, Total: int32(len(f.activities))}, nil
+}
+
+// fakeFeedComments serves a user's comments, newest first
+type fakeFeedComments struct {
+	taskpb.TaskServiceClient
+	comments []*taskpb.Comment
+	err      error
+	req      *taskpb.ListCommentsRequest
+}
+
+func (f *fakeFeedComments) ListComments(ctx context.Context, in *taskpb.ListCommentsRequest, opts ...grpc.CallOption) (*taskpb.ListCommentsResponse, error) {
+	f.req = in
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &taskpb.ListCommentsResponse{Comments: f.comments, Total: int32(len(f.comments))}, nil
+}
+
+func newFeedSources() (*fakeFeedActivities, *fakeFeedComments) {
+	return &fakeFeedActivities{activities: []*analyticspb.TaskActivity{
+		{Id: 3, TaskId: 10, Action: "completed", CreatedAt: at(30)},
+		{Id: 2, TaskId: 11, Action: "updated", CreatedAt: at(20)},
+		{Id: 1, TaskId: 10, Action: "created", CreatedAt: at(0)},
+	}}, &fakeFeedComments{comments: []*taskpb.Comment{
+		{Id: 8, TaskId: 10, Comment: "Shipped", CreatedAt: at(40)},
+		{Id: 7, TaskId: 11, Comment: "On it", CreatedAt: at(20)},
+		{Id: 6, TaskId: 10, Comment: "Looks good", CreatedAt: at(10)},
+	}}
+}
+
+// feedOrder lists items as type:id
+func feedOrder(items []FeedItem) []string {
+	var order []string
+	for _, item := range items {
+		order = append(order, item.Type+":"+strconv.FormatInt(item.ID, 10))
+	}
+	return order
+}
+
+func TestMergeFeed(t *testing.T) {
+	activities, comments := newFeedSources()
+	items := mergeFeed(activities.activities, comments.comments)
+
+	// Newest first; at the same time activities come before comments
+	want := []string{"comment:8", "activity:3", "activity:2", "comment:7", "comment:6", "activity:1"}
+	if got := feedOrder(items); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if items[0].Comment != "Shipped" || items[1].Action != "completed" || items[1].TaskID != 10 {
+		t.Errorf("items = %+v, want the source fields carried over", items[:2])
+	}
+}
+
+// feedBody is a FeedResponse with its items decoded
+type feedBody struct {
+	PaginatedResponse
+	Data          []FeedItem `json:"data"`
+	FailedSources []string   `json:"failed_sources"`
+}
+
+func serveFeed(t *testing.T, h *FeedHandler, query string) (*httptest.ResponseRecorder, feedBody) {
+	t.Helper()
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("user_id", int64(5)) })
+	r.GET("/feed", h.GetFeed)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/feed"+query, nil))
+	var body feedBody
+	if w.Code == http.StatusOK {
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+	}
+	return w, body
+}
+
+func TestFeedHandler_GetFeed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	activities, comments := newFeedSources()
+	h := &FeedHandler{analyticsClient: activities, taskClient: comments}
+
+	w, body := serveFeed(t, h, "?page=2&limit=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	// Page 2 needs the newest 4 of each source to be merged correctly
+	if activities.req.UserId != 5 || activities.req.Limit != 4 || comments.req.UserId != 5 || comments.req.Limit != 4 {
+		t.Errorf("requests = %v, %v, want user 5's newest 4", activities.req, comments.req)
+	}
+	if got, want := feedOrder(body.Data), []string{"activity:2", "comment:7"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("page 2 = %v, want %v", got, want)
+	}
+	if body.Total != 6 || body.TotalPages != 3 || !body.HasNext || body.FailedSources != nil {
+		t.Errorf("envelope = %+v, want 6 items over 3 pages from both sources", body.PaginatedResponse)
+	}
+}
+
+func TestFeedHandler_GetFeed_SourceFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	down := status.Error(codes.Unavailable, "connection refused")
+
+	t.Run("comments", func(t *testing.T) {
+		activities, comments := newFeedSources()
+		comments.err = down
+		w, body := serveFeed(t, &FeedHandler{analyticsClient: activities, taskClient: comments}, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+		}
+		if got, want := feedOrder(body.Data), []string{"activity:3", "activity:2", "activity:1"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("feed = %v, want only activities %v", got, want)
+		}
+		if !reflect.DeepEqual(body.FailedSources, []string{FeedItemComment}) || body.Total != 3 {
+			t.Errorf("failed sources = %v, total = %d; want comments missing and 3 items", body.FailedSources, body.Total)
+		}
+	})
+
+	t.Run("activities", func(t *testing.T) {
+		activities, comments := newFeedSources()
+		activities.err = down
+		w, body := serveFeed(t, &FeedHandler{analyticsClient: activities, taskClient: comments}, "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+		}
+		if got, want := feedOrder(body.Data), []string{"comment:8", "comment:7", "comment:6"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("feed = %v, want only comments %v", got, want)
+		}
+		if !reflect.DeepEqual(body.FailedSources, []string{FeedItemActivity}) {
+			t.Errorf("failed sources = %v, want activities missing", body.FailedSources)
+		}
+	})
+
+	t.Run("both", func(t *testing.T) {
+		activities, comments := newFeedSources()
+		activities.err, comments.err = down, down
+		w, _ := serveFeed(t, &FeedHandler{analyticsClient: activities, taskClient: comments}, "")
+		if w.Code != http.StatusServiceUnavailable {
+			t.Errorf("status = %d, want 503", w.Code)
+		}
+	})
+}
+
+func TestFeedHandler_GetFeed_TooDeep(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	activities, comments := newFeedSources()
+	w, _ := serveFeed(t, &FeedHandler{analyticsClient: activities, taskClient: comments}, "?page=6&limit=20")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", w.Code)
+	}
+}
@@ -1,26 +1,40 @@
 package handler
 
 import (
-	"context"
 	"io"
+	"log/slog"
+	"mime"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
 	pb "github.com/portfolio/proto/media"
+	taskpb "github.com/portfolio/proto/task"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MediaHandler handles media endpoints
 type MediaHandler struct {
 	mediaClient pb.MediaServiceClient
+	taskClient  taskpb.TaskServiceClient
+	access      *ProjectAccessChecker
 }
 
-// NewMediaHandler creates a new MediaHandler
-func NewMediaHandler(conn *grpc.ClientConn) *MediaHandler {
+// NewMediaHandler creates a new MediaHandler. The task service resolves the
+// project of files uploaded for a task, and the auth service checks access
+// to the project a file belongs to.
+func NewMediaHandler(conn, taskConn, authConn *grpc.ClientConn) *MediaHandler {
 	return &MediaHandler{
 		mediaClient: pb.NewMediaServiceClient(conn),
+		taskClient:  taskpb.NewTaskServiceClient(taskConn),
+		access:      NewProjectAccessChecker(authConn),
 	}
 }
 
@@ -31,7 +45,59 @@ const (
 	ChunkSize = 64 * 1024
 )
 
-// UploadFile uploads a file
+// parseOptionalID reads an optional positive ID; "" is 0
+func parseOptionalID(raw, name string) (int64, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, status.Error(codes.InvalidArgument, name+" must be a positive integer")
+	}
+	return id, nil
+}
+
+// uploadTarget resolves the optional project_id and task_id form fields of
+// an upload. A task implies its project; the caller needs write access to
+// the project unless they are an admin.
+func (h *MediaHandler) uploadTarget(c *gin.Context) (projectID, taskID int64, err error) {
+	if projectID, err = parseOptionalID(c.PostForm("project_id"), "project_id"); err != nil {
+		return 0, 0, err
+	}
+	if taskID, err = parseOptionalID(c.PostForm("task_id"), "task_id"); err != nil {
+		return 0, 0, err
+	}
+	if projectID == 0 && taskID == 0 {
+		return 0, 0, nil
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if taskID != 0 {
+		resp, err := h.taskClient.GetTask(ctx, &taskpb.GetTaskRequest{Id: taskID})
+		if err != nil {
+			return 0, 0, err
+		}
+		if projectID != 0 && projectID != resp.Task.ProjectId {
+			return 0, 0, status.Error(codes.InvalidArgument, "task_id is not in project_id")
+		}
+		projectID = resp.Task.ProjectId
+	}
+	if !isAdmin(c) {
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), projectID, AccessWrite)
+		if err != nil {
+			return 0, 0, err
+		}
+		if !allowed {
+			return 0, 0, status.Error(codes.PermissionDenied, "Access to this project denied")
+		}
+	}
+	return projectID, taskID, nil
+}
+
+// UploadFile uploads a file, optionally for a project or task given as the
+// project_id and task_id form fields
 // POST /api/media/upload
 func (h *MediaHandler) UploadFile(c *gin.Context) {
 	// Limit body size
@@ -39,7 +105,7 @@ func (h *MediaHandler) UploadFile(c *gin.Context) {
 
 	file, header, err := c.Request.FormFile("file")
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "File is required: " + err.Error()})
+		apierror.Respond(c, codes.InvalidArgument, "File is required: "+err.Error())
 		return
 	}
 	defer file.Close()
@@ -57,12 +123,18 @@ func (h *MediaHandler) UploadFile(c *gin.Context) {
 		userID = v
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute) // Longer timeout for upload
+	projectID, taskID, err := h.uploadTarget(c)
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	stream, err := h.mediaClient.UploadFile(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start upload: " + err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -73,11 +145,13 @@ func (h *MediaHandler) UploadFile(c *gin.Context) {
 				FileName:   header.Filename,
 				FileType:   fileType,
 				UploadedBy: userID,
+				ProjectId:  projectID,
+				TaskId:     taskID,
 			},
 		},
 	}
 	if err := stream.Send(req); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send metadata: " + err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -89,7 +163,7 @@ func (h *MediaHandler) UploadFile(c *gin.Context) {
 			break
 		}
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file: " + err.Error()})
+			apierror.Respond(c, codes.InvalidArgument, "Failed to read file: "+err.Error())
 			return
 		}
 
@@ -99,7 +173,7 @@ func (h *MediaHandler) UploadFile(c *gin.Context) {
 			},
 		}
 		if err := stream.Send(req); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to send chunk: " + err.Error()})
+			apierror.RespondGRPC(c, err)
 			return
 		}
 	}
@@ -107,7 +181,7 @@ func (h *MediaHandler) UploadFile(c *gin.Context) {
 	// 3. Close and Recv
 	resp, err := stream.CloseAndRecv()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Upload failed: " + err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -116,75 +190,239 @@ func (h *MediaHandler) UploadFile(c *gin.Context) {
 
 // GetFile returns a file by ID
 // GET /api/media/:id
-func (h *MediaHandler) GetFile(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+func (h *MediaHandler) GetFile(c *gin.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
-		return
+		return status.Error(codes.InvalidArgument, "Invalid ID")
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.mediaClient.GetFile(ctx, &pb.GetFileRequest{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return err
 	}
 
-	c.JSON(http.StatusOK, resp.File)
+	// Media files are never edited, so the upload time is the last change
+	respondConditional(c, resp.File, resp.File.GetUploadedAt())
+	return nil
 }
 
-// DeleteFile deletes a file
-// DELETE /api/media/:id
-func (h *MediaHandler) DeleteFile(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
+// Cache-Control of file downloads. A URL whose ?v= is the file's content
+// hash names that exact content, so browsers may keep it for good; other
+// download URLs are revalidated against the ETag after a few minutes.
+const (
+	immutableCacheControl   = "private, max-age=31536000, immutable"
+	revalidateCacheControl  = "private, max-age=300"
+	defaultDownloadMimeType = "application/octet-stream"
+)
+
+// DownloadFile serves a file's content with its content hash as the ETag.
+// Pass the content_hash as ?v= for a content-addressed URL that is cached
+// as immutable. Only raster images are shown inline; anything else is sent
+// as an attachment.
+// GET /api/media/:id/download
+func (h *MediaHandler) DownloadFile(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid ID")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := streamContext(c)
 	defer cancel()
+	// The media service skips reading content the client already holds
+	stream, err := h.mediaClient.DownloadFile(ctx, &pb.DownloadFileRequest{Id: id, CachedHashes: ifNoneMatchTags(c.Request)})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
 
-	_, err = h.mediaClient.DeleteFile(ctx, &pb.DeleteFileRequest{Id: id})
+	// Errors before the file record can still get a proper status
+	first, err := stream.Recv()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if err == io.EOF {
+			err = status.Error(codes.Internal, "media service sent no file")
+		}
+		apierror.RespondGRPC(c, err)
+		return
+	}
+	file := first.GetFile()
+	if file == nil {
+		apierror.Respond(c, codes.Internal, "media service sent content before the file")
 		return
 	}
 
+	etag := `"` + file.ContentHash + `"`
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", file.GetUploadedAt().AsTime().UTC().Format(http.TimeFormat))
+	if v := c.Query("v"); v != "" && v == file.ContentHash {
+		c.Header("Cache-Control", immutableCacheControl)
+	} else {
+		c.Header("Cache-Control", revalidateCacheControl)
+	}
+	if notModified(c.Request, etag, file.GetUploadedAt().AsTime()) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(file.FileName))
+	if contentType == "" {
+		contentType = defaultDownloadMimeType
+	}
+	disposition := "attachment"
+	if inlineDownload(contentType) {
+		disposition = "inline"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{"filename": file.FileName}))
+	// Files stored before sizes were recorded have none
+	if file.FileSize > 0 {
+		c.Header("Content-Length", strconv.FormatInt(file.FileSize, 10))
+	}
+	c.Status(http.StatusOK)
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			// Headers are gone, so a truncated file is all the client sees
+			slog.ErrorContext(ctx, "file download ended early", "file_id", id, "error", err)
+			return
+		}
+		if _, err := c.Writer.Write(resp.GetChunk()); err != nil {
+			return
+		}
+	}
+}
+
+// inlineDownload reports whether content of contentType may be shown in the
+// browser: raster images only, so stored HTML or SVG can't run script on the
+// gateway's origin
+func inlineDownload(contentType string) bool {
+	return strings.HasPrefix(contentType, "image/") && !strings.HasPrefix(contentType, "image/svg")
+}
+
+// DeleteFile deletes a file
+// DELETE /api/media/:id
+func (h *MediaHandler) DeleteFile(c *gin.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "Invalid ID")
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if _, err := h.mediaClient.DeleteFile(ctx, &pb.DeleteFileRequest{Id: id}); err != nil {
+		return err
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "File deleted successfully"})
+	return nil
 }
 
-// ListFiles returns list of files
+// ListFiles returns list of files, optionally filtered by file_type and an
+// uploaded_after/uploaded_before range
 // GET /api/media
-func (h *MediaHandler) ListFiles(c *gin.Context) {
-	// page := c.DefaultQuery("page", "1")
-	// limit := c.DefaultQuery("limit", "10")
-	fileType := c.Query("file_type")
+func (h *MediaHandler) ListFiles(c *gin.Context) error {
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return nil
+	}
+	uploadedAfter, err := parseTimeQuery(c, "uploaded_after")
+	if err != nil {
+		return err
+	}
+	uploadedBefore, err := parseTimeQuery(c, "uploaded_before")
+	if err != nil {
+		return err
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.mediaClient.ListFiles(ctx, &pb.ListFilesRequest{
-		Page:     1,
-		Limit:    100,
-		FileType: fileType,
+		Page:           page,
+		Limit:          limit,
+		FileType:       c.Query("file_type"),
+		UploadedAfter:  uploadedAfter,
+		UploadedBefore: uploadedBefore,
 	})
+	if err != nil {
+		return err
+	}
 
+	respondPaginated(c, resp.Files, resp.Total, page, resp.Limit)
+	return nil
+}
+
+// ListProjectFiles returns the files uploaded for a project, newest first,
+// optionally narrowed to one of its tasks with task_id and by file_type
+// GET /api/projects/:id/files
+func (h *MediaHandler) ListProjectFiles(c *gin.Context) error {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || projectID <= 0 {
+		return status.Error(codes.InvalidArgument, "Invalid Project ID")
+	}
+	taskID, err := parseOptionalID(c.Query("task_id"), "task_id")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return err
 	}
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return nil
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
 
-	c.JSON(http.StatusOK, resp.Files) // Note: Proto response wraps files in 'Files' field? Yes checked proto.
+	if !isAdmin(c) {
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), projectID, AccessRead)
+		if err != nil {
+			return err
+		}
+		if !allowed {
+			return status.Error(codes.PermissionDenied, "Access to this project denied")
+		}
+	}
+
+	resp, err := h.mediaClient.ListFiles(ctx, &pb.ListFilesRequest{
+		Page:      page,
+		Limit:     limit,
+		FileType:  c.Query("file_type"),
+		ProjectId: projectID,
+		TaskId:    taskID,
+	})
+	if err != nil {
+		return err
+	}
+
+	respondPaginated(c, resp.Files, resp.Total, page, resp.Limit)
+	return nil
+}
+
+// parseTimeQuery reads an optional RFC 3339 query parameter
+func parseTimeQuery(c *gin.Context, name string) (*timestamppb.Timestamp, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, name+" must be an RFC 3339 timestamp")
+	}
+	return timestamppb.New(t), nil
 }
 
 // GetUserFiles returns files uploaded by current user
 // GET /api/media/my-files
-func (h *MediaHandler) GetUserFiles(c *gin.Context) {
+func (h *MediaHandler) GetUserFiles(c *gin.Context) error {
 	userIDVal, _ := c.Get("user_id")
 	var userID int64
 	if v, ok := userIDVal.(float64); ok {
@@ -192,22 +430,23 @@ func (h *MediaHandler) GetUserFiles(c *gin.Context) {
 	} else if v, ok := userIDVal.(int64); ok {
 		userID = v
 	}
-	// page := c.DefaultQuery("page", "1")
-	// limit := c.DefaultQuery("limit", "10")
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return nil
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.mediaClient.GetFilesByUser(ctx, &pb.GetFilesByUserRequest{
 		UserId: userID,
-		Page:   1,
-		Limit:  100,
+		Page:   page,
+		Limit:  limit,
 	})
-
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return err
 	}
 
-	c.JSON(http.StatusOK, resp.Files)
+	respondPaginated(c, resp.Files, resp.Total, page, resp.Limit)
+	return nil
 }
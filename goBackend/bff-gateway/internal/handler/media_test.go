@@ -0,0 +1,348 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	authpb "github.com/portfolio/proto/auth"
+	pb "github.com/portfolio/proto/media"
+	taskpb "github.com/portfolio/proto/task"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeMediaClient stubs the media service; unimplemented methods panic
+type fakeMediaClient struct {
+	pb.MediaServiceClient
+	listReq *pb.ListFilesRequest
+}
+
+func (f *fakeMediaClient) ListFiles(ctx context.Context, in *pb.ListFilesRequest, opts ...grpc.CallOption) (*pb.ListFilesResponse, error) {
+	f.listReq = in
+	if in.FileType == "video" {
+		return nil, status.Error(codes.InvalidArgument, "invalid file type")
+	}
+	return &pb.ListFilesResponse{Files: []*pb.MediaFile{{Id: 1}, {Id: 2}}, Total: 12, Limit: int32(pagination.Sizes{}.Limit(int(in.Limit)))}, nil
+}
+
+func TestMediaHandler_ListFiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	may := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2024, 6, 1, 12, 30, 0, 0, time.FixedZone("", 2*60*60))
+
+	tests := []struct {
+		name       string
+		query      string
+		wantCode   int
+		wantPage   int32
+		wantLimit  int32
+		wantType   string
+		wantAfter  *time.Time
+		wantBefore *time.Time
+	}{
+		{name: "Defaults", query: "", wantCode: http.StatusOK, wantPage: 1, wantLimit: 0},
+		{name: "Paging", query: "?page=3&limit=5", wantCode: http.StatusOK, wantPage: 3, wantLimit: 5},
+		{name: "Type", query: "?file_type=image", wantCode: http.StatusOK, wantPage: 1, wantLimit: 0, wantType: "image"},
+		{
+			name:     "Date range",
+			query:    "?uploaded_after=2024-05-01T00:00:00Z&uploaded_before=2024-06-01T12:30:00%2B02:00",
+			wantCode: http.StatusOK, wantPage: 1, wantLimit: 0, wantAfter: &may, wantBefore: &june,
+		},
+		{name: "Invalid start", query: "?uploaded_after=2024-05-01", wantCode: http.StatusBadRequest},
+		{name: "Invalid end", query: "?uploaded_before=yesterday", wantCode: http.StatusBadRequest},
+		{name: "Invalid page", query: "?page=abc", wantCode: http.StatusBadRequest},
+		{name: "Unknown type", query: "?file_type=video", wantCode: http.StatusBadRequest, wantPage: 1, wantLimit: 0, wantType: "video"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeMediaClient{}
+			h := &MediaHandler{mediaClient: client}
+			r := gin.New()
+			r.GET("/media", apierror.Handle(h.ListFiles))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/media"+tt.query, nil))
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+
+			req := client.listReq
+			if tt.wantPage == 0 {
+				if req != nil {
+					t.Errorf("media service was called with %+v", req)
+				}
+				return
+			}
+			if req.Page != tt.wantPage || req.Limit != tt.wantLimit || req.FileType != tt.wantType {
+				t.Errorf("request = %+v, want page %d limit %d type %q", req, tt.wantPage, tt.wantLimit, tt.wantType)
+			}
+			checkTime := func(name string, got *timestamppb.Timestamp, want *time.Time) {
+				if (got == nil) != (want == nil) || (want != nil && !got.AsTime().Equal(*want)) {
+					t.Errorf("%s = %v, want %v", name, got, want)
+				}
+			}
+			checkTime("uploaded_after", req.UploadedAfter, tt.wantAfter)
+			checkTime("uploaded_before", req.UploadedBefore, tt.wantBefore)
+
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var body envelope
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			// The envelope reports the page size the service applied
+			served := int32(pagination.Sizes{}.Limit(int(tt.wantLimit)))
+			if len(body.Data) != 2 || body.Total != 12 || body.Page != tt.wantPage || body.Limit != served {
+				t.Errorf("envelope = %+v, want 2 files of 12 on page %d of %d", body, tt.wantPage, served)
+			}
+		})
+	}
+}
+
+func TestMediaHandler_ListProjectFiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auth := &fakeAuthClient{accesses: []*authpb.UserProjectAccess{{UserId: 2, ProjectId: 10, AccessLevel: AccessRead}}}
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantTask int64
+	}{
+		{"project", "/projects/10/files", http.StatusOK, 0},
+		{"task", "/projects/10/files?task_id=4", http.StatusOK, 4},
+		{"no access", "/projects/11/files", http.StatusForbidden, 0},
+		{"invalid task", "/projects/10/files?task_id=x", http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeMediaClient{}
+			h := &MediaHandler{mediaClient: client, access: &ProjectAccessChecker{authClient: auth}}
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set("user_id", int64(2)) })
+			r.GET("/projects/:id/files", apierror.Handle(h.ListProjectFiles))
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				if client.listReq != nil {
+					t.Errorf("media service was called with %+v", client.listReq)
+				}
+				return
+			}
+			if req := client.listReq; req.ProjectId != 10 || req.TaskId != tt.wantTask {
+				t.Errorf("request = %+v, want project 10 and task %d", req, tt.wantTask)
+			}
+		})
+	}
+}
+
+func TestMediaHandler_UploadTarget(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auth := &fakeAuthClient{accesses: []*authpb.UserProjectAccess{
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 2, ProjectId: 11, AccessLevel: AccessRead},
+	}}
+	tasks := &fakeTaskClient{tasks: map[int64]*taskpb.Task{4: {Id: 4, ProjectId: 10}, 5: {Id: 5, ProjectId: 11}}}
+
+	tests := []struct {
+		name        string
+		form        string
+		wantCode    codes.Code
+		wantProject int64
+		wantTask    int64
+	}{
+		{name: "unassociated", form: ""},
+		{name: "project", form: "project_id=10", wantProject: 10},
+		{name: "task implies its project", form: "task_id=4", wantProject: 10, wantTask: 4},
+		{name: "task in another project", form: "project_id=11&task_id=4", wantCode: codes.InvalidArgument},
+		{name: "read-only project", form: "task_id=5", wantCode: codes.PermissionDenied},
+		{name: "unknown task", form: "task_id=9", wantCode: codes.NotFound},
+		{name: "invalid project", form: "project_id=-1", wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &MediaHandler{taskClient: tasks, access: &ProjectAccessChecker{authClient: auth}}
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodPost, "/media/upload", strings.NewReader(tt.form))
+			c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			c.Set("user_id", int64(2))
+
+			projectID, taskID, err := h.uploadTarget(c)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("uploadTarget() error = %v, want %s", err, tt.wantCode)
+			}
+			if projectID != tt.wantProject || taskID != tt.wantTask {
+				t.Errorf("uploadTarget() = project %d, task %d; want %d, %d", projectID, taskID, tt.wantProject, tt.wantTask)
+			}
+		})
+	}
+}
+
+// fakeDownloadStream serves fixed download messages, then io.EOF
+type fakeDownloadStream struct {
+	grpc.ClientStream
+	msgs []*pb.DownloadFileResponse
+}
+
+func (s *fakeDownloadStream) Recv() (*pb.DownloadFileResponse, error) {
+	if len(s.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+// fakeDownloadClient serves file 7, a small PNG split into two chunks, and
+// file 9, a PDF stored before sizes were recorded. Like the media service it
+// sends only the record when the client holds the content.
+type fakeDownloadClient struct {
+	pb.MediaServiceClient
+	// cached is the last request's cached hashes
+	cached *[]string
+}
+
+func (f fakeDownloadClient) DownloadFile(ctx context.Context, in *pb.DownloadFileRequest, opts ...grpc.CallOption) (pb.MediaService_DownloadFileClient, error) {
+	*f.cached = in.CachedHashes
+	uploaded := timestamppb.New(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	var file *pb.MediaFile
+	switch in.Id {
+	case 7:
+		file = &pb.MediaFile{Id: 7, FileName: "photo.png", FileSize: 8, ContentHash: "abc123", UploadedAt: uploaded}
+	case 9:
+		file = &pb.MediaFile{Id: 9, FileName: "report.pdf", ContentHash: "def456", UploadedAt: uploaded}
+	default:
+		return nil, status.Error(codes.NotFound, "file not found")
+	}
+	msgs := []*pb.DownloadFileResponse{{Data: &pb.DownloadFileResponse_File{File: file}}}
+	if !slices.Contains(in.CachedHashes, file.ContentHash) {
+		msgs = append(msgs,
+			&pb.DownloadFileResponse{Data: &pb.DownloadFileResponse_Chunk{Chunk: []byte("png-")}},
+			&pb.DownloadFileResponse{Data: &pb.DownloadFileResponse_Chunk{Chunk: []byte("data")}})
+	}
+	return &fakeDownloadStream{msgs: msgs}, nil
+}
+
+func TestMediaHandler_DownloadFile(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var cached []string
+	h := &MediaHandler{mediaClient: fakeDownloadClient{cached: &cached}}
+	r := gin.New()
+	r.GET("/media/:id/download", h.DownloadFile)
+
+	tests := []struct {
+		name            string
+		path            string
+		ifNoneMatch     string
+		wantCode        int
+		wantCache       string
+		wantBody        string
+		wantCached      []string
+		wantETag        string
+		wantType        string
+		wantDisposition string
+		wantLength      string
+		wantNoHeader    bool
+	}{
+		{name: "Plain URL", path: "/media/7/download", wantCode: http.StatusOK, wantCache: revalidateCacheControl, wantBody: "png-data"},
+		{name: "Content-addressed URL", path: "/media/7/download?v=abc123", wantCode: http.StatusOK, wantCache: immutableCacheControl, wantBody: "png-data"},
+		{name: "Stale hash", path: "/media/7/download?v=old", wantCode: http.StatusOK, wantCache: revalidateCacheControl, wantBody: "png-data"},
+		{name: "Not modified", path: "/media/7/download", ifNoneMatch: `"abc123"`, wantCode: http.StatusNotModified, wantCache: revalidateCacheControl, wantCached: []string{"abc123"}},
+		{name: "Stale ETags", path: "/media/7/download", ifNoneMatch: `W/"old", "older"`, wantCode: http.StatusOK, wantCache: revalidateCacheControl,
+			wantBody: "png-data", wantCached: []string{"old", "older"}},
+		{name: "Document without a size", path: "/media/9/download", wantCode: http.StatusOK, wantCache: revalidateCacheControl, wantBody: "png-data",
+			wantETag: `"def456"`, wantType: "application/pdf", wantDisposition: `attachment; filename=report.pdf`, wantLength: "-"},
+		{name: "Missing file", path: "/media/8/download", wantCode: http.StatusNotFound, wantNoHeader: true},
+		{name: "Invalid ID", path: "/media/abc/download", wantCode: http.StatusBadRequest, wantNoHeader: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantETag == "" {
+				tt.wantETag, tt.wantType, tt.wantDisposition, tt.wantLength = `"abc123"`, "image/png", `inline; filename=photo.png`, "8"
+			}
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			cached = nil
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body.String())
+			}
+			if tt.wantNoHeader {
+				if got := w.Header().Get("Cache-Control"); got != "" {
+					t.Errorf("Cache-Control = %q on an error", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(cached, tt.wantCached) {
+				t.Errorf("cached hashes sent = %q, want %q", cached, tt.wantCached)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+			if got := w.Header().Get("Cache-Control"); got != tt.wantCache {
+				t.Errorf("Cache-Control = %q, want %q", got, tt.wantCache)
+			}
+			if tt.wantCode != http.StatusOK {
+				if w.Body.Len() != 0 {
+					t.Errorf("body = %q, want none", w.Body.String())
+				}
+				return
+			}
+			if got := w.Body.String(); got != tt.wantBody {
+				t.Errorf("body = %q, want %q", got, tt.wantBody)
+			}
+			if got := w.Header().Get("Content-Type"); got != tt.wantType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.wantType)
+			}
+			if got := w.Header().Get("Content-Disposition"); got != tt.wantDisposition {
+				t.Errorf("Content-Disposition = %q, want %q", got, tt.wantDisposition)
+			}
+			length, ok := w.Header()["Content-Length"]
+			if tt.wantLength == "-" && ok {
+				t.Errorf("Content-Length = %q, want none", length)
+			} else if tt.wantLength != "-" && (!ok || length[0] != tt.wantLength) {
+				t.Errorf("Content-Length = %q, want %s", length, tt.wantLength)
+			}
+		})
+	}
+}
+
+func TestInlineDownload(t *testing.T) {
+	tests := map[string]bool{
+		"image/png":                true,
+		"image/jpeg":               true,
+		"image/svg+xml":            false,
+		"text/html; charset=utf-8": false,
+		"application/pdf":          false,
+		defaultDownloadMimeType:    false,
+	}
+	for contentType, want := range tests {
+		if got := inlineDownload(contentType); got != want {
+			t.Errorf("inlineDownload(%q) = %v, want %v", contentType, got, want)
+		}
+	}
+}
@@ -1,24 +1,31 @@
 package handler
 
 import (
-	"context"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
-	"time"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	analyticspb "github.com/portfolio/proto/analytics"
 	pb "github.com/portfolio/proto/project"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
 )
 
 // ProjectHandler handles project endpoints
 type ProjectHandler struct {
-	projectClient pb.ProjectServiceClient
+	projectClient   pb.ProjectServiceClient
+	analyticsClient analyticspb.AnalyticsServiceClient
 }
 
 // NewProjectHandler creates a new ProjectHandler
-func NewProjectHandler(conn *grpc.ClientConn) *ProjectHandler {
+func NewProjectHandler(conn, analyticsConn *grpc.ClientConn) *ProjectHandler {
 	return &ProjectHandler{
-		projectClient: pb.NewProjectServiceClient(conn),
+		projectClient:   pb.NewProjectServiceClient(conn),
+		analyticsClient: analyticspb.NewAnalyticsServiceClient(analyticsConn),
 	}
 }
 
@@ -38,11 +45,11 @@ type CreateProjectRequest struct {
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	var req CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.projectClient.CreateProject(ctx, &pb.CreateProjectRequest{
@@ -54,7 +61,7 @@ func (h *ProjectHandler) CreateProject(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -68,20 +75,102 @@ func (h *ProjectHandler) GetProject(c *gin.Context) {
 		ID int64 `uri:"id" binding:"required"`
 	}
 	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.projectClient.GetProject(ctx, &pb.GetProjectRequest{Id: req.ID})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Project)
+	respondConditional(c, resp.Project, resp.Project.GetUpdatedAt())
+}
+
+// overviewRecentLimit is how many recent views and activities an overview includes
+const overviewRecentLimit = 5
+
+// ProjectOverview is everything the project detail page shows. The analytics
+// fields are left out when the analytics service can't be reached.
+type ProjectOverview struct {
+	Project            *pb.Project                 `json:"project"`
+	AnalyticsAvailable bool                        `json:"analytics_available"`
+	Stats              *analyticspb.ProjectStats   `json:"stats,omitempty"`
+	TotalViews         int32                       `json:"total_views,omitempty"`
+	RecentViews        []*analyticspb.ProjectView  `json:"recent_views,omitempty"`
+	RecentActivity     []*analyticspb.TaskActivity `json:"recent_activity,omitempty"`
+}
+
+// GetProjectOverview returns a project with its relations, stats and recent
+// views and activity, fetched concurrently
+// GET /api/projects/:id/overview
+func (h *ProjectHandler) GetProjectOverview(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	// Only the project call fails the request, and cancels the analytics
+	// calls still in flight; analytics errors are kept apart so the project
+	// can still be returned without them
+	g, gctx := errgroup.WithContext(ctx)
+	var (
+		project                         *pb.ProjectResponse
+		stats                           *analyticspb.ProjectStatsResponse
+		views                           *analyticspb.ProjectViewsResponse
+		activity                        *analyticspb.TaskActivitiesResponse
+		statsErr, viewsErr, activityErr error
+	)
+	g.Go(func() (err error) {
+		project, err = h.projectClient.GetProject(gctx, &pb.GetProjectRequest{Id: req.ID})
+		return err
+	})
+	g.Go(func() error {
+		stats, statsErr = h.analyticsClient.GetProjectStats(gctx, &analyticspb.GetProjectStatsRequest{ProjectId: req.ID})
+		return nil
+	})
+	g.Go(func() error {
+		views, viewsErr = h.analyticsClient.GetProjectViews(gctx, &analyticspb.GetProjectViewsRequest{
+			ProjectId: req.ID,
+			Page:      1,
+			Limit:     overviewRecentLimit,
+		})
+		return nil
+	})
+	g.Go(func() error {
+		activity, activityErr = h.analyticsClient.GetTaskActivities(gctx, &analyticspb.GetTaskActivitiesRequest{
+			ProjectId: req.ID,
+			Page:      1,
+			Limit:     overviewRecentLimit,
+		})
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	overview := ProjectOverview{Project: project.Project}
+	if err := errors.Join(statsErr, viewsErr, activityErr); err != nil {
+		slog.WarnContext(ctx, "project overview without analytics", "project_id", req.ID, "error", err)
+	} else {
+		overview.AnalyticsAvailable = true
+		overview.Stats = stats.Stats
+		overview.TotalViews = views.TotalViews
+		overview.RecentViews = views.Views
+		overview.RecentActivity = activity.Activities
+	}
+	c.JSON(http.StatusOK, overview)
 }
 
 // UpdateProject updates a project
@@ -91,17 +180,17 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 		ID int64 `uri:"id" binding:"required"`
 	}{}
 	if err := c.ShouldBindUri(&idStruct); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
 	var req CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.projectClient.UpdateProject(ctx, &pb.UpdateProjectRequest{
@@ -114,7 +203,7 @@ func (h *ProjectHandler) UpdateProject(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -128,43 +217,265 @@ func (h *ProjectHandler) DeleteProject(c *gin.Context) {
 		ID int64 `uri:"id" binding:"required"`
 	}
 	if err := c.ShouldBindUri(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	_, err := h.projectClient.DeleteProject(ctx, &pb.DeleteProjectRequest{Id: req.ID})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
 }
 
-// ListProjects returns list of projects
+// CompleteProject marks a project completed. It answers 409 while tasks are
+// still open unless ?force=true is given.
+// POST /api/projects/:id/complete
+func (h *ProjectHandler) CompleteProject(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	var query struct {
+		Force bool `form:"force"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.CompleteProject(ctx, &pb.CompleteProjectRequest{Id: req.ID, Force: query.Force})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Project)
+}
+
+// ArchiveProject archives a project, hiding it from project lists unless
+// ?include_archived=true is given. Its tasks and history are kept.
+// POST /api/projects/:id/archive
+func (h *ProjectHandler) ArchiveProject(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.ArchiveProject(ctx, &pb.ArchiveProjectRequest{Id: req.ID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Project)
+}
+
+// UnarchiveProject makes an archived project active again. It answers 409
+// for projects that aren't archived.
+// POST /api/projects/:id/unarchive
+func (h *ProjectHandler) UnarchiveProject(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.UnarchiveProject(ctx, &pb.UnarchiveProjectRequest{Id: req.ID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Project)
+}
+
+// ProjectSettingsRequest represents update project settings request. An
+// empty status or a zero priority unsets that default.
+type ProjectSettingsRequest struct {
+	DefaultTaskStatus   string `json:"default_task_status" binding:"omitempty,oneof=Todo InProgress Done"`
+	DefaultTaskPriority int32  `json:"default_task_priority" binding:"omitempty,min=1,max=4"`
+}
+
+// GetProjectSettings returns a project's defaults for new tasks
+// GET /api/projects/:id/settings
+func (h *ProjectHandler) GetProjectSettings(c *gin.Context) {
+	var uri struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uri); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.GetProjectSettings(ctx, &pb.GetProjectSettingsRequest{ProjectId: uri.ID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Settings)
+}
+
+// UpdateProjectSettings replaces a project's defaults for new tasks
+// PUT /api/projects/:id/settings
+func (h *ProjectHandler) UpdateProjectSettings(c *gin.Context) {
+	var uri struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uri); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	var req ProjectSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.UpdateProjectSettings(ctx, &pb.UpdateProjectSettingsRequest{
+		ProjectId:           uri.ID,
+		DefaultTaskStatus:   req.DefaultTaskStatus,
+		DefaultTaskPriority: req.DefaultTaskPriority,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Settings)
+}
+
+// CloneProject creates an active project with the skills, tech stack and
+// links of an existing one. The body is optional; without a name the clone
+// is named after the original with " (copy)".
+// POST /api/projects/:id/clone
+func (h *ProjectHandler) CloneProject(c *gin.Context) {
+	var uri struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uri); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.CloneProject(ctx, &pb.CloneProjectRequest{Id: uri.ID, Name: req.Name})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Project)
+}
+
+// ListProjects returns list of projects, leaving out archived ones unless
+// ?include_archived=true or ?status=archived is given. ?include=skills,tech
+// loads those relations for the whole page. With ?ids=3,1 it returns the
+// projects with those IDs in that order, skipping missing ones.
 // GET /api/projects
 func (h *ProjectHandler) ListProjects(c *gin.Context) {
-	// page := c.DefaultQuery("page", "1")
-	// limit := c.DefaultQuery("limit", "10")
-	status := c.Query("status")
+	if _, ok := c.GetQuery("ids"); ok {
+		h.batchGetProjects(c)
+		return
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+	var query struct {
+		Status          string `form:"status"`
+		IncludeArchived bool   `form:"include_archived"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
+	// The project service rejects unknown relations
+	var include []string
+	for _, value := range c.QueryArray("include") {
+		for _, relation := range strings.Split(value, ",") {
+			if relation = strings.TrimSpace(relation); relation != "" {
+				include = append(include, relation)
+			}
+		}
+	}
+
 	resp, err := h.projectClient.ListProjects(ctx, &pb.ListProjectsRequest{
-		Page:   1, // Simplification
-		Limit:  10,
-		Status: status,
+		Page:            page,
+		Limit:           limit,
+		Status:          query.Status,
+		IncludeArchived: query.IncludeArchived,
+		Include:         include,
 	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	respondPaginated(c, resp.Projects, resp.Total, page, resp.Limit)
+}
+
+// batchGetProjects answers GET /api/projects?ids=. IDs may be comma-separated,
+// repeated, or both.
+func (h *ProjectHandler) batchGetProjects(c *gin.Context) {
+	ids, ok := parseIDList(c, "ids")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.BatchGetProjects(ctx, &pb.BatchGetProjectsRequest{Ids: ids})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Projects)
+	respondList(c, resp.Projects, len(resp.Projects))
 }
 
 // AddSkill adds a skill to project
@@ -174,7 +485,7 @@ func (h *ProjectHandler) AddSkill(c *gin.Context) {
 		ID int64 `uri:"id" binding:"required"`
 	}
 	if err := c.ShouldBindUri(&uri); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
@@ -182,11 +493,11 @@ func (h *ProjectHandler) AddSkill(c *gin.Context) {
 		SkillID int64 `json:"skill_id" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	_, err := h.projectClient.AddProjectSkill(ctx, &pb.AddProjectSkillRequest{
@@ -195,7 +506,7 @@ func (h *ProjectHandler) AddSkill(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -209,18 +520,18 @@ func (h *ProjectHandler) AddTech(c *gin.Context) {
 		ID int64 `uri:"id" binding:"required"`
 	}
 	if err := c.ShouldBindUri(&uri); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 	var req struct {
 		TechName string `json:"tech_name" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	_, err := h.projectClient.AddProjectTech(ctx, &pb.AddProjectTechRequest{
@@ -229,7 +540,7 @@ func (h *ProjectHandler) AddTech(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -243,7 +554,7 @@ func (h *ProjectHandler) AddImage(c *gin.Context) {
 		ID int64 `uri:"id" binding:"required"`
 	}
 	if err := c.ShouldBindUri(&uri); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 	var req struct {
@@ -251,11 +562,11 @@ func (h *ProjectHandler) AddImage(c *gin.Context) {
 		Description string `json:"description"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.projectClient.AddProjectImage(ctx, &pb.AddProjectImageRequest{
@@ -265,7 +576,7 @@ func (h *ProjectHandler) AddImage(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -279,7 +590,7 @@ func (h *ProjectHandler) AddLink(c *gin.Context) {
 		ID int64 `uri:"id" binding:"required"`
 	}
 	if err := c.ShouldBindUri(&uri); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 	var req struct {
@@ -287,11 +598,11 @@ func (h *ProjectHandler) AddLink(c *gin.Context) {
 		LinkType string `json:"link_type" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.projectClient.AddProjectLink(ctx, &pb.AddProjectLinkRequest{
@@ -301,25 +612,51 @@ func (h *ProjectHandler) AddLink(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, resp.Link)
 }
 
+// ListLinks returns the links of a project, optionally filtered by type
+// GET /api/projects/:id/links?type=github
+func (h *ProjectHandler) ListLinks(c *gin.Context) {
+	var uri struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uri); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.ListProjectLinks(ctx, &pb.ListProjectLinksRequest{
+		ProjectId: uri.ID,
+		LinkType:  c.Query("type"),
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	respondList(c, resp.Links, len(resp.Links))
+}
+
 // ListSkills returns all skills
 // GET /api/skills
 func (h *ProjectHandler) ListSkills(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.projectClient.ListSkills(ctx, &pb.Empty{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, resp.Skills)
+	respondList(c, resp.Skills, len(resp.Skills))
 }
 
 // CreateSkill creates a new skill
@@ -329,22 +666,119 @@ func (h *ProjectHandler) CreateSkill(c *gin.Context) {
 		Name string `json:"name" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.projectClient.CreateSkill(ctx, &pb.CreateSkillRequest{Name: req.Name})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, resp.Skill)
 }
 
+// CreateWebhookRequest represents create webhook request
+type CreateWebhookRequest struct {
+	URL string `json:"url" binding:"required,url"`
+	// Secret signs the deliveries; one is generated when it is left out
+	Secret     string   `json:"secret" binding:"omitempty,min=16"`
+	EventTypes []string `json:"event_types" binding:"required,min=1,dive,oneof=task.created task.updated task.deleted project.created project.updated project.deleted"`
+}
+
+// ListWebhooks returns every webhook subscription; secrets are not included
+// GET /api/webhooks
+func (h *ProjectHandler) ListWebhooks(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.ListWebhooks(ctx, &pb.Empty{})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+	respondList(c, resp.Webhooks, len(resp.Webhooks))
+}
+
+// CreateWebhook subscribes a URL to task and project events. The response
+// is the only one that includes the secret.
+// POST /api/webhooks
+func (h *ProjectHandler) CreateWebhook(c *gin.Context) {
+	var req CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.CreateWebhook(ctx, &pb.CreateWebhookRequest{
+		Url:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Webhook)
+}
+
+// DeleteWebhook removes a webhook subscription and its delivery history
+// DELETE /api/webhooks/:id
+func (h *ProjectHandler) DeleteWebhook(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if _, err := h.projectClient.DeleteWebhook(ctx, &pb.DeleteWebhookRequest{Id: req.ID}); err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// ListWebhookDeliveries returns a webhook's latest delivery attempts, newest
+// first; limit defaults to the configured page size
+// GET /api/webhooks/:id/deliveries
+func (h *ProjectHandler) ListWebhookDeliveries(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	limit, ok := parsePageParam(c, "limit", 0)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.projectClient.ListWebhookDeliveries(ctx, &pb.ListWebhookDeliveriesRequest{WebhookId: req.ID, Limit: limit})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+	respondList(c, resp.Deliveries, len(resp.Deliveries))
+}
+
 // AddMember adds a member to project (MOCK)
 // POST /api/projects/:id/members
 func (h *ProjectHandler) AddMember(c *gin.Context) {
@@ -354,7 +788,7 @@ func (h *ProjectHandler) AddMember(c *gin.Context) {
 		Role   string `json:"role"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
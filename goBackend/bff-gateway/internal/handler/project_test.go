@@ -0,0 +1,587 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	analyticspb "github.com/portfolio/proto/analytics"
+	pb "github.com/portfolio/proto/project"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeProjectClient stubs the project service; unimplemented methods panic
+type fakeProjectClient struct {
+	pb.ProjectServiceClient
+	projects    map[int64]*pb.Project
+	completeReq *pb.CompleteProjectRequest
+	batchReq    *pb.BatchGetProjectsRequest
+	cloneReq    *pb.CloneProjectRequest
+	listReq     *pb.ListProjectsRequest
+	settingsReq *pb.UpdateProjectSettingsRequest
+	webhookReq  *pb.CreateWebhookRequest
+}
+
+func (f *fakeProjectClient) GetProject(ctx context.Context, in *pb.GetProjectRequest, opts ...grpc.CallOption) (*pb.ProjectResponse, error) {
+	project, ok := f.projects[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return &pb.ProjectResponse{Project: project}, nil
+}
+
+// BatchGetProjects returns the known projects in the order asked for
+func (f *fakeProjectClient) BatchGetProjects(ctx context.Context, in *pb.BatchGetProjectsRequest, opts ...grpc.CallOption) (*pb.BatchGetProjectsResponse, error) {
+	f.batchReq = in
+	resp := &pb.BatchGetProjectsResponse{}
+	for _, id := range in.Ids {
+		if project, ok := f.projects[id]; ok {
+			resp.Projects = append(resp.Projects, project)
+		}
+	}
+	return resp, nil
+}
+
+// CompleteProject refuses unless forced, as if the project had open tasks
+func (f *fakeProjectClient) CompleteProject(ctx context.Context, in *pb.CompleteProjectRequest, opts ...grpc.CallOption) (*pb.ProjectResponse, error) {
+	f.completeReq = in
+	if !in.Force {
+		return nil, status.Error(codes.FailedPrecondition, "project has 2 open tasks")
+	}
+	return &pb.ProjectResponse{Project: &pb.Project{Id: in.Id, Status: "completed"}}, nil
+}
+
+// CloneProject names the clone like the project service does
+func (f *fakeProjectClient) CloneProject(ctx context.Context, in *pb.CloneProjectRequest, opts ...grpc.CallOption) (*pb.ProjectResponse, error) {
+	f.cloneReq = in
+	source, ok := f.projects[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	name := in.Name
+	if name == "" {
+		name = source.Name + " (copy)"
+	}
+	return &pb.ProjectResponse{Project: &pb.Project{Id: 50, Name: name, Status: "active"}}, nil
+}
+
+func serveOverview(t *testing.T, analytics *fakeAnalyticsClient, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	h := &ProjectHandler{
+		projectClient: &fakeProjectClient{projects: map[int64]*pb.Project{
+			3: {Id: 3, Name: "Portfolio", TechStack: []string{"Go"}},
+		}},
+		analyticsClient: analytics,
+	}
+	r := gin.New()
+	r.GET("/projects/:id/overview", h.GetProjectOverview)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+	return w
+}
+
+type overviewBody struct {
+	Project            *pb.Project                `json:"project"`
+	AnalyticsAvailable bool                       `json:"analytics_available"`
+	Stats              *analyticspb.ProjectStats  `json:"stats"`
+	TotalViews         int32                      `json:"total_views"`
+	RecentViews        []*analyticspb.ProjectView `json:"recent_views"`
+}
+
+func TestProjectHandler_GetProjectOverview(t *testing.T) {
+	analytics := &fakeAnalyticsClient{}
+	w := serveOverview(t, analytics, "/projects/3/overview")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (%s)", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var body overviewBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Project == nil || body.Project.Name != "Portfolio" || len(body.Project.TechStack) != 1 {
+		t.Errorf("project = %+v, want Portfolio with its tech stack", body.Project)
+	}
+	if !body.AnalyticsAvailable || body.Stats == nil || body.Stats.TotalTasks != 4 {
+		t.Errorf("stats = %+v (available %v), want 4 tasks", body.Stats, body.AnalyticsAvailable)
+	}
+	if body.TotalViews != 45 || len(body.RecentViews) != 2 {
+		t.Errorf("views = %d of %d, want 2 of 45", len(body.RecentViews), body.TotalViews)
+	}
+	if analytics.viewsReq.Limit != overviewRecentLimit || analytics.activitiesReq.ProjectId != 3 || analytics.activitiesReq.Limit != overviewRecentLimit {
+		t.Errorf("analytics requests = %v / %v, want project 3 limited to %d", analytics.viewsReq, analytics.activitiesReq, overviewRecentLimit)
+	}
+}
+
+func TestProjectHandler_GetProjectOverview_AnalyticsDown(t *testing.T) {
+	analytics := &fakeAnalyticsClient{err: status.Error(codes.Unavailable, "connection refused")}
+	w := serveOverview(t, analytics, "/projects/3/overview")
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (%s)", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(w.Body.Bytes(), &raw); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if string(raw["analytics_available"]) != "false" {
+		t.Errorf("analytics_available = %s, want false", raw["analytics_available"])
+	}
+	for _, key := range []string{"stats", "total_views", "recent_views", "recent_activity"} {
+		if _, ok := raw[key]; ok {
+			t.Errorf("%s present although analytics is down", key)
+		}
+	}
+	if _, ok := raw["project"]; !ok {
+		t.Error("project missing")
+	}
+}
+
+func TestProjectHandler_GetProjectOverview_ProjectNotFound(t *testing.T) {
+	w := serveOverview(t, &fakeAnalyticsClient{}, "/projects/9/overview")
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// blockingAnalyticsClient answers GetProjectStats only once ctx is done
+type blockingAnalyticsClient struct {
+	*fakeAnalyticsClient
+}
+
+func (blockingAnalyticsClient) GetProjectStats(ctx context.Context, in *analyticspb.GetProjectStatsRequest, opts ...grpc.CallOption) (*analyticspb.ProjectStatsResponse, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestProjectHandler_GetProjectOverview_ProjectErrorCancelsAnalytics(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := &ProjectHandler{
+		projectClient:   &fakeProjectClient{},
+		analyticsClient: blockingAnalyticsClient{&fakeAnalyticsClient{}},
+	}
+	r := gin.New()
+	r.GET("/projects/:id/overview", h.GetProjectOverview)
+
+	done := make(chan int)
+	go func() {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/projects/9/overview", nil))
+		done <- w.Code
+	}()
+	select {
+	case code := <-done:
+		if code != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", code, http.StatusNotFound)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("overview kept waiting for analytics after the project call failed")
+	}
+}
+
+func TestProjectHandler_CompleteProject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeProjectClient{}
+	h := &ProjectHandler{projectClient: client}
+	r := gin.New()
+	r.POST("/projects/:id/complete", h.CompleteProject)
+
+	tests := []struct {
+		path      string
+		want      int
+		wantForce bool
+	}{
+		{"/projects/3/complete", http.StatusConflict, false},
+		{"/projects/3/complete?force=true", http.StatusOK, true},
+		{"/projects/3/complete?force=maybe", http.StatusBadRequest, false},
+	}
+	for _, tt := range tests {
+		client.completeReq = nil
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+		if w.Code != tt.want {
+			t.Errorf("POST %s status = %d, want %d", tt.path, w.Code, tt.want)
+		}
+		if tt.want != http.StatusBadRequest && (client.completeReq == nil || client.completeReq.Force != tt.wantForce) {
+			t.Errorf("POST %s sent %v, want force=%v", tt.path, client.completeReq, tt.wantForce)
+		}
+	}
+}
+
+// ListProjects lists the known projects, leaving out archived ones like the project service
+func (f *fakeProjectClient) ListProjects(ctx context.Context, in *pb.ListProjectsRequest, opts ...grpc.CallOption) (*pb.ListProjectsResponse, error) {
+	f.listReq = in
+	resp := &pb.ListProjectsResponse{}
+	for id := int64(1); id <= int64(len(f.projects)); id++ {
+		project := f.projects[id]
+		if in.Status != "" && project.Status != in.Status {
+			continue
+		}
+		if in.Status == "" && !in.IncludeArchived && project.Status == "archived" {
+			continue
+		}
+		resp.Projects = append(resp.Projects, project)
+	}
+	resp.Total = int32(len(resp.Projects))
+	return resp, nil
+}
+
+// ArchiveProject archives a known project
+func (f *fakeProjectClient) ArchiveProject(ctx context.Context, in *pb.ArchiveProjectRequest, opts ...grpc.CallOption) (*pb.ProjectResponse, error) {
+	project, ok := f.projects[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	project.Status = "archived"
+	return &pb.ProjectResponse{Project: project}, nil
+}
+
+// UnarchiveProject reactivates an archived project
+func (f *fakeProjectClient) UnarchiveProject(ctx context.Context, in *pb.UnarchiveProjectRequest, opts ...grpc.CallOption) (*pb.ProjectResponse, error) {
+	project, ok := f.projects[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	if project.Status != "archived" {
+		return nil, status.Error(codes.FailedPrecondition, "project is not archived")
+	}
+	project.Status = "active"
+	return &pb.ProjectResponse{Project: project}, nil
+}
+
+func TestProjectHandler_ArchiveProject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeProjectClient{projects: map[int64]*pb.Project{
+		1: {Id: 1, Name: "Current", Status: "active"},
+		2: {Id: 2, Name: "Old", Status: "active"},
+	}}
+	h := &ProjectHandler{projectClient: client}
+	r := gin.New()
+	r.GET("/projects", h.ListProjects)
+	r.POST("/projects/:id/archive", h.ArchiveProject)
+	r.POST("/projects/:id/unarchive", h.UnarchiveProject)
+
+	serve := func(method, path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+		return w
+	}
+	listed := func(path string) []int64 {
+		w := serve(http.MethodGet, path)
+		if w.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d (%s)", path, w.Code, w.Body.String())
+		}
+		var body struct {
+			Data []*pb.Project `json:"data"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("GET %s body = %s: %v", path, w.Body.String(), err)
+		}
+		var ids []int64
+		for _, p := range body.Data {
+			ids = append(ids, p.Id)
+		}
+		return ids
+	}
+
+	if w := serve(http.MethodPost, "/projects/2/archive"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"status":"archived"`) {
+		t.Fatalf("archive status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if ids := listed("/projects"); !reflect.DeepEqual(ids, []int64{1}) {
+		t.Errorf("default list = %v, want [1]", ids)
+	}
+	if ids := listed("/projects?include_archived=true"); !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Errorf("list with archived = %v, want [1 2]", ids)
+	}
+	if !client.listReq.IncludeArchived {
+		t.Errorf("request = %v, want include_archived", client.listReq)
+	}
+	if w := serve(http.MethodGet, "/projects?include_archived=maybe"); w.Code != http.StatusBadRequest {
+		t.Errorf("invalid flag status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+
+	if w := serve(http.MethodPost, "/projects/2/unarchive"); w.Code != http.StatusOK {
+		t.Fatalf("unarchive status = %d, body = %s", w.Code, w.Body.String())
+	}
+	if ids := listed("/projects"); !reflect.DeepEqual(ids, []int64{1, 2}) {
+		t.Errorf("list after unarchive = %v, want [1 2]", ids)
+	}
+	if w := serve(http.MethodPost, "/projects/1/unarchive"); w.Code != http.StatusConflict {
+		t.Errorf("unarchive active status = %d, want %d", w.Code, http.StatusConflict)
+	}
+	if w := serve(http.MethodPost, "/projects/9/archive"); w.Code != http.StatusNotFound {
+		t.Errorf("archive missing status = %d, want %d", w.Code, http.StatusNotFound)
+	}
+}
+
+// UpdateProjectSettings echoes the settings for known projects
+func (f *fakeProjectClient) UpdateProjectSettings(ctx context.Context, in *pb.UpdateProjectSettingsRequest, opts ...grpc.CallOption) (*pb.ProjectSettingsResponse, error) {
+	f.settingsReq = in
+	if _, ok := f.projects[in.ProjectId]; !ok {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return &pb.ProjectSettingsResponse{Settings: &pb.ProjectSettings{
+		ProjectId:           in.ProjectId,
+		DefaultTaskStatus:   in.DefaultTaskStatus,
+		DefaultTaskPriority: in.DefaultTaskPriority,
+	}}, nil
+}
+
+func TestProjectHandler_UpdateProjectSettings(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		wantStatus int
+		wantFields []string
+	}{
+		{"Both defaults", "/projects/1/settings", `{"default_task_status":"InProgress","default_task_priority":1}`, http.StatusOK, nil},
+		{"Unset defaults", "/projects/1/settings", `{}`, http.StatusOK, nil},
+		{"Unknown status", "/projects/1/settings", `{"default_task_status":"Blocked"}`, http.StatusBadRequest, []string{"default_task_status"}},
+		{"Priority out of range", "/projects/1/settings", `{"default_task_priority":7}`, http.StatusBadRequest, []string{"default_task_priority"}},
+		{"Missing project", "/projects/9/settings", `{"default_task_priority":2}`, http.StatusNotFound, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeProjectClient{projects: map[int64]*pb.Project{1: {Id: 1}}}
+			h := &ProjectHandler{projectClient: client}
+			r := gin.New()
+			r.PUT("/projects/:id/settings", h.UpdateProjectSettings)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPut, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			for _, field := range tt.wantFields {
+				if !strings.Contains(w.Body.String(), `"`+field+`"`) {
+					t.Errorf("body = %s, want an error for %s", w.Body.String(), field)
+				}
+			}
+			if tt.wantStatus == http.StatusBadRequest && client.settingsReq != nil {
+				t.Errorf("project service was called with %v", client.settingsReq)
+			}
+		})
+	}
+}
+
+// CreateWebhook echoes the webhook with a generated secret when none is given
+func (f *fakeProjectClient) CreateWebhook(ctx context.Context, in *pb.CreateWebhookRequest, opts ...grpc.CallOption) (*pb.WebhookResponse, error) {
+	f.webhookReq = in
+	secret := in.Secret
+	if secret == "" {
+		secret = "generated"
+	}
+	return &pb.WebhookResponse{Webhook: &pb.Webhook{Id: 1, Url: in.Url, EventTypes: in.EventTypes, Secret: secret}}, nil
+}
+
+func TestProjectHandler_CreateWebhook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantFields []string
+	}{
+		{"Valid", `{"url":"https://hooks.example.com/in","event_types":["task.created","project.deleted"]}`, http.StatusCreated, nil},
+		{"Own secret", `{"url":"https://hooks.example.com/in","secret":"0123456789abcdef","event_types":["task.updated"]}`, http.StatusCreated, nil},
+		{"Short secret", `{"url":"https://hooks.example.com/in","secret":"short","event_types":["task.updated"]}`, http.StatusBadRequest, []string{"secret"}},
+		{"Bad URL", `{"url":"not a url","event_types":["task.created"]}`, http.StatusBadRequest, []string{"url"}},
+		{"No event types", `{"url":"https://hooks.example.com/in","event_types":[]}`, http.StatusBadRequest, []string{"event_types"}},
+		{"Unknown event type", `{"url":"https://hooks.example.com/in","event_types":["task.created","task.exploded"]}`, http.StatusBadRequest, []string{"event_types[1]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeProjectClient{}
+			h := &ProjectHandler{projectClient: client}
+			r := gin.New()
+			r.POST("/webhooks", h.CreateWebhook)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			for _, field := range tt.wantFields {
+				if !strings.Contains(w.Body.String(), `"`+field+`"`) {
+					t.Errorf("body = %s, want an error for %s", w.Body.String(), field)
+				}
+			}
+			if tt.wantStatus == http.StatusBadRequest && client.webhookReq != nil {
+				t.Errorf("project service was called with %v", client.webhookReq)
+			}
+			if tt.wantStatus == http.StatusCreated && !strings.Contains(w.Body.String(), `"secret"`) {
+				t.Errorf("body = %s, want the secret", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestProjectHandler_CloneProject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		want     int
+		wantName string
+	}{
+		{"Default name", "/projects/3/clone", "", http.StatusCreated, "Portfolio (copy)"},
+		{"Given name", "/projects/3/clone", `{"name":"Portfolio v2"}`, http.StatusCreated, "Portfolio v2"},
+		{"Malformed body", "/projects/3/clone", `{"name":`, http.StatusBadRequest, ""},
+		{"Invalid ID", "/projects/abc/clone", "", http.StatusBadRequest, ""},
+		{"Missing project", "/projects/9/clone", "", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeProjectClient{projects: map[int64]*pb.Project{3: {Id: 3, Name: "Portfolio", Status: "completed"}}}
+			h := &ProjectHandler{projectClient: client}
+			r := gin.New()
+			r.POST("/projects/:id/clone", h.CloneProject)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.want, w.Body.String())
+			}
+			if tt.want != http.StatusCreated {
+				return
+			}
+			var project pb.Project
+			if err := json.Unmarshal(w.Body.Bytes(), &project); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if project.Name != tt.wantName || project.Status != "active" {
+				t.Errorf("clone = %q (%s), want %q (active)", project.Name, project.Status, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestProjectHandler_ListProjects_Include(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeProjectClient{projects: map[int64]*pb.Project{1: {Id: 1, Name: "Portfolio", Status: "active"}}}
+	h := &ProjectHandler{projectClient: client}
+	r := gin.New()
+	r.GET("/projects", h.ListProjects)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/projects?include=skills,%20tech&include=links", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d (%s)", w.Code, w.Body.String())
+	}
+	if want := []string{"skills", "tech", "links"}; !reflect.DeepEqual(client.listReq.Include, want) {
+		t.Errorf("include = %v, want %v", client.listReq.Include, want)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/projects", nil))
+	if client.listReq.Include != nil {
+		t.Errorf("include without the parameter = %v, want none", client.listReq.Include)
+	}
+}
+
+func TestProjectHandler_ListProjects_ByIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeProjectClient{projects: map[int64]*pb.Project{
+		1: {Id: 1, Name: "First"},
+		3: {Id: 3, Name: "Third"},
+	}}
+	h := &ProjectHandler{projectClient: client}
+	r := gin.New()
+	r.GET("/projects", h.ListProjects)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/projects?ids=3,9&ids=1", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (%s)", w.Code, http.StatusOK, w.Body.String())
+	}
+	if got := client.batchReq.Ids; len(got) != 3 || got[0] != 3 || got[1] != 9 || got[2] != 1 {
+		t.Errorf("requested ids = %v, want [3 9 1]", got)
+	}
+	var body struct {
+		Data  []*pb.Project `json:"data"`
+		Total int32         `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Total != 2 || len(body.Data) != 2 || body.Data[0].Id != 3 || body.Data[1].Id != 1 {
+		t.Errorf("data = %v (total %d), want projects 3 then 1", body.Data, body.Total)
+	}
+
+	for _, path := range []string{"/projects?ids=", "/projects?ids=1,x", "/projects?ids=-2"} {
+		client.batchReq = nil
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusBadRequest || client.batchReq != nil {
+			t.Errorf("GET %s status = %d, want %d without a backend call", path, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestProjectHandler_GetProject_ConditionalGet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	updated := time.Date(2024, 5, 1, 12, 30, 15, 500, time.UTC)
+	client := &fakeProjectClient{projects: map[int64]*pb.Project{
+		3: {Id: 3, Name: "Portfolio", UpdatedAt: timestamppb.New(updated)},
+	}}
+	h := &ProjectHandler{projectClient: client}
+	r := gin.New()
+	r.GET("/projects/:id", h.GetProject)
+
+	get := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/projects/3", nil)
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("", "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("status = %d, ETag = %q; want 200 with an ETag", first.Code, etag)
+	}
+	if got := first.Header().Get("Last-Modified"); got != "Wed, 01 May 2024 12:30:15 GMT" {
+		t.Errorf("Last-Modified = %q", got)
+	}
+
+	if w := get("If-None-Match", etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("matching If-None-Match = %d with %d bytes, want a bodiless 304", w.Code, w.Body.Len())
+	}
+	if w := get("If-None-Match", `"stale", W/`+etag); w.Code != http.StatusNotModified {
+		t.Errorf("weak match in a list = %d, want 304", w.Code)
+	}
+	if w := get("If-None-Match", `"stale"`); w.Code != http.StatusOK || w.Body.Len() == 0 {
+		t.Errorf("stale If-None-Match = %d, want 200 with the project", w.Code)
+	}
+	if w := get("If-Modified-Since", first.Header().Get("Last-Modified")); w.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since = Last-Modified gave %d, want 304", w.Code)
+	}
+
+	// Any change to the payload changes the ETag
+	client.projects[3].Name = "Renamed"
+	if w := get("If-None-Match", etag); w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("after a change = %d with ETag %q, want 200 and a new ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
@@ -1,11 +1,63 @@
 package handler
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	"github.com/portfolio/shared/middleware"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// DefaultRequestTimeout is used when no timeout has been set on the request
+const DefaultRequestTimeout = 5 * time.Second
+
+// requestContext returns the context for a backend call made while handling c.
+// It is derived from the request's context, so the call is cancelled when the
+// client disconnects and never outlives an earlier deadline set on the
+// request. The timeout caps it otherwise; it comes from
+// middleware.TimeoutMiddleware so it can be configured globally and
+// overridden per route. The request ID, client IP and the authenticated user
+// are passed on in the gRPC metadata.
+func requestContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	timeout := DefaultRequestTimeout
+	if v, ok := c.Get("request_timeout"); ok {
+		if d, ok := v.(time.Duration); ok && d > 0 {
+			timeout = d
+		}
+	}
+	parent := context.Background()
+	if c.Request != nil {
+		parent = c.Request.Context()
+	}
+	ctx := middleware.WithRequestID(parent, c.GetString("request_id"))
+	if c.Request != nil {
+		ctx = middleware.WithClientIP(ctx, c.ClientIP())
+	}
+	ctx = middleware.WithCaller(ctx, middleware.Caller{UserID: c.GetInt64("user_id"), Role: c.GetString("role")})
+	return context.WithTimeout(ctx, timeout)
+}
+
+// streamContext is requestContext for long-lived streams: it carries the
+// same metadata but has no timeout and ends when the client disconnects
+func streamContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	ctx := middleware.WithRequestID(c.Request.Context(), c.GetString("request_id"))
+	ctx = middleware.WithClientIP(ctx, c.ClientIP())
+	ctx = middleware.WithCaller(ctx, middleware.Caller{UserID: c.GetInt64("user_id"), Role: c.GetString("role")})
+	return context.WithCancel(ctx)
+}
+
 func parseTime(t string) *timestamppb.Timestamp {
 	if t == "" {
 		return nil
@@ -15,4 +67,212 @@ func parseTime(t string) *timestamppb.Timestamp {
 		return nil
 	}
 	return timestamppb.New(parsed)
-}
\ No newline at end of file
+}
+
+// Nullable is a JSON field that tells an omitted key apart from an explicit
+// null: Set is false when the key is missing, and Value is nil for null.
+type Nullable[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// UnmarshalJSON is only called for keys present in the document
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	if bytes.Equal(data, []byte("null")) {
+		n.Value = nil
+		return nil
+	}
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	n.Value = &v
+	return nil
+}
+
+// parsePagination reads the page and limit query parameters. A missing or
+// negative page is page 1. A missing or non-positive limit is passed on as
+// 0; the service listing replaces it with its default page size and lowers
+// one above its maximum to that maximum rather than failing, and reports
+// the page size it applied. A value that is not a number is answered with a
+// 400 and ok is false.
+func parsePagination(c *gin.Context) (page, limit int32, ok bool) {
+	page, ok = parsePageParam(c, "page", 1)
+	if !ok {
+		return 0, 0, false
+	}
+	limit, ok = parsePageParam(c, "limit", 0)
+	if !ok {
+		return 0, 0, false
+	}
+	if page < 1 {
+		page = 1
+	}
+	if limit < 0 {
+		limit = 0
+	}
+	return page, limit, true
+}
+
+// parsePageParam parses one pagination parameter. Values beyond int32 are
+// saturated rather than rejected so they can be clamped like any other.
+func parsePageParam(c *gin.Context, name string, fallback int32) (int32, bool) {
+	raw := c.Query(name)
+	if raw == "" {
+		return fallback, true
+	}
+	v, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil && !errors.Is(err, strconv.ErrRange) {
+		apierror.Respond(c, codes.InvalidArgument, name+" must be an integer")
+		return 0, false
+	}
+	return int32(v), true
+}
+
+// parseIDList reads a list of IDs from the query parameter name. IDs may be
+// comma-separated, repeated, or both. Anything but a positive integer is
+// answered with a 400 and ok is false.
+func parseIDList(c *gin.Context, name string) (ids []int64, ok bool) {
+	for _, value := range c.QueryArray(name) {
+		for _, raw := range strings.Split(value, ",") {
+			id, err := strconv.ParseInt(strings.TrimSpace(raw), 10, 64)
+			if err != nil || id <= 0 {
+				apierror.Respond(c, codes.InvalidArgument, name+" must be positive integers")
+				return nil, false
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
+
+// PaginatedResponse is the envelope returned by every list endpoint
+type PaginatedResponse struct {
+	Data       interface{} `json:"data"`
+	Total      int32       `json:"total"`
+	Page       int32       `json:"page"`
+	Limit      int32       `json:"limit"`
+	TotalPages int32       `json:"total_pages"`
+	HasNext    bool        `json:"has_next"`
+}
+
+// newPaginatedResponse builds the envelope for one page of a list. A nil
+// slice is sent as an empty array so clients always get a list in data.
+func newPaginatedResponse(data interface{}, total, page, limit int32) PaginatedResponse {
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
+		data = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+	totalPages := int32(0)
+	if limit > 0 {
+		totalPages = (total + limit - 1) / limit
+	}
+	return PaginatedResponse{
+		Data:       data,
+		Total:      total,
+		Page:       page,
+		Limit:      limit,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+	}
+}
+
+// respondPaginated writes one page of a list in a PaginatedResponse and sets
+// X-Total-Count
+func respondPaginated(c *gin.Context, data interface{}, total, page, limit int32) {
+	c.Header("X-Total-Count", strconv.Itoa(int(total)))
+	c.JSON(http.StatusOK, newPaginatedResponse(data, total, page, limit))
+}
+
+// CursorResponse is the envelope for lists paged by cursor. There is no total
+// or page count; clients follow next_cursor until has_next is false.
+type CursorResponse struct {
+	Data       interface{} `json:"data"`
+	Limit      int32       `json:"limit"`
+	NextCursor string      `json:"next_cursor"`
+	HasNext    bool        `json:"has_next"`
+}
+
+// respondCursor writes one cursor-paged page of a list in a CursorResponse
+func respondCursor(c *gin.Context, data interface{}, limit int32, nextCursor string) {
+	if v := reflect.ValueOf(data); v.Kind() == reflect.Slice && v.IsNil() {
+		data = reflect.MakeSlice(v.Type(), 0, 0).Interface()
+	}
+	c.JSON(http.StatusOK, CursorResponse{
+		Data:       data,
+		Limit:      limit,
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+	})
+}
+
+// respondConditional writes a single resource as JSON with an ETag hashed from
+// the body, so it changes with anything nested in it and not only with
+// updated_at, and a Last-Modified of modified when it is set. A request
+// whose If-None-Match (or, without one, If-Modified-Since) shows the client
+// already has this version gets a bodiless 304 instead.
+func respondConditional(c *gin.Context, resource interface{}, modifiedAt *timestamppb.Timestamp) {
+	var modified time.Time
+	if modifiedAt != nil {
+		modified = modifiedAt.AsTime()
+	}
+	body, err := json.Marshal(resource)
+	if err != nil {
+		c.JSON(http.StatusOK, resource)
+		return
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Header("ETag", etag)
+	if !modified.IsZero() {
+		c.Header("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+
+	if notModified(c.Request, etag, modified) {
+		c.Status(http.StatusNotModified)
+		c.Writer.WriteHeaderNow()
+		return
+	}
+	c.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// notModified reports whether the request's validators match the current
+// version. If-None-Match takes precedence over If-Modified-Since (RFC 9110).
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+	if modified.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	// HTTP dates have one-second precision
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// ifNoneMatchTags returns the quoted entity tags listed in If-None-Match,
+// without their quotes or weak prefix
+func ifNoneMatchTags(r *http.Request) []string {
+	var tags []string
+	for _, tag := range strings.Split(r.Header.Get("If-None-Match"), ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if len(tag) > 2 && tag[0] == '"' && tag[len(tag)-1] == '"' {
+			tags = append(tags, tag[1:len(tag)-1])
+		}
+	}
+	return tags
+}
+
+// respondList writes a list the backend returns in full as a single page
+func respondList(c *gin.Context, data interface{}, count int) {
+	respondPaginated(c, data, int32(count), 1, int32(count))
+}
@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/middleware"
+	projectpb "github.com/portfolio/proto/project"
+	sharedmw "github.com/portfolio/shared/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestRequestContext_Timeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		set     bool
+		want    time.Duration
+	}{
+		{name: "Configured", timeout: 30 * time.Second, set: true, want: 30 * time.Second},
+		{name: "Upload override", timeout: 2 * time.Minute, set: true, want: 2 * time.Minute},
+		{name: "Default", want: DefaultRequestTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			if tt.set {
+				middleware.TimeoutMiddleware(tt.timeout)(c)
+			}
+
+			start := time.Now()
+			ctx, cancel := requestContext(c)
+			defer cancel()
+
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				t.Fatal("requestContext() has no deadline")
+			}
+			if got := deadline.Sub(start); got < tt.want-time.Second || got > tt.want+time.Second {
+				t.Errorf("requestContext() timeout = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRequestContext_FollowsRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	t.Run("Cancelled", func(t *testing.T) {
+		parent, cancelParent := context.WithCancel(context.Background())
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil).WithContext(parent)
+
+		ctx, cancel := requestContext(c)
+		defer cancel()
+		cancelParent()
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("requestContext() outlived its request")
+		}
+		if !errors.Is(ctx.Err(), context.Canceled) {
+			t.Errorf("ctx.Err() = %v, want context.Canceled", ctx.Err())
+		}
+	})
+
+	t.Run("Earlier deadline", func(t *testing.T) {
+		parent, cancelParent := context.WithTimeout(context.Background(), time.Second)
+		defer cancelParent()
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil).WithContext(parent)
+		middleware.TimeoutMiddleware(time.Minute)(c)
+
+		ctx, cancel := requestContext(c)
+		defer cancel()
+		want, _ := parent.Deadline()
+		if got, ok := ctx.Deadline(); !ok || !got.Equal(want) {
+			t.Errorf("requestContext() deadline = %v, want the request's %v", got, want)
+		}
+	})
+}
+
+// blockingProjectServer holds GetProject calls until they are cancelled
+type blockingProjectServer struct {
+	projectpb.UnimplementedProjectServiceServer
+	started chan struct{}
+	aborted chan error
+}
+
+func (s *blockingProjectServer) GetProject(ctx context.Context, req *projectpb.GetProjectRequest) (*projectpb.ProjectResponse, error) {
+	close(s.started)
+	<-ctx.Done()
+	s.aborted <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func TestRequestContext_ClientDisconnectAbortsCall(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	backend := &blockingProjectServer{started: make(chan struct{}), aborted: make(chan error, 1)}
+	projectpb.RegisterProjectServiceServer(srv, backend)
+	go srv.Serve(lis)
+	defer srv.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	r := gin.New()
+	r.Use(middleware.TimeoutMiddleware(time.Minute))
+	r.GET("/projects/:id", (&ProjectHandler{projectClient: projectpb.NewProjectServiceClient(conn)}).GetProject)
+
+	clientCtx, disconnect := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/projects/1", nil).WithContext(clientCtx))
+	}()
+
+	select {
+	case <-backend.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("GetProject never reached the backend")
+	}
+	disconnect()
+
+	select {
+	case err := <-backend.aborted:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("backend context ended with %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("backend call kept running after the client disconnected")
+	}
+	<-done
+	if w.Code != 499 {
+		t.Errorf("status = %d, want 499 for a closed request", w.Code)
+	}
+}
+
+func TestRequestContext_Caller(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx, cancel := requestContext(c)
+	cancel()
+	if caller, ok := sharedmw.CallerFromContext(ctx); ok {
+		t.Errorf("anonymous request carries caller %+v", caller)
+	}
+
+	c.Set("user_id", int64(7))
+	c.Set("role", "user")
+	ctx, cancel = requestContext(c)
+	defer cancel()
+	if caller, ok := sharedmw.CallerFromContext(ctx); !ok || caller != (sharedmw.Caller{UserID: 7, Role: "user"}) {
+		t.Errorf("requestContext() caller = %+v (found %v), want user 7 with role user", caller, ok)
+	}
+}
+
+func TestNewPaginatedResponse(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int32
+		page        int32
+		limit       int32
+		wantPages   int32
+		wantHasNext bool
+	}{
+		{name: "First of several", total: 45, page: 1, limit: 20, wantPages: 3, wantHasNext: true},
+		{name: "Last partial page", total: 45, page: 3, limit: 20, wantPages: 3, wantHasNext: false},
+		{name: "Exact fit", total: 40, page: 2, limit: 20, wantPages: 2, wantHasNext: false},
+		{name: "Past the end", total: 5, page: 4, limit: 20, wantPages: 1, wantHasNext: false},
+		{name: "Empty", total: 0, page: 1, limit: 0, wantPages: 0, wantHasNext: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newPaginatedResponse([]int{}, tt.total, tt.page, tt.limit)
+			if got.TotalPages != tt.wantPages || got.HasNext != tt.wantHasNext {
+				t.Errorf("total_pages = %d, has_next = %v, want %d, %v", got.TotalPages, got.HasNext, tt.wantPages, tt.wantHasNext)
+			}
+		})
+	}
+}
+
+func TestNewPaginatedResponse_NilSlice(t *testing.T) {
+	var items []string
+	got := newPaginatedResponse(items, 0, 1, 20)
+	if data, ok := got.Data.([]string); !ok || data == nil {
+		t.Errorf("data = %#v, want an empty slice", got.Data)
+	}
+}
+
+func TestParsePagination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		query     string
+		wantPage  int32
+		wantLimit int32
+	}{
+		{name: "Defaults", query: "", wantPage: 1, wantLimit: 0},
+		{name: "Given", query: "?page=3&limit=50", wantPage: 3, wantLimit: 50},
+		{name: "Negative page", query: "?page=-2", wantPage: 1, wantLimit: 0},
+		{name: "Zero limit", query: "?limit=0", wantPage: 1, wantLimit: 0},
+		{name: "Negative limit", query: "?limit=-5", wantPage: 1, wantLimit: 0},
+		{name: "Over max limit is left to the service", query: "?limit=5000", wantPage: 1, wantLimit: 5000},
+		{name: "Beyond int32", query: "?page=99999999999&limit=99999999999", wantPage: math.MaxInt32, wantLimit: math.MaxInt32},
+		{name: "Huge negative", query: "?page=-99999999999&limit=-99999999999", wantPage: 1, wantLimit: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+tt.query, nil)
+
+			page, limit, ok := parsePagination(c)
+			if !ok {
+				t.Fatalf("parsePagination() rejected %q with %d", tt.query, w.Code)
+			}
+			if page != tt.wantPage || limit != tt.wantLimit {
+				t.Errorf("page, limit = %d, %d, want %d, %d", page, limit, tt.wantPage, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestParsePagination_NotANumber(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, query := range []string{"?page=abc", "?limit=ten", "?page=1.5", "?limit=20abc"} {
+		t.Run(query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/"+query, nil)
+
+			if _, _, ok := parsePagination(c); ok {
+				t.Fatalf("parsePagination(%q) accepted a non-numeric value", query)
+			}
+			if w.Code != http.StatusBadRequest || !c.IsAborted() {
+				t.Errorf("status = %d, aborted = %v, want 400 and aborted", w.Code, c.IsAborted())
+			}
+		})
+	}
+}
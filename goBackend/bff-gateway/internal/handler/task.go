@@ -2,27 +2,67 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
 	pb "github.com/portfolio/proto/task"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // TaskHandler handles task endpoints
 type TaskHandler struct {
 	taskClient pb.TaskServiceClient
+	access     *ProjectAccessChecker
 }
 
 // NewTaskHandler creates a new TaskHandler
-func NewTaskHandler(conn *grpc.ClientConn) *TaskHandler {
+func NewTaskHandler(conn, authConn *grpc.ClientConn) *TaskHandler {
 	return &TaskHandler{
 		taskClient: pb.NewTaskServiceClient(conn),
+		access:     NewProjectAccessChecker(authConn),
 	}
 }
 
+// authorizeTask loads a task and checks that the caller holds at least level
+// on its project. On failure it writes the response and returns nil.
+func (h *TaskHandler) authorizeTask(c *gin.Context, ctx context.Context, id int64, level string) *pb.Task {
+	resp, err := h.taskClient.GetTask(ctx, &pb.GetTaskRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			apierror.Respond(c, codes.NotFound, "Task not found")
+			return nil
+		}
+		apierror.RespondGRPC(c, err)
+		return nil
+	}
+
+	if isAdmin(c) {
+		return resp.Task
+	}
+	allowed, err := h.access.HasAccess(ctx, currentUserID(c), resp.Task.ProjectId, level)
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return nil
+	}
+	if !allowed {
+		apierror.Respond(c, codes.PermissionDenied, "Access to this project denied")
+		return nil
+	}
+	return resp.Task
+}
+
 // CreateTaskRequest represents create task request
 type CreateTaskRequest struct {
 	ProjectID   int64  `json:"project_id"`
@@ -40,11 +80,11 @@ type CreateTaskRequest struct {
 func (h *TaskHandler) CreateTask(c *gin.Context) {
 	var req CreateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.taskClient.CreateTask(ctx, &pb.CreateTaskRequest{
@@ -58,7 +98,7 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -71,20 +111,31 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid ID")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
-	resp, err := h.taskClient.GetTask(ctx, &pb.GetTaskRequest{Id: id})
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	task := h.authorizeTask(c, ctx, id, AccessRead)
+	if task == nil {
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Task)
+	respondConditional(c, task, taskModifiedAt(task))
+}
+
+// taskModifiedAt is when the task or, if later, one of its subtasks last
+// changed, since subtask edits don't touch the task's own updated_at
+func taskModifiedAt(task *pb.Task) *timestamppb.Timestamp {
+	latest := task.UpdatedAt
+	for _, s := range task.Subtasks {
+		if s.UpdatedAt != nil && (latest == nil || s.UpdatedAt.AsTime().After(latest.AsTime())) {
+			latest = s.UpdatedAt
+		}
+	}
+	return latest
 }
 
 // UpdateTask updates a task
@@ -93,19 +144,23 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid ID")
 		return
 	}
 
 	var req CreateTaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
+	if h.authorizeTask(c, ctx, id, AccessWrite) == nil {
+		return
+	}
+
 	resp, err := h.taskClient.UpdateTask(ctx, &pb.UpdateTaskRequest{
 		Id:          id,
 		Title:       req.Title,
@@ -117,7 +172,107 @@ func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Task)
+}
+
+// PatchTaskRequest updates only the fields present in the body. assigned_to
+// and due_date may be null to unset them, and description null or "" to
+// empty it.
+type PatchTaskRequest struct {
+	Title       *string          `json:"title"`
+	Description Nullable[string] `json:"description"`
+	Status      *string          `json:"status"`
+	Priority    *int32           `json:"priority"`
+	AssignedTo  Nullable[int64]  `json:"assigned_to"`
+	DueDate     Nullable[string] `json:"due_date"`
+}
+
+// toProto validates the patch and converts it to an update request
+func (p *PatchTaskRequest) toProto(id int64) (*pb.UpdateTaskRequest, string) {
+	if p.Title == nil && !p.Description.Set && p.Status == nil && p.Priority == nil &&
+		!p.AssignedTo.Set && !p.DueDate.Set {
+		return nil, "no fields to update"
+	}
+
+	req := &pb.UpdateTaskRequest{Id: id}
+	if p.Title != nil {
+		if strings.TrimSpace(*p.Title) == "" {
+			return nil, "title must not be empty"
+		}
+		req.Title = *p.Title
+	}
+	if p.Description.Set {
+		if p.Description.Value == nil || *p.Description.Value == "" {
+			req.ClearDescription = true
+		} else {
+			req.Description = *p.Description.Value
+		}
+	}
+	if p.Status != nil {
+		if *p.Status == "" {
+			return nil, "status must not be empty"
+		}
+		req.Status = *p.Status
+	}
+	if p.Priority != nil {
+		if *p.Priority < 1 {
+			return nil, "priority must be positive"
+		}
+		req.Priority = *p.Priority
+	}
+	if p.AssignedTo.Set {
+		if p.AssignedTo.Value == nil {
+			req.ClearAssignedTo = true
+		} else if *p.AssignedTo.Value <= 0 {
+			return nil, "assigned_to must be a user id or null"
+		} else {
+			req.AssignedTo = *p.AssignedTo.Value
+		}
+	}
+	if p.DueDate.Set {
+		if p.DueDate.Value == nil {
+			req.ClearDueDate = true
+		} else if req.DueDate = parseTime(*p.DueDate.Value); req.DueDate == nil {
+			return nil, "due_date must be an RFC 3339 timestamp or null"
+		}
+	}
+	return req, ""
+}
+
+// PatchTask updates the fields present in the body and leaves the rest as they are
+// PATCH /api/tasks/:id
+func (h *TaskHandler) PatchTask(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid ID")
+		return
+	}
+
+	var req PatchTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	update, msg := req.toProto(id)
+	if update == nil {
+		apierror.Respond(c, codes.InvalidArgument, msg)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, id, AccessWrite) == nil {
+		return
+	}
+
+	resp, err := h.taskClient.UpdateTask(ctx, update)
+	if err != nil {
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -130,50 +285,438 @@ func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid ID")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
+	if h.authorizeTask(c, ctx, id, AccessWrite) == nil {
+		return
+	}
+
 	_, err = h.taskClient.DeleteTask(ctx, &pb.DeleteTaskRequest{Id: id})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
 }
 
+// BulkDeleteTasksRequest represents bulk delete tasks request
+type BulkDeleteTasksRequest struct {
+	IDs []int64 `json:"ids" binding:"required,min=1"`
+}
+
+// BulkDeleteTasks deletes several tasks at once
+// DELETE /api/tasks/bulk
+func (h *TaskHandler) BulkDeleteTasks(c *gin.Context) {
+	var req BulkDeleteTasksRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	// Every task must be writable, or nothing is deleted
+	for _, id := range req.IDs {
+		if h.authorizeTask(c, ctx, id, AccessWrite) == nil {
+			return
+		}
+	}
+
+	resp, err := h.taskClient.BulkDeleteTasks(ctx, &pb.BulkDeleteTasksRequest{Ids: req.IDs})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": resp.Deleted})
+}
+
+// DemoteTask converts a task into a subtask of another task
+// POST /api/tasks/:id/demote
+func (h *TaskHandler) DemoteTask(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid ID")
+		return
+	}
+
+	var req struct {
+		ParentTaskID int64 `json:"parent_task_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	// The caller needs write access to both the task and its new parent
+	if h.authorizeTask(c, ctx, id, AccessWrite) == nil {
+		return
+	}
+	if h.authorizeTask(c, ctx, req.ParentTaskID, AccessWrite) == nil {
+		return
+	}
+
+	resp, err := h.taskClient.DemoteTask(ctx, &pb.DemoteTaskRequest{
+		Id:           id,
+		ParentTaskId: req.ParentTaskID,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Subtask)
+}
+
+// DuplicateTask copies a task with its subtasks and tags. The copy starts as
+// Todo without a due date unless ?keep_due_date=true is given.
+// POST /api/tasks/:id/duplicate
+func (h *TaskHandler) DuplicateTask(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	var query struct {
+		KeepDueDate bool `form:"keep_due_date"`
+	}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, req.ID, AccessWrite) == nil {
+		return
+	}
+
+	resp, err := h.taskClient.DuplicateTask(ctx, &pb.DuplicateTaskRequest{
+		Id:          req.ID,
+		KeepDueDate: query.KeepDueDate,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Task)
+}
+
+// MoveTaskRequest represents move task request
+type MoveTaskRequest struct {
+	ProjectID      int64 `json:"project_id" binding:"required,gt=0"`
+	ClearAssignees bool  `json:"clear_assignees"`
+}
+
+// MoveTask moves a task with its subtasks, comments and tags to another
+// project. The caller needs write access to both projects.
+// POST /api/tasks/:id/move
+func (h *TaskHandler) MoveTask(c *gin.Context) {
+	var uri struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uri); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	var req MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, uri.ID, AccessWrite) == nil {
+		return
+	}
+	if !isAdmin(c) {
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), req.ProjectID, AccessWrite)
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		if !allowed {
+			apierror.Respond(c, codes.PermissionDenied, "Access to the target project denied")
+			return
+		}
+	}
+
+	resp, err := h.taskClient.MoveTask(ctx, &pb.MoveTaskRequest{
+		Id:             uri.ID,
+		ProjectId:      req.ProjectID,
+		ClearAssignees: req.ClearAssignees,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Task)
+}
+
 // ListTasks returns list of tasks
 // GET /api/tasks
 func (h *TaskHandler) ListTasks(c *gin.Context) {
-	// page := c.DefaultQuery("page", "1")
-	// limit := c.DefaultQuery("limit", "10")
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
 	status := c.Query("status")
 	projectIDStr := c.Query("project_id")
 	var projectID int64
 	if projectIDStr != "" {
 		projectID, _ = strconv.ParseInt(projectIDStr, 10, 64)
 	}
+	var tagID int64
+	if tagIDStr := c.Query("tag_id"); tagIDStr != "" {
+		var err error
+		if tagID, err = strconv.ParseInt(tagIDStr, 10, 64); err != nil || tagID <= 0 {
+			apierror.Respond(c, codes.InvalidArgument, "tag_id must be a positive integer")
+			return
+		}
+	}
+	tagIDs, ok := parseIDList(c, "tags")
+	if !ok {
+		return
+	}
+	matchAnyTag, ok := parseTagMode(c)
+	if !ok {
+		return
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
-	resp, err := h.taskClient.ListTasks(ctx, &pb.ListTasksRequest{
-		ProjectId: projectID,
-		Page:      1,
-		Limit:     100, // fetching more for now
-		Status:    status,
+	req := &pb.ListTasksRequest{
+		ProjectId:   projectID,
+		Page:        page,
+		Limit:       limit,
+		Status:      status,
+		TagId:       tagID,
+		Tag:         c.Query("tag"),
+		TagIds:      tagIDs,
+		MatchAnyTag: matchAnyTag,
+	}
+	// ?cursor= (even empty) switches to keyset pagination; page is ignored
+	cursor, useCursor := c.GetQuery("cursor")
+	if useCursor {
+		req.Cursor = &cursor
+	}
+	resp, err := h.taskClient.ListTasks(ctx, req)
+
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	if useCursor {
+		respondCursor(c, resp.Tasks, resp.Limit, resp.NextCursor)
+		return
+	}
+	respondPaginated(c, resp.Tasks, resp.Total, page, resp.Limit)
+}
+
+// parseTagMode reads ?tag_mode=all|any, reporting whether tasks may match any
+// of the requested tags. On failure it writes the response and returns false.
+func parseTagMode(c *gin.Context) (matchAny bool, ok bool) {
+	switch c.DefaultQuery("tag_mode", "all") {
+	case "all":
+		return false, true
+	case "any":
+		return true, true
+	}
+	apierror.Respond(c, codes.InvalidArgument, "tag_mode must be all or any")
+	return false, false
+}
+
+// TaskSummaryResponse counts a project's tasks for the board. ByStatus has
+// every status, including those without tasks; overdue tasks are past their
+// due date and not done.
+type TaskSummaryResponse struct {
+	ProjectID  int64            `json:"project_id"`
+	Total      int32            `json:"total"`
+	ByStatus   map[string]int32 `json:"by_status"`
+	Overdue    int32            `json:"overdue"`
+	Unassigned int32            `json:"unassigned"`
+}
+
+// GetTaskSummary returns a project's task counts per status, and how many
+// are overdue or unassigned, without listing the tasks
+// GET /api/projects/:id/tasks/summary
+func (h *TaskHandler) GetTaskSummary(c *gin.Context) {
+	projectID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || projectID <= 0 {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Project ID")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if !isAdmin(c) {
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), projectID, AccessRead)
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		if !allowed {
+			apierror.Respond(c, codes.PermissionDenied, "Access to this project denied")
+			return
+		}
+	}
+
+	resp, err := h.taskClient.GetTaskSummary(ctx, &pb.GetTaskSummaryRequest{ProjectId: projectID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, TaskSummaryResponse{
+		ProjectID:  resp.ProjectId,
+		Total:      resp.Total,
+		ByStatus:   resp.StatusCounts,
+		Overdue:    resp.Overdue,
+		Unassigned: resp.Unassigned,
 	})
+}
+
+// ExportTasks streams every matching task of a project as newline-delimited
+// JSON, one task per line, without paging. Filters match ListTasks.
+// GET /api/tasks/export?project_id=
+func (h *TaskHandler) ExportTasks(c *gin.Context) {
+	projectID, err := strconv.ParseInt(c.Query("project_id"), 10, 64)
+	if err != nil || projectID <= 0 {
+		apierror.Respond(c, codes.InvalidArgument, "project_id must be a positive integer")
+		return
+	}
+	var assignedTo int64
+	if v := c.Query("assigned_to"); v != "" {
+		if assignedTo, err = strconv.ParseInt(v, 10, 64); err != nil || assignedTo <= 0 {
+			apierror.Respond(c, codes.InvalidArgument, "assigned_to must be a positive integer")
+			return
+		}
+	}
+	tagIDs, ok := parseIDList(c, "tags")
+	if !ok {
+		return
+	}
+	matchAnyTag, ok := parseTagMode(c)
+	if !ok {
+		return
+	}
+
+	if !isAdmin(c) {
+		ctx, cancel := requestContext(c)
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), projectID, AccessRead)
+		cancel()
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		if !allowed {
+			apierror.Respond(c, codes.PermissionDenied, "Access to this project denied")
+			return
+		}
+	}
+
+	ctx, cancel := streamContext(c)
+	defer cancel()
+	stream, err := h.taskClient.StreamTasks(ctx, &pb.StreamTasksRequest{
+		ProjectId:   projectID,
+		Status:      c.Query("status"),
+		AssignedTo:  assignedTo,
+		TagIds:      tagIDs,
+		Tag:         c.Query("tag"),
+		MatchAnyTag: matchAnyTag,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	// Errors before the first batch can still get a proper status
+	batch, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "application/x-ndjson")
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="project-%d-tasks.ndjson"`, projectID))
+	c.Status(http.StatusOK)
+	enc := json.NewEncoder(c.Writer)
+	for err == nil {
+		for _, task := range batch.Tasks {
+			if err := enc.Encode(task); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+		batch, err = stream.Recv()
+	}
+	if err != io.EOF {
+		// Headers are gone, so a truncated export is all the client sees
+		slog.ErrorContext(ctx, "task export ended early", "project_id", projectID, "error", err)
+	}
+}
+
+// TaskVersionResponse is the minimal task payload used for cache sync
+type TaskVersionResponse struct {
+	ID        int64     `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ListTaskIDs returns only the id and updated_at of a project's tasks so
+// clients can diff their cache and fetch just the changed ones
+// GET /api/tasks/ids?project_id=1
+func (h *TaskHandler) ListTaskIDs(c *gin.Context) {
+	projectID, err := strconv.ParseInt(c.Query("project_id"), 10, 64)
+	if err != nil || projectID <= 0 {
+		apierror.Respond(c, codes.InvalidArgument, "project_id is required")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if !isAdmin(c) {
+		allowed, err := h.access.HasAccess(ctx, currentUserID(c), projectID, AccessRead)
+		if err != nil {
+			apierror.RespondGRPC(c, err)
+			return
+		}
+		if !allowed {
+			apierror.Respond(c, codes.PermissionDenied, "Access to this project denied")
+			return
+		}
+	}
 
+	resp, err := h.taskClient.ListTaskIDs(ctx, &pb.ListTaskIDsRequest{ProjectId: projectID})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Tasks)
+	versions := make([]TaskVersionResponse, 0, len(resp.Tasks))
+	for _, t := range resp.Tasks {
+		versions = append(versions, TaskVersionResponse{ID: t.Id, UpdatedAt: t.UpdatedAt.AsTime()})
+	}
+	respondList(c, versions, len(versions))
 }
 
 // CreateSubtask creates a new subtask
@@ -182,7 +725,7 @@ func (h *TaskHandler) CreateSubtask(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
 		return
 	}
 
@@ -192,11 +735,11 @@ func (h *TaskHandler) CreateSubtask(c *gin.Context) {
 		DueDate    string `json:"due_date"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.taskClient.CreateSubtask(ctx, &pb.CreateSubtaskRequest{
@@ -207,33 +750,160 @@ func (h *TaskHandler) CreateSubtask(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, resp.Subtask)
 }
 
+// CreateSubtasks creates several subtasks at once, appended in the given order
+// POST /api/tasks/:id/subtasks/bulk
+func (h *TaskHandler) CreateSubtasks(c *gin.Context) {
+	taskIDStr := c.Param("id")
+	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+
+	var req struct {
+		Titles []string `json:"titles" binding:"required,min=1"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, taskID, AccessWrite) == nil {
+		return
+	}
+
+	resp, err := h.taskClient.CreateSubtasks(ctx, &pb.CreateSubtasksRequest{
+		TaskId: taskID,
+		Titles: req.Titles,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Subtasks)
+}
+
 // ListSubtasks returns list of subtasks
 // GET /api/tasks/:id/subtasks
 func (h *TaskHandler) ListSubtasks(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+
+	page, limit, ok := parsePagination(c)
+	if !ok {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
-	resp, err := h.taskClient.ListSubtasks(ctx, &pb.ListSubtasksRequest{TaskId: taskID})
+	resp, err := h.taskClient.ListSubtasks(ctx, &pb.ListSubtasksRequest{
+		TaskId: taskID,
+		Page:   page,
+		Limit:  limit,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Subtasks)
+	respondPaginated(c, resp.Subtasks, resp.Total, page, resp.Limit)
+}
+
+// MoveSubtask moves a subtask under another task
+// PATCH /api/tasks/:id/subtasks/:subtaskId/move
+func (h *TaskHandler) MoveSubtask(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+	subtaskID, err := strconv.ParseInt(c.Param("subtaskId"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Subtask ID")
+		return
+	}
+
+	var req struct {
+		TaskID int64 `json:"task_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	// The caller needs write access to both tasks' projects
+	if h.authorizeTask(c, ctx, taskID, AccessWrite) == nil {
+		return
+	}
+	if h.authorizeTask(c, ctx, req.TaskID, AccessWrite) == nil {
+		return
+	}
+
+	// The task service refuses subtasks that aren't under the task in the path
+	resp, err := h.taskClient.MoveSubtask(ctx, &pb.MoveSubtaskRequest{
+		Id:         subtaskID,
+		TaskId:     req.TaskID,
+		FromTaskId: taskID,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp.Subtask)
+}
+
+// PromoteSubtask converts a subtask into a task
+// POST /api/tasks/:id/subtasks/:subtaskId/promote
+func (h *TaskHandler) PromoteSubtask(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+	subtaskID, err := strconv.ParseInt(c.Param("subtaskId"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Subtask ID")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, taskID, AccessWrite) == nil {
+		return
+	}
+
+	// The task service refuses subtasks that aren't under the task in the path
+	resp, err := h.taskClient.PromoteSubtask(ctx, &pb.PromoteSubtaskRequest{
+		Id:     subtaskID,
+		TaskId: taskID,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp.Task)
 }
 
 // AddComment adds a comment to task
@@ -242,7 +912,7 @@ func (h *TaskHandler) AddComment(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
 		return
 	}
 
@@ -250,32 +920,21 @@ func (h *TaskHandler) AddComment(c *gin.Context) {
 		Comment string `json:"comment" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	userID, _ := c.Get("user_id") // Assuming set by middleware
-	var uid int64
-	// Handle both float64 (json default) and int
-	if v, ok := userID.(float64); ok {
-		uid = int64(v)
-	} else if v, ok := userID.(int); ok {
-		uid = int64(v)
-	} else if v, ok := userID.(int64); ok {
-		uid = v
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	// The task service takes the author from the caller in the metadata
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.taskClient.AddComment(ctx, &pb.AddCommentRequest{
 		TaskId:  taskID,
-		UserId:  uid,
 		Comment: req.Comment,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -288,20 +947,54 @@ func (h *TaskHandler) ListComments(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+
+	page, limit, ok := parsePagination(c)
+	if !ok {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
-	resp, err := h.taskClient.ListComments(ctx, &pb.ListCommentsRequest{TaskId: taskID})
+	resp, err := h.taskClient.ListComments(ctx, &pb.ListCommentsRequest{
+		TaskId: taskID,
+		Page:   page,
+		Limit:  limit,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Comments)
+	respondPaginated(c, resp.Comments, resp.Total, page, resp.Limit)
+}
+
+// DeleteComments deletes every comment on a task (admin only)
+// DELETE /api/tasks/:id/comments
+func (h *TaskHandler) DeleteComments(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, taskID, AccessWrite) == nil {
+		return
+	}
+
+	resp, err := h.taskClient.DeleteTaskComments(ctx, &pb.DeleteTaskCommentsRequest{TaskId: taskID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"deleted": resp.Deleted})
 }
 
 // AddAttachment adds attachment to task
@@ -310,28 +1003,31 @@ func (h *TaskHandler) AddAttachment(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
 		return
 	}
 
+	// Either an external link or the ID of a file uploaded via /api/media/upload
 	var req struct {
-		FileURL string `json:"file_url" binding:"required"`
+		FileURL     string `json:"file_url" binding:"required_without=MediaFileID,excluded_with=MediaFileID"`
+		MediaFileID int64  `json:"media_file_id"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.taskClient.AddAttachment(ctx, &pb.AddAttachmentRequest{
-		TaskId:  taskID,
-		FileUrl: req.FileURL,
+		TaskId:      taskID,
+		FileUrl:     req.FileURL,
+		MediaFileId: req.MediaFileID,
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -344,20 +1040,54 @@ func (h *TaskHandler) ListAttachments(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	page, limit, ok := parsePagination(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	resp, err := h.taskClient.ListAttachments(ctx, &pb.ListAttachmentsRequest{
+		TaskId: taskID,
+		Page:   page,
+		Limit:  limit,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	respondPaginated(c, resp.Attachments, resp.Total, page, resp.Limit)
+}
+
+// DeleteAttachments deletes every attachment on a task (admin only)
+// DELETE /api/tasks/:id/attachments
+func (h *TaskHandler) DeleteAttachments(c *gin.Context) {
+	taskID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
-	resp, err := h.taskClient.ListAttachments(ctx, &pb.ListAttachmentsRequest{TaskId: taskID})
+	if h.authorizeTask(c, ctx, taskID, AccessWrite) == nil {
+		return
+	}
+
+	resp, err := h.taskClient.DeleteTaskAttachments(ctx, &pb.DeleteTaskAttachmentsRequest{TaskId: taskID})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, resp.Attachments)
+	c.JSON(http.StatusOK, gin.H{"deleted": resp.Deleted})
 }
 
 // AddTag adds a tag to task
@@ -374,16 +1104,16 @@ func (h *TaskHandler) CreateTag(c *gin.Context) {
 		Name string `json:"name" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.taskClient.CreateTag(ctx, &pb.CreateTagRequest{Name: req.Name})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
@@ -393,15 +1123,15 @@ func (h *TaskHandler) CreateTag(c *gin.Context) {
 // ListTags returns all tags
 // GET /api/tags
 func (h *TaskHandler) ListTags(c *gin.Context) {
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	resp, err := h.taskClient.ListTags(ctx, &pb.Empty{})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, resp.Tags)
+	respondList(c, resp.Tags, len(resp.Tags))
 }
 
 // AddTag implementation
@@ -409,7 +1139,7 @@ func (h *TaskHandler) AddTag(c *gin.Context) {
 	taskIDStr := c.Param("id")
 	taskID, err := strconv.ParseInt(taskIDStr, 10, 64)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Task ID"})
+		apierror.Respond(c, codes.InvalidArgument, "Invalid Task ID")
 		return
 	}
 
@@ -417,11 +1147,11 @@ func (h *TaskHandler) AddTag(c *gin.Context) {
 		TagID int64 `json:"tag_id" binding:"required"`
 	}
 	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		apierror.RespondBinding(c, err)
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := requestContext(c)
 	defer cancel()
 
 	_, err = h.taskClient.AddTaskTag(ctx, &pb.AddTaskTagRequest{
@@ -430,9 +1160,160 @@ func (h *TaskHandler) AddTag(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		apierror.RespondGRPC(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{"message": "Tag added to task"})
 }
+
+// RemoveTag removes a tag from a task
+// DELETE /api/tasks/:id/tags/:tagId
+func (h *TaskHandler) RemoveTag(c *gin.Context) {
+	var req struct {
+		TaskID int64 `uri:"id" binding:"required"`
+		TagID  int64 `uri:"tagId" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, req.TaskID, AccessWrite) == nil {
+		return
+	}
+
+	_, err := h.taskClient.RemoveTaskTag(ctx, &pb.RemoveTaskTagRequest{
+		TaskId: req.TaskID,
+		TagId:  req.TagID,
+	})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag removed from task"})
+}
+
+// AddWatcher makes the current user, or with user_id (admins only) another
+// user, a watcher of a task. Watching a task again is not an error.
+// POST /api/tasks/:id/watchers
+func (h *TaskHandler) AddWatcher(c *gin.Context) {
+	var uri struct {
+		TaskID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&uri); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+	var req struct {
+		UserID int64 `json:"user_id"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	// The task service defaults the watcher to the caller in the metadata
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, uri.TaskID, AccessRead) == nil {
+		return
+	}
+
+	_, err := h.taskClient.AddWatcher(ctx, &pb.AddWatcherRequest{TaskId: uri.TaskID, UserId: req.UserID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Watching task"})
+}
+
+// RemoveWatcher stops a user watching a task; users with read access may
+// remove themselves, and those with write access anyone. Removing a user who
+// isn't watching is not an error.
+// DELETE /api/tasks/:id/watchers/:userId
+func (h *TaskHandler) RemoveWatcher(c *gin.Context) {
+	var req struct {
+		TaskID int64 `uri:"id" binding:"required"`
+		UserID int64 `uri:"userId" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	level := AccessRead
+	if req.UserID != currentUserID(c) {
+		level = AccessWrite
+	}
+	if h.authorizeTask(c, ctx, req.TaskID, level) == nil {
+		return
+	}
+
+	_, err := h.taskClient.RemoveWatcher(ctx, &pb.RemoveWatcherRequest{TaskId: req.TaskID, UserId: req.UserID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Stopped watching task"})
+}
+
+// ListWatchers returns the users watching a task, longest watching first
+// GET /api/tasks/:id/watchers
+func (h *TaskHandler) ListWatchers(c *gin.Context) {
+	var req struct {
+		TaskID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if h.authorizeTask(c, ctx, req.TaskID, AccessRead) == nil {
+		return
+	}
+
+	resp, err := h.taskClient.ListWatchers(ctx, &pb.ListWatchersRequest{TaskId: req.TaskID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	respondList(c, resp.Watchers, len(resp.Watchers))
+}
+
+// DeleteTag deletes a tag and removes it from every task (admin only)
+// DELETE /api/tags/:id
+func (h *TaskHandler) DeleteTag(c *gin.Context) {
+	var req struct {
+		ID int64 `uri:"id" binding:"required"`
+	}
+	if err := c.ShouldBindUri(&req); err != nil {
+		apierror.RespondBinding(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	_, err := h.taskClient.DeleteTag(ctx, &pb.DeleteTagRequest{Id: req.ID})
+	if err != nil {
+		apierror.RespondGRPC(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+}
@@ -0,0 +1,1493 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/middleware"
+	authpb "github.com/portfolio/proto/auth"
+	pb "github.com/portfolio/proto/task"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// adminOnly grants no permissions to any role, leaving only the admin superuser
+var adminOnly = middleware.NewRolePermissions(func(ctx context.Context) (map[string][]string, error) {
+	return map[string][]string{}, nil
+}, time.Minute)
+
+// fakeTaskClient stubs the task service; unimplemented methods panic
+type fakeTaskClient struct {
+	pb.TaskServiceClient
+	versions  []*pb.TaskVersion
+	gotReq    *pb.ListTaskIDsRequest
+	gotList   *pb.ListTasksRequest
+	gotUpdate *pb.UpdateTaskRequest
+	tasks     map[int64]*pb.Task
+	updated   bool
+	deleted   bool
+	titles    []string
+	cleared   []string
+	gotRemove *pb.RemoveTaskTagRequest
+	gotTagDel int64
+	gotCopy   *pb.DuplicateTaskRequest
+	gotMove   *pb.MoveTaskRequest
+	gotMoveST *pb.MoveSubtaskRequest
+	gotBulk   []int64
+	gotDemote *pb.DemoteTaskRequest
+	gotPromo  *pb.PromoteSubtaskRequest
+	watched   *pb.AddWatcherRequest
+	unwatched *pb.RemoveWatcherRequest
+}
+
+func (f *fakeTaskClient) GetTask(ctx context.Context, in *pb.GetTaskRequest, opts ...grpc.CallOption) (*pb.TaskResponse, error) {
+	task, ok := f.tasks[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &pb.TaskResponse{Task: task}, nil
+}
+
+func (f *fakeTaskClient) UpdateTask(ctx context.Context, in *pb.UpdateTaskRequest, opts ...grpc.CallOption) (*pb.TaskResponse, error) {
+	f.updated = true
+	f.gotUpdate = in
+	return &pb.TaskResponse{Task: f.tasks[in.Id]}, nil
+}
+
+func (f *fakeTaskClient) DeleteTask(ctx context.Context, in *pb.DeleteTaskRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	f.deleted = true
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeTaskClient) DeleteTaskComments(ctx context.Context, in *pb.DeleteTaskCommentsRequest, opts ...grpc.CallOption) (*pb.DeleteTaskCommentsResponse, error) {
+	f.cleared = append(f.cleared, "comments")
+	return &pb.DeleteTaskCommentsResponse{Deleted: 3}, nil
+}
+
+func (f *fakeTaskClient) DeleteTaskAttachments(ctx context.Context, in *pb.DeleteTaskAttachmentsRequest, opts ...grpc.CallOption) (*pb.DeleteTaskAttachmentsResponse, error) {
+	f.cleared = append(f.cleared, "attachments")
+	return &pb.DeleteTaskAttachmentsResponse{Deleted: 2}, nil
+}
+
+// fakeAuthClient serves project access from a fixed list
+type fakeAuthClient struct {
+	authpb.AuthServiceClient
+	accesses []*authpb.UserProjectAccess
+}
+
+func (f *fakeAuthClient) GetUserProjectAccess(ctx context.Context, in *authpb.GetUserProjectAccessRequest, opts ...grpc.CallOption) (*authpb.UserProjectAccessResponse, error) {
+	var result []*authpb.UserProjectAccess
+	for _, a := range f.accesses {
+		if a.UserId == in.UserId {
+			result = append(result, a)
+		}
+	}
+	return &authpb.UserProjectAccessResponse{Accesses: result}, nil
+}
+
+// CheckAccess mirrors the auth service: admin includes write, and write read
+func (f *fakeAuthClient) CheckAccess(ctx context.Context, in *authpb.CheckAccessRequest, opts ...grpc.CallOption) (*authpb.CheckAccessResponse, error) {
+	rank := map[string]int{AccessRead: 1, AccessWrite: 2, AccessAdmin: 3}
+	for _, a := range f.accesses {
+		if a.UserId == in.UserId && a.ProjectId == in.ProjectId {
+			return &authpb.CheckAccessResponse{AccessLevel: a.AccessLevel, Allowed: rank[a.AccessLevel] >= rank[in.MinLevel]}, nil
+		}
+	}
+	return &authpb.CheckAccessResponse{}, nil
+}
+
+func (f *fakeTaskClient) ListTasks(ctx context.Context, in *pb.ListTasksRequest, opts ...grpc.CallOption) (*pb.ListTasksResponse, error) {
+	f.gotList = in
+	const total = 45
+	limit := pagination.Sizes{}.Limit(int(in.Limit))
+	var tasks []*pb.Task
+	if in.Cursor != nil {
+		// The fake's cursor is just the last id seen
+		after, _ := strconv.ParseInt(in.GetCursor(), 10, 64)
+		for id := after + 1; id <= total && len(tasks) < limit; id++ {
+			tasks = append(tasks, &pb.Task{Id: id})
+		}
+		var next string
+		if n := len(tasks); n > 0 && tasks[n-1].Id < total {
+			next = strconv.FormatInt(tasks[n-1].Id, 10)
+		}
+		return &pb.ListTasksResponse{Tasks: tasks, NextCursor: next, Limit: int32(limit)}, nil
+	}
+	for id := int64((int(in.Page)-1)*limit + 1); id <= total && len(tasks) < limit; id++ {
+		tasks = append(tasks, &pb.Task{Id: id})
+	}
+	return &pb.ListTasksResponse{Tasks: tasks, Total: total, Limit: int32(limit)}, nil
+}
+
+func TestTaskHandler_ListTasks_Envelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &TaskHandler{taskClient: &fakeTaskClient{}}
+	r := gin.New()
+	r.GET("/tasks", h.ListTasks)
+
+	tests := []struct {
+		query       string
+		wantPage    float64
+		wantLimit   float64
+		wantLen     int
+		wantHasNext bool
+	}{
+		{query: "", wantPage: 1, wantLimit: 20, wantLen: 20, wantHasNext: true},
+		{query: "?page=2&limit=20", wantPage: 2, wantLimit: 20, wantLen: 20, wantHasNext: true},
+		{query: "?page=3&limit=20", wantPage: 3, wantLimit: 20, wantLen: 5, wantHasNext: false},
+		{query: "?page=9&limit=10", wantPage: 9, wantLimit: 10, wantLen: 0, wantHasNext: false},
+		{query: "?limit=500", wantPage: 1, wantLimit: 100, wantLen: 45, wantHasNext: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks"+tt.query, nil))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+
+			var body map[string]interface{}
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatalf("invalid JSON: %v", err)
+			}
+			for _, key := range []string{"data", "total", "page", "limit", "has_next"} {
+				if _, ok := body[key]; !ok {
+					t.Errorf("envelope is missing %q: %s", key, w.Body.String())
+				}
+			}
+			data, ok := body["data"].([]interface{})
+			if !ok {
+				t.Fatalf("data = %v, want an array", body["data"])
+			}
+			if len(data) != tt.wantLen || body["total"] != float64(45) || body["page"] != tt.wantPage ||
+				body["limit"] != tt.wantLimit || body["has_next"] != tt.wantHasNext {
+				t.Errorf("envelope = %d items, total %v, page %v, limit %v, has_next %v",
+					len(data), body["total"], body["page"], body["limit"], body["has_next"])
+			}
+		})
+	}
+}
+
+func TestTaskHandler_ListTasks_TagFilter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeTaskClient{}
+	h := &TaskHandler{taskClient: client}
+	r := gin.New()
+	r.GET("/tasks", h.ListTasks)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks?tag=backend&tag_id=3", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if client.gotList.Tag != "backend" || client.gotList.TagId != 3 {
+		t.Errorf("forwarded tag = %q, tag_id = %d, want backend and 3", client.gotList.Tag, client.gotList.TagId)
+	}
+
+	if len(client.gotList.TagIds) != 0 || client.gotList.MatchAnyTag {
+		t.Errorf("forwarded tag_ids = %v, any = %v; want none and all", client.gotList.TagIds, client.gotList.MatchAnyTag)
+	}
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks?tags=1,2&tags=5&tag_mode=any", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("tags status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := client.gotList.TagIds; len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 5 || !client.gotList.MatchAnyTag {
+		t.Errorf("forwarded tag_ids = %v, any = %v; want [1 2 5] and any", got, client.gotList.MatchAnyTag)
+	}
+
+	for _, query := range []string{"tag_id=abc", "tags=1,x", "tags=0", "tags=1&tag_mode=some"} {
+		client.gotList = nil
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s status = %d, want %d", query, w.Code, http.StatusBadRequest)
+		}
+		if client.gotList != nil {
+			t.Errorf("%s should not reach the task service", query)
+		}
+	}
+}
+
+func TestTaskHandler_ListTasks_Cursor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeTaskClient{}
+	h := &TaskHandler{taskClient: client}
+	r := gin.New()
+	r.GET("/tasks", h.ListTasks)
+
+	var seen int
+	next := ""
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("cursor never reached the last page")
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks?limit=20&page=3&cursor="+next, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		if client.gotList.Cursor == nil || client.gotList.GetCursor() != next {
+			t.Fatalf("forwarded cursor = %v, want %q", client.gotList.Cursor, next)
+		}
+
+		var body CursorResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("invalid JSON: %v", err)
+		}
+		data, _ := body.Data.([]interface{})
+		seen += len(data)
+		if body.HasNext != (body.NextCursor != "") {
+			t.Errorf("has_next = %v with next_cursor %q", body.HasNext, body.NextCursor)
+		}
+		if !body.HasNext {
+			break
+		}
+		next = body.NextCursor
+	}
+	if seen != 45 {
+		t.Errorf("walked %d tasks, want all 45", seen)
+	}
+
+	// Without ?cursor the offset envelope is unchanged
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+	if client.gotList.Cursor != nil {
+		t.Errorf("offset request forwarded cursor %q", client.gotList.GetCursor())
+	}
+	if !strings.Contains(w.Body.String(), `"total":45`) {
+		t.Errorf("offset body = %s, want a total", w.Body.String())
+	}
+}
+
+func (f *fakeTaskClient) ListTaskIDs(ctx context.Context, in *pb.ListTaskIDsRequest, opts ...grpc.CallOption) (*pb.ListTaskIDsResponse, error) {
+	f.gotReq = in
+	return &pb.ListTaskIDsResponse{Tasks: f.versions}, nil
+}
+
+func (f *fakeTaskClient) CreateSubtasks(ctx context.Context, in *pb.CreateSubtasksRequest, opts ...grpc.CallOption) (*pb.ListSubtasksResponse, error) {
+	if _, ok := f.tasks[in.TaskId]; !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	if len(in.Titles) > 3 {
+		return nil, status.Error(codes.FailedPrecondition, "task cannot have more than 3 subtasks")
+	}
+	f.titles = in.Titles
+	subtasks := make([]*pb.Subtask, len(in.Titles))
+	for i, title := range in.Titles {
+		subtasks[i] = &pb.Subtask{Id: int64(i + 1), TaskId: in.TaskId, Title: title, Position: int32(i + 1)}
+	}
+	return &pb.ListSubtasksResponse{Subtasks: subtasks}, nil
+}
+
+func TestTaskHandler_CreateSubtasks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {Id: 1, ProjectId: 10}, 2: {Id: 2, ProjectId: 20}}}
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 1, ProjectId: 20, AccessLevel: AccessRead},
+	}
+	h := &TaskHandler{
+		taskClient: client,
+		access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+	}
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Set("role", "user")
+	})
+	r.POST("/tasks/:id/subtasks/bulk", h.CreateSubtasks)
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{name: "Created", path: "/tasks/1/subtasks/bulk", body: `{"titles":["Design","Build"]}`, wantCode: http.StatusCreated},
+		{name: "Empty list", path: "/tasks/1/subtasks/bulk", body: `{"titles":[]}`, wantCode: http.StatusBadRequest},
+		{name: "Invalid ID", path: "/tasks/abc/subtasks/bulk", body: `{"titles":["A"]}`, wantCode: http.StatusBadRequest},
+		{name: "Missing task", path: "/tasks/9/subtasks/bulk", body: `{"titles":["A"]}`, wantCode: http.StatusNotFound},
+		{name: "Reader", path: "/tasks/2/subtasks/bulk", body: `{"titles":["A"]}`, wantCode: http.StatusForbidden},
+		{name: "Over the limit", path: "/tasks/1/subtasks/bulk", body: `{"titles":["A","B","C","D"]}`, wantCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+
+	if len(client.titles) != 2 || client.titles[0] != "Design" || client.titles[1] != "Build" {
+		t.Errorf("titles = %v, want [Design Build]", client.titles)
+	}
+}
+
+func (f *fakeTaskClient) AddAttachment(ctx context.Context, in *pb.AddAttachmentRequest, opts ...grpc.CallOption) (*pb.AttachmentResponse, error) {
+	if in.MediaFileId == 99 {
+		return nil, status.Error(codes.NotFound, "media file not found")
+	}
+	return &pb.AttachmentResponse{Attachment: &pb.Attachment{Id: 1, TaskId: in.TaskId, FileUrl: in.FileUrl, MediaFileId: in.MediaFileId}}, nil
+}
+
+func TestTaskHandler_AddAttachment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &TaskHandler{taskClient: &fakeTaskClient{}}
+	r := gin.New()
+	r.POST("/tasks/:id/attachments", h.AddAttachment)
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "Link", body: `{"file_url":"https://example.com/spec"}`, wantCode: http.StatusCreated},
+		{name: "Media file", body: `{"media_file_id":3}`, wantCode: http.StatusCreated},
+		{name: "Unknown media file", body: `{"media_file_id":99}`, wantCode: http.StatusNotFound},
+		{name: "Neither", body: `{}`, wantCode: http.StatusBadRequest},
+		{name: "Both", body: `{"file_url":"https://example.com","media_file_id":3}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/tasks/1/attachments", strings.NewReader(tt.body)))
+			if w.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.wantCode, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskHandler_ListTaskIDs(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	updated := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	client := &fakeTaskClient{versions: []*pb.TaskVersion{
+		{Id: 1, UpdatedAt: timestamppb.New(updated)},
+		{Id: 2, UpdatedAt: timestamppb.New(updated.Add(time.Hour))},
+	}}
+	h := &TaskHandler{
+		taskClient: client,
+		access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: []*authpb.UserProjectAccess{{UserId: 1, ProjectId: 5, AccessLevel: AccessRead}}}},
+	}
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("user_id", int64(1))
+		c.Set("role", "user")
+	})
+	r.GET("/tasks/ids", h.ListTaskIDs)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/ids?project_id=5", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if client.gotReq.GetProjectId() != 5 {
+		t.Errorf("project id = %d, want 5", client.gotReq.GetProjectId())
+	}
+
+	var envelope struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	body := envelope.Data
+	if len(body) != 2 {
+		t.Fatalf("got %d entries, want 2", len(body))
+	}
+	for _, entry := range body {
+		if len(entry) != 2 || entry["id"] == nil || entry["updated_at"] == nil {
+			t.Errorf("entry = %v, want only id and updated_at", entry)
+		}
+	}
+	if body[0]["updated_at"] != updated.Format(time.RFC3339) {
+		t.Errorf("updated_at = %v, want %s", body[0]["updated_at"], updated.Format(time.RFC3339))
+	}
+}
+
+func TestTaskHandler_ListTaskIDs_ProjectAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{{UserId: 1, ProjectId: 5, AccessLevel: AccessRead}}
+	tests := []struct {
+		name       string
+		userID     int64
+		role       string
+		wantStatus int
+	}{
+		{"Reader allowed", 1, "user", http.StatusOK},
+		{"No access denied", 2, "user", http.StatusForbidden},
+		{"Admin role allowed", 2, "admin", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{}
+			h := &TaskHandler{
+				taskClient: client,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", tt.role)
+			})
+			r.GET("/tasks/ids", h.ListTaskIDs)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/tasks/ids?project_id=5", nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusForbidden && client.gotReq != nil {
+				t.Errorf("task service was called with %v", client.gotReq)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_ListTaskIDs_RequiresProject(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := &TaskHandler{taskClient: &fakeTaskClient{}}
+	r := gin.New()
+	r.GET("/tasks/ids", h.ListTaskIDs)
+
+	for _, url := range []string{"/tasks/ids", "/tasks/ids?project_id=abc", "/tasks/ids?project_id=0"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, url, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s status = %d, want %d", url, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestTaskHandler_ProjectAccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessRead},
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 3, ProjectId: 20, AccessLevel: AccessAdmin},
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		userID int64
+		role   string
+		want   int
+	}{
+		{"Reader can get", http.MethodGet, "/tasks/1", 1, "user", http.StatusOK},
+		{"Writer can get", http.MethodGet, "/tasks/1", 2, "user", http.StatusOK},
+		{"Other project denied", http.MethodGet, "/tasks/1", 3, "user", http.StatusForbidden},
+		{"No access denied", http.MethodGet, "/tasks/1", 4, "user", http.StatusForbidden},
+		{"Admin role allowed", http.MethodGet, "/tasks/1", 4, "admin", http.StatusOK},
+		{"Missing task", http.MethodGet, "/tasks/99", 1, "user", http.StatusNotFound},
+		{"Reader cannot update", http.MethodPut, "/tasks/1", 1, "user", http.StatusForbidden},
+		{"Writer can update", http.MethodPut, "/tasks/1", 2, "user", http.StatusOK},
+		{"Reader cannot patch", http.MethodPatch, "/tasks/1", 1, "user", http.StatusForbidden},
+		{"Writer can patch", http.MethodPatch, "/tasks/1", 2, "user", http.StatusOK},
+		{"Reader cannot delete", http.MethodDelete, "/tasks/1", 1, "user", http.StatusForbidden},
+		{"Writer can delete", http.MethodDelete, "/tasks/1", 2, "user", http.StatusOK},
+		{"Reader cannot duplicate", http.MethodPost, "/tasks/1/duplicate", 1, "user", http.StatusForbidden},
+		{"Writer can duplicate", http.MethodPost, "/tasks/1/duplicate", 2, "user", http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskClient := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {Id: 1, ProjectId: 10, Title: "Task"}}}
+			h := &TaskHandler{
+				taskClient: taskClient,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", tt.role)
+			})
+			r.GET("/tasks/:id", h.GetTask)
+			r.PUT("/tasks/:id", h.UpdateTask)
+			r.PATCH("/tasks/:id", h.PatchTask)
+			r.DELETE("/tasks/:id", h.DeleteTask)
+			r.POST("/tasks/:id/duplicate", h.DuplicateTask)
+
+			var body *strings.Reader
+			if tt.method == http.MethodPut || tt.method == http.MethodPatch {
+				body = strings.NewReader(`{"title":"Renamed"}`)
+			} else {
+				body = strings.NewReader("")
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, body))
+
+			if w.Code != tt.want {
+				t.Fatalf("%s %s status = %d, want %d (%s)", tt.method, tt.path, w.Code, tt.want, w.Body.String())
+			}
+			if tt.want == http.StatusForbidden && (taskClient.updated || taskClient.deleted || taskClient.gotCopy != nil) {
+				t.Error("task was modified despite denied access")
+			}
+		})
+	}
+}
+
+func (f *fakeTaskClient) BulkDeleteTasks(ctx context.Context, in *pb.BulkDeleteTasksRequest, opts ...grpc.CallOption) (*pb.BulkDeleteTasksResponse, error) {
+	f.gotBulk = in.Ids
+	return &pb.BulkDeleteTasksResponse{Deleted: int32(len(in.Ids))}, nil
+}
+
+func TestTaskHandler_BulkDeleteTasks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 1, ProjectId: 20, AccessLevel: AccessRead},
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessRead},
+	}
+
+	tests := []struct {
+		name       string
+		body       string
+		userID     int64
+		role       string
+		wantStatus int
+	}{
+		{"Writer on every project", `{"ids":[1,2]}`, 1, "user", http.StatusOK},
+		{"Reader cannot delete", `{"ids":[1]}`, 2, "user", http.StatusForbidden},
+		{"One task not writable", `{"ids":[1,3]}`, 1, "user", http.StatusForbidden},
+		{"Admin role allowed", `{"ids":[1,3]}`, 4, "admin", http.StatusOK},
+		{"Missing task", `{"ids":[1,99]}`, 1, "user", http.StatusNotFound},
+		{"Empty list", `{"ids":[]}`, 1, "user", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{
+				1: {Id: 1, ProjectId: 10},
+				2: {Id: 2, ProjectId: 10},
+				3: {Id: 3, ProjectId: 20},
+			}}
+			h := &TaskHandler{
+				taskClient: client,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", tt.role)
+			})
+			r.DELETE("/tasks/bulk", h.BulkDeleteTasks)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tasks/bulk", strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK && client.gotBulk != nil {
+				t.Errorf("task service was called with %v", client.gotBulk)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_PatchTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	due := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		body string
+		want *pb.UpdateTaskRequest
+	}{
+		{"single field", `{"title":"Renamed"}`, &pb.UpdateTaskRequest{Id: 1, Title: "Renamed"}},
+		{"omitted nullable fields are kept", `{"priority":2}`, &pb.UpdateTaskRequest{Id: 1, Priority: 2}},
+		{"null assignee is cleared", `{"assigned_to":null}`, &pb.UpdateTaskRequest{Id: 1, ClearAssignedTo: true}},
+		{"null due date is cleared", `{"due_date":null,"assigned_to":7}`, &pb.UpdateTaskRequest{Id: 1, AssignedTo: 7, ClearDueDate: true}},
+		{"due date is set", `{"due_date":"2025-01-02T00:00:00Z"}`, &pb.UpdateTaskRequest{Id: 1, DueDate: timestamppb.New(due)}},
+		{"description is set", `{"description":"Details"}`, &pb.UpdateTaskRequest{Id: 1, Description: "Details"}},
+		{"null description is cleared", `{"description":null}`, &pb.UpdateTaskRequest{Id: 1, ClearDescription: true}},
+		{"empty description is cleared", `{"description":""}`, &pb.UpdateTaskRequest{Id: 1, ClearDescription: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {Id: 1, ProjectId: 10}}}
+			h := &TaskHandler{taskClient: client}
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set("role", "admin") })
+			r.PATCH("/tasks/:id", h.PatchTask)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(tt.body)))
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, http.StatusOK, w.Body.String())
+			}
+			if !proto.Equal(client.gotUpdate, tt.want) {
+				t.Errorf("UpdateTask request = %v, want %v", client.gotUpdate, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_PatchTask_Invalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for _, body := range []string{
+		`{}`,
+		`{"title":"  "}`,
+		`{"status":""}`,
+		`{"priority":0}`,
+		`{"assigned_to":0}`,
+		`{"assigned_to":"bob"}`,
+		`{"due_date":"tomorrow"}`,
+	} {
+		client := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {Id: 1, ProjectId: 10}}}
+		h := &TaskHandler{taskClient: client}
+		r := gin.New()
+		r.Use(func(c *gin.Context) { c.Set("role", "admin") })
+		r.PATCH("/tasks/:id", h.PatchTask)
+
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(body)))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("PATCH %s status = %d, want %d", body, w.Code, http.StatusBadRequest)
+		}
+		if client.updated {
+			t.Errorf("PATCH %s reached the task service", body)
+		}
+	}
+}
+
+func TestNullable_UnmarshalJSON(t *testing.T) {
+	var body struct {
+		Omitted Nullable[int64] `json:"omitted"`
+		Null    Nullable[int64] `json:"null"`
+		Value   Nullable[int64] `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(`{"null":null,"value":3}`), &body); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if body.Omitted.Set {
+		t.Error("omitted key reported as set")
+	}
+	if !body.Null.Set || body.Null.Value != nil {
+		t.Errorf("null = %+v, want set with no value", body.Null)
+	}
+	if !body.Value.Set || body.Value.Value == nil || *body.Value.Value != 3 {
+		t.Errorf("value = %+v, want set to 3", body.Value)
+	}
+}
+
+func TestTaskHandler_ClearCommentsAndAttachments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		role        string
+		path        string
+		wantStatus  int
+		wantDeleted int32
+	}{
+		{"Admin clears comments", "admin", "/tasks/1/comments", http.StatusOK, 3},
+		{"Admin clears attachments", "admin", "/tasks/1/attachments", http.StatusOK, 2},
+		{"User is forbidden", "user", "/tasks/1/comments", http.StatusForbidden, 0},
+		{"Missing task", "admin", "/tasks/99/attachments", http.StatusNotFound, 0},
+		{"Invalid ID", "admin", "/tasks/abc/comments", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {Id: 1, ProjectId: 10}}}
+			h := &TaskHandler{taskClient: client}
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set("role", tt.role) })
+			r.DELETE("/tasks/:id/comments", middleware.RequirePermission(adminOnly, middleware.PermDeleteComments), h.DeleteComments)
+			r.DELETE("/tasks/:id/attachments", middleware.RequirePermission(adminOnly, middleware.PermDeleteAttachments), h.DeleteAttachments)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if len(client.cleared) != 0 {
+					t.Errorf("task service was called: %v", client.cleared)
+				}
+				return
+			}
+			var resp struct {
+				Deleted int32 `json:"deleted"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || resp.Deleted != tt.wantDeleted {
+				t.Errorf("body = %s, want deleted %d", w.Body.String(), tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func (f *fakeTaskClient) RemoveTaskTag(ctx context.Context, in *pb.RemoveTaskTagRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	f.gotRemove = in
+	if in.TagId == 99 {
+		return nil, status.Error(codes.NotFound, "task does not have this tag")
+	}
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeTaskClient) DeleteTag(ctx context.Context, in *pb.DeleteTagRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	f.gotTagDel = in.Id
+	switch in.Id {
+	case 99:
+		return nil, status.Error(codes.NotFound, "tag not found")
+	case 50:
+		return nil, status.Error(codes.FailedPrecondition, "tag is still used by tasks")
+	}
+	return &pb.Empty{}, nil
+}
+
+func TestTaskHandler_RemoveTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessRead},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		userID     int64
+		wantStatus int
+		wantTask   int64
+		wantTag    int64
+	}{
+		{"Removes mapping", "/tasks/4/tags/7", 1, http.StatusOK, 4, 7},
+		{"Mapping missing", "/tasks/4/tags/99", 1, http.StatusNotFound, 4, 99},
+		{"Reader cannot remove", "/tasks/4/tags/7", 2, http.StatusForbidden, 0, 0},
+		{"Missing task", "/tasks/5/tags/7", 1, http.StatusNotFound, 0, 0},
+		{"Invalid task ID", "/tasks/abc/tags/7", 1, http.StatusBadRequest, 0, 0},
+		{"Invalid tag ID", "/tasks/4/tags/xyz", 1, http.StatusBadRequest, 0, 0},
+		{"Zero tag ID", "/tasks/4/tags/0", 1, http.StatusBadRequest, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{4: {Id: 4, ProjectId: 10}}}
+			h := &TaskHandler{
+				taskClient: client,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", "user")
+			})
+			r.DELETE("/tasks/:id/tags/:tagId", h.RemoveTag)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantTask == 0 {
+				if client.gotRemove != nil {
+					t.Errorf("task service was called with %v", client.gotRemove)
+				}
+				return
+			}
+			if client.gotRemove.GetTaskId() != tt.wantTask || client.gotRemove.GetTagId() != tt.wantTag {
+				t.Errorf("request = %v, want task %d tag %d", client.gotRemove, tt.wantTask, tt.wantTag)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_DeleteTag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		role       string
+		path       string
+		wantStatus int
+		wantID     int64
+	}{
+		{"Admin deletes tag", "admin", "/tags/7", http.StatusOK, 7},
+		{"Unknown tag", "admin", "/tags/99", http.StatusNotFound, 99},
+		{"Tag in use", "admin", "/tags/50", http.StatusConflict, 50},
+		{"User is forbidden", "user", "/tags/7", http.StatusForbidden, 0},
+		{"Invalid ID", "admin", "/tags/abc", http.StatusBadRequest, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{}
+			h := &TaskHandler{taskClient: client}
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set("role", tt.role) })
+			r.DELETE("/tags/:id", middleware.RequirePermission(adminOnly, middleware.PermDeleteTags), h.DeleteTag)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if client.gotTagDel != tt.wantID {
+				t.Errorf("deleted tag = %d, want %d", client.gotTagDel, tt.wantID)
+			}
+		})
+	}
+}
+
+func (f *fakeTaskClient) DuplicateTask(ctx context.Context, in *pb.DuplicateTaskRequest, opts ...grpc.CallOption) (*pb.TaskResponse, error) {
+	f.gotCopy = in
+	source, ok := f.tasks[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &pb.TaskResponse{Task: &pb.Task{Id: 100, ProjectId: source.ProjectId, Title: source.Title, Status: "Todo"}}, nil
+}
+
+func TestTaskHandler_DuplicateTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		path         string
+		wantStatus   int
+		wantKeepDate bool
+	}{
+		{"Clears due date", "/tasks/1/duplicate", http.StatusCreated, false},
+		{"Keeps due date", "/tasks/1/duplicate?keep_due_date=true", http.StatusCreated, true},
+		{"Invalid flag", "/tasks/1/duplicate?keep_due_date=maybe", http.StatusBadRequest, false},
+		{"Invalid ID", "/tasks/abc/duplicate", http.StatusBadRequest, false},
+		{"Missing task", "/tasks/99/duplicate", http.StatusNotFound, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {Id: 1, ProjectId: 10, Title: "Template"}}}
+			h := &TaskHandler{taskClient: client}
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set("role", "admin") })
+			r.POST("/tasks/:id/duplicate", h.DuplicateTask)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				if client.gotCopy != nil {
+					t.Errorf("task service was called with %v", client.gotCopy)
+				}
+				return
+			}
+			if client.gotCopy.GetId() != 1 || client.gotCopy.GetKeepDueDate() != tt.wantKeepDate {
+				t.Errorf("request = %v, want id 1 keep_due_date %v", client.gotCopy, tt.wantKeepDate)
+			}
+			var task pb.Task
+			if err := json.Unmarshal(w.Body.Bytes(), &task); err != nil || task.Id != 100 {
+				t.Errorf("body = %s, want the copy", w.Body.String())
+			}
+		})
+	}
+}
+
+func (f *fakeTaskClient) MoveTask(ctx context.Context, in *pb.MoveTaskRequest, opts ...grpc.CallOption) (*pb.TaskResponse, error) {
+	f.gotMove = in
+	task, ok := f.tasks[in.Id]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	if in.ProjectId == task.ProjectId {
+		return nil, status.Error(codes.InvalidArgument, "task is already in this project")
+	}
+	if in.ProjectId == 404 {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return &pb.TaskResponse{Task: &pb.Task{Id: task.Id, ProjectId: in.ProjectId, Title: task.Title}}, nil
+}
+
+func TestTaskHandler_MoveTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 1, ProjectId: 20, AccessLevel: AccessWrite},
+		{UserId: 1, ProjectId: 30, AccessLevel: AccessRead},
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessRead},
+		{UserId: 2, ProjectId: 20, AccessLevel: AccessWrite},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		userID     int64
+		role       string
+		wantStatus int
+	}{
+		{"Writer on both", "/tasks/1/move", `{"project_id":20}`, 1, "user", http.StatusOK},
+		{"Clears assignees", "/tasks/1/move", `{"project_id":20,"clear_assignees":true}`, 1, "user", http.StatusOK},
+		{"Reader on target", "/tasks/1/move", `{"project_id":30}`, 1, "user", http.StatusForbidden},
+		{"Reader on source", "/tasks/1/move", `{"project_id":20}`, 2, "user", http.StatusForbidden},
+		{"Admin to missing project", "/tasks/1/move", `{"project_id":404}`, 3, "admin", http.StatusNotFound},
+		{"Same project", "/tasks/1/move", `{"project_id":10}`, 1, "user", http.StatusBadRequest},
+		{"Missing project", "/tasks/1/move", `{}`, 1, "user", http.StatusBadRequest},
+		{"Missing task", "/tasks/99/move", `{"project_id":20}`, 1, "user", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {Id: 1, ProjectId: 10, Title: "Migrate billing"}}}
+			h := &TaskHandler{
+				taskClient: client,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", tt.role)
+			})
+			r.POST("/tasks/:id/move", h.MoveTask)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusForbidden && client.gotMove != nil {
+				t.Errorf("task service was called with %v", client.gotMove)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			if client.gotMove.GetProjectId() != 20 || client.gotMove.GetClearAssignees() != strings.Contains(tt.body, "clear_assignees") {
+				t.Errorf("request = %v, want project 20 as asked", client.gotMove)
+			}
+			var task pb.Task
+			if err := json.Unmarshal(w.Body.Bytes(), &task); err != nil || task.Id != 1 || task.ProjectId != 20 {
+				t.Errorf("body = %s, want task 1 in project 20", w.Body.String())
+			}
+		})
+	}
+}
+
+// MoveSubtask knows subtask 5 under task 1
+func (f *fakeTaskClient) MoveSubtask(ctx context.Context, in *pb.MoveSubtaskRequest, opts ...grpc.CallOption) (*pb.SubtaskResponse, error) {
+	f.gotMoveST = in
+	if in.Id != 5 || in.FromTaskId != 1 {
+		return nil, status.Error(codes.NotFound, "subtask not found")
+	}
+	return &pb.SubtaskResponse{Subtask: &pb.Subtask{Id: 5, TaskId: in.TaskId}}, nil
+}
+
+func TestTaskHandler_MoveSubtask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 1, ProjectId: 20, AccessLevel: AccessRead},
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessRead},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		userID     int64
+		wantStatus int
+	}{
+		{"Writer on both", "/tasks/1/subtasks/5/move", `{"task_id":2}`, 1, http.StatusOK},
+		{"Subtask of another task", "/tasks/2/subtasks/5/move", `{"task_id":1}`, 1, http.StatusNotFound},
+		{"Reader on source", "/tasks/1/subtasks/5/move", `{"task_id":2}`, 2, http.StatusForbidden},
+		{"Reader on destination", "/tasks/1/subtasks/5/move", `{"task_id":3}`, 1, http.StatusForbidden},
+		{"Missing destination", "/tasks/1/subtasks/5/move", `{"task_id":99}`, 1, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{
+				1: {Id: 1, ProjectId: 10},
+				2: {Id: 2, ProjectId: 10},
+				3: {Id: 3, ProjectId: 20},
+			}}
+			h := &TaskHandler{
+				taskClient: client,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", "user")
+			})
+			r.PATCH("/tasks/:id/subtasks/:subtaskId/move", h.MoveSubtask)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusForbidden && client.gotMoveST != nil {
+				t.Errorf("task service was called with %v", client.gotMoveST)
+			}
+		})
+	}
+}
+
+// PromoteSubtask knows subtask 5 under task 1
+func (f *fakeTaskClient) PromoteSubtask(ctx context.Context, in *pb.PromoteSubtaskRequest, opts ...grpc.CallOption) (*pb.TaskResponse, error) {
+	f.gotPromo = in
+	if in.Id != 5 || in.TaskId != 1 {
+		return nil, status.Error(codes.NotFound, "subtask not found")
+	}
+	return &pb.TaskResponse{Task: &pb.Task{Id: 7, ProjectId: 10}}, nil
+}
+
+func TestTaskHandler_PromoteSubtask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessRead},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		userID     int64
+		wantStatus int
+	}{
+		{"Writer", "/tasks/1/subtasks/5/promote", 1, http.StatusCreated},
+		{"Subtask of another task", "/tasks/2/subtasks/5/promote", 1, http.StatusNotFound},
+		{"Reader", "/tasks/1/subtasks/5/promote", 2, http.StatusForbidden},
+		{"Missing task", "/tasks/99/subtasks/5/promote", 1, http.StatusNotFound},
+		{"Invalid task ID", "/tasks/abc/subtasks/5/promote", 1, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{
+				1: {Id: 1, ProjectId: 10},
+				2: {Id: 2, ProjectId: 10},
+			}}
+			h := &TaskHandler{
+				taskClient: client,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", "user")
+			})
+			r.POST("/tasks/:id/subtasks/:subtaskId/promote", h.PromoteSubtask)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus == http.StatusForbidden && client.gotPromo != nil {
+				t.Errorf("task service was called with %v", client.gotPromo)
+			}
+		})
+	}
+}
+
+func (f *fakeTaskClient) DemoteTask(ctx context.Context, in *pb.DemoteTaskRequest, opts ...grpc.CallOption) (*pb.SubtaskResponse, error) {
+	f.gotDemote = in
+	return &pb.SubtaskResponse{Subtask: &pb.Subtask{Id: 1, TaskId: in.ParentTaskId}}, nil
+}
+
+func TestTaskHandler_DemoteTask(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	accesses := []*authpb.UserProjectAccess{
+		{UserId: 1, ProjectId: 10, AccessLevel: AccessWrite},
+		{UserId: 1, ProjectId: 20, AccessLevel: AccessRead},
+		{UserId: 2, ProjectId: 10, AccessLevel: AccessRead},
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		userID     int64
+		wantStatus int
+	}{
+		{"Writer on both", "/tasks/1/demote", `{"parent_task_id":2}`, 1, http.StatusCreated},
+		{"Reader on task", "/tasks/1/demote", `{"parent_task_id":2}`, 2, http.StatusForbidden},
+		{"Reader on parent", "/tasks/1/demote", `{"parent_task_id":3}`, 1, http.StatusForbidden},
+		{"Reader on task only", "/tasks/3/demote", `{"parent_task_id":1}`, 1, http.StatusForbidden},
+		{"Missing parent", "/tasks/1/demote", `{"parent_task_id":99}`, 1, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeTaskClient{tasks: map[int64]*pb.Task{
+				1: {Id: 1, ProjectId: 10},
+				2: {Id: 2, ProjectId: 10},
+				3: {Id: 3, ProjectId: 20},
+			}}
+			h := &TaskHandler{
+				taskClient: client,
+				access:     &ProjectAccessChecker{authClient: &fakeAuthClient{accesses: accesses}},
+			}
+			r := gin.New()
+			r.Use(func(c *gin.Context) {
+				c.Set("user_id", tt.userID)
+				c.Set("role", "user")
+			})
+			r.POST("/tasks/:id/demote", h.DemoteTask)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated && client.gotDemote != nil {
+				t.Errorf("task service was called with %v", client.gotDemote)
+			}
+		})
+	}
+}
+
+// fakeBatchStream serves fixed task batches, then err (io.EOF when nil)
+type fakeBatchStream struct {
+	grpc.ClientStream
+	batches []*pb.TaskBatch
+	err     error
+}
+
+func (s *fakeBatchStream) Recv() (*pb.TaskBatch, error) {
+	if len(s.batches) == 0 {
+		if s.err != nil {
+			return nil, s.err
+		}
+		return nil, io.EOF
+	}
+	batch := s.batches[0]
+	s.batches = s.batches[1:]
+	return batch, nil
+}
+
+// fakeExportClient streams rows tasks in batches of 100
+type fakeExportClient struct {
+	pb.TaskServiceClient
+	rows int
+	err  error
+	got  *pb.StreamTasksRequest
+}
+
+func (f *fakeExportClient) StreamTasks(ctx context.Context, in *pb.StreamTasksRequest, opts ...grpc.CallOption) (pb.TaskService_StreamTasksClient, error) {
+	f.got = in
+	stream := &fakeBatchStream{err: f.err}
+	for id := 1; id <= f.rows; id += 100 {
+		batch := &pb.TaskBatch{}
+		for i := id; i < id+100 && i <= f.rows; i++ {
+			batch.Tasks = append(batch.Tasks, &pb.Task{Id: int64(i), ProjectId: in.ProjectId})
+		}
+		stream.batches = append(stream.batches, batch)
+	}
+	return stream, nil
+}
+
+func TestExportTasks_StreamsNDJSON(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	client := &fakeExportClient{rows: 1234}
+	h := &TaskHandler{taskClient: client}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/api/tasks/export?project_id=7&status=Done&tags=3&tag_mode=any", nil)
+	c.Set("role", "admin")
+	h.ExportTasks(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/x-ndjson" {
+		t.Errorf("Content-Type = %q, want application/x-ndjson", ct)
+	}
+	if client.got.ProjectId != 7 || client.got.Status != "Done" || !client.got.MatchAnyTag || len(client.got.TagIds) != 1 {
+		t.Errorf("StreamTasks request = %+v", client.got)
+	}
+
+	scanner := bufio.NewScanner(w.Body)
+	rows := 0
+	for scanner.Scan() {
+		var task pb.Task
+		if err := json.Unmarshal(scanner.Bytes(), &task); err != nil {
+			t.Fatalf("line %d is not a task: %v", rows+1, err)
+		}
+		rows++
+		if task.Id != int64(rows) {
+			t.Fatalf("line %d has task %d", rows, task.Id)
+		}
+	}
+	if rows != 1234 {
+		t.Errorf("exported %d rows, want 1234", rows)
+	}
+}
+
+func TestExportTasks_Errors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name   string
+		query  string
+		client *fakeExportClient
+		want   int
+	}{
+		{name: "Missing project", query: "", client: &fakeExportClient{}, want: http.StatusBadRequest},
+		{name: "Bad tag mode", query: "project_id=7&tag_mode=some", client: &fakeExportClient{}, want: http.StatusBadRequest},
+		{name: "Service unavailable", query: "project_id=7", client: &fakeExportClient{err: status.Error(codes.Unavailable, "down")}, want: http.StatusServiceUnavailable},
+		{name: "Empty project", query: "project_id=7", client: &fakeExportClient{}, want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/api/tasks/export?"+tt.query, nil)
+			c.Set("role", "admin")
+			(&TaskHandler{taskClient: tt.client}).ExportTasks(c)
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d", w.Code, tt.want)
+			}
+		})
+	}
+}
+
+// fakeSummaryClient serves a fixed task summary and records the project asked for
+type fakeSummaryClient struct {
+	pb.TaskServiceClient
+	projectID int64
+}
+
+func (f *fakeSummaryClient) GetTaskSummary(ctx context.Context, in *pb.GetTaskSummaryRequest, opts ...grpc.CallOption) (*pb.TaskSummaryResponse, error) {
+	f.projectID = in.ProjectId
+	return &pb.TaskSummaryResponse{
+		ProjectId:    in.ProjectId,
+		Total:        3,
+		StatusCounts: map[string]int32{"Todo": 3, "InProgress": 0, "Done": 0},
+		Overdue:      1,
+	}, nil
+}
+
+func TestTaskHandler_GetTask_LastModifiedCoversSubtasks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	taskUpdated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	subtaskUpdated := taskUpdated.Add(time.Hour)
+	client := &fakeTaskClient{tasks: map[int64]*pb.Task{1: {
+		Id:        1,
+		ProjectId: 10,
+		UpdatedAt: timestamppb.New(taskUpdated),
+		Subtasks: []*pb.Subtask{
+			{Id: 1, UpdatedAt: timestamppb.New(subtaskUpdated)},
+			{Id: 2, UpdatedAt: timestamppb.New(taskUpdated.Add(-time.Hour))},
+		},
+	}}}
+	h := &TaskHandler{taskClient: client}
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("role", "admin") })
+	r.GET("/tasks/:id", h.GetTask)
+
+	get := func(since time.Time) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/tasks/1", nil)
+		req.Header.Set("If-Modified-Since", since.Format(http.TimeFormat))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get(taskUpdated)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status since the task update = %d, want %d: the subtask changed later", w.Code, http.StatusOK)
+	}
+	if got, want := w.Header().Get("Last-Modified"), subtaskUpdated.Format(http.TimeFormat); got != want {
+		t.Errorf("Last-Modified = %q, want %q", got, want)
+	}
+	if w := get(subtaskUpdated); w.Code != http.StatusNotModified {
+		t.Errorf("status since the subtask update = %d, want %d", w.Code, http.StatusNotModified)
+	}
+}
+
+func TestTaskHandler_GetTaskSummary(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	auth := &fakeAuthClient{accesses: []*authpb.UserProjectAccess{{UserId: 2, ProjectId: 10, AccessLevel: AccessRead}}}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{"reader", "/projects/10/tasks/summary", http.StatusOK},
+		{"no access", "/projects/11/tasks/summary", http.StatusForbidden},
+		{"invalid id", "/projects/abc/tasks/summary", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeSummaryClient{}
+			h := &TaskHandler{taskClient: client, access: &ProjectAccessChecker{authClient: auth}}
+			r := gin.New()
+			r.Use(func(c *gin.Context) { c.Set("user_id", int64(2)) })
+			r.GET("/projects/:id/tasks/summary", h.GetTaskSummary)
+
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if client.projectID != 0 {
+					t.Error("summary was fetched without access")
+				}
+				return
+			}
+			// Zero counts are still present
+			want := `{"project_id":10,"total":3,"by_status":{"Done":0,"InProgress":0,"Todo":3},"overdue":1,"unassigned":0}`
+			if got := w.Body.String(); got != want {
+				t.Errorf("body = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func (f *fakeTaskClient) AddWatcher(ctx context.Context, in *pb.AddWatcherRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	f.watched = in
+	if _, ok := f.tasks[in.TaskId]; !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeTaskClient) RemoveWatcher(ctx context.Context, in *pb.RemoveWatcherRequest, opts ...grpc.CallOption) (*pb.Empty, error) {
+	f.unwatched = in
+	return &pb.Empty{}, nil
+}
+
+func (f *fakeTaskClient) ListWatchers(ctx context.Context, in *pb.ListWatchersRequest, opts ...grpc.CallOption) (*pb.ListWatchersResponse, error) {
+	if _, ok := f.tasks[in.TaskId]; !ok {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &pb.ListWatchersResponse{Watchers: []*pb.Watcher{
+		{TaskId: in.TaskId, UserId: 6},
+		{TaskId: in.TaskId, UserId: 9},
+	}}, nil
+}
+
+func TestTaskHandler_Watchers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	client := &fakeTaskClient{tasks: map[int64]*pb.Task{4: {Id: 4, ProjectId: 1}}}
+	auth := &fakeAuthClient{accesses: []*authpb.UserProjectAccess{
+		{UserId: 5, ProjectId: 1, AccessLevel: AccessRead},
+		{UserId: 7, ProjectId: 1, AccessLevel: AccessWrite},
+	}}
+	h := &TaskHandler{taskClient: client, access: &ProjectAccessChecker{authClient: auth}}
+	// Requests are made as user 5, who can read the project, unless set
+	userID := int64(5)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("user_id", userID) })
+	r.GET("/tasks/:id/watchers", h.ListWatchers)
+	r.POST("/tasks/:id/watchers", h.AddWatcher)
+	r.DELETE("/tasks/:id/watchers/:userId", h.RemoveWatcher)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	// Without a body the task service watches for the caller
+	if w := serve(http.MethodPost, "/tasks/4/watchers", ""); w.Code != http.StatusOK {
+		t.Fatalf("add status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if got := client.watched; got.TaskId != 4 || got.UserId != 0 {
+		t.Errorf("add request = %v, want task 4 for the caller", got)
+	}
+	if w := serve(http.MethodPost, "/tasks/4/watchers", `{"user_id":6}`); w.Code != http.StatusOK || client.watched.UserId != 6 {
+		t.Errorf("add for user 6: status = %d, request = %v", w.Code, client.watched)
+	}
+	if w := serve(http.MethodPost, "/tasks/99/watchers", ""); w.Code != http.StatusNotFound {
+		t.Errorf("add to missing task status = %d, want 404", w.Code)
+	}
+	if w := serve(http.MethodPost, "/tasks/4/watchers", `{"user_id":"six"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("add with invalid body status = %d, want 400", w.Code)
+	}
+
+	if w := serve(http.MethodDelete, "/tasks/4/watchers/5", ""); w.Code != http.StatusOK {
+		t.Fatalf("remove self status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	if got := client.unwatched; got.TaskId != 4 || got.UserId != 5 {
+		t.Errorf("remove request = %v, want task 4 user 5", got)
+	}
+	// Removing someone else takes write access
+	client.unwatched = nil
+	if w := serve(http.MethodDelete, "/tasks/4/watchers/6", ""); w.Code != http.StatusForbidden || client.unwatched != nil {
+		t.Errorf("remove another user with read access: status = %d, request = %v, want 403 and no request", w.Code, client.unwatched)
+	}
+	userID = 7
+	if w := serve(http.MethodDelete, "/tasks/4/watchers/6", ""); w.Code != http.StatusOK || client.unwatched.GetUserId() != 6 {
+		t.Errorf("remove another user with write access: status = %d, request = %v", w.Code, client.unwatched)
+	}
+	if w := serve(http.MethodDelete, "/tasks/4/watchers/abc", ""); w.Code != http.StatusBadRequest {
+		t.Errorf("remove with invalid user status = %d, want 400", w.Code)
+	}
+
+	// Users without access to the project can't see or change its watchers
+	userID = 8
+	for _, req := range []struct{ method, path string }{
+		{http.MethodGet, "/tasks/4/watchers"},
+		{http.MethodPost, "/tasks/4/watchers"},
+		{http.MethodDelete, "/tasks/4/watchers/8"},
+	} {
+		if w := serve(req.method, req.path, ""); w.Code != http.StatusForbidden {
+			t.Errorf("%s %s without access: status = %d, want 403", req.method, req.path, w.Code)
+		}
+	}
+	userID = 5
+
+	w := serve(http.MethodGet, "/tasks/4/watchers", "")
+	if w.Code != http.StatusOK {
+		t.Fatalf("list status = %d, want 200: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Data  []*pb.Watcher `json:"data"`
+		Total int32         `json:"total"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body.Total != 2 || len(body.Data) != 2 || body.Data[0].UserId != 6 || body.Data[1].UserId != 9 {
+		t.Errorf("list body = %s, want watchers 6 and 9", w.Body.String())
+	}
+}
@@ -1,40 +1,66 @@
 package middleware
 
 import (
-	"net/http"
+	"context"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	authpb "github.com/portfolio/proto/auth"
 	"github.com/portfolio/shared/jwt"
+	"google.golang.org/grpc/codes"
 )
 
-// AuthMiddleware creates JWT authentication middleware
-func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
-	tokenService := jwt.NewTokenService(jwtSecret, 0)
+// RevocationChecker reports whether a token that passed local validation
+// has since been revoked
+type RevocationChecker func(ctx context.Context, token string) (bool, error)
 
+// AuthRevocationChecker asks the auth service whether a token is still valid
+func AuthRevocationChecker(client authpb.AuthServiceClient) RevocationChecker {
+	return func(ctx context.Context, token string) (bool, error) {
+		resp, err := client.ValidateToken(ctx, &authpb.ValidateTokenRequest{Token: token})
+		if err != nil {
+			return false, err
+		}
+		return !resp.Valid, nil
+	}
+}
+
+// AuthMiddleware creates JWT authentication middleware that validates
+// tokens with tokenService. If revoked is non-nil, tokens are also checked
+// against it so logged out tokens are rejected before they expire.
+func AuthMiddleware(tokenService *jwt.TokenService, revoked RevocationChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
+			apierror.Respond(c, codes.Unauthenticated, "Authorization header required")
 			return
 		}
 
 		// Check Bearer token format
 		parts := strings.SplitN(authHeader, " ", 2)
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
-			c.Abort()
+			apierror.Respond(c, codes.Unauthenticated, "Invalid authorization format")
 			return
 		}
 
 		// Validate token
 		claims, err := tokenService.ValidateToken(parts[1])
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
+			apierror.Respond(c, codes.Unauthenticated, "Invalid or expired token")
 			return
 		}
+		if revoked != nil {
+			isRevoked, err := revoked(c.Request.Context(), parts[1])
+			if err != nil {
+				apierror.Respond(c, codes.Unavailable, "Failed to check token")
+				return
+			}
+			if isRevoked {
+				apierror.Respond(c, codes.Unauthenticated, "Invalid or expired token")
+				return
+			}
+		}
 
 		// Set user info in context
 		c.Set("user_id", claims.UserID)
@@ -45,44 +71,3 @@ func AuthMiddleware(jwtSecret string) gin.HandlerFunc {
 		c.Next()
 	}
 }
-
-// RoleMiddleware checks if user has required role
-func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
-	return func(c *gin.Context) {
-		role, exists := c.Get("role")
-		if !exists {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
-			c.Abort()
-			return
-		}
-
-		userRole := role.(string)
-		for _, allowedRole := range allowedRoles {
-			if userRole == allowedRole {
-				c.Next()
-				return
-			}
-		}
-
-		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
-		c.Abort()
-	}
-}
-
-// CORSMiddleware handles CORS
-func CORSMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Authorization")
-		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Max-Age", "86400")
-
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(http.StatusNoContent)
-			return
-		}
-
-		c.Next()
-	}
-}
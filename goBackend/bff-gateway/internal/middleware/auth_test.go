@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/shared/jwt"
+)
+
+func TestAuthMiddleware_Revocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tokens := jwt.NewTokenService("secret", time.Hour)
+	valid, _ := tokens.GenerateToken(1, "alice", "alice@example.com", "user")
+	loggedOut, _ := tokens.GenerateToken(1, "alice", "alice@example.com", "user")
+
+	revoked := func(ctx context.Context, token string) (bool, error) {
+		return token == loggedOut, nil
+	}
+	unavailable := func(ctx context.Context, token string) (bool, error) {
+		return false, errors.New("auth service down")
+	}
+
+	tests := []struct {
+		name       string
+		checker    RevocationChecker
+		token      string
+		wantStatus int
+	}{
+		{"Valid token", revoked, valid, http.StatusOK},
+		{"Revoked token", revoked, loggedOut, http.StatusUnauthorized},
+		{"Check disabled", nil, loggedOut, http.StatusOK},
+		{"Check unavailable", unavailable, valid, http.StatusServiceUnavailable},
+		{"Bad signature", revoked, valid + "x", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.New()
+			r.GET("/api/projects", AuthMiddleware(tokens, tt.checker), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+			req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	"google.golang.org/grpc/codes"
+)
+
+// CORSConfig controls which cross-origin requests are allowed
+type CORSConfig struct {
+	// AllowedOrigins lists exact origins such as "https://app.example.com".
+	// A single "*" allows any origin, but then credentials are not allowed.
+	AllowedOrigins []string
+	AllowedMethods []string
+	AllowedHeaders []string
+	ExposedHeaders []string
+	MaxAge         time.Duration
+}
+
+// DefaultCORSConfig returns a configuration for local development
+func DefaultCORSConfig() CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: []string{
+			"http://localhost:3000",
+			"http://localhost:5173",
+			"http://127.0.0.1:3000",
+			"http://127.0.0.1:5173",
+		},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Origin", "Content-Type", "Authorization", RequestIDHeader, "If-None-Match", "If-Modified-Since"},
+		// Content-Disposition and Content-Length let browser code name and
+		// size file downloads
+		ExposedHeaders: []string{"X-Total-Count", RequestIDHeader, "ETag", "Content-Disposition", "Content-Length"},
+		MaxAge:         24 * time.Hour,
+	}
+}
+
+// CORSMiddleware handles CORS. Requests from origins that are not allowed
+// are rejected with 403; requests without an Origin header pass through.
+func CORSMiddleware(cfg CORSConfig) gin.HandlerFunc {
+	allowAny := false
+	allowed := make(map[string]bool, len(cfg.AllowedOrigins))
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			allowAny = true
+		}
+		allowed[strings.TrimRight(origin, "/")] = true
+	}
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(cfg.AllowedHeaders, ", ")
+	exposed := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Origin")
+		if !allowAny && !allowed[origin] {
+			apierror.Respond(c, codes.PermissionDenied, "Origin not allowed")
+			return
+		}
+
+		if allowAny {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+		if exposed != "" {
+			c.Header("Access-Control-Expose-Headers", exposed)
+		}
+
+		// Preflight
+		if c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != "" {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			c.Header("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
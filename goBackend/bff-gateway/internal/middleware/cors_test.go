@@ -0,0 +1,133 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCORSRouter(cfg CORSConfig) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(CORSMiddleware(cfg))
+	r.GET("/api/projects", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"ok": true})
+	})
+	return r
+}
+
+func TestCORSMiddleware_AllowedOrigin(t *testing.T) {
+	r := newCORSRouter(DefaultCORSConfig())
+
+	req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "http://localhost:3000" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want the request origin", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Access-Control-Allow-Credentials = %q, want true", got)
+	}
+	if got := w.Header().Get("Vary"); got != "Origin" {
+		t.Errorf("Vary = %q, want Origin", got)
+	}
+}
+
+func TestCORSMiddleware_DisallowedOrigin(t *testing.T) {
+	r := newCORSRouter(DefaultCORSConfig())
+
+	for _, method := range []string{http.MethodGet, http.MethodOptions} {
+		req := httptest.NewRequest(method, "/api/projects", nil)
+		req.Header.Set("Origin", "https://evil.example.com")
+		req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s status = %d, want %d", method, w.Code, http.StatusForbidden)
+		}
+		if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("%s Access-Control-Allow-Origin = %q, want none", method, got)
+		}
+	}
+}
+
+func TestCORSMiddleware_Preflight(t *testing.T) {
+	cfg := DefaultCORSConfig()
+	cfg.AllowedOrigins = []string{"https://app.example.com"}
+	r := newCORSRouter(cfg)
+
+	req := httptest.NewRequest(http.MethodOptions, "/api/projects", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPatch)
+	req.Header.Set("Access-Control-Request-Headers", "Authorization")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	want := map[string]string{
+		"Access-Control-Allow-Origin":      "https://app.example.com",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Allow-Methods":     "GET, POST, PUT, PATCH, DELETE, OPTIONS",
+		"Access-Control-Allow-Headers":     "Origin, Content-Type, Authorization, X-Request-ID, If-None-Match, If-Modified-Since",
+		"Access-Control-Max-Age":           "86400",
+	}
+	for header, value := range want {
+		if got := w.Header().Get(header); got != value {
+			t.Errorf("%s = %q, want %q", header, got, value)
+		}
+	}
+}
+
+func TestCORSMiddleware_NoOrigin(t *testing.T) {
+	r := newCORSRouter(DefaultCORSConfig())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/projects", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want none for same-origin requests", got)
+	}
+}
+
+func TestCORSMiddleware_ExposesDownloadHeaders(t *testing.T) {
+	r := newCORSRouter(DefaultCORSConfig())
+	r.GET("/api/media/:id/download", func(c *gin.Context) {
+		c.Header("Content-Disposition", `attachment; filename="cv.pdf"`)
+		c.Data(http.StatusOK, "application/pdf", []byte("%PDF-1.7"))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/media/1/download", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	want := "X-Total-Count, X-Request-ID, ETag, Content-Disposition, Content-Length"
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != want {
+		t.Errorf("Access-Control-Expose-Headers = %q, want %q", got, want)
+	}
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="cv.pdf"` {
+		t.Errorf("Content-Disposition = %q", got)
+	}
+
+	// A configured list replaces the defaults
+	cfg := DefaultCORSConfig()
+	cfg.ExposedHeaders = []string{"Content-Disposition"}
+	w = httptest.NewRecorder()
+	newCORSRouter(cfg).ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Expose-Headers"); got != "Content-Disposition" {
+		t.Errorf("configured Access-Control-Expose-Headers = %q, want Content-Disposition", got)
+	}
+}
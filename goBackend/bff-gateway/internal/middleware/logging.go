@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	sharedmw "github.com/portfolio/shared/middleware"
+)
+
+// RequestIDHeader is the HTTP header carrying the request ID
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware assigns every request an ID, reusing the one sent by the
+// client if it passes sharedmw.ValidRequestID, and echoes it in the response
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !sharedmw.ValidRequestID(requestID) {
+			requestID = sharedmw.NewRequestID()
+		}
+		c.Set("request_id", requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// LoggerMiddleware writes one structured log entry per request
+func LoggerMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		attrs := []any{
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", c.ClientIP(),
+			"request_id", c.GetString("request_id"),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, "error", c.Errors.String())
+		}
+		slog.InfoContext(c.Request.Context(), "http request", attrs...)
+	}
+}
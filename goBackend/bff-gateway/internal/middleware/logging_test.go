@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(RequestIDMiddleware())
+	var seen string
+	r.GET("/api/projects", func(c *gin.Context) {
+		seen = c.GetString("request_id")
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{"Valid ID is reused", "req-123", true},
+		{"Missing ID is generated", "", false},
+		{"Overlong ID is replaced", strings.Repeat("a", 65), false},
+		{"Unsafe characters are replaced", "req 123\t<x>", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/projects", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			got := w.Header().Get(RequestIDHeader)
+			if got != seen {
+				t.Errorf("response ID %q != context ID %q", got, seen)
+			}
+			if tt.keep && got != tt.header {
+				t.Errorf("request ID = %q, want %q", got, tt.header)
+			}
+			if !tt.keep && (got == "" || got == tt.header) {
+				t.Errorf("request ID = %q, want a freshly generated one", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMetricsMiddleware_CountsRequests(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := NewHTTPMetrics(prometheus.NewRegistry())
+	r := gin.New()
+	r.Use(MetricsMiddleware(m))
+	r.GET("/api/tasks/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/api/fail", func(c *gin.Context) { c.Status(http.StatusInternalServerError) })
+
+	for _, path := range []string{"/api/tasks/1", "/api/tasks/2", "/api/fail"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", "/api/tasks/:id", "200")); got != 2 {
+		t.Errorf("requests for /api/tasks/:id = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.errors.WithLabelValues("GET", "/api/fail")); got != 1 {
+		t.Errorf("errors for /api/fail = %v, want 1", got)
+	}
+}
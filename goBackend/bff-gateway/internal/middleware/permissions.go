@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	authpb "github.com/portfolio/proto/auth"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+)
+
+// Capabilities a role can be granted through the role_permissions table
+const (
+	PermManageUsers       = "users:manage"
+	PermDeleteComments    = "comments:delete"
+	PermDeleteAttachments = "attachments:delete"
+	PermDeleteTags        = "tags:delete"
+	PermManageWebhooks    = "webhooks:manage"
+)
+
+// superuserRole is granted every permission without any rows in role_permissions
+const superuserRole = "admin"
+
+// PermissionLoader returns the permissions of every role, keyed by role name
+type PermissionLoader func(ctx context.Context) (map[string][]string, error)
+
+// AuthPermissionLoader loads role permissions from the auth service
+func AuthPermissionLoader(client authpb.AuthServiceClient) PermissionLoader {
+	return func(ctx context.Context) (map[string][]string, error) {
+		resp, err := client.GetRoles(ctx, &authpb.Empty{})
+		if err != nil {
+			return nil, err
+		}
+		perms := make(map[string][]string, len(resp.Roles))
+		for _, role := range resp.Roles {
+			perms[role.Name] = role.Permissions
+		}
+		return perms, nil
+	}
+}
+
+// permissionLoadTimeout bounds a reload shared by every waiting request, so
+// it doesn't depend on the deadline of whichever request started it
+const permissionLoadTimeout = 5 * time.Second
+
+// RolePermissions caches the role to permission mapping for ttl so that
+// each request doesn't need a round trip to the auth service. Lookups read
+// the current snapshot without locking; when it expires, one reload runs and
+// concurrent lookups wait for that instead of starting their own.
+type RolePermissions struct {
+	load PermissionLoader
+	ttl  time.Duration
+
+	current atomic.Pointer[permissionSnapshot]
+	reloads singleflight.Group
+}
+
+// permissionSnapshot is one load of the role to permission mapping
+type permissionSnapshot struct {
+	perms    map[string]map[string]bool
+	loadedAt time.Time
+}
+
+func (s *permissionSnapshot) fresh(ttl time.Duration) bool {
+	return s != nil && time.Since(s.loadedAt) < ttl
+}
+
+// NewRolePermissions creates a RolePermissions backed by load
+func NewRolePermissions(load PermissionLoader, ttl time.Duration) *RolePermissions {
+	return &RolePermissions{load: load, ttl: ttl}
+}
+
+// Allows reports whether role has been granted permission. The admin role
+// is a superuser and is always allowed.
+func (p *RolePermissions) Allows(ctx context.Context, role, permission string) (bool, error) {
+	if role == superuserRole {
+		return true, nil
+	}
+
+	snapshot := p.current.Load()
+	if !snapshot.fresh(p.ttl) {
+		var err error
+		if snapshot, err = p.reload(ctx); err != nil {
+			return false, err
+		}
+	}
+	return snapshot.perms[role][permission], nil
+}
+
+// reload loads the mapping once for all callers waiting on it
+func (p *RolePermissions) reload(ctx context.Context) (*permissionSnapshot, error) {
+	v, err, _ := p.reloads.Do("", func() (any, error) {
+		// A reload that finished while this one was being set up is good enough
+		if snapshot := p.current.Load(); snapshot.fresh(p.ttl) {
+			return snapshot, nil
+		}
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), permissionLoadTimeout)
+		defer cancel()
+		loaded, err := p.load(loadCtx)
+		if err != nil {
+			return nil, err
+		}
+		snapshot := &permissionSnapshot{
+			perms:    make(map[string]map[string]bool, len(loaded)),
+			loadedAt: time.Now(),
+		}
+		for name, list := range loaded {
+			set := make(map[string]bool, len(list))
+			for _, perm := range list {
+				set[perm] = true
+			}
+			snapshot.perms[name] = set
+		}
+		p.current.Store(snapshot)
+		return snapshot, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*permissionSnapshot), nil
+}
+
+// RequirePermission only lets through users whose role has been granted permission
+func RequirePermission(perms *RolePermissions, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("role")
+		if role == "" {
+			apierror.Respond(c, codes.PermissionDenied, "Access denied")
+			return
+		}
+
+		allowed, err := perms.Allows(c.Request.Context(), role, permission)
+		if err != nil {
+			apierror.Respond(c, codes.Unavailable, "Failed to load role permissions")
+			return
+		}
+		if !allowed {
+			apierror.Respond(c, codes.PermissionDenied, "Insufficient permissions")
+			return
+		}
+
+		c.Next()
+	}
+}
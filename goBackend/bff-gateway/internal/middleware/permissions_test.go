@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newPermissionRouter(perms *RolePermissions, role string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set("role", role) })
+	r.DELETE("/tags/:id", RequirePermission(perms, PermDeleteTags), func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+	r.GET("/users", RequirePermission(perms, PermManageUsers), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return r
+}
+
+func TestRequirePermission(t *testing.T) {
+	loader := func(ctx context.Context) (map[string][]string, error) {
+		return map[string][]string{
+			"admin":     {},
+			"moderator": {PermDeleteTags},
+			"user":      {},
+		}, nil
+	}
+	perms := NewRolePermissions(loader, time.Minute)
+
+	tests := []struct {
+		name       string
+		role       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{"Custom role granted", "moderator", http.MethodDelete, "/tags/1", http.StatusNoContent},
+		{"Custom role denied", "moderator", http.MethodGet, "/users", http.StatusForbidden},
+		{"Role without permissions", "user", http.MethodDelete, "/tags/1", http.StatusForbidden},
+		{"Unknown role", "guest", http.MethodDelete, "/tags/1", http.StatusForbidden},
+		{"Missing role", "", http.MethodDelete, "/tags/1", http.StatusForbidden},
+		{"Admin is superuser", "admin", http.MethodGet, "/users", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newPermissionRouter(perms, tt.role)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d (%s)", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequirePermission_LoaderError(t *testing.T) {
+	perms := NewRolePermissions(func(ctx context.Context) (map[string][]string, error) {
+		return nil, errors.New("auth service down")
+	}, time.Minute)
+
+	w := httptest.NewRecorder()
+	newPermissionRouter(perms, "moderator").ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tags/1", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+
+	// Admin doesn't depend on the permission table
+	w = httptest.NewRecorder()
+	newPermissionRouter(perms, "admin").ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/tags/1", nil))
+	if w.Code != http.StatusNoContent {
+		t.Errorf("admin status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+}
+
+func TestRolePermissions_Reload(t *testing.T) {
+	granted := []string{}
+	loads := 0
+	perms := NewRolePermissions(func(ctx context.Context) (map[string][]string, error) {
+		loads++
+		return map[string][]string{"moderator": granted}, nil
+	}, time.Millisecond)
+
+	ctx := context.Background()
+	if ok, _ := perms.Allows(ctx, "moderator", PermDeleteTags); ok {
+		t.Fatal("moderator allowed before being granted tags:delete")
+	}
+
+	granted = []string{PermDeleteTags}
+	time.Sleep(2 * time.Millisecond)
+	if ok, _ := perms.Allows(ctx, "moderator", PermDeleteTags); !ok {
+		t.Fatal("moderator denied after grant and cache expiry")
+	}
+	if loads != 2 {
+		t.Errorf("loads = %d, want 2", loads)
+	}
+}
+
+func TestRolePermissions_ConcurrentReloadLoadsOnce(t *testing.T) {
+	var loads atomic.Int32
+	release := make(chan struct{})
+	perms := NewRolePermissions(func(ctx context.Context) (map[string][]string, error) {
+		loads.Add(1)
+		<-release
+		return map[string][]string{"moderator": {PermDeleteTags}}, nil
+	}, time.Minute)
+
+	var wg sync.WaitGroup
+	denied := make(chan struct{}, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := perms.Allows(context.Background(), "moderator", PermDeleteTags); !ok || err != nil {
+				denied <- struct{}{}
+			}
+		}()
+	}
+	// Let the lookups pile up on the blocked load before releasing it
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := loads.Load(); n != 1 {
+		t.Errorf("loads = %d, want 1", n)
+	}
+	if len(denied) != 0 {
+		t.Errorf("%d lookups were denied, want all allowed", len(denied))
+	}
+}
+
+func TestRolePermissions_LoadOutlivesCancelledRequest(t *testing.T) {
+	perms := NewRolePermissions(func(ctx context.Context) (map[string][]string, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return map[string][]string{"moderator": {PermDeleteTags}}, nil
+	}, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if ok, err := perms.Allows(ctx, "moderator", PermDeleteTags); !ok || err != nil {
+		t.Errorf("Allows() = %v, %v; want the shared load not tied to the request", ok, err)
+	}
+}
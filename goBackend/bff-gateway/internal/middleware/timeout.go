@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TimeoutMiddleware sets the timeout handlers use for backend calls.
+// Applied on a single route it overrides the global value.
+func TimeoutMiddleware(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("request_timeout", timeout)
+		c.Next()
+	}
+}
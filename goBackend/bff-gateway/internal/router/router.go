@@ -2,33 +2,55 @@ package router
 
 import (
 	"github.com/gin-gonic/gin"
+	"github.com/portfolio/bff-gateway/internal/apierror"
+	"github.com/portfolio/bff-gateway/internal/config"
 	"github.com/portfolio/bff-gateway/internal/grpc"
 	"github.com/portfolio/bff-gateway/internal/handler"
 	"github.com/portfolio/bff-gateway/internal/middleware"
+	authpb "github.com/portfolio/proto/auth"
+	"github.com/portfolio/shared/jwt"
+	"github.com/portfolio/shared/metrics"
+	"github.com/portfolio/shared/pagination"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 // SetupRouter configures all routes
-func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
-	r := gin.Default()
+func SetupRouter(cfg *config.Config, clients *grpc.ClientManager, tokens *jwt.TokenService) *gin.Engine {
+	r := gin.New()
+	registry := metrics.NewRegistry()
 
 	// Global middleware
-	r.Use(middleware.CORSMiddleware())
+	r.Use(middleware.RequestIDMiddleware())
+	r.Use(middleware.LoggerMiddleware())
+	r.Use(middleware.MetricsMiddleware(middleware.NewHTTPMetrics(registry)))
+	r.Use(middleware.CORSMiddleware(corsConfig(cfg)))
 	r.Use(gin.Recovery())
+	r.Use(middleware.TimeoutMiddleware(cfg.RequestTimeout))
 
 	// Health check
 	r.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "ok"})
 	})
 
+	// Prometheus metrics
+	r.GET("/metrics", gin.WrapH(promhttp.HandlerFor(registry, promhttp.HandlerOpts{})))
+
 	// API routes
 	api := r.Group("/api")
 
 	// Initialize handlers
 	authHandler := handler.NewAuthHandler(clients.GetAuthConn())
-	projectHandler := handler.NewProjectHandler(clients.GetProjectConn())
-	taskHandler := handler.NewTaskHandler(clients.GetTaskConn())
-	analyticsHandler := handler.NewAnalyticsHandler(clients.GetAnalyticsConn())
-	mediaHandler := handler.NewMediaHandler(clients.GetMediaConn())
+	projectHandler := handler.NewProjectHandler(clients.GetProjectConn(), clients.GetAnalyticsConn())
+	taskHandler := handler.NewTaskHandler(clients.GetTaskConn(), clients.GetAuthConn())
+	analyticsHandler := handler.NewAnalyticsHandler(clients.GetAnalyticsConn(), clients.GetAuthConn(), clients.GetProjectConn())
+	mediaHandler := handler.NewMediaHandler(clients.GetMediaConn(), clients.GetTaskConn(), clients.GetAuthConn())
+	feedHandler := handler.NewFeedHandler(clients.GetAnalyticsConn(), clients.GetTaskConn(), pagination.New(cfg.DefaultPageSize, cfg.MaxPageSize))
+
+	// Role capabilities come from the auth service's role_permissions table
+	perms := middleware.NewRolePermissions(
+		middleware.AuthPermissionLoader(authpb.NewAuthServiceClient(clients.GetAuthConn())),
+		cfg.RolePermissionsTTL,
+	)
 
 	// ==========================================
 	// Auth routes (public)
@@ -44,21 +66,32 @@ func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
 	// Protected routes (require authentication)
 	// ==========================================
 	protected := api.Group("")
-	protected.Use(middleware.AuthMiddleware(jwtSecret))
+	var revoked middleware.RevocationChecker
+	if cfg.CheckTokenRevocation {
+		revoked = middleware.AuthRevocationChecker(authpb.NewAuthServiceClient(clients.GetAuthConn()))
+	}
+	protected.Use(middleware.AuthMiddleware(tokens, revoked))
 	{
 		// Auth - Profile
 		protected.GET("/auth/profile", authHandler.GetProfile)
+		protected.POST("/auth/logout", authHandler.Logout)
+		protected.GET("/auth/projects", authHandler.ListUserProjects)
+		protected.GET("/feed", feedHandler.GetFeed)
 
-		// Users (admin only)
+		// Users (requires users:manage)
 		users := protected.Group("/users")
-		users.Use(middleware.RoleMiddleware("admin"))
+		users.Use(middleware.RequirePermission(perms, middleware.PermManageUsers))
 		{
 			users.GET("", authHandler.ListUsers)
 			users.GET("/:id", authHandler.GetUser)
 			users.PUT("/:id", authHandler.UpdateUser)
 			users.DELETE("/:id", authHandler.DeleteUser)
+			users.GET("/:id/projects", authHandler.ListUserProjects)
 		}
 
+		// Audit log (the auth service only allows admins)
+		protected.GET("/audit-log", authHandler.GetAuditLog)
+
 		// ==========================================
 		// Projects
 		// ==========================================
@@ -67,8 +100,19 @@ func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
 			projects.POST("", projectHandler.CreateProject)
 			projects.GET("", projectHandler.ListProjects)
 			projects.GET("/:id", projectHandler.GetProject)
+			projects.GET("/:id/overview", projectHandler.GetProjectOverview)
+			projects.GET("/:id/events", taskHandler.ProjectEvents)
+			projects.GET("/:id/tasks.csv", taskHandler.ExportTasksCSV)
+			projects.GET("/:id/tasks/summary", taskHandler.GetTaskSummary)
+			projects.GET("/:id/files", apierror.Handle(mediaHandler.ListProjectFiles))
 			projects.PUT("/:id", projectHandler.UpdateProject)
 			projects.DELETE("/:id", projectHandler.DeleteProject)
+			projects.POST("/:id/complete", projectHandler.CompleteProject)
+			projects.POST("/:id/clone", projectHandler.CloneProject)
+			projects.POST("/:id/archive", projectHandler.ArchiveProject)
+			projects.POST("/:id/unarchive", projectHandler.UnarchiveProject)
+			projects.GET("/:id/settings", projectHandler.GetProjectSettings)
+			projects.PUT("/:id/settings", projectHandler.UpdateProjectSettings)
 
 			// Project skills
 			projects.POST("/:id/skills", projectHandler.AddSkill)
@@ -81,6 +125,7 @@ func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
 
 			// Project links
 			projects.POST("/:id/links", projectHandler.AddLink)
+			projects.GET("/:id/links", projectHandler.ListLinks)
 
 			// Project members
 			projects.POST("/:id/members", projectHandler.AddMember)
@@ -101,24 +146,42 @@ func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
 		{
 			tasks.POST("", taskHandler.CreateTask)
 			tasks.GET("", taskHandler.ListTasks)
+			tasks.GET("/ids", taskHandler.ListTaskIDs)
+			tasks.GET("/export", taskHandler.ExportTasks)
+			tasks.DELETE("/bulk", taskHandler.BulkDeleteTasks)
 			tasks.GET("/:id", taskHandler.GetTask)
 			tasks.PUT("/:id", taskHandler.UpdateTask)
+			tasks.PATCH("/:id", taskHandler.PatchTask)
 			tasks.DELETE("/:id", taskHandler.DeleteTask)
+			tasks.POST("/:id/demote", taskHandler.DemoteTask)
+			tasks.POST("/:id/duplicate", taskHandler.DuplicateTask)
+			tasks.POST("/:id/move", taskHandler.MoveTask)
 
 			// Subtasks
 			tasks.POST("/:id/subtasks", taskHandler.CreateSubtask)
+			tasks.POST("/:id/subtasks/bulk", taskHandler.CreateSubtasks)
 			tasks.GET("/:id/subtasks", taskHandler.ListSubtasks)
+			tasks.PATCH("/:id/subtasks/:subtaskId/move", taskHandler.MoveSubtask)
+			tasks.POST("/:id/subtasks/:subtaskId/promote", taskHandler.PromoteSubtask)
 
 			// Comments
 			tasks.POST("/:id/comments", taskHandler.AddComment)
 			tasks.GET("/:id/comments", taskHandler.ListComments)
+			tasks.DELETE("/:id/comments", middleware.RequirePermission(perms, middleware.PermDeleteComments), taskHandler.DeleteComments)
 
 			// Attachments
 			tasks.POST("/:id/attachments", taskHandler.AddAttachment)
 			tasks.GET("/:id/attachments", taskHandler.ListAttachments)
+			tasks.DELETE("/:id/attachments", middleware.RequirePermission(perms, middleware.PermDeleteAttachments), taskHandler.DeleteAttachments)
 
 			// Tags
 			tasks.POST("/:id/tags", taskHandler.AddTag)
+			tasks.DELETE("/:id/tags/:tagId", taskHandler.RemoveTag)
+
+			// Watchers
+			tasks.GET("/:id/watchers", taskHandler.ListWatchers)
+			tasks.POST("/:id/watchers", taskHandler.AddWatcher)
+			tasks.DELETE("/:id/watchers/:userId", taskHandler.RemoveWatcher)
 		}
 
 		// Tags
@@ -126,6 +189,7 @@ func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
 		{
 			tags.GET("", taskHandler.ListTags)
 			tags.POST("", taskHandler.CreateTag)
+			tags.DELETE("/:id", middleware.RequirePermission(perms, middleware.PermDeleteTags), taskHandler.DeleteTag)
 		}
 
 		// ==========================================
@@ -135,11 +199,16 @@ func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
 		{
 			// Dashboard
 			analytics.GET("/dashboard", analyticsHandler.GetDashboardStats)
+			analytics.GET("/trending", analyticsHandler.GetTrendingProjects)
+			analytics.GET("/recently-viewed", analyticsHandler.GetRecentlyViewedProjects)
 
 			// Project analytics
 			analytics.POST("/projects/:id/view", analyticsHandler.RecordProjectView)
+			analytics.POST("/views/batch", analyticsHandler.BatchRecordProjectViews)
 			analytics.GET("/projects/:id/views", analyticsHandler.GetProjectViews)
+			analytics.GET("/projects/:id/views.csv", analyticsHandler.ExportProjectViewsCSV)
 			analytics.GET("/projects/:id/stats", analyticsHandler.GetProjectStats)
+			analytics.GET("/projects/:id/cycle-time", analyticsHandler.GetCycleTime)
 
 			// Task analytics
 			analytics.POST("/tasks/:id/activity", analyticsHandler.RecordTaskActivity)
@@ -151,13 +220,44 @@ func SetupRouter(jwtSecret string, clients *grpc.ClientManager) *gin.Engine {
 		// ==========================================
 		media := protected.Group("/media")
 		{
-			media.POST("/upload", mediaHandler.UploadFile)
-			media.GET("", mediaHandler.ListFiles)
-			media.GET("/my-files", mediaHandler.GetUserFiles)
-			media.GET("/:id", mediaHandler.GetFile)
-			media.DELETE("/:id", mediaHandler.DeleteFile)
+			media.POST("/upload", middleware.TimeoutMiddleware(cfg.UploadTimeout), mediaHandler.UploadFile)
+			media.GET("", apierror.Handle(mediaHandler.ListFiles))
+			media.GET("/my-files", apierror.Handle(mediaHandler.GetUserFiles))
+			media.GET("/:id", apierror.Handle(mediaHandler.GetFile))
+			media.GET("/:id/download", mediaHandler.DownloadFile)
+			media.DELETE("/:id", apierror.Handle(mediaHandler.DeleteFile))
+		}
+
+		// ==========================================
+		// Webhooks (requires webhooks:manage)
+		// ==========================================
+		webhooks := protected.Group("/webhooks")
+		webhooks.Use(middleware.RequirePermission(perms, middleware.PermManageWebhooks))
+		{
+			webhooks.GET("", projectHandler.ListWebhooks)
+			webhooks.POST("", projectHandler.CreateWebhook)
+			webhooks.DELETE("/:id", projectHandler.DeleteWebhook)
+			webhooks.GET("/:id/deliveries", projectHandler.ListWebhookDeliveries)
 		}
 	}
 
 	return r
 }
+
+// corsConfig applies the configured CORS overrides to the development defaults
+func corsConfig(cfg *config.Config) middleware.CORSConfig {
+	cors := middleware.DefaultCORSConfig()
+	if len(cfg.AllowedOrigins) > 0 {
+		cors.AllowedOrigins = cfg.AllowedOrigins
+	}
+	if len(cfg.AllowedMethods) > 0 {
+		cors.AllowedMethods = cfg.AllowedMethods
+	}
+	if len(cfg.AllowedHeaders) > 0 {
+		cors.AllowedHeaders = cfg.AllowedHeaders
+	}
+	if len(cfg.ExposedHeaders) > 0 {
+		cors.ExposedHeaders = cfg.ExposedHeaders
+	}
+	return cors
+}
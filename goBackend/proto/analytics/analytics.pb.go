@@ -130,7 +130,7 @@ func (x *ProjectView) GetViewedAt() *timestamppb.Timestamp {
 type RecordProjectViewRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ignored; the viewer is the caller in the x-user-id metadata
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -179,18 +179,178 @@ func (x *RecordProjectViewRequest) GetUserId() int64 {
 	return 0
 }
 
+// One view in a batch. user_id defaults to the caller; only admins may
+// record views for other users. viewed_at defaults to the time of the call.
+type ProjectViewEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ViewedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=viewed_at,json=viewedAt,proto3" json:"viewed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectViewEntry) Reset() {
+	*x = ProjectViewEntry{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectViewEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectViewEntry) ProtoMessage() {}
+
+func (x *ProjectViewEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectViewEntry.ProtoReflect.Descriptor instead.
+func (*ProjectViewEntry) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProjectViewEntry) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ProjectViewEntry) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ProjectViewEntry) GetViewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ViewedAt
+	}
+	return nil
+}
+
+type BatchRecordProjectViewsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Views         []*ProjectViewEntry    `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"` // at most 500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRecordProjectViewsRequest) Reset() {
+	*x = BatchRecordProjectViewsRequest{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRecordProjectViewsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRecordProjectViewsRequest) ProtoMessage() {}
+
+func (x *BatchRecordProjectViewsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRecordProjectViewsRequest.ProtoReflect.Descriptor instead.
+func (*BatchRecordProjectViewsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BatchRecordProjectViewsRequest) GetViews() []*ProjectViewEntry {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+type BatchRecordProjectViewsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Recorded      int32                  `protobuf:"varint,1,opt,name=recorded,proto3" json:"recorded,omitempty"`
+	Skipped       int32                  `protobuf:"varint,2,opt,name=skipped,proto3" json:"skipped,omitempty"` // invalid entries and repeats within the dedup window
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchRecordProjectViewsResponse) Reset() {
+	*x = BatchRecordProjectViewsResponse{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchRecordProjectViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchRecordProjectViewsResponse) ProtoMessage() {}
+
+func (x *BatchRecordProjectViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchRecordProjectViewsResponse.ProtoReflect.Descriptor instead.
+func (*BatchRecordProjectViewsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BatchRecordProjectViewsResponse) GetRecorded() int32 {
+	if x != nil {
+		return x.Recorded
+	}
+	return 0
+}
+
+func (x *BatchRecordProjectViewsResponse) GetSkipped() int32 {
+	if x != nil {
+		return x.Skipped
+	}
+	return 0
+}
+
 type GetProjectViewsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
 	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetProjectViewsRequest) Reset() {
 	*x = GetProjectViewsRequest{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[3]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -202,7 +362,7 @@ func (x *GetProjectViewsRequest) String() string {
 func (*GetProjectViewsRequest) ProtoMessage() {}
 
 func (x *GetProjectViewsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[3]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -215,53 +375,383 @@ func (x *GetProjectViewsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProjectViewsRequest.ProtoReflect.Descriptor instead.
 func (*GetProjectViewsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{3}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetProjectViewsRequest) GetProjectId() int64 {
 	if x != nil {
-		return x.ProjectId
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *GetProjectViewsRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetProjectViewsRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetProjectViewsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetProjectViewsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ProjectViewsResponse struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Views      []*ProjectView         `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
+	TotalViews int32                  `protobuf:"varint,2,opt,name=total_views,json=totalViews,proto3" json:"total_views,omitempty"` // views matching the date range
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectViewsResponse) Reset() {
+	*x = ProjectViewsResponse{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectViewsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectViewsResponse) ProtoMessage() {}
+
+func (x *ProjectViewsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectViewsResponse.ProtoReflect.Descriptor instead.
+func (*ProjectViewsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProjectViewsResponse) GetViews() []*ProjectView {
+	if x != nil {
+		return x.Views
+	}
+	return nil
+}
+
+func (x *ProjectViewsResponse) GetTotalViews() int32 {
+	if x != nil {
+		return x.TotalViews
+	}
+	return 0
+}
+
+func (x *ProjectViewsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetTrendingProjectsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Since *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=since,proto3" json:"since,omitempty"` // start of the range, which ends now
+	Limit int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Projects that may be ranked. Unless all_projects is set only these are
+	// considered, so an empty list yields no results.
+	ProjectIds    []int64 `protobuf:"varint,3,rep,packed,name=project_ids,json=projectIds,proto3" json:"project_ids,omitempty"`
+	AllProjects   bool    `protobuf:"varint,4,opt,name=all_projects,json=allProjects,proto3" json:"all_projects,omitempty"`
+	Sort          string  `protobuf:"bytes,5,opt,name=sort,proto3" json:"sort,omitempty"` // trending (default) or views
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTrendingProjectsRequest) Reset() {
+	*x = GetTrendingProjectsRequest{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTrendingProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTrendingProjectsRequest) ProtoMessage() {}
+
+func (x *GetTrendingProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTrendingProjectsRequest.ProtoReflect.Descriptor instead.
+func (*GetTrendingProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetTrendingProjectsRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetTrendingProjectsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTrendingProjectsRequest) GetProjectIds() []int64 {
+	if x != nil {
+		return x.ProjectIds
+	}
+	return nil
+}
+
+func (x *GetTrendingProjectsRequest) GetAllProjects() bool {
+	if x != nil {
+		return x.AllProjects
+	}
+	return false
+}
+
+func (x *GetTrendingProjectsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+type ProjectTrend struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Views     int32                  `protobuf:"varint,2,opt,name=views,proto3" json:"views,omitempty"`
+	// Views weighted by recency: one just now counts 1, one at the start of
+	// the range close to 0
+	Score         float64 `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectTrend) Reset() {
+	*x = ProjectTrend{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectTrend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectTrend) ProtoMessage() {}
+
+func (x *ProjectTrend) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectTrend.ProtoReflect.Descriptor instead.
+func (*ProjectTrend) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProjectTrend) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ProjectTrend) GetViews() int32 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *ProjectTrend) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+type TrendingProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*ProjectTrend        `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrendingProjectsResponse) Reset() {
+	*x = TrendingProjectsResponse{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrendingProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrendingProjectsResponse) ProtoMessage() {}
+
+func (x *TrendingProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrendingProjectsResponse.ProtoReflect.Descriptor instead.
+func (*TrendingProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TrendingProjectsResponse) GetProjects() []*ProjectTrend {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+// user_id must be the caller unless the caller is an admin
+type GetRecentlyViewedProjectsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Limit  int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Projects that may be listed. Unless all_projects is set only these are
+	// considered, so an empty list yields no results.
+	ProjectIds    []int64 `protobuf:"varint,3,rep,packed,name=project_ids,json=projectIds,proto3" json:"project_ids,omitempty"`
+	AllProjects   bool    `protobuf:"varint,4,opt,name=all_projects,json=allProjects,proto3" json:"all_projects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRecentlyViewedProjectsRequest) Reset() {
+	*x = GetRecentlyViewedProjectsRequest{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRecentlyViewedProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRecentlyViewedProjectsRequest) ProtoMessage() {}
+
+func (x *GetRecentlyViewedProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRecentlyViewedProjectsRequest.ProtoReflect.Descriptor instead.
+func (*GetRecentlyViewedProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetRecentlyViewedProjectsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetRecentlyViewedProjectsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
-func (x *GetProjectViewsRequest) GetStartDate() *timestamppb.Timestamp {
+func (x *GetRecentlyViewedProjectsRequest) GetProjectIds() []int64 {
 	if x != nil {
-		return x.StartDate
+		return x.ProjectIds
 	}
 	return nil
 }
 
-func (x *GetProjectViewsRequest) GetEndDate() *timestamppb.Timestamp {
+func (x *GetRecentlyViewedProjectsRequest) GetAllProjects() bool {
 	if x != nil {
-		return x.EndDate
+		return x.AllProjects
 	}
-	return nil
+	return false
 }
 
-type ProjectViewsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Views         []*ProjectView         `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
-	TotalViews    int32                  `protobuf:"varint,2,opt,name=total_views,json=totalViews,proto3" json:"total_views,omitempty"`
+type RecentlyViewedProjectsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The user's latest view of each project they viewed, most recent first
+	Views         []*ProjectView `protobuf:"bytes,1,rep,name=views,proto3" json:"views,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProjectViewsResponse) Reset() {
-	*x = ProjectViewsResponse{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[4]
+func (x *RecentlyViewedProjectsResponse) Reset() {
+	*x = RecentlyViewedProjectsResponse{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProjectViewsResponse) String() string {
+func (x *RecentlyViewedProjectsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProjectViewsResponse) ProtoMessage() {}
+func (*RecentlyViewedProjectsResponse) ProtoMessage() {}
 
-func (x *ProjectViewsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[4]
+func (x *RecentlyViewedProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -272,25 +762,18 @@ func (x *ProjectViewsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProjectViewsResponse.ProtoReflect.Descriptor instead.
-func (*ProjectViewsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use RecentlyViewedProjectsResponse.ProtoReflect.Descriptor instead.
+func (*RecentlyViewedProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ProjectViewsResponse) GetViews() []*ProjectView {
+func (x *RecentlyViewedProjectsResponse) GetViews() []*ProjectView {
 	if x != nil {
 		return x.Views
 	}
 	return nil
 }
 
-func (x *ProjectViewsResponse) GetTotalViews() int32 {
-	if x != nil {
-		return x.TotalViews
-	}
-	return 0
-}
-
 // Task Activity messages
 type TaskActivity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -305,7 +788,7 @@ type TaskActivity struct {
 
 func (x *TaskActivity) Reset() {
 	*x = TaskActivity{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[5]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -317,7 +800,7 @@ func (x *TaskActivity) String() string {
 func (*TaskActivity) ProtoMessage() {}
 
 func (x *TaskActivity) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[5]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -330,7 +813,7 @@ func (x *TaskActivity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskActivity.ProtoReflect.Descriptor instead.
 func (*TaskActivity) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{5}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *TaskActivity) GetId() int64 {
@@ -379,7 +862,7 @@ type RecordTaskActivityRequest struct {
 
 func (x *RecordTaskActivityRequest) Reset() {
 	*x = RecordTaskActivityRequest{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[6]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -391,7 +874,7 @@ func (x *RecordTaskActivityRequest) String() string {
 func (*RecordTaskActivityRequest) ProtoMessage() {}
 
 func (x *RecordTaskActivityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[6]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -404,7 +887,7 @@ func (x *RecordTaskActivityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecordTaskActivityRequest.ProtoReflect.Descriptor instead.
 func (*RecordTaskActivityRequest) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{6}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *RecordTaskActivityRequest) GetTaskId() int64 {
@@ -432,13 +915,16 @@ type GetTaskActivitiesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"` // optional: get all activities for a project
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	UserId        int64                  `protobuf:"varint,5,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional: get all of a user's activities
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetTaskActivitiesRequest) Reset() {
 	*x = GetTaskActivitiesRequest{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[7]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -450,7 +936,7 @@ func (x *GetTaskActivitiesRequest) String() string {
 func (*GetTaskActivitiesRequest) ProtoMessage() {}
 
 func (x *GetTaskActivitiesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[7]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,7 +949,7 @@ func (x *GetTaskActivitiesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTaskActivitiesRequest.ProtoReflect.Descriptor instead.
 func (*GetTaskActivitiesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{7}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetTaskActivitiesRequest) GetTaskId() int64 {
@@ -480,16 +966,40 @@ func (x *GetTaskActivitiesRequest) GetProjectId() int64 {
 	return 0
 }
 
+func (x *GetTaskActivitiesRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetTaskActivitiesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetTaskActivitiesRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
 type TaskActivitiesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Activities    []*TaskActivity        `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Activities []*TaskActivity        `protobuf:"bytes,1,rep,name=activities,proto3" json:"activities,omitempty"`
+	Total      int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *TaskActivitiesResponse) Reset() {
 	*x = TaskActivitiesResponse{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[8]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -501,7 +1011,7 @@ func (x *TaskActivitiesResponse) String() string {
 func (*TaskActivitiesResponse) ProtoMessage() {}
 
 func (x *TaskActivitiesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[8]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +1024,7 @@ func (x *TaskActivitiesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TaskActivitiesResponse.ProtoReflect.Descriptor instead.
 func (*TaskActivitiesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{8}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *TaskActivitiesResponse) GetActivities() []*TaskActivity {
@@ -524,6 +1034,20 @@ func (x *TaskActivitiesResponse) GetActivities() []*TaskActivity {
 	return nil
 }
 
+func (x *TaskActivitiesResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TaskActivitiesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 // Project Stats messages
 type ProjectStats struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -538,7 +1062,7 @@ type ProjectStats struct {
 
 func (x *ProjectStats) Reset() {
 	*x = ProjectStats{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[9]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -550,7 +1074,7 @@ func (x *ProjectStats) String() string {
 func (*ProjectStats) ProtoMessage() {}
 
 func (x *ProjectStats) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[9]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -563,7 +1087,7 @@ func (x *ProjectStats) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProjectStats.ProtoReflect.Descriptor instead.
 func (*ProjectStats) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{9}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *ProjectStats) GetProjectId() int64 {
@@ -610,7 +1134,7 @@ type GetProjectStatsRequest struct {
 
 func (x *GetProjectStatsRequest) Reset() {
 	*x = GetProjectStatsRequest{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[10]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -622,7 +1146,7 @@ func (x *GetProjectStatsRequest) String() string {
 func (*GetProjectStatsRequest) ProtoMessage() {}
 
 func (x *GetProjectStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[10]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,7 +1159,7 @@ func (x *GetProjectStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProjectStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetProjectStatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{10}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetProjectStatsRequest) GetProjectId() int64 {
@@ -654,7 +1178,7 @@ type ProjectStatsResponse struct {
 
 func (x *ProjectStatsResponse) Reset() {
 	*x = ProjectStatsResponse{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[11]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -666,7 +1190,7 @@ func (x *ProjectStatsResponse) String() string {
 func (*ProjectStatsResponse) ProtoMessage() {}
 
 func (x *ProjectStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[11]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -679,7 +1203,7 @@ func (x *ProjectStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProjectStatsResponse.ProtoReflect.Descriptor instead.
 func (*ProjectStatsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{11}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *ProjectStatsResponse) GetStats() *ProjectStats {
@@ -700,7 +1224,7 @@ type UpdateProjectStatsRequest struct {
 
 func (x *UpdateProjectStatsRequest) Reset() {
 	*x = UpdateProjectStatsRequest{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[12]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -712,7 +1236,7 @@ func (x *UpdateProjectStatsRequest) String() string {
 func (*UpdateProjectStatsRequest) ProtoMessage() {}
 
 func (x *UpdateProjectStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[12]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -725,7 +1249,7 @@ func (x *UpdateProjectStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProjectStatsRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProjectStatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{12}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *UpdateProjectStatsRequest) GetProjectId() int64 {
@@ -749,17 +1273,152 @@ func (x *UpdateProjectStatsRequest) GetCompletedTasks() int64 {
 	return 0
 }
 
+// Lead time from creation to completion of the project's tasks completed
+// between since and until
+type GetCycleTimeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`
+	Until         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCycleTimeRequest) Reset() {
+	*x = GetCycleTimeRequest{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCycleTimeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCycleTimeRequest) ProtoMessage() {}
+
+func (x *GetCycleTimeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCycleTimeRequest.ProtoReflect.Descriptor instead.
+func (*GetCycleTimeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetCycleTimeRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *GetCycleTimeRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *GetCycleTimeRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+type CycleTimeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId      int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	CompletedTasks int32                  `protobuf:"varint,2,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	// Zero when no task was completed in the range
+	AverageLeadTimeSeconds float64 `protobuf:"fixed64,3,opt,name=average_lead_time_seconds,json=averageLeadTimeSeconds,proto3" json:"average_lead_time_seconds,omitempty"`
+	MedianLeadTimeSeconds  float64 `protobuf:"fixed64,4,opt,name=median_lead_time_seconds,json=medianLeadTimeSeconds,proto3" json:"median_lead_time_seconds,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *CycleTimeResponse) Reset() {
+	*x = CycleTimeResponse{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CycleTimeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CycleTimeResponse) ProtoMessage() {}
+
+func (x *CycleTimeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CycleTimeResponse.ProtoReflect.Descriptor instead.
+func (*CycleTimeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CycleTimeResponse) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *CycleTimeResponse) GetCompletedTasks() int32 {
+	if x != nil {
+		return x.CompletedTasks
+	}
+	return 0
+}
+
+func (x *CycleTimeResponse) GetAverageLeadTimeSeconds() float64 {
+	if x != nil {
+		return x.AverageLeadTimeSeconds
+	}
+	return 0
+}
+
+func (x *CycleTimeResponse) GetMedianLeadTimeSeconds() float64 {
+	if x != nil {
+		return x.MedianLeadTimeSeconds
+	}
+	return 0
+}
+
 // Dashboard Stats messages
 type GetDashboardStatsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional: filter by user
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // optional: filter by user
+	// Projects whose stats are aggregated. Unless all_projects is set only
+	// these are counted, so an empty list yields an empty dashboard.
+	ProjectIds    []int64 `protobuf:"varint,2,rep,packed,name=project_ids,json=projectIds,proto3" json:"project_ids,omitempty"`
+	AllProjects   bool    `protobuf:"varint,3,opt,name=all_projects,json=allProjects,proto3" json:"all_projects,omitempty"` // admins see every project
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetDashboardStatsRequest) Reset() {
 	*x = GetDashboardStatsRequest{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[13]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -771,7 +1430,7 @@ func (x *GetDashboardStatsRequest) String() string {
 func (*GetDashboardStatsRequest) ProtoMessage() {}
 
 func (x *GetDashboardStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[13]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -784,7 +1443,7 @@ func (x *GetDashboardStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDashboardStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetDashboardStatsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{13}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetDashboardStatsRequest) GetUserId() int64 {
@@ -794,6 +1453,20 @@ func (x *GetDashboardStatsRequest) GetUserId() int64 {
 	return 0
 }
 
+func (x *GetDashboardStatsRequest) GetProjectIds() []int64 {
+	if x != nil {
+		return x.ProjectIds
+	}
+	return nil
+}
+
+func (x *GetDashboardStatsRequest) GetAllProjects() bool {
+	if x != nil {
+		return x.AllProjects
+	}
+	return false
+}
+
 type DashboardStatsResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	TotalProjects  int32                  `protobuf:"varint,1,opt,name=total_projects,json=totalProjects,proto3" json:"total_projects,omitempty"`
@@ -802,13 +1475,19 @@ type DashboardStatsResponse struct {
 	CompletedTasks int32                  `protobuf:"varint,4,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
 	PendingTasks   int32                  `protobuf:"varint,5,opt,name=pending_tasks,json=pendingTasks,proto3" json:"pending_tasks,omitempty"`
 	ProjectStats   []*ProjectStats        `protobuf:"bytes,6,rep,name=project_stats,json=projectStats,proto3" json:"project_stats,omitempty"`
+	// Sections below are only filled when user_id is set
+	RecentActivity []*TaskActivity     `protobuf:"bytes,7,rep,name=recent_activity,json=recentActivity,proto3" json:"recent_activity,omitempty"`
+	UserViews      int32               `protobuf:"varint,8,opt,name=user_views,json=userViews,proto3" json:"user_views,omitempty"`
+	AssignedTasks  *AssignedTaskCounts `protobuf:"bytes,9,opt,name=assigned_tasks,json=assignedTasks,proto3" json:"assigned_tasks,omitempty"`
+	// Sections that could not be loaded and were left empty
+	FailedSections []string `protobuf:"bytes,10,rep,name=failed_sections,json=failedSections,proto3" json:"failed_sections,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DashboardStatsResponse) Reset() {
 	*x = DashboardStatsResponse{}
-	mi := &file_proto_analytics_analytics_proto_msgTypes[14]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -820,7 +1499,7 @@ func (x *DashboardStatsResponse) String() string {
 func (*DashboardStatsResponse) ProtoMessage() {}
 
 func (x *DashboardStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_analytics_proto_msgTypes[14]
+	mi := &file_proto_analytics_analytics_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,7 +1512,7 @@ func (x *DashboardStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DashboardStatsResponse.ProtoReflect.Descriptor instead.
 func (*DashboardStatsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{14}
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *DashboardStatsResponse) GetTotalProjects() int32 {
@@ -878,6 +1557,94 @@ func (x *DashboardStatsResponse) GetProjectStats() []*ProjectStats {
 	return nil
 }
 
+func (x *DashboardStatsResponse) GetRecentActivity() []*TaskActivity {
+	if x != nil {
+		return x.RecentActivity
+	}
+	return nil
+}
+
+func (x *DashboardStatsResponse) GetUserViews() int32 {
+	if x != nil {
+		return x.UserViews
+	}
+	return 0
+}
+
+func (x *DashboardStatsResponse) GetAssignedTasks() *AssignedTaskCounts {
+	if x != nil {
+		return x.AssignedTasks
+	}
+	return nil
+}
+
+func (x *DashboardStatsResponse) GetFailedSections() []string {
+	if x != nil {
+		return x.FailedSections
+	}
+	return nil
+}
+
+type AssignedTaskCounts struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Completed     int32                  `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	Pending       int32                  `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssignedTaskCounts) Reset() {
+	*x = AssignedTaskCounts{}
+	mi := &file_proto_analytics_analytics_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignedTaskCounts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignedTaskCounts) ProtoMessage() {}
+
+func (x *AssignedTaskCounts) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_analytics_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignedTaskCounts.ProtoReflect.Descriptor instead.
+func (*AssignedTaskCounts) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_analytics_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AssignedTaskCounts) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AssignedTaskCounts) GetCompleted() int32 {
+	if x != nil {
+		return x.Completed
+	}
+	return 0
+}
+
+func (x *AssignedTaskCounts) GetPending() int32 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
 var File_proto_analytics_analytics_proto protoreflect.FileDescriptor
 
 const file_proto_analytics_analytics_proto_rawDesc = "" +
@@ -893,17 +1660,52 @@ const file_proto_analytics_analytics_proto_rawDesc = "" +
 	"\x18RecordProjectViewRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\xa9\x01\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"\x83\x01\n" +
+	"\x10ProjectViewEntry\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x127\n" +
+	"\tviewed_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bviewedAt\"S\n" +
+	"\x1eBatchRecordProjectViewsRequest\x121\n" +
+	"\x05views\x18\x01 \x03(\v2\x1b.analytics.ProjectViewEntryR\x05views\"W\n" +
+	"\x1fBatchRecordProjectViewsResponse\x12\x1a\n" +
+	"\brecorded\x18\x01 \x01(\x05R\brecorded\x12\x18\n" +
+	"\askipped\x18\x02 \x01(\x05R\askipped\"\xd3\x01\n" +
 	"\x16GetProjectViewsRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\x03R\tprojectId\x129\n" +
 	"\n" +
 	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"e\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"{\n" +
 	"\x14ProjectViewsResponse\x12,\n" +
 	"\x05views\x18\x01 \x03(\v2\x16.analytics.ProjectViewR\x05views\x12\x1f\n" +
 	"\vtotal_views\x18\x02 \x01(\x05R\n" +
-	"totalViews\"\xa3\x01\n" +
+	"totalViews\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xbc\x01\n" +
+	"\x1aGetTrendingProjectsRequest\x120\n" +
+	"\x05since\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vproject_ids\x18\x03 \x03(\x03R\n" +
+	"projectIds\x12!\n" +
+	"\fall_projects\x18\x04 \x01(\bR\vallProjects\x12\x12\n" +
+	"\x04sort\x18\x05 \x01(\tR\x04sort\"Y\n" +
+	"\fProjectTrend\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x14\n" +
+	"\x05views\x18\x02 \x01(\x05R\x05views\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\"O\n" +
+	"\x18TrendingProjectsResponse\x123\n" +
+	"\bprojects\x18\x01 \x03(\v2\x17.analytics.ProjectTrendR\bprojects\"\x95\x01\n" +
+	" GetRecentlyViewedProjectsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vproject_ids\x18\x03 \x03(\x03R\n" +
+	"projectIds\x12!\n" +
+	"\fall_projects\x18\x04 \x01(\bR\vallProjects\"N\n" +
+	"\x1eRecentlyViewedProjectsResponse\x12,\n" +
+	"\x05views\x18\x01 \x03(\v2\x16.analytics.ProjectViewR\x05views\"\xa3\x01\n" +
 	"\fTaskActivity\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x17\n" +
@@ -914,15 +1716,20 @@ const file_proto_analytics_analytics_proto_rawDesc = "" +
 	"\x19RecordTaskActivityRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x16\n" +
-	"\x06action\x18\x03 \x01(\tR\x06action\"R\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\"\x95\x01\n" +
 	"\x18GetTaskActivitiesRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x1d\n" +
 	"\n" +
-	"project_id\x18\x02 \x01(\x03R\tprojectId\"Q\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x05 \x01(\x03R\x06userId\"}\n" +
 	"\x16TaskActivitiesResponse\x127\n" +
 	"\n" +
 	"activities\x18\x01 \x03(\v2\x17.analytics.TaskActivityR\n" +
-	"activities\"\xe1\x01\n" +
+	"activities\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xe1\x01\n" +
 	"\fProjectStats\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x1f\n" +
@@ -941,9 +1748,23 @@ const file_proto_analytics_analytics_proto_rawDesc = "" +
 	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x1f\n" +
 	"\vtotal_tasks\x18\x02 \x01(\x03R\n" +
 	"totalTasks\x12'\n" +
-	"\x0fcompleted_tasks\x18\x03 \x01(\x03R\x0ecompletedTasks\"3\n" +
+	"\x0fcompleted_tasks\x18\x03 \x01(\x03R\x0ecompletedTasks\"\x98\x01\n" +
+	"\x13GetCycleTimeRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\"\xcf\x01\n" +
+	"\x11CycleTimeResponse\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12'\n" +
+	"\x0fcompleted_tasks\x18\x02 \x01(\x05R\x0ecompletedTasks\x129\n" +
+	"\x19average_lead_time_seconds\x18\x03 \x01(\x01R\x16averageLeadTimeSeconds\x127\n" +
+	"\x18median_lead_time_seconds\x18\x04 \x01(\x01R\x15medianLeadTimeSeconds\"w\n" +
 	"\x18GetDashboardStatsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x95\x02\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1f\n" +
+	"\vproject_ids\x18\x02 \x03(\x03R\n" +
+	"projectIds\x12!\n" +
+	"\fall_projects\x18\x03 \x01(\bR\vallProjects\"\xe5\x03\n" +
 	"\x16DashboardStatsResponse\x12%\n" +
 	"\x0etotal_projects\x18\x01 \x01(\x05R\rtotalProjects\x12'\n" +
 	"\x0factive_projects\x18\x02 \x01(\x05R\x0eactiveProjects\x12\x1f\n" +
@@ -951,15 +1772,29 @@ const file_proto_analytics_analytics_proto_rawDesc = "" +
 	"totalTasks\x12'\n" +
 	"\x0fcompleted_tasks\x18\x04 \x01(\x05R\x0ecompletedTasks\x12#\n" +
 	"\rpending_tasks\x18\x05 \x01(\x05R\fpendingTasks\x12<\n" +
-	"\rproject_stats\x18\x06 \x03(\v2\x17.analytics.ProjectStatsR\fprojectStats2\xf1\x04\n" +
+	"\rproject_stats\x18\x06 \x03(\v2\x17.analytics.ProjectStatsR\fprojectStats\x12@\n" +
+	"\x0frecent_activity\x18\a \x03(\v2\x17.analytics.TaskActivityR\x0erecentActivity\x12\x1d\n" +
+	"\n" +
+	"user_views\x18\b \x01(\x05R\tuserViews\x12D\n" +
+	"\x0eassigned_tasks\x18\t \x01(\v2\x1d.analytics.AssignedTaskCountsR\rassignedTasks\x12'\n" +
+	"\x0ffailed_sections\x18\n" +
+	" \x03(\tR\x0efailedSections\"b\n" +
+	"\x12AssignedTaskCounts\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12\x1c\n" +
+	"\tcompleted\x18\x02 \x01(\x05R\tcompleted\x12\x18\n" +
+	"\apending\x18\x03 \x01(\x05R\apending2\x89\b\n" +
 	"\x10AnalyticsService\x12J\n" +
 	"\x11RecordProjectView\x12#.analytics.RecordProjectViewRequest\x1a\x10.analytics.Empty\x12U\n" +
-	"\x0fGetProjectViews\x12!.analytics.GetProjectViewsRequest\x1a\x1f.analytics.ProjectViewsResponse\x12L\n" +
+	"\x0fGetProjectViews\x12!.analytics.GetProjectViewsRequest\x1a\x1f.analytics.ProjectViewsResponse\x12p\n" +
+	"\x17BatchRecordProjectViews\x12).analytics.BatchRecordProjectViewsRequest\x1a*.analytics.BatchRecordProjectViewsResponse\x12a\n" +
+	"\x13GetTrendingProjects\x12%.analytics.GetTrendingProjectsRequest\x1a#.analytics.TrendingProjectsResponse\x12s\n" +
+	"\x19GetRecentlyViewedProjects\x12+.analytics.GetRecentlyViewedProjectsRequest\x1a).analytics.RecentlyViewedProjectsResponse\x12L\n" +
 	"\x12RecordTaskActivity\x12$.analytics.RecordTaskActivityRequest\x1a\x10.analytics.Empty\x12[\n" +
 	"\x11GetTaskActivities\x12#.analytics.GetTaskActivitiesRequest\x1a!.analytics.TaskActivitiesResponse\x12U\n" +
 	"\x0fGetProjectStats\x12!.analytics.GetProjectStatsRequest\x1a\x1f.analytics.ProjectStatsResponse\x12[\n" +
 	"\x12UpdateProjectStats\x12$.analytics.UpdateProjectStatsRequest\x1a\x1f.analytics.ProjectStatsResponse\x12[\n" +
-	"\x11GetDashboardStats\x12#.analytics.GetDashboardStatsRequest\x1a!.analytics.DashboardStatsResponseB&Z$github.com/portfolio/proto/analyticsb\x06proto3"
+	"\x11GetDashboardStats\x12#.analytics.GetDashboardStatsRequest\x1a!.analytics.DashboardStatsResponse\x12L\n" +
+	"\fGetCycleTime\x12\x1e.analytics.GetCycleTimeRequest\x1a\x1c.analytics.CycleTimeResponseB&Z$github.com/portfolio/proto/analyticsb\x06proto3"
 
 var (
 	file_proto_analytics_analytics_proto_rawDescOnce sync.Once
@@ -973,54 +1808,82 @@ func file_proto_analytics_analytics_proto_rawDescGZIP() []byte {
 	return file_proto_analytics_analytics_proto_rawDescData
 }
 
-var file_proto_analytics_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_proto_analytics_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_proto_analytics_analytics_proto_goTypes = []any{
-	(*Empty)(nil),                     // 0: analytics.Empty
-	(*ProjectView)(nil),               // 1: analytics.ProjectView
-	(*RecordProjectViewRequest)(nil),  // 2: analytics.RecordProjectViewRequest
-	(*GetProjectViewsRequest)(nil),    // 3: analytics.GetProjectViewsRequest
-	(*ProjectViewsResponse)(nil),      // 4: analytics.ProjectViewsResponse
-	(*TaskActivity)(nil),              // 5: analytics.TaskActivity
-	(*RecordTaskActivityRequest)(nil), // 6: analytics.RecordTaskActivityRequest
-	(*GetTaskActivitiesRequest)(nil),  // 7: analytics.GetTaskActivitiesRequest
-	(*TaskActivitiesResponse)(nil),    // 8: analytics.TaskActivitiesResponse
-	(*ProjectStats)(nil),              // 9: analytics.ProjectStats
-	(*GetProjectStatsRequest)(nil),    // 10: analytics.GetProjectStatsRequest
-	(*ProjectStatsResponse)(nil),      // 11: analytics.ProjectStatsResponse
-	(*UpdateProjectStatsRequest)(nil), // 12: analytics.UpdateProjectStatsRequest
-	(*GetDashboardStatsRequest)(nil),  // 13: analytics.GetDashboardStatsRequest
-	(*DashboardStatsResponse)(nil),    // 14: analytics.DashboardStatsResponse
-	(*timestamppb.Timestamp)(nil),     // 15: google.protobuf.Timestamp
+	(*Empty)(nil),                            // 0: analytics.Empty
+	(*ProjectView)(nil),                      // 1: analytics.ProjectView
+	(*RecordProjectViewRequest)(nil),         // 2: analytics.RecordProjectViewRequest
+	(*ProjectViewEntry)(nil),                 // 3: analytics.ProjectViewEntry
+	(*BatchRecordProjectViewsRequest)(nil),   // 4: analytics.BatchRecordProjectViewsRequest
+	(*BatchRecordProjectViewsResponse)(nil),  // 5: analytics.BatchRecordProjectViewsResponse
+	(*GetProjectViewsRequest)(nil),           // 6: analytics.GetProjectViewsRequest
+	(*ProjectViewsResponse)(nil),             // 7: analytics.ProjectViewsResponse
+	(*GetTrendingProjectsRequest)(nil),       // 8: analytics.GetTrendingProjectsRequest
+	(*ProjectTrend)(nil),                     // 9: analytics.ProjectTrend
+	(*TrendingProjectsResponse)(nil),         // 10: analytics.TrendingProjectsResponse
+	(*GetRecentlyViewedProjectsRequest)(nil), // 11: analytics.GetRecentlyViewedProjectsRequest
+	(*RecentlyViewedProjectsResponse)(nil),   // 12: analytics.RecentlyViewedProjectsResponse
+	(*TaskActivity)(nil),                     // 13: analytics.TaskActivity
+	(*RecordTaskActivityRequest)(nil),        // 14: analytics.RecordTaskActivityRequest
+	(*GetTaskActivitiesRequest)(nil),         // 15: analytics.GetTaskActivitiesRequest
+	(*TaskActivitiesResponse)(nil),           // 16: analytics.TaskActivitiesResponse
+	(*ProjectStats)(nil),                     // 17: analytics.ProjectStats
+	(*GetProjectStatsRequest)(nil),           // 18: analytics.GetProjectStatsRequest
+	(*ProjectStatsResponse)(nil),             // 19: analytics.ProjectStatsResponse
+	(*UpdateProjectStatsRequest)(nil),        // 20: analytics.UpdateProjectStatsRequest
+	(*GetCycleTimeRequest)(nil),              // 21: analytics.GetCycleTimeRequest
+	(*CycleTimeResponse)(nil),                // 22: analytics.CycleTimeResponse
+	(*GetDashboardStatsRequest)(nil),         // 23: analytics.GetDashboardStatsRequest
+	(*DashboardStatsResponse)(nil),           // 24: analytics.DashboardStatsResponse
+	(*AssignedTaskCounts)(nil),               // 25: analytics.AssignedTaskCounts
+	(*timestamppb.Timestamp)(nil),            // 26: google.protobuf.Timestamp
 }
 var file_proto_analytics_analytics_proto_depIdxs = []int32{
-	15, // 0: analytics.ProjectView.viewed_at:type_name -> google.protobuf.Timestamp
-	15, // 1: analytics.GetProjectViewsRequest.start_date:type_name -> google.protobuf.Timestamp
-	15, // 2: analytics.GetProjectViewsRequest.end_date:type_name -> google.protobuf.Timestamp
-	1,  // 3: analytics.ProjectViewsResponse.views:type_name -> analytics.ProjectView
-	15, // 4: analytics.TaskActivity.created_at:type_name -> google.protobuf.Timestamp
-	5,  // 5: analytics.TaskActivitiesResponse.activities:type_name -> analytics.TaskActivity
-	15, // 6: analytics.ProjectStats.last_updated:type_name -> google.protobuf.Timestamp
-	9,  // 7: analytics.ProjectStatsResponse.stats:type_name -> analytics.ProjectStats
-	9,  // 8: analytics.DashboardStatsResponse.project_stats:type_name -> analytics.ProjectStats
-	2,  // 9: analytics.AnalyticsService.RecordProjectView:input_type -> analytics.RecordProjectViewRequest
-	3,  // 10: analytics.AnalyticsService.GetProjectViews:input_type -> analytics.GetProjectViewsRequest
-	6,  // 11: analytics.AnalyticsService.RecordTaskActivity:input_type -> analytics.RecordTaskActivityRequest
-	7,  // 12: analytics.AnalyticsService.GetTaskActivities:input_type -> analytics.GetTaskActivitiesRequest
-	10, // 13: analytics.AnalyticsService.GetProjectStats:input_type -> analytics.GetProjectStatsRequest
-	12, // 14: analytics.AnalyticsService.UpdateProjectStats:input_type -> analytics.UpdateProjectStatsRequest
-	13, // 15: analytics.AnalyticsService.GetDashboardStats:input_type -> analytics.GetDashboardStatsRequest
-	0,  // 16: analytics.AnalyticsService.RecordProjectView:output_type -> analytics.Empty
-	4,  // 17: analytics.AnalyticsService.GetProjectViews:output_type -> analytics.ProjectViewsResponse
-	0,  // 18: analytics.AnalyticsService.RecordTaskActivity:output_type -> analytics.Empty
-	8,  // 19: analytics.AnalyticsService.GetTaskActivities:output_type -> analytics.TaskActivitiesResponse
-	11, // 20: analytics.AnalyticsService.GetProjectStats:output_type -> analytics.ProjectStatsResponse
-	11, // 21: analytics.AnalyticsService.UpdateProjectStats:output_type -> analytics.ProjectStatsResponse
-	14, // 22: analytics.AnalyticsService.GetDashboardStats:output_type -> analytics.DashboardStatsResponse
-	16, // [16:23] is the sub-list for method output_type
-	9,  // [9:16] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	26, // 0: analytics.ProjectView.viewed_at:type_name -> google.protobuf.Timestamp
+	26, // 1: analytics.ProjectViewEntry.viewed_at:type_name -> google.protobuf.Timestamp
+	3,  // 2: analytics.BatchRecordProjectViewsRequest.views:type_name -> analytics.ProjectViewEntry
+	26, // 3: analytics.GetProjectViewsRequest.start_date:type_name -> google.protobuf.Timestamp
+	26, // 4: analytics.GetProjectViewsRequest.end_date:type_name -> google.protobuf.Timestamp
+	1,  // 5: analytics.ProjectViewsResponse.views:type_name -> analytics.ProjectView
+	26, // 6: analytics.GetTrendingProjectsRequest.since:type_name -> google.protobuf.Timestamp
+	9,  // 7: analytics.TrendingProjectsResponse.projects:type_name -> analytics.ProjectTrend
+	1,  // 8: analytics.RecentlyViewedProjectsResponse.views:type_name -> analytics.ProjectView
+	26, // 9: analytics.TaskActivity.created_at:type_name -> google.protobuf.Timestamp
+	13, // 10: analytics.TaskActivitiesResponse.activities:type_name -> analytics.TaskActivity
+	26, // 11: analytics.ProjectStats.last_updated:type_name -> google.protobuf.Timestamp
+	17, // 12: analytics.ProjectStatsResponse.stats:type_name -> analytics.ProjectStats
+	26, // 13: analytics.GetCycleTimeRequest.since:type_name -> google.protobuf.Timestamp
+	26, // 14: analytics.GetCycleTimeRequest.until:type_name -> google.protobuf.Timestamp
+	17, // 15: analytics.DashboardStatsResponse.project_stats:type_name -> analytics.ProjectStats
+	13, // 16: analytics.DashboardStatsResponse.recent_activity:type_name -> analytics.TaskActivity
+	25, // 17: analytics.DashboardStatsResponse.assigned_tasks:type_name -> analytics.AssignedTaskCounts
+	2,  // 18: analytics.AnalyticsService.RecordProjectView:input_type -> analytics.RecordProjectViewRequest
+	6,  // 19: analytics.AnalyticsService.GetProjectViews:input_type -> analytics.GetProjectViewsRequest
+	4,  // 20: analytics.AnalyticsService.BatchRecordProjectViews:input_type -> analytics.BatchRecordProjectViewsRequest
+	8,  // 21: analytics.AnalyticsService.GetTrendingProjects:input_type -> analytics.GetTrendingProjectsRequest
+	11, // 22: analytics.AnalyticsService.GetRecentlyViewedProjects:input_type -> analytics.GetRecentlyViewedProjectsRequest
+	14, // 23: analytics.AnalyticsService.RecordTaskActivity:input_type -> analytics.RecordTaskActivityRequest
+	15, // 24: analytics.AnalyticsService.GetTaskActivities:input_type -> analytics.GetTaskActivitiesRequest
+	18, // 25: analytics.AnalyticsService.GetProjectStats:input_type -> analytics.GetProjectStatsRequest
+	20, // 26: analytics.AnalyticsService.UpdateProjectStats:input_type -> analytics.UpdateProjectStatsRequest
+	23, // 27: analytics.AnalyticsService.GetDashboardStats:input_type -> analytics.GetDashboardStatsRequest
+	21, // 28: analytics.AnalyticsService.GetCycleTime:input_type -> analytics.GetCycleTimeRequest
+	0,  // 29: analytics.AnalyticsService.RecordProjectView:output_type -> analytics.Empty
+	7,  // 30: analytics.AnalyticsService.GetProjectViews:output_type -> analytics.ProjectViewsResponse
+	5,  // 31: analytics.AnalyticsService.BatchRecordProjectViews:output_type -> analytics.BatchRecordProjectViewsResponse
+	10, // 32: analytics.AnalyticsService.GetTrendingProjects:output_type -> analytics.TrendingProjectsResponse
+	12, // 33: analytics.AnalyticsService.GetRecentlyViewedProjects:output_type -> analytics.RecentlyViewedProjectsResponse
+	0,  // 34: analytics.AnalyticsService.RecordTaskActivity:output_type -> analytics.Empty
+	16, // 35: analytics.AnalyticsService.GetTaskActivities:output_type -> analytics.TaskActivitiesResponse
+	19, // 36: analytics.AnalyticsService.GetProjectStats:output_type -> analytics.ProjectStatsResponse
+	19, // 37: analytics.AnalyticsService.UpdateProjectStats:output_type -> analytics.ProjectStatsResponse
+	24, // 38: analytics.AnalyticsService.GetDashboardStats:output_type -> analytics.DashboardStatsResponse
+	22, // 39: analytics.AnalyticsService.GetCycleTime:output_type -> analytics.CycleTimeResponse
+	29, // [29:40] is the sub-list for method output_type
+	18, // [18:29] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_proto_analytics_analytics_proto_init() }
@@ -1034,7 +1897,7 @@ func file_proto_analytics_analytics_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_analytics_analytics_proto_rawDesc), len(file_proto_analytics_analytics_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
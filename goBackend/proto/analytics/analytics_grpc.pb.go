@@ -19,13 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AnalyticsService_RecordProjectView_FullMethodName  = "/analytics.AnalyticsService/RecordProjectView"
-	AnalyticsService_GetProjectViews_FullMethodName    = "/analytics.AnalyticsService/GetProjectViews"
-	AnalyticsService_RecordTaskActivity_FullMethodName = "/analytics.AnalyticsService/RecordTaskActivity"
-	AnalyticsService_GetTaskActivities_FullMethodName  = "/analytics.AnalyticsService/GetTaskActivities"
-	AnalyticsService_GetProjectStats_FullMethodName    = "/analytics.AnalyticsService/GetProjectStats"
-	AnalyticsService_UpdateProjectStats_FullMethodName = "/analytics.AnalyticsService/UpdateProjectStats"
-	AnalyticsService_GetDashboardStats_FullMethodName  = "/analytics.AnalyticsService/GetDashboardStats"
+	AnalyticsService_RecordProjectView_FullMethodName         = "/analytics.AnalyticsService/RecordProjectView"
+	AnalyticsService_GetProjectViews_FullMethodName           = "/analytics.AnalyticsService/GetProjectViews"
+	AnalyticsService_BatchRecordProjectViews_FullMethodName   = "/analytics.AnalyticsService/BatchRecordProjectViews"
+	AnalyticsService_GetTrendingProjects_FullMethodName       = "/analytics.AnalyticsService/GetTrendingProjects"
+	AnalyticsService_GetRecentlyViewedProjects_FullMethodName = "/analytics.AnalyticsService/GetRecentlyViewedProjects"
+	AnalyticsService_RecordTaskActivity_FullMethodName        = "/analytics.AnalyticsService/RecordTaskActivity"
+	AnalyticsService_GetTaskActivities_FullMethodName         = "/analytics.AnalyticsService/GetTaskActivities"
+	AnalyticsService_GetProjectStats_FullMethodName           = "/analytics.AnalyticsService/GetProjectStats"
+	AnalyticsService_UpdateProjectStats_FullMethodName        = "/analytics.AnalyticsService/UpdateProjectStats"
+	AnalyticsService_GetDashboardStats_FullMethodName         = "/analytics.AnalyticsService/GetDashboardStats"
+	AnalyticsService_GetCycleTime_FullMethodName              = "/analytics.AnalyticsService/GetCycleTime"
 )
 
 // AnalyticsServiceClient is the client API for AnalyticsService service.
@@ -37,6 +41,9 @@ type AnalyticsServiceClient interface {
 	// Project Views
 	RecordProjectView(ctx context.Context, in *RecordProjectViewRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetProjectViews(ctx context.Context, in *GetProjectViewsRequest, opts ...grpc.CallOption) (*ProjectViewsResponse, error)
+	BatchRecordProjectViews(ctx context.Context, in *BatchRecordProjectViewsRequest, opts ...grpc.CallOption) (*BatchRecordProjectViewsResponse, error)
+	GetTrendingProjects(ctx context.Context, in *GetTrendingProjectsRequest, opts ...grpc.CallOption) (*TrendingProjectsResponse, error)
+	GetRecentlyViewedProjects(ctx context.Context, in *GetRecentlyViewedProjectsRequest, opts ...grpc.CallOption) (*RecentlyViewedProjectsResponse, error)
 	// Task Activity
 	RecordTaskActivity(ctx context.Context, in *RecordTaskActivityRequest, opts ...grpc.CallOption) (*Empty, error)
 	GetTaskActivities(ctx context.Context, in *GetTaskActivitiesRequest, opts ...grpc.CallOption) (*TaskActivitiesResponse, error)
@@ -44,6 +51,7 @@ type AnalyticsServiceClient interface {
 	GetProjectStats(ctx context.Context, in *GetProjectStatsRequest, opts ...grpc.CallOption) (*ProjectStatsResponse, error)
 	UpdateProjectStats(ctx context.Context, in *UpdateProjectStatsRequest, opts ...grpc.CallOption) (*ProjectStatsResponse, error)
 	GetDashboardStats(ctx context.Context, in *GetDashboardStatsRequest, opts ...grpc.CallOption) (*DashboardStatsResponse, error)
+	GetCycleTime(ctx context.Context, in *GetCycleTimeRequest, opts ...grpc.CallOption) (*CycleTimeResponse, error)
 }
 
 type analyticsServiceClient struct {
@@ -74,6 +82,36 @@ func (c *analyticsServiceClient) GetProjectViews(ctx context.Context, in *GetPro
 	return out, nil
 }
 
+func (c *analyticsServiceClient) BatchRecordProjectViews(ctx context.Context, in *BatchRecordProjectViewsRequest, opts ...grpc.CallOption) (*BatchRecordProjectViewsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchRecordProjectViewsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_BatchRecordProjectViews_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetTrendingProjects(ctx context.Context, in *GetTrendingProjectsRequest, opts ...grpc.CallOption) (*TrendingProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TrendingProjectsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetTrendingProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *analyticsServiceClient) GetRecentlyViewedProjects(ctx context.Context, in *GetRecentlyViewedProjectsRequest, opts ...grpc.CallOption) (*RecentlyViewedProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecentlyViewedProjectsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetRecentlyViewedProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *analyticsServiceClient) RecordTaskActivity(ctx context.Context, in *RecordTaskActivityRequest, opts ...grpc.CallOption) (*Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Empty)
@@ -124,6 +162,16 @@ func (c *analyticsServiceClient) GetDashboardStats(ctx context.Context, in *GetD
 	return out, nil
 }
 
+func (c *analyticsServiceClient) GetCycleTime(ctx context.Context, in *GetCycleTimeRequest, opts ...grpc.CallOption) (*CycleTimeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CycleTimeResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_GetCycleTime_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AnalyticsServiceServer is the server API for AnalyticsService service.
 // All implementations must embed UnimplementedAnalyticsServiceServer
 // for forward compatibility.
@@ -133,6 +181,9 @@ type AnalyticsServiceServer interface {
 	// Project Views
 	RecordProjectView(context.Context, *RecordProjectViewRequest) (*Empty, error)
 	GetProjectViews(context.Context, *GetProjectViewsRequest) (*ProjectViewsResponse, error)
+	BatchRecordProjectViews(context.Context, *BatchRecordProjectViewsRequest) (*BatchRecordProjectViewsResponse, error)
+	GetTrendingProjects(context.Context, *GetTrendingProjectsRequest) (*TrendingProjectsResponse, error)
+	GetRecentlyViewedProjects(context.Context, *GetRecentlyViewedProjectsRequest) (*RecentlyViewedProjectsResponse, error)
 	// Task Activity
 	RecordTaskActivity(context.Context, *RecordTaskActivityRequest) (*Empty, error)
 	GetTaskActivities(context.Context, *GetTaskActivitiesRequest) (*TaskActivitiesResponse, error)
@@ -140,6 +191,7 @@ type AnalyticsServiceServer interface {
 	GetProjectStats(context.Context, *GetProjectStatsRequest) (*ProjectStatsResponse, error)
 	UpdateProjectStats(context.Context, *UpdateProjectStatsRequest) (*ProjectStatsResponse, error)
 	GetDashboardStats(context.Context, *GetDashboardStatsRequest) (*DashboardStatsResponse, error)
+	GetCycleTime(context.Context, *GetCycleTimeRequest) (*CycleTimeResponse, error)
 	mustEmbedUnimplementedAnalyticsServiceServer()
 }
 
@@ -156,6 +208,15 @@ func (UnimplementedAnalyticsServiceServer) RecordProjectView(context.Context, *R
 func (UnimplementedAnalyticsServiceServer) GetProjectViews(context.Context, *GetProjectViewsRequest) (*ProjectViewsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProjectViews not implemented")
 }
+func (UnimplementedAnalyticsServiceServer) BatchRecordProjectViews(context.Context, *BatchRecordProjectViewsRequest) (*BatchRecordProjectViewsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchRecordProjectViews not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetTrendingProjects(context.Context, *GetTrendingProjectsRequest) (*TrendingProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrendingProjects not implemented")
+}
+func (UnimplementedAnalyticsServiceServer) GetRecentlyViewedProjects(context.Context, *GetRecentlyViewedProjectsRequest) (*RecentlyViewedProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRecentlyViewedProjects not implemented")
+}
 func (UnimplementedAnalyticsServiceServer) RecordTaskActivity(context.Context, *RecordTaskActivityRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RecordTaskActivity not implemented")
 }
@@ -171,6 +232,9 @@ func (UnimplementedAnalyticsServiceServer) UpdateProjectStats(context.Context, *
 func (UnimplementedAnalyticsServiceServer) GetDashboardStats(context.Context, *GetDashboardStatsRequest) (*DashboardStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDashboardStats not implemented")
 }
+func (UnimplementedAnalyticsServiceServer) GetCycleTime(context.Context, *GetCycleTimeRequest) (*CycleTimeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCycleTime not implemented")
+}
 func (UnimplementedAnalyticsServiceServer) mustEmbedUnimplementedAnalyticsServiceServer() {}
 func (UnimplementedAnalyticsServiceServer) testEmbeddedByValue()                          {}
 
@@ -228,6 +292,60 @@ func _AnalyticsService_GetProjectViews_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnalyticsService_BatchRecordProjectViews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchRecordProjectViewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).BatchRecordProjectViews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_BatchRecordProjectViews_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).BatchRecordProjectViews(ctx, req.(*BatchRecordProjectViewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetTrendingProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrendingProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetTrendingProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetTrendingProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetTrendingProjects(ctx, req.(*GetTrendingProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AnalyticsService_GetRecentlyViewedProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRecentlyViewedProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetRecentlyViewedProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetRecentlyViewedProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetRecentlyViewedProjects(ctx, req.(*GetRecentlyViewedProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AnalyticsService_RecordTaskActivity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RecordTaskActivityRequest)
 	if err := dec(in); err != nil {
@@ -318,6 +436,24 @@ func _AnalyticsService_GetDashboardStats_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnalyticsService_GetCycleTime_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCycleTimeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).GetCycleTime(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_GetCycleTime_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).GetCycleTime(ctx, req.(*GetCycleTimeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AnalyticsService_ServiceDesc is the grpc.ServiceDesc for AnalyticsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -333,6 +469,18 @@ var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProjectViews",
 			Handler:    _AnalyticsService_GetProjectViews_Handler,
 		},
+		{
+			MethodName: "BatchRecordProjectViews",
+			Handler:    _AnalyticsService_BatchRecordProjectViews_Handler,
+		},
+		{
+			MethodName: "GetTrendingProjects",
+			Handler:    _AnalyticsService_GetTrendingProjects_Handler,
+		},
+		{
+			MethodName: "GetRecentlyViewedProjects",
+			Handler:    _AnalyticsService_GetRecentlyViewedProjects_Handler,
+		},
 		{
 			MethodName: "RecordTaskActivity",
 			Handler:    _AnalyticsService_RecordTaskActivity_Handler,
@@ -353,6 +501,10 @@ var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetDashboardStats",
 			Handler:    _AnalyticsService_GetDashboardStats_Handler,
 		},
+		{
+			MethodName: "GetCycleTime",
+			Handler:    _AnalyticsService_GetCycleTime_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/analytics/analytics.proto",
@@ -463,6 +463,51 @@ func (x *ValidateTokenResponse) GetUser() *User {
 	return nil
 }
 
+// Logout revokes the token until it expires
+type LogoutRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogoutRequest) Reset() {
+	*x = LogoutRequest{}
+	mi := &file_proto_auth_auth_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogoutRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogoutRequest) ProtoMessage() {}
+
+func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
+func (*LogoutRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *LogoutRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
 type GetUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -472,7 +517,7 @@ type GetUserRequest struct {
 
 func (x *GetUserRequest) Reset() {
 	*x = GetUserRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[8]
+	mi := &file_proto_auth_auth_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -484,7 +529,7 @@ func (x *GetUserRequest) String() string {
 func (*GetUserRequest) ProtoMessage() {}
 
 func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[8]
+	mi := &file_proto_auth_auth_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -497,7 +542,7 @@ func (x *GetUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserRequest.ProtoReflect.Descriptor instead.
 func (*GetUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{8}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetUserRequest) GetId() int64 {
@@ -516,7 +561,7 @@ type UserResponse struct {
 
 func (x *UserResponse) Reset() {
 	*x = UserResponse{}
-	mi := &file_proto_auth_auth_proto_msgTypes[9]
+	mi := &file_proto_auth_auth_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -528,7 +573,7 @@ func (x *UserResponse) String() string {
 func (*UserResponse) ProtoMessage() {}
 
 func (x *UserResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[9]
+	mi := &file_proto_auth_auth_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -541,7 +586,7 @@ func (x *UserResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserResponse.ProtoReflect.Descriptor instead.
 func (*UserResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{9}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *UserResponse) GetUser() *User {
@@ -563,7 +608,7 @@ type UpdateUserRequest struct {
 
 func (x *UpdateUserRequest) Reset() {
 	*x = UpdateUserRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[10]
+	mi := &file_proto_auth_auth_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -575,7 +620,7 @@ func (x *UpdateUserRequest) String() string {
 func (*UpdateUserRequest) ProtoMessage() {}
 
 func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[10]
+	mi := &file_proto_auth_auth_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,7 +633,7 @@ func (x *UpdateUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateUserRequest.ProtoReflect.Descriptor instead.
 func (*UpdateUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{10}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateUserRequest) GetId() int64 {
@@ -628,7 +673,7 @@ type DeleteUserRequest struct {
 
 func (x *DeleteUserRequest) Reset() {
 	*x = DeleteUserRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[11]
+	mi := &file_proto_auth_auth_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -640,7 +685,7 @@ func (x *DeleteUserRequest) String() string {
 func (*DeleteUserRequest) ProtoMessage() {}
 
 func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[11]
+	mi := &file_proto_auth_auth_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,7 +698,7 @@ func (x *DeleteUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteUserRequest.ProtoReflect.Descriptor instead.
 func (*DeleteUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{11}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteUserRequest) GetId() int64 {
@@ -673,7 +718,7 @@ type ListUsersRequest struct {
 
 func (x *ListUsersRequest) Reset() {
 	*x = ListUsersRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[12]
+	mi := &file_proto_auth_auth_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -685,7 +730,7 @@ func (x *ListUsersRequest) String() string {
 func (*ListUsersRequest) ProtoMessage() {}
 
 func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[12]
+	mi := &file_proto_auth_auth_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -698,7 +743,7 @@ func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
 func (*ListUsersRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{12}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListUsersRequest) GetPage() int32 {
@@ -716,16 +761,18 @@ func (x *ListUsersRequest) GetLimit() int32 {
 }
 
 type ListUsersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Total int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListUsersResponse) Reset() {
 	*x = ListUsersResponse{}
-	mi := &file_proto_auth_auth_proto_msgTypes[13]
+	mi := &file_proto_auth_auth_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -737,7 +784,7 @@ func (x *ListUsersResponse) String() string {
 func (*ListUsersResponse) ProtoMessage() {}
 
 func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[13]
+	mi := &file_proto_auth_auth_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -750,7 +797,7 @@ func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
 func (*ListUsersResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{13}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListUsersResponse) GetUsers() []*User {
@@ -767,18 +814,26 @@ func (x *ListUsersResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *ListUsersResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 // Role messages
 type Role struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Permissions   []string               `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"` // capabilities from role_permissions
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Role) Reset() {
 	*x = Role{}
-	mi := &file_proto_auth_auth_proto_msgTypes[14]
+	mi := &file_proto_auth_auth_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -790,7 +845,7 @@ func (x *Role) String() string {
 func (*Role) ProtoMessage() {}
 
 func (x *Role) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[14]
+	mi := &file_proto_auth_auth_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -803,7 +858,7 @@ func (x *Role) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Role.ProtoReflect.Descriptor instead.
 func (*Role) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{14}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *Role) GetId() int64 {
@@ -820,6 +875,13 @@ func (x *Role) GetName() string {
 	return ""
 }
 
+func (x *Role) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
 type CreateRoleRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -829,7 +891,7 @@ type CreateRoleRequest struct {
 
 func (x *CreateRoleRequest) Reset() {
 	*x = CreateRoleRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[15]
+	mi := &file_proto_auth_auth_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -841,7 +903,7 @@ func (x *CreateRoleRequest) String() string {
 func (*CreateRoleRequest) ProtoMessage() {}
 
 func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[15]
+	mi := &file_proto_auth_auth_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -854,7 +916,7 @@ func (x *CreateRoleRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateRoleRequest.ProtoReflect.Descriptor instead.
 func (*CreateRoleRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{15}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *CreateRoleRequest) GetName() string {
@@ -873,7 +935,7 @@ type RoleResponse struct {
 
 func (x *RoleResponse) Reset() {
 	*x = RoleResponse{}
-	mi := &file_proto_auth_auth_proto_msgTypes[16]
+	mi := &file_proto_auth_auth_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -885,7 +947,7 @@ func (x *RoleResponse) String() string {
 func (*RoleResponse) ProtoMessage() {}
 
 func (x *RoleResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[16]
+	mi := &file_proto_auth_auth_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -898,7 +960,7 @@ func (x *RoleResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoleResponse.ProtoReflect.Descriptor instead.
 func (*RoleResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{16}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RoleResponse) GetRole() *Role {
@@ -917,7 +979,7 @@ type ListRolesResponse struct {
 
 func (x *ListRolesResponse) Reset() {
 	*x = ListRolesResponse{}
-	mi := &file_proto_auth_auth_proto_msgTypes[17]
+	mi := &file_proto_auth_auth_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -929,7 +991,7 @@ func (x *ListRolesResponse) String() string {
 func (*ListRolesResponse) ProtoMessage() {}
 
 func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[17]
+	mi := &file_proto_auth_auth_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -942,7 +1004,7 @@ func (x *ListRolesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListRolesResponse.ProtoReflect.Descriptor instead.
 func (*ListRolesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{17}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *ListRolesResponse) GetRoles() []*Role {
@@ -964,7 +1026,7 @@ type UserProjectAccess struct {
 
 func (x *UserProjectAccess) Reset() {
 	*x = UserProjectAccess{}
-	mi := &file_proto_auth_auth_proto_msgTypes[18]
+	mi := &file_proto_auth_auth_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -976,7 +1038,7 @@ func (x *UserProjectAccess) String() string {
 func (*UserProjectAccess) ProtoMessage() {}
 
 func (x *UserProjectAccess) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[18]
+	mi := &file_proto_auth_auth_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,7 +1051,7 @@ func (x *UserProjectAccess) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserProjectAccess.ProtoReflect.Descriptor instead.
 func (*UserProjectAccess) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{18}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *UserProjectAccess) GetUserId() int64 {
@@ -1022,7 +1084,7 @@ type GetUserProjectAccessRequest struct {
 
 func (x *GetUserProjectAccessRequest) Reset() {
 	*x = GetUserProjectAccessRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[19]
+	mi := &file_proto_auth_auth_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1034,7 +1096,7 @@ func (x *GetUserProjectAccessRequest) String() string {
 func (*GetUserProjectAccessRequest) ProtoMessage() {}
 
 func (x *GetUserProjectAccessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[19]
+	mi := &file_proto_auth_auth_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1047,7 +1109,7 @@ func (x *GetUserProjectAccessRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserProjectAccessRequest.ProtoReflect.Descriptor instead.
 func (*GetUserProjectAccessRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{19}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *GetUserProjectAccessRequest) GetUserId() int64 {
@@ -1066,7 +1128,7 @@ type UserProjectAccessResponse struct {
 
 func (x *UserProjectAccessResponse) Reset() {
 	*x = UserProjectAccessResponse{}
-	mi := &file_proto_auth_auth_proto_msgTypes[20]
+	mi := &file_proto_auth_auth_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1078,7 +1140,7 @@ func (x *UserProjectAccessResponse) String() string {
 func (*UserProjectAccessResponse) ProtoMessage() {}
 
 func (x *UserProjectAccessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[20]
+	mi := &file_proto_auth_auth_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1091,7 +1153,7 @@ func (x *UserProjectAccessResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserProjectAccessResponse.ProtoReflect.Descriptor instead.
 func (*UserProjectAccessResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{20}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *UserProjectAccessResponse) GetAccesses() []*UserProjectAccess {
@@ -1112,7 +1174,7 @@ type SetUserProjectAccessRequest struct {
 
 func (x *SetUserProjectAccessRequest) Reset() {
 	*x = SetUserProjectAccessRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[21]
+	mi := &file_proto_auth_auth_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1124,7 +1186,7 @@ func (x *SetUserProjectAccessRequest) String() string {
 func (*SetUserProjectAccessRequest) ProtoMessage() {}
 
 func (x *SetUserProjectAccessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[21]
+	mi := &file_proto_auth_auth_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1137,7 +1199,7 @@ func (x *SetUserProjectAccessRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetUserProjectAccessRequest.ProtoReflect.Descriptor instead.
 func (*SetUserProjectAccessRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{21}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *SetUserProjectAccessRequest) GetUserId() int64 {
@@ -1171,7 +1233,7 @@ type RemoveUserProjectAccessRequest struct {
 
 func (x *RemoveUserProjectAccessRequest) Reset() {
 	*x = RemoveUserProjectAccessRequest{}
-	mi := &file_proto_auth_auth_proto_msgTypes[22]
+	mi := &file_proto_auth_auth_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1183,7 +1245,7 @@ func (x *RemoveUserProjectAccessRequest) String() string {
 func (*RemoveUserProjectAccessRequest) ProtoMessage() {}
 
 func (x *RemoveUserProjectAccessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_auth_proto_msgTypes[22]
+	mi := &file_proto_auth_auth_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1196,7 +1258,7 @@ func (x *RemoveUserProjectAccessRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveUserProjectAccessRequest.ProtoReflect.Descriptor instead.
 func (*RemoveUserProjectAccessRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_auth_proto_rawDescGZIP(), []int{22}
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *RemoveUserProjectAccessRequest) GetUserId() int64 {
@@ -1213,6 +1275,539 @@ func (x *RemoveUserProjectAccessRequest) GetProjectId() int64 {
 	return 0
 }
 
+type CheckAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	MinLevel      string                 `protobuf:"bytes,3,opt,name=min_level,json=minLevel,proto3" json:"min_level,omitempty"` // read, write or admin; empty accepts any level
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAccessRequest) Reset() {
+	*x = CheckAccessRequest{}
+	mi := &file_proto_auth_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAccessRequest) ProtoMessage() {}
+
+func (x *CheckAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAccessRequest.ProtoReflect.Descriptor instead.
+func (*CheckAccessRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CheckAccessRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CheckAccessRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *CheckAccessRequest) GetMinLevel() string {
+	if x != nil {
+		return x.MinLevel
+	}
+	return ""
+}
+
+type CheckAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessLevel   string                 `protobuf:"bytes,1,opt,name=access_level,json=accessLevel,proto3" json:"access_level,omitempty"` // empty when the user has no access
+	Allowed       bool                   `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`                           // access_level is at least min_level
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckAccessResponse) Reset() {
+	*x = CheckAccessResponse{}
+	mi := &file_proto_auth_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckAccessResponse) ProtoMessage() {}
+
+func (x *CheckAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckAccessResponse.ProtoReflect.Descriptor instead.
+func (*CheckAccessResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CheckAccessResponse) GetAccessLevel() string {
+	if x != nil {
+		return x.AccessLevel
+	}
+	return ""
+}
+
+func (x *CheckAccessResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+// ListUserProjects pages through a user's accessible projects with details
+// from the project service
+type ListUserProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserProjectsRequest) Reset() {
+	*x = ListUserProjectsRequest{}
+	mi := &file_proto_auth_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserProjectsRequest) ProtoMessage() {}
+
+func (x *ListUserProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListUserProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListUserProjectsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListUserProjectsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListUserProjectsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type UserProject struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	AccessLevel   string                 `protobuf:"bytes,2,opt,name=access_level,json=accessLevel,proto3" json:"access_level,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"` // empty if the project no longer exists
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserProject) Reset() {
+	*x = UserProject{}
+	mi := &file_proto_auth_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserProject) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserProject) ProtoMessage() {}
+
+func (x *UserProject) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserProject.ProtoReflect.Descriptor instead.
+func (*UserProject) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UserProject) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *UserProject) GetAccessLevel() string {
+	if x != nil {
+		return x.AccessLevel
+	}
+	return ""
+}
+
+func (x *UserProject) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserProject) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *UserProject) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListUserProjectsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Projects []*UserProject         `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	Total    int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUserProjectsResponse) Reset() {
+	*x = ListUserProjectsResponse{}
+	mi := &file_proto_auth_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUserProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUserProjectsResponse) ProtoMessage() {}
+
+func (x *ListUserProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUserProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListUserProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListUserProjectsResponse) GetProjects() []*UserProject {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *ListUserProjectsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListUserProjectsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Audit log messages
+type AuditEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ActorId       int64                  `protobuf:"varint,2,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // 0 when nobody was signed in
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Target        string                 `protobuf:"bytes,4,opt,name=target,proto3" json:"target,omitempty"`
+	Details       string                 `protobuf:"bytes,5,opt,name=details,proto3" json:"details,omitempty"`
+	Ip            string                 `protobuf:"bytes,6,opt,name=ip,proto3" json:"ip,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEntry) Reset() {
+	*x = AuditEntry{}
+	mi := &file_proto_auth_auth_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEntry) ProtoMessage() {}
+
+func (x *AuditEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEntry.ProtoReflect.Descriptor instead.
+func (*AuditEntry) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AuditEntry) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *AuditEntry) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetDetails() string {
+	if x != nil {
+		return x.Details
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AuditEntry) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type GetAuditLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	ActorId       int64                  `protobuf:"varint,3,opt,name=actor_id,json=actorId,proto3" json:"actor_id,omitempty"` // optional filter
+	Action        string                 `protobuf:"bytes,4,opt,name=action,proto3" json:"action,omitempty"`                   // optional filter
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogRequest) Reset() {
+	*x = GetAuditLogRequest{}
+	mi := &file_proto_auth_auth_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogRequest) ProtoMessage() {}
+
+func (x *GetAuditLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogRequest.ProtoReflect.Descriptor instead.
+func (*GetAuditLogRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetAuditLogRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetActorId() int64 {
+	if x != nil {
+		return x.ActorId
+	}
+	return 0
+}
+
+func (x *GetAuditLogRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type GetAuditLogResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Entries []*AuditEntry          `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total   int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuditLogResponse) Reset() {
+	*x = GetAuditLogResponse{}
+	mi := &file_proto_auth_auth_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuditLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuditLogResponse) ProtoMessage() {}
+
+func (x *GetAuditLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_auth_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuditLogResponse.ProtoReflect.Descriptor instead.
+func (*GetAuditLogResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_auth_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetAuditLogResponse) GetEntries() []*AuditEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *GetAuditLogResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *GetAuditLogResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 var File_proto_auth_auth_proto protoreflect.FileDescriptor
 
 const file_proto_auth_auth_proto_rawDesc = "" +
@@ -1249,7 +1844,9 @@ const file_proto_auth_auth_proto_rawDesc = "" +
 	"\x15ValidateTokenResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x1e\n" +
 	"\x04user\x18\x02 \x01(\v2\n" +
-	".auth.UserR\x04user\" \n" +
+	".auth.UserR\x04user\"%\n" +
+	"\rLogoutRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\" \n" +
 	"\x0eGetUserRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\".\n" +
 	"\fUserResponse\x12\x1e\n" +
@@ -1264,14 +1861,16 @@ const file_proto_auth_auth_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"<\n" +
 	"\x10ListUsersRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\"K\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"a\n" +
 	"\x11ListUsersResponse\x12 \n" +
 	"\x05users\x18\x01 \x03(\v2\n" +
 	".auth.UserR\x05users\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"*\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"L\n" +
 	"\x04Role\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"'\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions\"'\n" +
 	"\x11CreateRoleRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\".\n" +
 	"\fRoleResponse\x12\x1e\n" +
@@ -1297,11 +1896,54 @@ const file_proto_auth_auth_proto_rawDesc = "" +
 	"\x1eRemoveUserProjectAccessRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
 	"\n" +
-	"project_id\x18\x02 \x01(\x03R\tprojectId2\x85\x06\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\"i\n" +
+	"\x12CheckAccessRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x1b\n" +
+	"\tmin_level\x18\x03 \x01(\tR\bminLevel\"R\n" +
+	"\x13CheckAccessResponse\x12!\n" +
+	"\faccess_level\x18\x01 \x01(\tR\vaccessLevel\x12\x18\n" +
+	"\aallowed\x18\x02 \x01(\bR\aallowed\"\\\n" +
+	"\x17ListUserProjectsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\x9d\x01\n" +
+	"\vUserProject\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12!\n" +
+	"\faccess_level\x18\x02 \x01(\tR\vaccessLevel\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"u\n" +
+	"\x18ListUserProjectsResponse\x12-\n" +
+	"\bprojects\x18\x01 \x03(\v2\x11.auth.UserProjectR\bprojects\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xcc\x01\n" +
+	"\n" +
+	"AuditEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\bactor_id\x18\x02 \x01(\x03R\aactorId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x04 \x01(\tR\x06target\x12\x18\n" +
+	"\adetails\x18\x05 \x01(\tR\adetails\x12\x0e\n" +
+	"\x02ip\x18\x06 \x01(\tR\x02ip\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"q\n" +
+	"\x12GetAuditLogRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x19\n" +
+	"\bactor_id\x18\x03 \x01(\x03R\aactorId\x12\x16\n" +
+	"\x06action\x18\x04 \x01(\tR\x06action\"m\n" +
+	"\x13GetAuditLogResponse\x12*\n" +
+	"\aentries\x18\x01 \x03(\v2\x10.auth.AuditEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit2\x8c\b\n" +
 	"\vAuthService\x129\n" +
 	"\bRegister\x12\x15.auth.RegisterRequest\x1a\x16.auth.RegisterResponse\x120\n" +
 	"\x05Login\x12\x12.auth.LoginRequest\x1a\x13.auth.LoginResponse\x12H\n" +
-	"\rValidateToken\x12\x1a.auth.ValidateTokenRequest\x1a\x1b.auth.ValidateTokenResponse\x123\n" +
+	"\rValidateToken\x12\x1a.auth.ValidateTokenRequest\x1a\x1b.auth.ValidateTokenResponse\x12*\n" +
+	"\x06Logout\x12\x13.auth.LogoutRequest\x1a\v.auth.Empty\x123\n" +
 	"\aGetUser\x12\x14.auth.GetUserRequest\x1a\x12.auth.UserResponse\x129\n" +
 	"\n" +
 	"UpdateUser\x12\x17.auth.UpdateUserRequest\x1a\x12.auth.UserResponse\x122\n" +
@@ -1313,7 +1955,10 @@ const file_proto_auth_auth_proto_rawDesc = "" +
 	"\bGetRoles\x12\v.auth.Empty\x1a\x17.auth.ListRolesResponse\x12Z\n" +
 	"\x14GetUserProjectAccess\x12!.auth.GetUserProjectAccessRequest\x1a\x1f.auth.UserProjectAccessResponse\x12F\n" +
 	"\x14SetUserProjectAccess\x12!.auth.SetUserProjectAccessRequest\x1a\v.auth.Empty\x12L\n" +
-	"\x17RemoveUserProjectAccess\x12$.auth.RemoveUserProjectAccessRequest\x1a\v.auth.EmptyB!Z\x1fgithub.com/portfolio/proto/authb\x06proto3"
+	"\x17RemoveUserProjectAccess\x12$.auth.RemoveUserProjectAccessRequest\x1a\v.auth.Empty\x12B\n" +
+	"\vCheckAccess\x12\x18.auth.CheckAccessRequest\x1a\x19.auth.CheckAccessResponse\x12Q\n" +
+	"\x10ListUserProjects\x12\x1d.auth.ListUserProjectsRequest\x1a\x1e.auth.ListUserProjectsResponse\x12B\n" +
+	"\vGetAuditLog\x12\x18.auth.GetAuditLogRequest\x1a\x19.auth.GetAuditLogResponseB!Z\x1fgithub.com/portfolio/proto/authb\x06proto3"
 
 var (
 	file_proto_auth_auth_proto_rawDescOnce sync.Once
@@ -1327,7 +1972,7 @@ func file_proto_auth_auth_proto_rawDescGZIP() []byte {
 	return file_proto_auth_auth_proto_rawDescData
 }
 
-var file_proto_auth_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
+var file_proto_auth_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 32)
 var file_proto_auth_auth_proto_goTypes = []any{
 	(*Empty)(nil),                          // 0: auth.Empty
 	(*User)(nil),                           // 1: auth.User
@@ -1337,63 +1982,83 @@ var file_proto_auth_auth_proto_goTypes = []any{
 	(*LoginResponse)(nil),                  // 5: auth.LoginResponse
 	(*ValidateTokenRequest)(nil),           // 6: auth.ValidateTokenRequest
 	(*ValidateTokenResponse)(nil),          // 7: auth.ValidateTokenResponse
-	(*GetUserRequest)(nil),                 // 8: auth.GetUserRequest
-	(*UserResponse)(nil),                   // 9: auth.UserResponse
-	(*UpdateUserRequest)(nil),              // 10: auth.UpdateUserRequest
-	(*DeleteUserRequest)(nil),              // 11: auth.DeleteUserRequest
-	(*ListUsersRequest)(nil),               // 12: auth.ListUsersRequest
-	(*ListUsersResponse)(nil),              // 13: auth.ListUsersResponse
-	(*Role)(nil),                           // 14: auth.Role
-	(*CreateRoleRequest)(nil),              // 15: auth.CreateRoleRequest
-	(*RoleResponse)(nil),                   // 16: auth.RoleResponse
-	(*ListRolesResponse)(nil),              // 17: auth.ListRolesResponse
-	(*UserProjectAccess)(nil),              // 18: auth.UserProjectAccess
-	(*GetUserProjectAccessRequest)(nil),    // 19: auth.GetUserProjectAccessRequest
-	(*UserProjectAccessResponse)(nil),      // 20: auth.UserProjectAccessResponse
-	(*SetUserProjectAccessRequest)(nil),    // 21: auth.SetUserProjectAccessRequest
-	(*RemoveUserProjectAccessRequest)(nil), // 22: auth.RemoveUserProjectAccessRequest
-	(*timestamppb.Timestamp)(nil),          // 23: google.protobuf.Timestamp
+	(*LogoutRequest)(nil),                  // 8: auth.LogoutRequest
+	(*GetUserRequest)(nil),                 // 9: auth.GetUserRequest
+	(*UserResponse)(nil),                   // 10: auth.UserResponse
+	(*UpdateUserRequest)(nil),              // 11: auth.UpdateUserRequest
+	(*DeleteUserRequest)(nil),              // 12: auth.DeleteUserRequest
+	(*ListUsersRequest)(nil),               // 13: auth.ListUsersRequest
+	(*ListUsersResponse)(nil),              // 14: auth.ListUsersResponse
+	(*Role)(nil),                           // 15: auth.Role
+	(*CreateRoleRequest)(nil),              // 16: auth.CreateRoleRequest
+	(*RoleResponse)(nil),                   // 17: auth.RoleResponse
+	(*ListRolesResponse)(nil),              // 18: auth.ListRolesResponse
+	(*UserProjectAccess)(nil),              // 19: auth.UserProjectAccess
+	(*GetUserProjectAccessRequest)(nil),    // 20: auth.GetUserProjectAccessRequest
+	(*UserProjectAccessResponse)(nil),      // 21: auth.UserProjectAccessResponse
+	(*SetUserProjectAccessRequest)(nil),    // 22: auth.SetUserProjectAccessRequest
+	(*RemoveUserProjectAccessRequest)(nil), // 23: auth.RemoveUserProjectAccessRequest
+	(*CheckAccessRequest)(nil),             // 24: auth.CheckAccessRequest
+	(*CheckAccessResponse)(nil),            // 25: auth.CheckAccessResponse
+	(*ListUserProjectsRequest)(nil),        // 26: auth.ListUserProjectsRequest
+	(*UserProject)(nil),                    // 27: auth.UserProject
+	(*ListUserProjectsResponse)(nil),       // 28: auth.ListUserProjectsResponse
+	(*AuditEntry)(nil),                     // 29: auth.AuditEntry
+	(*GetAuditLogRequest)(nil),             // 30: auth.GetAuditLogRequest
+	(*GetAuditLogResponse)(nil),            // 31: auth.GetAuditLogResponse
+	(*timestamppb.Timestamp)(nil),          // 32: google.protobuf.Timestamp
 }
 var file_proto_auth_auth_proto_depIdxs = []int32{
-	23, // 0: auth.User.created_at:type_name -> google.protobuf.Timestamp
-	23, // 1: auth.User.updated_at:type_name -> google.protobuf.Timestamp
+	32, // 0: auth.User.created_at:type_name -> google.protobuf.Timestamp
+	32, // 1: auth.User.updated_at:type_name -> google.protobuf.Timestamp
 	1,  // 2: auth.RegisterResponse.user:type_name -> auth.User
 	1,  // 3: auth.LoginResponse.user:type_name -> auth.User
 	1,  // 4: auth.ValidateTokenResponse.user:type_name -> auth.User
 	1,  // 5: auth.UserResponse.user:type_name -> auth.User
 	1,  // 6: auth.ListUsersResponse.users:type_name -> auth.User
-	14, // 7: auth.RoleResponse.role:type_name -> auth.Role
-	14, // 8: auth.ListRolesResponse.roles:type_name -> auth.Role
-	18, // 9: auth.UserProjectAccessResponse.accesses:type_name -> auth.UserProjectAccess
-	2,  // 10: auth.AuthService.Register:input_type -> auth.RegisterRequest
-	4,  // 11: auth.AuthService.Login:input_type -> auth.LoginRequest
-	6,  // 12: auth.AuthService.ValidateToken:input_type -> auth.ValidateTokenRequest
-	8,  // 13: auth.AuthService.GetUser:input_type -> auth.GetUserRequest
-	10, // 14: auth.AuthService.UpdateUser:input_type -> auth.UpdateUserRequest
-	11, // 15: auth.AuthService.DeleteUser:input_type -> auth.DeleteUserRequest
-	12, // 16: auth.AuthService.ListUsers:input_type -> auth.ListUsersRequest
-	15, // 17: auth.AuthService.CreateRole:input_type -> auth.CreateRoleRequest
-	0,  // 18: auth.AuthService.GetRoles:input_type -> auth.Empty
-	19, // 19: auth.AuthService.GetUserProjectAccess:input_type -> auth.GetUserProjectAccessRequest
-	21, // 20: auth.AuthService.SetUserProjectAccess:input_type -> auth.SetUserProjectAccessRequest
-	22, // 21: auth.AuthService.RemoveUserProjectAccess:input_type -> auth.RemoveUserProjectAccessRequest
-	3,  // 22: auth.AuthService.Register:output_type -> auth.RegisterResponse
-	5,  // 23: auth.AuthService.Login:output_type -> auth.LoginResponse
-	7,  // 24: auth.AuthService.ValidateToken:output_type -> auth.ValidateTokenResponse
-	9,  // 25: auth.AuthService.GetUser:output_type -> auth.UserResponse
-	9,  // 26: auth.AuthService.UpdateUser:output_type -> auth.UserResponse
-	0,  // 27: auth.AuthService.DeleteUser:output_type -> auth.Empty
-	13, // 28: auth.AuthService.ListUsers:output_type -> auth.ListUsersResponse
-	16, // 29: auth.AuthService.CreateRole:output_type -> auth.RoleResponse
-	17, // 30: auth.AuthService.GetRoles:output_type -> auth.ListRolesResponse
-	20, // 31: auth.AuthService.GetUserProjectAccess:output_type -> auth.UserProjectAccessResponse
-	0,  // 32: auth.AuthService.SetUserProjectAccess:output_type -> auth.Empty
-	0,  // 33: auth.AuthService.RemoveUserProjectAccess:output_type -> auth.Empty
-	22, // [22:34] is the sub-list for method output_type
-	10, // [10:22] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	15, // 7: auth.RoleResponse.role:type_name -> auth.Role
+	15, // 8: auth.ListRolesResponse.roles:type_name -> auth.Role
+	19, // 9: auth.UserProjectAccessResponse.accesses:type_name -> auth.UserProjectAccess
+	27, // 10: auth.ListUserProjectsResponse.projects:type_name -> auth.UserProject
+	32, // 11: auth.AuditEntry.created_at:type_name -> google.protobuf.Timestamp
+	29, // 12: auth.GetAuditLogResponse.entries:type_name -> auth.AuditEntry
+	2,  // 13: auth.AuthService.Register:input_type -> auth.RegisterRequest
+	4,  // 14: auth.AuthService.Login:input_type -> auth.LoginRequest
+	6,  // 15: auth.AuthService.ValidateToken:input_type -> auth.ValidateTokenRequest
+	8,  // 16: auth.AuthService.Logout:input_type -> auth.LogoutRequest
+	9,  // 17: auth.AuthService.GetUser:input_type -> auth.GetUserRequest
+	11, // 18: auth.AuthService.UpdateUser:input_type -> auth.UpdateUserRequest
+	12, // 19: auth.AuthService.DeleteUser:input_type -> auth.DeleteUserRequest
+	13, // 20: auth.AuthService.ListUsers:input_type -> auth.ListUsersRequest
+	16, // 21: auth.AuthService.CreateRole:input_type -> auth.CreateRoleRequest
+	0,  // 22: auth.AuthService.GetRoles:input_type -> auth.Empty
+	20, // 23: auth.AuthService.GetUserProjectAccess:input_type -> auth.GetUserProjectAccessRequest
+	22, // 24: auth.AuthService.SetUserProjectAccess:input_type -> auth.SetUserProjectAccessRequest
+	23, // 25: auth.AuthService.RemoveUserProjectAccess:input_type -> auth.RemoveUserProjectAccessRequest
+	24, // 26: auth.AuthService.CheckAccess:input_type -> auth.CheckAccessRequest
+	26, // 27: auth.AuthService.ListUserProjects:input_type -> auth.ListUserProjectsRequest
+	30, // 28: auth.AuthService.GetAuditLog:input_type -> auth.GetAuditLogRequest
+	3,  // 29: auth.AuthService.Register:output_type -> auth.RegisterResponse
+	5,  // 30: auth.AuthService.Login:output_type -> auth.LoginResponse
+	7,  // 31: auth.AuthService.ValidateToken:output_type -> auth.ValidateTokenResponse
+	0,  // 32: auth.AuthService.Logout:output_type -> auth.Empty
+	10, // 33: auth.AuthService.GetUser:output_type -> auth.UserResponse
+	10, // 34: auth.AuthService.UpdateUser:output_type -> auth.UserResponse
+	0,  // 35: auth.AuthService.DeleteUser:output_type -> auth.Empty
+	14, // 36: auth.AuthService.ListUsers:output_type -> auth.ListUsersResponse
+	17, // 37: auth.AuthService.CreateRole:output_type -> auth.RoleResponse
+	18, // 38: auth.AuthService.GetRoles:output_type -> auth.ListRolesResponse
+	21, // 39: auth.AuthService.GetUserProjectAccess:output_type -> auth.UserProjectAccessResponse
+	0,  // 40: auth.AuthService.SetUserProjectAccess:output_type -> auth.Empty
+	0,  // 41: auth.AuthService.RemoveUserProjectAccess:output_type -> auth.Empty
+	25, // 42: auth.AuthService.CheckAccess:output_type -> auth.CheckAccessResponse
+	28, // 43: auth.AuthService.ListUserProjects:output_type -> auth.ListUserProjectsResponse
+	31, // 44: auth.AuthService.GetAuditLog:output_type -> auth.GetAuditLogResponse
+	29, // [29:45] is the sub-list for method output_type
+	13, // [13:29] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_proto_auth_auth_proto_init() }
@@ -1407,7 +2072,7 @@ func file_proto_auth_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_auth_auth_proto_rawDesc), len(file_proto_auth_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   23,
+			NumMessages:   32,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+)
+
+// Request rules checked by the validation interceptor before a request
+// reaches the handler. Rules that need the database stay in the use cases.
+
+var errUserIDRequired = errors.New("user id is required")
+
+// Validate checks a RegisterRequest
+func (r *RegisterRequest) Validate() error {
+	switch {
+	case strings.TrimSpace(r.Username) == "":
+		return errors.New("username is required")
+	case !strings.Contains(r.Email, "@"):
+		return errors.New("a valid email is required")
+	case r.Password == "":
+		return errors.New("password is required")
+	}
+	return nil
+}
+
+// Validate checks a LoginRequest
+func (r *LoginRequest) Validate() error {
+	if r.Email == "" || r.Password == "" {
+		return errors.New("email and password are required")
+	}
+	return nil
+}
+
+// Validate checks an UpdateUserRequest
+func (r *UpdateUserRequest) Validate() error {
+	if r.Id <= 0 {
+		return errUserIDRequired
+	}
+	if r.Email != "" && !strings.Contains(r.Email, "@") {
+		return errors.New("email must be valid")
+	}
+	return nil
+}
+
+// Validate checks a CreateRoleRequest
+func (r *CreateRoleRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("role name is required")
+	}
+	return nil
+}
+
+// Validate checks a SetUserProjectAccessRequest. The access level itself is
+// checked by the use case.
+func (r *SetUserProjectAccessRequest) Validate() error {
+	if r.UserId <= 0 {
+		return errUserIDRequired
+	}
+	if r.ProjectId <= 0 {
+		return errors.New("project id is required")
+	}
+	return nil
+}
@@ -60,14 +60,19 @@ func (*Empty) Descriptor() ([]byte, []int) {
 
 // MediaFile messages
 type MediaFile struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileUrl       string                 `protobuf:"bytes,3,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
-	UploadedBy    int64                  `protobuf:"varint,4,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
-	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
-	FileType      string                 `protobuf:"bytes,6,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"` // image, document, resume
-	FileSize      int64                  `protobuf:"varint,7,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	FileName     string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileUrl      string                 `protobuf:"bytes,3,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	UploadedBy   int64                  `protobuf:"varint,4,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	UploadedAt   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	FileType     string                 `protobuf:"bytes,6,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"` // image, document, resume
+	FileSize     int64                  `protobuf:"varint,7,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	ThumbnailUrl string                 `protobuf:"bytes,8,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"` // images only
+	// The project and task the file was uploaded for; 0 when it has none
+	ProjectId     int64  `protobuf:"varint,9,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId        int64  `protobuf:"varint,10,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ContentHash   string `protobuf:"bytes,11,opt,name=content_hash,json=contentHash,proto3" json:"content_hash,omitempty"` // hex SHA-256 of the content
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -151,6 +156,34 @@ func (x *MediaFile) GetFileSize() int64 {
 	return 0
 }
 
+func (x *MediaFile) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *MediaFile) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *MediaFile) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *MediaFile) GetContentHash() string {
+	if x != nil {
+		return x.ContentHash
+	}
+	return ""
+}
+
 type UploadFileRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Data:
@@ -234,10 +267,13 @@ func (*UploadFileRequest_Metadata) isUploadFileRequest_Data() {}
 func (*UploadFileRequest_Chunk) isUploadFileRequest_Data() {}
 
 type FileMetadata struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	FileName      string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
-	FileType      string                 `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
-	UploadedBy    int64                  `protobuf:"varint,3,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	FileName   string                 `protobuf:"bytes,1,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	FileType   string                 `protobuf:"bytes,2,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"`
+	UploadedBy int64                  `protobuf:"varint,3,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"` // must be the caller
+	// optional: the project and task the file belongs to
+	ProjectId     int64 `protobuf:"varint,4,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId        int64 `protobuf:"varint,5,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -293,6 +329,20 @@ func (x *FileMetadata) GetUploadedBy() int64 {
 	return 0
 }
 
+func (x *FileMetadata) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *FileMetadata) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
 type UploadFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	File          *MediaFile             `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
@@ -425,6 +475,144 @@ func (x *MediaFileResponse) GetFile() *MediaFile {
 	return nil
 }
 
+type DownloadFileRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Content hashes the client already holds. When the file's is among them
+	// only the record is sent and its content is never read.
+	CachedHashes  []string `protobuf:"bytes,2,rep,name=cached_hashes,json=cachedHashes,proto3" json:"cached_hashes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileRequest) Reset() {
+	*x = DownloadFileRequest{}
+	mi := &file_proto_media_media_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileRequest) ProtoMessage() {}
+
+func (x *DownloadFileRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_media_media_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileRequest.ProtoReflect.Descriptor instead.
+func (*DownloadFileRequest) Descriptor() ([]byte, []int) {
+	return file_proto_media_media_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DownloadFileRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DownloadFileRequest) GetCachedHashes() []string {
+	if x != nil {
+		return x.CachedHashes
+	}
+	return nil
+}
+
+// The file record comes first, with content_hash always set, followed by
+// its content in chunks unless the client already holds it
+type DownloadFileResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*DownloadFileResponse_File
+	//	*DownloadFileResponse_Chunk
+	Data          isDownloadFileResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadFileResponse) Reset() {
+	*x = DownloadFileResponse{}
+	mi := &file_proto_media_media_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadFileResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadFileResponse) ProtoMessage() {}
+
+func (x *DownloadFileResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_media_media_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadFileResponse.ProtoReflect.Descriptor instead.
+func (*DownloadFileResponse) Descriptor() ([]byte, []int) {
+	return file_proto_media_media_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DownloadFileResponse) GetData() isDownloadFileResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *DownloadFileResponse) GetFile() *MediaFile {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadFileResponse_File); ok {
+			return x.File
+		}
+	}
+	return nil
+}
+
+func (x *DownloadFileResponse) GetChunk() []byte {
+	if x != nil {
+		if x, ok := x.Data.(*DownloadFileResponse_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isDownloadFileResponse_Data interface {
+	isDownloadFileResponse_Data()
+}
+
+type DownloadFileResponse_File struct {
+	File *MediaFile `protobuf:"bytes,1,opt,name=file,proto3,oneof"`
+}
+
+type DownloadFileResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadFileResponse_File) isDownloadFileResponse_Data() {}
+
+func (*DownloadFileResponse_Chunk) isDownloadFileResponse_Data() {}
+
 type DeleteFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -434,7 +622,7 @@ type DeleteFileRequest struct {
 
 func (x *DeleteFileRequest) Reset() {
 	*x = DeleteFileRequest{}
-	mi := &file_proto_media_media_proto_msgTypes[7]
+	mi := &file_proto_media_media_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -446,7 +634,7 @@ func (x *DeleteFileRequest) String() string {
 func (*DeleteFileRequest) ProtoMessage() {}
 
 func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_media_media_proto_msgTypes[7]
+	mi := &file_proto_media_media_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -459,7 +647,7 @@ func (x *DeleteFileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteFileRequest.ProtoReflect.Descriptor instead.
 func (*DeleteFileRequest) Descriptor() ([]byte, []int) {
-	return file_proto_media_media_proto_rawDescGZIP(), []int{7}
+	return file_proto_media_media_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteFileRequest) GetId() int64 {
@@ -470,17 +658,24 @@ func (x *DeleteFileRequest) GetId() int64 {
 }
 
 type ListFilesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	FileType      string                 `protobuf:"bytes,3,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"` // optional filter
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Page     int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit    int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	FileType string                 `protobuf:"bytes,3,opt,name=file_type,json=fileType,proto3" json:"file_type,omitempty"` // optional filter
+	// optional upload time range, from uploaded_after (inclusive) to
+	// uploaded_before (exclusive)
+	UploadedAfter  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=uploaded_after,json=uploadedAfter,proto3" json:"uploaded_after,omitempty"`
+	UploadedBefore *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=uploaded_before,json=uploadedBefore,proto3" json:"uploaded_before,omitempty"`
+	// optional filters on the project or task a file was uploaded for
+	ProjectId     int64 `protobuf:"varint,6,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId        int64 `protobuf:"varint,7,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListFilesRequest) Reset() {
 	*x = ListFilesRequest{}
-	mi := &file_proto_media_media_proto_msgTypes[8]
+	mi := &file_proto_media_media_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -492,7 +687,7 @@ func (x *ListFilesRequest) String() string {
 func (*ListFilesRequest) ProtoMessage() {}
 
 func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_media_media_proto_msgTypes[8]
+	mi := &file_proto_media_media_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +700,7 @@ func (x *ListFilesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFilesRequest.ProtoReflect.Descriptor instead.
 func (*ListFilesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_media_media_proto_rawDescGZIP(), []int{8}
+	return file_proto_media_media_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListFilesRequest) GetPage() int32 {
@@ -529,17 +724,47 @@ func (x *ListFilesRequest) GetFileType() string {
 	return ""
 }
 
+func (x *ListFilesRequest) GetUploadedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UploadedAfter
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetUploadedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UploadedBefore
+	}
+	return nil
+}
+
+func (x *ListFilesRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListFilesRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
 type ListFilesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Files         []*MediaFile           `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Files []*MediaFile           `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
+	Total int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ListFilesResponse) Reset() {
 	*x = ListFilesResponse{}
-	mi := &file_proto_media_media_proto_msgTypes[9]
+	mi := &file_proto_media_media_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -551,7 +776,7 @@ func (x *ListFilesResponse) String() string {
 func (*ListFilesResponse) ProtoMessage() {}
 
 func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_media_media_proto_msgTypes[9]
+	mi := &file_proto_media_media_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,7 +789,7 @@ func (x *ListFilesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListFilesResponse.ProtoReflect.Descriptor instead.
 func (*ListFilesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_media_media_proto_rawDescGZIP(), []int{9}
+	return file_proto_media_media_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ListFilesResponse) GetFiles() []*MediaFile {
@@ -581,6 +806,13 @@ func (x *ListFilesResponse) GetTotal() int32 {
 	return 0
 }
 
+func (x *ListFilesResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
 type GetFilesByUserRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -592,7 +824,7 @@ type GetFilesByUserRequest struct {
 
 func (x *GetFilesByUserRequest) Reset() {
 	*x = GetFilesByUserRequest{}
-	mi := &file_proto_media_media_proto_msgTypes[10]
+	mi := &file_proto_media_media_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -604,7 +836,7 @@ func (x *GetFilesByUserRequest) String() string {
 func (*GetFilesByUserRequest) ProtoMessage() {}
 
 func (x *GetFilesByUserRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_media_media_proto_msgTypes[10]
+	mi := &file_proto_media_media_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -617,7 +849,7 @@ func (x *GetFilesByUserRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetFilesByUserRequest.ProtoReflect.Descriptor instead.
 func (*GetFilesByUserRequest) Descriptor() ([]byte, []int) {
-	return file_proto_media_media_proto_rawDescGZIP(), []int{10}
+	return file_proto_media_media_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetFilesByUserRequest) GetUserId() int64 {
@@ -641,12 +873,57 @@ func (x *GetFilesByUserRequest) GetLimit() int32 {
 	return 0
 }
 
+// Files come back in the order of ids; unknown ids are left out
+type GetFilesByIDsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFilesByIDsRequest) Reset() {
+	*x = GetFilesByIDsRequest{}
+	mi := &file_proto_media_media_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFilesByIDsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFilesByIDsRequest) ProtoMessage() {}
+
+func (x *GetFilesByIDsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_media_media_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFilesByIDsRequest.ProtoReflect.Descriptor instead.
+func (*GetFilesByIDsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_media_media_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetFilesByIDsRequest) GetIds() []int64 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 var File_proto_media_media_proto protoreflect.FileDescriptor
 
 const file_proto_media_media_proto_rawDesc = "" +
 	"\n" +
 	"\x17proto/media/media.proto\x12\x05media\x1a\x1fgoogle/protobuf/timestamp.proto\"\a\n" +
-	"\x05Empty\"\xeb\x01\n" +
+	"\x05Empty\"\xeb\x02\n" +
 	"\tMediaFile\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1b\n" +
 	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x19\n" +
@@ -656,35 +933,59 @@ const file_proto_media_media_proto_rawDesc = "" +
 	"\vuploaded_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"uploadedAt\x12\x1b\n" +
 	"\tfile_type\x18\x06 \x01(\tR\bfileType\x12\x1b\n" +
-	"\tfile_size\x18\a \x01(\x03R\bfileSize\"f\n" +
+	"\tfile_size\x18\a \x01(\x03R\bfileSize\x12#\n" +
+	"\rthumbnail_url\x18\b \x01(\tR\fthumbnailUrl\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\t \x01(\x03R\tprojectId\x12\x17\n" +
+	"\atask_id\x18\n" +
+	" \x01(\x03R\x06taskId\x12!\n" +
+	"\fcontent_hash\x18\v \x01(\tR\vcontentHash\"f\n" +
 	"\x11UploadFileRequest\x121\n" +
 	"\bmetadata\x18\x01 \x01(\v2\x13.media.FileMetadataH\x00R\bmetadata\x12\x16\n" +
 	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
-	"\x04data\"i\n" +
+	"\x04data\"\xb6\x01\n" +
 	"\fFileMetadata\x12\x1b\n" +
 	"\tfile_name\x18\x01 \x01(\tR\bfileName\x12\x1b\n" +
 	"\tfile_type\x18\x02 \x01(\tR\bfileType\x12\x1f\n" +
 	"\vuploaded_by\x18\x03 \x01(\x03R\n" +
-	"uploadedBy\":\n" +
+	"uploadedBy\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x04 \x01(\x03R\tprojectId\x12\x17\n" +
+	"\atask_id\x18\x05 \x01(\x03R\x06taskIdJ\x04\b\x06\x10\aR\ruploader_role\":\n" +
 	"\x12UploadFileResponse\x12$\n" +
 	"\x04file\x18\x01 \x01(\v2\x10.media.MediaFileR\x04file\" \n" +
 	"\x0eGetFileRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\"9\n" +
 	"\x11MediaFileResponse\x12$\n" +
-	"\x04file\x18\x01 \x01(\v2\x10.media.MediaFileR\x04file\"#\n" +
+	"\x04file\x18\x01 \x01(\v2\x10.media.MediaFileR\x04file\"J\n" +
+	"\x13DownloadFileRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12#\n" +
+	"\rcached_hashes\x18\x02 \x03(\tR\fcachedHashes\"^\n" +
+	"\x14DownloadFileResponse\x12&\n" +
+	"\x04file\x18\x01 \x01(\v2\x10.media.MediaFileH\x00R\x04file\x12\x16\n" +
+	"\x05chunk\x18\x02 \x01(\fH\x00R\x05chunkB\x06\n" +
+	"\x04data\"#\n" +
 	"\x11DeleteFileRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"Y\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x99\x02\n" +
 	"\x10ListFilesRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x1b\n" +
-	"\tfile_type\x18\x03 \x01(\tR\bfileType\"Q\n" +
+	"\tfile_type\x18\x03 \x01(\tR\bfileType\x12A\n" +
+	"\x0euploaded_after\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ruploadedAfter\x12C\n" +
+	"\x0fuploaded_before\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0euploadedBefore\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x06 \x01(\x03R\tprojectId\x12\x17\n" +
+	"\atask_id\x18\a \x01(\x03R\x06taskId\"g\n" +
 	"\x11ListFilesResponse\x12&\n" +
 	"\x05files\x18\x01 \x03(\v2\x10.media.MediaFileR\x05files\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"Z\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Z\n" +
 	"\x15GetFilesByUserRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
 	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x03 \x01(\x05R\x05limit2\xcf\x02\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"(\n" +
+	"\x14GetFilesByIDsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids2\xe2\x03\n" +
 	"\fMediaService\x12C\n" +
 	"\n" +
 	"UploadFile\x12\x18.media.UploadFileRequest\x1a\x19.media.UploadFileResponse(\x01\x12:\n" +
@@ -692,7 +993,9 @@ const file_proto_media_media_proto_rawDesc = "" +
 	"\n" +
 	"DeleteFile\x12\x18.media.DeleteFileRequest\x1a\f.media.Empty\x12>\n" +
 	"\tListFiles\x12\x17.media.ListFilesRequest\x1a\x18.media.ListFilesResponse\x12H\n" +
-	"\x0eGetFilesByUser\x12\x1c.media.GetFilesByUserRequest\x1a\x18.media.ListFilesResponseB\"Z github.com/portfolio/proto/mediab\x06proto3"
+	"\x0eGetFilesByUser\x12\x1c.media.GetFilesByUserRequest\x1a\x18.media.ListFilesResponse\x12F\n" +
+	"\rGetFilesByIDs\x12\x1b.media.GetFilesByIDsRequest\x1a\x18.media.ListFilesResponse\x12I\n" +
+	"\fDownloadFile\x12\x1a.media.DownloadFileRequest\x1a\x1b.media.DownloadFileResponse0\x01B\"Z github.com/portfolio/proto/mediab\x06proto3"
 
 var (
 	file_proto_media_media_proto_rawDescOnce sync.Once
@@ -706,7 +1009,7 @@ func file_proto_media_media_proto_rawDescGZIP() []byte {
 	return file_proto_media_media_proto_rawDescData
 }
 
-var file_proto_media_media_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_proto_media_media_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_proto_media_media_proto_goTypes = []any{
 	(*Empty)(nil),                 // 0: media.Empty
 	(*MediaFile)(nil),             // 1: media.MediaFile
@@ -715,33 +1018,43 @@ var file_proto_media_media_proto_goTypes = []any{
 	(*UploadFileResponse)(nil),    // 4: media.UploadFileResponse
 	(*GetFileRequest)(nil),        // 5: media.GetFileRequest
 	(*MediaFileResponse)(nil),     // 6: media.MediaFileResponse
-	(*DeleteFileRequest)(nil),     // 7: media.DeleteFileRequest
-	(*ListFilesRequest)(nil),      // 8: media.ListFilesRequest
-	(*ListFilesResponse)(nil),     // 9: media.ListFilesResponse
-	(*GetFilesByUserRequest)(nil), // 10: media.GetFilesByUserRequest
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
+	(*DownloadFileRequest)(nil),   // 7: media.DownloadFileRequest
+	(*DownloadFileResponse)(nil),  // 8: media.DownloadFileResponse
+	(*DeleteFileRequest)(nil),     // 9: media.DeleteFileRequest
+	(*ListFilesRequest)(nil),      // 10: media.ListFilesRequest
+	(*ListFilesResponse)(nil),     // 11: media.ListFilesResponse
+	(*GetFilesByUserRequest)(nil), // 12: media.GetFilesByUserRequest
+	(*GetFilesByIDsRequest)(nil),  // 13: media.GetFilesByIDsRequest
+	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
 }
 var file_proto_media_media_proto_depIdxs = []int32{
-	11, // 0: media.MediaFile.uploaded_at:type_name -> google.protobuf.Timestamp
+	14, // 0: media.MediaFile.uploaded_at:type_name -> google.protobuf.Timestamp
 	3,  // 1: media.UploadFileRequest.metadata:type_name -> media.FileMetadata
 	1,  // 2: media.UploadFileResponse.file:type_name -> media.MediaFile
 	1,  // 3: media.MediaFileResponse.file:type_name -> media.MediaFile
-	1,  // 4: media.ListFilesResponse.files:type_name -> media.MediaFile
-	2,  // 5: media.MediaService.UploadFile:input_type -> media.UploadFileRequest
-	5,  // 6: media.MediaService.GetFile:input_type -> media.GetFileRequest
-	7,  // 7: media.MediaService.DeleteFile:input_type -> media.DeleteFileRequest
-	8,  // 8: media.MediaService.ListFiles:input_type -> media.ListFilesRequest
-	10, // 9: media.MediaService.GetFilesByUser:input_type -> media.GetFilesByUserRequest
-	4,  // 10: media.MediaService.UploadFile:output_type -> media.UploadFileResponse
-	6,  // 11: media.MediaService.GetFile:output_type -> media.MediaFileResponse
-	0,  // 12: media.MediaService.DeleteFile:output_type -> media.Empty
-	9,  // 13: media.MediaService.ListFiles:output_type -> media.ListFilesResponse
-	9,  // 14: media.MediaService.GetFilesByUser:output_type -> media.ListFilesResponse
-	10, // [10:15] is the sub-list for method output_type
-	5,  // [5:10] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	1,  // 4: media.DownloadFileResponse.file:type_name -> media.MediaFile
+	14, // 5: media.ListFilesRequest.uploaded_after:type_name -> google.protobuf.Timestamp
+	14, // 6: media.ListFilesRequest.uploaded_before:type_name -> google.protobuf.Timestamp
+	1,  // 7: media.ListFilesResponse.files:type_name -> media.MediaFile
+	2,  // 8: media.MediaService.UploadFile:input_type -> media.UploadFileRequest
+	5,  // 9: media.MediaService.GetFile:input_type -> media.GetFileRequest
+	9,  // 10: media.MediaService.DeleteFile:input_type -> media.DeleteFileRequest
+	10, // 11: media.MediaService.ListFiles:input_type -> media.ListFilesRequest
+	12, // 12: media.MediaService.GetFilesByUser:input_type -> media.GetFilesByUserRequest
+	13, // 13: media.MediaService.GetFilesByIDs:input_type -> media.GetFilesByIDsRequest
+	7,  // 14: media.MediaService.DownloadFile:input_type -> media.DownloadFileRequest
+	4,  // 15: media.MediaService.UploadFile:output_type -> media.UploadFileResponse
+	6,  // 16: media.MediaService.GetFile:output_type -> media.MediaFileResponse
+	0,  // 17: media.MediaService.DeleteFile:output_type -> media.Empty
+	11, // 18: media.MediaService.ListFiles:output_type -> media.ListFilesResponse
+	11, // 19: media.MediaService.GetFilesByUser:output_type -> media.ListFilesResponse
+	11, // 20: media.MediaService.GetFilesByIDs:output_type -> media.ListFilesResponse
+	8,  // 21: media.MediaService.DownloadFile:output_type -> media.DownloadFileResponse
+	15, // [15:22] is the sub-list for method output_type
+	8,  // [8:15] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_proto_media_media_proto_init() }
@@ -753,13 +1066,17 @@ func file_proto_media_media_proto_init() {
 		(*UploadFileRequest_Metadata)(nil),
 		(*UploadFileRequest_Chunk)(nil),
 	}
+	file_proto_media_media_proto_msgTypes[8].OneofWrappers = []any{
+		(*DownloadFileResponse_File)(nil),
+		(*DownloadFileResponse_Chunk)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_media_media_proto_rawDesc), len(file_proto_media_media_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,8 @@ const (
 	MediaService_DeleteFile_FullMethodName     = "/media.MediaService/DeleteFile"
 	MediaService_ListFiles_FullMethodName      = "/media.MediaService/ListFiles"
 	MediaService_GetFilesByUser_FullMethodName = "/media.MediaService/GetFilesByUser"
+	MediaService_GetFilesByIDs_FullMethodName  = "/media.MediaService/GetFilesByIDs"
+	MediaService_DownloadFile_FullMethodName   = "/media.MediaService/DownloadFile"
 )
 
 // MediaServiceClient is the client API for MediaService service.
@@ -37,6 +39,8 @@ type MediaServiceClient interface {
 	DeleteFile(ctx context.Context, in *DeleteFileRequest, opts ...grpc.CallOption) (*Empty, error)
 	ListFiles(ctx context.Context, in *ListFilesRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
 	GetFilesByUser(ctx context.Context, in *GetFilesByUserRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
+	GetFilesByIDs(ctx context.Context, in *GetFilesByIDsRequest, opts ...grpc.CallOption) (*ListFilesResponse, error)
+	DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadFileResponse], error)
 }
 
 type mediaServiceClient struct {
@@ -100,6 +104,35 @@ func (c *mediaServiceClient) GetFilesByUser(ctx context.Context, in *GetFilesByU
 	return out, nil
 }
 
+func (c *mediaServiceClient) GetFilesByIDs(ctx context.Context, in *GetFilesByIDsRequest, opts ...grpc.CallOption) (*ListFilesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFilesResponse)
+	err := c.cc.Invoke(ctx, MediaService_GetFilesByIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) DownloadFile(ctx context.Context, in *DownloadFileRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadFileResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[1], MediaService_DownloadFile_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadFileRequest, DownloadFileResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_DownloadFileClient = grpc.ServerStreamingClient[DownloadFileResponse]
+
 // MediaServiceServer is the server API for MediaService service.
 // All implementations must embed UnimplementedMediaServiceServer
 // for forward compatibility.
@@ -111,6 +144,8 @@ type MediaServiceServer interface {
 	DeleteFile(context.Context, *DeleteFileRequest) (*Empty, error)
 	ListFiles(context.Context, *ListFilesRequest) (*ListFilesResponse, error)
 	GetFilesByUser(context.Context, *GetFilesByUserRequest) (*ListFilesResponse, error)
+	GetFilesByIDs(context.Context, *GetFilesByIDsRequest) (*ListFilesResponse, error)
+	DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[DownloadFileResponse]) error
 	mustEmbedUnimplementedMediaServiceServer()
 }
 
@@ -136,6 +171,12 @@ func (UnimplementedMediaServiceServer) ListFiles(context.Context, *ListFilesRequ
 func (UnimplementedMediaServiceServer) GetFilesByUser(context.Context, *GetFilesByUserRequest) (*ListFilesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFilesByUser not implemented")
 }
+func (UnimplementedMediaServiceServer) GetFilesByIDs(context.Context, *GetFilesByIDsRequest) (*ListFilesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFilesByIDs not implemented")
+}
+func (UnimplementedMediaServiceServer) DownloadFile(*DownloadFileRequest, grpc.ServerStreamingServer[DownloadFileResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadFile not implemented")
+}
 func (UnimplementedMediaServiceServer) mustEmbedUnimplementedMediaServiceServer() {}
 func (UnimplementedMediaServiceServer) testEmbeddedByValue()                      {}
 
@@ -236,6 +277,35 @@ func _MediaService_GetFilesByUser_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaService_GetFilesByIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFilesByIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).GetFilesByIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_GetFilesByIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).GetFilesByIDs(ctx, req.(*GetFilesByIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_DownloadFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaServiceServer).DownloadFile(m, &grpc.GenericServerStream[DownloadFileRequest, DownloadFileResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_DownloadFileServer = grpc.ServerStreamingServer[DownloadFileResponse]
+
 // MediaService_ServiceDesc is the grpc.ServiceDesc for MediaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -259,6 +329,10 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetFilesByUser",
 			Handler:    _MediaService_GetFilesByUser_Handler,
 		},
+		{
+			MethodName: "GetFilesByIDs",
+			Handler:    _MediaService_GetFilesByIDs_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -266,6 +340,11 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _MediaService_UploadFile_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DownloadFile",
+			Handler:       _MediaService_DownloadFile_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/media/media.proto",
 }
@@ -268,8 +268,11 @@ func (x *CreateProjectRequest) GetStatus() string {
 }
 
 type GetProjectRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Only the project's own fields, without skills, tech, images and links,
+	// for callers that just check it exists
+	Basic         bool `protobuf:"varint,2,opt,name=basic,proto3" json:"basic,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -311,6 +314,13 @@ func (x *GetProjectRequest) GetId() int64 {
 	return 0
 }
 
+func (x *GetProjectRequest) GetBasic() bool {
+	if x != nil {
+		return x.Basic
+	}
+	return false
+}
+
 type ProjectResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
@@ -484,10 +494,14 @@ func (x *DeleteProjectRequest) GetId() int64 {
 }
 
 type ListProjectsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Page          int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // optional filter
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Page            int32                  `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	Limit           int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Status          string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                                           // optional filter
+	IncludeArchived bool                   `protobuf:"varint,4,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"` // archived projects are left out unless set or filtered by status
+	// Relations loaded for every project on the page: skills, tech, images,
+	// links. Projects come without them by default.
+	Include       []string `protobuf:"bytes,5,rep,name=include,proto3" json:"include,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -543,10 +557,26 @@ func (x *ListProjectsRequest) GetStatus() string {
 	return ""
 }
 
+func (x *ListProjectsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *ListProjectsRequest) GetInclude() []string {
+	if x != nil {
+		return x.Include
+	}
+	return nil
+}
+
 type ListProjectsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Projects []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	Total    int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -595,29 +625,34 @@ func (x *ListProjectsResponse) GetTotal() int32 {
 	return 0
 }
 
-// Skill messages
-type Skill struct {
+func (x *ListProjectsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type BatchGetProjectsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Skill) Reset() {
-	*x = Skill{}
+func (x *BatchGetProjectsRequest) Reset() {
+	*x = BatchGetProjectsRequest{}
 	mi := &file_proto_project_project_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Skill) String() string {
+func (x *BatchGetProjectsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Skill) ProtoMessage() {}
+func (*BatchGetProjectsRequest) ProtoMessage() {}
 
-func (x *Skill) ProtoReflect() protoreflect.Message {
+func (x *BatchGetProjectsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -629,46 +664,40 @@ func (x *Skill) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
-func (*Skill) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetProjectsRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetProjectsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Skill) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *Skill) GetName() string {
+func (x *BatchGetProjectsRequest) GetIds() []int64 {
 	if x != nil {
-		return x.Name
+		return x.Ids
 	}
-	return ""
+	return nil
 }
 
-type CreateSkillRequest struct {
+// Projects in the order requested, without missing ones
+type BatchGetProjectsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSkillRequest) Reset() {
-	*x = CreateSkillRequest{}
+func (x *BatchGetProjectsResponse) Reset() {
+	*x = BatchGetProjectsResponse{}
 	mi := &file_proto_project_project_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSkillRequest) String() string {
+func (x *BatchGetProjectsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSkillRequest) ProtoMessage() {}
+func (*BatchGetProjectsResponse) ProtoMessage() {}
 
-func (x *CreateSkillRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchGetProjectsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -680,39 +709,40 @@ func (x *CreateSkillRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSkillRequest.ProtoReflect.Descriptor instead.
-func (*CreateSkillRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchGetProjectsResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetProjectsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CreateSkillRequest) GetName() string {
+func (x *BatchGetProjectsResponse) GetProjects() []*Project {
 	if x != nil {
-		return x.Name
+		return x.Projects
 	}
-	return ""
+	return nil
 }
 
-type SkillResponse struct {
+type CompleteProjectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Skill         *Skill                 `protobuf:"bytes,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Force         bool                   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"` // complete even if tasks are still open
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SkillResponse) Reset() {
-	*x = SkillResponse{}
+func (x *CompleteProjectRequest) Reset() {
+	*x = CompleteProjectRequest{}
 	mi := &file_proto_project_project_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SkillResponse) String() string {
+func (x *CompleteProjectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SkillResponse) ProtoMessage() {}
+func (*CompleteProjectRequest) ProtoMessage() {}
 
-func (x *SkillResponse) ProtoReflect() protoreflect.Message {
+func (x *CompleteProjectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -724,39 +754,47 @@ func (x *SkillResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SkillResponse.ProtoReflect.Descriptor instead.
-func (*SkillResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CompleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*CompleteProjectRequest) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SkillResponse) GetSkill() *Skill {
+func (x *CompleteProjectRequest) GetId() int64 {
 	if x != nil {
-		return x.Skill
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type ListSkillsResponse struct {
+func (x *CompleteProjectRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type CloneProjectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Skills        []*Skill               `protobuf:"bytes,1,rep,name=skills,proto3" json:"skills,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // defaults to the original's name with " (copy)"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSkillsResponse) Reset() {
-	*x = ListSkillsResponse{}
+func (x *CloneProjectRequest) Reset() {
+	*x = CloneProjectRequest{}
 	mi := &file_proto_project_project_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSkillsResponse) String() string {
+func (x *CloneProjectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSkillsResponse) ProtoMessage() {}
+func (*CloneProjectRequest) ProtoMessage() {}
 
-func (x *ListSkillsResponse) ProtoReflect() protoreflect.Message {
+func (x *CloneProjectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -768,40 +806,46 @@ func (x *ListSkillsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSkillsResponse.ProtoReflect.Descriptor instead.
-func (*ListSkillsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CloneProjectRequest.ProtoReflect.Descriptor instead.
+func (*CloneProjectRequest) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ListSkillsResponse) GetSkills() []*Skill {
+func (x *CloneProjectRequest) GetId() int64 {
 	if x != nil {
-		return x.Skills
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-type AddProjectSkillRequest struct {
+func (x *CloneProjectRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ArchiveProjectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	SkillId       int64                  `protobuf:"varint,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddProjectSkillRequest) Reset() {
-	*x = AddProjectSkillRequest{}
+func (x *ArchiveProjectRequest) Reset() {
+	*x = ArchiveProjectRequest{}
 	mi := &file_proto_project_project_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddProjectSkillRequest) String() string {
+func (x *ArchiveProjectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddProjectSkillRequest) ProtoMessage() {}
+func (*ArchiveProjectRequest) ProtoMessage() {}
 
-func (x *AddProjectSkillRequest) ProtoReflect() protoreflect.Message {
+func (x *ArchiveProjectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -813,47 +857,39 @@ func (x *AddProjectSkillRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddProjectSkillRequest.ProtoReflect.Descriptor instead.
-func (*AddProjectSkillRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ArchiveProjectRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveProjectRequest) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *AddProjectSkillRequest) GetProjectId() int64 {
-	if x != nil {
-		return x.ProjectId
-	}
-	return 0
-}
-
-func (x *AddProjectSkillRequest) GetSkillId() int64 {
+func (x *ArchiveProjectRequest) GetId() int64 {
 	if x != nil {
-		return x.SkillId
+		return x.Id
 	}
 	return 0
 }
 
-type RemoveProjectSkillRequest struct {
+type UnarchiveProjectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	SkillId       int64                  `protobuf:"varint,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveProjectSkillRequest) Reset() {
-	*x = RemoveProjectSkillRequest{}
+func (x *UnarchiveProjectRequest) Reset() {
+	*x = UnarchiveProjectRequest{}
 	mi := &file_proto_project_project_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveProjectSkillRequest) String() string {
+func (x *UnarchiveProjectRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveProjectSkillRequest) ProtoMessage() {}
+func (*UnarchiveProjectRequest) ProtoMessage() {}
 
-func (x *RemoveProjectSkillRequest) ProtoReflect() protoreflect.Message {
+func (x *UnarchiveProjectRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -865,48 +901,43 @@ func (x *RemoveProjectSkillRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveProjectSkillRequest.ProtoReflect.Descriptor instead.
-func (*RemoveProjectSkillRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UnarchiveProjectRequest.ProtoReflect.Descriptor instead.
+func (*UnarchiveProjectRequest) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *RemoveProjectSkillRequest) GetProjectId() int64 {
-	if x != nil {
-		return x.ProjectId
-	}
-	return 0
-}
-
-func (x *RemoveProjectSkillRequest) GetSkillId() int64 {
+func (x *UnarchiveProjectRequest) GetId() int64 {
 	if x != nil {
-		return x.SkillId
+		return x.Id
 	}
 	return 0
 }
 
-// Tech Stack messages
-type AddProjectTechRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	TechName      string                 `protobuf:"bytes,2,opt,name=tech_name,json=techName,proto3" json:"tech_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Project settings messages
+type ProjectSettings struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId           int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	DefaultTaskStatus   string                 `protobuf:"bytes,2,opt,name=default_task_status,json=defaultTaskStatus,proto3" json:"default_task_status,omitempty"`        // empty uses the task service default
+	DefaultTaskPriority int32                  `protobuf:"varint,3,opt,name=default_task_priority,json=defaultTaskPriority,proto3" json:"default_task_priority,omitempty"` // 0 uses the task service default
+	UpdatedAt           *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *AddProjectTechRequest) Reset() {
-	*x = AddProjectTechRequest{}
+func (x *ProjectSettings) Reset() {
+	*x = ProjectSettings{}
 	mi := &file_proto_project_project_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddProjectTechRequest) String() string {
+func (x *ProjectSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddProjectTechRequest) ProtoMessage() {}
+func (*ProjectSettings) ProtoMessage() {}
 
-func (x *AddProjectTechRequest) ProtoReflect() protoreflect.Message {
+func (x *ProjectSettings) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -918,47 +949,60 @@ func (x *AddProjectTechRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddProjectTechRequest.ProtoReflect.Descriptor instead.
-func (*AddProjectTechRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProjectSettings.ProtoReflect.Descriptor instead.
+func (*ProjectSettings) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *AddProjectTechRequest) GetProjectId() int64 {
+func (x *ProjectSettings) GetProjectId() int64 {
 	if x != nil {
 		return x.ProjectId
 	}
 	return 0
 }
 
-func (x *AddProjectTechRequest) GetTechName() string {
+func (x *ProjectSettings) GetDefaultTaskStatus() string {
 	if x != nil {
-		return x.TechName
+		return x.DefaultTaskStatus
 	}
 	return ""
 }
 
-type RemoveProjectTechRequest struct {
+func (x *ProjectSettings) GetDefaultTaskPriority() int32 {
+	if x != nil {
+		return x.DefaultTaskPriority
+	}
+	return 0
+}
+
+func (x *ProjectSettings) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type GetProjectSettingsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	TechName      string                 `protobuf:"bytes,2,opt,name=tech_name,json=techName,proto3" json:"tech_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveProjectTechRequest) Reset() {
-	*x = RemoveProjectTechRequest{}
+func (x *GetProjectSettingsRequest) Reset() {
+	*x = GetProjectSettingsRequest{}
 	mi := &file_proto_project_project_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveProjectTechRequest) String() string {
+func (x *GetProjectSettingsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveProjectTechRequest) ProtoMessage() {}
+func (*GetProjectSettingsRequest) ProtoMessage() {}
 
-func (x *RemoveProjectTechRequest) ProtoReflect() protoreflect.Message {
+func (x *GetProjectSettingsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -970,51 +1014,42 @@ func (x *RemoveProjectTechRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveProjectTechRequest.ProtoReflect.Descriptor instead.
-func (*RemoveProjectTechRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetProjectSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectSettingsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *RemoveProjectTechRequest) GetProjectId() int64 {
+func (x *GetProjectSettingsRequest) GetProjectId() int64 {
 	if x != nil {
 		return x.ProjectId
 	}
 	return 0
 }
 
-func (x *RemoveProjectTechRequest) GetTechName() string {
-	if x != nil {
-		return x.TechName
-	}
-	return ""
-}
-
-// Image messages
-type ProjectImage struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// UpdateProjectSettingsRequest replaces the settings; empty fields are unset
+type UpdateProjectSettingsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId           int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	DefaultTaskStatus   string                 `protobuf:"bytes,2,opt,name=default_task_status,json=defaultTaskStatus,proto3" json:"default_task_status,omitempty"`
+	DefaultTaskPriority int32                  `protobuf:"varint,3,opt,name=default_task_priority,json=defaultTaskPriority,proto3" json:"default_task_priority,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
-func (x *ProjectImage) Reset() {
-	*x = ProjectImage{}
+func (x *UpdateProjectSettingsRequest) Reset() {
+	*x = UpdateProjectSettingsRequest{}
 	mi := &file_proto_project_project_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProjectImage) String() string {
+func (x *UpdateProjectSettingsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProjectImage) ProtoMessage() {}
+func (*UpdateProjectSettingsRequest) ProtoMessage() {}
 
-func (x *ProjectImage) ProtoReflect() protoreflect.Message {
+func (x *UpdateProjectSettingsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1026,69 +1061,53 @@ func (x *ProjectImage) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProjectImage.ProtoReflect.Descriptor instead.
-func (*ProjectImage) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateProjectSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectSettingsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ProjectImage) GetId() int64 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
-}
-
-func (x *ProjectImage) GetProjectId() int64 {
+func (x *UpdateProjectSettingsRequest) GetProjectId() int64 {
 	if x != nil {
 		return x.ProjectId
 	}
 	return 0
 }
 
-func (x *ProjectImage) GetImageUrl() string {
-	if x != nil {
-		return x.ImageUrl
-	}
-	return ""
-}
-
-func (x *ProjectImage) GetDescription() string {
+func (x *UpdateProjectSettingsRequest) GetDefaultTaskStatus() string {
 	if x != nil {
-		return x.Description
+		return x.DefaultTaskStatus
 	}
 	return ""
 }
 
-func (x *ProjectImage) GetUploadedAt() *timestamppb.Timestamp {
+func (x *UpdateProjectSettingsRequest) GetDefaultTaskPriority() int32 {
 	if x != nil {
-		return x.UploadedAt
+		return x.DefaultTaskPriority
 	}
-	return nil
+	return 0
 }
 
-type AddProjectImageRequest struct {
+type ProjectSettingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Settings      *ProjectSettings       `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddProjectImageRequest) Reset() {
-	*x = AddProjectImageRequest{}
+func (x *ProjectSettingsResponse) Reset() {
+	*x = ProjectSettingsResponse{}
 	mi := &file_proto_project_project_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddProjectImageRequest) String() string {
+func (x *ProjectSettingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddProjectImageRequest) ProtoMessage() {}
+func (*ProjectSettingsResponse) ProtoMessage() {}
 
-func (x *AddProjectImageRequest) ProtoReflect() protoreflect.Message {
+func (x *ProjectSettingsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_project_project_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1100,98 +1119,1141 @@ func (x *AddProjectImageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddProjectImageRequest.ProtoReflect.Descriptor instead.
-func (*AddProjectImageRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProjectSettingsResponse.ProtoReflect.Descriptor instead.
+func (*ProjectSettingsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_project_project_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *AddProjectImageRequest) GetProjectId() int64 {
+func (x *ProjectSettingsResponse) GetSettings() *ProjectSettings {
 	if x != nil {
-		return x.ProjectId
+		return x.Settings
 	}
-	return 0
+	return nil
 }
 
-func (x *AddProjectImageRequest) GetImageUrl() string {
-	if x != nil {
-		return x.ImageUrl
-	}
-	return ""
+// Skill messages
+type Skill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddProjectImageRequest) GetDescription() string {
-	if x != nil {
+func (x *Skill) Reset() {
+	*x = Skill{}
+	mi := &file_proto_project_project_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Skill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Skill) ProtoMessage() {}
+
+func (x *Skill) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Skill.ProtoReflect.Descriptor instead.
+func (*Skill) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Skill) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Skill) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSkillRequest) Reset() {
+	*x = CreateSkillRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSkillRequest) ProtoMessage() {}
+
+func (x *CreateSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSkillRequest.ProtoReflect.Descriptor instead.
+func (*CreateSkillRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CreateSkillRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SkillResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skill         *Skill                 `protobuf:"bytes,1,opt,name=skill,proto3" json:"skill,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SkillResponse) Reset() {
+	*x = SkillResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SkillResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SkillResponse) ProtoMessage() {}
+
+func (x *SkillResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SkillResponse.ProtoReflect.Descriptor instead.
+func (*SkillResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SkillResponse) GetSkill() *Skill {
+	if x != nil {
+		return x.Skill
+	}
+	return nil
+}
+
+type ListSkillsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Skills        []*Skill               `protobuf:"bytes,1,rep,name=skills,proto3" json:"skills,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSkillsResponse) Reset() {
+	*x = ListSkillsResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSkillsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSkillsResponse) ProtoMessage() {}
+
+func (x *ListSkillsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSkillsResponse.ProtoReflect.Descriptor instead.
+func (*ListSkillsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListSkillsResponse) GetSkills() []*Skill {
+	if x != nil {
+		return x.Skills
+	}
+	return nil
+}
+
+type AddProjectSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	SkillId       int64                  `protobuf:"varint,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProjectSkillRequest) Reset() {
+	*x = AddProjectSkillRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProjectSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProjectSkillRequest) ProtoMessage() {}
+
+func (x *AddProjectSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProjectSkillRequest.ProtoReflect.Descriptor instead.
+func (*AddProjectSkillRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AddProjectSkillRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *AddProjectSkillRequest) GetSkillId() int64 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
+}
+
+type RemoveProjectSkillRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	SkillId       int64                  `protobuf:"varint,2,opt,name=skill_id,json=skillId,proto3" json:"skill_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProjectSkillRequest) Reset() {
+	*x = RemoveProjectSkillRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProjectSkillRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProjectSkillRequest) ProtoMessage() {}
+
+func (x *RemoveProjectSkillRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProjectSkillRequest.ProtoReflect.Descriptor instead.
+func (*RemoveProjectSkillRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RemoveProjectSkillRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *RemoveProjectSkillRequest) GetSkillId() int64 {
+	if x != nil {
+		return x.SkillId
+	}
+	return 0
+}
+
+// Tech Stack messages
+type AddProjectTechRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TechName      string                 `protobuf:"bytes,2,opt,name=tech_name,json=techName,proto3" json:"tech_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProjectTechRequest) Reset() {
+	*x = AddProjectTechRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProjectTechRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProjectTechRequest) ProtoMessage() {}
+
+func (x *AddProjectTechRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProjectTechRequest.ProtoReflect.Descriptor instead.
+func (*AddProjectTechRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AddProjectTechRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *AddProjectTechRequest) GetTechName() string {
+	if x != nil {
+		return x.TechName
+	}
+	return ""
+}
+
+type RemoveProjectTechRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TechName      string                 `protobuf:"bytes,2,opt,name=tech_name,json=techName,proto3" json:"tech_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProjectTechRequest) Reset() {
+	*x = RemoveProjectTechRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProjectTechRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProjectTechRequest) ProtoMessage() {}
+
+func (x *RemoveProjectTechRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProjectTechRequest.ProtoReflect.Descriptor instead.
+func (*RemoveProjectTechRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RemoveProjectTechRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *RemoveProjectTechRequest) GetTechName() string {
+	if x != nil {
+		return x.TechName
+	}
+	return ""
+}
+
+// Image messages
+type ProjectImage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,3,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectImage) Reset() {
+	*x = ProjectImage{}
+	mi := &file_proto_project_project_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectImage) ProtoMessage() {}
+
+func (x *ProjectImage) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectImage.ProtoReflect.Descriptor instead.
+func (*ProjectImage) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ProjectImage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProjectImage) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ProjectImage) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *ProjectImage) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ProjectImage) GetUploadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return nil
+}
+
+type AddProjectImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ImageUrl      string                 `protobuf:"bytes,2,opt,name=image_url,json=imageUrl,proto3" json:"image_url,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProjectImageRequest) Reset() {
+	*x = AddProjectImageRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProjectImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProjectImageRequest) ProtoMessage() {}
+
+func (x *AddProjectImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProjectImageRequest.ProtoReflect.Descriptor instead.
+func (*AddProjectImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AddProjectImageRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *AddProjectImageRequest) GetImageUrl() string {
+	if x != nil {
+		return x.ImageUrl
+	}
+	return ""
+}
+
+func (x *AddProjectImageRequest) GetDescription() string {
+	if x != nil {
 		return x.Description
 	}
 	return ""
 }
 
-type ProjectImageResponse struct {
+type ProjectImageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Image         *ProjectImage          `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectImageResponse) Reset() {
+	*x = ProjectImageResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectImageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectImageResponse) ProtoMessage() {}
+
+func (x *ProjectImageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectImageResponse.ProtoReflect.Descriptor instead.
+func (*ProjectImageResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ProjectImageResponse) GetImage() *ProjectImage {
+	if x != nil {
+		return x.Image
+	}
+	return nil
+}
+
+type RemoveProjectImageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProjectImageRequest) Reset() {
+	*x = RemoveProjectImageRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProjectImageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProjectImageRequest) ProtoMessage() {}
+
+func (x *RemoveProjectImageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProjectImageRequest.ProtoReflect.Descriptor instead.
+func (*RemoveProjectImageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *RemoveProjectImageRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListProjectImagesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectImagesRequest) Reset() {
+	*x = ListProjectImagesRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectImagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectImagesRequest) ProtoMessage() {}
+
+func (x *ListProjectImagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectImagesRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectImagesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListProjectImagesRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+type ListProjectImagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Images        []*ProjectImage        `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectImagesResponse) Reset() {
+	*x = ListProjectImagesResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectImagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectImagesResponse) ProtoMessage() {}
+
+func (x *ListProjectImagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectImagesResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectImagesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListProjectImagesResponse) GetImages() []*ProjectImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+// Link messages
+type ProjectLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	LinkUrl       string                 `protobuf:"bytes,3,opt,name=link_url,json=linkUrl,proto3" json:"link_url,omitempty"`
+	LinkType      string                 `protobuf:"bytes,4,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"` // github, live, document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectLink) Reset() {
+	*x = ProjectLink{}
+	mi := &file_proto_project_project_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectLink) ProtoMessage() {}
+
+func (x *ProjectLink) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectLink.ProtoReflect.Descriptor instead.
+func (*ProjectLink) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ProjectLink) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProjectLink) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ProjectLink) GetLinkUrl() string {
+	if x != nil {
+		return x.LinkUrl
+	}
+	return ""
+}
+
+func (x *ProjectLink) GetLinkType() string {
+	if x != nil {
+		return x.LinkType
+	}
+	return ""
+}
+
+type AddProjectLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	LinkUrl       string                 `protobuf:"bytes,2,opt,name=link_url,json=linkUrl,proto3" json:"link_url,omitempty"`
+	LinkType      string                 `protobuf:"bytes,3,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddProjectLinkRequest) Reset() {
+	*x = AddProjectLinkRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddProjectLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddProjectLinkRequest) ProtoMessage() {}
+
+func (x *AddProjectLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddProjectLinkRequest.ProtoReflect.Descriptor instead.
+func (*AddProjectLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AddProjectLinkRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *AddProjectLinkRequest) GetLinkUrl() string {
+	if x != nil {
+		return x.LinkUrl
+	}
+	return ""
+}
+
+func (x *AddProjectLinkRequest) GetLinkType() string {
+	if x != nil {
+		return x.LinkType
+	}
+	return ""
+}
+
+type ProjectLinkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Image         *ProjectImage          `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	Link          *ProjectLink           `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProjectImageResponse) Reset() {
-	*x = ProjectImageResponse{}
-	mi := &file_proto_project_project_proto_msgTypes[19]
+func (x *ProjectLinkResponse) Reset() {
+	*x = ProjectLinkResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProjectImageResponse) String() string {
+func (x *ProjectLinkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProjectImageResponse) ProtoMessage() {}
+func (*ProjectLinkResponse) ProtoMessage() {}
 
-func (x *ProjectImageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[19]
+func (x *ProjectLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectLinkResponse.ProtoReflect.Descriptor instead.
+func (*ProjectLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ProjectLinkResponse) GetLink() *ProjectLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+type RemoveProjectLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveProjectLinkRequest) Reset() {
+	*x = RemoveProjectLinkRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveProjectLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveProjectLinkRequest) ProtoMessage() {}
+
+func (x *RemoveProjectLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveProjectLinkRequest.ProtoReflect.Descriptor instead.
+func (*RemoveProjectLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RemoveProjectLinkRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type ListProjectLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	LinkType      string                 `protobuf:"bytes,2,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"` // optional filter: github, live, document
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectLinksRequest) Reset() {
+	*x = ListProjectLinksRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectLinksRequest) ProtoMessage() {}
+
+func (x *ListProjectLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectLinksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListProjectLinksRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *ListProjectLinksRequest) GetLinkType() string {
+	if x != nil {
+		return x.LinkType
+	}
+	return ""
+}
+
+type ListProjectLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ProjectLink         `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectLinksResponse) Reset() {
+	*x = ListProjectLinksResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectLinksResponse) ProtoMessage() {}
+
+func (x *ListProjectLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectLinksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListProjectLinksResponse) GetLinks() []*ProjectLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+// Webhook messages
+type Webhook struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url        string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	EventTypes []string               `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"` // task.created, project.deleted, ...
+	// Only set in the response to CreateWebhook
+	Secret        string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_proto_project_project_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *Webhook) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventTypes() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.EventTypes
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use ProjectImageResponse.ProtoReflect.Descriptor instead.
-func (*ProjectImageResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{19}
+func (x *Webhook) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
 }
 
-func (x *ProjectImageResponse) GetImage() *ProjectImage {
+func (x *Webhook) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Image
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type RemoveProjectImageRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+type CreateWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Url   string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// Signs deliveries; generated when empty
+	Secret        string   `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	EventTypes    []string `protobuf:"bytes,3,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveProjectImageRequest) Reset() {
-	*x = RemoveProjectImageRequest{}
-	mi := &file_proto_project_project_proto_msgTypes[20]
+func (x *CreateWebhookRequest) Reset() {
+	*x = CreateWebhookRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveProjectImageRequest) String() string {
+func (x *CreateWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveProjectImageRequest) ProtoMessage() {}
+func (*CreateWebhookRequest) ProtoMessage() {}
 
-func (x *RemoveProjectImageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[20]
+func (x *CreateWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1202,40 +2264,54 @@ func (x *RemoveProjectImageRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveProjectImageRequest.ProtoReflect.Descriptor instead.
-func (*RemoveProjectImageRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use CreateWebhookRequest.ProtoReflect.Descriptor instead.
+func (*CreateWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *RemoveProjectImageRequest) GetId() int64 {
+func (x *CreateWebhookRequest) GetUrl() string {
 	if x != nil {
-		return x.Id
+		return x.Url
 	}
-	return 0
+	return ""
 }
 
-type ListProjectImagesRequest struct {
+func (x *CreateWebhookRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *CreateWebhookRequest) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+type WebhookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProjectImagesRequest) Reset() {
-	*x = ListProjectImagesRequest{}
-	mi := &file_proto_project_project_proto_msgTypes[21]
+func (x *WebhookResponse) Reset() {
+	*x = WebhookResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProjectImagesRequest) String() string {
+func (x *WebhookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProjectImagesRequest) ProtoMessage() {}
+func (*WebhookResponse) ProtoMessage() {}
 
-func (x *ListProjectImagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[21]
+func (x *WebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1246,40 +2322,40 @@ func (x *ListProjectImagesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProjectImagesRequest.ProtoReflect.Descriptor instead.
-func (*ListProjectImagesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use WebhookResponse.ProtoReflect.Descriptor instead.
+func (*WebhookResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *ListProjectImagesRequest) GetProjectId() int64 {
+func (x *WebhookResponse) GetWebhook() *Webhook {
 	if x != nil {
-		return x.ProjectId
+		return x.Webhook
 	}
-	return 0
+	return nil
 }
 
-type ListProjectImagesResponse struct {
+type ListWebhooksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Images        []*ProjectImage        `protobuf:"bytes,1,rep,name=images,proto3" json:"images,omitempty"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProjectImagesResponse) Reset() {
-	*x = ListProjectImagesResponse{}
-	mi := &file_proto_project_project_proto_msgTypes[22]
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProjectImagesResponse) String() string {
+func (x *ListWebhooksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProjectImagesResponse) ProtoMessage() {}
+func (*ListWebhooksResponse) ProtoMessage() {}
 
-func (x *ListProjectImagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[22]
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1290,44 +2366,40 @@ func (x *ListProjectImagesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProjectImagesResponse.ProtoReflect.Descriptor instead.
-func (*ListProjectImagesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListProjectImagesResponse) GetImages() []*ProjectImage {
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
 	if x != nil {
-		return x.Images
+		return x.Webhooks
 	}
 	return nil
 }
 
-// Link messages
-type ProjectLink struct {
+type DeleteWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	LinkUrl       string                 `protobuf:"bytes,3,opt,name=link_url,json=linkUrl,proto3" json:"link_url,omitempty"`
-	LinkType      string                 `protobuf:"bytes,4,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"` // github, live, document
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProjectLink) Reset() {
-	*x = ProjectLink{}
-	mi := &file_proto_project_project_proto_msgTypes[23]
+func (x *DeleteWebhookRequest) Reset() {
+	*x = DeleteWebhookRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProjectLink) String() string {
+func (x *DeleteWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProjectLink) ProtoMessage() {}
+func (*DeleteWebhookRequest) ProtoMessage() {}
 
-func (x *ProjectLink) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[23]
+func (x *DeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1338,63 +2410,48 @@ func (x *ProjectLink) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProjectLink.ProtoReflect.Descriptor instead.
-func (*ProjectLink) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use DeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*DeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ProjectLink) GetId() int64 {
+func (x *DeleteWebhookRequest) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *ProjectLink) GetProjectId() int64 {
-	if x != nil {
-		return x.ProjectId
-	}
-	return 0
-}
-
-func (x *ProjectLink) GetLinkUrl() string {
-	if x != nil {
-		return x.LinkUrl
-	}
-	return ""
-}
-
-func (x *ProjectLink) GetLinkType() string {
-	if x != nil {
-		return x.LinkType
-	}
-	return ""
-}
-
-type AddProjectLinkRequest struct {
+type WebhookDelivery struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	LinkUrl       string                 `protobuf:"bytes,2,opt,name=link_url,json=linkUrl,proto3" json:"link_url,omitempty"`
-	LinkType      string                 `protobuf:"bytes,3,opt,name=link_type,json=linkType,proto3" json:"link_type,omitempty"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,2,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	EventId       string                 `protobuf:"bytes,3,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
+	EventType     string                 `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Attempt       int32                  `protobuf:"varint,5,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,6,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"` // 0 when the endpoint couldn't be reached
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Succeeded     bool                   `protobuf:"varint,8,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddProjectLinkRequest) Reset() {
-	*x = AddProjectLinkRequest{}
-	mi := &file_proto_project_project_proto_msgTypes[24]
+func (x *WebhookDelivery) Reset() {
+	*x = WebhookDelivery{}
+	mi := &file_proto_project_project_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddProjectLinkRequest) String() string {
+func (x *WebhookDelivery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddProjectLinkRequest) ProtoMessage() {}
+func (*WebhookDelivery) ProtoMessage() {}
 
-func (x *AddProjectLinkRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[24]
+func (x *WebhookDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1405,98 +2462,97 @@ func (x *AddProjectLinkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddProjectLinkRequest.ProtoReflect.Descriptor instead.
-func (*AddProjectLinkRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use WebhookDelivery.ProtoReflect.Descriptor instead.
+func (*WebhookDelivery) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *AddProjectLinkRequest) GetProjectId() int64 {
+func (x *WebhookDelivery) GetId() int64 {
 	if x != nil {
-		return x.ProjectId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *AddProjectLinkRequest) GetLinkUrl() string {
+func (x *WebhookDelivery) GetWebhookId() int64 {
 	if x != nil {
-		return x.LinkUrl
+		return x.WebhookId
 	}
-	return ""
+	return 0
 }
 
-func (x *AddProjectLinkRequest) GetLinkType() string {
+func (x *WebhookDelivery) GetEventId() string {
 	if x != nil {
-		return x.LinkType
+		return x.EventId
 	}
 	return ""
 }
 
-type ProjectLinkResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Link          *ProjectLink           `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *WebhookDelivery) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
 }
 
-func (x *ProjectLinkResponse) Reset() {
-	*x = ProjectLinkResponse{}
-	mi := &file_proto_project_project_proto_msgTypes[25]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *WebhookDelivery) GetAttempt() int32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
 }
 
-func (x *ProjectLinkResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *WebhookDelivery) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
 }
 
-func (*ProjectLinkResponse) ProtoMessage() {}
-
-func (x *ProjectLinkResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[25]
+func (x *WebhookDelivery) GetError() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Error
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ProjectLinkResponse.ProtoReflect.Descriptor instead.
-func (*ProjectLinkResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{25}
+func (x *WebhookDelivery) GetSucceeded() bool {
+	if x != nil {
+		return x.Succeeded
+	}
+	return false
 }
 
-func (x *ProjectLinkResponse) GetLink() *ProjectLink {
+func (x *WebhookDelivery) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Link
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type RemoveProjectLinkRequest struct {
+type ListWebhookDeliveriesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	WebhookId     int64                  `protobuf:"varint,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveProjectLinkRequest) Reset() {
-	*x = RemoveProjectLinkRequest{}
-	mi := &file_proto_project_project_proto_msgTypes[26]
+func (x *ListWebhookDeliveriesRequest) Reset() {
+	*x = ListWebhookDeliveriesRequest{}
+	mi := &file_proto_project_project_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveProjectLinkRequest) String() string {
+func (x *ListWebhookDeliveriesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveProjectLinkRequest) ProtoMessage() {}
+func (*ListWebhookDeliveriesRequest) ProtoMessage() {}
 
-func (x *RemoveProjectLinkRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[26]
+func (x *ListWebhookDeliveriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1507,84 +2563,47 @@ func (x *RemoveProjectLinkRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveProjectLinkRequest.ProtoReflect.Descriptor instead.
-func (*RemoveProjectLinkRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use ListWebhookDeliveriesRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *RemoveProjectLinkRequest) GetId() int64 {
+func (x *ListWebhookDeliveriesRequest) GetWebhookId() int64 {
 	if x != nil {
-		return x.Id
+		return x.WebhookId
 	}
 	return 0
 }
 
-type ListProjectLinksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListProjectLinksRequest) Reset() {
-	*x = ListProjectLinksRequest{}
-	mi := &file_proto_project_project_proto_msgTypes[27]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListProjectLinksRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListProjectLinksRequest) ProtoMessage() {}
-
-func (x *ListProjectLinksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[27]
-	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
-	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListProjectLinksRequest.ProtoReflect.Descriptor instead.
-func (*ListProjectLinksRequest) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *ListProjectLinksRequest) GetProjectId() int64 {
+func (x *ListWebhookDeliveriesRequest) GetLimit() int32 {
 	if x != nil {
-		return x.ProjectId
+		return x.Limit
 	}
 	return 0
 }
 
-type ListProjectLinksResponse struct {
+type ListWebhookDeliveriesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Links         []*ProjectLink         `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	Deliveries    []*WebhookDelivery     `protobuf:"bytes,1,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListProjectLinksResponse) Reset() {
-	*x = ListProjectLinksResponse{}
-	mi := &file_proto_project_project_proto_msgTypes[28]
+func (x *ListWebhookDeliveriesResponse) Reset() {
+	*x = ListWebhookDeliveriesResponse{}
+	mi := &file_proto_project_project_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListProjectLinksResponse) String() string {
+func (x *ListWebhookDeliveriesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListProjectLinksResponse) ProtoMessage() {}
+func (*ListWebhookDeliveriesResponse) ProtoMessage() {}
 
-func (x *ListProjectLinksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_project_project_proto_msgTypes[28]
+func (x *ListWebhookDeliveriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_project_project_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1595,14 +2614,14 @@ func (x *ListProjectLinksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListProjectLinksResponse.ProtoReflect.Descriptor instead.
-func (*ListProjectLinksResponse) Descriptor() ([]byte, []int) {
-	return file_proto_project_project_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use ListWebhookDeliveriesResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhookDeliveriesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_project_project_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *ListProjectLinksResponse) GetLinks() []*ProjectLink {
+func (x *ListWebhookDeliveriesResponse) GetDeliveries() []*WebhookDelivery {
 	if x != nil {
-		return x.Links
+		return x.Deliveries
 	}
 	return nil
 }
@@ -1637,9 +2656,10 @@ const file_proto_project_project_proto_rawDesc = "" +
 	"\n" +
 	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
 	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x16\n" +
-	"\x06status\x18\x05 \x01(\tR\x06status\"#\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\"9\n" +
 	"\x11GetProjectRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"=\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05basic\x18\x02 \x01(\bR\x05basic\"=\n" +
 	"\x0fProjectResponse\x12*\n" +
 	"\aproject\x18\x01 \x01(\v2\x10.project.ProjectR\aproject\"\xe6\x01\n" +
 	"\x14UpdateProjectRequest\x12\x0e\n" +
@@ -1651,14 +2671,48 @@ const file_proto_project_project_proto_rawDesc = "" +
 	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x16\n" +
 	"\x06status\x18\x06 \x01(\tR\x06status\"&\n" +
 	"\x14DeleteProjectRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"W\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x9c\x01\n" +
 	"\x13ListProjectsRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06status\x18\x03 \x01(\tR\x06status\"Z\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12)\n" +
+	"\x10include_archived\x18\x04 \x01(\bR\x0fincludeArchived\x12\x18\n" +
+	"\ainclude\x18\x05 \x03(\tR\ainclude\"p\n" +
 	"\x14ListProjectsResponse\x12,\n" +
 	"\bprojects\x18\x01 \x03(\v2\x10.project.ProjectR\bprojects\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"+\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"+\n" +
+	"\x17BatchGetProjectsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"H\n" +
+	"\x18BatchGetProjectsResponse\x12,\n" +
+	"\bprojects\x18\x01 \x03(\v2\x10.project.ProjectR\bprojects\">\n" +
+	"\x16CompleteProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"9\n" +
+	"\x13CloneProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"'\n" +
+	"\x15ArchiveProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\")\n" +
+	"\x17UnarchiveProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xcf\x01\n" +
+	"\x0fProjectSettings\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12.\n" +
+	"\x13default_task_status\x18\x02 \x01(\tR\x11defaultTaskStatus\x122\n" +
+	"\x15default_task_priority\x18\x03 \x01(\x05R\x13defaultTaskPriority\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\":\n" +
+	"\x19GetProjectSettingsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\"\xa1\x01\n" +
+	"\x1cUpdateProjectSettingsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12.\n" +
+	"\x13default_task_status\x18\x02 \x01(\tR\x11defaultTaskStatus\x122\n" +
+	"\x15default_task_priority\x18\x03 \x01(\x05R\x13defaultTaskPriority\"O\n" +
+	"\x17ProjectSettingsResponse\x124\n" +
+	"\bsettings\x18\x01 \x01(\v2\x18.project.ProjectSettingsR\bsettings\"+\n" +
 	"\x05Skill\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"(\n" +
@@ -1720,19 +2774,68 @@ const file_proto_project_project_proto_rawDesc = "" +
 	"\x13ProjectLinkResponse\x12(\n" +
 	"\x04link\x18\x01 \x01(\v2\x14.project.ProjectLinkR\x04link\"*\n" +
 	"\x18RemoveProjectLinkRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"8\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"U\n" +
 	"\x17ListProjectLinksRequest\x12\x1d\n" +
 	"\n" +
-	"project_id\x18\x01 \x01(\x03R\tprojectId\"F\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x1b\n" +
+	"\tlink_type\x18\x02 \x01(\tR\blinkType\"F\n" +
 	"\x18ListProjectLinksResponse\x12*\n" +
-	"\x05links\x18\x01 \x03(\v2\x14.project.ProjectLinkR\x05links2\xf6\t\n" +
+	"\x05links\x18\x01 \x03(\v2\x14.project.ProjectLinkR\x05links\"\x9f\x01\n" +
+	"\aWebhook\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"a\n" +
+	"\x14CreateWebhookRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x16\n" +
+	"\x06secret\x18\x02 \x01(\tR\x06secret\x12\x1f\n" +
+	"\vevent_types\x18\x03 \x03(\tR\n" +
+	"eventTypes\"=\n" +
+	"\x0fWebhookResponse\x12*\n" +
+	"\awebhook\x18\x01 \x01(\v2\x10.project.WebhookR\awebhook\"D\n" +
+	"\x14ListWebhooksResponse\x12,\n" +
+	"\bwebhooks\x18\x01 \x03(\v2\x10.project.WebhookR\bwebhooks\"&\n" +
+	"\x14DeleteWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xa4\x02\n" +
+	"\x0fWebhookDelivery\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x02 \x01(\x03R\twebhookId\x12\x19\n" +
+	"\bevent_id\x18\x03 \x01(\tR\aeventId\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tR\teventType\x12\x18\n" +
+	"\aattempt\x18\x05 \x01(\x05R\aattempt\x12\x1f\n" +
+	"\vstatus_code\x18\x06 \x01(\x05R\n" +
+	"statusCode\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1c\n" +
+	"\tsucceeded\x18\b \x01(\bR\tsucceeded\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"S\n" +
+	"\x1cListWebhookDeliveriesRequest\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\x03R\twebhookId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"Y\n" +
+	"\x1dListWebhookDeliveriesResponse\x128\n" +
+	"\n" +
+	"deliveries\x18\x01 \x03(\v2\x18.project.WebhookDeliveryR\n" +
+	"deliveries2\xf0\x10\n" +
 	"\x0eProjectService\x12H\n" +
 	"\rCreateProject\x12\x1d.project.CreateProjectRequest\x1a\x18.project.ProjectResponse\x12B\n" +
 	"\n" +
 	"GetProject\x12\x1a.project.GetProjectRequest\x1a\x18.project.ProjectResponse\x12H\n" +
 	"\rUpdateProject\x12\x1d.project.UpdateProjectRequest\x1a\x18.project.ProjectResponse\x12>\n" +
 	"\rDeleteProject\x12\x1d.project.DeleteProjectRequest\x1a\x0e.project.Empty\x12K\n" +
-	"\fListProjects\x12\x1c.project.ListProjectsRequest\x1a\x1d.project.ListProjectsResponse\x12B\n" +
+	"\fListProjects\x12\x1c.project.ListProjectsRequest\x1a\x1d.project.ListProjectsResponse\x12L\n" +
+	"\x0fCompleteProject\x12\x1f.project.CompleteProjectRequest\x1a\x18.project.ProjectResponse\x12W\n" +
+	"\x10BatchGetProjects\x12 .project.BatchGetProjectsRequest\x1a!.project.BatchGetProjectsResponse\x12F\n" +
+	"\fCloneProject\x12\x1c.project.CloneProjectRequest\x1a\x18.project.ProjectResponse\x12J\n" +
+	"\x0eArchiveProject\x12\x1e.project.ArchiveProjectRequest\x1a\x18.project.ProjectResponse\x12N\n" +
+	"\x10UnarchiveProject\x12 .project.UnarchiveProjectRequest\x1a\x18.project.ProjectResponse\x12Z\n" +
+	"\x12GetProjectSettings\x12\".project.GetProjectSettingsRequest\x1a .project.ProjectSettingsResponse\x12`\n" +
+	"\x15UpdateProjectSettings\x12%.project.UpdateProjectSettingsRequest\x1a .project.ProjectSettingsResponse\x12B\n" +
 	"\vCreateSkill\x12\x1b.project.CreateSkillRequest\x1a\x16.project.SkillResponse\x129\n" +
 	"\n" +
 	"ListSkills\x12\x0e.project.Empty\x1a\x1b.project.ListSkillsResponse\x12B\n" +
@@ -1745,7 +2848,11 @@ const file_proto_project_project_proto_rawDesc = "" +
 	"\x11ListProjectImages\x12!.project.ListProjectImagesRequest\x1a\".project.ListProjectImagesResponse\x12N\n" +
 	"\x0eAddProjectLink\x12\x1e.project.AddProjectLinkRequest\x1a\x1c.project.ProjectLinkResponse\x12F\n" +
 	"\x11RemoveProjectLink\x12!.project.RemoveProjectLinkRequest\x1a\x0e.project.Empty\x12W\n" +
-	"\x10ListProjectLinks\x12 .project.ListProjectLinksRequest\x1a!.project.ListProjectLinksResponseB$Z\"github.com/portfolio/proto/projectb\x06proto3"
+	"\x10ListProjectLinks\x12 .project.ListProjectLinksRequest\x1a!.project.ListProjectLinksResponse\x12H\n" +
+	"\rCreateWebhook\x12\x1d.project.CreateWebhookRequest\x1a\x18.project.WebhookResponse\x12=\n" +
+	"\fListWebhooks\x12\x0e.project.Empty\x1a\x1d.project.ListWebhooksResponse\x12>\n" +
+	"\rDeleteWebhook\x12\x1d.project.DeleteWebhookRequest\x1a\x0e.project.Empty\x12f\n" +
+	"\x15ListWebhookDeliveries\x12%.project.ListWebhookDeliveriesRequest\x1a&.project.ListWebhookDeliveriesResponseB$Z\"github.com/portfolio/proto/projectb\x06proto3"
 
 var (
 	file_proto_project_project_proto_rawDescOnce sync.Once
@@ -1759,99 +2866,147 @@ func file_proto_project_project_proto_rawDescGZIP() []byte {
 	return file_proto_project_project_proto_rawDescData
 }
 
-var file_proto_project_project_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_proto_project_project_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_proto_project_project_proto_goTypes = []any{
-	(*Empty)(nil),                     // 0: project.Empty
-	(*Project)(nil),                   // 1: project.Project
-	(*CreateProjectRequest)(nil),      // 2: project.CreateProjectRequest
-	(*GetProjectRequest)(nil),         // 3: project.GetProjectRequest
-	(*ProjectResponse)(nil),           // 4: project.ProjectResponse
-	(*UpdateProjectRequest)(nil),      // 5: project.UpdateProjectRequest
-	(*DeleteProjectRequest)(nil),      // 6: project.DeleteProjectRequest
-	(*ListProjectsRequest)(nil),       // 7: project.ListProjectsRequest
-	(*ListProjectsResponse)(nil),      // 8: project.ListProjectsResponse
-	(*Skill)(nil),                     // 9: project.Skill
-	(*CreateSkillRequest)(nil),        // 10: project.CreateSkillRequest
-	(*SkillResponse)(nil),             // 11: project.SkillResponse
-	(*ListSkillsResponse)(nil),        // 12: project.ListSkillsResponse
-	(*AddProjectSkillRequest)(nil),    // 13: project.AddProjectSkillRequest
-	(*RemoveProjectSkillRequest)(nil), // 14: project.RemoveProjectSkillRequest
-	(*AddProjectTechRequest)(nil),     // 15: project.AddProjectTechRequest
-	(*RemoveProjectTechRequest)(nil),  // 16: project.RemoveProjectTechRequest
-	(*ProjectImage)(nil),              // 17: project.ProjectImage
-	(*AddProjectImageRequest)(nil),    // 18: project.AddProjectImageRequest
-	(*ProjectImageResponse)(nil),      // 19: project.ProjectImageResponse
-	(*RemoveProjectImageRequest)(nil), // 20: project.RemoveProjectImageRequest
-	(*ListProjectImagesRequest)(nil),  // 21: project.ListProjectImagesRequest
-	(*ListProjectImagesResponse)(nil), // 22: project.ListProjectImagesResponse
-	(*ProjectLink)(nil),               // 23: project.ProjectLink
-	(*AddProjectLinkRequest)(nil),     // 24: project.AddProjectLinkRequest
-	(*ProjectLinkResponse)(nil),       // 25: project.ProjectLinkResponse
-	(*RemoveProjectLinkRequest)(nil),  // 26: project.RemoveProjectLinkRequest
-	(*ListProjectLinksRequest)(nil),   // 27: project.ListProjectLinksRequest
-	(*ListProjectLinksResponse)(nil),  // 28: project.ListProjectLinksResponse
-	(*timestamppb.Timestamp)(nil),     // 29: google.protobuf.Timestamp
+	(*Empty)(nil),                         // 0: project.Empty
+	(*Project)(nil),                       // 1: project.Project
+	(*CreateProjectRequest)(nil),          // 2: project.CreateProjectRequest
+	(*GetProjectRequest)(nil),             // 3: project.GetProjectRequest
+	(*ProjectResponse)(nil),               // 4: project.ProjectResponse
+	(*UpdateProjectRequest)(nil),          // 5: project.UpdateProjectRequest
+	(*DeleteProjectRequest)(nil),          // 6: project.DeleteProjectRequest
+	(*ListProjectsRequest)(nil),           // 7: project.ListProjectsRequest
+	(*ListProjectsResponse)(nil),          // 8: project.ListProjectsResponse
+	(*BatchGetProjectsRequest)(nil),       // 9: project.BatchGetProjectsRequest
+	(*BatchGetProjectsResponse)(nil),      // 10: project.BatchGetProjectsResponse
+	(*CompleteProjectRequest)(nil),        // 11: project.CompleteProjectRequest
+	(*CloneProjectRequest)(nil),           // 12: project.CloneProjectRequest
+	(*ArchiveProjectRequest)(nil),         // 13: project.ArchiveProjectRequest
+	(*UnarchiveProjectRequest)(nil),       // 14: project.UnarchiveProjectRequest
+	(*ProjectSettings)(nil),               // 15: project.ProjectSettings
+	(*GetProjectSettingsRequest)(nil),     // 16: project.GetProjectSettingsRequest
+	(*UpdateProjectSettingsRequest)(nil),  // 17: project.UpdateProjectSettingsRequest
+	(*ProjectSettingsResponse)(nil),       // 18: project.ProjectSettingsResponse
+	(*Skill)(nil),                         // 19: project.Skill
+	(*CreateSkillRequest)(nil),            // 20: project.CreateSkillRequest
+	(*SkillResponse)(nil),                 // 21: project.SkillResponse
+	(*ListSkillsResponse)(nil),            // 22: project.ListSkillsResponse
+	(*AddProjectSkillRequest)(nil),        // 23: project.AddProjectSkillRequest
+	(*RemoveProjectSkillRequest)(nil),     // 24: project.RemoveProjectSkillRequest
+	(*AddProjectTechRequest)(nil),         // 25: project.AddProjectTechRequest
+	(*RemoveProjectTechRequest)(nil),      // 26: project.RemoveProjectTechRequest
+	(*ProjectImage)(nil),                  // 27: project.ProjectImage
+	(*AddProjectImageRequest)(nil),        // 28: project.AddProjectImageRequest
+	(*ProjectImageResponse)(nil),          // 29: project.ProjectImageResponse
+	(*RemoveProjectImageRequest)(nil),     // 30: project.RemoveProjectImageRequest
+	(*ListProjectImagesRequest)(nil),      // 31: project.ListProjectImagesRequest
+	(*ListProjectImagesResponse)(nil),     // 32: project.ListProjectImagesResponse
+	(*ProjectLink)(nil),                   // 33: project.ProjectLink
+	(*AddProjectLinkRequest)(nil),         // 34: project.AddProjectLinkRequest
+	(*ProjectLinkResponse)(nil),           // 35: project.ProjectLinkResponse
+	(*RemoveProjectLinkRequest)(nil),      // 36: project.RemoveProjectLinkRequest
+	(*ListProjectLinksRequest)(nil),       // 37: project.ListProjectLinksRequest
+	(*ListProjectLinksResponse)(nil),      // 38: project.ListProjectLinksResponse
+	(*Webhook)(nil),                       // 39: project.Webhook
+	(*CreateWebhookRequest)(nil),          // 40: project.CreateWebhookRequest
+	(*WebhookResponse)(nil),               // 41: project.WebhookResponse
+	(*ListWebhooksResponse)(nil),          // 42: project.ListWebhooksResponse
+	(*DeleteWebhookRequest)(nil),          // 43: project.DeleteWebhookRequest
+	(*WebhookDelivery)(nil),               // 44: project.WebhookDelivery
+	(*ListWebhookDeliveriesRequest)(nil),  // 45: project.ListWebhookDeliveriesRequest
+	(*ListWebhookDeliveriesResponse)(nil), // 46: project.ListWebhookDeliveriesResponse
+	(*timestamppb.Timestamp)(nil),         // 47: google.protobuf.Timestamp
 }
 var file_proto_project_project_proto_depIdxs = []int32{
-	29, // 0: project.Project.start_date:type_name -> google.protobuf.Timestamp
-	29, // 1: project.Project.end_date:type_name -> google.protobuf.Timestamp
-	9,  // 2: project.Project.skills:type_name -> project.Skill
-	17, // 3: project.Project.images:type_name -> project.ProjectImage
-	23, // 4: project.Project.links:type_name -> project.ProjectLink
-	29, // 5: project.Project.created_at:type_name -> google.protobuf.Timestamp
-	29, // 6: project.Project.updated_at:type_name -> google.protobuf.Timestamp
-	29, // 7: project.CreateProjectRequest.start_date:type_name -> google.protobuf.Timestamp
-	29, // 8: project.CreateProjectRequest.end_date:type_name -> google.protobuf.Timestamp
+	47, // 0: project.Project.start_date:type_name -> google.protobuf.Timestamp
+	47, // 1: project.Project.end_date:type_name -> google.protobuf.Timestamp
+	19, // 2: project.Project.skills:type_name -> project.Skill
+	27, // 3: project.Project.images:type_name -> project.ProjectImage
+	33, // 4: project.Project.links:type_name -> project.ProjectLink
+	47, // 5: project.Project.created_at:type_name -> google.protobuf.Timestamp
+	47, // 6: project.Project.updated_at:type_name -> google.protobuf.Timestamp
+	47, // 7: project.CreateProjectRequest.start_date:type_name -> google.protobuf.Timestamp
+	47, // 8: project.CreateProjectRequest.end_date:type_name -> google.protobuf.Timestamp
 	1,  // 9: project.ProjectResponse.project:type_name -> project.Project
-	29, // 10: project.UpdateProjectRequest.start_date:type_name -> google.protobuf.Timestamp
-	29, // 11: project.UpdateProjectRequest.end_date:type_name -> google.protobuf.Timestamp
+	47, // 10: project.UpdateProjectRequest.start_date:type_name -> google.protobuf.Timestamp
+	47, // 11: project.UpdateProjectRequest.end_date:type_name -> google.protobuf.Timestamp
 	1,  // 12: project.ListProjectsResponse.projects:type_name -> project.Project
-	9,  // 13: project.SkillResponse.skill:type_name -> project.Skill
-	9,  // 14: project.ListSkillsResponse.skills:type_name -> project.Skill
-	29, // 15: project.ProjectImage.uploaded_at:type_name -> google.protobuf.Timestamp
-	17, // 16: project.ProjectImageResponse.image:type_name -> project.ProjectImage
-	17, // 17: project.ListProjectImagesResponse.images:type_name -> project.ProjectImage
-	23, // 18: project.ProjectLinkResponse.link:type_name -> project.ProjectLink
-	23, // 19: project.ListProjectLinksResponse.links:type_name -> project.ProjectLink
-	2,  // 20: project.ProjectService.CreateProject:input_type -> project.CreateProjectRequest
-	3,  // 21: project.ProjectService.GetProject:input_type -> project.GetProjectRequest
-	5,  // 22: project.ProjectService.UpdateProject:input_type -> project.UpdateProjectRequest
-	6,  // 23: project.ProjectService.DeleteProject:input_type -> project.DeleteProjectRequest
-	7,  // 24: project.ProjectService.ListProjects:input_type -> project.ListProjectsRequest
-	10, // 25: project.ProjectService.CreateSkill:input_type -> project.CreateSkillRequest
-	0,  // 26: project.ProjectService.ListSkills:input_type -> project.Empty
-	13, // 27: project.ProjectService.AddProjectSkill:input_type -> project.AddProjectSkillRequest
-	14, // 28: project.ProjectService.RemoveProjectSkill:input_type -> project.RemoveProjectSkillRequest
-	15, // 29: project.ProjectService.AddProjectTech:input_type -> project.AddProjectTechRequest
-	16, // 30: project.ProjectService.RemoveProjectTech:input_type -> project.RemoveProjectTechRequest
-	18, // 31: project.ProjectService.AddProjectImage:input_type -> project.AddProjectImageRequest
-	20, // 32: project.ProjectService.RemoveProjectImage:input_type -> project.RemoveProjectImageRequest
-	21, // 33: project.ProjectService.ListProjectImages:input_type -> project.ListProjectImagesRequest
-	24, // 34: project.ProjectService.AddProjectLink:input_type -> project.AddProjectLinkRequest
-	26, // 35: project.ProjectService.RemoveProjectLink:input_type -> project.RemoveProjectLinkRequest
-	27, // 36: project.ProjectService.ListProjectLinks:input_type -> project.ListProjectLinksRequest
-	4,  // 37: project.ProjectService.CreateProject:output_type -> project.ProjectResponse
-	4,  // 38: project.ProjectService.GetProject:output_type -> project.ProjectResponse
-	4,  // 39: project.ProjectService.UpdateProject:output_type -> project.ProjectResponse
-	0,  // 40: project.ProjectService.DeleteProject:output_type -> project.Empty
-	8,  // 41: project.ProjectService.ListProjects:output_type -> project.ListProjectsResponse
-	11, // 42: project.ProjectService.CreateSkill:output_type -> project.SkillResponse
-	12, // 43: project.ProjectService.ListSkills:output_type -> project.ListSkillsResponse
-	0,  // 44: project.ProjectService.AddProjectSkill:output_type -> project.Empty
-	0,  // 45: project.ProjectService.RemoveProjectSkill:output_type -> project.Empty
-	0,  // 46: project.ProjectService.AddProjectTech:output_type -> project.Empty
-	0,  // 47: project.ProjectService.RemoveProjectTech:output_type -> project.Empty
-	19, // 48: project.ProjectService.AddProjectImage:output_type -> project.ProjectImageResponse
-	0,  // 49: project.ProjectService.RemoveProjectImage:output_type -> project.Empty
-	22, // 50: project.ProjectService.ListProjectImages:output_type -> project.ListProjectImagesResponse
-	25, // 51: project.ProjectService.AddProjectLink:output_type -> project.ProjectLinkResponse
-	0,  // 52: project.ProjectService.RemoveProjectLink:output_type -> project.Empty
-	28, // 53: project.ProjectService.ListProjectLinks:output_type -> project.ListProjectLinksResponse
-	37, // [37:54] is the sub-list for method output_type
-	20, // [20:37] is the sub-list for method input_type
-	20, // [20:20] is the sub-list for extension type_name
-	20, // [20:20] is the sub-list for extension extendee
-	0,  // [0:20] is the sub-list for field type_name
+	1,  // 13: project.BatchGetProjectsResponse.projects:type_name -> project.Project
+	47, // 14: project.ProjectSettings.updated_at:type_name -> google.protobuf.Timestamp
+	15, // 15: project.ProjectSettingsResponse.settings:type_name -> project.ProjectSettings
+	19, // 16: project.SkillResponse.skill:type_name -> project.Skill
+	19, // 17: project.ListSkillsResponse.skills:type_name -> project.Skill
+	47, // 18: project.ProjectImage.uploaded_at:type_name -> google.protobuf.Timestamp
+	27, // 19: project.ProjectImageResponse.image:type_name -> project.ProjectImage
+	27, // 20: project.ListProjectImagesResponse.images:type_name -> project.ProjectImage
+	33, // 21: project.ProjectLinkResponse.link:type_name -> project.ProjectLink
+	33, // 22: project.ListProjectLinksResponse.links:type_name -> project.ProjectLink
+	47, // 23: project.Webhook.created_at:type_name -> google.protobuf.Timestamp
+	39, // 24: project.WebhookResponse.webhook:type_name -> project.Webhook
+	39, // 25: project.ListWebhooksResponse.webhooks:type_name -> project.Webhook
+	47, // 26: project.WebhookDelivery.created_at:type_name -> google.protobuf.Timestamp
+	44, // 27: project.ListWebhookDeliveriesResponse.deliveries:type_name -> project.WebhookDelivery
+	2,  // 28: project.ProjectService.CreateProject:input_type -> project.CreateProjectRequest
+	3,  // 29: project.ProjectService.GetProject:input_type -> project.GetProjectRequest
+	5,  // 30: project.ProjectService.UpdateProject:input_type -> project.UpdateProjectRequest
+	6,  // 31: project.ProjectService.DeleteProject:input_type -> project.DeleteProjectRequest
+	7,  // 32: project.ProjectService.ListProjects:input_type -> project.ListProjectsRequest
+	11, // 33: project.ProjectService.CompleteProject:input_type -> project.CompleteProjectRequest
+	9,  // 34: project.ProjectService.BatchGetProjects:input_type -> project.BatchGetProjectsRequest
+	12, // 35: project.ProjectService.CloneProject:input_type -> project.CloneProjectRequest
+	13, // 36: project.ProjectService.ArchiveProject:input_type -> project.ArchiveProjectRequest
+	14, // 37: project.ProjectService.UnarchiveProject:input_type -> project.UnarchiveProjectRequest
+	16, // 38: project.ProjectService.GetProjectSettings:input_type -> project.GetProjectSettingsRequest
+	17, // 39: project.ProjectService.UpdateProjectSettings:input_type -> project.UpdateProjectSettingsRequest
+	20, // 40: project.ProjectService.CreateSkill:input_type -> project.CreateSkillRequest
+	0,  // 41: project.ProjectService.ListSkills:input_type -> project.Empty
+	23, // 42: project.ProjectService.AddProjectSkill:input_type -> project.AddProjectSkillRequest
+	24, // 43: project.ProjectService.RemoveProjectSkill:input_type -> project.RemoveProjectSkillRequest
+	25, // 44: project.ProjectService.AddProjectTech:input_type -> project.AddProjectTechRequest
+	26, // 45: project.ProjectService.RemoveProjectTech:input_type -> project.RemoveProjectTechRequest
+	28, // 46: project.ProjectService.AddProjectImage:input_type -> project.AddProjectImageRequest
+	30, // 47: project.ProjectService.RemoveProjectImage:input_type -> project.RemoveProjectImageRequest
+	31, // 48: project.ProjectService.ListProjectImages:input_type -> project.ListProjectImagesRequest
+	34, // 49: project.ProjectService.AddProjectLink:input_type -> project.AddProjectLinkRequest
+	36, // 50: project.ProjectService.RemoveProjectLink:input_type -> project.RemoveProjectLinkRequest
+	37, // 51: project.ProjectService.ListProjectLinks:input_type -> project.ListProjectLinksRequest
+	40, // 52: project.ProjectService.CreateWebhook:input_type -> project.CreateWebhookRequest
+	0,  // 53: project.ProjectService.ListWebhooks:input_type -> project.Empty
+	43, // 54: project.ProjectService.DeleteWebhook:input_type -> project.DeleteWebhookRequest
+	45, // 55: project.ProjectService.ListWebhookDeliveries:input_type -> project.ListWebhookDeliveriesRequest
+	4,  // 56: project.ProjectService.CreateProject:output_type -> project.ProjectResponse
+	4,  // 57: project.ProjectService.GetProject:output_type -> project.ProjectResponse
+	4,  // 58: project.ProjectService.UpdateProject:output_type -> project.ProjectResponse
+	0,  // 59: project.ProjectService.DeleteProject:output_type -> project.Empty
+	8,  // 60: project.ProjectService.ListProjects:output_type -> project.ListProjectsResponse
+	4,  // 61: project.ProjectService.CompleteProject:output_type -> project.ProjectResponse
+	10, // 62: project.ProjectService.BatchGetProjects:output_type -> project.BatchGetProjectsResponse
+	4,  // 63: project.ProjectService.CloneProject:output_type -> project.ProjectResponse
+	4,  // 64: project.ProjectService.ArchiveProject:output_type -> project.ProjectResponse
+	4,  // 65: project.ProjectService.UnarchiveProject:output_type -> project.ProjectResponse
+	18, // 66: project.ProjectService.GetProjectSettings:output_type -> project.ProjectSettingsResponse
+	18, // 67: project.ProjectService.UpdateProjectSettings:output_type -> project.ProjectSettingsResponse
+	21, // 68: project.ProjectService.CreateSkill:output_type -> project.SkillResponse
+	22, // 69: project.ProjectService.ListSkills:output_type -> project.ListSkillsResponse
+	0,  // 70: project.ProjectService.AddProjectSkill:output_type -> project.Empty
+	0,  // 71: project.ProjectService.RemoveProjectSkill:output_type -> project.Empty
+	0,  // 72: project.ProjectService.AddProjectTech:output_type -> project.Empty
+	0,  // 73: project.ProjectService.RemoveProjectTech:output_type -> project.Empty
+	29, // 74: project.ProjectService.AddProjectImage:output_type -> project.ProjectImageResponse
+	0,  // 75: project.ProjectService.RemoveProjectImage:output_type -> project.Empty
+	32, // 76: project.ProjectService.ListProjectImages:output_type -> project.ListProjectImagesResponse
+	35, // 77: project.ProjectService.AddProjectLink:output_type -> project.ProjectLinkResponse
+	0,  // 78: project.ProjectService.RemoveProjectLink:output_type -> project.Empty
+	38, // 79: project.ProjectService.ListProjectLinks:output_type -> project.ListProjectLinksResponse
+	41, // 80: project.ProjectService.CreateWebhook:output_type -> project.WebhookResponse
+	42, // 81: project.ProjectService.ListWebhooks:output_type -> project.ListWebhooksResponse
+	0,  // 82: project.ProjectService.DeleteWebhook:output_type -> project.Empty
+	46, // 83: project.ProjectService.ListWebhookDeliveries:output_type -> project.ListWebhookDeliveriesResponse
+	56, // [56:84] is the sub-list for method output_type
+	28, // [28:56] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_project_project_proto_init() }
@@ -1865,7 +3020,7 @@ func file_proto_project_project_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_project_project_proto_rawDesc), len(file_proto_project_project_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,23 +19,34 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProjectService_CreateProject_FullMethodName      = "/project.ProjectService/CreateProject"
-	ProjectService_GetProject_FullMethodName         = "/project.ProjectService/GetProject"
-	ProjectService_UpdateProject_FullMethodName      = "/project.ProjectService/UpdateProject"
-	ProjectService_DeleteProject_FullMethodName      = "/project.ProjectService/DeleteProject"
-	ProjectService_ListProjects_FullMethodName       = "/project.ProjectService/ListProjects"
-	ProjectService_CreateSkill_FullMethodName        = "/project.ProjectService/CreateSkill"
-	ProjectService_ListSkills_FullMethodName         = "/project.ProjectService/ListSkills"
-	ProjectService_AddProjectSkill_FullMethodName    = "/project.ProjectService/AddProjectSkill"
-	ProjectService_RemoveProjectSkill_FullMethodName = "/project.ProjectService/RemoveProjectSkill"
-	ProjectService_AddProjectTech_FullMethodName     = "/project.ProjectService/AddProjectTech"
-	ProjectService_RemoveProjectTech_FullMethodName  = "/project.ProjectService/RemoveProjectTech"
-	ProjectService_AddProjectImage_FullMethodName    = "/project.ProjectService/AddProjectImage"
-	ProjectService_RemoveProjectImage_FullMethodName = "/project.ProjectService/RemoveProjectImage"
-	ProjectService_ListProjectImages_FullMethodName  = "/project.ProjectService/ListProjectImages"
-	ProjectService_AddProjectLink_FullMethodName     = "/project.ProjectService/AddProjectLink"
-	ProjectService_RemoveProjectLink_FullMethodName  = "/project.ProjectService/RemoveProjectLink"
-	ProjectService_ListProjectLinks_FullMethodName   = "/project.ProjectService/ListProjectLinks"
+	ProjectService_CreateProject_FullMethodName         = "/project.ProjectService/CreateProject"
+	ProjectService_GetProject_FullMethodName            = "/project.ProjectService/GetProject"
+	ProjectService_UpdateProject_FullMethodName         = "/project.ProjectService/UpdateProject"
+	ProjectService_DeleteProject_FullMethodName         = "/project.ProjectService/DeleteProject"
+	ProjectService_ListProjects_FullMethodName          = "/project.ProjectService/ListProjects"
+	ProjectService_CompleteProject_FullMethodName       = "/project.ProjectService/CompleteProject"
+	ProjectService_BatchGetProjects_FullMethodName      = "/project.ProjectService/BatchGetProjects"
+	ProjectService_CloneProject_FullMethodName          = "/project.ProjectService/CloneProject"
+	ProjectService_ArchiveProject_FullMethodName        = "/project.ProjectService/ArchiveProject"
+	ProjectService_UnarchiveProject_FullMethodName      = "/project.ProjectService/UnarchiveProject"
+	ProjectService_GetProjectSettings_FullMethodName    = "/project.ProjectService/GetProjectSettings"
+	ProjectService_UpdateProjectSettings_FullMethodName = "/project.ProjectService/UpdateProjectSettings"
+	ProjectService_CreateSkill_FullMethodName           = "/project.ProjectService/CreateSkill"
+	ProjectService_ListSkills_FullMethodName            = "/project.ProjectService/ListSkills"
+	ProjectService_AddProjectSkill_FullMethodName       = "/project.ProjectService/AddProjectSkill"
+	ProjectService_RemoveProjectSkill_FullMethodName    = "/project.ProjectService/RemoveProjectSkill"
+	ProjectService_AddProjectTech_FullMethodName        = "/project.ProjectService/AddProjectTech"
+	ProjectService_RemoveProjectTech_FullMethodName     = "/project.ProjectService/RemoveProjectTech"
+	ProjectService_AddProjectImage_FullMethodName       = "/project.ProjectService/AddProjectImage"
+	ProjectService_RemoveProjectImage_FullMethodName    = "/project.ProjectService/RemoveProjectImage"
+	ProjectService_ListProjectImages_FullMethodName     = "/project.ProjectService/ListProjectImages"
+	ProjectService_AddProjectLink_FullMethodName        = "/project.ProjectService/AddProjectLink"
+	ProjectService_RemoveProjectLink_FullMethodName     = "/project.ProjectService/RemoveProjectLink"
+	ProjectService_ListProjectLinks_FullMethodName      = "/project.ProjectService/ListProjectLinks"
+	ProjectService_CreateWebhook_FullMethodName         = "/project.ProjectService/CreateWebhook"
+	ProjectService_ListWebhooks_FullMethodName          = "/project.ProjectService/ListWebhooks"
+	ProjectService_DeleteWebhook_FullMethodName         = "/project.ProjectService/DeleteWebhook"
+	ProjectService_ListWebhookDeliveries_FullMethodName = "/project.ProjectService/ListWebhookDeliveries"
 )
 
 // ProjectServiceClient is the client API for ProjectService service.
@@ -50,6 +61,14 @@ type ProjectServiceClient interface {
 	UpdateProject(ctx context.Context, in *UpdateProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
 	DeleteProject(ctx context.Context, in *DeleteProjectRequest, opts ...grpc.CallOption) (*Empty, error)
 	ListProjects(ctx context.Context, in *ListProjectsRequest, opts ...grpc.CallOption) (*ListProjectsResponse, error)
+	CompleteProject(ctx context.Context, in *CompleteProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	BatchGetProjects(ctx context.Context, in *BatchGetProjectsRequest, opts ...grpc.CallOption) (*BatchGetProjectsResponse, error)
+	CloneProject(ctx context.Context, in *CloneProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	UnarchiveProject(ctx context.Context, in *UnarchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error)
+	// Per-project defaults for new tasks
+	GetProjectSettings(ctx context.Context, in *GetProjectSettingsRequest, opts ...grpc.CallOption) (*ProjectSettingsResponse, error)
+	UpdateProjectSettings(ctx context.Context, in *UpdateProjectSettingsRequest, opts ...grpc.CallOption) (*ProjectSettingsResponse, error)
 	// Skills
 	CreateSkill(ctx context.Context, in *CreateSkillRequest, opts ...grpc.CallOption) (*SkillResponse, error)
 	ListSkills(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListSkillsResponse, error)
@@ -66,6 +85,11 @@ type ProjectServiceClient interface {
 	AddProjectLink(ctx context.Context, in *AddProjectLinkRequest, opts ...grpc.CallOption) (*ProjectLinkResponse, error)
 	RemoveProjectLink(ctx context.Context, in *RemoveProjectLinkRequest, opts ...grpc.CallOption) (*Empty, error)
 	ListProjectLinks(ctx context.Context, in *ListProjectLinksRequest, opts ...grpc.CallOption) (*ListProjectLinksResponse, error)
+	// Webhooks for task and project changes (the gateway only allows admins)
+	CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error)
+	ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWebhooksResponse, error)
+	DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error)
 }
 
 type projectServiceClient struct {
@@ -126,6 +150,76 @@ func (c *projectServiceClient) ListProjects(ctx context.Context, in *ListProject
 	return out, nil
 }
 
+func (c *projectServiceClient) CompleteProject(ctx context.Context, in *CompleteProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectService_CompleteProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) BatchGetProjects(ctx context.Context, in *BatchGetProjectsRequest, opts ...grpc.CallOption) (*BatchGetProjectsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetProjectsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_BatchGetProjects_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) CloneProject(ctx context.Context, in *CloneProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectService_CloneProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ArchiveProject(ctx context.Context, in *ArchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ArchiveProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) UnarchiveProject(ctx context.Context, in *UnarchiveProjectRequest, opts ...grpc.CallOption) (*ProjectResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectResponse)
+	err := c.cc.Invoke(ctx, ProjectService_UnarchiveProject_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) GetProjectSettings(ctx context.Context, in *GetProjectSettingsRequest, opts ...grpc.CallOption) (*ProjectSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectSettingsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_GetProjectSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) UpdateProjectSettings(ctx context.Context, in *UpdateProjectSettingsRequest, opts ...grpc.CallOption) (*ProjectSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ProjectSettingsResponse)
+	err := c.cc.Invoke(ctx, ProjectService_UpdateProjectSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *projectServiceClient) CreateSkill(ctx context.Context, in *CreateSkillRequest, opts ...grpc.CallOption) (*SkillResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SkillResponse)
@@ -246,6 +340,46 @@ func (c *projectServiceClient) ListProjectLinks(ctx context.Context, in *ListPro
 	return out, nil
 }
 
+func (c *projectServiceClient) CreateWebhook(ctx context.Context, in *CreateWebhookRequest, opts ...grpc.CallOption) (*WebhookResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WebhookResponse)
+	err := c.cc.Invoke(ctx, ProjectService_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ListWebhooks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListWebhooksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) DeleteWebhook(ctx context.Context, in *DeleteWebhookRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, ProjectService_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *projectServiceClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesRequest, opts ...grpc.CallOption) (*ListWebhookDeliveriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesResponse)
+	err := c.cc.Invoke(ctx, ProjectService_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProjectServiceServer is the server API for ProjectService service.
 // All implementations must embed UnimplementedProjectServiceServer
 // for forward compatibility.
@@ -258,6 +392,14 @@ type ProjectServiceServer interface {
 	UpdateProject(context.Context, *UpdateProjectRequest) (*ProjectResponse, error)
 	DeleteProject(context.Context, *DeleteProjectRequest) (*Empty, error)
 	ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error)
+	CompleteProject(context.Context, *CompleteProjectRequest) (*ProjectResponse, error)
+	BatchGetProjects(context.Context, *BatchGetProjectsRequest) (*BatchGetProjectsResponse, error)
+	CloneProject(context.Context, *CloneProjectRequest) (*ProjectResponse, error)
+	ArchiveProject(context.Context, *ArchiveProjectRequest) (*ProjectResponse, error)
+	UnarchiveProject(context.Context, *UnarchiveProjectRequest) (*ProjectResponse, error)
+	// Per-project defaults for new tasks
+	GetProjectSettings(context.Context, *GetProjectSettingsRequest) (*ProjectSettingsResponse, error)
+	UpdateProjectSettings(context.Context, *UpdateProjectSettingsRequest) (*ProjectSettingsResponse, error)
 	// Skills
 	CreateSkill(context.Context, *CreateSkillRequest) (*SkillResponse, error)
 	ListSkills(context.Context, *Empty) (*ListSkillsResponse, error)
@@ -274,6 +416,11 @@ type ProjectServiceServer interface {
 	AddProjectLink(context.Context, *AddProjectLinkRequest) (*ProjectLinkResponse, error)
 	RemoveProjectLink(context.Context, *RemoveProjectLinkRequest) (*Empty, error)
 	ListProjectLinks(context.Context, *ListProjectLinksRequest) (*ListProjectLinksResponse, error)
+	// Webhooks for task and project changes (the gateway only allows admins)
+	CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookResponse, error)
+	ListWebhooks(context.Context, *Empty) (*ListWebhooksResponse, error)
+	DeleteWebhook(context.Context, *DeleteWebhookRequest) (*Empty, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error)
 	mustEmbedUnimplementedProjectServiceServer()
 }
 
@@ -299,6 +446,27 @@ func (UnimplementedProjectServiceServer) DeleteProject(context.Context, *DeleteP
 func (UnimplementedProjectServiceServer) ListProjects(context.Context, *ListProjectsRequest) (*ListProjectsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProjects not implemented")
 }
+func (UnimplementedProjectServiceServer) CompleteProject(context.Context, *CompleteProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CompleteProject not implemented")
+}
+func (UnimplementedProjectServiceServer) BatchGetProjects(context.Context, *BatchGetProjectsRequest) (*BatchGetProjectsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetProjects not implemented")
+}
+func (UnimplementedProjectServiceServer) CloneProject(context.Context, *CloneProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CloneProject not implemented")
+}
+func (UnimplementedProjectServiceServer) ArchiveProject(context.Context, *ArchiveProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveProject not implemented")
+}
+func (UnimplementedProjectServiceServer) UnarchiveProject(context.Context, *UnarchiveProjectRequest) (*ProjectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveProject not implemented")
+}
+func (UnimplementedProjectServiceServer) GetProjectSettings(context.Context, *GetProjectSettingsRequest) (*ProjectSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProjectSettings not implemented")
+}
+func (UnimplementedProjectServiceServer) UpdateProjectSettings(context.Context, *UpdateProjectSettingsRequest) (*ProjectSettingsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateProjectSettings not implemented")
+}
 func (UnimplementedProjectServiceServer) CreateSkill(context.Context, *CreateSkillRequest) (*SkillResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSkill not implemented")
 }
@@ -335,6 +503,18 @@ func (UnimplementedProjectServiceServer) RemoveProjectLink(context.Context, *Rem
 func (UnimplementedProjectServiceServer) ListProjectLinks(context.Context, *ListProjectLinksRequest) (*ListProjectLinksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListProjectLinks not implemented")
 }
+func (UnimplementedProjectServiceServer) CreateWebhook(context.Context, *CreateWebhookRequest) (*WebhookResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedProjectServiceServer) ListWebhooks(context.Context, *Empty) (*ListWebhooksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedProjectServiceServer) DeleteWebhook(context.Context, *DeleteWebhookRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedProjectServiceServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesRequest) (*ListWebhookDeliveriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
 func (UnimplementedProjectServiceServer) mustEmbedUnimplementedProjectServiceServer() {}
 func (UnimplementedProjectServiceServer) testEmbeddedByValue()                        {}
 
@@ -446,6 +626,132 @@ func _ProjectService_ListProjects_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_CompleteProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompleteProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).CompleteProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_CompleteProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).CompleteProject(ctx, req.(*CompleteProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_BatchGetProjects_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetProjectsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).BatchGetProjects(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_BatchGetProjects_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).BatchGetProjects(ctx, req.(*BatchGetProjectsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_CloneProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).CloneProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_CloneProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).CloneProject(ctx, req.(*CloneProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ArchiveProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ArchiveProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ArchiveProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ArchiveProject(ctx, req.(*ArchiveProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_UnarchiveProject_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveProjectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).UnarchiveProject(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_UnarchiveProject_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).UnarchiveProject(ctx, req.(*UnarchiveProjectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_GetProjectSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProjectSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).GetProjectSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_GetProjectSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).GetProjectSettings(ctx, req.(*GetProjectSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_UpdateProjectSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateProjectSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).UpdateProjectSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_UpdateProjectSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).UpdateProjectSettings(ctx, req.(*UpdateProjectSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProjectService_CreateSkill_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateSkillRequest)
 	if err := dec(in); err != nil {
@@ -662,6 +968,78 @@ func _ProjectService_ListProjectLinks_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProjectService_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).CreateWebhook(ctx, req.(*CreateWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListWebhooks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteWebhookRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).DeleteWebhook(ctx, req.(*DeleteWebhookRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProjectService_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProjectServiceServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProjectService_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProjectServiceServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProjectService_ServiceDesc is the grpc.ServiceDesc for ProjectService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -689,6 +1067,34 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListProjects",
 			Handler:    _ProjectService_ListProjects_Handler,
 		},
+		{
+			MethodName: "CompleteProject",
+			Handler:    _ProjectService_CompleteProject_Handler,
+		},
+		{
+			MethodName: "BatchGetProjects",
+			Handler:    _ProjectService_BatchGetProjects_Handler,
+		},
+		{
+			MethodName: "CloneProject",
+			Handler:    _ProjectService_CloneProject_Handler,
+		},
+		{
+			MethodName: "ArchiveProject",
+			Handler:    _ProjectService_ArchiveProject_Handler,
+		},
+		{
+			MethodName: "UnarchiveProject",
+			Handler:    _ProjectService_UnarchiveProject_Handler,
+		},
+		{
+			MethodName: "GetProjectSettings",
+			Handler:    _ProjectService_GetProjectSettings_Handler,
+		},
+		{
+			MethodName: "UpdateProjectSettings",
+			Handler:    _ProjectService_UpdateProjectSettings_Handler,
+		},
 		{
 			MethodName: "CreateSkill",
 			Handler:    _ProjectService_CreateSkill_Handler,
@@ -737,6 +1143,22 @@ var ProjectService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListProjectLinks",
 			Handler:    _ProjectService_ListProjectLinks_Handler,
 		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _ProjectService_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _ProjectService_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _ProjectService_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _ProjectService_ListWebhookDeliveries_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/project/project.proto",
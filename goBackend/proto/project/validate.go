@@ -0,0 +1,59 @@
+package project
+
+import (
+	"errors"
+	"strings"
+)
+
+// Request rules checked by the validation interceptor before a request
+// reaches the handler. Rules that need the database stay in the use cases.
+
+var (
+	errNameRequired     = errors.New("name is required")
+	errInvalidDateRange = errors.New("end date must not be before start date")
+)
+
+// Validate checks a CreateProjectRequest
+func (r *CreateProjectRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errNameRequired
+	}
+	if r.StartDate != nil && r.EndDate != nil && r.EndDate.AsTime().Before(r.StartDate.AsTime()) {
+		return errInvalidDateRange
+	}
+	return nil
+}
+
+// Validate checks an UpdateProjectRequest. The date range is checked again
+// by the use case once missing dates are filled in from the stored project.
+func (r *UpdateProjectRequest) Validate() error {
+	if r.Id <= 0 {
+		return errors.New("project id is required")
+	}
+	if r.Name != "" && strings.TrimSpace(r.Name) == "" {
+		return errNameRequired
+	}
+	if r.StartDate != nil && r.EndDate != nil && r.EndDate.AsTime().Before(r.StartDate.AsTime()) {
+		return errInvalidDateRange
+	}
+	return nil
+}
+
+// Validate checks a CreateSkillRequest
+func (r *CreateSkillRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errNameRequired
+	}
+	return nil
+}
+
+// Validate checks an AddProjectLinkRequest
+func (r *AddProjectLinkRequest) Validate() error {
+	if r.ProjectId <= 0 {
+		return errors.New("project id is required")
+	}
+	if strings.TrimSpace(r.LinkUrl) == "" {
+		return errors.New("link url is required")
+	}
+	return nil
+}
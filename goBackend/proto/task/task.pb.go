@@ -73,6 +73,7 @@ type Task struct {
 	Tags          []*Tag                 `protobuf:"bytes,10,rep,name=tags,proto3" json:"tags,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Progress      float64                `protobuf:"fixed64,13,opt,name=progress,proto3" json:"progress,omitempty"` // share of completed subtasks (0-1), set in list responses
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -191,6 +192,13 @@ func (x *Task) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Task) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
 type CreateTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
@@ -372,16 +380,20 @@ func (x *TaskResponse) GetTask() *Task {
 }
 
 type UpdateTaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	AssignedTo    int64                  `protobuf:"varint,6,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Status      string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority    int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	AssignedTo  int64                  `protobuf:"varint,6,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	DueDate     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	// clear_* unset the optional fields; they can't be combined with a new value
+	ClearAssignedTo  bool `protobuf:"varint,8,opt,name=clear_assigned_to,json=clearAssignedTo,proto3" json:"clear_assigned_to,omitempty"`
+	ClearDueDate     bool `protobuf:"varint,9,opt,name=clear_due_date,json=clearDueDate,proto3" json:"clear_due_date,omitempty"`
+	ClearDescription bool `protobuf:"varint,10,opt,name=clear_description,json=clearDescription,proto3" json:"clear_description,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *UpdateTaskRequest) Reset() {
@@ -463,6 +475,27 @@ func (x *UpdateTaskRequest) GetDueDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *UpdateTaskRequest) GetClearAssignedTo() bool {
+	if x != nil {
+		return x.ClearAssignedTo
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetClearDueDate() bool {
+	if x != nil {
+		return x.ClearDueDate
+	}
+	return false
+}
+
+func (x *UpdateTaskRequest) GetClearDescription() bool {
+	if x != nil {
+		return x.ClearDescription
+	}
+	return false
+}
+
 type DeleteTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -507,31 +540,27 @@ func (x *DeleteTaskRequest) GetId() int64 {
 	return 0
 }
 
-type ListTasksRequest struct {
+type WatchProjectTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	AssignedTo    int64                  `protobuf:"varint,5,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksRequest) Reset() {
-	*x = ListTasksRequest{}
+func (x *WatchProjectTasksRequest) Reset() {
+	*x = WatchProjectTasksRequest{}
 	mi := &file_proto_task_task_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksRequest) String() string {
+func (x *WatchProjectTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksRequest) ProtoMessage() {}
+func (*WatchProjectTasksRequest) ProtoMessage() {}
 
-func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+func (x *WatchProjectTasksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -543,68 +572,44 @@ func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
-func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use WatchProjectTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchProjectTasksRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListTasksRequest) GetProjectId() int64 {
+func (x *WatchProjectTasksRequest) GetProjectId() int64 {
 	if x != nil {
 		return x.ProjectId
 	}
 	return 0
 }
 
-func (x *ListTasksRequest) GetPage() int32 {
-	if x != nil {
-		return x.Page
-	}
-	return 0
-}
-
-func (x *ListTasksRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *ListTasksRequest) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-func (x *ListTasksRequest) GetAssignedTo() int64 {
-	if x != nil {
-		return x.AssignedTo
-	}
-	return 0
-}
-
-type ListTasksResponse struct {
+// A change to a task; type is task.created, task.updated or task.deleted
+type TaskEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
-	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TaskId        int64                  `protobuf:"varint,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Task          *Task                  `protobuf:"bytes,4,opt,name=task,proto3" json:"task,omitempty"` // unset for task.deleted
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTasksResponse) Reset() {
-	*x = ListTasksResponse{}
+func (x *TaskEvent) Reset() {
+	*x = TaskEvent{}
 	mi := &file_proto_task_task_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTasksResponse) String() string {
+func (x *TaskEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTasksResponse) ProtoMessage() {}
+func (*TaskEvent) ProtoMessage() {}
 
-func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+func (x *TaskEvent) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -616,54 +621,81 @@ func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
-func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TaskEvent.ProtoReflect.Descriptor instead.
+func (*TaskEvent) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListTasksResponse) GetTasks() []*Task {
+func (x *TaskEvent) GetType() string {
 	if x != nil {
-		return x.Tasks
+		return x.Type
 	}
-	return nil
+	return ""
 }
 
-func (x *ListTasksResponse) GetTotal() int32 {
+func (x *TaskEvent) GetProjectId() int64 {
 	if x != nil {
-		return x.Total
+		return x.ProjectId
 	}
 	return 0
 }
 
-// Subtask messages
-type Subtask struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	TaskId        int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	AssignedTo    int64                  `protobuf:"varint,5,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+func (x *TaskEvent) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *TaskEvent) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *TaskEvent) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId  int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Page       int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit      int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	Status     string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	AssignedTo int64                  `protobuf:"varint,5,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	TagId      int64                  `protobuf:"varint,6,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	// tag filters by tag name; an unknown name yields no tasks
+	Tag string `protobuf:"bytes,7,opt,name=tag,proto3" json:"tag,omitempty"`
+	// cursor switches to keyset pagination in id order: send it empty for the
+	// first page, then next_cursor from the previous response. page is ignored.
+	Cursor *string `protobuf:"bytes,8,opt,name=cursor,proto3,oneof" json:"cursor,omitempty"`
+	// tag_ids filters by several tags, together with tag_id and tag. Tasks
+	// need all of them, or any one when match_any_tag is set.
+	TagIds        []int64 `protobuf:"varint,9,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	MatchAnyTag   bool    `protobuf:"varint,10,opt,name=match_any_tag,json=matchAnyTag,proto3" json:"match_any_tag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Subtask) Reset() {
-	*x = Subtask{}
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
 	mi := &file_proto_task_task_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Subtask) String() string {
+func (x *ListTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Subtask) ProtoMessage() {}
+func (*ListTasksRequest) ProtoMessage() {}
 
-func (x *Subtask) ProtoReflect() protoreflect.Message {
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -675,91 +707,108 @@ func (x *Subtask) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Subtask.ProtoReflect.Descriptor instead.
-func (*Subtask) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *Subtask) GetId() int64 {
+func (x *ListTasksRequest) GetProjectId() int64 {
 	if x != nil {
-		return x.Id
+		return x.ProjectId
 	}
 	return 0
 }
 
-func (x *Subtask) GetTaskId() int64 {
+func (x *ListTasksRequest) GetPage() int32 {
 	if x != nil {
-		return x.TaskId
+		return x.Page
 	}
 	return 0
 }
 
-func (x *Subtask) GetTitle() string {
+func (x *ListTasksRequest) GetLimit() int32 {
 	if x != nil {
-		return x.Title
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-func (x *Subtask) GetStatus() string {
+func (x *ListTasksRequest) GetStatus() string {
 	if x != nil {
 		return x.Status
 	}
 	return ""
 }
 
-func (x *Subtask) GetAssignedTo() int64 {
+func (x *ListTasksRequest) GetAssignedTo() int64 {
 	if x != nil {
 		return x.AssignedTo
 	}
 	return 0
 }
 
-func (x *Subtask) GetDueDate() *timestamppb.Timestamp {
+func (x *ListTasksRequest) GetTagId() int64 {
 	if x != nil {
-		return x.DueDate
+		return x.TagId
 	}
-	return nil
+	return 0
 }
 
-func (x *Subtask) GetCreatedAt() *timestamppb.Timestamp {
+func (x *ListTasksRequest) GetTag() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.Tag
 	}
-	return nil
+	return ""
 }
 
-func (x *Subtask) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *ListTasksRequest) GetCursor() string {
+	if x != nil && x.Cursor != nil {
+		return *x.Cursor
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetTagIds() []int64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.TagIds
 	}
 	return nil
 }
 
-type CreateSubtaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	AssignedTo    int64                  `protobuf:"varint,3,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+func (x *ListTasksRequest) GetMatchAnyTag() bool {
+	if x != nil {
+		return x.MatchAnyTag
+	}
+	return false
+}
+
+type ListTasksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Tasks []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	// total is only set for offset pagination
+	Total int32 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// next_cursor fetches the following page; empty on the last one
+	NextCursor string `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateSubtaskRequest) Reset() {
-	*x = CreateSubtaskRequest{}
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
 	mi := &file_proto_task_task_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateSubtaskRequest) String() string {
+func (x *ListTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateSubtaskRequest) ProtoMessage() {}
+func (*ListTasksResponse) ProtoMessage() {}
 
-func (x *CreateSubtaskRequest) ProtoReflect() protoreflect.Message {
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -771,60 +820,67 @@ func (x *CreateSubtaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateSubtaskRequest.ProtoReflect.Descriptor instead.
-func (*CreateSubtaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CreateSubtaskRequest) GetTaskId() int64 {
+func (x *ListTasksResponse) GetTasks() []*Task {
 	if x != nil {
-		return x.TaskId
+		return x.Tasks
 	}
-	return 0
+	return nil
 }
 
-func (x *CreateSubtaskRequest) GetTitle() string {
+func (x *ListTasksResponse) GetTotal() int32 {
 	if x != nil {
-		return x.Title
+		return x.Total
 	}
-	return ""
+	return 0
 }
 
-func (x *CreateSubtaskRequest) GetAssignedTo() int64 {
+func (x *ListTasksResponse) GetNextCursor() string {
 	if x != nil {
-		return x.AssignedTo
+		return x.NextCursor
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateSubtaskRequest) GetDueDate() *timestamppb.Timestamp {
+func (x *ListTasksResponse) GetLimit() int32 {
 	if x != nil {
-		return x.DueDate
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type SubtaskResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Subtask       *Subtask               `protobuf:"bytes,1,opt,name=subtask,proto3" json:"subtask,omitempty"`
+type StreamTasksRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId  int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Status     string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	AssignedTo int64                  `protobuf:"varint,3,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	// Same tag filters as ListTasksRequest
+	TagIds        []int64 `protobuf:"varint,4,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+	Tag           string  `protobuf:"bytes,5,opt,name=tag,proto3" json:"tag,omitempty"`
+	MatchAnyTag   bool    `protobuf:"varint,6,opt,name=match_any_tag,json=matchAnyTag,proto3" json:"match_any_tag,omitempty"`
+	BatchSize     int32   `protobuf:"varint,7,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"` // tasks per message; 0 uses the default of 500, at most 1000
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubtaskResponse) Reset() {
-	*x = SubtaskResponse{}
+func (x *StreamTasksRequest) Reset() {
+	*x = StreamTasksRequest{}
 	mi := &file_proto_task_task_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubtaskResponse) String() string {
+func (x *StreamTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubtaskResponse) ProtoMessage() {}
+func (*StreamTasksRequest) ProtoMessage() {}
 
-func (x *SubtaskResponse) ProtoReflect() protoreflect.Message {
+func (x *StreamTasksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -836,115 +892,127 @@ func (x *SubtaskResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubtaskResponse.ProtoReflect.Descriptor instead.
-func (*SubtaskResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use StreamTasksRequest.ProtoReflect.Descriptor instead.
+func (*StreamTasksRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *SubtaskResponse) GetSubtask() *Subtask {
+func (x *StreamTasksRequest) GetProjectId() int64 {
 	if x != nil {
-		return x.Subtask
+		return x.ProjectId
 	}
-	return nil
+	return 0
 }
 
-type UpdateSubtaskRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	AssignedTo    int64                  `protobuf:"varint,4,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
-	DueDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *StreamTasksRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
 }
 
-func (x *UpdateSubtaskRequest) Reset() {
-	*x = UpdateSubtaskRequest{}
-	mi := &file_proto_task_task_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *StreamTasksRequest) GetAssignedTo() int64 {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return 0
 }
 
-func (x *UpdateSubtaskRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *StreamTasksRequest) GetTagIds() []int64 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
 }
 
-func (*UpdateSubtaskRequest) ProtoMessage() {}
-
-func (x *UpdateSubtaskRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[12]
+func (x *StreamTasksRequest) GetTag() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Tag
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use UpdateSubtaskRequest.ProtoReflect.Descriptor instead.
-func (*UpdateSubtaskRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{12}
+func (x *StreamTasksRequest) GetMatchAnyTag() bool {
+	if x != nil {
+		return x.MatchAnyTag
+	}
+	return false
 }
 
-func (x *UpdateSubtaskRequest) GetId() int64 {
+func (x *StreamTasksRequest) GetBatchSize() int32 {
 	if x != nil {
-		return x.Id
+		return x.BatchSize
 	}
 	return 0
 }
 
-func (x *UpdateSubtaskRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
+type TaskBatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSubtaskRequest) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
+func (x *TaskBatch) Reset() {
+	*x = TaskBatch{}
+	mi := &file_proto_task_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSubtaskRequest) GetAssignedTo() int64 {
+func (x *TaskBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskBatch) ProtoMessage() {}
+
+func (x *TaskBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[12]
 	if x != nil {
-		return x.AssignedTo
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *UpdateSubtaskRequest) GetDueDate() *timestamppb.Timestamp {
+// Deprecated: Use TaskBatch.ProtoReflect.Descriptor instead.
+func (*TaskBatch) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *TaskBatch) GetTasks() []*Task {
 	if x != nil {
-		return x.DueDate
+		return x.Tasks
 	}
 	return nil
 }
 
-type DeleteSubtaskRequest struct {
+// TaskVersion is the minimal view of a task used by clients syncing a local cache
+type TaskVersion struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSubtaskRequest) Reset() {
-	*x = DeleteSubtaskRequest{}
+func (x *TaskVersion) Reset() {
+	*x = TaskVersion{}
 	mi := &file_proto_task_task_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSubtaskRequest) String() string {
+func (x *TaskVersion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSubtaskRequest) ProtoMessage() {}
+func (*TaskVersion) ProtoMessage() {}
 
-func (x *DeleteSubtaskRequest) ProtoReflect() protoreflect.Message {
+func (x *TaskVersion) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -956,39 +1024,46 @@ func (x *DeleteSubtaskRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSubtaskRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSubtaskRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TaskVersion.ProtoReflect.Descriptor instead.
+func (*TaskVersion) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *DeleteSubtaskRequest) GetId() int64 {
+func (x *TaskVersion) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-type ListSubtasksRequest struct {
+func (x *TaskVersion) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListTaskIDsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSubtasksRequest) Reset() {
-	*x = ListSubtasksRequest{}
+func (x *ListTaskIDsRequest) Reset() {
+	*x = ListTaskIDsRequest{}
 	mi := &file_proto_task_task_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSubtasksRequest) String() string {
+func (x *ListTaskIDsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSubtasksRequest) ProtoMessage() {}
+func (*ListTaskIDsRequest) ProtoMessage() {}
 
-func (x *ListSubtasksRequest) ProtoReflect() protoreflect.Message {
+func (x *ListTaskIDsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1000,39 +1075,39 @@ func (x *ListSubtasksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSubtasksRequest.ProtoReflect.Descriptor instead.
-func (*ListSubtasksRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTaskIDsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskIDsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListSubtasksRequest) GetTaskId() int64 {
+func (x *ListTaskIDsRequest) GetProjectId() int64 {
 	if x != nil {
-		return x.TaskId
+		return x.ProjectId
 	}
 	return 0
 }
 
-type ListSubtasksResponse struct {
+type ListTaskIDsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Subtasks      []*Subtask             `protobuf:"bytes,1,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
+	Tasks         []*TaskVersion         `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSubtasksResponse) Reset() {
-	*x = ListSubtasksResponse{}
+func (x *ListTaskIDsResponse) Reset() {
+	*x = ListTaskIDsResponse{}
 	mi := &file_proto_task_task_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSubtasksResponse) String() string {
+func (x *ListTaskIDsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSubtasksResponse) ProtoMessage() {}
+func (*ListTaskIDsResponse) ProtoMessage() {}
 
-func (x *ListSubtasksResponse) ProtoReflect() protoreflect.Message {
+func (x *ListTaskIDsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1044,44 +1119,41 @@ func (x *ListSubtasksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSubtasksResponse.ProtoReflect.Descriptor instead.
-func (*ListSubtasksResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListTaskIDsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskIDsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListSubtasksResponse) GetSubtasks() []*Subtask {
+func (x *ListTaskIDsResponse) GetTasks() []*TaskVersion {
 	if x != nil {
-		return x.Subtasks
+		return x.Tasks
 	}
 	return nil
 }
 
-// Comment messages
-type Comment struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	TaskId        int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ProjectTaskCount struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId      int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	TotalTasks     int32                  `protobuf:"varint,2,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	CompletedTasks int32                  `protobuf:"varint,3,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *Comment) Reset() {
-	*x = Comment{}
+func (x *ProjectTaskCount) Reset() {
+	*x = ProjectTaskCount{}
 	mi := &file_proto_task_task_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Comment) String() string {
+func (x *ProjectTaskCount) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Comment) ProtoMessage() {}
+func (*ProjectTaskCount) ProtoMessage() {}
 
-func (x *Comment) ProtoReflect() protoreflect.Message {
+func (x *ProjectTaskCount) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1093,69 +1165,54 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
-func (*Comment) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProjectTaskCount.ProtoReflect.Descriptor instead.
+func (*ProjectTaskCount) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Comment) GetId() int64 {
+func (x *ProjectTaskCount) GetProjectId() int64 {
 	if x != nil {
-		return x.Id
+		return x.ProjectId
 	}
 	return 0
 }
 
-func (x *Comment) GetTaskId() int64 {
+func (x *ProjectTaskCount) GetTotalTasks() int32 {
 	if x != nil {
-		return x.TaskId
+		return x.TotalTasks
 	}
 	return 0
 }
 
-func (x *Comment) GetUserId() int64 {
+func (x *ProjectTaskCount) GetCompletedTasks() int32 {
 	if x != nil {
-		return x.UserId
+		return x.CompletedTasks
 	}
 	return 0
 }
 
-func (x *Comment) GetComment() string {
-	if x != nil {
-		return x.Comment
-	}
-	return ""
-}
-
-func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
-}
-
-type AddCommentRequest struct {
+// Projects without tasks are absent
+type CountProjectTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	Projects      []*ProjectTaskCount    `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddCommentRequest) Reset() {
-	*x = AddCommentRequest{}
+func (x *CountProjectTasksResponse) Reset() {
+	*x = CountProjectTasksResponse{}
 	mi := &file_proto_task_task_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddCommentRequest) String() string {
+func (x *CountProjectTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddCommentRequest) ProtoMessage() {}
+func (*CountProjectTasksResponse) ProtoMessage() {}
 
-func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+func (x *CountProjectTasksResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1167,53 +1224,39 @@ func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
-func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CountProjectTasksResponse.ProtoReflect.Descriptor instead.
+func (*CountProjectTasksResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *AddCommentRequest) GetTaskId() int64 {
-	if x != nil {
-		return x.TaskId
-	}
-	return 0
-}
-
-func (x *AddCommentRequest) GetUserId() int64 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
-}
-
-func (x *AddCommentRequest) GetComment() string {
+func (x *CountProjectTasksResponse) GetProjects() []*ProjectTaskCount {
 	if x != nil {
-		return x.Comment
+		return x.Projects
 	}
-	return ""
+	return nil
 }
 
-type CommentResponse struct {
+type GetTaskSummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CommentResponse) Reset() {
-	*x = CommentResponse{}
+func (x *GetTaskSummaryRequest) Reset() {
+	*x = GetTaskSummaryRequest{}
 	mi := &file_proto_task_task_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CommentResponse) String() string {
+func (x *GetTaskSummaryRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommentResponse) ProtoMessage() {}
+func (*GetTaskSummaryRequest) ProtoMessage() {}
 
-func (x *CommentResponse) ProtoReflect() protoreflect.Message {
+func (x *GetTaskSummaryRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1225,39 +1268,45 @@ func (x *CommentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommentResponse.ProtoReflect.Descriptor instead.
-func (*CommentResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetTaskSummaryRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskSummaryRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CommentResponse) GetComment() *Comment {
+func (x *GetTaskSummaryRequest) GetProjectId() int64 {
 	if x != nil {
-		return x.Comment
+		return x.ProjectId
 	}
-	return nil
+	return 0
 }
 
-type DeleteCommentRequest struct {
+// status_counts has every status, including those without tasks. Overdue
+// tasks are past their due date and not done.
+type TaskSummaryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId     int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	StatusCounts  map[string]int32       `protobuf:"bytes,3,rep,name=status_counts,json=statusCounts,proto3" json:"status_counts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Overdue       int32                  `protobuf:"varint,4,opt,name=overdue,proto3" json:"overdue,omitempty"`
+	Unassigned    int32                  `protobuf:"varint,5,opt,name=unassigned,proto3" json:"unassigned,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteCommentRequest) Reset() {
-	*x = DeleteCommentRequest{}
+func (x *TaskSummaryResponse) Reset() {
+	*x = TaskSummaryResponse{}
 	mi := &file_proto_task_task_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteCommentRequest) String() string {
+func (x *TaskSummaryResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCommentRequest) ProtoMessage() {}
+func (*TaskSummaryResponse) ProtoMessage() {}
 
-func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+func (x *TaskSummaryResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1269,39 +1318,67 @@ func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TaskSummaryResponse.ProtoReflect.Descriptor instead.
+func (*TaskSummaryResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *DeleteCommentRequest) GetId() int64 {
+func (x *TaskSummaryResponse) GetProjectId() int64 {
 	if x != nil {
-		return x.Id
+		return x.ProjectId
 	}
 	return 0
 }
 
-type ListCommentsRequest struct {
+func (x *TaskSummaryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *TaskSummaryResponse) GetStatusCounts() map[string]int32 {
+	if x != nil {
+		return x.StatusCounts
+	}
+	return nil
+}
+
+func (x *TaskSummaryResponse) GetOverdue() int32 {
+	if x != nil {
+		return x.Overdue
+	}
+	return 0
+}
+
+func (x *TaskSummaryResponse) GetUnassigned() int32 {
+	if x != nil {
+		return x.Unassigned
+	}
+	return 0
+}
+
+type BulkDeleteTasksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Ids           []int64                `protobuf:"varint,1,rep,packed,name=ids,proto3" json:"ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCommentsRequest) Reset() {
-	*x = ListCommentsRequest{}
+func (x *BulkDeleteTasksRequest) Reset() {
+	*x = BulkDeleteTasksRequest{}
 	mi := &file_proto_task_task_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCommentsRequest) String() string {
+func (x *BulkDeleteTasksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCommentsRequest) ProtoMessage() {}
+func (*BulkDeleteTasksRequest) ProtoMessage() {}
 
-func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+func (x *BulkDeleteTasksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1313,39 +1390,39 @@ func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
-func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkDeleteTasksRequest.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTasksRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *ListCommentsRequest) GetTaskId() int64 {
+func (x *BulkDeleteTasksRequest) GetIds() []int64 {
 	if x != nil {
-		return x.TaskId
+		return x.Ids
 	}
-	return 0
+	return nil
 }
 
-type ListCommentsResponse struct {
+type BulkDeleteTasksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comments      []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Deleted       int32                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCommentsResponse) Reset() {
-	*x = ListCommentsResponse{}
+func (x *BulkDeleteTasksResponse) Reset() {
+	*x = BulkDeleteTasksResponse{}
 	mi := &file_proto_task_task_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCommentsResponse) String() string {
+func (x *BulkDeleteTasksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCommentsResponse) ProtoMessage() {}
+func (*BulkDeleteTasksResponse) ProtoMessage() {}
 
-func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+func (x *BulkDeleteTasksResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1357,43 +1434,40 @@ func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
-func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BulkDeleteTasksResponse.ProtoReflect.Descriptor instead.
+func (*BulkDeleteTasksResponse) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *ListCommentsResponse) GetComments() []*Comment {
+func (x *BulkDeleteTasksResponse) GetDeleted() int32 {
 	if x != nil {
-		return x.Comments
+		return x.Deleted
 	}
-	return nil
+	return 0
 }
 
-// Attachment messages
-type Attachment struct {
+type DemoteTaskRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	TaskId        int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	FileUrl       string                 `protobuf:"bytes,3,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
-	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	ParentTaskId  int64                  `protobuf:"varint,2,opt,name=parent_task_id,json=parentTaskId,proto3" json:"parent_task_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Attachment) Reset() {
-	*x = Attachment{}
+func (x *DemoteTaskRequest) Reset() {
+	*x = DemoteTaskRequest{}
 	mi := &file_proto_task_task_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Attachment) String() string {
+func (x *DemoteTaskRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Attachment) ProtoMessage() {}
+func (*DemoteTaskRequest) ProtoMessage() {}
 
-func (x *Attachment) ProtoReflect() protoreflect.Message {
+func (x *DemoteTaskRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_task_task_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1405,62 +1479,1560 @@ func (x *Attachment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
-func (*Attachment) Descriptor() ([]byte, []int) {
+// Deprecated: Use DemoteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DemoteTaskRequest) Descriptor() ([]byte, []int) {
 	return file_proto_task_task_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Attachment) GetId() int64 {
+func (x *DemoteTaskRequest) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Attachment) GetTaskId() int64 {
+func (x *DemoteTaskRequest) GetParentTaskId() int64 {
 	if x != nil {
-		return x.TaskId
+		return x.ParentTaskId
 	}
 	return 0
 }
 
-func (x *Attachment) GetFileUrl() string {
-	if x != nil {
-		return x.FileUrl
-	}
-	return ""
+type DuplicateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	KeepDueDate   bool                   `protobuf:"varint,2,opt,name=keep_due_date,json=keepDueDate,proto3" json:"keep_due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DuplicateTaskRequest) Reset() {
+	*x = DuplicateTaskRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DuplicateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DuplicateTaskRequest) ProtoMessage() {}
+
+func (x *DuplicateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DuplicateTaskRequest.ProtoReflect.Descriptor instead.
+func (*DuplicateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DuplicateTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DuplicateTaskRequest) GetKeepDueDate() bool {
+	if x != nil {
+		return x.KeepDueDate
+	}
+	return false
+}
+
+type MoveTaskRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ProjectId int64                  `protobuf:"varint,2,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	// Unassigns the task and its subtasks instead of keeping their assignees
+	ClearAssignees bool `protobuf:"varint,3,opt,name=clear_assignees,json=clearAssignees,proto3" json:"clear_assignees,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MoveTaskRequest) Reset() {
+	*x = MoveTaskRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveTaskRequest) ProtoMessage() {}
+
+func (x *MoveTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveTaskRequest.ProtoReflect.Descriptor instead.
+func (*MoveTaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *MoveTaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetProjectId() int64 {
+	if x != nil {
+		return x.ProjectId
+	}
+	return 0
+}
+
+func (x *MoveTaskRequest) GetClearAssignees() bool {
+	if x != nil {
+		return x.ClearAssignees
+	}
+	return false
+}
+
+// Subtask messages
+type Subtask struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	AssignedTo    int64                  `protobuf:"varint,5,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Position      int32                  `protobuf:"varint,9,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Subtask) Reset() {
+	*x = Subtask{}
+	mi := &file_proto_task_task_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subtask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subtask) ProtoMessage() {}
+
+func (x *Subtask) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subtask.ProtoReflect.Descriptor instead.
+func (*Subtask) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Subtask) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Subtask) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Subtask) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Subtask) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Subtask) GetAssignedTo() int64 {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return 0
+}
+
+func (x *Subtask) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+func (x *Subtask) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Subtask) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Subtask) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type CreateSubtaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	AssignedTo    int64                  `protobuf:"varint,3,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubtaskRequest) Reset() {
+	*x = CreateSubtaskRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubtaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubtaskRequest) ProtoMessage() {}
+
+func (x *CreateSubtaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubtaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubtaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CreateSubtaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *CreateSubtaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateSubtaskRequest) GetAssignedTo() int64 {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return 0
+}
+
+func (x *CreateSubtaskRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+type SubtaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subtask       *Subtask               `protobuf:"bytes,1,opt,name=subtask,proto3" json:"subtask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubtaskResponse) Reset() {
+	*x = SubtaskResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubtaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubtaskResponse) ProtoMessage() {}
+
+func (x *SubtaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubtaskResponse.ProtoReflect.Descriptor instead.
+func (*SubtaskResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SubtaskResponse) GetSubtask() *Subtask {
+	if x != nil {
+		return x.Subtask
+	}
+	return nil
+}
+
+type CreateSubtasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Titles        []string               `protobuf:"bytes,2,rep,name=titles,proto3" json:"titles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSubtasksRequest) Reset() {
+	*x = CreateSubtasksRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSubtasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSubtasksRequest) ProtoMessage() {}
+
+func (x *CreateSubtasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSubtasksRequest.ProtoReflect.Descriptor instead.
+func (*CreateSubtasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateSubtasksRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *CreateSubtasksRequest) GetTitles() []string {
+	if x != nil {
+		return x.Titles
+	}
+	return nil
+}
+
+type UpdateSubtaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	AssignedTo    int64                  `protobuf:"varint,4,opt,name=assigned_to,json=assignedTo,proto3" json:"assigned_to,omitempty"`
+	DueDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=due_date,json=dueDate,proto3" json:"due_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSubtaskRequest) Reset() {
+	*x = UpdateSubtaskRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubtaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubtaskRequest) ProtoMessage() {}
+
+func (x *UpdateSubtaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubtaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubtaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *UpdateSubtaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSubtaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateSubtaskRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *UpdateSubtaskRequest) GetAssignedTo() int64 {
+	if x != nil {
+		return x.AssignedTo
+	}
+	return 0
+}
+
+func (x *UpdateSubtaskRequest) GetDueDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueDate
+	}
+	return nil
+}
+
+type DeleteSubtaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSubtaskRequest) Reset() {
+	*x = DeleteSubtaskRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSubtaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSubtaskRequest) ProtoMessage() {}
+
+func (x *DeleteSubtaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSubtaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSubtaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *DeleteSubtaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type MoveSubtaskRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"` // destination task
+	// optional: the task the subtask must currently belong to; it is reported
+	// as not found otherwise
+	FromTaskId    int64 `protobuf:"varint,3,opt,name=from_task_id,json=fromTaskId,proto3" json:"from_task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveSubtaskRequest) Reset() {
+	*x = MoveSubtaskRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveSubtaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveSubtaskRequest) ProtoMessage() {}
+
+func (x *MoveSubtaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveSubtaskRequest.ProtoReflect.Descriptor instead.
+func (*MoveSubtaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MoveSubtaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *MoveSubtaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *MoveSubtaskRequest) GetFromTaskId() int64 {
+	if x != nil {
+		return x.FromTaskId
+	}
+	return 0
+}
+
+type PromoteSubtaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// optional: the task the subtask must belong to; it is reported as not
+	// found otherwise
+	TaskId        int64 `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PromoteSubtaskRequest) Reset() {
+	*x = PromoteSubtaskRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PromoteSubtaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PromoteSubtaskRequest) ProtoMessage() {}
+
+func (x *PromoteSubtaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PromoteSubtaskRequest.ProtoReflect.Descriptor instead.
+func (*PromoteSubtaskRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *PromoteSubtaskRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *PromoteSubtaskRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type ListSubtasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubtasksRequest) Reset() {
+	*x = ListSubtasksRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubtasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubtasksRequest) ProtoMessage() {}
+
+func (x *ListSubtasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubtasksRequest.ProtoReflect.Descriptor instead.
+func (*ListSubtasksRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListSubtasksRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ListSubtasksRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListSubtasksRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListSubtasksResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Subtasks []*Subtask             `protobuf:"bytes,1,rep,name=subtasks,proto3" json:"subtasks,omitempty"`
+	Total    int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSubtasksResponse) Reset() {
+	*x = ListSubtasksResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSubtasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSubtasksResponse) ProtoMessage() {}
+
+func (x *ListSubtasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSubtasksResponse.ProtoReflect.Descriptor instead.
+func (*ListSubtasksResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListSubtasksResponse) GetSubtasks() []*Subtask {
+	if x != nil {
+		return x.Subtasks
+	}
+	return nil
+}
+
+func (x *ListSubtasksResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListSubtasksResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Comment messages
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Comment       string                 `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_proto_task_task_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *Comment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Comment) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Comment) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Comment) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AddCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // ignored; the author is the caller in the x-user-id metadata
+	Comment       string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddCommentRequest) Reset() {
+	*x = AddCommentRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddCommentRequest) ProtoMessage() {}
+
+func (x *AddCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddCommentRequest.ProtoReflect.Descriptor instead.
+func (*AddCommentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AddCommentRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddCommentRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *AddCommentRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type CommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *Comment               `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommentResponse) Reset() {
+	*x = CommentResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommentResponse) ProtoMessage() {}
+
+func (x *CommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommentResponse.ProtoReflect.Descriptor instead.
+func (*CommentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CommentResponse) GetComment() *Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteCommentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Deletes every comment on a task
+type DeleteTaskCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskCommentsRequest) Reset() {
+	*x = DeleteTaskCommentsRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskCommentsRequest) ProtoMessage() {}
+
+func (x *DeleteTaskCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskCommentsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteTaskCommentsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+type DeleteTaskCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int32                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskCommentsResponse) Reset() {
+	*x = DeleteTaskCommentsResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskCommentsResponse) ProtoMessage() {}
+
+func (x *DeleteTaskCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskCommentsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *DeleteTaskCommentsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type ListCommentsRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	TaskId int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Page   int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit  int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Instead of task_id: the user's comments on every task, newest first.
+	// Only the user themselves and admins may list them.
+	UserId        int64 `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListCommentsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListCommentsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Comments []*Comment             `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Total    int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListCommentsResponse) GetComments() []*Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListCommentsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Attachment messages
+type Attachment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	TaskId        int64                  `protobuf:"varint,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	FileUrl       string                 `protobuf:"bytes,3,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	Type          string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`                                     // link, media
+	MediaFileId   int64                  `protobuf:"varint,6,opt,name=media_file_id,json=mediaFileId,proto3" json:"media_file_id,omitempty"` // set for media attachments
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_proto_task_task_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Attachment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Attachment) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *Attachment) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
 }
 
 func (x *Attachment) GetUploadedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UploadedAt
+		return x.UploadedAt
+	}
+	return nil
+}
+
+func (x *Attachment) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Attachment) GetMediaFileId() int64 {
+	if x != nil {
+		return x.MediaFileId
+	}
+	return 0
+}
+
+// Exactly one of file_url (external link) or media_file_id (uploaded file) is set
+type AddAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	FileUrl       string                 `protobuf:"bytes,2,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	MediaFileId   int64                  `protobuf:"varint,3,opt,name=media_file_id,json=mediaFileId,proto3" json:"media_file_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddAttachmentRequest) Reset() {
+	*x = AddAttachmentRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddAttachmentRequest) ProtoMessage() {}
+
+func (x *AddAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*AddAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *AddAttachmentRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
+	}
+	return 0
+}
+
+func (x *AddAttachmentRequest) GetFileUrl() string {
+	if x != nil {
+		return x.FileUrl
+	}
+	return ""
+}
+
+func (x *AddAttachmentRequest) GetMediaFileId() int64 {
+	if x != nil {
+		return x.MediaFileId
+	}
+	return 0
+}
+
+type AttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AttachmentResponse) Reset() {
+	*x = AttachmentResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachmentResponse) ProtoMessage() {}
+
+func (x *AttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachmentResponse.ProtoReflect.Descriptor instead.
+func (*AttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *AttachmentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type DeleteAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAttachmentRequest) Reset() {
+	*x = DeleteAttachmentRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAttachmentRequest) ProtoMessage() {}
+
+func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *DeleteAttachmentRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Deletes every attachment on a task
+type DeleteTaskAttachmentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskAttachmentsRequest) Reset() {
+	*x = DeleteTaskAttachmentsRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskAttachmentsRequest) ProtoMessage() {}
+
+func (x *DeleteTaskAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DeleteTaskAttachmentsRequest) GetTaskId() int64 {
+	if x != nil {
+		return x.TaskId
 	}
-	return nil
+	return 0
 }
 
-type AddAttachmentRequest struct {
+type DeleteTaskAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deleted       int32                  `protobuf:"varint,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskAttachmentsResponse) Reset() {
+	*x = DeleteTaskAttachmentsResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskAttachmentsResponse) ProtoMessage() {}
+
+func (x *DeleteTaskAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *DeleteTaskAttachmentsResponse) GetDeleted() int32 {
+	if x != nil {
+		return x.Deleted
+	}
+	return 0
+}
+
+type ListAttachmentsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	FileUrl       string                 `protobuf:"bytes,2,opt,name=file_url,json=fileUrl,proto3" json:"file_url,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddAttachmentRequest) Reset() {
-	*x = AddAttachmentRequest{}
-	mi := &file_proto_task_task_proto_msgTypes[23]
+func (x *ListAttachmentsRequest) Reset() {
+	*x = ListAttachmentsRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddAttachmentRequest) String() string {
+func (x *ListAttachmentsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddAttachmentRequest) ProtoMessage() {}
+func (*ListAttachmentsRequest) ProtoMessage() {}
 
-func (x *AddAttachmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[23]
+func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,47 +3043,168 @@ func (x *AddAttachmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddAttachmentRequest.ProtoReflect.Descriptor instead.
-func (*AddAttachmentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *AddAttachmentRequest) GetTaskId() int64 {
+func (x *ListAttachmentsRequest) GetTaskId() int64 {
 	if x != nil {
 		return x.TaskId
 	}
 	return 0
 }
 
-func (x *AddAttachmentRequest) GetFileUrl() string {
+func (x *ListAttachmentsRequest) GetPage() int32 {
 	if x != nil {
-		return x.FileUrl
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListAttachmentsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAttachmentsResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Attachments []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	Total       int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	// limit is the page size the service applied
+	Limit         int32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAttachmentsResponse) Reset() {
+	*x = ListAttachmentsResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAttachmentsResponse) ProtoMessage() {}
+
+func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+func (x *ListAttachmentsResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListAttachmentsResponse) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// Tag messages
+type Tag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tag) Reset() {
+	*x = Tag{}
+	mi := &file_proto_task_task_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tag) ProtoMessage() {}
+
+func (x *Tag) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
+func (*Tag) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Tag) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Tag) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-type AttachmentResponse struct {
+type CreateTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AttachmentResponse) Reset() {
-	*x = AttachmentResponse{}
-	mi := &file_proto_task_task_proto_msgTypes[24]
+func (x *CreateTagRequest) Reset() {
+	*x = CreateTagRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AttachmentResponse) String() string {
+func (x *CreateTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AttachmentResponse) ProtoMessage() {}
+func (*CreateTagRequest) ProtoMessage() {}
 
-func (x *AttachmentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[24]
+func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1522,40 +3215,84 @@ func (x *AttachmentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AttachmentResponse.ProtoReflect.Descriptor instead.
-func (*AttachmentResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use CreateTagRequest.ProtoReflect.Descriptor instead.
+func (*CreateTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *AttachmentResponse) GetAttachment() *Attachment {
+func (x *CreateTagRequest) GetName() string {
 	if x != nil {
-		return x.Attachment
+		return x.Name
+	}
+	return ""
+}
+
+type TagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagResponse) Reset() {
+	*x = TagResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagResponse) ProtoMessage() {}
+
+func (x *TagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagResponse.ProtoReflect.Descriptor instead.
+func (*TagResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *TagResponse) GetTag() *Tag {
+	if x != nil {
+		return x.Tag
 	}
 	return nil
 }
 
-type DeleteAttachmentRequest struct {
+type ListTagsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteAttachmentRequest) Reset() {
-	*x = DeleteAttachmentRequest{}
-	mi := &file_proto_task_task_proto_msgTypes[25]
+func (x *ListTagsResponse) Reset() {
+	*x = ListTagsResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteAttachmentRequest) String() string {
+func (x *ListTagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteAttachmentRequest) ProtoMessage() {}
+func (*ListTagsResponse) ProtoMessage() {}
 
-func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[25]
+func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1566,40 +3303,41 @@ func (x *DeleteAttachmentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteAttachmentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteAttachmentRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
+func (*ListTagsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *DeleteAttachmentRequest) GetId() int64 {
+func (x *ListTagsResponse) GetTags() []*Tag {
 	if x != nil {
-		return x.Id
+		return x.Tags
 	}
-	return 0
+	return nil
 }
 
-type ListAttachmentsRequest struct {
+type AddTaskTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TagId         int64                  `protobuf:"varint,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAttachmentsRequest) Reset() {
-	*x = ListAttachmentsRequest{}
-	mi := &file_proto_task_task_proto_msgTypes[26]
+func (x *AddTaskTagRequest) Reset() {
+	*x = AddTaskTagRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAttachmentsRequest) String() string {
+func (x *AddTaskTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAttachmentsRequest) ProtoMessage() {}
+func (*AddTaskTagRequest) ProtoMessage() {}
 
-func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[26]
+func (x *AddTaskTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1610,40 +3348,48 @@ func (x *ListAttachmentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAttachmentsRequest.ProtoReflect.Descriptor instead.
-func (*ListAttachmentsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use AddTaskTagRequest.ProtoReflect.Descriptor instead.
+func (*AddTaskTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *ListAttachmentsRequest) GetTaskId() int64 {
+func (x *AddTaskTagRequest) GetTaskId() int64 {
 	if x != nil {
 		return x.TaskId
 	}
 	return 0
 }
 
-type ListAttachmentsResponse struct {
+func (x *AddTaskTagRequest) GetTagId() int64 {
+	if x != nil {
+		return x.TagId
+	}
+	return 0
+}
+
+type RemoveTaskTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	TagId         int64                  `protobuf:"varint,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListAttachmentsResponse) Reset() {
-	*x = ListAttachmentsResponse{}
-	mi := &file_proto_task_task_proto_msgTypes[27]
+func (x *RemoveTaskTagRequest) Reset() {
+	*x = RemoveTaskTagRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListAttachmentsResponse) String() string {
+func (x *RemoveTaskTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListAttachmentsResponse) ProtoMessage() {}
+func (*RemoveTaskTagRequest) ProtoMessage() {}
 
-func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[27]
+func (x *RemoveTaskTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1654,42 +3400,47 @@ func (x *ListAttachmentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListAttachmentsResponse.ProtoReflect.Descriptor instead.
-func (*ListAttachmentsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use RemoveTaskTagRequest.ProtoReflect.Descriptor instead.
+func (*RemoveTaskTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *ListAttachmentsResponse) GetAttachments() []*Attachment {
+func (x *RemoveTaskTagRequest) GetTaskId() int64 {
 	if x != nil {
-		return x.Attachments
+		return x.TaskId
 	}
-	return nil
+	return 0
 }
 
-// Tag messages
-type Tag struct {
+func (x *RemoveTaskTagRequest) GetTagId() int64 {
+	if x != nil {
+		return x.TagId
+	}
+	return 0
+}
+
+type DeleteTagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Tag) Reset() {
-	*x = Tag{}
-	mi := &file_proto_task_task_proto_msgTypes[28]
+func (x *DeleteTagRequest) Reset() {
+	*x = DeleteTagRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Tag) String() string {
+func (x *DeleteTagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Tag) ProtoMessage() {}
+func (*DeleteTagRequest) ProtoMessage() {}
 
-func (x *Tag) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[28]
+func (x *DeleteTagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1700,47 +3451,45 @@ func (x *Tag) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Tag.ProtoReflect.Descriptor instead.
-func (*Tag) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use DeleteTagRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTagRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *Tag) GetId() int64 {
+func (x *DeleteTagRequest) GetId() int64 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *Tag) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
-}
-
-type CreateTagRequest struct {
+// Watcher messages
+// Watchers are named in a task's update and delete events. user_id defaults
+// to the caller; only admins may add or remove other users.
+type Watcher struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateTagRequest) Reset() {
-	*x = CreateTagRequest{}
-	mi := &file_proto_task_task_proto_msgTypes[29]
+func (x *Watcher) Reset() {
+	*x = Watcher{}
+	mi := &file_proto_task_task_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateTagRequest) String() string {
+func (x *Watcher) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTagRequest) ProtoMessage() {}
+func (*Watcher) ProtoMessage() {}
 
-func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[29]
+func (x *Watcher) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1751,40 +3500,55 @@ func (x *CreateTagRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTagRequest.ProtoReflect.Descriptor instead.
-func (*CreateTagRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use Watcher.ProtoReflect.Descriptor instead.
+func (*Watcher) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *CreateTagRequest) GetName() string {
+func (x *Watcher) GetTaskId() int64 {
 	if x != nil {
-		return x.Name
+		return x.TaskId
 	}
-	return ""
+	return 0
 }
 
-type TagResponse struct {
+func (x *Watcher) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Watcher) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type AddWatcherRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tag           *Tag                   `protobuf:"bytes,1,opt,name=tag,proto3" json:"tag,omitempty"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TagResponse) Reset() {
-	*x = TagResponse{}
-	mi := &file_proto_task_task_proto_msgTypes[30]
+func (x *AddWatcherRequest) Reset() {
+	*x = AddWatcherRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TagResponse) String() string {
+func (x *AddWatcherRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TagResponse) ProtoMessage() {}
+func (*AddWatcherRequest) ProtoMessage() {}
 
-func (x *TagResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[30]
+func (x *AddWatcherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1795,40 +3559,48 @@ func (x *TagResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TagResponse.ProtoReflect.Descriptor instead.
-func (*TagResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use AddWatcherRequest.ProtoReflect.Descriptor instead.
+func (*AddWatcherRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *TagResponse) GetTag() *Tag {
+func (x *AddWatcherRequest) GetTaskId() int64 {
 	if x != nil {
-		return x.Tag
+		return x.TaskId
 	}
-	return nil
+	return 0
 }
 
-type ListTagsResponse struct {
+func (x *AddWatcherRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type RemoveWatcherRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Tags          []*Tag                 `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTagsResponse) Reset() {
-	*x = ListTagsResponse{}
-	mi := &file_proto_task_task_proto_msgTypes[31]
+func (x *RemoveWatcherRequest) Reset() {
+	*x = RemoveWatcherRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTagsResponse) String() string {
+func (x *RemoveWatcherRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTagsResponse) ProtoMessage() {}
+func (*RemoveWatcherRequest) ProtoMessage() {}
 
-func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[31]
+func (x *RemoveWatcherRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1839,41 +3611,47 @@ func (x *ListTagsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTagsResponse.ProtoReflect.Descriptor instead.
-func (*ListTagsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use RemoveWatcherRequest.ProtoReflect.Descriptor instead.
+func (*RemoveWatcherRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *ListTagsResponse) GetTags() []*Tag {
+func (x *RemoveWatcherRequest) GetTaskId() int64 {
 	if x != nil {
-		return x.Tags
+		return x.TaskId
 	}
-	return nil
+	return 0
 }
 
-type AddTaskTagRequest struct {
+func (x *RemoveWatcherRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type ListWatchersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	TagId         int64                  `protobuf:"varint,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddTaskTagRequest) Reset() {
-	*x = AddTaskTagRequest{}
-	mi := &file_proto_task_task_proto_msgTypes[32]
+func (x *ListWatchersRequest) Reset() {
+	*x = ListWatchersRequest{}
+	mi := &file_proto_task_task_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddTaskTagRequest) String() string {
+func (x *ListWatchersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddTaskTagRequest) ProtoMessage() {}
+func (*ListWatchersRequest) ProtoMessage() {}
 
-func (x *AddTaskTagRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[32]
+func (x *ListWatchersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1884,48 +3662,40 @@ func (x *AddTaskTagRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddTaskTagRequest.ProtoReflect.Descriptor instead.
-func (*AddTaskTagRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use ListWatchersRequest.ProtoReflect.Descriptor instead.
+func (*ListWatchersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *AddTaskTagRequest) GetTaskId() int64 {
+func (x *ListWatchersRequest) GetTaskId() int64 {
 	if x != nil {
 		return x.TaskId
 	}
 	return 0
 }
 
-func (x *AddTaskTagRequest) GetTagId() int64 {
-	if x != nil {
-		return x.TagId
-	}
-	return 0
-}
-
-type RemoveTaskTagRequest struct {
+type ListWatchersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	TaskId        int64                  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	TagId         int64                  `protobuf:"varint,2,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+	Watchers      []*Watcher             `protobuf:"bytes,1,rep,name=watchers,proto3" json:"watchers,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveTaskTagRequest) Reset() {
-	*x = RemoveTaskTagRequest{}
-	mi := &file_proto_task_task_proto_msgTypes[33]
+func (x *ListWatchersResponse) Reset() {
+	*x = ListWatchersResponse{}
+	mi := &file_proto_task_task_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveTaskTagRequest) String() string {
+func (x *ListWatchersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveTaskTagRequest) ProtoMessage() {}
+func (*ListWatchersResponse) ProtoMessage() {}
 
-func (x *RemoveTaskTagRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_task_task_proto_msgTypes[33]
+func (x *ListWatchersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_task_task_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1936,23 +3706,16 @@ func (x *RemoveTaskTagRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveTaskTagRequest.ProtoReflect.Descriptor instead.
-func (*RemoveTaskTagRequest) Descriptor() ([]byte, []int) {
-	return file_proto_task_task_proto_rawDescGZIP(), []int{33}
-}
-
-func (x *RemoveTaskTagRequest) GetTaskId() int64 {
-	if x != nil {
-		return x.TaskId
-	}
-	return 0
+// Deprecated: Use ListWatchersResponse.ProtoReflect.Descriptor instead.
+func (*ListWatchersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_task_task_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *RemoveTaskTagRequest) GetTagId() int64 {
+func (x *ListWatchersResponse) GetWatchers() []*Watcher {
 	if x != nil {
-		return x.TagId
+		return x.Watchers
 	}
-	return 0
+	return nil
 }
 
 var File_proto_task_task_proto protoreflect.FileDescriptor
@@ -1960,7 +3723,7 @@ var File_proto_task_task_proto protoreflect.FileDescriptor
 const file_proto_task_task_proto_rawDesc = "" +
 	"\n" +
 	"\x15proto/task/task.proto\x12\x04task\x1a\x1fgoogle/protobuf/timestamp.proto\"\a\n" +
-	"\x05Empty\"\xb9\x03\n" +
+	"\x05Empty\"\xd5\x03\n" +
 	"\x04Task\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
 	"\n" +
@@ -1978,7 +3741,8 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf6\x01\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\bprogress\x18\r \x01(\x01R\bprogress\"\xf6\x01\n" +
 	"\x11CreateTaskRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x14\n" +
@@ -1993,7 +3757,7 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\".\n" +
 	"\fTaskResponse\x12\x1e\n" +
 	"\x04task\x18\x01 \x01(\v2\n" +
-	".task.TaskR\x04task\"\xe7\x01\n" +
+	".task.TaskR\x04task\"\xe6\x02\n" +
 	"\x11UpdateTaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -2002,9 +3766,25 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x1f\n" +
 	"\vassigned_to\x18\x06 \x01(\x03R\n" +
 	"assignedTo\x125\n" +
-	"\bdue_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\"#\n" +
+	"\bdue_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\x12*\n" +
+	"\x11clear_assigned_to\x18\b \x01(\bR\x0fclearAssignedTo\x12$\n" +
+	"\x0eclear_due_date\x18\t \x01(\bR\fclearDueDate\x12+\n" +
+	"\x11clear_description\x18\n" +
+	" \x01(\bR\x10clearDescription\"#\n" +
 	"\x11DeleteTaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"\x94\x01\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"9\n" +
+	"\x18WatchProjectTasksRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\"\xb4\x01\n" +
+	"\tTaskEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12\x17\n" +
+	"\atask_id\x18\x03 \x01(\x03R\x06taskId\x12\x1e\n" +
+	"\x04task\x18\x04 \x01(\v2\n" +
+	".task.TaskR\x04task\x12;\n" +
+	"\voccurred_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\xa2\x02\n" +
 	"\x10ListTasksRequest\x12\x1d\n" +
 	"\n" +
 	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x12\n" +
@@ -2012,11 +3792,82 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
 	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
 	"\vassigned_to\x18\x05 \x01(\x03R\n" +
-	"assignedTo\"K\n" +
+	"assignedTo\x12\x15\n" +
+	"\x06tag_id\x18\x06 \x01(\x03R\x05tagId\x12\x10\n" +
+	"\x03tag\x18\a \x01(\tR\x03tag\x12\x1b\n" +
+	"\x06cursor\x18\b \x01(\tH\x00R\x06cursor\x88\x01\x01\x12\x17\n" +
+	"\atag_ids\x18\t \x03(\x03R\x06tagIds\x12\"\n" +
+	"\rmatch_any_tag\x18\n" +
+	" \x01(\bR\vmatchAnyTagB\t\n" +
+	"\a_cursor\"\x82\x01\n" +
 	"\x11ListTasksResponse\x12 \n" +
 	"\x05tasks\x18\x01 \x03(\v2\n" +
 	".task.TaskR\x05tasks\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\"\xae\x02\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xda\x01\n" +
+	"\x12StreamTasksRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vassigned_to\x18\x03 \x01(\x03R\n" +
+	"assignedTo\x12\x17\n" +
+	"\atag_ids\x18\x04 \x03(\x03R\x06tagIds\x12\x10\n" +
+	"\x03tag\x18\x05 \x01(\tR\x03tag\x12\"\n" +
+	"\rmatch_any_tag\x18\x06 \x01(\bR\vmatchAnyTag\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\a \x01(\x05R\tbatchSize\"-\n" +
+	"\tTaskBatch\x12 \n" +
+	"\x05tasks\x18\x01 \x03(\v2\n" +
+	".task.TaskR\x05tasks\"X\n" +
+	"\vTaskVersion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x129\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"3\n" +
+	"\x12ListTaskIDsRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\">\n" +
+	"\x13ListTaskIDsResponse\x12'\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x11.task.TaskVersionR\x05tasks\"{\n" +
+	"\x10ProjectTaskCount\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x1f\n" +
+	"\vtotal_tasks\x18\x02 \x01(\x05R\n" +
+	"totalTasks\x12'\n" +
+	"\x0fcompleted_tasks\x18\x03 \x01(\x05R\x0ecompletedTasks\"O\n" +
+	"\x19CountProjectTasksResponse\x122\n" +
+	"\bprojects\x18\x01 \x03(\v2\x16.task.ProjectTaskCountR\bprojects\"6\n" +
+	"\x15GetTaskSummaryRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\"\x97\x02\n" +
+	"\x13TaskSummaryResponse\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\x03R\tprojectId\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12P\n" +
+	"\rstatus_counts\x18\x03 \x03(\v2+.task.TaskSummaryResponse.StatusCountsEntryR\fstatusCounts\x12\x18\n" +
+	"\aoverdue\x18\x04 \x01(\x05R\aoverdue\x12\x1e\n" +
+	"\n" +
+	"unassigned\x18\x05 \x01(\x05R\n" +
+	"unassigned\x1a?\n" +
+	"\x11StatusCountsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"*\n" +
+	"\x16BulkDeleteTasksRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"3\n" +
+	"\x17BulkDeleteTasksResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted\"I\n" +
+	"\x11DemoteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12$\n" +
+	"\x0eparent_task_id\x18\x02 \x01(\x03R\fparentTaskId\"J\n" +
+	"\x14DuplicateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\"\n" +
+	"\rkeep_due_date\x18\x02 \x01(\bR\vkeepDueDate\"i\n" +
+	"\x0fMoveTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x02 \x01(\x03R\tprojectId\x12'\n" +
+	"\x0fclear_assignees\x18\x03 \x01(\bR\x0eclearAssignees\"\xca\x02\n" +
 	"\aSubtask\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x14\n" +
@@ -2028,7 +3879,8 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9d\x01\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\bposition\x18\t \x01(\x05R\bposition\"\x9d\x01\n" +
 	"\x14CreateSubtaskRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1f\n" +
@@ -2036,7 +3888,10 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"assignedTo\x125\n" +
 	"\bdue_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\":\n" +
 	"\x0fSubtaskResponse\x12'\n" +
-	"\asubtask\x18\x01 \x01(\v2\r.task.SubtaskR\asubtask\"\xac\x01\n" +
+	"\asubtask\x18\x01 \x01(\v2\r.task.SubtaskR\asubtask\"H\n" +
+	"\x15CreateSubtasksRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x16\n" +
+	"\x06titles\x18\x02 \x03(\tR\x06titles\"\xac\x01\n" +
 	"\x14UpdateSubtaskRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x16\n" +
@@ -2045,11 +3900,23 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"assignedTo\x125\n" +
 	"\bdue_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\adueDate\"&\n" +
 	"\x14DeleteSubtaskRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\".\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"_\n" +
+	"\x12MoveSubtaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12 \n" +
+	"\ffrom_task_id\x18\x03 \x01(\x03R\n" +
+	"fromTaskId\"@\n" +
+	"\x15PromoteSubtaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\x03R\x06taskId\"X\n" +
 	"\x13ListSubtasksRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"A\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"m\n" +
 	"\x14ListSubtasksResponse\x12)\n" +
-	"\bsubtasks\x18\x01 \x03(\v2\r.task.SubtaskR\bsubtasks\"\xa0\x01\n" +
+	"\bsubtasks\x18\x01 \x03(\v2\r.task.SubtaskR\bsubtasks\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xa0\x01\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x17\n" +
@@ -2064,31 +3931,51 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\x0fCommentResponse\x12'\n" +
 	"\acomment\x18\x01 \x01(\v2\r.task.CommentR\acomment\"&\n" +
 	"\x14DeleteCommentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\".\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"4\n" +
+	"\x19DeleteTaskCommentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"6\n" +
+	"\x1aDeleteTaskCommentsResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted\"q\n" +
 	"\x13ListCommentsRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"A\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x03R\x06userId\"m\n" +
 	"\x14ListCommentsResponse\x12)\n" +
-	"\bcomments\x18\x01 \x03(\v2\r.task.CommentR\bcomments\"\x8d\x01\n" +
+	"\bcomments\x18\x01 \x03(\v2\r.task.CommentR\bcomments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xc5\x01\n" +
 	"\n" +
 	"Attachment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\atask_id\x18\x02 \x01(\x03R\x06taskId\x12\x19\n" +
 	"\bfile_url\x18\x03 \x01(\tR\afileUrl\x12;\n" +
 	"\vuploaded_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
-	"uploadedAt\"J\n" +
+	"uploadedAt\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\"\n" +
+	"\rmedia_file_id\x18\x06 \x01(\x03R\vmediaFileId\"n\n" +
 	"\x14AddAttachmentRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x19\n" +
-	"\bfile_url\x18\x02 \x01(\tR\afileUrl\"F\n" +
+	"\bfile_url\x18\x02 \x01(\tR\afileUrl\x12\"\n" +
+	"\rmedia_file_id\x18\x03 \x01(\x03R\vmediaFileId\"F\n" +
 	"\x12AttachmentResponse\x120\n" +
 	"\n" +
 	"attachment\x18\x01 \x01(\v2\x10.task.AttachmentR\n" +
 	"attachment\")\n" +
 	"\x17DeleteAttachmentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"1\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"7\n" +
+	"\x1cDeleteTaskAttachmentsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"9\n" +
+	"\x1dDeleteTaskAttachmentsResponse\x12\x18\n" +
+	"\adeleted\x18\x01 \x01(\x05R\adeleted\"[\n" +
 	"\x16ListAttachmentsRequest\x12\x17\n" +
-	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"M\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"y\n" +
 	"\x17ListAttachmentsResponse\x122\n" +
-	"\vattachments\x18\x01 \x03(\v2\x10.task.AttachmentR\vattachments\")\n" +
+	"\vattachments\x18\x01 \x03(\v2\x10.task.AttachmentR\vattachments\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\")\n" +
 	"\x03Tag\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"&\n" +
@@ -2103,7 +3990,24 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\x06tag_id\x18\x02 \x01(\x03R\x05tagId\"F\n" +
 	"\x14RemoveTaskTagRequest\x12\x17\n" +
 	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x15\n" +
-	"\x06tag_id\x18\x02 \x01(\x03R\x05tagId2\xa0\t\n" +
+	"\x06tag_id\x18\x02 \x01(\x03R\x05tagId\"\"\n" +
+	"\x10DeleteTagRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"v\n" +
+	"\aWatcher\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"E\n" +
+	"\x11AddWatcherRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\"H\n" +
+	"\x14RemoveWatcherRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\".\n" +
+	"\x13ListWatchersRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\x03R\x06taskId\"A\n" +
+	"\x14ListWatchersResponse\x12)\n" +
+	"\bwatchers\x18\x01 \x03(\v2\r.task.WatcherR\bwatchers2\xeb\x12\n" +
 	"\vTaskService\x129\n" +
 	"\n" +
 	"CreateTask\x12\x17.task.CreateTaskRequest\x1a\x12.task.TaskResponse\x123\n" +
@@ -2113,22 +4017,42 @@ const file_proto_task_task_proto_rawDesc = "" +
 	"\n" +
 	"DeleteTask\x12\x17.task.DeleteTaskRequest\x1a\v.task.Empty\x12<\n" +
 	"\tListTasks\x12\x16.task.ListTasksRequest\x1a\x17.task.ListTasksResponse\x12B\n" +
-	"\rCreateSubtask\x12\x1a.task.CreateSubtaskRequest\x1a\x15.task.SubtaskResponse\x12B\n" +
+	"\vListTaskIDs\x12\x18.task.ListTaskIDsRequest\x1a\x19.task.ListTaskIDsResponse\x12A\n" +
+	"\x11CountProjectTasks\x12\v.task.Empty\x1a\x1f.task.CountProjectTasksResponse\x12H\n" +
+	"\x0eGetTaskSummary\x12\x1b.task.GetTaskSummaryRequest\x1a\x19.task.TaskSummaryResponse\x12:\n" +
+	"\vStreamTasks\x12\x18.task.StreamTasksRequest\x1a\x0f.task.TaskBatch0\x01\x12N\n" +
+	"\x0fBulkDeleteTasks\x12\x1c.task.BulkDeleteTasksRequest\x1a\x1d.task.BulkDeleteTasksResponse\x12<\n" +
+	"\n" +
+	"DemoteTask\x12\x17.task.DemoteTaskRequest\x1a\x15.task.SubtaskResponse\x12?\n" +
+	"\rDuplicateTask\x12\x1a.task.DuplicateTaskRequest\x1a\x12.task.TaskResponse\x125\n" +
+	"\bMoveTask\x12\x15.task.MoveTaskRequest\x1a\x12.task.TaskResponse\x12F\n" +
+	"\x11WatchProjectTasks\x12\x1e.task.WatchProjectTasksRequest\x1a\x0f.task.TaskEvent0\x01\x12B\n" +
+	"\rCreateSubtask\x12\x1a.task.CreateSubtaskRequest\x1a\x15.task.SubtaskResponse\x12I\n" +
+	"\x0eCreateSubtasks\x12\x1b.task.CreateSubtasksRequest\x1a\x1a.task.ListSubtasksResponse\x12B\n" +
 	"\rUpdateSubtask\x12\x1a.task.UpdateSubtaskRequest\x1a\x15.task.SubtaskResponse\x128\n" +
 	"\rDeleteSubtask\x12\x1a.task.DeleteSubtaskRequest\x1a\v.task.Empty\x12E\n" +
-	"\fListSubtasks\x12\x19.task.ListSubtasksRequest\x1a\x1a.task.ListSubtasksResponse\x12<\n" +
+	"\fListSubtasks\x12\x19.task.ListSubtasksRequest\x1a\x1a.task.ListSubtasksResponse\x12>\n" +
+	"\vMoveSubtask\x12\x18.task.MoveSubtaskRequest\x1a\x15.task.SubtaskResponse\x12A\n" +
+	"\x0ePromoteSubtask\x12\x1b.task.PromoteSubtaskRequest\x1a\x12.task.TaskResponse\x12<\n" +
 	"\n" +
 	"AddComment\x12\x17.task.AddCommentRequest\x1a\x15.task.CommentResponse\x128\n" +
-	"\rDeleteComment\x12\x1a.task.DeleteCommentRequest\x1a\v.task.Empty\x12E\n" +
+	"\rDeleteComment\x12\x1a.task.DeleteCommentRequest\x1a\v.task.Empty\x12W\n" +
+	"\x12DeleteTaskComments\x12\x1f.task.DeleteTaskCommentsRequest\x1a .task.DeleteTaskCommentsResponse\x12E\n" +
 	"\fListComments\x12\x19.task.ListCommentsRequest\x1a\x1a.task.ListCommentsResponse\x12E\n" +
 	"\rAddAttachment\x12\x1a.task.AddAttachmentRequest\x1a\x18.task.AttachmentResponse\x12>\n" +
-	"\x10DeleteAttachment\x12\x1d.task.DeleteAttachmentRequest\x1a\v.task.Empty\x12N\n" +
+	"\x10DeleteAttachment\x12\x1d.task.DeleteAttachmentRequest\x1a\v.task.Empty\x12`\n" +
+	"\x15DeleteTaskAttachments\x12\".task.DeleteTaskAttachmentsRequest\x1a#.task.DeleteTaskAttachmentsResponse\x12N\n" +
 	"\x0fListAttachments\x12\x1c.task.ListAttachmentsRequest\x1a\x1d.task.ListAttachmentsResponse\x126\n" +
 	"\tCreateTag\x12\x16.task.CreateTagRequest\x1a\x11.task.TagResponse\x12/\n" +
 	"\bListTags\x12\v.task.Empty\x1a\x16.task.ListTagsResponse\x122\n" +
 	"\n" +
 	"AddTaskTag\x12\x17.task.AddTaskTagRequest\x1a\v.task.Empty\x128\n" +
-	"\rRemoveTaskTag\x12\x1a.task.RemoveTaskTagRequest\x1a\v.task.EmptyB!Z\x1fgithub.com/portfolio/proto/taskb\x06proto3"
+	"\rRemoveTaskTag\x12\x1a.task.RemoveTaskTagRequest\x1a\v.task.Empty\x120\n" +
+	"\tDeleteTag\x12\x16.task.DeleteTagRequest\x1a\v.task.Empty\x122\n" +
+	"\n" +
+	"AddWatcher\x12\x17.task.AddWatcherRequest\x1a\v.task.Empty\x128\n" +
+	"\rRemoveWatcher\x12\x1a.task.RemoveWatcherRequest\x1a\v.task.Empty\x12E\n" +
+	"\fListWatchers\x12\x19.task.ListWatchersRequest\x1a\x1a.task.ListWatchersResponseB!Z\x1fgithub.com/portfolio/proto/taskb\x06proto3"
 
 var (
 	file_proto_task_task_proto_rawDescOnce sync.Once
@@ -2142,112 +4066,187 @@ func file_proto_task_task_proto_rawDescGZIP() []byte {
 	return file_proto_task_task_proto_rawDescData
 }
 
-var file_proto_task_task_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_proto_task_task_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_proto_task_task_proto_goTypes = []any{
-	(*Empty)(nil),                   // 0: task.Empty
-	(*Task)(nil),                    // 1: task.Task
-	(*CreateTaskRequest)(nil),       // 2: task.CreateTaskRequest
-	(*GetTaskRequest)(nil),          // 3: task.GetTaskRequest
-	(*TaskResponse)(nil),            // 4: task.TaskResponse
-	(*UpdateTaskRequest)(nil),       // 5: task.UpdateTaskRequest
-	(*DeleteTaskRequest)(nil),       // 6: task.DeleteTaskRequest
-	(*ListTasksRequest)(nil),        // 7: task.ListTasksRequest
-	(*ListTasksResponse)(nil),       // 8: task.ListTasksResponse
-	(*Subtask)(nil),                 // 9: task.Subtask
-	(*CreateSubtaskRequest)(nil),    // 10: task.CreateSubtaskRequest
-	(*SubtaskResponse)(nil),         // 11: task.SubtaskResponse
-	(*UpdateSubtaskRequest)(nil),    // 12: task.UpdateSubtaskRequest
-	(*DeleteSubtaskRequest)(nil),    // 13: task.DeleteSubtaskRequest
-	(*ListSubtasksRequest)(nil),     // 14: task.ListSubtasksRequest
-	(*ListSubtasksResponse)(nil),    // 15: task.ListSubtasksResponse
-	(*Comment)(nil),                 // 16: task.Comment
-	(*AddCommentRequest)(nil),       // 17: task.AddCommentRequest
-	(*CommentResponse)(nil),         // 18: task.CommentResponse
-	(*DeleteCommentRequest)(nil),    // 19: task.DeleteCommentRequest
-	(*ListCommentsRequest)(nil),     // 20: task.ListCommentsRequest
-	(*ListCommentsResponse)(nil),    // 21: task.ListCommentsResponse
-	(*Attachment)(nil),              // 22: task.Attachment
-	(*AddAttachmentRequest)(nil),    // 23: task.AddAttachmentRequest
-	(*AttachmentResponse)(nil),      // 24: task.AttachmentResponse
-	(*DeleteAttachmentRequest)(nil), // 25: task.DeleteAttachmentRequest
-	(*ListAttachmentsRequest)(nil),  // 26: task.ListAttachmentsRequest
-	(*ListAttachmentsResponse)(nil), // 27: task.ListAttachmentsResponse
-	(*Tag)(nil),                     // 28: task.Tag
-	(*CreateTagRequest)(nil),        // 29: task.CreateTagRequest
-	(*TagResponse)(nil),             // 30: task.TagResponse
-	(*ListTagsResponse)(nil),        // 31: task.ListTagsResponse
-	(*AddTaskTagRequest)(nil),       // 32: task.AddTaskTagRequest
-	(*RemoveTaskTagRequest)(nil),    // 33: task.RemoveTaskTagRequest
-	(*timestamppb.Timestamp)(nil),   // 34: google.protobuf.Timestamp
+	(*Empty)(nil),                         // 0: task.Empty
+	(*Task)(nil),                          // 1: task.Task
+	(*CreateTaskRequest)(nil),             // 2: task.CreateTaskRequest
+	(*GetTaskRequest)(nil),                // 3: task.GetTaskRequest
+	(*TaskResponse)(nil),                  // 4: task.TaskResponse
+	(*UpdateTaskRequest)(nil),             // 5: task.UpdateTaskRequest
+	(*DeleteTaskRequest)(nil),             // 6: task.DeleteTaskRequest
+	(*WatchProjectTasksRequest)(nil),      // 7: task.WatchProjectTasksRequest
+	(*TaskEvent)(nil),                     // 8: task.TaskEvent
+	(*ListTasksRequest)(nil),              // 9: task.ListTasksRequest
+	(*ListTasksResponse)(nil),             // 10: task.ListTasksResponse
+	(*StreamTasksRequest)(nil),            // 11: task.StreamTasksRequest
+	(*TaskBatch)(nil),                     // 12: task.TaskBatch
+	(*TaskVersion)(nil),                   // 13: task.TaskVersion
+	(*ListTaskIDsRequest)(nil),            // 14: task.ListTaskIDsRequest
+	(*ListTaskIDsResponse)(nil),           // 15: task.ListTaskIDsResponse
+	(*ProjectTaskCount)(nil),              // 16: task.ProjectTaskCount
+	(*CountProjectTasksResponse)(nil),     // 17: task.CountProjectTasksResponse
+	(*GetTaskSummaryRequest)(nil),         // 18: task.GetTaskSummaryRequest
+	(*TaskSummaryResponse)(nil),           // 19: task.TaskSummaryResponse
+	(*BulkDeleteTasksRequest)(nil),        // 20: task.BulkDeleteTasksRequest
+	(*BulkDeleteTasksResponse)(nil),       // 21: task.BulkDeleteTasksResponse
+	(*DemoteTaskRequest)(nil),             // 22: task.DemoteTaskRequest
+	(*DuplicateTaskRequest)(nil),          // 23: task.DuplicateTaskRequest
+	(*MoveTaskRequest)(nil),               // 24: task.MoveTaskRequest
+	(*Subtask)(nil),                       // 25: task.Subtask
+	(*CreateSubtaskRequest)(nil),          // 26: task.CreateSubtaskRequest
+	(*SubtaskResponse)(nil),               // 27: task.SubtaskResponse
+	(*CreateSubtasksRequest)(nil),         // 28: task.CreateSubtasksRequest
+	(*UpdateSubtaskRequest)(nil),          // 29: task.UpdateSubtaskRequest
+	(*DeleteSubtaskRequest)(nil),          // 30: task.DeleteSubtaskRequest
+	(*MoveSubtaskRequest)(nil),            // 31: task.MoveSubtaskRequest
+	(*PromoteSubtaskRequest)(nil),         // 32: task.PromoteSubtaskRequest
+	(*ListSubtasksRequest)(nil),           // 33: task.ListSubtasksRequest
+	(*ListSubtasksResponse)(nil),          // 34: task.ListSubtasksResponse
+	(*Comment)(nil),                       // 35: task.Comment
+	(*AddCommentRequest)(nil),             // 36: task.AddCommentRequest
+	(*CommentResponse)(nil),               // 37: task.CommentResponse
+	(*DeleteCommentRequest)(nil),          // 38: task.DeleteCommentRequest
+	(*DeleteTaskCommentsRequest)(nil),     // 39: task.DeleteTaskCommentsRequest
+	(*DeleteTaskCommentsResponse)(nil),    // 40: task.DeleteTaskCommentsResponse
+	(*ListCommentsRequest)(nil),           // 41: task.ListCommentsRequest
+	(*ListCommentsResponse)(nil),          // 42: task.ListCommentsResponse
+	(*Attachment)(nil),                    // 43: task.Attachment
+	(*AddAttachmentRequest)(nil),          // 44: task.AddAttachmentRequest
+	(*AttachmentResponse)(nil),            // 45: task.AttachmentResponse
+	(*DeleteAttachmentRequest)(nil),       // 46: task.DeleteAttachmentRequest
+	(*DeleteTaskAttachmentsRequest)(nil),  // 47: task.DeleteTaskAttachmentsRequest
+	(*DeleteTaskAttachmentsResponse)(nil), // 48: task.DeleteTaskAttachmentsResponse
+	(*ListAttachmentsRequest)(nil),        // 49: task.ListAttachmentsRequest
+	(*ListAttachmentsResponse)(nil),       // 50: task.ListAttachmentsResponse
+	(*Tag)(nil),                           // 51: task.Tag
+	(*CreateTagRequest)(nil),              // 52: task.CreateTagRequest
+	(*TagResponse)(nil),                   // 53: task.TagResponse
+	(*ListTagsResponse)(nil),              // 54: task.ListTagsResponse
+	(*AddTaskTagRequest)(nil),             // 55: task.AddTaskTagRequest
+	(*RemoveTaskTagRequest)(nil),          // 56: task.RemoveTaskTagRequest
+	(*DeleteTagRequest)(nil),              // 57: task.DeleteTagRequest
+	(*Watcher)(nil),                       // 58: task.Watcher
+	(*AddWatcherRequest)(nil),             // 59: task.AddWatcherRequest
+	(*RemoveWatcherRequest)(nil),          // 60: task.RemoveWatcherRequest
+	(*ListWatchersRequest)(nil),           // 61: task.ListWatchersRequest
+	(*ListWatchersResponse)(nil),          // 62: task.ListWatchersResponse
+	nil,                                   // 63: task.TaskSummaryResponse.StatusCountsEntry
+	(*timestamppb.Timestamp)(nil),         // 64: google.protobuf.Timestamp
 }
 var file_proto_task_task_proto_depIdxs = []int32{
-	34, // 0: task.Task.due_date:type_name -> google.protobuf.Timestamp
-	9,  // 1: task.Task.subtasks:type_name -> task.Subtask
-	28, // 2: task.Task.tags:type_name -> task.Tag
-	34, // 3: task.Task.created_at:type_name -> google.protobuf.Timestamp
-	34, // 4: task.Task.updated_at:type_name -> google.protobuf.Timestamp
-	34, // 5: task.CreateTaskRequest.due_date:type_name -> google.protobuf.Timestamp
+	64, // 0: task.Task.due_date:type_name -> google.protobuf.Timestamp
+	25, // 1: task.Task.subtasks:type_name -> task.Subtask
+	51, // 2: task.Task.tags:type_name -> task.Tag
+	64, // 3: task.Task.created_at:type_name -> google.protobuf.Timestamp
+	64, // 4: task.Task.updated_at:type_name -> google.protobuf.Timestamp
+	64, // 5: task.CreateTaskRequest.due_date:type_name -> google.protobuf.Timestamp
 	1,  // 6: task.TaskResponse.task:type_name -> task.Task
-	34, // 7: task.UpdateTaskRequest.due_date:type_name -> google.protobuf.Timestamp
-	1,  // 8: task.ListTasksResponse.tasks:type_name -> task.Task
-	34, // 9: task.Subtask.due_date:type_name -> google.protobuf.Timestamp
-	34, // 10: task.Subtask.created_at:type_name -> google.protobuf.Timestamp
-	34, // 11: task.Subtask.updated_at:type_name -> google.protobuf.Timestamp
-	34, // 12: task.CreateSubtaskRequest.due_date:type_name -> google.protobuf.Timestamp
-	9,  // 13: task.SubtaskResponse.subtask:type_name -> task.Subtask
-	34, // 14: task.UpdateSubtaskRequest.due_date:type_name -> google.protobuf.Timestamp
-	9,  // 15: task.ListSubtasksResponse.subtasks:type_name -> task.Subtask
-	34, // 16: task.Comment.created_at:type_name -> google.protobuf.Timestamp
-	16, // 17: task.CommentResponse.comment:type_name -> task.Comment
-	16, // 18: task.ListCommentsResponse.comments:type_name -> task.Comment
-	34, // 19: task.Attachment.uploaded_at:type_name -> google.protobuf.Timestamp
-	22, // 20: task.AttachmentResponse.attachment:type_name -> task.Attachment
-	22, // 21: task.ListAttachmentsResponse.attachments:type_name -> task.Attachment
-	28, // 22: task.TagResponse.tag:type_name -> task.Tag
-	28, // 23: task.ListTagsResponse.tags:type_name -> task.Tag
-	2,  // 24: task.TaskService.CreateTask:input_type -> task.CreateTaskRequest
-	3,  // 25: task.TaskService.GetTask:input_type -> task.GetTaskRequest
-	5,  // 26: task.TaskService.UpdateTask:input_type -> task.UpdateTaskRequest
-	6,  // 27: task.TaskService.DeleteTask:input_type -> task.DeleteTaskRequest
-	7,  // 28: task.TaskService.ListTasks:input_type -> task.ListTasksRequest
-	10, // 29: task.TaskService.CreateSubtask:input_type -> task.CreateSubtaskRequest
-	12, // 30: task.TaskService.UpdateSubtask:input_type -> task.UpdateSubtaskRequest
-	13, // 31: task.TaskService.DeleteSubtask:input_type -> task.DeleteSubtaskRequest
-	14, // 32: task.TaskService.ListSubtasks:input_type -> task.ListSubtasksRequest
-	17, // 33: task.TaskService.AddComment:input_type -> task.AddCommentRequest
-	19, // 34: task.TaskService.DeleteComment:input_type -> task.DeleteCommentRequest
-	20, // 35: task.TaskService.ListComments:input_type -> task.ListCommentsRequest
-	23, // 36: task.TaskService.AddAttachment:input_type -> task.AddAttachmentRequest
-	25, // 37: task.TaskService.DeleteAttachment:input_type -> task.DeleteAttachmentRequest
-	26, // 38: task.TaskService.ListAttachments:input_type -> task.ListAttachmentsRequest
-	29, // 39: task.TaskService.CreateTag:input_type -> task.CreateTagRequest
-	0,  // 40: task.TaskService.ListTags:input_type -> task.Empty
-	32, // 41: task.TaskService.AddTaskTag:input_type -> task.AddTaskTagRequest
-	33, // 42: task.TaskService.RemoveTaskTag:input_type -> task.RemoveTaskTagRequest
-	4,  // 43: task.TaskService.CreateTask:output_type -> task.TaskResponse
-	4,  // 44: task.TaskService.GetTask:output_type -> task.TaskResponse
-	4,  // 45: task.TaskService.UpdateTask:output_type -> task.TaskResponse
-	0,  // 46: task.TaskService.DeleteTask:output_type -> task.Empty
-	8,  // 47: task.TaskService.ListTasks:output_type -> task.ListTasksResponse
-	11, // 48: task.TaskService.CreateSubtask:output_type -> task.SubtaskResponse
-	11, // 49: task.TaskService.UpdateSubtask:output_type -> task.SubtaskResponse
-	0,  // 50: task.TaskService.DeleteSubtask:output_type -> task.Empty
-	15, // 51: task.TaskService.ListSubtasks:output_type -> task.ListSubtasksResponse
-	18, // 52: task.TaskService.AddComment:output_type -> task.CommentResponse
-	0,  // 53: task.TaskService.DeleteComment:output_type -> task.Empty
-	21, // 54: task.TaskService.ListComments:output_type -> task.ListCommentsResponse
-	24, // 55: task.TaskService.AddAttachment:output_type -> task.AttachmentResponse
-	0,  // 56: task.TaskService.DeleteAttachment:output_type -> task.Empty
-	27, // 57: task.TaskService.ListAttachments:output_type -> task.ListAttachmentsResponse
-	30, // 58: task.TaskService.CreateTag:output_type -> task.TagResponse
-	31, // 59: task.TaskService.ListTags:output_type -> task.ListTagsResponse
-	0,  // 60: task.TaskService.AddTaskTag:output_type -> task.Empty
-	0,  // 61: task.TaskService.RemoveTaskTag:output_type -> task.Empty
-	43, // [43:62] is the sub-list for method output_type
-	24, // [24:43] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	64, // 7: task.UpdateTaskRequest.due_date:type_name -> google.protobuf.Timestamp
+	1,  // 8: task.TaskEvent.task:type_name -> task.Task
+	64, // 9: task.TaskEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	1,  // 10: task.ListTasksResponse.tasks:type_name -> task.Task
+	1,  // 11: task.TaskBatch.tasks:type_name -> task.Task
+	64, // 12: task.TaskVersion.updated_at:type_name -> google.protobuf.Timestamp
+	13, // 13: task.ListTaskIDsResponse.tasks:type_name -> task.TaskVersion
+	16, // 14: task.CountProjectTasksResponse.projects:type_name -> task.ProjectTaskCount
+	63, // 15: task.TaskSummaryResponse.status_counts:type_name -> task.TaskSummaryResponse.StatusCountsEntry
+	64, // 16: task.Subtask.due_date:type_name -> google.protobuf.Timestamp
+	64, // 17: task.Subtask.created_at:type_name -> google.protobuf.Timestamp
+	64, // 18: task.Subtask.updated_at:type_name -> google.protobuf.Timestamp
+	64, // 19: task.CreateSubtaskRequest.due_date:type_name -> google.protobuf.Timestamp
+	25, // 20: task.SubtaskResponse.subtask:type_name -> task.Subtask
+	64, // 21: task.UpdateSubtaskRequest.due_date:type_name -> google.protobuf.Timestamp
+	25, // 22: task.ListSubtasksResponse.subtasks:type_name -> task.Subtask
+	64, // 23: task.Comment.created_at:type_name -> google.protobuf.Timestamp
+	35, // 24: task.CommentResponse.comment:type_name -> task.Comment
+	35, // 25: task.ListCommentsResponse.comments:type_name -> task.Comment
+	64, // 26: task.Attachment.uploaded_at:type_name -> google.protobuf.Timestamp
+	43, // 27: task.AttachmentResponse.attachment:type_name -> task.Attachment
+	43, // 28: task.ListAttachmentsResponse.attachments:type_name -> task.Attachment
+	51, // 29: task.TagResponse.tag:type_name -> task.Tag
+	51, // 30: task.ListTagsResponse.tags:type_name -> task.Tag
+	64, // 31: task.Watcher.created_at:type_name -> google.protobuf.Timestamp
+	58, // 32: task.ListWatchersResponse.watchers:type_name -> task.Watcher
+	2,  // 33: task.TaskService.CreateTask:input_type -> task.CreateTaskRequest
+	3,  // 34: task.TaskService.GetTask:input_type -> task.GetTaskRequest
+	5,  // 35: task.TaskService.UpdateTask:input_type -> task.UpdateTaskRequest
+	6,  // 36: task.TaskService.DeleteTask:input_type -> task.DeleteTaskRequest
+	9,  // 37: task.TaskService.ListTasks:input_type -> task.ListTasksRequest
+	14, // 38: task.TaskService.ListTaskIDs:input_type -> task.ListTaskIDsRequest
+	0,  // 39: task.TaskService.CountProjectTasks:input_type -> task.Empty
+	18, // 40: task.TaskService.GetTaskSummary:input_type -> task.GetTaskSummaryRequest
+	11, // 41: task.TaskService.StreamTasks:input_type -> task.StreamTasksRequest
+	20, // 42: task.TaskService.BulkDeleteTasks:input_type -> task.BulkDeleteTasksRequest
+	22, // 43: task.TaskService.DemoteTask:input_type -> task.DemoteTaskRequest
+	23, // 44: task.TaskService.DuplicateTask:input_type -> task.DuplicateTaskRequest
+	24, // 45: task.TaskService.MoveTask:input_type -> task.MoveTaskRequest
+	7,  // 46: task.TaskService.WatchProjectTasks:input_type -> task.WatchProjectTasksRequest
+	26, // 47: task.TaskService.CreateSubtask:input_type -> task.CreateSubtaskRequest
+	28, // 48: task.TaskService.CreateSubtasks:input_type -> task.CreateSubtasksRequest
+	29, // 49: task.TaskService.UpdateSubtask:input_type -> task.UpdateSubtaskRequest
+	30, // 50: task.TaskService.DeleteSubtask:input_type -> task.DeleteSubtaskRequest
+	33, // 51: task.TaskService.ListSubtasks:input_type -> task.ListSubtasksRequest
+	31, // 52: task.TaskService.MoveSubtask:input_type -> task.MoveSubtaskRequest
+	32, // 53: task.TaskService.PromoteSubtask:input_type -> task.PromoteSubtaskRequest
+	36, // 54: task.TaskService.AddComment:input_type -> task.AddCommentRequest
+	38, // 55: task.TaskService.DeleteComment:input_type -> task.DeleteCommentRequest
+	39, // 56: task.TaskService.DeleteTaskComments:input_type -> task.DeleteTaskCommentsRequest
+	41, // 57: task.TaskService.ListComments:input_type -> task.ListCommentsRequest
+	44, // 58: task.TaskService.AddAttachment:input_type -> task.AddAttachmentRequest
+	46, // 59: task.TaskService.DeleteAttachment:input_type -> task.DeleteAttachmentRequest
+	47, // 60: task.TaskService.DeleteTaskAttachments:input_type -> task.DeleteTaskAttachmentsRequest
+	49, // 61: task.TaskService.ListAttachments:input_type -> task.ListAttachmentsRequest
+	52, // 62: task.TaskService.CreateTag:input_type -> task.CreateTagRequest
+	0,  // 63: task.TaskService.ListTags:input_type -> task.Empty
+	55, // 64: task.TaskService.AddTaskTag:input_type -> task.AddTaskTagRequest
+	56, // 65: task.TaskService.RemoveTaskTag:input_type -> task.RemoveTaskTagRequest
+	57, // 66: task.TaskService.DeleteTag:input_type -> task.DeleteTagRequest
+	59, // 67: task.TaskService.AddWatcher:input_type -> task.AddWatcherRequest
+	60, // 68: task.TaskService.RemoveWatcher:input_type -> task.RemoveWatcherRequest
+	61, // 69: task.TaskService.ListWatchers:input_type -> task.ListWatchersRequest
+	4,  // 70: task.TaskService.CreateTask:output_type -> task.TaskResponse
+	4,  // 71: task.TaskService.GetTask:output_type -> task.TaskResponse
+	4,  // 72: task.TaskService.UpdateTask:output_type -> task.TaskResponse
+	0,  // 73: task.TaskService.DeleteTask:output_type -> task.Empty
+	10, // 74: task.TaskService.ListTasks:output_type -> task.ListTasksResponse
+	15, // 75: task.TaskService.ListTaskIDs:output_type -> task.ListTaskIDsResponse
+	17, // 76: task.TaskService.CountProjectTasks:output_type -> task.CountProjectTasksResponse
+	19, // 77: task.TaskService.GetTaskSummary:output_type -> task.TaskSummaryResponse
+	12, // 78: task.TaskService.StreamTasks:output_type -> task.TaskBatch
+	21, // 79: task.TaskService.BulkDeleteTasks:output_type -> task.BulkDeleteTasksResponse
+	27, // 80: task.TaskService.DemoteTask:output_type -> task.SubtaskResponse
+	4,  // 81: task.TaskService.DuplicateTask:output_type -> task.TaskResponse
+	4,  // 82: task.TaskService.MoveTask:output_type -> task.TaskResponse
+	8,  // 83: task.TaskService.WatchProjectTasks:output_type -> task.TaskEvent
+	27, // 84: task.TaskService.CreateSubtask:output_type -> task.SubtaskResponse
+	34, // 85: task.TaskService.CreateSubtasks:output_type -> task.ListSubtasksResponse
+	27, // 86: task.TaskService.UpdateSubtask:output_type -> task.SubtaskResponse
+	0,  // 87: task.TaskService.DeleteSubtask:output_type -> task.Empty
+	34, // 88: task.TaskService.ListSubtasks:output_type -> task.ListSubtasksResponse
+	27, // 89: task.TaskService.MoveSubtask:output_type -> task.SubtaskResponse
+	4,  // 90: task.TaskService.PromoteSubtask:output_type -> task.TaskResponse
+	37, // 91: task.TaskService.AddComment:output_type -> task.CommentResponse
+	0,  // 92: task.TaskService.DeleteComment:output_type -> task.Empty
+	40, // 93: task.TaskService.DeleteTaskComments:output_type -> task.DeleteTaskCommentsResponse
+	42, // 94: task.TaskService.ListComments:output_type -> task.ListCommentsResponse
+	45, // 95: task.TaskService.AddAttachment:output_type -> task.AttachmentResponse
+	0,  // 96: task.TaskService.DeleteAttachment:output_type -> task.Empty
+	48, // 97: task.TaskService.DeleteTaskAttachments:output_type -> task.DeleteTaskAttachmentsResponse
+	50, // 98: task.TaskService.ListAttachments:output_type -> task.ListAttachmentsResponse
+	53, // 99: task.TaskService.CreateTag:output_type -> task.TagResponse
+	54, // 100: task.TaskService.ListTags:output_type -> task.ListTagsResponse
+	0,  // 101: task.TaskService.AddTaskTag:output_type -> task.Empty
+	0,  // 102: task.TaskService.RemoveTaskTag:output_type -> task.Empty
+	0,  // 103: task.TaskService.DeleteTag:output_type -> task.Empty
+	0,  // 104: task.TaskService.AddWatcher:output_type -> task.Empty
+	0,  // 105: task.TaskService.RemoveWatcher:output_type -> task.Empty
+	62, // 106: task.TaskService.ListWatchers:output_type -> task.ListWatchersResponse
+	70, // [70:107] is the sub-list for method output_type
+	33, // [33:70] is the sub-list for method input_type
+	33, // [33:33] is the sub-list for extension type_name
+	33, // [33:33] is the sub-list for extension extendee
+	0,  // [0:33] is the sub-list for field type_name
 }
 
 func init() { file_proto_task_task_proto_init() }
@@ -2255,13 +4254,14 @@ func file_proto_task_task_proto_init() {
 	if File_proto_task_task_proto != nil {
 		return
 	}
+	file_proto_task_task_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_task_task_proto_rawDesc), len(file_proto_task_task_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   34,
+			NumMessages:   64,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,25 +19,43 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	TaskService_CreateTask_FullMethodName       = "/task.TaskService/CreateTask"
-	TaskService_GetTask_FullMethodName          = "/task.TaskService/GetTask"
-	TaskService_UpdateTask_FullMethodName       = "/task.TaskService/UpdateTask"
-	TaskService_DeleteTask_FullMethodName       = "/task.TaskService/DeleteTask"
-	TaskService_ListTasks_FullMethodName        = "/task.TaskService/ListTasks"
-	TaskService_CreateSubtask_FullMethodName    = "/task.TaskService/CreateSubtask"
-	TaskService_UpdateSubtask_FullMethodName    = "/task.TaskService/UpdateSubtask"
-	TaskService_DeleteSubtask_FullMethodName    = "/task.TaskService/DeleteSubtask"
-	TaskService_ListSubtasks_FullMethodName     = "/task.TaskService/ListSubtasks"
-	TaskService_AddComment_FullMethodName       = "/task.TaskService/AddComment"
-	TaskService_DeleteComment_FullMethodName    = "/task.TaskService/DeleteComment"
-	TaskService_ListComments_FullMethodName     = "/task.TaskService/ListComments"
-	TaskService_AddAttachment_FullMethodName    = "/task.TaskService/AddAttachment"
-	TaskService_DeleteAttachment_FullMethodName = "/task.TaskService/DeleteAttachment"
-	TaskService_ListAttachments_FullMethodName  = "/task.TaskService/ListAttachments"
-	TaskService_CreateTag_FullMethodName        = "/task.TaskService/CreateTag"
-	TaskService_ListTags_FullMethodName         = "/task.TaskService/ListTags"
-	TaskService_AddTaskTag_FullMethodName       = "/task.TaskService/AddTaskTag"
-	TaskService_RemoveTaskTag_FullMethodName    = "/task.TaskService/RemoveTaskTag"
+	TaskService_CreateTask_FullMethodName            = "/task.TaskService/CreateTask"
+	TaskService_GetTask_FullMethodName               = "/task.TaskService/GetTask"
+	TaskService_UpdateTask_FullMethodName            = "/task.TaskService/UpdateTask"
+	TaskService_DeleteTask_FullMethodName            = "/task.TaskService/DeleteTask"
+	TaskService_ListTasks_FullMethodName             = "/task.TaskService/ListTasks"
+	TaskService_ListTaskIDs_FullMethodName           = "/task.TaskService/ListTaskIDs"
+	TaskService_CountProjectTasks_FullMethodName     = "/task.TaskService/CountProjectTasks"
+	TaskService_GetTaskSummary_FullMethodName        = "/task.TaskService/GetTaskSummary"
+	TaskService_StreamTasks_FullMethodName           = "/task.TaskService/StreamTasks"
+	TaskService_BulkDeleteTasks_FullMethodName       = "/task.TaskService/BulkDeleteTasks"
+	TaskService_DemoteTask_FullMethodName            = "/task.TaskService/DemoteTask"
+	TaskService_DuplicateTask_FullMethodName         = "/task.TaskService/DuplicateTask"
+	TaskService_MoveTask_FullMethodName              = "/task.TaskService/MoveTask"
+	TaskService_WatchProjectTasks_FullMethodName     = "/task.TaskService/WatchProjectTasks"
+	TaskService_CreateSubtask_FullMethodName         = "/task.TaskService/CreateSubtask"
+	TaskService_CreateSubtasks_FullMethodName        = "/task.TaskService/CreateSubtasks"
+	TaskService_UpdateSubtask_FullMethodName         = "/task.TaskService/UpdateSubtask"
+	TaskService_DeleteSubtask_FullMethodName         = "/task.TaskService/DeleteSubtask"
+	TaskService_ListSubtasks_FullMethodName          = "/task.TaskService/ListSubtasks"
+	TaskService_MoveSubtask_FullMethodName           = "/task.TaskService/MoveSubtask"
+	TaskService_PromoteSubtask_FullMethodName        = "/task.TaskService/PromoteSubtask"
+	TaskService_AddComment_FullMethodName            = "/task.TaskService/AddComment"
+	TaskService_DeleteComment_FullMethodName         = "/task.TaskService/DeleteComment"
+	TaskService_DeleteTaskComments_FullMethodName    = "/task.TaskService/DeleteTaskComments"
+	TaskService_ListComments_FullMethodName          = "/task.TaskService/ListComments"
+	TaskService_AddAttachment_FullMethodName         = "/task.TaskService/AddAttachment"
+	TaskService_DeleteAttachment_FullMethodName      = "/task.TaskService/DeleteAttachment"
+	TaskService_DeleteTaskAttachments_FullMethodName = "/task.TaskService/DeleteTaskAttachments"
+	TaskService_ListAttachments_FullMethodName       = "/task.TaskService/ListAttachments"
+	TaskService_CreateTag_FullMethodName             = "/task.TaskService/CreateTag"
+	TaskService_ListTags_FullMethodName              = "/task.TaskService/ListTags"
+	TaskService_AddTaskTag_FullMethodName            = "/task.TaskService/AddTaskTag"
+	TaskService_RemoveTaskTag_FullMethodName         = "/task.TaskService/RemoveTaskTag"
+	TaskService_DeleteTag_FullMethodName             = "/task.TaskService/DeleteTag"
+	TaskService_AddWatcher_FullMethodName            = "/task.TaskService/AddWatcher"
+	TaskService_RemoveWatcher_FullMethodName         = "/task.TaskService/RemoveWatcher"
+	TaskService_ListWatchers_FullMethodName          = "/task.TaskService/ListWatchers"
 )
 
 // TaskServiceClient is the client API for TaskService service.
@@ -52,24 +70,48 @@ type TaskServiceClient interface {
 	UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
 	DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*Empty, error)
 	ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error)
+	ListTaskIDs(ctx context.Context, in *ListTaskIDsRequest, opts ...grpc.CallOption) (*ListTaskIDsResponse, error)
+	// Counts every project's tasks, for analytics to reconcile project stats
+	CountProjectTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CountProjectTasksResponse, error)
+	// Counts a project's tasks per status, and those overdue or unassigned
+	GetTaskSummary(ctx context.Context, in *GetTaskSummaryRequest, opts ...grpc.CallOption) (*TaskSummaryResponse, error)
+	// Streams every matching task in id order, a batch at a time, for exports
+	StreamTasks(ctx context.Context, in *StreamTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskBatch], error)
+	BulkDeleteTasks(ctx context.Context, in *BulkDeleteTasksRequest, opts ...grpc.CallOption) (*BulkDeleteTasksResponse, error)
+	DemoteTask(ctx context.Context, in *DemoteTaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error)
+	DuplicateTask(ctx context.Context, in *DuplicateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	// Moves a task, with its subtasks, comments and tags, to another project
+	MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
+	// Streams task changes in a project until the caller cancels
+	WatchProjectTasks(ctx context.Context, in *WatchProjectTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskEvent], error)
 	// Subtasks
 	CreateSubtask(ctx context.Context, in *CreateSubtaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error)
+	CreateSubtasks(ctx context.Context, in *CreateSubtasksRequest, opts ...grpc.CallOption) (*ListSubtasksResponse, error)
 	UpdateSubtask(ctx context.Context, in *UpdateSubtaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error)
 	DeleteSubtask(ctx context.Context, in *DeleteSubtaskRequest, opts ...grpc.CallOption) (*Empty, error)
 	ListSubtasks(ctx context.Context, in *ListSubtasksRequest, opts ...grpc.CallOption) (*ListSubtasksResponse, error)
+	MoveSubtask(ctx context.Context, in *MoveSubtaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error)
+	PromoteSubtask(ctx context.Context, in *PromoteSubtaskRequest, opts ...grpc.CallOption) (*TaskResponse, error)
 	// Comments
 	AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error)
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteTaskComments(ctx context.Context, in *DeleteTaskCommentsRequest, opts ...grpc.CallOption) (*DeleteTaskCommentsResponse, error)
 	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
 	// Attachments
 	AddAttachment(ctx context.Context, in *AddAttachmentRequest, opts ...grpc.CallOption) (*AttachmentResponse, error)
 	DeleteAttachment(ctx context.Context, in *DeleteAttachmentRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteTaskAttachments(ctx context.Context, in *DeleteTaskAttachmentsRequest, opts ...grpc.CallOption) (*DeleteTaskAttachmentsResponse, error)
 	ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error)
 	// Tags
 	CreateTag(ctx context.Context, in *CreateTagRequest, opts ...grpc.CallOption) (*TagResponse, error)
 	ListTags(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*ListTagsResponse, error)
 	AddTaskTag(ctx context.Context, in *AddTaskTagRequest, opts ...grpc.CallOption) (*Empty, error)
 	RemoveTaskTag(ctx context.Context, in *RemoveTaskTagRequest, opts ...grpc.CallOption) (*Empty, error)
+	DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Watchers
+	AddWatcher(ctx context.Context, in *AddWatcherRequest, opts ...grpc.CallOption) (*Empty, error)
+	RemoveWatcher(ctx context.Context, in *RemoveWatcherRequest, opts ...grpc.CallOption) (*Empty, error)
+	ListWatchers(ctx context.Context, in *ListWatchersRequest, opts ...grpc.CallOption) (*ListWatchersResponse, error)
 }
 
 type taskServiceClient struct {
@@ -130,6 +172,114 @@ func (c *taskServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest,
 	return out, nil
 }
 
+func (c *taskServiceClient) ListTaskIDs(ctx context.Context, in *ListTaskIDsRequest, opts ...grpc.CallOption) (*ListTaskIDsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTaskIDsResponse)
+	err := c.cc.Invoke(ctx, TaskService_ListTaskIDs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) CountProjectTasks(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*CountProjectTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountProjectTasksResponse)
+	err := c.cc.Invoke(ctx, TaskService_CountProjectTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) GetTaskSummary(ctx context.Context, in *GetTaskSummaryRequest, opts ...grpc.CallOption) (*TaskSummaryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskSummaryResponse)
+	err := c.cc.Invoke(ctx, TaskService_GetTaskSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) StreamTasks(ctx context.Context, in *StreamTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskBatch], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[0], TaskService_StreamTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StreamTasksRequest, TaskBatch]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_StreamTasksClient = grpc.ServerStreamingClient[TaskBatch]
+
+func (c *taskServiceClient) BulkDeleteTasks(ctx context.Context, in *BulkDeleteTasksRequest, opts ...grpc.CallOption) (*BulkDeleteTasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkDeleteTasksResponse)
+	err := c.cc.Invoke(ctx, TaskService_BulkDeleteTasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) DemoteTask(ctx context.Context, in *DemoteTaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubtaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_DemoteTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) DuplicateTask(ctx context.Context, in *DuplicateTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_DuplicateTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_MoveTask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) WatchProjectTasks(ctx context.Context, in *WatchProjectTasksRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TaskEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &TaskService_ServiceDesc.Streams[1], TaskService_WatchProjectTasks_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchProjectTasksRequest, TaskEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_WatchProjectTasksClient = grpc.ServerStreamingClient[TaskEvent]
+
 func (c *taskServiceClient) CreateSubtask(ctx context.Context, in *CreateSubtaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubtaskResponse)
@@ -140,6 +290,16 @@ func (c *taskServiceClient) CreateSubtask(ctx context.Context, in *CreateSubtask
 	return out, nil
 }
 
+func (c *taskServiceClient) CreateSubtasks(ctx context.Context, in *CreateSubtasksRequest, opts ...grpc.CallOption) (*ListSubtasksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSubtasksResponse)
+	err := c.cc.Invoke(ctx, TaskService_CreateSubtasks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskServiceClient) UpdateSubtask(ctx context.Context, in *UpdateSubtaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubtaskResponse)
@@ -170,6 +330,26 @@ func (c *taskServiceClient) ListSubtasks(ctx context.Context, in *ListSubtasksRe
 	return out, nil
 }
 
+func (c *taskServiceClient) MoveSubtask(ctx context.Context, in *MoveSubtaskRequest, opts ...grpc.CallOption) (*SubtaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubtaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_MoveSubtask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) PromoteSubtask(ctx context.Context, in *PromoteSubtaskRequest, opts ...grpc.CallOption) (*TaskResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TaskResponse)
+	err := c.cc.Invoke(ctx, TaskService_PromoteSubtask_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskServiceClient) AddComment(ctx context.Context, in *AddCommentRequest, opts ...grpc.CallOption) (*CommentResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CommentResponse)
@@ -190,6 +370,16 @@ func (c *taskServiceClient) DeleteComment(ctx context.Context, in *DeleteComment
 	return out, nil
 }
 
+func (c *taskServiceClient) DeleteTaskComments(ctx context.Context, in *DeleteTaskCommentsRequest, opts ...grpc.CallOption) (*DeleteTaskCommentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTaskCommentsResponse)
+	err := c.cc.Invoke(ctx, TaskService_DeleteTaskComments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListCommentsResponse)
@@ -220,6 +410,16 @@ func (c *taskServiceClient) DeleteAttachment(ctx context.Context, in *DeleteAtta
 	return out, nil
 }
 
+func (c *taskServiceClient) DeleteTaskAttachments(ctx context.Context, in *DeleteTaskAttachmentsRequest, opts ...grpc.CallOption) (*DeleteTaskAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteTaskAttachmentsResponse)
+	err := c.cc.Invoke(ctx, TaskService_DeleteTaskAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *taskServiceClient) ListAttachments(ctx context.Context, in *ListAttachmentsRequest, opts ...grpc.CallOption) (*ListAttachmentsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListAttachmentsResponse)
@@ -270,6 +470,46 @@ func (c *taskServiceClient) RemoveTaskTag(ctx context.Context, in *RemoveTaskTag
 	return out, nil
 }
 
+func (c *taskServiceClient) DeleteTag(ctx context.Context, in *DeleteTagRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, TaskService_DeleteTag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) AddWatcher(ctx context.Context, in *AddWatcherRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, TaskService_AddWatcher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) RemoveWatcher(ctx context.Context, in *RemoveWatcherRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, TaskService_RemoveWatcher_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *taskServiceClient) ListWatchers(ctx context.Context, in *ListWatchersRequest, opts ...grpc.CallOption) (*ListWatchersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWatchersResponse)
+	err := c.cc.Invoke(ctx, TaskService_ListWatchers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TaskServiceServer is the server API for TaskService service.
 // All implementations must embed UnimplementedTaskServiceServer
 // for forward compatibility.
@@ -282,24 +522,48 @@ type TaskServiceServer interface {
 	UpdateTask(context.Context, *UpdateTaskRequest) (*TaskResponse, error)
 	DeleteTask(context.Context, *DeleteTaskRequest) (*Empty, error)
 	ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error)
+	ListTaskIDs(context.Context, *ListTaskIDsRequest) (*ListTaskIDsResponse, error)
+	// Counts every project's tasks, for analytics to reconcile project stats
+	CountProjectTasks(context.Context, *Empty) (*CountProjectTasksResponse, error)
+	// Counts a project's tasks per status, and those overdue or unassigned
+	GetTaskSummary(context.Context, *GetTaskSummaryRequest) (*TaskSummaryResponse, error)
+	// Streams every matching task in id order, a batch at a time, for exports
+	StreamTasks(*StreamTasksRequest, grpc.ServerStreamingServer[TaskBatch]) error
+	BulkDeleteTasks(context.Context, *BulkDeleteTasksRequest) (*BulkDeleteTasksResponse, error)
+	DemoteTask(context.Context, *DemoteTaskRequest) (*SubtaskResponse, error)
+	DuplicateTask(context.Context, *DuplicateTaskRequest) (*TaskResponse, error)
+	// Moves a task, with its subtasks, comments and tags, to another project
+	MoveTask(context.Context, *MoveTaskRequest) (*TaskResponse, error)
+	// Streams task changes in a project until the caller cancels
+	WatchProjectTasks(*WatchProjectTasksRequest, grpc.ServerStreamingServer[TaskEvent]) error
 	// Subtasks
 	CreateSubtask(context.Context, *CreateSubtaskRequest) (*SubtaskResponse, error)
+	CreateSubtasks(context.Context, *CreateSubtasksRequest) (*ListSubtasksResponse, error)
 	UpdateSubtask(context.Context, *UpdateSubtaskRequest) (*SubtaskResponse, error)
 	DeleteSubtask(context.Context, *DeleteSubtaskRequest) (*Empty, error)
 	ListSubtasks(context.Context, *ListSubtasksRequest) (*ListSubtasksResponse, error)
+	MoveSubtask(context.Context, *MoveSubtaskRequest) (*SubtaskResponse, error)
+	PromoteSubtask(context.Context, *PromoteSubtaskRequest) (*TaskResponse, error)
 	// Comments
 	AddComment(context.Context, *AddCommentRequest) (*CommentResponse, error)
 	DeleteComment(context.Context, *DeleteCommentRequest) (*Empty, error)
+	DeleteTaskComments(context.Context, *DeleteTaskCommentsRequest) (*DeleteTaskCommentsResponse, error)
 	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
 	// Attachments
 	AddAttachment(context.Context, *AddAttachmentRequest) (*AttachmentResponse, error)
 	DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*Empty, error)
+	DeleteTaskAttachments(context.Context, *DeleteTaskAttachmentsRequest) (*DeleteTaskAttachmentsResponse, error)
 	ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error)
 	// Tags
 	CreateTag(context.Context, *CreateTagRequest) (*TagResponse, error)
 	ListTags(context.Context, *Empty) (*ListTagsResponse, error)
 	AddTaskTag(context.Context, *AddTaskTagRequest) (*Empty, error)
 	RemoveTaskTag(context.Context, *RemoveTaskTagRequest) (*Empty, error)
+	DeleteTag(context.Context, *DeleteTagRequest) (*Empty, error)
+	// Watchers
+	AddWatcher(context.Context, *AddWatcherRequest) (*Empty, error)
+	RemoveWatcher(context.Context, *RemoveWatcherRequest) (*Empty, error)
+	ListWatchers(context.Context, *ListWatchersRequest) (*ListWatchersResponse, error)
 	mustEmbedUnimplementedTaskServiceServer()
 }
 
@@ -325,9 +589,39 @@ func (UnimplementedTaskServiceServer) DeleteTask(context.Context, *DeleteTaskReq
 func (UnimplementedTaskServiceServer) ListTasks(context.Context, *ListTasksRequest) (*ListTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTasks not implemented")
 }
+func (UnimplementedTaskServiceServer) ListTaskIDs(context.Context, *ListTaskIDsRequest) (*ListTaskIDsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTaskIDs not implemented")
+}
+func (UnimplementedTaskServiceServer) CountProjectTasks(context.Context, *Empty) (*CountProjectTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountProjectTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) GetTaskSummary(context.Context, *GetTaskSummaryRequest) (*TaskSummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaskSummary not implemented")
+}
+func (UnimplementedTaskServiceServer) StreamTasks(*StreamTasksRequest, grpc.ServerStreamingServer[TaskBatch]) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) BulkDeleteTasks(context.Context, *BulkDeleteTasksRequest) (*BulkDeleteTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkDeleteTasks not implemented")
+}
+func (UnimplementedTaskServiceServer) DemoteTask(context.Context, *DemoteTaskRequest) (*SubtaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DemoteTask not implemented")
+}
+func (UnimplementedTaskServiceServer) DuplicateTask(context.Context, *DuplicateTaskRequest) (*TaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DuplicateTask not implemented")
+}
+func (UnimplementedTaskServiceServer) MoveTask(context.Context, *MoveTaskRequest) (*TaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveTask not implemented")
+}
+func (UnimplementedTaskServiceServer) WatchProjectTasks(*WatchProjectTasksRequest, grpc.ServerStreamingServer[TaskEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchProjectTasks not implemented")
+}
 func (UnimplementedTaskServiceServer) CreateSubtask(context.Context, *CreateSubtaskRequest) (*SubtaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateSubtask not implemented")
 }
+func (UnimplementedTaskServiceServer) CreateSubtasks(context.Context, *CreateSubtasksRequest) (*ListSubtasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSubtasks not implemented")
+}
 func (UnimplementedTaskServiceServer) UpdateSubtask(context.Context, *UpdateSubtaskRequest) (*SubtaskResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateSubtask not implemented")
 }
@@ -337,12 +631,21 @@ func (UnimplementedTaskServiceServer) DeleteSubtask(context.Context, *DeleteSubt
 func (UnimplementedTaskServiceServer) ListSubtasks(context.Context, *ListSubtasksRequest) (*ListSubtasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSubtasks not implemented")
 }
+func (UnimplementedTaskServiceServer) MoveSubtask(context.Context, *MoveSubtaskRequest) (*SubtaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveSubtask not implemented")
+}
+func (UnimplementedTaskServiceServer) PromoteSubtask(context.Context, *PromoteSubtaskRequest) (*TaskResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteSubtask not implemented")
+}
 func (UnimplementedTaskServiceServer) AddComment(context.Context, *AddCommentRequest) (*CommentResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddComment not implemented")
 }
 func (UnimplementedTaskServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
 }
+func (UnimplementedTaskServiceServer) DeleteTaskComments(context.Context, *DeleteTaskCommentsRequest) (*DeleteTaskCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTaskComments not implemented")
+}
 func (UnimplementedTaskServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
 }
@@ -352,6 +655,9 @@ func (UnimplementedTaskServiceServer) AddAttachment(context.Context, *AddAttachm
 func (UnimplementedTaskServiceServer) DeleteAttachment(context.Context, *DeleteAttachmentRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
 }
+func (UnimplementedTaskServiceServer) DeleteTaskAttachments(context.Context, *DeleteTaskAttachmentsRequest) (*DeleteTaskAttachmentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTaskAttachments not implemented")
+}
 func (UnimplementedTaskServiceServer) ListAttachments(context.Context, *ListAttachmentsRequest) (*ListAttachmentsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
 }
@@ -367,6 +673,18 @@ func (UnimplementedTaskServiceServer) AddTaskTag(context.Context, *AddTaskTagReq
 func (UnimplementedTaskServiceServer) RemoveTaskTag(context.Context, *RemoveTaskTagRequest) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveTaskTag not implemented")
 }
+func (UnimplementedTaskServiceServer) DeleteTag(context.Context, *DeleteTagRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteTag not implemented")
+}
+func (UnimplementedTaskServiceServer) AddWatcher(context.Context, *AddWatcherRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddWatcher not implemented")
+}
+func (UnimplementedTaskServiceServer) RemoveWatcher(context.Context, *RemoveWatcherRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveWatcher not implemented")
+}
+func (UnimplementedTaskServiceServer) ListWatchers(context.Context, *ListWatchersRequest) (*ListWatchersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWatchers not implemented")
+}
 func (UnimplementedTaskServiceServer) mustEmbedUnimplementedTaskServiceServer() {}
 func (UnimplementedTaskServiceServer) testEmbeddedByValue()                     {}
 
@@ -478,6 +796,154 @@ func _TaskService_ListTasks_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_ListTaskIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTaskIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListTaskIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ListTaskIDs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListTaskIDs(ctx, req.(*ListTaskIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_CountProjectTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).CountProjectTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_CountProjectTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).CountProjectTasks(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_GetTaskSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaskSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).GetTaskSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_GetTaskSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).GetTaskSummary(ctx, req.(*GetTaskSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_StreamTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).StreamTasks(m, &grpc.GenericServerStream[StreamTasksRequest, TaskBatch]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_StreamTasksServer = grpc.ServerStreamingServer[TaskBatch]
+
+func _TaskService_BulkDeleteTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkDeleteTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).BulkDeleteTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_BulkDeleteTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).BulkDeleteTasks(ctx, req.(*BulkDeleteTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_DemoteTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DemoteTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DemoteTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DemoteTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DemoteTask(ctx, req.(*DemoteTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_DuplicateTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DuplicateTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DuplicateTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DuplicateTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DuplicateTask(ctx, req.(*DuplicateTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_MoveTask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveTaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).MoveTask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_MoveTask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).MoveTask(ctx, req.(*MoveTaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_WatchProjectTasks_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchProjectTasksRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TaskServiceServer).WatchProjectTasks(m, &grpc.GenericServerStream[WatchProjectTasksRequest, TaskEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type TaskService_WatchProjectTasksServer = grpc.ServerStreamingServer[TaskEvent]
+
 func _TaskService_CreateSubtask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateSubtaskRequest)
 	if err := dec(in); err != nil {
@@ -496,6 +962,24 @@ func _TaskService_CreateSubtask_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_CreateSubtasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSubtasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).CreateSubtasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_CreateSubtasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).CreateSubtasks(ctx, req.(*CreateSubtasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskService_UpdateSubtask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateSubtaskRequest)
 	if err := dec(in); err != nil {
@@ -550,6 +1034,42 @@ func _TaskService_ListSubtasks_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_MoveSubtask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveSubtaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).MoveSubtask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_MoveSubtask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).MoveSubtask(ctx, req.(*MoveSubtaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_PromoteSubtask_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteSubtaskRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).PromoteSubtask(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_PromoteSubtask_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).PromoteSubtask(ctx, req.(*PromoteSubtaskRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskService_AddComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddCommentRequest)
 	if err := dec(in); err != nil {
@@ -586,6 +1106,24 @@ func _TaskService_DeleteComment_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_DeleteTaskComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteTaskComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteTaskComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteTaskComments(ctx, req.(*DeleteTaskCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListCommentsRequest)
 	if err := dec(in); err != nil {
@@ -640,6 +1178,24 @@ func _TaskService_DeleteAttachment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_DeleteTaskAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTaskAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteTaskAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteTaskAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteTaskAttachments(ctx, req.(*DeleteTaskAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TaskService_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListAttachmentsRequest)
 	if err := dec(in); err != nil {
@@ -730,6 +1286,78 @@ func _TaskService_RemoveTaskTag_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TaskService_DeleteTag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteTagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).DeleteTag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_DeleteTag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).DeleteTag(ctx, req.(*DeleteTagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_AddWatcher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddWatcherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).AddWatcher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_AddWatcher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).AddWatcher(ctx, req.(*AddWatcherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_RemoveWatcher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveWatcherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).RemoveWatcher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_RemoveWatcher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).RemoveWatcher(ctx, req.(*RemoveWatcherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TaskService_ListWatchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWatchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TaskServiceServer).ListWatchers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TaskService_ListWatchers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TaskServiceServer).ListWatchers(ctx, req.(*ListWatchersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TaskService_ServiceDesc is the grpc.ServiceDesc for TaskService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -757,10 +1385,42 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTasks",
 			Handler:    _TaskService_ListTasks_Handler,
 		},
+		{
+			MethodName: "ListTaskIDs",
+			Handler:    _TaskService_ListTaskIDs_Handler,
+		},
+		{
+			MethodName: "CountProjectTasks",
+			Handler:    _TaskService_CountProjectTasks_Handler,
+		},
+		{
+			MethodName: "GetTaskSummary",
+			Handler:    _TaskService_GetTaskSummary_Handler,
+		},
+		{
+			MethodName: "BulkDeleteTasks",
+			Handler:    _TaskService_BulkDeleteTasks_Handler,
+		},
+		{
+			MethodName: "DemoteTask",
+			Handler:    _TaskService_DemoteTask_Handler,
+		},
+		{
+			MethodName: "DuplicateTask",
+			Handler:    _TaskService_DuplicateTask_Handler,
+		},
+		{
+			MethodName: "MoveTask",
+			Handler:    _TaskService_MoveTask_Handler,
+		},
 		{
 			MethodName: "CreateSubtask",
 			Handler:    _TaskService_CreateSubtask_Handler,
 		},
+		{
+			MethodName: "CreateSubtasks",
+			Handler:    _TaskService_CreateSubtasks_Handler,
+		},
 		{
 			MethodName: "UpdateSubtask",
 			Handler:    _TaskService_UpdateSubtask_Handler,
@@ -773,6 +1433,14 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSubtasks",
 			Handler:    _TaskService_ListSubtasks_Handler,
 		},
+		{
+			MethodName: "MoveSubtask",
+			Handler:    _TaskService_MoveSubtask_Handler,
+		},
+		{
+			MethodName: "PromoteSubtask",
+			Handler:    _TaskService_PromoteSubtask_Handler,
+		},
 		{
 			MethodName: "AddComment",
 			Handler:    _TaskService_AddComment_Handler,
@@ -781,6 +1449,10 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteComment",
 			Handler:    _TaskService_DeleteComment_Handler,
 		},
+		{
+			MethodName: "DeleteTaskComments",
+			Handler:    _TaskService_DeleteTaskComments_Handler,
+		},
 		{
 			MethodName: "ListComments",
 			Handler:    _TaskService_ListComments_Handler,
@@ -793,6 +1465,10 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAttachment",
 			Handler:    _TaskService_DeleteAttachment_Handler,
 		},
+		{
+			MethodName: "DeleteTaskAttachments",
+			Handler:    _TaskService_DeleteTaskAttachments_Handler,
+		},
 		{
 			MethodName: "ListAttachments",
 			Handler:    _TaskService_ListAttachments_Handler,
@@ -813,7 +1489,34 @@ var TaskService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveTaskTag",
 			Handler:    _TaskService_RemoveTaskTag_Handler,
 		},
+		{
+			MethodName: "DeleteTag",
+			Handler:    _TaskService_DeleteTag_Handler,
+		},
+		{
+			MethodName: "AddWatcher",
+			Handler:    _TaskService_AddWatcher_Handler,
+		},
+		{
+			MethodName: "RemoveWatcher",
+			Handler:    _TaskService_RemoveWatcher_Handler,
+		},
+		{
+			MethodName: "ListWatchers",
+			Handler:    _TaskService_ListWatchers_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTasks",
+			Handler:       _TaskService_StreamTasks_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchProjectTasks",
+			Handler:       _TaskService_WatchProjectTasks_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/task/task.proto",
 }
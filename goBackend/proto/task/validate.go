@@ -0,0 +1,110 @@
+package task
+
+import (
+	"errors"
+	"strings"
+)
+
+// Request rules checked by the validation interceptor before a request
+// reaches the handler. Rules that need the database stay in the use cases.
+
+var (
+	errTaskIDRequired    = errors.New("task id is required")
+	errProjectIDRequired = errors.New("project id is required")
+	errTitleRequired     = errors.New("title is required")
+	errPriorityRange     = errors.New("priority must be between 1 (high) and 4 (none)")
+	errNegativeAssignee  = errors.New("assigned_to must not be negative")
+)
+
+// validPriority accepts 0, which leaves the priority at its default or unchanged
+func validPriority(p int32) bool {
+	return p >= 0 && p <= 4
+}
+
+// Validate checks a CreateTaskRequest
+func (r *CreateTaskRequest) Validate() error {
+	switch {
+	case r.ProjectId <= 0:
+		return errProjectIDRequired
+	case strings.TrimSpace(r.Title) == "":
+		return errTitleRequired
+	case !validPriority(r.Priority):
+		return errPriorityRange
+	case r.AssignedTo < 0:
+		return errNegativeAssignee
+	}
+	return nil
+}
+
+// Validate checks an UpdateTaskRequest
+func (r *UpdateTaskRequest) Validate() error {
+	switch {
+	case r.Id <= 0:
+		return errTaskIDRequired
+	case r.Title != "" && strings.TrimSpace(r.Title) == "":
+		return errTitleRequired
+	case !validPriority(r.Priority):
+		return errPriorityRange
+	case r.AssignedTo < 0:
+		return errNegativeAssignee
+	case r.ClearAssignedTo && r.AssignedTo != 0, r.ClearDueDate && r.DueDate != nil:
+		return errors.New("a field cannot be both set and cleared")
+	}
+	return nil
+}
+
+// Validate checks a CreateSubtaskRequest
+func (r *CreateSubtaskRequest) Validate() error {
+	switch {
+	case r.TaskId <= 0:
+		return errTaskIDRequired
+	case strings.TrimSpace(r.Title) == "":
+		return errTitleRequired
+	case r.AssignedTo < 0:
+		return errNegativeAssignee
+	}
+	return nil
+}
+
+// Validate checks an UpdateSubtaskRequest
+func (r *UpdateSubtaskRequest) Validate() error {
+	switch {
+	case r.Id <= 0:
+		return errors.New("subtask id is required")
+	case r.Title != "" && strings.TrimSpace(r.Title) == "":
+		return errTitleRequired
+	case r.AssignedTo < 0:
+		return errNegativeAssignee
+	}
+	return nil
+}
+
+// Validate checks an AddCommentRequest
+func (r *AddCommentRequest) Validate() error {
+	switch {
+	case r.TaskId <= 0:
+		return errTaskIDRequired
+	case strings.TrimSpace(r.Comment) == "":
+		return errors.New("comment is required")
+	}
+	return nil
+}
+
+// Validate checks an AddAttachmentRequest
+func (r *AddAttachmentRequest) Validate() error {
+	switch {
+	case r.TaskId <= 0:
+		return errTaskIDRequired
+	case r.FileUrl != "" && r.MediaFileId != 0:
+		return errors.New("only one of file_url or media_file_id may be set")
+	}
+	return nil
+}
+
+// Validate checks a CreateTagRequest
+func (r *CreateTagRequest) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("tag name is required")
+	}
+	return nil
+}
@@ -1,32 +1,61 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 
 	"github.com/portfolio/analytics-service/internal/config"
 	grpcHandler "github.com/portfolio/analytics-service/internal/delivery/grpc"
+	domain "github.com/portfolio/analytics-service/internal/domain/repository"
 	"github.com/portfolio/analytics-service/internal/infrastructure/repository"
+	"github.com/portfolio/analytics-service/internal/infrastructure/task"
 	"github.com/portfolio/analytics-service/internal/usecase"
+	pb "github.com/portfolio/proto/analytics"
+	"github.com/portfolio/shared/cache"
 	"github.com/portfolio/shared/database"
+	"github.com/portfolio/shared/grpctls"
+	"github.com/portfolio/shared/logging"
+	"github.com/portfolio/shared/metrics"
 	"github.com/portfolio/shared/middleware"
+	"github.com/portfolio/shared/migrations"
+	"github.com/portfolio/shared/pagination"
 	"google.golang.org/grpc"
-	pb "github.com/portfolio/proto/analytics"
 )
 
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply database migrations and exit")
+	flag.Parse()
+
+	logging.Setup("analytics-service")
+
 	// Load configuration
 	cfg := config.Load()
+	tlsConfig := grpctls.Config{
+		Enabled:  cfg.GRPCTLSEnabled,
+		CertFile: cfg.GRPCTLSCertFile,
+		KeyFile:  cfg.GRPCTLSKeyFile,
+		CAFile:   cfg.GRPCTLSCAFile,
+	}
 
 	// Initialize database connection
 	dbConfig := database.Config{
-		Host:     cfg.DBHost,
-		Port:     cfg.DBPort,
-		User:     cfg.DBUser,
-		Password: cfg.DBPassword,
-		DBName:   cfg.DBName,
-		SSLMode:  cfg.DBSSLMode,
+		Host:        cfg.DBHost,
+		Port:        cfg.DBPort,
+		User:        cfg.DBUser,
+		Password:    cfg.DBPassword,
+		DBName:      cfg.DBName,
+		SSLMode:     cfg.DBSSLMode,
+		SSLRootCert: cfg.DBSSLRootCert,
+		SSLCert:     cfg.DBSSLCert,
+		SSLKey:      cfg.DBSSLKey,
+
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
 	}
 
 	pool, err := database.NewPool(dbConfig)
@@ -37,19 +66,67 @@ func main() {
 
 	db := pool.GetDB()
 
+	// Apply pending schema migrations
+	if _, err := migrations.Run(context.Background(), db); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if *migrateOnly {
+		log.Println("Migrations applied")
+		return
+	}
+
 	// Initialize repositories
 	viewRepo := repository.NewPostgresProjectViewRepository(db)
 	actRepo := repository.NewPostgresTaskActivityRepository(db)
-	statsRepo := repository.NewPostgresProjectStatsRepository(db)
+	var statsRepo domain.ProjectStatsRepository = repository.NewPostgresProjectStatsRepository(db)
+	// The dashboard is polled often, so stats reads are cached; updates
+	// through statsRepo drop the entries they replace. Redis shares the
+	// cache and its invalidations between replicas.
+	if cfg.StatsCacheTTL > 0 {
+		statsCache := cache.New(context.Background(), cache.Config{RedisURL: cfg.RedisURL, Namespace: "analytics"})
+		statsRepo = repository.NewCachedProjectStatsRepository(statsRepo, statsCache, cfg.StatsCacheTTL)
+	}
 
 	// Initialize use cases
-	analyticsUseCase := usecase.NewAnalyticsUseCase(viewRepo, actRepo, statsRepo)
+	analyticsUseCase := usecase.NewAnalyticsUseCase(viewRepo, actRepo, statsRepo, pagination.New(cfg.DefaultPageSize, cfg.MaxPageSize))
+
+	// Project stats are periodically rebuilt from the task service's counts
+	// so missed updates don't leave the dashboard wrong
+	if cfg.StatsRecomputeInterval > 0 {
+		dialCreds, err := grpctls.DialOption(tlsConfig)
+		if err != nil {
+			log.Fatalf("Failed to set up gRPC client TLS: %v", err)
+		}
+		taskConn, err := grpc.Dial(cfg.TaskServiceURL, dialCreds)
+		if err != nil {
+			log.Fatalf("Failed to connect to task service: %v", err)
+		}
+		defer taskConn.Close()
+
+		recomputer := usecase.NewStatsRecomputer(statsRepo, task.NewClient(taskConn))
+		go recomputer.Run(context.Background(), cfg.StatsRecomputeInterval)
+	}
+
+	// Metrics
+	registry := metrics.NewRegistry()
+	grpcMetrics := metrics.NewGRPCServerMetrics(registry)
+	metrics.Serve(cfg.MetricsPort, registry)
+
+	// Plaintext unless GRPC_TLS_ENABLED is set
+	serverCreds, err := grpctls.ServerOption(tlsConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up gRPC TLS: %v", err)
+	}
 
 	// Create gRPC server with middleware
 	grpcServer := grpc.NewServer(
+		serverCreds,
 		grpc.ChainUnaryInterceptor(
 			middleware.RecoveryInterceptor(),
+			grpcMetrics.UnaryInterceptor(),
 			middleware.LoggingInterceptor(),
+			middleware.IdentityInterceptor(),
+			middleware.ValidationInterceptor(),
 		),
 	)
 
@@ -3,8 +3,11 @@ module github.com/portfolio/analytics-service
 go 1.21
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/lib/pq v1.10.9
 	github.com/portfolio/proto v0.0.0
 	github.com/portfolio/shared v0.0.0
+	golang.org/x/sync v0.8.0
 	google.golang.org/grpc v1.64.0
 	google.golang.org/protobuf v1.34.0
 )
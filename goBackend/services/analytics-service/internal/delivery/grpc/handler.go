@@ -2,12 +2,15 @@ package grpc
 
 import (
 	"context"
-	"fmt"
+	"time"
 
+	"github.com/portfolio/analytics-service/internal/domain/entity"
 	"github.com/portfolio/analytics-service/internal/usecase"
 	pb "github.com/portfolio/proto/analytics"
+	"github.com/portfolio/shared/middleware"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // AnalyticsServer implements the AnalyticsService gRPC server
@@ -25,6 +28,172 @@ func NewAnalyticsServer(
 	}
 }
 
+// RecordProjectView records that the caller forwarded in the metadata viewed
+// a project; the request's user_id is ignored so views can't be spoofed
+func (s *AnalyticsServer) RecordProjectView(ctx context.Context, req *pb.RecordProjectViewRequest) (*pb.Empty, error) {
+	caller, ok := middleware.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller identity required")
+	}
+	if err := s.analyticsUseCase.RecordProjectView(ctx, req.ProjectId, caller.UserID); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Empty{}, nil
+}
+
+// BatchRecordProjectViews records views a client collected. Entries without
+// a user are the caller's; only admins may record views for someone else.
+func (s *AnalyticsServer) BatchRecordProjectViews(ctx context.Context, req *pb.BatchRecordProjectViewsRequest) (*pb.BatchRecordProjectViewsResponse, error) {
+	caller, ok := middleware.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller identity required")
+	}
+
+	views := make([]*entity.ProjectView, len(req.Views))
+	for i, e := range req.Views {
+		userID := e.UserId
+		if userID == 0 {
+			userID = caller.UserID
+		}
+		if userID != caller.UserID && caller.Role != "admin" {
+			return nil, status.Error(codes.PermissionDenied, "only admins may record views for other users")
+		}
+		views[i] = &entity.ProjectView{ProjectID: e.ProjectId, UserID: userID}
+		if e.ViewedAt != nil {
+			views[i].ViewedAt = e.ViewedAt.AsTime()
+		}
+	}
+
+	recorded, skipped, err := s.analyticsUseCase.BatchRecordProjectViews(ctx, views)
+	if err != nil {
+		if err == usecase.ErrEmptyViewBatch || err == usecase.ErrViewBatchTooLarge {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.BatchRecordProjectViewsResponse{Recorded: int32(recorded), Skipped: int32(skipped)}, nil
+}
+
+// GetProjectViews returns a page of views for a project
+func (s *AnalyticsServer) GetProjectViews(ctx context.Context, req *pb.GetProjectViewsRequest) (*pb.ProjectViewsResponse, error) {
+	var startDate, endDate *time.Time
+	if req.StartDate != nil {
+		t := req.StartDate.AsTime()
+		startDate = &t
+	}
+	if req.EndDate != nil {
+		t := req.EndDate.AsTime()
+		endDate = &t
+	}
+
+	views, total, err := s.analyticsUseCase.GetProjectViews(ctx, req.ProjectId, startDate, endDate, int(req.Page), int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoViews := make([]*pb.ProjectView, len(views))
+	for i, v := range views {
+		protoViews[i] = &pb.ProjectView{
+			Id:        v.ID,
+			ProjectId: v.ProjectID,
+			UserId:    v.UserID,
+			ViewedAt:  timestamppb.New(v.ViewedAt),
+		}
+	}
+	return &pb.ProjectViewsResponse{
+		Views:      protoViews,
+		TotalViews: int32(total),
+		Limit:      int32(s.analyticsUseCase.PageLimit(int(req.Limit))),
+	}, nil
+}
+
+// GetTrendingProjects returns the most viewed projects since the given time
+func (s *AnalyticsServer) GetTrendingProjects(ctx context.Context, req *pb.GetTrendingProjectsRequest) (*pb.TrendingProjectsResponse, error) {
+	if req.Since == nil {
+		return nil, status.Error(codes.InvalidArgument, "since is required")
+	}
+	scope := usecase.DashboardScope{AllProjects: req.AllProjects, ProjectIDs: req.ProjectIds}
+	trends, err := s.analyticsUseCase.GetTrendingProjects(ctx, req.Since.AsTime(), scope, req.Sort, int(req.Limit))
+	if err != nil {
+		if err == usecase.ErrInvalidTrendRange || err == usecase.ErrInvalidTrendSort {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.TrendingProjectsResponse{}
+	for _, t := range trends {
+		resp.Projects = append(resp.Projects, &pb.ProjectTrend{
+			ProjectId: t.ProjectID,
+			Views:     int32(t.Views),
+			Score:     t.Score,
+		})
+	}
+	return resp, nil
+}
+
+// GetRecentlyViewedProjects returns the projects a user viewed last
+func (s *AnalyticsServer) GetRecentlyViewedProjects(ctx context.Context, req *pb.GetRecentlyViewedProjectsRequest) (*pb.RecentlyViewedProjectsResponse, error) {
+	caller, ok := middleware.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller identity required")
+	}
+	if caller.UserID != req.UserId && caller.Role != "admin" {
+		return nil, status.Error(codes.PermissionDenied, "cannot list another user's views")
+	}
+	scope := usecase.DashboardScope{AllProjects: req.AllProjects, ProjectIDs: req.ProjectIds}
+	views, err := s.analyticsUseCase.GetRecentlyViewedProjects(ctx, req.UserId, scope, int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.RecentlyViewedProjectsResponse{}
+	for _, v := range views {
+		resp.Views = append(resp.Views, &pb.ProjectView{
+			Id:        v.ID,
+			ProjectId: v.ProjectID,
+			UserId:    v.UserID,
+			ViewedAt:  timestamppb.New(v.ViewedAt),
+		})
+	}
+	return resp, nil
+}
+
+// GetTaskActivities returns a page of activities for a task, project or user
+func (s *AnalyticsServer) GetTaskActivities(ctx context.Context, req *pb.GetTaskActivitiesRequest) (*pb.TaskActivitiesResponse, error) {
+	if req.UserId != 0 {
+		caller, ok := middleware.CallerFromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "caller identity required")
+		}
+		if caller.UserID != req.UserId && caller.Role != "admin" {
+			return nil, status.Error(codes.PermissionDenied, "cannot list another user's activities")
+		}
+	}
+	activities, total, err := s.analyticsUseCase.GetTaskActivities(ctx, req.TaskId, req.ProjectId, req.UserId, int(req.Page), int(req.Limit))
+	if err != nil {
+		if err == usecase.ErrMissingTarget {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoActivities := make([]*pb.TaskActivity, len(activities))
+	for i, a := range activities {
+		protoActivities[i] = &pb.TaskActivity{
+			Id:        a.ID,
+			TaskId:    a.TaskID,
+			UserId:    a.UserID,
+			Action:    a.Action,
+			CreatedAt: timestamppb.New(a.CreatedAt),
+		}
+	}
+	return &pb.TaskActivitiesResponse{
+		Activities: protoActivities,
+		Total:      int32(total),
+		Limit:      int32(s.analyticsUseCase.PageLimit(int(req.Limit))),
+	}, nil
+}
 
 func (s *AnalyticsServer) RecordTaskActivity(ctx context.Context, req *pb.RecordTaskActivityRequest) (*pb.Empty, error) {
 
@@ -39,10 +208,11 @@ func (s *AnalyticsServer) RecordTaskActivity(ctx context.Context, req *pb.Record
 
 // GetProjectStats returns project stats
 func (s *AnalyticsServer) GetProjectStats(ctx context.Context, req *pb.GetProjectStatsRequest) (*pb.ProjectStatsResponse, error) {
-	fmt.Println("GetProjectStats")
-	fmt.Println( req.ProjectId)
 	stats, err := s.analyticsUseCase.GetProjectStats(ctx, req.ProjectId)
 	if err != nil {
+		if err == usecase.ErrInvalidProjectID {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.ProjectStatsResponse{
@@ -56,13 +226,75 @@ func (s *AnalyticsServer) GetProjectStats(ctx context.Context, req *pb.GetProjec
 }
 
 func (s *AnalyticsServer) UpdateProjectStats(ctx context.Context, req *pb.UpdateProjectStatsRequest) (*pb.ProjectStatsResponse, error) {
-	fmt.Println("UpdateProjectStats")
-	fmt.Println( req.ProjectId)
-	fmt.Println( req.TotalTasks)
-	fmt.Println( req.CompletedTasks)
 	_ , err := s.analyticsUseCase.UpdateProjectStats(ctx, req.ProjectId, int(req.TotalTasks), int(req.CompletedTasks))
 	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.ProjectStatsResponse{}, nil
 }
+
+// GetCycleTime returns the lead times of a project's tasks completed in a range
+func (s *AnalyticsServer) GetCycleTime(ctx context.Context, req *pb.GetCycleTimeRequest) (*pb.CycleTimeResponse, error) {
+	if req.Since == nil || req.Until == nil {
+		return nil, status.Error(codes.InvalidArgument, "since and until are required")
+	}
+	cycle, err := s.analyticsUseCase.GetCycleTime(ctx, req.ProjectId, req.Since.AsTime(), req.Until.AsTime())
+	if err != nil {
+		if err == usecase.ErrInvalidProjectID || err == usecase.ErrInvalidCycleTimeRange {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CycleTimeResponse{
+		ProjectId:              cycle.ProjectID,
+		CompletedTasks:         int32(cycle.CompletedTasks),
+		AverageLeadTimeSeconds: cycle.AverageLeadTime.Seconds(),
+		MedianLeadTimeSeconds:  cycle.MedianLeadTime.Seconds(),
+	}, nil
+}
+
+// GetDashboardStats returns the dashboard over the requested projects, with
+// the user sections when a user is given
+func (s *AnalyticsServer) GetDashboardStats(ctx context.Context, req *pb.GetDashboardStatsRequest) (*pb.DashboardStatsResponse, error) {
+	scope := usecase.DashboardScope{AllProjects: req.AllProjects, ProjectIDs: req.ProjectIds}
+	dashboard, err := s.analyticsUseCase.GetDashboardStats(ctx, req.UserId, scope)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.DashboardStatsResponse{
+		TotalProjects:  int32(dashboard.TotalProjects),
+		ActiveProjects: int32(dashboard.ActiveProjects),
+		TotalTasks:     int32(dashboard.TotalTasks),
+		CompletedTasks: int32(dashboard.CompletedTasks),
+		PendingTasks:   int32(dashboard.PendingTasks),
+		UserViews:      int32(dashboard.UserViews),
+		FailedSections: dashboard.FailedSections,
+	}
+	for _, stats := range dashboard.ProjectStats {
+		resp.ProjectStats = append(resp.ProjectStats, &pb.ProjectStats{
+			ProjectId:       stats.ProjectID,
+			TotalTasks:      int32(stats.TotalTasks),
+			CompletedTasks:  int32(stats.CompletedTasks),
+			ProgressPercent: stats.ProgressPercent,
+			LastUpdated:     timestamppb.New(stats.LastUpdated),
+		})
+	}
+	for _, a := range dashboard.RecentActivity {
+		resp.RecentActivity = append(resp.RecentActivity, &pb.TaskActivity{
+			Id:        a.ID,
+			TaskId:    a.TaskID,
+			UserId:    a.UserID,
+			Action:    a.Action,
+			CreatedAt: timestamppb.New(a.CreatedAt),
+		})
+	}
+	if counts := dashboard.AssignedTasks; counts != nil {
+		resp.AssignedTasks = &pb.AssignedTaskCounts{
+			Total:     int32(counts.Total),
+			Completed: int32(counts.Completed),
+			Pending:   int32(counts.Pending),
+		}
+	}
+	return resp, nil
+}
@@ -1,6 +1,9 @@
 package entity
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // ProjectView represents a project view event
 type ProjectView struct {
@@ -19,6 +22,50 @@ func NewProjectView(projectID, userID int64) *ProjectView {
 	}
 }
 
+// ViewDedupWindow is how long after a user's view of a project further
+// views of it by the same user are dropped from batches, so client retries
+// and reloads don't inflate the counts
+const ViewDedupWindow = 30 * time.Minute
+
+// DedupeViews drops views that repeat a user's view of the same project
+// within window of the last one kept, oldest first. Anonymous views are
+// always kept since they can't be told apart.
+func DedupeViews(views []*ProjectView, window time.Duration) []*ProjectView {
+	sorted := make([]*ProjectView, len(views))
+	copy(sorted, views)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ViewedAt.Before(sorted[j].ViewedAt)
+	})
+
+	type viewer struct{ projectID, userID int64 }
+	last := make(map[viewer]time.Time)
+	kept := sorted[:0]
+	for _, v := range sorted {
+		if v.UserID != 0 {
+			key := viewer{v.ProjectID, v.UserID}
+			if seen, ok := last[key]; ok && v.ViewedAt.Sub(seen) < window {
+				continue
+			}
+			last[key] = v.ViewedAt
+		}
+		kept = append(kept, v)
+	}
+	return kept
+}
+
+// ProjectTrend is a project's views over a time range
+type ProjectTrend struct {
+	ProjectID int64   `json:"project_id"`
+	Views     int     `json:"views"`
+	Score     float64 `json:"score"` // views weighted by how recent they are
+}
+
+// Trending project orderings
+const (
+	TrendSortScore = "trending"
+	TrendSortViews = "views"
+)
+
 // TaskActivity represents a task activity event
 type TaskActivity struct {
 	ID        int64     `json:"id"`
@@ -80,12 +127,43 @@ func (s *ProjectStats) UpdateProgress() {
 	s.LastUpdated = time.Now()
 }
 
-// DashboardStats represents dashboard statistics
+// DashboardStats represents dashboard statistics. The user sections are
+// only filled for a dashboard requested for a user; FailedSections names the
+// sections that could not be loaded and were left empty.
 type DashboardStats struct {
-	TotalProjects  int             `json:"total_projects"`
-	ActiveProjects int             `json:"active_projects"`
-	TotalTasks     int             `json:"total_tasks"`
-	CompletedTasks int             `json:"completed_tasks"`
-	PendingTasks   int             `json:"pending_tasks"`
-	ProjectStats   []*ProjectStats `json:"project_stats"`
+	TotalProjects  int                 `json:"total_projects"`
+	ActiveProjects int                 `json:"active_projects"`
+	TotalTasks     int                 `json:"total_tasks"`
+	CompletedTasks int                 `json:"completed_tasks"`
+	PendingTasks   int                 `json:"pending_tasks"`
+	ProjectStats   []*ProjectStats     `json:"project_stats"`
+	RecentActivity []*TaskActivity     `json:"recent_activity,omitempty"`
+	UserViews      int                 `json:"user_views"`
+	AssignedTasks  *AssignedTaskCounts `json:"assigned_tasks,omitempty"`
+	FailedSections []string            `json:"failed_sections,omitempty"`
 }
+
+// AssignedTaskCounts counts the tasks assigned to a user
+type AssignedTaskCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+	Pending   int `json:"pending"`
+}
+
+// CycleTime is how long a project's tasks completed in a time range took,
+// measured as lead time from creation to completion. The durations are zero
+// when no task was completed in the range.
+type CycleTime struct {
+	ProjectID       int64         `json:"project_id"`
+	CompletedTasks  int           `json:"completed_tasks"`
+	AverageLeadTime time.Duration `json:"average_lead_time"`
+	MedianLeadTime  time.Duration `json:"median_lead_time"`
+}
+
+// Dashboard sections, as reported in DashboardStats.FailedSections
+const (
+	SectionProjectStats  = "project_stats"
+	SectionActivity      = "recent_activity"
+	SectionViews         = "user_views"
+	SectionAssignedTasks = "assigned_tasks"
+)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/portfolio/analytics-service/internal/domain/entity"
@@ -10,20 +11,52 @@ import (
 // ProjectViewRepository defines the interface for project view data access
 type ProjectViewRepository interface {
 	Record(ctx context.Context, view *entity.ProjectView) error
-	GetByProjectID(ctx context.Context, projectID int64, startDate, endDate *time.Time) ([]*entity.ProjectView, error)
+	// RecordBatch stores views in one transaction, skipping any that repeat
+	// a user's view of the project within window, whether stored already or
+	// earlier in the batch. It returns how many were stored.
+	RecordBatch(ctx context.Context, views []*entity.ProjectView, window time.Duration) (int, error)
+	GetByProjectID(ctx context.Context, projectID int64, startDate, endDate *time.Time, page, limit int) ([]*entity.ProjectView, int, error)
 	CountByProjectID(ctx context.Context, projectID int64) (int, error)
+	// TopProjects ranks projects by their views between since and until,
+	// by score or by count as orderBy says. Each view scores its position in
+	// the range, from 0 at since to 1 at until. A nil projectIDs ranks every
+	// project.
+	TopProjects(ctx context.Context, since, until time.Time, projectIDs []int64, orderBy string, limit int) ([]*entity.ProjectTrend, error)
+	CountByUserID(ctx context.Context, userID int64) (int, error)
+	// RecentByUserID returns the user's latest view of each project they
+	// viewed, most recent first. A nil projectIDs lists every project.
+	RecentByUserID(ctx context.Context, userID int64, projectIDs []int64, limit int) ([]*entity.ProjectView, error)
 }
 
 // TaskActivityRepository defines the interface for task activity data access
 type TaskActivityRepository interface {
 	Record(ctx context.Context, activity *entity.TaskActivity) error
-	GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskActivity, error)
-	GetByProjectID(ctx context.Context, projectID int64) ([]*entity.TaskActivity, error)
+	GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskActivity, int, error)
+	GetByProjectID(ctx context.Context, projectID int64, page, limit int) ([]*entity.TaskActivity, int, error)
+	GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.TaskActivity, int, error)
+	// CountAssignedTasks counts the tasks assigned to a user by completion,
+	// reading the tasks table that activity is already joined against
+	CountAssignedTasks(ctx context.Context, userID int64) (*entity.AssignedTaskCounts, error)
+	// CycleTime summarizes the lead times of a project's tasks completed
+	// between since and until, inclusive, from the tasks table
+	CycleTime(ctx context.Context, projectID int64, since, until time.Time) (*entity.CycleTime, error)
 }
 
+// TaskCounter counts tasks in the task service, which owns them. Only the
+// task counts of the stats are filled in.
+type TaskCounter interface {
+	// CountProjectTasks counts the tasks of every project that has any
+	CountProjectTasks(ctx context.Context) ([]*entity.ProjectStats, error)
+}
+
+// ErrProjectStatsNotFound is returned by ProjectStatsRepository.Get when no
+// stats have been stored for the project yet
+var ErrProjectStatsNotFound = errors.New("project stats not found")
+
 // ProjectStatsRepository defines the interface for project stats data access
 type ProjectStatsRepository interface {
 	Get(ctx context.Context, projectID int64) (*entity.ProjectStats, error)
 	Upsert(ctx context.Context, stats *entity.ProjectStats) error
 	GetAll(ctx context.Context) ([]*entity.ProjectStats, error)
+	GetByProjectIDs(ctx context.Context, projectIDs []int64) ([]*entity.ProjectStats, error)
 }
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/portfolio/analytics-service/internal/domain/entity"
+	"github.com/portfolio/analytics-service/internal/domain/repository"
+	"github.com/portfolio/shared/cache"
+)
+
+// allStatsKey caches GetAll; each project's stats are cached under statsKey
+const allStatsKey = "project_stats:all"
+
+func statsKey(projectID int64) string {
+	return "project_stats:" + strconv.FormatInt(projectID, 10)
+}
+
+// CachedProjectStatsRepository caches the reads of a repository.ProjectStatsRepository.
+// Stats are cached per project, including projects that have none, so
+// scoped dashboards share entries with single-project reads. Upsert drops
+// the project's entry and the full list, and fails if it can't. A read that
+// races an Upsert may cache the stats from just before it until the TTL runs
+// out.
+type CachedProjectStatsRepository struct {
+	repo  repository.ProjectStatsRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedProjectStatsRepository caches repo's reads in c for ttl
+func NewCachedProjectStatsRepository(repo repository.ProjectStatsRepository, c cache.Cache, ttl time.Duration) *CachedProjectStatsRepository {
+	return &CachedProjectStatsRepository{repo: repo, cache: c, ttl: ttl}
+}
+
+var _ repository.ProjectStatsRepository = (*CachedProjectStatsRepository)(nil)
+
+// Get gets a project's stats, or repository.ErrProjectStatsNotFound
+func (r *CachedProjectStatsRepository) Get(ctx context.Context, projectID int64) (*entity.ProjectStats, error) {
+	var stats *entity.ProjectStats
+	if cache.GetJSON(ctx, r.cache, statsKey(projectID), &stats) {
+		if stats == nil {
+			return nil, repository.ErrProjectStatsNotFound
+		}
+		return stats, nil
+	}
+
+	stats, err := r.repo.Get(ctx, projectID)
+	if err != nil && !errors.Is(err, repository.ErrProjectStatsNotFound) {
+		return nil, err
+	}
+	cache.SetJSON(ctx, r.cache, statsKey(projectID), stats, r.ttl)
+	return stats, err
+}
+
+// Upsert stores stats and drops the cached copies they replace
+func (r *CachedProjectStatsRepository) Upsert(ctx context.Context, stats *entity.ProjectStats) error {
+	if err := r.repo.Upsert(ctx, stats); err != nil {
+		return err
+	}
+	return r.cache.Delete(ctx, statsKey(stats.ProjectID), allStatsKey)
+}
+
+// GetAll gets the stats of every project ordered by project ID
+func (r *CachedProjectStatsRepository) GetAll(ctx context.Context) ([]*entity.ProjectStats, error) {
+	var all []*entity.ProjectStats
+	if cache.GetJSON(ctx, r.cache, allStatsKey, &all) {
+		return all, nil
+	}
+	all, err := r.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	cache.SetJSON(ctx, r.cache, allStatsKey, all, r.ttl)
+	return all, nil
+}
+
+// GetByProjectIDs gets the stats of the given projects ordered by project
+// ID, reading only the projects that aren't cached
+func (r *CachedProjectStatsRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) ([]*entity.ProjectStats, error) {
+	var found []*entity.ProjectStats
+	var missing []int64
+	seen := make(map[int64]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		var stats *entity.ProjectStats
+		if !cache.GetJSON(ctx, r.cache, statsKey(id), &stats) {
+			missing = append(missing, id)
+		} else if stats != nil {
+			found = append(found, stats)
+		}
+	}
+
+	if len(missing) > 0 {
+		loaded, err := r.repo.GetByProjectIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[int64]*entity.ProjectStats, len(loaded))
+		for _, stats := range loaded {
+			byID[stats.ProjectID] = stats
+		}
+		// Projects without stats are cached as absent too
+		for _, id := range missing {
+			cache.SetJSON(ctx, r.cache, statsKey(id), byID[id], r.ttl)
+		}
+		found = append(found, loaded...)
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].ProjectID < found[j].ProjectID })
+	return found, nil
+}
@@ -3,9 +3,16 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/portfolio/analytics-service/internal/domain/entity"
+	domain "github.com/portfolio/analytics-service/internal/domain/repository"
 )
 
 // PostgresProjectViewRepository implements ProjectViewRepository
@@ -24,38 +31,126 @@ func (r *PostgresProjectViewRepository) Record(ctx context.Context, view *entity
 	return r.db.QueryRowContext(ctx, query, view.ProjectID, view.UserID, view.ViewedAt).Scan(&view.ID)
 }
 
-// GetByProjectID gets project views with optional date range
-func (r *PostgresProjectViewRepository) GetByProjectID(ctx context.Context, projectID int64, startDate, endDate *time.Time) ([]*entity.ProjectView, error) {
-	query := `SELECT id, project_id, user_id, viewed_at FROM project_views WHERE project_id = $1`
+// viewerLockKeys returns the advisory lock keys of the users whose views are
+// deduplicated, in ascending order so concurrent batches take shared locks in
+// the same order and can't deadlock. Locking per project and user lets two
+// batches carrying the same view not both pass the duplicate check, while
+// batches for other viewers proceed in parallel. Anonymous views aren't
+// deduplicated and need no lock.
+func viewerLockKeys(views []*entity.ProjectView) []int64 {
+	seen := make(map[int64]bool)
+	var keys []int64
+	for _, v := range views {
+		if v.UserID == 0 {
+			continue
+		}
+		h := fnv.New64a()
+		fmt.Fprintf(h, "project_views:%d:%d", v.ProjectID, v.UserID)
+		key := int64(h.Sum64())
+		if !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+// RecordBatch records views with one multi-row INSERT. Repeats within the
+// batch are dropped first; the INSERT skips those matching a stored view.
+func (r *PostgresProjectViewRepository) RecordBatch(ctx context.Context, views []*entity.ProjectView, window time.Duration) (int, error) {
+	views = entity.DedupeViews(views, window)
+	if len(views) == 0 {
+		return 0, nil
+	}
+
+	values := make([]string, len(views))
+	args := []interface{}{window.Seconds()}
+	for i, v := range views {
+		n := len(args)
+		values[i] = fmt.Sprintf("($%d::bigint, $%d::bigint, $%d::timestamp)", n+1, n+2, n+3)
+		args = append(args, v.ProjectID, v.UserID, v.ViewedAt)
+	}
+	query := `
+		INSERT INTO project_views (project_id, user_id, viewed_at)
+		SELECT v.project_id, v.user_id, v.viewed_at
+		FROM (VALUES ` + strings.Join(values, ", ") + `) AS v(project_id, user_id, viewed_at)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM project_views p
+			WHERE p.project_id = v.project_id AND p.user_id = v.user_id
+			AND p.viewed_at > v.viewed_at - $1::float8 * INTERVAL '1 second'
+			AND p.viewed_at < v.viewed_at + $1::float8 * INTERVAL '1 second'
+		)`
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if keys := viewerLockKeys(views); len(keys) > 0 {
+		lock := `SELECT pg_advisory_xact_lock(k) FROM unnest($1::bigint[]) AS k`
+		if _, err := tx.ExecContext(ctx, lock, pq.Array(keys)); err != nil {
+			return 0, err
+		}
+	}
+	result, err := tx.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	recorded, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int(recorded), nil
+}
+
+// GetByProjectID gets a page of project views with optional date range
+func (r *PostgresProjectViewRepository) GetByProjectID(ctx context.Context, projectID int64, startDate, endDate *time.Time, page, limit int) ([]*entity.ProjectView, int, error) {
+	baseQuery := `FROM project_views WHERE project_id = $1`
 	args := []interface{}{projectID}
 	argIndex := 2
 
 	if startDate != nil {
-		query += ` AND viewed_at >= $` + string(rune('0'+argIndex))
+		baseQuery += ` AND viewed_at >= $` + strconv.Itoa(argIndex)
 		args = append(args, startDate)
 		argIndex++
 	}
 	if endDate != nil {
-		query += ` AND viewed_at <= $` + string(rune('0'+argIndex))
+		baseQuery += ` AND viewed_at <= $` + strconv.Itoa(argIndex)
 		args = append(args, endDate)
+		argIndex++
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) `+baseQuery, args...).Scan(&total); err != nil {
+		return nil, 0, err
 	}
-	query += ` ORDER BY viewed_at DESC`
+
+	query := `SELECT id, project_id, user_id, viewed_at ` + baseQuery +
+		` ORDER BY viewed_at DESC, id DESC LIMIT $` + strconv.Itoa(argIndex) + ` OFFSET $` + strconv.Itoa(argIndex+1)
+	args = append(args, limit, (page-1)*limit)
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
 	var views []*entity.ProjectView
 	for rows.Next() {
 		view := &entity.ProjectView{}
-		if err := rows.Scan(&view.ID, &view.ProjectID, &view.UserID, &view.ViewedAt); err != nil {
-			return nil, err
+		var userID sql.NullInt64
+		if err := rows.Scan(&view.ID, &view.ProjectID, &userID, &view.ViewedAt); err != nil {
+			return nil, 0, err
 		}
+		view.UserID = userID.Int64
 		views = append(views, view)
 	}
-	return views, nil
+	return views, total, nil
 }
 
 // CountByProjectID counts total views for a project
@@ -66,6 +161,85 @@ func (r *PostgresProjectViewRepository) CountByProjectID(ctx context.Context, pr
 	return count, err
 }
 
+// TopProjects ranks projects by their views in a time range
+func (r *PostgresProjectViewRepository) TopProjects(ctx context.Context, since, until time.Time, projectIDs []int64, orderBy string, limit int) ([]*entity.ProjectTrend, error) {
+	order := `score DESC, views DESC, project_id`
+	if orderBy == entity.TrendSortViews {
+		order = `views DESC, score DESC, project_id`
+	}
+
+	query := `
+		SELECT project_id, COUNT(*) AS views,
+			SUM(EXTRACT(EPOCH FROM viewed_at - $1::timestamp)::float8) / $3::float8 AS score
+		FROM project_views
+		WHERE viewed_at >= $1 AND viewed_at <= $2`
+	args := []interface{}{since, until, until.Sub(since).Seconds(), limit}
+	if projectIDs != nil {
+		query += ` AND project_id = ANY($5)`
+		args = append(args, pq.Array(projectIDs))
+	}
+	query += ` GROUP BY project_id ORDER BY ` + order + ` LIMIT $4`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var trends []*entity.ProjectTrend
+	for rows.Next() {
+		trend := &entity.ProjectTrend{}
+		if err := rows.Scan(&trend.ProjectID, &trend.Views, &trend.Score); err != nil {
+			return nil, err
+		}
+		trends = append(trends, trend)
+	}
+	return trends, rows.Err()
+}
+
+// CountByUserID counts the views a user has made
+func (r *PostgresProjectViewRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM project_views WHERE user_id = $1`
+	var count int
+	err := r.db.QueryRowContext(ctx, query, userID).Scan(&count)
+	return count, err
+}
+
+// RecentByUserID returns the user's latest view of each project, most
+// recent first
+func (r *PostgresProjectViewRepository) RecentByUserID(ctx context.Context, userID int64, projectIDs []int64, limit int) ([]*entity.ProjectView, error) {
+	query := `
+		SELECT id, project_id, user_id, viewed_at FROM (
+			SELECT DISTINCT ON (project_id) id, project_id, user_id, viewed_at
+			FROM project_views
+			WHERE user_id = $1`
+	args := []interface{}{userID, limit}
+	if projectIDs != nil {
+		query += ` AND project_id = ANY($3)`
+		args = append(args, pq.Array(projectIDs))
+	}
+	query += `
+			ORDER BY project_id, viewed_at DESC, id DESC
+		) latest
+		ORDER BY viewed_at DESC, id DESC
+		LIMIT $2`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []*entity.ProjectView
+	for rows.Next() {
+		v := &entity.ProjectView{}
+		if err := rows.Scan(&v.ID, &v.ProjectID, &v.UserID, &v.ViewedAt); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
 // PostgresTaskActivityRepository implements TaskActivityRepository
 type PostgresTaskActivityRepository struct {
 	db *sql.DB
@@ -82,36 +256,106 @@ func (r *PostgresTaskActivityRepository) Record(ctx context.Context, activity *e
 	return r.db.QueryRowContext(ctx, query, activity.TaskID, activity.UserID, activity.Action, activity.CreatedAt).Scan(&activity.ID)
 }
 
-// GetByTaskID gets activities for a task
-func (r *PostgresTaskActivityRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskActivity, error) {
-	query := `SELECT id, task_id, user_id, action, created_at FROM task_activity WHERE task_id = $1 ORDER BY created_at DESC`
-	rows, err := r.db.QueryContext(ctx, query, taskID)
-	if err != nil {
-		return nil, err
+// GetByTaskID gets a page of activities for a task
+func (r *PostgresTaskActivityRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskActivity, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM task_activity WHERE task_id = $1`
+	if err := r.db.QueryRowContext(ctx, countQuery, taskID).Scan(&total); err != nil {
+		return nil, 0, err
 	}
-	defer rows.Close()
 
-	var activities []*entity.TaskActivity
-	for rows.Next() {
-		activity := &entity.TaskActivity{}
-		if err := rows.Scan(&activity.ID, &activity.TaskID, &activity.UserID, &activity.Action, &activity.CreatedAt); err != nil {
-			return nil, err
-		}
-		activities = append(activities, activity)
+	query := `SELECT id, task_id, user_id, action, created_at FROM task_activity WHERE task_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3`
+	activities, err := r.query(ctx, query, taskID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
 	}
-	return activities, nil
+	return activities, total, nil
 }
 
-// GetByProjectID gets activities for all tasks in a project
-func (r *PostgresTaskActivityRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.TaskActivity, error) {
+// GetByProjectID gets a page of activities for all tasks in a project
+func (r *PostgresTaskActivityRepository) GetByProjectID(ctx context.Context, projectID int64, page, limit int) ([]*entity.TaskActivity, int, error) {
+	var total int
+	countQuery := `
+		SELECT COUNT(*)
+		FROM task_activity ta
+		INNER JOIN tasks t ON ta.task_id = t.id
+		WHERE t.project_id = $1
+	`
+	if err := r.db.QueryRowContext(ctx, countQuery, projectID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
 	query := `
 		SELECT ta.id, ta.task_id, ta.user_id, ta.action, ta.created_at
 		FROM task_activity ta
 		INNER JOIN tasks t ON ta.task_id = t.id
 		WHERE t.project_id = $1
-		ORDER BY ta.created_at DESC
+		ORDER BY ta.created_at DESC, ta.id DESC
+		LIMIT $2 OFFSET $3
 	`
-	rows, err := r.db.QueryContext(ctx, query, projectID)
+	activities, err := r.query(ctx, query, projectID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
+
+// GetByUserID gets a page of a user's activities
+func (r *PostgresTaskActivityRepository) GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.TaskActivity, int, error) {
+	var total int
+	countQuery := `SELECT COUNT(*) FROM task_activity WHERE user_id = $1`
+	if err := r.db.QueryRowContext(ctx, countQuery, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `SELECT id, task_id, user_id, action, created_at FROM task_activity WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3`
+	activities, err := r.query(ctx, query, userID, limit, (page-1)*limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	return activities, total, nil
+}
+
+// CountAssignedTasks counts the tasks assigned to a user and how many are done
+func (r *PostgresTaskActivityRepository) CountAssignedTasks(ctx context.Context, userID int64) (*entity.AssignedTaskCounts, error) {
+	query := `SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'Done') FROM tasks WHERE assigned_to = $1`
+	counts := &entity.AssignedTaskCounts{}
+	if err := r.db.QueryRowContext(ctx, query, userID).Scan(&counts.Total, &counts.Completed); err != nil {
+		return nil, err
+	}
+	counts.Pending = counts.Total - counts.Completed
+	return counts, nil
+}
+
+// CycleTime summarizes the lead times of a project's tasks completed in the
+// inclusive range
+func (r *PostgresTaskActivityRepository) CycleTime(ctx context.Context, projectID int64, since, until time.Time) (*entity.CycleTime, error) {
+	query := `
+		SELECT COUNT(*),
+			COALESCE(AVG(EXTRACT(EPOCH FROM completed_at - created_at)), 0),
+			COALESCE(PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM completed_at - created_at)), 0)
+		FROM tasks
+		WHERE project_id = $1 AND completed_at >= $2 AND completed_at <= $3
+	`
+	cycle := &entity.CycleTime{ProjectID: projectID}
+	var average, median float64
+	if err := r.db.QueryRowContext(ctx, query, projectID, since, until).Scan(&cycle.CompletedTasks, &average, &median); err != nil {
+		return nil, err
+	}
+	cycle.AverageLeadTime = seconds(average)
+	cycle.MedianLeadTime = seconds(median)
+	return cycle, nil
+}
+
+// seconds converts a number of seconds Postgres computed to a Duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+func (r *PostgresTaskActivityRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entity.TaskActivity, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -120,12 +364,15 @@ func (r *PostgresTaskActivityRepository) GetByProjectID(ctx context.Context, pro
 	var activities []*entity.TaskActivity
 	for rows.Next() {
 		activity := &entity.TaskActivity{}
-		if err := rows.Scan(&activity.ID, &activity.TaskID, &activity.UserID, &activity.Action, &activity.CreatedAt); err != nil {
+		// user_id is NULL for activity recorded by the system
+		var userID sql.NullInt64
+		if err := rows.Scan(&activity.ID, &activity.TaskID, &userID, &activity.Action, &activity.CreatedAt); err != nil {
 			return nil, err
 		}
+		activity.UserID = userID.Int64
 		activities = append(activities, activity)
 	}
-	return activities, nil
+	return activities, rows.Err()
 }
 
 // PostgresProjectStatsRepository implements ProjectStatsRepository
@@ -146,6 +393,9 @@ func (r *PostgresProjectStatsRepository) Get(ctx context.Context, projectID int6
 		&stats.ProjectID, &stats.TotalTasks, &stats.CompletedTasks,
 		&stats.ProgressPercent, &stats.LastUpdated,
 	)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrProjectStatsNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -170,8 +420,23 @@ func (r *PostgresProjectStatsRepository) Upsert(ctx context.Context, stats *enti
 
 // GetAll gets all project stats
 func (r *PostgresProjectStatsRepository) GetAll(ctx context.Context) ([]*entity.ProjectStats, error) {
-	query := `SELECT project_id, total_tasks, completed_tasks, progress_percent, last_updated FROM project_stats`
-	rows, err := r.db.QueryContext(ctx, query)
+	query := `SELECT project_id, total_tasks, completed_tasks, progress_percent, last_updated FROM project_stats ORDER BY project_id`
+	return r.query(ctx, query)
+}
+
+// GetByProjectIDs gets the stats of the given projects ordered by project ID;
+// projects without stats are skipped
+func (r *PostgresProjectStatsRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) ([]*entity.ProjectStats, error) {
+	if len(projectIDs) == 0 {
+		return nil, nil
+	}
+	query := `SELECT project_id, total_tasks, completed_tasks, progress_percent, last_updated FROM project_stats
+		WHERE project_id = ANY($1) ORDER BY project_id`
+	return r.query(ctx, query, pq.Array(projectIDs))
+}
+
+func (r *PostgresProjectStatsRepository) query(ctx context.Context, query string, args ...interface{}) ([]*entity.ProjectStats, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -185,5 +450,5 @@ func (r *PostgresProjectStatsRepository) GetAll(ctx context.Context) ([]*entity.
 		}
 		allStats = append(allStats, stats)
 	}
-	return allStats, nil
+	return allStats, rows.Err()
 }
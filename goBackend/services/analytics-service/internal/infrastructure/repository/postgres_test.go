@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/portfolio/analytics-service/internal/domain/entity"
+)
+
+func TestPostgresProjectViewRepository_RecordBatch(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	views := []*entity.ProjectView{
+		{ProjectID: 1, UserID: 5, ViewedAt: base},
+		{ProjectID: 1, UserID: 5, ViewedAt: base.Add(10 * time.Minute)}, // repeat within the window
+		{ProjectID: 2, UserID: 5, ViewedAt: base.Add(time.Minute)},
+		{ProjectID: 1, UserID: 5, ViewedAt: base.Add(45 * time.Minute)},
+		{ProjectID: 1, UserID: 6, ViewedAt: base.Add(5 * time.Minute)}, // already stored
+	}
+
+	// Repeats within the batch never reach the database; the INSERT checks
+	// the rest against stored views and reports what it inserted
+	insert := regexp.QuoteMeta(`INSERT INTO project_views (project_id, user_id, viewed_at)`) +
+		`.*` + regexp.QuoteMeta(`FROM (VALUES ($2::bigint, $3::bigint, $4::timestamp), ($5::bigint, $6::bigint, $7::timestamp), ($8::bigint, $9::bigint, $10::timestamp), ($11::bigint, $12::bigint, $13::timestamp)) AS v(project_id, user_id, viewed_at)`) +
+		`.*` + regexp.QuoteMeta(`WHERE NOT EXISTS`)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(k) FROM unnest($1::bigint[]) AS k`)).
+		WithArgs(pq.Array(viewerLockKeys(views))).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(insert).
+		WithArgs(
+			float64(1800),
+			int64(1), int64(5), base,
+			int64(2), int64(5), base.Add(time.Minute),
+			int64(1), int64(6), base.Add(5*time.Minute),
+			int64(1), int64(5), base.Add(45*time.Minute),
+		).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	repo := NewPostgresProjectViewRepository(db)
+	recorded, err := repo.RecordBatch(context.Background(), views, entity.ViewDedupWindow)
+	if err != nil {
+		t.Fatalf("RecordBatch() error = %v", err)
+	}
+	if recorded != 3 {
+		t.Errorf("RecordBatch() = %d, want 3", recorded)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresProjectViewRepository_RecordBatchRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta(`SELECT pg_advisory_xact_lock(k)`)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO project_views`)).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	repo := NewPostgresProjectViewRepository(db)
+	views := []*entity.ProjectView{{ProjectID: 1, UserID: 5, ViewedAt: time.Now()}}
+	if _, err := repo.RecordBatch(context.Background(), views, entity.ViewDedupWindow); err == nil {
+		t.Error("RecordBatch() expected error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestViewerLockKeys(t *testing.T) {
+	now := time.Now()
+	keys := viewerLockKeys([]*entity.ProjectView{
+		{ProjectID: 1, UserID: 5, ViewedAt: now},
+		{ProjectID: 1, UserID: 5, ViewedAt: now.Add(time.Hour)},
+		{ProjectID: 2, UserID: 5, ViewedAt: now},
+		{ProjectID: 1, UserID: 0, ViewedAt: now}, // anonymous
+	})
+	if len(keys) != 2 {
+		t.Fatalf("got %d keys, want one per project and user", len(keys))
+	}
+	if keys[0] >= keys[1] {
+		t.Errorf("keys = %v, want ascending", keys)
+	}
+	if len(viewerLockKeys([]*entity.ProjectView{{ProjectID: 1, ViewedAt: now}})) != 0 {
+		t.Error("anonymous views took a lock")
+	}
+}
+
+func TestPostgresProjectViewRepository_TopProjects(t *testing.T) {
+	until := time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC)
+	since := until.Add(-7 * 24 * time.Hour)
+	base := `SELECT project_id, COUNT(*) AS views,
+			SUM(EXTRACT(EPOCH FROM viewed_at - $1::timestamp)::float8) / $3::float8 AS score
+		FROM project_views
+		WHERE viewed_at >= $1 AND viewed_at <= $2`
+
+	tests := []struct {
+		name       string
+		projectIDs []int64
+		orderBy    string
+		query      string
+		args       []driver.Value
+	}{
+		{"all by score", nil, entity.TrendSortScore,
+			base + ` GROUP BY project_id ORDER BY score DESC, views DESC, project_id LIMIT $4`,
+			[]driver.Value{since, until, float64(604800), int64(10)}},
+		{"scoped by views", []int64{1, 2}, entity.TrendSortViews,
+			base + ` AND project_id = ANY($5) GROUP BY project_id ORDER BY views DESC, score DESC, project_id LIMIT $4`,
+			[]driver.Value{since, until, float64(604800), int64(10), "{1,2}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			rows := sqlmock.NewRows([]string{"project_id", "views", "score"}).
+				AddRow(2, 3, 2.5).
+				AddRow(1, 4, 1.25)
+			mock.ExpectQuery("^" + regexp.QuoteMeta(strings.TrimSpace(tt.query)) + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(rows)
+
+			repo := NewPostgresProjectViewRepository(db)
+			trends, err := repo.TopProjects(context.Background(), since, until, tt.projectIDs, tt.orderBy, 10)
+			if err != nil {
+				t.Fatalf("TopProjects() error = %v", err)
+			}
+			if len(trends) != 2 || trends[0].ProjectID != 2 || trends[0].Views != 3 || trends[1].Score != 1.25 {
+				t.Errorf("TopProjects() = %+v, want the rows in the order returned", trends)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresProjectViewRepository_RecentByUserID(t *testing.T) {
+	now := time.Date(2024, 3, 8, 12, 0, 0, 0, time.UTC)
+	inner := `SELECT id, project_id, user_id, viewed_at FROM (
+			SELECT DISTINCT ON (project_id) id, project_id, user_id, viewed_at
+			FROM project_views
+			WHERE user_id = $1`
+	outer := `
+			ORDER BY project_id, viewed_at DESC, id DESC
+		) latest
+		ORDER BY viewed_at DESC, id DESC
+		LIMIT $2`
+
+	tests := []struct {
+		name       string
+		projectIDs []int64
+		query      string
+		args       []driver.Value
+	}{
+		{"all", nil, inner + outer, []driver.Value{int64(5), int64(20)}},
+		{"scoped", []int64{1, 2, 7}, inner + ` AND project_id = ANY($3)` + outer, []driver.Value{int64(5), int64(20), "{1,2,7}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery("^" + regexp.QuoteMeta(tt.query) + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "user_id", "viewed_at"}).
+					AddRow(31, 2, 5, now).
+					AddRow(27, 7, 5, now.Add(-time.Hour)).
+					AddRow(12, 1, 5, now.Add(-24*time.Hour)))
+
+			views, err := NewPostgresProjectViewRepository(db).RecentByUserID(context.Background(), 5, tt.projectIDs, 20)
+			if err != nil {
+				t.Fatalf("RecentByUserID() error = %v", err)
+			}
+			var got []int64
+			for _, v := range views {
+				got = append(got, v.ProjectID)
+			}
+			if len(got) != 3 || got[0] != 2 || got[1] != 7 || got[2] != 1 || !views[0].ViewedAt.Equal(now) {
+				t.Errorf("RecentByUserID() projects = %v, want 2, 7, 1 most recent first", got)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresTaskActivityRepository_CycleTime(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	until := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	since := until.AddDate(0, -1, 0)
+	mock.ExpectQuery(regexp.QuoteMeta("PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM completed_at - created_at))")).
+		WithArgs(int64(5), since, until).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "avg", "median"}).AddRow(3, 5400.0, 3600.5))
+
+	cycle, err := NewPostgresTaskActivityRepository(db).CycleTime(context.Background(), 5, since, until)
+	if err != nil {
+		t.Fatalf("CycleTime() error = %v", err)
+	}
+	want := entity.CycleTime{
+		ProjectID:       5,
+		CompletedTasks:  3,
+		AverageLeadTime: 90 * time.Minute,
+		MedianLeadTime:  time.Hour + 500*time.Millisecond,
+	}
+	if *cycle != want {
+		t.Errorf("CycleTime() = %+v, want %+v", cycle, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,37 @@
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/portfolio/analytics-service/internal/domain/entity"
+	taskpb "github.com/portfolio/proto/task"
+	"google.golang.org/grpc"
+)
+
+// Client implements TaskCounter on top of the task service
+type Client struct {
+	taskClient taskpb.TaskServiceClient
+}
+
+// NewClient creates a new task service client
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{taskClient: taskpb.NewTaskServiceClient(conn)}
+}
+
+// CountProjectTasks counts the tasks of every project that has any
+func (c *Client) CountProjectTasks(ctx context.Context) ([]*entity.ProjectStats, error) {
+	resp, err := c.taskClient.CountProjectTasks(ctx, &taskpb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count project tasks: %w", err)
+	}
+	counts := make([]*entity.ProjectStats, len(resp.Projects))
+	for i, p := range resp.Projects {
+		counts[i] = &entity.ProjectStats{
+			ProjectID:      p.ProjectId,
+			TotalTasks:     int(p.TotalTasks),
+			CompletedTasks: int(p.CompletedTasks),
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,470 @@
+// Package testutil provides in-memory implementations of the analytics
+// service repositories so usecases can be tested without a database. They
+// follow the Postgres repositories' filtering, ordering and pagination.
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/portfolio/analytics-service/internal/domain/entity"
+	"github.com/portfolio/analytics-service/internal/domain/repository"
+)
+
+// Store holds the rows shared by the in-memory repositories
+type Store struct {
+	mu           sync.Mutex
+	lastID       map[string]int64
+	views        []*entity.ProjectView
+	activities   []*entity.TaskActivity
+	stats        map[int64]*entity.ProjectStats
+	taskProjects map[int64]int64 // stands in for the tasks table joined by activity queries
+	assignees    map[int64]assignee
+	completions  map[int64]completion
+}
+
+// assignee is who a task is assigned to and whether it is done
+type assignee struct {
+	userID int64
+	done   bool
+}
+
+// completion is when a task of a project was created and completed
+type completion struct {
+	projectID              int64
+	createdAt, completedAt time.Time
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{
+		lastID:       make(map[string]int64),
+		stats:        make(map[int64]*entity.ProjectStats),
+		taskProjects: make(map[int64]int64),
+		assignees:    make(map[int64]assignee),
+		completions:  make(map[int64]completion),
+	}
+}
+
+// SetTaskProject records which project a task belongs to, which
+// TaskActivityRepository.GetByProjectID needs to find a project's activity
+func (s *Store) SetTaskProject(taskID, projectID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.taskProjects[taskID] = projectID
+}
+
+// SetTaskAssignee records who a task is assigned to and whether it is done,
+// which TaskActivityRepository.CountAssignedTasks needs to count it
+func (s *Store) SetTaskAssignee(taskID, userID int64, done bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assignees[taskID] = assignee{userID: userID, done: done}
+}
+
+// SetTaskCompletion records when a project's task was created and completed,
+// which TaskActivityRepository.CycleTime needs to measure its lead time
+func (s *Store) SetTaskCompletion(taskID, projectID int64, createdAt, completedAt time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.completions[taskID] = completion{projectID: projectID, createdAt: createdAt, completedAt: completedAt}
+}
+
+// nextID emulates a SERIAL column; the caller holds mu
+func (s *Store) nextID(table string) int64 {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// paginate returns the given page of items, or nil when it is empty
+func paginate[T any](items []T, page, limit int) []T {
+	offset := (page - 1) * limit
+	if offset < 0 || offset >= len(items) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// ProjectViewRepository is an in-memory repository.ProjectViewRepository
+type ProjectViewRepository struct{ s *Store }
+
+// NewProjectViewRepository creates a ProjectViewRepository backed by s
+func NewProjectViewRepository(s *Store) *ProjectViewRepository {
+	return &ProjectViewRepository{s: s}
+}
+
+var _ repository.ProjectViewRepository = (*ProjectViewRepository)(nil)
+
+// Record stores view and assigns its ID
+func (r *ProjectViewRepository) Record(ctx context.Context, view *entity.ProjectView) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	view.ID = r.s.nextID("project_views")
+	stored := *view
+	r.s.views = append(r.s.views, &stored)
+	return nil
+}
+
+// RecordBatch stores the views that don't repeat a stored or earlier view by
+// the same user of the same project within window
+func (r *ProjectViewRepository) RecordBatch(ctx context.Context, views []*entity.ProjectView, window time.Duration) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	recorded := 0
+	for _, view := range entity.DedupeViews(views, window) {
+		if r.seenWithin(view, window) {
+			continue
+		}
+		view.ID = r.s.nextID("project_views")
+		stored := *view
+		r.s.views = append(r.s.views, &stored)
+		recorded++
+	}
+	return recorded, nil
+}
+
+// seenWithin reports whether a stored view matches view within window; the
+// caller holds mu
+func (r *ProjectViewRepository) seenWithin(view *entity.ProjectView, window time.Duration) bool {
+	if view.UserID == 0 {
+		return false
+	}
+	for _, v := range r.s.views {
+		if v.ProjectID != view.ProjectID || v.UserID != view.UserID {
+			continue
+		}
+		if d := v.ViewedAt.Sub(view.ViewedAt); d > -window && d < window {
+			return true
+		}
+	}
+	return false
+}
+
+// TopProjects ranks projects by their views in the inclusive range
+func (r *ProjectViewRepository) TopProjects(ctx context.Context, since, until time.Time, projectIDs []int64, orderBy string, limit int) ([]*entity.ProjectTrend, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var allowed map[int64]bool
+	if projectIDs != nil {
+		allowed = make(map[int64]bool, len(projectIDs))
+		for _, id := range projectIDs {
+			allowed[id] = true
+		}
+	}
+	span := until.Sub(since).Seconds()
+	byProject := make(map[int64]*entity.ProjectTrend)
+	for _, v := range r.s.views {
+		if v.ViewedAt.Before(since) || v.ViewedAt.After(until) {
+			continue
+		}
+		if allowed != nil && !allowed[v.ProjectID] {
+			continue
+		}
+		trend, ok := byProject[v.ProjectID]
+		if !ok {
+			trend = &entity.ProjectTrend{ProjectID: v.ProjectID}
+			byProject[v.ProjectID] = trend
+		}
+		trend.Views++
+		trend.Score += v.ViewedAt.Sub(since).Seconds() / span
+	}
+
+	trends := make([]*entity.ProjectTrend, 0, len(byProject))
+	for _, trend := range byProject {
+		trends = append(trends, trend)
+	}
+	sort.Slice(trends, func(i, j int) bool {
+		a, b := trends[i], trends[j]
+		if orderBy == entity.TrendSortViews && a.Views != b.Views {
+			return a.Views > b.Views
+		}
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Views != b.Views {
+			return a.Views > b.Views
+		}
+		return a.ProjectID < b.ProjectID
+	})
+	if len(trends) > limit {
+		trends = trends[:limit]
+	}
+	return trends, nil
+}
+
+// GetByProjectID gets a page of a project's views, newest first, within an
+// optional inclusive date range
+func (r *ProjectViewRepository) GetByProjectID(ctx context.Context, projectID int64, startDate, endDate *time.Time, page, limit int) ([]*entity.ProjectView, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var matched []*entity.ProjectView
+	for _, v := range r.s.views {
+		if v.ProjectID != projectID {
+			continue
+		}
+		if startDate != nil && v.ViewedAt.Before(*startDate) {
+			continue
+		}
+		if endDate != nil && v.ViewedAt.After(*endDate) {
+			continue
+		}
+		found := *v
+		matched = append(matched, &found)
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].ViewedAt.Equal(matched[j].ViewedAt) {
+			return matched[i].ViewedAt.After(matched[j].ViewedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// CountByProjectID counts all views of a project
+func (r *ProjectViewRepository) CountByProjectID(ctx context.Context, projectID int64) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	count := 0
+	for _, v := range r.s.views {
+		if v.ProjectID == projectID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountByUserID counts the views a user has made
+func (r *ProjectViewRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	count := 0
+	for _, v := range r.s.views {
+		if v.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// RecentByUserID returns the user's latest view of each project, most
+// recent first
+func (r *ProjectViewRepository) RecentByUserID(ctx context.Context, userID int64, projectIDs []int64, limit int) ([]*entity.ProjectView, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var allowed map[int64]bool
+	if projectIDs != nil {
+		allowed = make(map[int64]bool, len(projectIDs))
+		for _, id := range projectIDs {
+			allowed[id] = true
+		}
+	}
+	newer := func(a, b *entity.ProjectView) bool {
+		if !a.ViewedAt.Equal(b.ViewedAt) {
+			return a.ViewedAt.After(b.ViewedAt)
+		}
+		return a.ID > b.ID
+	}
+	latest := make(map[int64]*entity.ProjectView)
+	for _, v := range r.s.views {
+		if v.UserID != userID || (allowed != nil && !allowed[v.ProjectID]) {
+			continue
+		}
+		if seen, ok := latest[v.ProjectID]; !ok || newer(v, seen) {
+			latest[v.ProjectID] = v
+		}
+	}
+
+	views := make([]*entity.ProjectView, 0, len(latest))
+	for _, v := range latest {
+		found := *v
+		views = append(views, &found)
+	}
+	sort.Slice(views, func(i, j int) bool { return newer(views[i], views[j]) })
+	if len(views) > limit {
+		views = views[:limit]
+	}
+	return views, nil
+}
+
+// TaskActivityRepository is an in-memory repository.TaskActivityRepository
+type TaskActivityRepository struct{ s *Store }
+
+// NewTaskActivityRepository creates a TaskActivityRepository backed by s
+func NewTaskActivityRepository(s *Store) *TaskActivityRepository {
+	return &TaskActivityRepository{s: s}
+}
+
+var _ repository.TaskActivityRepository = (*TaskActivityRepository)(nil)
+
+// Record stores activity and assigns its ID
+func (r *TaskActivityRepository) Record(ctx context.Context, activity *entity.TaskActivity) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	activity.ID = r.s.nextID("task_activity")
+	stored := *activity
+	r.s.activities = append(r.s.activities, &stored)
+	return nil
+}
+
+// GetByTaskID gets a page of a task's activity, newest first
+func (r *TaskActivityRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskActivity, int, error) {
+	return r.filter(func(a *entity.TaskActivity) bool { return a.TaskID == taskID }, page, limit)
+}
+
+// GetByProjectID gets a page of activity on a project's tasks, newest first.
+// Tasks must be registered with Store.SetTaskProject.
+func (r *TaskActivityRepository) GetByProjectID(ctx context.Context, projectID int64, page, limit int) ([]*entity.TaskActivity, int, error) {
+	return r.filter(func(a *entity.TaskActivity) bool {
+		id, ok := r.s.taskProjects[a.TaskID]
+		return ok && id == projectID
+	}, page, limit)
+}
+
+// GetByUserID gets a page of a user's activity, newest first
+func (r *TaskActivityRepository) GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.TaskActivity, int, error) {
+	return r.filter(func(a *entity.TaskActivity) bool { return a.UserID == userID }, page, limit)
+}
+
+// CountAssignedTasks counts the tasks registered with Store.SetTaskAssignee for userID
+func (r *TaskActivityRepository) CountAssignedTasks(ctx context.Context, userID int64) (*entity.AssignedTaskCounts, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	counts := &entity.AssignedTaskCounts{}
+	for _, a := range r.s.assignees {
+		if a.userID != userID {
+			continue
+		}
+		counts.Total++
+		if a.done {
+			counts.Completed++
+		}
+	}
+	counts.Pending = counts.Total - counts.Completed
+	return counts, nil
+}
+
+// CycleTime summarizes the lead times of the tasks registered with
+// Store.SetTaskCompletion for projectID that were completed in the inclusive
+// range. An even count takes the median between the middle two, like
+// PERCENTILE_CONT.
+func (r *TaskActivityRepository) CycleTime(ctx context.Context, projectID int64, since, until time.Time) (*entity.CycleTime, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var leadTimes []time.Duration
+	var sum time.Duration
+	for _, c := range r.s.completions {
+		if c.projectID != projectID || c.completedAt.Before(since) || c.completedAt.After(until) {
+			continue
+		}
+		leadTimes = append(leadTimes, c.completedAt.Sub(c.createdAt))
+		sum += c.completedAt.Sub(c.createdAt)
+	}
+
+	cycle := &entity.CycleTime{ProjectID: projectID, CompletedTasks: len(leadTimes)}
+	if n := len(leadTimes); n > 0 {
+		sort.Slice(leadTimes, func(i, j int) bool { return leadTimes[i] < leadTimes[j] })
+		cycle.AverageLeadTime = sum / time.Duration(n)
+		cycle.MedianLeadTime = (leadTimes[(n-1)/2] + leadTimes[n/2]) / 2
+	}
+	return cycle, nil
+}
+
+func (r *TaskActivityRepository) filter(keep func(*entity.TaskActivity) bool, page, limit int) ([]*entity.TaskActivity, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var matched []*entity.TaskActivity
+	for _, a := range r.s.activities {
+		if keep(a) {
+			found := *a
+			matched = append(matched, &found)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// ProjectStatsRepository is an in-memory repository.ProjectStatsRepository
+type ProjectStatsRepository struct{ s *Store }
+
+// NewProjectStatsRepository creates a ProjectStatsRepository backed by s
+func NewProjectStatsRepository(s *Store) *ProjectStatsRepository {
+	return &ProjectStatsRepository{s: s}
+}
+
+var _ repository.ProjectStatsRepository = (*ProjectStatsRepository)(nil)
+
+// Get gets a project's stats, or repository.ErrProjectStatsNotFound
+func (r *ProjectStatsRepository) Get(ctx context.Context, projectID int64) (*entity.ProjectStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stats, ok := r.s.stats[projectID]
+	if !ok {
+		return nil, repository.ErrProjectStatsNotFound
+	}
+	found := *stats
+	return &found, nil
+}
+
+// Upsert stores stats, stamping last_updated with the current time like the Postgres query
+func (r *ProjectStatsRepository) Upsert(ctx context.Context, stats *entity.ProjectStats) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored := *stats
+	stored.LastUpdated = time.Now()
+	r.s.stats[stats.ProjectID] = &stored
+	return nil
+}
+
+// GetAll gets the stats of every project ordered by project ID
+func (r *ProjectStatsRepository) GetAll(ctx context.Context) ([]*entity.ProjectStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var all []*entity.ProjectStats
+	for _, stats := range r.s.stats {
+		found := *stats
+		all = append(all, &found)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].ProjectID < all[j].ProjectID })
+	return all, nil
+}
+
+// GetByProjectIDs gets the stats of the given projects ordered by project ID
+func (r *ProjectStatsRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) ([]*entity.ProjectStats, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var found []*entity.ProjectStats
+	seen := make(map[int64]bool, len(projectIDs))
+	for _, id := range projectIDs {
+		stats, ok := r.s.stats[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		copied := *stats
+		found = append(found, &copied)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ProjectID < found[j].ProjectID })
+	return found, nil
+}
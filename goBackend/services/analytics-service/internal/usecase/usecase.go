@@ -4,14 +4,41 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/portfolio/analytics-service/internal/domain/entity"
 	"github.com/portfolio/analytics-service/internal/domain/repository"
+	"github.com/portfolio/shared/pagination"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// dashboardRecentActivity is how many of a user's latest activities the dashboard shows
+	dashboardRecentActivity = 10
+	// dashboardConcurrency bounds how many dashboard sections are queried at once
+	dashboardConcurrency = 3
+
+	// MaxViewBatch caps how many views BatchRecordProjectViews takes at once
+	MaxViewBatch = 500
+	// viewClockSkew is how far ahead of the server clock a batched view's
+	// time may be before it is rejected as invalid
+	viewClockSkew = 5 * time.Minute
+
+	defaultTrendingLimit = 10
+	maxTrendingLimit     = 50
 )
 
 var (
-	ErrProjectStatsNotFound = errors.New("project stats not found")
+	ErrProjectStatsNotFound  = repository.ErrProjectStatsNotFound
+	ErrMissingTarget         = errors.New("task id, project id or user id is required")
+	ErrInvalidProjectID      = errors.New("project id must be positive")
+	ErrEmptyViewBatch        = errors.New("view batch is empty")
+	ErrViewBatchTooLarge     = fmt.Errorf("view batch is limited to %d views", MaxViewBatch)
+	ErrInvalidTrendRange     = errors.New("trending range must start in the past")
+	ErrInvalidTrendSort      = errors.New("sort must be trending or views")
+	ErrInvalidCycleTimeRange = errors.New("cycle time range must end after it starts")
 )
 
 // AnalyticsUseCase handles analytics business logic
@@ -19,38 +46,119 @@ type AnalyticsUseCase struct {
 	viewRepo  repository.ProjectViewRepository
 	actRepo   repository.TaskActivityRepository
 	statsRepo repository.ProjectStatsRepository
+	pages     pagination.Sizes
 }
 
-// NewAnalyticsUseCase creates a new AnalyticsUseCase
+// NewAnalyticsUseCase creates a new AnalyticsUseCase listing pages of the
+// given sizes
 func NewAnalyticsUseCase(
 	viewRepo repository.ProjectViewRepository,
 	actRepo repository.TaskActivityRepository,
 	statsRepo repository.ProjectStatsRepository,
+	pages pagination.Sizes,
 ) *AnalyticsUseCase {
 	return &AnalyticsUseCase{
 		viewRepo:  viewRepo,
 		actRepo:   actRepo,
 		statsRepo: statsRepo,
+		pages:     pages,
 	}
 }
 
+// PageLimit is the page size the list methods apply for limit
+func (uc *AnalyticsUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
 // RecordProjectView records a project view
 func (uc *AnalyticsUseCase) RecordProjectView(ctx context.Context, projectID, userID int64) error {
 	view := entity.NewProjectView(projectID, userID)
 	return uc.viewRepo.Record(ctx, view)
 }
 
-// GetProjectViews gets project views within a date range
-func (uc *AnalyticsUseCase) GetProjectViews(ctx context.Context, projectID int64, startDate, endDate *time.Time) ([]*entity.ProjectView, int, error) {
-	views, err := uc.viewRepo.GetByProjectID(ctx, projectID, startDate, endDate)
-	if err != nil {
-		return nil, 0, err
+// BatchRecordProjectViews records views collected by a client. Views without
+// a time are taken to have happened now. Invalid views, repeats within
+// entity.ViewDedupWindow and views older than that window are skipped rather
+// than failing the batch; a backdated view could otherwise slip in between
+// stored views whose duplicate checks have long passed.
+func (uc *AnalyticsUseCase) BatchRecordProjectViews(ctx context.Context, views []*entity.ProjectView) (recorded, skipped int, err error) {
+	if len(views) == 0 {
+		return 0, 0, ErrEmptyViewBatch
 	}
-	count, err := uc.viewRepo.CountByProjectID(ctx, projectID)
-	if err != nil {
-		return nil, 0, err
+	if len(views) > MaxViewBatch {
+		return 0, 0, ErrViewBatchTooLarge
+	}
+
+	now := time.Now()
+	valid := make([]*entity.ProjectView, 0, len(views))
+	for _, v := range views {
+		if v.ViewedAt.IsZero() {
+			v.ViewedAt = now
+		}
+		if v.ProjectID <= 0 || v.UserID < 0 || v.ViewedAt.After(now.Add(viewClockSkew)) ||
+			v.ViewedAt.Before(now.Add(-entity.ViewDedupWindow)) {
+			continue
+		}
+		valid = append(valid, v)
+	}
+	if len(valid) > 0 {
+		if recorded, err = uc.viewRepo.RecordBatch(ctx, valid, entity.ViewDedupWindow); err != nil {
+			return 0, 0, err
+		}
+	}
+	return recorded, len(views) - recorded, nil
+}
+
+// GetProjectViews gets a page of project views within a date range
+// along with the number of views in that range
+func (uc *AnalyticsUseCase) GetProjectViews(ctx context.Context, projectID int64, startDate, endDate *time.Time, page, limit int) ([]*entity.ProjectView, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+	return uc.viewRepo.GetByProjectID(ctx, projectID, startDate, endDate, page, limit)
+}
+
+// GetTrendingProjects ranks the projects in scope by their views from since
+// until now, by recency-weighted score unless orderBy is
+// entity.TrendSortViews
+func (uc *AnalyticsUseCase) GetTrendingProjects(ctx context.Context, since time.Time, scope DashboardScope, orderBy string, limit int) ([]*entity.ProjectTrend, error) {
+	switch orderBy {
+	case "":
+		orderBy = entity.TrendSortScore
+	case entity.TrendSortScore, entity.TrendSortViews:
+	default:
+		return nil, ErrInvalidTrendSort
+	}
+	now := time.Now()
+	if !since.Before(now) {
+		return nil, ErrInvalidTrendRange
+	}
+	if limit < 1 {
+		limit = defaultTrendingLimit
+	}
+	if limit > maxTrendingLimit {
+		limit = maxTrendingLimit
 	}
-	return views, count, nil
+
+	var projectIDs []int64
+	if !scope.AllProjects {
+		if len(scope.ProjectIDs) == 0 {
+			return nil, nil
+		}
+		projectIDs = scope.ProjectIDs
+	}
+	return uc.viewRepo.TopProjects(ctx, since, now, projectIDs, orderBy, limit)
+}
+
+// GetRecentlyViewedProjects returns the user's latest view of each project
+// in scope they viewed, most recent first
+func (uc *AnalyticsUseCase) GetRecentlyViewedProjects(ctx context.Context, userID int64, scope DashboardScope, limit int) ([]*entity.ProjectView, error) {
+	var projectIDs []int64
+	if !scope.AllProjects {
+		if len(scope.ProjectIDs) == 0 {
+			return nil, nil
+		}
+		projectIDs = scope.ProjectIDs
+	}
+	return uc.viewRepo.RecentByUserID(ctx, userID, projectIDs, uc.pages.Limit(limit))
 }
 
 // RecordTaskActivity records a task activity
@@ -59,16 +167,33 @@ func (uc *AnalyticsUseCase) RecordTaskActivity(ctx context.Context, taskID, user
 	return uc.actRepo.Record(ctx, activity)
 }
 
-// GetTaskActivities gets activities for a task
-func (uc *AnalyticsUseCase) GetTaskActivities(ctx context.Context, taskID int64) ([]*entity.TaskActivity, error) {
-	return uc.actRepo.GetByTaskID(ctx, taskID)
+// GetTaskActivities gets a page of activities for a task, or when taskID is
+// 0 for every task in a project or else everything a user did
+func (uc *AnalyticsUseCase) GetTaskActivities(ctx context.Context, taskID, projectID, userID int64, page, limit int) ([]*entity.TaskActivity, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+	switch {
+	case taskID > 0:
+		return uc.actRepo.GetByTaskID(ctx, taskID, page, limit)
+	case projectID > 0:
+		return uc.actRepo.GetByProjectID(ctx, projectID, page, limit)
+	case userID > 0:
+		return uc.actRepo.GetByUserID(ctx, userID, page, limit)
+	}
+	return nil, 0, ErrMissingTarget
 }
 
-// GetProjectStats gets stats for a project
+// GetProjectStats gets stats for a project. A project whose stats were never
+// computed gets zeroed stats; nothing is stored until UpdateProjectStats.
 func (uc *AnalyticsUseCase) GetProjectStats(ctx context.Context, projectID int64) (*entity.ProjectStats, error) {
+	if projectID <= 0 {
+		return nil, ErrInvalidProjectID
+	}
 	stats, err := uc.statsRepo.Get(ctx, projectID)
+	if errors.Is(err, ErrProjectStatsNotFound) {
+		return entity.NewProjectStats(projectID), nil
+	}
 	if err != nil {
-		return nil, ErrProjectStatsNotFound
+		return nil, err
 	}
 	return stats, nil
 }
@@ -81,33 +206,197 @@ func (uc *AnalyticsUseCase) UpdateProjectStats(ctx context.Context, projectID in
 		CompletedTasks: completedTasks,
 	}
 	stats.UpdateProgress()
-	fmt.Println(stats)
 	if err := uc.statsRepo.Upsert(ctx, stats); err != nil {
 		return nil, err
 	}
+	slog.DebugContext(ctx, "project stats updated",
+		"project_id", projectID, "total_tasks", totalTasks, "completed_tasks", completedTasks)
 	return stats, nil
 }
 
-// GetDashboardStats gets dashboard statistics
-func (uc *AnalyticsUseCase) GetDashboardStats(ctx context.Context) (*entity.DashboardStats, error) {
-	allStats, err := uc.statsRepo.GetAll(ctx)
+// StatsRecomputer rebuilds project stats from the task service's counts, so
+// stats that drifted because an event-based update was missed heal themselves
+type StatsRecomputer struct {
+	statsRepo repository.ProjectStatsRepository
+	tasks     repository.TaskCounter
+}
+
+// NewStatsRecomputer creates a new StatsRecomputer
+func NewStatsRecomputer(statsRepo repository.ProjectStatsRepository, tasks repository.TaskCounter) *StatsRecomputer {
+	return &StatsRecomputer{statsRepo: statsRepo, tasks: tasks}
+}
+
+// RecomputeAllProjectStats recounts every project's tasks and upserts the
+// stats that differ, returning how many were updated. Projects whose tasks
+// are all gone are reset to zero. Running it again changes nothing, and a
+// live update racing it is at worst overwritten by a count taken a moment
+// earlier, which the next run corrects. A failed upsert doesn't stop the
+// others; the failures are returned together.
+func (r *StatsRecomputer) RecomputeAllProjectStats(ctx context.Context) (int, error) {
+	counts, err := r.tasks.CountProjectTasks(ctx)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	current, err := r.statsRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	previous := make(map[int64]*entity.ProjectStats, len(current))
+	for _, stats := range current {
+		previous[stats.ProjectID] = stats
+	}
+	fresh := counts
+	counted := make(map[int64]bool, len(counts))
+	for _, count := range counts {
+		counted[count.ProjectID] = true
+	}
+	for _, stats := range current {
+		if !counted[stats.ProjectID] {
+			fresh = append(fresh, entity.NewProjectStats(stats.ProjectID))
+		}
+	}
+
+	updated := 0
+	var errs []error
+	for _, stats := range fresh {
+		if old, ok := previous[stats.ProjectID]; ok &&
+			old.TotalTasks == stats.TotalTasks && old.CompletedTasks == stats.CompletedTasks {
+			continue
+		}
+		stats.UpdateProgress()
+		if err := r.statsRepo.Upsert(ctx, stats); err != nil {
+			errs = append(errs, fmt.Errorf("project %d: %w", stats.ProjectID, err))
+			continue
+		}
+		updated++
+	}
+	return updated, errors.Join(errs...)
+}
+
+// Run recomputes the stats every interval until ctx is cancelled, logging
+// the outcome of each run
+func (r *StatsRecomputer) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			updated, err := r.RecomputeAllProjectStats(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "project stats recompute failed", "updated", updated, "error", err)
+				continue
+			}
+			slog.InfoContext(ctx, "project stats recomputed", "updated", updated)
+		}
 	}
+}
 
-	dashboard := &entity.DashboardStats{
-		ProjectStats: allStats,
+// GetCycleTime gets the average and median lead time of a project's tasks
+// completed between since and until. A project with no tasks completed in
+// the range gets a zero count and zero durations.
+func (uc *AnalyticsUseCase) GetCycleTime(ctx context.Context, projectID int64, since, until time.Time) (*entity.CycleTime, error) {
+	if projectID <= 0 {
+		return nil, ErrInvalidProjectID
+	}
+	if !until.After(since) {
+		return nil, ErrInvalidCycleTimeRange
 	}
+	return uc.actRepo.CycleTime(ctx, projectID, since, until)
+}
+
+// DashboardScope is the set of projects a dashboard aggregates, trending
+// projects are ranked from or recently viewed projects are listed from
+type DashboardScope struct {
+	AllProjects bool    // every project, for admins
+	ProjectIDs  []int64 // the projects the user can access otherwise
+}
+
+// GetDashboardStats gets dashboard statistics. The project totals cover the
+// projects in scope and, for a user, their recent activity, view count and
+// assigned tasks are loaded alongside them concurrently. A section that fails
+// is logged, left empty and named in FailedSections; an error is returned only
+// when every section fails.
+func (uc *AnalyticsUseCase) GetDashboardStats(ctx context.Context, userID int64, scope DashboardScope) (*entity.DashboardStats, error) {
+	dashboard := &entity.DashboardStats{}
 
-	for _, stats := range allStats {
-		dashboard.TotalProjects++
-		if stats.ProgressPercent < 100 {
-			dashboard.ActiveProjects++
+	// Sections report their own errors and never fail the group, so gctx is
+	// only cancelled with ctx or once every section is done
+	g, gctx := errgroup.WithContext(ctx)
+	var (
+		mu       sync.Mutex
+		sections []string
+		errs     = make(map[string]error)
+	)
+	g.SetLimit(dashboardConcurrency)
+	// load runs one section; each writes only its own fields of dashboard
+	load := func(name string, fn func() error) {
+		sections = append(sections, name)
+		g.Go(func() error {
+			if err := fn(); err != nil {
+				mu.Lock()
+				errs[name] = err
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+
+	load(entity.SectionProjectStats, func() error {
+		var allStats []*entity.ProjectStats
+		var err error
+		if scope.AllProjects {
+			allStats, err = uc.statsRepo.GetAll(gctx)
+		} else {
+			allStats, err = uc.statsRepo.GetByProjectIDs(gctx, scope.ProjectIDs)
+		}
+		if err != nil {
+			return err
+		}
+		dashboard.ProjectStats = allStats
+		for _, stats := range allStats {
+			dashboard.TotalProjects++
+			if stats.ProgressPercent < 100 {
+				dashboard.ActiveProjects++
+			}
+			dashboard.TotalTasks += stats.TotalTasks
+			dashboard.CompletedTasks += stats.CompletedTasks
 		}
-		dashboard.TotalTasks += stats.TotalTasks
-		dashboard.CompletedTasks += stats.CompletedTasks
+		dashboard.PendingTasks = dashboard.TotalTasks - dashboard.CompletedTasks
+		return nil
+	})
+
+	if userID > 0 {
+		load(entity.SectionActivity, func() error {
+			activities, _, err := uc.actRepo.GetByUserID(gctx, userID, 1, dashboardRecentActivity)
+			dashboard.RecentActivity = activities
+			return err
+		})
+		load(entity.SectionViews, func() error {
+			views, err := uc.viewRepo.CountByUserID(gctx, userID)
+			dashboard.UserViews = views
+			return err
+		})
+		load(entity.SectionAssignedTasks, func() error {
+			counts, err := uc.actRepo.CountAssignedTasks(gctx, userID)
+			dashboard.AssignedTasks = counts
+			return err
+		})
 	}
-	dashboard.PendingTasks = dashboard.TotalTasks - dashboard.CompletedTasks
+	g.Wait()
 
+	var failed []error
+	for _, name := range sections {
+		if err, ok := errs[name]; ok {
+			slog.WarnContext(ctx, "dashboard section failed", "section", name, "user_id", userID, "error", err)
+			dashboard.FailedSections = append(dashboard.FailedSections, name)
+			failed = append(failed, err)
+		}
+	}
+	if len(failed) == len(sections) {
+		return nil, errors.Join(failed...)
+	}
 	return dashboard, nil
 }
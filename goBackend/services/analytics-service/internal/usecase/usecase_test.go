@@ -0,0 +1,563 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/portfolio/analytics-service/internal/domain/entity"
+	"github.com/portfolio/analytics-service/internal/infrastructure/repository"
+	"github.com/portfolio/analytics-service/internal/testutil"
+	"github.com/portfolio/shared/cache"
+	"github.com/portfolio/shared/pagination"
+)
+
+// MockProjectStatsRepository keeps stats per project in memory
+type MockProjectStatsRepository struct {
+	stats     map[int64]*entity.ProjectStats
+	getErr    error
+	getAllErr error
+	upserts   int
+	reads     int
+}
+
+func (m *MockProjectStatsRepository) Get(ctx context.Context, projectID int64) (*entity.ProjectStats, error) {
+	m.reads++
+	if m.getErr != nil {
+		return nil, m.getErr
+	}
+	if stats, ok := m.stats[projectID]; ok {
+		return stats, nil
+	}
+	return nil, ErrProjectStatsNotFound
+}
+
+func (m *MockProjectStatsRepository) Upsert(ctx context.Context, stats *entity.ProjectStats) error {
+	m.upserts++
+	m.stats[stats.ProjectID] = stats
+	return nil
+}
+
+func (m *MockProjectStatsRepository) GetAll(ctx context.Context) ([]*entity.ProjectStats, error) {
+	m.reads++
+	if m.getAllErr != nil {
+		return nil, m.getAllErr
+	}
+	var all []*entity.ProjectStats
+	for _, stats := range m.stats {
+		all = append(all, stats)
+	}
+	return all, nil
+}
+
+func (m *MockProjectStatsRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) ([]*entity.ProjectStats, error) {
+	m.reads++
+	if m.getAllErr != nil {
+		return nil, m.getAllErr
+	}
+	var found []*entity.ProjectStats
+	for _, id := range projectIDs {
+		if stats, ok := m.stats[id]; ok {
+			found = append(found, stats)
+		}
+	}
+	return found, nil
+}
+
+func TestAnalyticsUseCase_GetProjectStats(t *testing.T) {
+	repo := &MockProjectStatsRepository{stats: map[int64]*entity.ProjectStats{
+		1: {ProjectID: 1, TotalTasks: 8, CompletedTasks: 2, ProgressPercent: 25},
+	}}
+	uc := NewAnalyticsUseCase(nil, nil, repo, pagination.Sizes{})
+
+	stats, err := uc.GetProjectStats(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetProjectStats() error = %v", err)
+	}
+	if stats.TotalTasks != 8 || stats.CompletedTasks != 2 || stats.ProgressPercent != 25 {
+		t.Errorf("GetProjectStats() = %+v, want the stored stats", stats)
+	}
+}
+
+func TestAnalyticsUseCase_GetProjectStats_DefaultsWhenAbsent(t *testing.T) {
+	repo := &MockProjectStatsRepository{stats: map[int64]*entity.ProjectStats{}}
+	uc := NewAnalyticsUseCase(nil, nil, repo, pagination.Sizes{})
+
+	stats, err := uc.GetProjectStats(context.Background(), 42)
+	if err != nil {
+		t.Fatalf("GetProjectStats() error = %v", err)
+	}
+	if stats.ProjectID != 42 || stats.TotalTasks != 0 || stats.CompletedTasks != 0 || stats.ProgressPercent != 0 {
+		t.Errorf("GetProjectStats() = %+v, want zeroed stats for project 42", stats)
+	}
+	if repo.upserts != 0 {
+		t.Errorf("GetProjectStats() stored %d rows, want none", repo.upserts)
+	}
+}
+
+func TestAnalyticsUseCase_GetProjectStats_Errors(t *testing.T) {
+	dbErr := errors.New("connection refused")
+
+	tests := []struct {
+		name      string
+		projectID int64
+		getErr    error
+		wantErr   error
+	}{
+		{"zero id", 0, nil, ErrInvalidProjectID},
+		{"negative id", -3, nil, ErrInvalidProjectID},
+		{"repository failure", 7, dbErr, dbErr},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockProjectStatsRepository{stats: map[int64]*entity.ProjectStats{}, getErr: tt.getErr}
+			uc := NewAnalyticsUseCase(nil, nil, repo, pagination.Sizes{})
+			if _, err := uc.GetProjectStats(context.Background(), tt.projectID); err != tt.wantErr {
+				t.Errorf("GetProjectStats(%d) error = %v, want %v", tt.projectID, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAnalyticsUseCase_CachedProjectStats(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockProjectStatsRepository{stats: map[int64]*entity.ProjectStats{
+		1: {ProjectID: 1, TotalTasks: 8, CompletedTasks: 2, ProgressPercent: 25},
+	}}
+	uc := NewAnalyticsUseCase(nil, nil, repository.NewCachedProjectStatsRepository(repo, cache.NewMemory(), time.Minute), pagination.Sizes{})
+
+	for i := 0; i < 2; i++ {
+		stats, err := uc.GetProjectStats(ctx, 1)
+		if err != nil {
+			t.Fatalf("GetProjectStats() error = %v", err)
+		}
+		if stats.CompletedTasks != 2 {
+			t.Errorf("GetProjectStats() = %+v, want the stored stats", stats)
+		}
+	}
+	if repo.reads != 1 {
+		t.Errorf("repository read %d times, want 1 within the TTL", repo.reads)
+	}
+
+	if _, err := uc.UpdateProjectStats(ctx, 1, 8, 6); err != nil {
+		t.Fatalf("UpdateProjectStats() error = %v", err)
+	}
+	stats, err := uc.GetProjectStats(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetProjectStats() error = %v", err)
+	}
+	if stats.CompletedTasks != 6 || repo.reads != 2 {
+		t.Errorf("after update got %+v in %d reads, want fresh stats from a second read", stats, repo.reads)
+	}
+}
+
+func TestAnalyticsUseCase_CachedDashboardStats(t *testing.T) {
+	ctx := context.Background()
+	repo := &MockProjectStatsRepository{stats: map[int64]*entity.ProjectStats{
+		1: {ProjectID: 1, TotalTasks: 4, CompletedTasks: 1},
+		2: {ProjectID: 2, TotalTasks: 6, CompletedTasks: 6},
+	}}
+	uc := NewAnalyticsUseCase(nil, nil, repository.NewCachedProjectStatsRepository(repo, cache.NewMemory(), time.Minute), pagination.Sizes{})
+
+	scope := DashboardScope{ProjectIDs: []int64{1, 2, 3}}
+	for i := 0; i < 2; i++ {
+		dashboard, err := uc.GetDashboardStats(ctx, 0, scope)
+		if err != nil {
+			t.Fatalf("GetDashboardStats() error = %v", err)
+		}
+		if dashboard.TotalTasks != 10 || dashboard.CompletedTasks != 7 {
+			t.Errorf("dashboard = %+v, want 10 tasks, 7 completed", dashboard)
+		}
+	}
+	// Project 3 has no stats; that is cached too
+	if repo.reads != 1 {
+		t.Errorf("repository read %d times, want 1 within the TTL", repo.reads)
+	}
+
+	if _, err := uc.UpdateProjectStats(ctx, 2, 6, 3); err != nil {
+		t.Fatalf("UpdateProjectStats() error = %v", err)
+	}
+	dashboard, _ := uc.GetDashboardStats(ctx, 0, scope)
+	if dashboard.CompletedTasks != 4 || repo.reads != 2 {
+		t.Errorf("after update got %d completed in %d reads, want 4 from a second read", dashboard.CompletedTasks, repo.reads)
+	}
+}
+
+// failingViewRepository fails every view count
+type failingViewRepository struct {
+	*testutil.ProjectViewRepository
+}
+
+func (failingViewRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	return 0, errors.New("connection refused")
+}
+
+func TestAnalyticsUseCase_GetDashboardStats_PartialResults(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	activity := testutil.NewTaskActivityRepository(store)
+	stats := &MockProjectStatsRepository{stats: map[int64]*entity.ProjectStats{
+		1: {ProjectID: 1, TotalTasks: 4, CompletedTasks: 4, ProgressPercent: 100},
+		2: {ProjectID: 2, TotalTasks: 6, CompletedTasks: 1, ProgressPercent: 16},
+	}}
+	uc := NewAnalyticsUseCase(failingViewRepository{testutil.NewProjectViewRepository(store)}, activity, stats, pagination.Sizes{})
+
+	activity.Record(ctx, entity.NewTaskActivity(10, 7, entity.ActionCompleted))
+	activity.Record(ctx, entity.NewTaskActivity(11, 8, entity.ActionCreated))
+	store.SetTaskAssignee(10, 7, true)
+	store.SetTaskAssignee(12, 7, false)
+	store.SetTaskAssignee(13, 7, false)
+
+	dashboard, err := uc.GetDashboardStats(ctx, 7, DashboardScope{AllProjects: true})
+	if err != nil {
+		t.Fatalf("GetDashboardStats() error = %v", err)
+	}
+	if len(dashboard.FailedSections) != 1 || dashboard.FailedSections[0] != entity.SectionViews {
+		t.Errorf("failed sections = %v, want only %s", dashboard.FailedSections, entity.SectionViews)
+	}
+	if dashboard.TotalProjects != 2 || dashboard.ActiveProjects != 1 || dashboard.PendingTasks != 5 {
+		t.Errorf("totals = %d projects, %d active, %d pending; want 2, 1, 5", dashboard.TotalProjects, dashboard.ActiveProjects, dashboard.PendingTasks)
+	}
+	if len(dashboard.RecentActivity) != 1 || dashboard.RecentActivity[0].TaskID != 10 {
+		t.Errorf("recent activity = %v, want only user 7's activity on task 10", dashboard.RecentActivity)
+	}
+	if got := dashboard.AssignedTasks; got == nil || got.Total != 3 || got.Completed != 1 || got.Pending != 2 {
+		t.Errorf("assigned tasks = %+v, want 3 total, 1 completed, 2 pending", got)
+	}
+}
+
+func TestAnalyticsUseCase_GetDashboardStats_AllSectionsFail(t *testing.T) {
+	stats := &MockProjectStatsRepository{getAllErr: errors.New("connection refused")}
+	uc := NewAnalyticsUseCase(nil, nil, stats, pagination.Sizes{})
+
+	// Without a user the project totals are the only section
+	if _, err := uc.GetDashboardStats(context.Background(), 0, DashboardScope{AllProjects: true}); err == nil {
+		t.Error("GetDashboardStats() error = nil, want the stats failure")
+	}
+}
+
+func TestAnalyticsUseCase_GetDashboardStats_ScopedToAccessibleProjects(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	stats := testutil.NewProjectStatsRepository(store)
+	uc := NewAnalyticsUseCase(testutil.NewProjectViewRepository(store), testutil.NewTaskActivityRepository(store), stats, pagination.Sizes{})
+
+	stats.Upsert(ctx, &entity.ProjectStats{ProjectID: 1, TotalTasks: 4, CompletedTasks: 4, ProgressPercent: 100})
+	stats.Upsert(ctx, &entity.ProjectStats{ProjectID: 2, TotalTasks: 6, CompletedTasks: 1, ProgressPercent: 16})
+	stats.Upsert(ctx, &entity.ProjectStats{ProjectID: 3, TotalTasks: 10, CompletedTasks: 5, ProgressPercent: 50})
+
+	tests := []struct {
+		name         string
+		scope        DashboardScope
+		wantProjects int
+		wantTasks    int
+		wantPending  int
+	}{
+		{"user with projects 1 and 2", DashboardScope{ProjectIDs: []int64{1, 2}}, 2, 10, 5},
+		{"user with project 3 and a project without stats", DashboardScope{ProjectIDs: []int64{3, 9}}, 1, 10, 5},
+		{"user without access", DashboardScope{}, 0, 0, 0},
+		{"admin", DashboardScope{AllProjects: true}, 3, 20, 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dashboard, err := uc.GetDashboardStats(ctx, 0, tt.scope)
+			if err != nil {
+				t.Fatalf("GetDashboardStats() error = %v", err)
+			}
+			if dashboard.TotalProjects != tt.wantProjects || dashboard.TotalTasks != tt.wantTasks || dashboard.PendingTasks != tt.wantPending {
+				t.Errorf("totals = %d projects, %d tasks, %d pending; want %d, %d, %d",
+					dashboard.TotalProjects, dashboard.TotalTasks, dashboard.PendingTasks, tt.wantProjects, tt.wantTasks, tt.wantPending)
+			}
+			if len(dashboard.ProjectStats) != tt.wantProjects {
+				t.Errorf("project stats = %d entries, want %d", len(dashboard.ProjectStats), tt.wantProjects)
+			}
+		})
+	}
+}
+
+func TestAnalyticsUseCase_BatchRecordProjectViews(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	views := testutil.NewProjectViewRepository(store)
+	uc := NewAnalyticsUseCase(views, nil, nil, pagination.Sizes{})
+
+	now := time.Now()
+	views.Record(ctx, &entity.ProjectView{ProjectID: 1, UserID: 6, ViewedAt: now.Add(-10 * time.Minute)})
+
+	recorded, skipped, err := uc.BatchRecordProjectViews(ctx, []*entity.ProjectView{
+		{ProjectID: 1, UserID: 5, ViewedAt: now.Add(-25 * time.Minute)},
+		{ProjectID: 1, UserID: 5, ViewedAt: now.Add(-20 * time.Minute)}, // repeat within the window
+		{ProjectID: 3, UserID: 5},                                // no time, so now
+		{ProjectID: 1, UserID: 6, ViewedAt: now},                 // already stored
+		{ProjectID: 0, UserID: 5, ViewedAt: now},                 // invalid project
+		{ProjectID: 2, UserID: 5, ViewedAt: now.Add(time.Hour)},  // in the future
+		{ProjectID: 2, UserID: 7, ViewedAt: now.Add(-time.Hour)}, // older than the window
+	})
+	if err != nil {
+		t.Fatalf("BatchRecordProjectViews() error = %v", err)
+	}
+	if recorded != 2 || skipped != 5 {
+		t.Errorf("BatchRecordProjectViews() = %d recorded, %d skipped; want 2, 5", recorded, skipped)
+	}
+	if count, _ := views.CountByProjectID(ctx, 1); count != 2 {
+		t.Errorf("project 1 has %d views, want 2", count)
+	}
+	if count, _ := views.CountByProjectID(ctx, 2); count != 0 {
+		t.Errorf("project 2 has %d views, want the backdated and future ones skipped", count)
+	}
+
+	if _, _, err := uc.BatchRecordProjectViews(ctx, nil); err != ErrEmptyViewBatch {
+		t.Errorf("empty batch error = %v, want %v", err, ErrEmptyViewBatch)
+	}
+	tooMany := make([]*entity.ProjectView, MaxViewBatch+1)
+	if _, _, err := uc.BatchRecordProjectViews(ctx, tooMany); err != ErrViewBatchTooLarge {
+		t.Errorf("oversized batch error = %v, want %v", err, ErrViewBatchTooLarge)
+	}
+}
+
+func TestAnalyticsUseCase_GetTrendingProjects(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	views := testutil.NewProjectViewRepository(store)
+	uc := NewAnalyticsUseCase(views, nil, nil, pagination.Sizes{})
+
+	now := time.Now()
+	seed := func(projectID int64, ages ...time.Duration) {
+		for _, age := range ages {
+			views.Record(ctx, &entity.ProjectView{ProjectID: projectID, UserID: 1, ViewedAt: now.Add(-age)})
+		}
+	}
+	day := 24 * time.Hour
+	seed(1, 6*day, 6*day, 6*day, 5*day)  // most views, but old
+	seed(2, time.Hour, 2*time.Hour, day) // fewer, recent
+	seed(3, 3*day)
+	seed(4, 10*day, 10*day, 10*day, 10*day, 10*day) // outside the range
+
+	since := now.Add(-7 * day)
+	tests := []struct {
+		name    string
+		scope   DashboardScope
+		orderBy string
+		limit   int
+		want    []int64
+	}{
+		{"trending", DashboardScope{AllProjects: true}, "", 0, []int64{2, 1, 3}},
+		{"most viewed", DashboardScope{AllProjects: true}, entity.TrendSortViews, 0, []int64{1, 2, 3}},
+		{"limited", DashboardScope{AllProjects: true}, entity.TrendSortViews, 1, []int64{1}},
+		{"scoped", DashboardScope{ProjectIDs: []int64{1, 3}}, "", 0, []int64{1, 3}},
+		{"no access", DashboardScope{}, "", 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trends, err := uc.GetTrendingProjects(ctx, since, tt.scope, tt.orderBy, tt.limit)
+			if err != nil {
+				t.Fatalf("GetTrendingProjects() error = %v", err)
+			}
+			var got []int64
+			for _, trend := range trends {
+				got = append(got, trend.ProjectID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ranking = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := uc.GetTrendingProjects(ctx, now.Add(time.Hour), DashboardScope{AllProjects: true}, "", 0); err != ErrInvalidTrendRange {
+		t.Errorf("future range error = %v, want %v", err, ErrInvalidTrendRange)
+	}
+	if _, err := uc.GetTrendingProjects(ctx, since, DashboardScope{AllProjects: true}, "newest", 0); err != ErrInvalidTrendSort {
+		t.Errorf("unknown sort error = %v, want %v", err, ErrInvalidTrendSort)
+	}
+}
+
+func TestAnalyticsUseCase_GetRecentlyViewedProjects(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	views := testutil.NewProjectViewRepository(store)
+	uc := NewAnalyticsUseCase(views, nil, nil, pagination.Sizes{})
+
+	now := time.Now()
+	seed := func(projectID, userID int64, age time.Duration) {
+		views.Record(ctx, &entity.ProjectView{ProjectID: projectID, UserID: userID, ViewedAt: now.Add(-age)})
+	}
+	seed(1, 5, 3*time.Hour)
+	seed(2, 5, 2*time.Hour)
+	seed(1, 5, time.Hour) // viewed again, so project 1 moves ahead of 2
+	seed(3, 5, 5*time.Hour)
+	seed(4, 6, time.Minute) // another user's view
+	seed(2, 5, 4*time.Hour)
+
+	all := DashboardScope{AllProjects: true}
+	tests := []struct {
+		name  string
+		scope DashboardScope
+		limit int
+		want  []int64
+	}{
+		{"all", all, 0, []int64{1, 2, 3}},
+		{"limited", all, 2, []int64{1, 2}},
+		{"scoped", DashboardScope{ProjectIDs: []int64{2, 3, 4}}, 0, []int64{2, 3}},
+		{"no accessible projects", DashboardScope{}, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recent, err := uc.GetRecentlyViewedProjects(ctx, 5, tt.scope, tt.limit)
+			if err != nil {
+				t.Fatalf("GetRecentlyViewedProjects() error = %v", err)
+			}
+			var got []int64
+			for _, v := range recent {
+				got = append(got, v.ProjectID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("projects = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	recent, _ := uc.GetRecentlyViewedProjects(ctx, 5, all, 0)
+	if age := now.Sub(recent[0].ViewedAt); age != time.Hour {
+		t.Errorf("project 1 viewed %v ago, want its latest view an hour ago", age)
+	}
+}
+
+func TestAnalyticsUseCase_GetCycleTime(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := NewAnalyticsUseCase(nil, testutil.NewTaskActivityRepository(store), nil, pagination.Sizes{})
+
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	seed := func(taskID, projectID int64, completed time.Time, leadTime time.Duration) {
+		store.SetTaskCompletion(taskID, projectID, completed.Add(-leadTime), completed)
+	}
+	seed(1, 1, start.Add(24*time.Hour), 2*time.Hour)
+	seed(2, 1, start.Add(48*time.Hour), 4*time.Hour)
+	seed(3, 1, start.Add(72*time.Hour), 12*time.Hour)
+	seed(4, 1, start.Add(96*time.Hour), 30*time.Hour)
+	seed(5, 1, start.Add(-time.Hour), 100*time.Hour) // completed before the range
+	seed(6, 2, start.Add(24*time.Hour), time.Hour)   // another project
+
+	cycle, err := uc.GetCycleTime(ctx, 1, start, end)
+	if err != nil {
+		t.Fatalf("GetCycleTime() error = %v", err)
+	}
+	want := &entity.CycleTime{
+		ProjectID:       1,
+		CompletedTasks:  4,
+		AverageLeadTime: 12 * time.Hour, // (2+4+12+30)/4
+		MedianLeadTime:  8 * time.Hour,  // between 4 and 12
+	}
+	if !reflect.DeepEqual(cycle, want) {
+		t.Errorf("GetCycleTime() = %+v, want %+v", cycle, want)
+	}
+
+	seed(7, 1, start.Add(120*time.Hour), 5*time.Hour)
+	if cycle, _ := uc.GetCycleTime(ctx, 1, start, end); cycle.MedianLeadTime != 5*time.Hour {
+		t.Errorf("odd count median = %v, want 5h", cycle.MedianLeadTime)
+	}
+
+	empty, err := uc.GetCycleTime(ctx, 3, start, end)
+	if err != nil {
+		t.Fatalf("GetCycleTime() without completed tasks error = %v", err)
+	}
+	if *empty != (entity.CycleTime{ProjectID: 3}) {
+		t.Errorf("without completed tasks = %+v, want zero counts", empty)
+	}
+
+	if _, err := uc.GetCycleTime(ctx, 0, start, end); err != ErrInvalidProjectID {
+		t.Errorf("invalid project error = %v, want %v", err, ErrInvalidProjectID)
+	}
+	if _, err := uc.GetCycleTime(ctx, 1, end, start); err != ErrInvalidCycleTimeRange {
+		t.Errorf("reversed range error = %v, want %v", err, ErrInvalidCycleTimeRange)
+	}
+}
+
+// stubTaskCounter returns fixed task counts in place of the task service
+type stubTaskCounter struct {
+	counts []*entity.ProjectStats
+	err    error
+}
+
+func (s *stubTaskCounter) CountProjectTasks(ctx context.Context) ([]*entity.ProjectStats, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	// Each call returns fresh stats, like a new response would
+	counts := make([]*entity.ProjectStats, len(s.counts))
+	for i, c := range s.counts {
+		copied := *c
+		counts[i] = &copied
+	}
+	return counts, nil
+}
+
+func TestStatsRecomputer_RecomputeAllProjectStats(t *testing.T) {
+	ctx := context.Background()
+	stats := testutil.NewProjectStatsRepository(testutil.NewStore())
+	stats.Upsert(ctx, &entity.ProjectStats{ProjectID: 1, TotalTasks: 10, CompletedTasks: 2, ProgressPercent: 20}) // drifted
+	stats.Upsert(ctx, &entity.ProjectStats{ProjectID: 2, TotalTasks: 4, CompletedTasks: 4, ProgressPercent: 100}) // up to date
+	stats.Upsert(ctx, &entity.ProjectStats{ProjectID: 3, TotalTasks: 3, CompletedTasks: 1, ProgressPercent: 33})  // tasks all deleted
+	unchanged, _ := stats.Get(ctx, 2)
+
+	tasks := &stubTaskCounter{counts: []*entity.ProjectStats{
+		{ProjectID: 1, TotalTasks: 10, CompletedTasks: 5},
+		{ProjectID: 2, TotalTasks: 4, CompletedTasks: 4},
+		{ProjectID: 4, TotalTasks: 2, CompletedTasks: 0}, // no stats yet
+	}}
+	recomputer := NewStatsRecomputer(stats, tasks)
+
+	updated, err := recomputer.RecomputeAllProjectStats(ctx)
+	if err != nil {
+		t.Fatalf("RecomputeAllProjectStats() error = %v", err)
+	}
+	if updated != 3 {
+		t.Errorf("updated = %d, want 3", updated)
+	}
+
+	want := map[int64][3]float64{ // total, completed, progress
+		1: {10, 5, 50},
+		2: {4, 4, 100},
+		3: {0, 0, 0},
+		4: {2, 0, 0},
+	}
+	all, _ := stats.GetAll(ctx)
+	if len(all) != len(want) {
+		t.Fatalf("got stats for %d projects, want %d", len(all), len(want))
+	}
+	for _, s := range all {
+		got := [3]float64{float64(s.TotalTasks), float64(s.CompletedTasks), s.ProgressPercent}
+		if got != want[s.ProjectID] {
+			t.Errorf("project %d stats = %v, want %v", s.ProjectID, got, want[s.ProjectID])
+		}
+	}
+	if s, _ := stats.Get(ctx, 2); !s.LastUpdated.Equal(unchanged.LastUpdated) {
+		t.Errorf("up to date stats were rewritten")
+	}
+
+	// A second run finds nothing to fix
+	if updated, err := recomputer.RecomputeAllProjectStats(ctx); err != nil || updated != 0 {
+		t.Errorf("second run = %d, %v; want 0 updates", updated, err)
+	}
+}
+
+func TestStatsRecomputer_TaskServiceDown(t *testing.T) {
+	ctx := context.Background()
+	stats := testutil.NewProjectStatsRepository(testutil.NewStore())
+	stats.Upsert(ctx, &entity.ProjectStats{ProjectID: 1, TotalTasks: 10, CompletedTasks: 2, ProgressPercent: 20})
+
+	down := errors.New("task service unavailable")
+	recomputer := NewStatsRecomputer(stats, &stubTaskCounter{err: down})
+	if _, err := recomputer.RecomputeAllProjectStats(ctx); !errors.Is(err, down) {
+		t.Fatalf("error = %v, want %v", err, down)
+	}
+	// Without counts nothing may be reset
+	if s, _ := stats.Get(ctx, 1); s.TotalTasks != 10 || s.CompletedTasks != 2 {
+		t.Errorf("stats = %+v, want them left alone", s)
+	}
+}
@@ -1,32 +1,63 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
+	"time"
 
 	"github.com/portfolio/auth-service/internal/config"
 	grpcHandler "github.com/portfolio/auth-service/internal/delivery/grpc"
+	domain "github.com/portfolio/auth-service/internal/domain/repository"
+	"github.com/portfolio/auth-service/internal/infrastructure/project"
 	"github.com/portfolio/auth-service/internal/infrastructure/repository"
 	"github.com/portfolio/auth-service/internal/usecase"
 	pb "github.com/portfolio/proto/auth"
+	"github.com/portfolio/shared/cache"
 	"github.com/portfolio/shared/database"
+	"github.com/portfolio/shared/grpctls"
+	"github.com/portfolio/shared/jwt"
+	"github.com/portfolio/shared/logging"
+	"github.com/portfolio/shared/metrics"
 	"github.com/portfolio/shared/middleware"
+	"github.com/portfolio/shared/migrations"
+	"github.com/portfolio/shared/pagination"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply database migrations and exit")
+	flag.Parse()
+
+	logging.Setup("auth-service")
+
 	// Load configuration
 	cfg := config.Load()
-	fmt.Println(cfg)
+	tlsConfig := grpctls.Config{
+		Enabled:  cfg.GRPCTLSEnabled,
+		CertFile: cfg.GRPCTLSCertFile,
+		KeyFile:  cfg.GRPCTLSKeyFile,
+		CAFile:   cfg.GRPCTLSCAFile,
+	}
+
 	// Initialize database connection
 	dbConfig := database.Config{
-		Host:     cfg.DBHost,
-		Port:     cfg.DBPort,
-		User:     cfg.DBUser,
-		Password: cfg.DBPassword,
-		DBName:   cfg.DBName,
-		SSLMode:  cfg.DBSSLMode,
+		Host:        cfg.DBHost,
+		Port:        cfg.DBPort,
+		User:        cfg.DBUser,
+		Password:    cfg.DBPassword,
+		DBName:      cfg.DBName,
+		SSLMode:     cfg.DBSSLMode,
+		SSLRootCert: cfg.DBSSLRootCert,
+		SSLCert:     cfg.DBSSLCert,
+		SSLKey:      cfg.DBSSLKey,
+
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
 	}
 
 	pool, err := database.NewPool(dbConfig)
@@ -37,26 +68,84 @@ func main() {
 
 	db := pool.GetDB()
 
+	// Apply pending schema migrations
+	if _, err := migrations.Run(context.Background(), db); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if *migrateOnly {
+		log.Println("Migrations applied")
+		return
+	}
+
+	// Calls to other services use the same TLS settings as the server
+	dialCreds, err := grpctls.DialOption(tlsConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up gRPC client TLS: %v", err)
+	}
+
+	// Project service supplies project details for access listings
+	projectConn, err := grpc.Dial(cfg.ProjectServiceURL, dialCreds)
+	if err != nil {
+		log.Fatalf("Failed to connect to project service: %v", err)
+	}
+	defer projectConn.Close()
+
 	// Initialize repositories
 	userRepo := repository.NewPostgresUserRepository(db)
 	roleRepo := repository.NewPostgresRoleRepository(db)
-	accessRepo := repository.NewPostgresUserProjectAccessRepository(db)
+	var accessRepo domain.UserProjectAccessRepository = repository.NewPostgresUserProjectAccessRepository(db)
+	// Every project and task request checks access, so lookups may be
+	// cached; Redis shares the cache and its invalidations between replicas.
+	if cfg.AccessCacheTTL > 0 {
+		accessCache := cache.New(context.Background(), cache.Config{RedisURL: cfg.RedisURL, Namespace: "auth"})
+		accessRepo = repository.NewCachedUserProjectAccessRepository(accessRepo, accessCache, cfg.AccessCacheTTL)
+	}
+	auditRepo := repository.NewPostgresAuditRepository(db)
+	revocationRepo := repository.NewPostgresRevocationRepository(db)
+
+	// Tokens are signed here; other services only validate them
+	tokenSvc, err := jwt.NewTokenServiceFromConfig(jwt.Config{
+		Algorithm:      cfg.JWTAlgorithm,
+		Secret:         cfg.JWTSecret,
+		PrivateKeyFile: cfg.JWTPrivateKeyFile,
+		PublicKeyFile:  cfg.JWTPublicKeyFile,
+	}, 24*time.Hour)
+	if err != nil {
+		log.Fatalf("Failed to set up token signing: %v", err)
+	}
 
 	// Initialize use cases
-	authUseCase := usecase.NewAuthUseCase(userRepo, roleRepo, accessRepo, cfg.JWTSecret)
+	pages := pagination.New(cfg.DefaultPageSize, cfg.MaxPageSize)
+	authUseCase := usecase.NewAuthUseCase(userRepo, roleRepo, accessRepo, auditRepo, revocationRepo, tokenSvc, pages)
 	roleUseCase := usecase.NewRoleUseCase(roleRepo)
-	accessUseCase := usecase.NewAccessUseCase(accessRepo)
+	accessUseCase := usecase.NewAccessUseCase(accessRepo, auditRepo, project.NewClient(projectConn), pages)
+	auditUseCase := usecase.NewAuditUseCase(auditRepo, pages)
+
+	// Metrics
+	registry := metrics.NewRegistry()
+	grpcMetrics := metrics.NewGRPCServerMetrics(registry)
+	metrics.Serve(cfg.MetricsPort, registry)
+
+	// Plaintext unless GRPC_TLS_ENABLED is set
+	serverCreds, err := grpctls.ServerOption(tlsConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up gRPC TLS: %v", err)
+	}
 
 	// Create gRPC server with middleware
 	grpcServer := grpc.NewServer(
+		serverCreds,
 		grpc.ChainUnaryInterceptor(
 			middleware.RecoveryInterceptor(),
+			grpcMetrics.UnaryInterceptor(),
 			middleware.LoggingInterceptor(),
+			middleware.IdentityInterceptor(),
+			middleware.ValidationInterceptor(),
 		),
 	)
 
 	// Register auth service
-	authServer := grpcHandler.NewAuthServer(authUseCase, roleUseCase, accessUseCase)
+	authServer := grpcHandler.NewAuthServer(authUseCase, roleUseCase, accessUseCase, auditUseCase)
 	pb.RegisterAuthServiceServer(grpcServer, authServer)
 
 	// Start server
@@ -3,36 +3,88 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config holds the application configuration
 type Config struct {
 	// Server
-	GRPCPort int
+	GRPCPort        int
+	GRPCTLSEnabled  bool
+	GRPCTLSCertFile string
+	GRPCTLSKeyFile  string
+	GRPCTLSCAFile   string
+	MetricsPort     int
 
 	// Database
-	DBHost     string
-	DBPort     int
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
-
-	// JWT
-	JWTSecret string
+	DBHost            string
+	DBPort            int
+	DBUser            string
+	DBPassword        string
+	DBName            string
+	DBSSLMode         string
+	DBSSLRootCert     string
+	DBSSLCert         string
+	DBSSLKey          string
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+
+	// List pages hold DefaultPageSize items unless a request asks for
+	// another size, up to MaxPageSize
+	DefaultPageSize int
+	MaxPageSize     int
+
+	// JWT; RS256 signs with the private key so other services only need
+	// the public key
+	JWTAlgorithm      string
+	JWTSecret         string
+	JWTPrivateKeyFile string
+	JWTPublicKeyFile  string
+
+	// Project service, for project details in access listings
+	ProjectServiceURL string
+
+	// AccessCacheTTL is how long access lookups are cached; 0 disables.
+	// Without Redis each replica caches on its own, so a revoked level can
+	// linger on the others until the TTL runs out.
+	AccessCacheTTL time.Duration
+	// RedisURL points the access cache at Redis; empty keeps it in memory
+	RedisURL string
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
-		GRPCPort:   getEnvInt("GRPC_PORT", 50051),
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnvInt("DB_PORT", 5432),
-		DBUser:     getEnv("DB_USER", "postgres"),
-		DBPassword: getEnv("DB_PASSWORD", "123456789"),
-		DBName:     getEnv("DB_NAME", "gobackend"),
-		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
-		JWTSecret:  getEnv("JWT_SECRET", "development-secret-key"),
+		GRPCPort:          getEnvInt("GRPC_PORT", 50051),
+		GRPCTLSEnabled:    getEnvBool("GRPC_TLS_ENABLED", false),
+		GRPCTLSCertFile:   getEnv("GRPC_TLS_CERT_FILE", ""),
+		GRPCTLSKeyFile:    getEnv("GRPC_TLS_KEY_FILE", ""),
+		GRPCTLSCAFile:     getEnv("GRPC_TLS_CA_FILE", ""),
+		MetricsPort:       getEnvInt("METRICS_PORT", 9101),
+		DBHost:            getEnv("DB_HOST", "localhost"),
+		DBPort:            getEnvInt("DB_PORT", 5432),
+		DBUser:            getEnv("DB_USER", "postgres"),
+		DBPassword:        getEnv("DB_PASSWORD", "123456789"),
+		DBName:            getEnv("DB_NAME", "gobackend"),
+		DBSSLMode:         getEnv("DB_SSL_MODE", "disable"),
+		DBSSLRootCert:     getEnv("DB_SSL_ROOT_CERT", ""),
+		DBSSLCert:         getEnv("DB_SSL_CERT", ""),
+		DBSSLKey:          getEnv("DB_SSL_KEY", ""),
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		DBConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 0),
+		DefaultPageSize:   getEnvInt("DEFAULT_PAGE_SIZE", 20),
+		MaxPageSize:       getEnvInt("MAX_PAGE_SIZE", 100),
+		JWTAlgorithm:      getEnv("JWT_ALGORITHM", "HS256"),
+		JWTSecret:         getEnv("JWT_SECRET", "development-secret-key"),
+		JWTPrivateKeyFile: getEnv("JWT_PRIVATE_KEY_FILE", ""),
+		JWTPublicKeyFile:  getEnv("JWT_PUBLIC_KEY_FILE", ""),
+		ProjectServiceURL: getEnv("PROJECT_SERVICE_URL", "localhost:50052"),
+		AccessCacheTTL:    getEnvDuration("ACCESS_CACHE_TTL", 0),
+		RedisURL:          getEnv("REDIS_URL", ""),
 	}
 }
 
@@ -51,3 +103,21 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -2,11 +2,14 @@ package grpc
 
 import (
 	"context"
+	"strings"
 
 	"github.com/portfolio/auth-service/internal/domain/entity"
+	"github.com/portfolio/auth-service/internal/domain/repository"
 	"github.com/portfolio/auth-service/internal/usecase"
 	pb "github.com/portfolio/proto/auth"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -17,6 +20,7 @@ type AuthServer struct {
 	authUseCase   *usecase.AuthUseCase
 	roleUseCase   *usecase.RoleUseCase
 	accessUseCase *usecase.AccessUseCase
+	auditUseCase  *usecase.AuditUseCase
 }
 
 // NewAuthServer creates a new AuthServer
@@ -24,11 +28,13 @@ func NewAuthServer(
 	authUseCase *usecase.AuthUseCase,
 	roleUseCase *usecase.RoleUseCase,
 	accessUseCase *usecase.AccessUseCase,
+	auditUseCase *usecase.AuditUseCase,
 ) *AuthServer {
 	return &AuthServer{
 		authUseCase:   authUseCase,
 		roleUseCase:   roleUseCase,
 		accessUseCase: accessUseCase,
+		auditUseCase:  auditUseCase,
 	}
 }
 
@@ -94,6 +100,17 @@ func (s *AuthServer) ValidateToken(ctx context.Context, req *pb.ValidateTokenReq
 	}, nil
 }
 
+// Logout revokes a token so it is no longer accepted
+func (s *AuthServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Empty, error) {
+	if err := s.authUseCase.Logout(ctx, req.Token); err != nil {
+		if err == usecase.ErrInvalidToken {
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Empty{}, nil
+}
+
 // GetUser retrieves a user by ID
 func (s *AuthServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.UserResponse, error) {
 	user, err := s.authUseCase.GetUser(ctx, req.Id)
@@ -109,7 +126,7 @@ func (s *AuthServer) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.U
 
 // UpdateUser updates a user
 func (s *AuthServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest) (*pb.UserResponse, error) {
-	user, err := s.authUseCase.UpdateUser(ctx, req.Id, req.Username, req.Email, req.Role)
+	user, err := s.authUseCase.UpdateUser(ctx, s.optionalCaller(ctx), req.Id, req.Username, req.Email, req.Role)
 	if err != nil {
 		if err == usecase.ErrUserNotFound {
 			return nil, status.Error(codes.NotFound, "user not found")
@@ -122,7 +139,7 @@ func (s *AuthServer) UpdateUser(ctx context.Context, req *pb.UpdateUserRequest)
 
 // DeleteUser deletes a user
 func (s *AuthServer) DeleteUser(ctx context.Context, req *pb.DeleteUserRequest) (*pb.Empty, error) {
-	if err := s.authUseCase.DeleteUser(ctx, req.Id); err != nil {
+	if err := s.authUseCase.DeleteUser(ctx, s.optionalCaller(ctx), req.Id); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
@@ -144,6 +161,7 @@ func (s *AuthServer) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*
 	return &pb.ListUsersResponse{
 		Users: protoUsers,
 		Total: int32(total),
+		Limit: int32(s.authUseCase.PageLimit(int(req.Limit))),
 	}, nil
 }
 
@@ -172,8 +190,9 @@ func (s *AuthServer) GetRoles(ctx context.Context, req *pb.Empty) (*pb.ListRoles
 	protoRoles := make([]*pb.Role, len(roles))
 	for i, role := range roles {
 		protoRoles[i] = &pb.Role{
-			Id:   role.ID,
-			Name: role.Name,
+			Id:          role.ID,
+			Name:        role.Name,
+			Permissions: role.Permissions,
 		}
 	}
 
@@ -199,10 +218,18 @@ func (s *AuthServer) GetUserProjectAccess(ctx context.Context, req *pb.GetUserPr
 	return &pb.UserProjectAccessResponse{Accesses: protoAccesses}, nil
 }
 
-// SetUserProjectAccess sets user's access to a project
+// SetUserProjectAccess sets user's access to a project. The caller must be
+// a global admin or an admin of the project.
 func (s *AuthServer) SetUserProjectAccess(ctx context.Context, req *pb.SetUserProjectAccessRequest) (*pb.Empty, error) {
-	if err := s.accessUseCase.SetAccess(ctx, req.UserId, req.ProjectId, req.AccessLevel); err != nil {
-		if err == usecase.ErrInvalidAccessLevel {
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accessUseCase.SetAccess(ctx, caller, req.UserId, req.ProjectId, req.AccessLevel); err != nil {
+		switch err {
+		case usecase.ErrAccessDenied:
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case usecase.ErrInvalidAccessLevel:
 			return nil, status.Error(codes.InvalidArgument, "invalid access level")
 		}
 		return nil, status.Error(codes.Internal, err.Error())
@@ -211,11 +238,119 @@ func (s *AuthServer) SetUserProjectAccess(ctx context.Context, req *pb.SetUserPr
 	return &pb.Empty{}, nil
 }
 
-// RemoveUserProjectAccess removes user's access to a project
+// RemoveUserProjectAccess removes user's access to a project. The caller
+// must be a global admin or an admin of the project.
 func (s *AuthServer) RemoveUserProjectAccess(ctx context.Context, req *pb.RemoveUserProjectAccessRequest) (*pb.Empty, error) {
-	if err := s.accessUseCase.RemoveAccess(ctx, req.UserId, req.ProjectId); err != nil {
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.accessUseCase.RemoveAccess(ctx, caller, req.UserId, req.ProjectId); err != nil {
+		if err == usecase.ErrAccessDenied {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	return &pb.Empty{}, nil
 }
+
+// CheckAccess returns a user's access level on a project and whether it
+// meets the requested minimum
+func (s *AuthServer) CheckAccess(ctx context.Context, req *pb.CheckAccessRequest) (*pb.CheckAccessResponse, error) {
+	level, allowed, err := s.accessUseCase.CheckAccess(ctx, req.UserId, req.ProjectId, req.MinLevel)
+	if err != nil {
+		if err == usecase.ErrInvalidAccessLevel {
+			return nil, status.Error(codes.InvalidArgument, "invalid access level")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.CheckAccessResponse{AccessLevel: level, Allowed: allowed}, nil
+}
+
+// ListUserProjects returns a page of a user's accessible projects with
+// their details
+func (s *AuthServer) ListUserProjects(ctx context.Context, req *pb.ListUserProjectsRequest) (*pb.ListUserProjectsResponse, error) {
+	projects, total, err := s.accessUseCase.ListUserProjects(ctx, req.UserId, int(req.Page), int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoProjects := make([]*pb.UserProject, len(projects))
+	for i, p := range projects {
+		protoProjects[i] = &pb.UserProject{
+			ProjectId:   p.Access.ProjectID,
+			AccessLevel: p.Access.AccessLevel,
+		}
+		if p.Project != nil {
+			protoProjects[i].Name = p.Project.Name
+			protoProjects[i].Description = p.Project.Description
+			protoProjects[i].Status = p.Project.Status
+		}
+	}
+	return &pb.ListUserProjectsResponse{
+		Projects: protoProjects,
+		Total:    int32(total),
+		Limit:    int32(s.accessUseCase.PageLimit(int(req.Limit))),
+	}, nil
+}
+
+// GetAuditLog returns a page of the audit log, newest first. The caller
+// must be a global admin.
+func (s *AuthServer) GetAuditLog(ctx context.Context, req *pb.GetAuditLogRequest) (*pb.GetAuditLogResponse, error) {
+	caller, err := s.caller(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter := repository.AuditFilter{ActorID: req.ActorId, Action: req.Action}
+	entries, total, err := s.auditUseCase.ListEntries(ctx, caller, filter, int(req.Page), int(req.Limit))
+	if err != nil {
+		if err == usecase.ErrAdminRequired {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoEntries := make([]*pb.AuditEntry, len(entries))
+	for i, entry := range entries {
+		protoEntries[i] = &pb.AuditEntry{
+			Id:        entry.ID,
+			ActorId:   entry.ActorID,
+			Action:    entry.Action,
+			Target:    entry.Target,
+			Details:   entry.Details,
+			Ip:        entry.IP,
+			CreatedAt: timestamppb.New(entry.CreatedAt),
+		}
+	}
+	return &pb.GetAuditLogResponse{
+		Entries: protoEntries,
+		Total:   int32(total),
+		Limit:   int32(s.auditUseCase.PageLimit(int(req.Limit))),
+	}, nil
+}
+
+// optionalCaller returns the caller like caller, or nil when there is no
+// valid token, for operations that only use it to attribute audit entries
+func (s *AuthServer) optionalCaller(ctx context.Context) *entity.User {
+	user, err := s.caller(ctx)
+	if err != nil {
+		return nil
+	}
+	return user
+}
+
+// caller returns the user whose bearer token is in the incoming
+// "authorization" metadata
+func (s *AuthServer) caller(ctx context.Context) (*entity.User, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 || !strings.HasPrefix(values[0], "Bearer ") {
+		return nil, status.Error(codes.Unauthenticated, "authorization token not provided")
+	}
+	user, err := s.authUseCase.ValidateToken(ctx, strings.TrimPrefix(values[0], "Bearer "))
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
+	}
+	return user, nil
+}
@@ -0,0 +1,83 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/portfolio/auth-service/internal/domain/entity"
+	"github.com/portfolio/auth-service/internal/usecase"
+	pb "github.com/portfolio/proto/auth"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// accessRepo serves one access level per user on project 10
+type accessRepo map[int64]string
+
+func (r accessRepo) Set(ctx context.Context, access *entity.UserProjectAccess) error { return nil }
+
+func (r accessRepo) Get(ctx context.Context, userID, projectID int64) (*entity.UserProjectAccess, error) {
+	level, ok := r[userID]
+	if !ok || projectID != 10 {
+		return nil, sql.ErrNoRows
+	}
+	return &entity.UserProjectAccess{UserID: userID, ProjectID: projectID, AccessLevel: level}, nil
+}
+
+func (r accessRepo) GetByUserID(ctx context.Context, userID int64) ([]*entity.UserProjectAccess, error) {
+	return nil, nil
+}
+
+func (r accessRepo) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.UserProjectAccess, error) {
+	return nil, nil
+}
+
+func (r accessRepo) Remove(ctx context.Context, userID, projectID int64) error { return nil }
+
+func TestAuthServer_CheckAccess(t *testing.T) {
+	repo := accessRepo{1: entity.AccessLevelRead, 2: entity.AccessLevelWrite, 3: entity.AccessLevelAdmin}
+	s := NewAuthServer(nil, nil, usecase.NewAccessUseCase(repo, nil, nil, pagination.Sizes{}), nil)
+
+	tests := []struct {
+		name        string
+		userID      int64
+		projectID   int64
+		minLevel    string
+		wantLevel   string
+		wantAllowed bool
+	}{
+		{"read meets read", 1, 10, "read", "read", true},
+		{"read below write", 1, 10, "write", "read", false},
+		{"write meets read", 2, 10, "read", "write", true},
+		{"write meets write", 2, 10, "write", "write", true},
+		{"write below admin", 2, 10, "admin", "write", false},
+		{"admin meets admin", 3, 10, "admin", "admin", true},
+		{"any level", 1, 10, "", "read", true},
+		{"none", 4, 10, "read", "", false},
+		{"none with any level", 4, 10, "", "", false},
+		{"other project", 3, 11, "read", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.CheckAccess(context.Background(), &pb.CheckAccessRequest{
+				UserId:    tt.userID,
+				ProjectId: tt.projectID,
+				MinLevel:  tt.minLevel,
+			})
+			if err != nil {
+				t.Fatalf("CheckAccess() error = %v", err)
+			}
+			if resp.AccessLevel != tt.wantLevel || resp.Allowed != tt.wantAllowed {
+				t.Errorf("CheckAccess() = %q allowed %v, want %q allowed %v", resp.AccessLevel, resp.Allowed, tt.wantLevel, tt.wantAllowed)
+			}
+		})
+	}
+
+	_, err := s.CheckAccess(context.Background(), &pb.CheckAccessRequest{UserId: 1, ProjectId: 10, MinLevel: "owner"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("CheckAccess(owner) code = %v, want InvalidArgument", status.Code(err))
+	}
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Audited actions
+const (
+	AuditRegister     = "register"
+	AuditLogin        = "login"
+	AuditLoginFailed  = "login_failed"
+	AuditLogout       = "logout"
+	AuditRoleChange   = "role_change"
+	AuditUserDelete   = "user_delete"
+	AuditAccessSet    = "access_set"
+	AuditAccessRemove = "access_remove"
+)
+
+// AuditEntry records a security-sensitive operation. It must never hold
+// passwords or tokens.
+type AuditEntry struct {
+	ID int64 `json:"id"`
+	// ActorID is the user who performed the action, or 0 if nobody was signed in
+	ActorID   int64     `json:"actor_id"`
+	Action    string    `json:"action"`
+	Target    string    `json:"target"`
+	Details   string    `json:"details"`
+	IP        string    `json:"ip"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -29,10 +29,14 @@ func NewUser(username, email, passwordHash, role string) *User {
 	}
 }
 
+// RoleAdmin is the role of global admins, who may manage every project
+const RoleAdmin = "admin"
+
 // Role represents a role entity
 type Role struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
 }
 
 // UserProjectAccess represents user's access to a project
@@ -73,3 +77,31 @@ func (a *UserProjectAccess) HasWriteAccess() bool {
 func (a *UserProjectAccess) HasAdminAccess() bool {
 	return a.AccessLevel == AccessLevelAdmin
 }
+
+// HasAccessLevel checks if the access is at least level
+func (a *UserProjectAccess) HasAccessLevel(level string) bool {
+	switch level {
+	case AccessLevelAdmin:
+		return a.HasAdminAccess()
+	case AccessLevelWrite:
+		return a.HasWriteAccess()
+	default:
+		return IsValidAccessLevel(a.AccessLevel)
+	}
+}
+
+// ProjectSummary holds the project details shown next to a user's access,
+// as reported by the project service
+type ProjectSummary struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Status      string `json:"status"`
+}
+
+// AccessibleProject is a project a user has access to. Project is nil when
+// the project service no longer knows the project.
+type AccessibleProject struct {
+	Access  *UserProjectAccess `json:"access"`
+	Project *ProjectSummary    `json:"project"`
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/portfolio/auth-service/internal/domain/entity"
 )
@@ -22,6 +23,7 @@ type RoleRepository interface {
 	Create(ctx context.Context, role *entity.Role) error
 	GetByID(ctx context.Context, id int64) (*entity.Role, error)
 	GetByName(ctx context.Context, name string) (*entity.Role, error)
+	// List lists all roles with their permissions
 	List(ctx context.Context) ([]*entity.Role, error)
 }
 
@@ -33,3 +35,34 @@ type UserProjectAccessRepository interface {
 	GetByProjectID(ctx context.Context, projectID int64) ([]*entity.UserProjectAccess, error)
 	Remove(ctx context.Context, userID, projectID int64) error
 }
+
+// AuditFilter narrows an audit log query; zero values match everything
+type AuditFilter struct {
+	ActorID int64
+	Action  string
+}
+
+// AuditRepository defines the interface for the audit log
+type AuditRepository interface {
+	Create(ctx context.Context, entry *entity.AuditEntry) error
+	// List returns a page of entries matching filter, newest first, and the total count
+	List(ctx context.Context, filter AuditFilter, page, limit int) ([]*entity.AuditEntry, int, error)
+}
+
+// ProjectDirectory looks up projects in the project service
+type ProjectDirectory interface {
+	// GetProjects returns the projects that exist among ids, keyed by id
+	GetProjects(ctx context.Context, ids []int64) (map[int64]*entity.ProjectSummary, error)
+}
+
+// RevocationRepository keeps track of revoked tokens until they expire
+type RevocationRepository interface {
+	// RevokeToken revokes the token with id jti until expiresAt
+	RevokeToken(ctx context.Context, jti string, userID int64, expiresAt time.Time) error
+	// RevokeUserTokens revokes every token issued to userID at or before
+	// before, until expiresAt
+	RevokeUserTokens(ctx context.Context, userID int64, before, expiresAt time.Time) error
+	// IsRevoked reports whether a token has been revoked, by its id or
+	// because all of the user's tokens issued by issuedAt were
+	IsRevoked(ctx context.Context, jti string, userID int64, issuedAt time.Time) (bool, error)
+}
@@ -0,0 +1,38 @@
+package project
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/portfolio/auth-service/internal/domain/entity"
+	projectpb "github.com/portfolio/proto/project"
+	"google.golang.org/grpc"
+)
+
+// Client implements ProjectDirectory on top of the project service
+type Client struct {
+	projectClient projectpb.ProjectServiceClient
+}
+
+// NewClient creates a new project service client
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{projectClient: projectpb.NewProjectServiceClient(conn)}
+}
+
+// GetProjects fetches the projects among ids in a single call
+func (c *Client) GetProjects(ctx context.Context, ids []int64) (map[int64]*entity.ProjectSummary, error) {
+	resp, err := c.projectClient.BatchGetProjects(ctx, &projectpb.BatchGetProjectsRequest{Ids: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	projects := make(map[int64]*entity.ProjectSummary, len(resp.Projects))
+	for _, p := range resp.Projects {
+		projects[p.Id] = &entity.ProjectSummary{
+			ID:          p.Id,
+			Name:        p.Name,
+			Description: p.Description,
+			Status:      p.Status,
+		}
+	}
+	return projects, nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/portfolio/auth-service/internal/domain/entity"
+	"github.com/portfolio/auth-service/internal/domain/repository"
+	"github.com/portfolio/shared/cache"
+)
+
+func accessKey(userID, projectID int64) string {
+	return "access:" + strconv.FormatInt(userID, 10) + ":" + strconv.FormatInt(projectID, 10)
+}
+
+// CachedUserProjectAccessRepository caches the single access lookups every
+// project and task request makes. Missing access is cached too and still
+// reported as sql.ErrNoRows. Set and Remove drop the entry, so with a shared
+// Redis cache changes apply at once; per-replica memory caches keep a
+// revoked level until the TTL runs out. When the entry can't be dropped the
+// change is still stored but reported as failed, so the caller retries
+// instead of trusting a level that is still cached.
+type CachedUserProjectAccessRepository struct {
+	repo  repository.UserProjectAccessRepository
+	cache cache.Cache
+	ttl   time.Duration
+}
+
+// NewCachedUserProjectAccessRepository caches repo's lookups in c for ttl
+func NewCachedUserProjectAccessRepository(repo repository.UserProjectAccessRepository, c cache.Cache, ttl time.Duration) *CachedUserProjectAccessRepository {
+	return &CachedUserProjectAccessRepository{repo: repo, cache: c, ttl: ttl}
+}
+
+var _ repository.UserProjectAccessRepository = (*CachedUserProjectAccessRepository)(nil)
+
+// Set sets a user's access to a project and drops the cached level
+func (r *CachedUserProjectAccessRepository) Set(ctx context.Context, access *entity.UserProjectAccess) error {
+	err := r.repo.Set(ctx, access)
+	if cacheErr := r.cache.Delete(ctx, accessKey(access.UserID, access.ProjectID)); err == nil {
+		err = cacheErr
+	}
+	return err
+}
+
+// Get gets user's access to a specific project
+func (r *CachedUserProjectAccessRepository) Get(ctx context.Context, userID, projectID int64) (*entity.UserProjectAccess, error) {
+	key := accessKey(userID, projectID)
+	var access *entity.UserProjectAccess
+	if cache.GetJSON(ctx, r.cache, key, &access) {
+		if access == nil {
+			return nil, sql.ErrNoRows
+		}
+		return access, nil
+	}
+
+	access, err := r.repo.Get(ctx, userID, projectID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	cache.SetJSON(ctx, r.cache, key, access, r.ttl)
+	return access, err
+}
+
+// GetByUserID gets all project accesses for a user
+func (r *CachedUserProjectAccessRepository) GetByUserID(ctx context.Context, userID int64) ([]*entity.UserProjectAccess, error) {
+	return r.repo.GetByUserID(ctx, userID)
+}
+
+// GetByProjectID gets all user accesses for a project
+func (r *CachedUserProjectAccessRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.UserProjectAccess, error) {
+	return r.repo.GetByProjectID(ctx, projectID)
+}
+
+// Remove removes user's access to a project and drops the cached level
+func (r *CachedUserProjectAccessRepository) Remove(ctx context.Context, userID, projectID int64) error {
+	err := r.repo.Remove(ctx, userID, projectID)
+	if cacheErr := r.cache.Delete(ctx, accessKey(userID, projectID)); err == nil {
+		err = cacheErr
+	}
+	return err
+}
@@ -3,9 +3,12 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/portfolio/auth-service/internal/domain/entity"
+	domain "github.com/portfolio/auth-service/internal/domain/repository"
 )
 
 // PostgresUserRepository implements UserRepository
@@ -175,9 +178,14 @@ func (r *PostgresRoleRepository) GetByName(ctx context.Context, name string) (*e
 	return role, nil
 }
 
-// List lists all roles
+// List lists all roles with their permissions
 func (r *PostgresRoleRepository) List(ctx context.Context) ([]*entity.Role, error) {
-	query := `SELECT id, name FROM roles ORDER BY id`
+	query := `
+		SELECT r.id, r.name, rp.permission
+		FROM roles r
+		LEFT JOIN role_permissions rp ON rp.role_id = r.id
+		ORDER BY r.id, rp.permission
+	`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
@@ -186,13 +194,21 @@ func (r *PostgresRoleRepository) List(ctx context.Context) ([]*entity.Role, erro
 
 	var roles []*entity.Role
 	for rows.Next() {
-		role := &entity.Role{}
-		if err := rows.Scan(&role.ID, &role.Name); err != nil {
+		var id int64
+		var name string
+		var permission sql.NullString
+		if err := rows.Scan(&id, &name, &permission); err != nil {
 			return nil, err
 		}
-		roles = append(roles, role)
+		if len(roles) == 0 || roles[len(roles)-1].ID != id {
+			roles = append(roles, &entity.Role{ID: id, Name: name, Permissions: []string{}})
+		}
+		if permission.Valid {
+			role := roles[len(roles)-1]
+			role.Permissions = append(role.Permissions, permission.String)
+		}
 	}
-	return roles, nil
+	return roles, rows.Err()
 }
 
 // PostgresUserProjectAccessRepository implements UserProjectAccessRepository
@@ -236,7 +252,7 @@ func (r *PostgresUserProjectAccessRepository) Get(ctx context.Context, userID, p
 func (r *PostgresUserProjectAccessRepository) GetByUserID(ctx context.Context, userID int64) ([]*entity.UserProjectAccess, error) {
 	query := `
 		SELECT user_id, project_id, access_level
-		FROM user_project_access WHERE user_id = $1
+		FROM user_project_access WHERE user_id = $1 ORDER BY project_id
 	`
 	rows, err := r.db.QueryContext(ctx, query, userID)
 	if err != nil {
@@ -259,7 +275,7 @@ func (r *PostgresUserProjectAccessRepository) GetByUserID(ctx context.Context, u
 func (r *PostgresUserProjectAccessRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.UserProjectAccess, error) {
 	query := `
 		SELECT user_id, project_id, access_level
-		FROM user_project_access WHERE project_id = $1
+		FROM user_project_access WHERE project_id = $1 ORDER BY user_id
 	`
 	rows, err := r.db.QueryContext(ctx, query, projectID)
 	if err != nil {
@@ -284,3 +300,140 @@ func (r *PostgresUserProjectAccessRepository) Remove(ctx context.Context, userID
 	_, err := r.db.ExecContext(ctx, query, userID, projectID)
 	return err
 }
+
+// PostgresAuditRepository implements AuditRepository
+type PostgresAuditRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresAuditRepository creates a new PostgresAuditRepository
+func NewPostgresAuditRepository(db *sql.DB) *PostgresAuditRepository {
+	return &PostgresAuditRepository{db: db}
+}
+
+// Create appends an entry to the audit log
+func (r *PostgresAuditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	query := `
+		INSERT INTO audit_log (actor_id, action, target, details, ip, created_at)
+		VALUES (NULLIF($1, 0), $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	return r.db.QueryRowContext(
+		ctx, query,
+		entry.ActorID, entry.Action, entry.Target, entry.Details, entry.IP, entry.CreatedAt,
+	).Scan(&entry.ID)
+}
+
+// List returns a page of audit entries matching filter, newest first
+func (r *PostgresAuditRepository) List(ctx context.Context, filter domain.AuditFilter, page, limit int) ([]*entity.AuditEntry, int, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.ActorID != 0 {
+		args = append(args, filter.ActorID)
+		conditions = append(conditions, fmt.Sprintf("actor_id = $%d", len(args)))
+	}
+	if filter.Action != "" {
+		args = append(args, filter.Action)
+		conditions = append(conditions, fmt.Sprintf("action = $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM audit_log`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	args = append(args, limit, (page-1)*limit)
+	query := fmt.Sprintf(`
+		SELECT id, COALESCE(actor_id, 0), action, target, details, ip, created_at
+		FROM audit_log%s
+		ORDER BY created_at DESC, id DESC
+		LIMIT $%d OFFSET $%d
+	`, where, len(args)-1, len(args))
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var entries []*entity.AuditEntry
+	for rows.Next() {
+		entry := &entity.AuditEntry{}
+		if err := rows.Scan(
+			&entry.ID, &entry.ActorID, &entry.Action, &entry.Target,
+			&entry.Details, &entry.IP, &entry.CreatedAt,
+		); err != nil {
+			return nil, 0, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, total, rows.Err()
+}
+
+// PostgresRevocationRepository implements RevocationRepository
+type PostgresRevocationRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresRevocationRepository creates a new PostgresRevocationRepository
+func NewPostgresRevocationRepository(db *sql.DB) *PostgresRevocationRepository {
+	return &PostgresRevocationRepository{db: db}
+}
+
+// RevokeToken revokes a single token until it expires
+func (r *PostgresRevocationRepository) RevokeToken(ctx context.Context, jti string, userID int64, expiresAt time.Time) error {
+	if err := r.purgeExpired(ctx); err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO revoked_tokens (jti, user_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`
+	_, err := r.db.ExecContext(ctx, query, jti, userID, expiresAt)
+	return err
+}
+
+// RevokeUserTokens revokes every token issued to a user up to before
+func (r *PostgresRevocationRepository) RevokeUserTokens(ctx context.Context, userID int64, before, expiresAt time.Time) error {
+	if err := r.purgeExpired(ctx); err != nil {
+		return err
+	}
+	query := `
+		INSERT INTO revoked_user_tokens (user_id, revoked_before, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET revoked_before = EXCLUDED.revoked_before, expires_at = EXCLUDED.expires_at
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, before, expiresAt)
+	return err
+}
+
+// IsRevoked reports whether a token is revoked
+func (r *PostgresRevocationRepository) IsRevoked(ctx context.Context, jti string, userID int64, issuedAt time.Time) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM revoked_tokens
+			WHERE jti = $1 AND expires_at > NOW()
+		) OR EXISTS (
+			SELECT 1 FROM revoked_user_tokens
+			WHERE user_id = $2 AND revoked_before >= $3 AND expires_at > NOW()
+		)
+	`
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, query, jti, userID, issuedAt).Scan(&revoked)
+	return revoked, err
+}
+
+// purgeExpired drops revocations of tokens that have expired anyway, which
+// keeps the tables no larger than the set of live revoked tokens
+func (r *PostgresRevocationRepository) purgeExpired(ctx context.Context) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM revoked_tokens WHERE expires_at <= NOW()`); err != nil {
+		return err
+	}
+	_, err := r.db.ExecContext(ctx, `DELETE FROM revoked_user_tokens WHERE expires_at <= NOW()`)
+	return err
+}
@@ -0,0 +1,228 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/portfolio/auth-service/internal/domain/entity"
+	"github.com/portfolio/auth-service/internal/infrastructure/repository"
+	"github.com/portfolio/shared/cache"
+	"github.com/portfolio/shared/pagination"
+)
+
+type accessKey struct{ userID, projectID int64 }
+
+// MockAccessRepository keeps access levels by user and project
+type MockAccessRepository map[accessKey]string
+
+func (m MockAccessRepository) Set(ctx context.Context, access *entity.UserProjectAccess) error {
+	m[accessKey{access.UserID, access.ProjectID}] = access.AccessLevel
+	return nil
+}
+
+func (m MockAccessRepository) Get(ctx context.Context, userID, projectID int64) (*entity.UserProjectAccess, error) {
+	level, ok := m[accessKey{userID, projectID}]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return &entity.UserProjectAccess{UserID: userID, ProjectID: projectID, AccessLevel: level}, nil
+}
+
+func (m MockAccessRepository) GetByUserID(ctx context.Context, userID int64) ([]*entity.UserProjectAccess, error) {
+	var accesses []*entity.UserProjectAccess
+	for key, level := range m {
+		if key.userID == userID {
+			accesses = append(accesses, &entity.UserProjectAccess{UserID: userID, ProjectID: key.projectID, AccessLevel: level})
+		}
+	}
+	sort.Slice(accesses, func(i, j int) bool { return accesses[i].ProjectID < accesses[j].ProjectID })
+	return accesses, nil
+}
+
+func (m MockAccessRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.UserProjectAccess, error) {
+	return nil, nil
+}
+
+func (m MockAccessRepository) Remove(ctx context.Context, userID, projectID int64) error {
+	delete(m, accessKey{userID, projectID})
+	return nil
+}
+
+func TestAccessUseCase_ManageAccess(t *testing.T) {
+	const project = 10
+	tests := []struct {
+		name    string
+		caller  *entity.User
+		wantErr error
+	}{
+		{"global admin", &entity.User{ID: 1, Role: entity.RoleAdmin}, nil},
+		{"project admin", &entity.User{ID: 2, Role: "user"}, nil},
+		{"project writer", &entity.User{ID: 3, Role: "user"}, ErrAccessDenied},
+		{"admin of another project", &entity.User{ID: 4, Role: "user"}, ErrAccessDenied},
+		{"no access", &entity.User{ID: 5, Role: "user"}, ErrAccessDenied},
+		{"no caller", nil, ErrAccessDenied},
+	}
+
+	for _, tt := range tests {
+		newRepo := func() MockAccessRepository {
+			return MockAccessRepository{
+				{2, project}:     entity.AccessLevelAdmin,
+				{3, project}:     entity.AccessLevelWrite,
+				{4, project + 1}: entity.AccessLevelAdmin,
+				{9, project}:     entity.AccessLevelRead,
+			}
+		}
+
+		t.Run("set "+tt.name, func(t *testing.T) {
+			repo := newRepo()
+			uc := NewAccessUseCase(repo, nil, nil, pagination.Sizes{})
+			err := uc.SetAccess(context.Background(), tt.caller, 9, project, entity.AccessLevelWrite)
+			if err != tt.wantErr {
+				t.Fatalf("SetAccess() error = %v, want %v", err, tt.wantErr)
+			}
+			want := entity.AccessLevelWrite
+			if tt.wantErr != nil {
+				want = entity.AccessLevelRead
+			}
+			if got := repo[accessKey{9, project}]; got != want {
+				t.Errorf("access level = %q, want %q", got, want)
+			}
+		})
+
+		t.Run("remove "+tt.name, func(t *testing.T) {
+			repo := newRepo()
+			uc := NewAccessUseCase(repo, nil, nil, pagination.Sizes{})
+			err := uc.RemoveAccess(context.Background(), tt.caller, 9, project)
+			if err != tt.wantErr {
+				t.Fatalf("RemoveAccess() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, kept := repo[accessKey{9, project}]; kept != (tt.wantErr != nil) {
+				t.Errorf("access kept = %v, want %v", kept, tt.wantErr != nil)
+			}
+		})
+	}
+}
+
+// countingAccessRepository counts the lookups that reach the repository
+type countingAccessRepository struct {
+	MockAccessRepository
+	gets int
+}
+
+func (m *countingAccessRepository) Get(ctx context.Context, userID, projectID int64) (*entity.UserProjectAccess, error) {
+	m.gets++
+	return m.MockAccessRepository.Get(ctx, userID, projectID)
+}
+
+func TestAccessUseCase_CachedCheckAccess(t *testing.T) {
+	const project = 10
+	ctx := context.Background()
+	repo := &countingAccessRepository{MockAccessRepository: MockAccessRepository{
+		{2, project}: entity.AccessLevelAdmin,
+		{9, project}: entity.AccessLevelRead,
+	}}
+	uc := NewAccessUseCase(repository.NewCachedUserProjectAccessRepository(repo, cache.NewMemory(), time.Minute), nil, nil, pagination.Sizes{})
+
+	check := func(userID int64, wantLevel string) {
+		t.Helper()
+		level, _, err := uc.CheckAccess(ctx, userID, project, "")
+		if err != nil || level != wantLevel {
+			t.Fatalf("CheckAccess(%d) = %q, %v; want %q", userID, level, err, wantLevel)
+		}
+	}
+
+	check(9, entity.AccessLevelRead)
+	check(9, entity.AccessLevelRead)
+	check(5, "")
+	check(5, "")
+	if repo.gets != 2 {
+		t.Errorf("repository lookups = %d, want 2 with levels and missing access cached", repo.gets)
+	}
+
+	admin := &entity.User{ID: 2, Role: "user"}
+	if err := uc.SetAccess(ctx, admin, 9, project, entity.AccessLevelWrite); err != nil {
+		t.Fatalf("SetAccess() error = %v", err)
+	}
+	check(9, entity.AccessLevelWrite)
+	if err := uc.RemoveAccess(ctx, admin, 9, project); err != nil {
+		t.Fatalf("RemoveAccess() error = %v", err)
+	}
+	check(9, "")
+}
+
+// stubProjects serves project details and records the ids requested
+type stubProjects struct {
+	projects map[int64]*entity.ProjectSummary
+	gotIDs   []int64
+}
+
+func (s *stubProjects) GetProjects(ctx context.Context, ids []int64) (map[int64]*entity.ProjectSummary, error) {
+	s.gotIDs = ids
+	found := make(map[int64]*entity.ProjectSummary)
+	for _, id := range ids {
+		if p, ok := s.projects[id]; ok {
+			found[id] = p
+		}
+	}
+	return found, nil
+}
+
+func TestAccessUseCase_ListUserProjects(t *testing.T) {
+	repo := MockAccessRepository{
+		{1, 10}: entity.AccessLevelAdmin,
+		{1, 20}: entity.AccessLevelRead,
+		{1, 30}: entity.AccessLevelWrite,
+		{2, 10}: entity.AccessLevelRead,
+	}
+	projects := &stubProjects{projects: map[int64]*entity.ProjectSummary{
+		10: {ID: 10, Name: "Website", Status: "active"},
+		20: {ID: 20, Name: "App", Status: "on_hold"},
+		// 30 has been deleted from the project service
+	}}
+	uc := NewAccessUseCase(repo, nil, projects, pagination.Sizes{})
+
+	tests := []struct {
+		name        string
+		page, limit int
+		wantIDs     []int64
+		wantNames   []string
+	}{
+		{"first page", 1, 2, []int64{10, 20}, []string{"Website", "App"}},
+		{"second page", 2, 2, []int64{30}, []string{""}},
+		{"past the end", 3, 2, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects.gotIDs = nil
+			got, total, err := uc.ListUserProjects(context.Background(), 1, tt.page, tt.limit)
+			if err != nil {
+				t.Fatalf("ListUserProjects() error = %v", err)
+			}
+			if total != 3 {
+				t.Errorf("total = %d, want 3", total)
+			}
+			if !reflect.DeepEqual(projects.gotIDs, tt.wantIDs) {
+				t.Errorf("requested ids = %v, want %v", projects.gotIDs, tt.wantIDs)
+			}
+			var names []string
+			for i, p := range got {
+				if p.Access.ProjectID != tt.wantIDs[i] {
+					t.Errorf("project %d = %d, want %d", i, p.Access.ProjectID, tt.wantIDs[i])
+				}
+				name := ""
+				if p.Project != nil {
+					name = p.Project.Name
+				}
+				names = append(names, name)
+			}
+			if !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("names = %v, want %v", names, tt.wantNames)
+			}
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/portfolio/auth-service/internal/domain/entity"
+	"github.com/portfolio/auth-service/internal/domain/repository"
+	"github.com/portfolio/shared/middleware"
+	"github.com/portfolio/shared/pagination"
+)
+
+// ErrAdminRequired is returned when a non-admin reads the audit log
+var ErrAdminRequired = errors.New("only admins can read the audit log")
+
+// recordAudit appends an entry to the audit log with the client IP from ctx.
+// The operation being audited has already happened, so a failure to record
+// it is logged rather than returned.
+func recordAudit(ctx context.Context, repo repository.AuditRepository, actorID int64, action, target, details string) {
+	if repo == nil {
+		return
+	}
+	entry := &entity.AuditEntry{
+		ActorID:   actorID,
+		Action:    action,
+		Target:    target,
+		Details:   details,
+		IP:        middleware.ClientIPFromContext(ctx),
+		CreatedAt: time.Now(),
+	}
+	if err := repo.Create(ctx, entry); err != nil {
+		slog.WarnContext(ctx, "failed to write audit entry", "action", action, "target", target, "error", err)
+	}
+}
+
+// actorID returns caller's id, or 0 when the caller is unknown
+func actorID(caller *entity.User) int64 {
+	if caller == nil {
+		return 0
+	}
+	return caller.ID
+}
+
+func userTarget(id int64) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+func accessTarget(userID, projectID int64) string {
+	return fmt.Sprintf("project:%d user:%d", projectID, userID)
+}
+
+// AuditUseCase handles reading the audit log
+type AuditUseCase struct {
+	auditRepo repository.AuditRepository
+	pages     pagination.Sizes
+}
+
+// NewAuditUseCase creates a new AuditUseCase listing entries in pages of the
+// given sizes
+func NewAuditUseCase(auditRepo repository.AuditRepository, pages pagination.Sizes) *AuditUseCase {
+	return &AuditUseCase{auditRepo: auditRepo, pages: pages}
+}
+
+// PageLimit is the page size ListEntries applies for limit
+func (uc *AuditUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
+// ListEntries returns a page of the audit log, newest first. Only global
+// admins may read it.
+func (uc *AuditUseCase) ListEntries(ctx context.Context, caller *entity.User, filter repository.AuditFilter, page, limit int) ([]*entity.AuditEntry, int, error) {
+	if caller == nil || caller.Role != entity.RoleAdmin {
+		return nil, 0, ErrAdminRequired
+	}
+	page, limit = uc.pages.Normalize(page, limit)
+	return uc.auditRepo.List(ctx, filter, page, limit)
+}
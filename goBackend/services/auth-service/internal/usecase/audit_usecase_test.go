@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/portfolio/auth-service/internal/domain/entity"
+	"github.com/portfolio/auth-service/internal/domain/repository"
+	"github.com/portfolio/shared/jwt"
+	"github.com/portfolio/shared/middleware"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc/metadata"
+)
+
+// MockAuditRepository keeps audit entries in memory
+type MockAuditRepository struct {
+	entries []*entity.AuditEntry
+}
+
+func (m *MockAuditRepository) Create(ctx context.Context, entry *entity.AuditEntry) error {
+	entry.ID = int64(len(m.entries) + 1)
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *MockAuditRepository) List(ctx context.Context, filter repository.AuditFilter, page, limit int) ([]*entity.AuditEntry, int, error) {
+	return m.entries, len(m.entries), nil
+}
+
+// clientContext simulates a request forwarded by the gateway for ip
+func clientContext(ip string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(middleware.ClientIPKey, ip))
+}
+
+func TestAuthUseCase_UpdateUser_AuditsRoleChange(t *testing.T) {
+	audit := &MockAuditRepository{}
+	uc := NewAuthUseCase(NewMockUserRepository(), nil, nil, audit, nil, jwt.NewTokenService("secret", time.Hour), pagination.Sizes{})
+	user, _, err := uc.Register(context.Background(), "bob", "bob@example.com", "password123", "user")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	audit.entries = nil
+
+	admin := &entity.User{ID: 42, Role: entity.RoleAdmin}
+	if _, err := uc.UpdateUser(clientContext("203.0.113.7"), admin, user.ID, "", "", entity.RoleAdmin); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+
+	if len(audit.entries) != 1 {
+		t.Fatalf("audit entries = %d, want 1", len(audit.entries))
+	}
+	got := audit.entries[0]
+	want := entity.AuditEntry{
+		ID:      1,
+		ActorID: 42,
+		Action:  entity.AuditRoleChange,
+		Target:  userTarget(user.ID),
+		Details: "user -> admin",
+		IP:      "203.0.113.7",
+	}
+	got.CreatedAt = want.CreatedAt
+	if *got != want {
+		t.Errorf("audit entry = %+v, want %+v", *got, want)
+	}
+
+	// Updates that keep the role aren't audited
+	if _, err := uc.UpdateUser(context.Background(), admin, user.ID, "bobby", "", entity.RoleAdmin); err != nil {
+		t.Fatalf("UpdateUser() error = %v", err)
+	}
+	if len(audit.entries) != 1 {
+		t.Errorf("audit entries = %d after a rename, want 1", len(audit.entries))
+	}
+}
+
+func TestAuthUseCase_AuditNeverStoresSecrets(t *testing.T) {
+	audit := &MockAuditRepository{}
+	uc := NewAuthUseCase(NewMockUserRepository(), nil, nil, audit, nil, jwt.NewTokenService("secret", time.Hour), pagination.Sizes{})
+	const password = "hunter2-secret"
+
+	_, regToken, _ := uc.Register(context.Background(), "carol", "carol@example.com", password, "user")
+	_, loginToken, _ := uc.Login(context.Background(), "carol@example.com", password)
+	uc.Login(context.Background(), "carol@example.com", password+"x")
+
+	actions := make([]string, len(audit.entries))
+	for i, entry := range audit.entries {
+		actions[i] = entry.Action
+		for _, secret := range []string{password, regToken, loginToken} {
+			if strings.Contains(entry.Target+entry.Details, secret) {
+				t.Errorf("%s entry %+v contains a secret", entry.Action, *entry)
+			}
+		}
+	}
+	want := []string{entity.AuditRegister, entity.AuditLogin, entity.AuditLoginFailed}
+	if strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Errorf("audited actions = %v, want %v", actions, want)
+	}
+}
+
+func TestAuditUseCase_ListEntries_AdminOnly(t *testing.T) {
+	uc := NewAuditUseCase(&MockAuditRepository{}, pagination.Sizes{})
+	for _, caller := range []*entity.User{nil, {ID: 2, Role: "user"}} {
+		if _, _, err := uc.ListEntries(context.Background(), caller, repository.AuditFilter{}, 1, 20); err != ErrAdminRequired {
+			t.Errorf("ListEntries(%v) error = %v, want %v", caller, err, ErrAdminRequired)
+		}
+	}
+	if _, _, err := uc.ListEntries(context.Background(), &entity.User{ID: 1, Role: entity.RoleAdmin}, repository.AuditFilter{}, 1, 20); err != nil {
+		t.Errorf("ListEntries(admin) error = %v", err)
+	}
+}
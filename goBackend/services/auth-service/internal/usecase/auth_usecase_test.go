@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/portfolio/auth-service/internal/domain/entity"
+	"github.com/portfolio/shared/jwt"
+	"github.com/portfolio/shared/pagination"
 )
 
 // MockUserRepository is a manual mock
@@ -45,8 +48,16 @@ func (m *MockUserRepository) GetByUsername(ctx context.Context, username string)
 	return nil, errors.New("user not found")
 }
 
+func (m *MockUserRepository) GetByID(ctx context.Context, id int64) (*entity.User, error) {
+	for _, user := range m.users {
+		if user.ID == id {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
 // Implement other methods as no-ops or panics if not used in tested paths
-func (m *MockUserRepository) GetByID(ctx context.Context, id int64) (*entity.User, error) { return nil, nil }
 func (m *MockUserRepository) Update(ctx context.Context, user *entity.User) error { return nil }
 func (m *MockUserRepository) Delete(ctx context.Context, id int64) error { return nil }
 func (m *MockUserRepository) List(ctx context.Context, page, limit int) ([]*entity.User, int, error) { return nil, 0, nil }
@@ -58,7 +69,7 @@ func TestAuthUseCase_Register(t *testing.T) {
 	// actually Register uses: userRepo.GetByEmail, userRepo.GetByUsername, userRepo.Create.
 	// It relies on tokenSvc internally.
 
-	uc := NewAuthUseCase(mockRepo, nil, nil, "secret")
+	uc := NewAuthUseCase(mockRepo, nil, nil, nil, nil, jwt.NewTokenService("secret", time.Hour), pagination.Sizes{})
 
 	tests := []struct {
 		name    string
@@ -110,7 +121,7 @@ func TestAuthUseCase_Register(t *testing.T) {
 
 func TestAuthUseCase_Login(t *testing.T) {
 	mockRepo := NewMockUserRepository()
-	uc := NewAuthUseCase(mockRepo, nil, nil, "secret")
+	uc := NewAuthUseCase(mockRepo, nil, nil, nil, nil, jwt.NewTokenService("secret", time.Hour), pagination.Sizes{})
 
 	// Pre-seed a user
 	uc.Register(context.Background(), "loginuser", "login@example.com", "password123", "user")
@@ -159,3 +170,77 @@ func TestAuthUseCase_Login(t *testing.T) {
 		})
 	}
 }
+
+// MockRevocationRepository keeps revocations in memory
+type MockRevocationRepository struct {
+	tokens map[string]time.Time
+	users  map[int64]time.Time
+}
+
+func NewMockRevocationRepository() *MockRevocationRepository {
+	return &MockRevocationRepository{tokens: make(map[string]time.Time), users: make(map[int64]time.Time)}
+}
+
+func (m *MockRevocationRepository) RevokeToken(ctx context.Context, jti string, userID int64, expiresAt time.Time) error {
+	m.tokens[jti] = expiresAt
+	return nil
+}
+
+func (m *MockRevocationRepository) RevokeUserTokens(ctx context.Context, userID int64, before, expiresAt time.Time) error {
+	m.users[userID] = before
+	return nil
+}
+
+func (m *MockRevocationRepository) IsRevoked(ctx context.Context, jti string, userID int64, issuedAt time.Time) (bool, error) {
+	if _, ok := m.tokens[jti]; ok {
+		return true, nil
+	}
+	before, ok := m.users[userID]
+	return ok && !issuedAt.After(before), nil
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	revocations := NewMockRevocationRepository()
+	uc := NewAuthUseCase(NewMockUserRepository(), nil, nil, nil, revocations, jwt.NewTokenService("secret", time.Hour), pagination.Sizes{})
+	ctx := context.Background()
+
+	uc.Register(ctx, "dave", "dave@example.com", "password123", "user")
+	_, token, _ := uc.Login(ctx, "dave@example.com", "password123")
+	_, other, _ := uc.Login(ctx, "dave@example.com", "password123")
+
+	if _, err := uc.ValidateToken(ctx, token); err != nil {
+		t.Fatalf("ValidateToken() before logout error = %v", err)
+	}
+	if err := uc.Logout(ctx, token); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := uc.ValidateToken(ctx, token); err != ErrInvalidToken {
+		t.Errorf("ValidateToken() after logout error = %v, want %v", err, ErrInvalidToken)
+	}
+	if err := uc.Logout(ctx, token); err != ErrInvalidToken {
+		t.Errorf("second Logout() error = %v, want %v", err, ErrInvalidToken)
+	}
+
+	// Other sessions stay signed in
+	if _, err := uc.ValidateToken(ctx, other); err != nil {
+		t.Errorf("ValidateToken() of another session error = %v", err)
+	}
+}
+
+func TestAuthUseCase_DeleteUser_RevokesTokens(t *testing.T) {
+	revocations := NewMockRevocationRepository()
+	uc := NewAuthUseCase(NewMockUserRepository(), nil, nil, nil, revocations, jwt.NewTokenService("secret", time.Hour), pagination.Sizes{})
+	ctx := context.Background()
+
+	user, first, _ := uc.Register(ctx, "erin", "erin@example.com", "password123", "user")
+	_, second, _ := uc.Login(ctx, "erin@example.com", "password123")
+
+	if err := uc.DeleteUser(ctx, nil, user.ID); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+	for _, token := range []string{first, second} {
+		if _, err := uc.ValidateToken(ctx, token); err != ErrInvalidToken {
+			t.Errorf("ValidateToken() after delete error = %v, want %v", err, ErrInvalidToken)
+		}
+	}
+}
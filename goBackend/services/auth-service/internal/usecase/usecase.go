@@ -2,12 +2,14 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"time"
 
 	"github.com/portfolio/auth-service/internal/domain/entity"
 	"github.com/portfolio/auth-service/internal/domain/repository"
 	"github.com/portfolio/shared/jwt"
+	"github.com/portfolio/shared/pagination"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -17,6 +19,7 @@ var (
 	ErrInvalidCredentials = errors.New("invalid credentials")
 	ErrInvalidToken       = errors.New("invalid token")
 	ErrInvalidAccessLevel = errors.New("invalid access level")
+	ErrAccessDenied       = errors.New("only project admins can manage project access")
 )
 
 // AuthUseCase handles authentication business logic
@@ -24,24 +27,39 @@ type AuthUseCase struct {
 	userRepo    repository.UserRepository
 	roleRepo    repository.RoleRepository
 	accessRepo  repository.UserProjectAccessRepository
+	auditRepo   repository.AuditRepository
+	revocations repository.RevocationRepository
 	tokenSvc    *jwt.TokenService
+	pages       pagination.Sizes
 }
 
-// NewAuthUseCase creates a new AuthUseCase
+// NewAuthUseCase creates a new AuthUseCase listing users in pages of the
+// given sizes
 func NewAuthUseCase(
 	userRepo repository.UserRepository,
 	roleRepo repository.RoleRepository,
 	accessRepo repository.UserProjectAccessRepository,
-	jwtSecret string,
+	auditRepo repository.AuditRepository,
+	revocations repository.RevocationRepository,
+	tokenSvc *jwt.TokenService,
+	pages pagination.Sizes,
 ) *AuthUseCase {
 	return &AuthUseCase{
-		userRepo:   userRepo,
-		roleRepo:   roleRepo,
-		accessRepo: accessRepo,
-		tokenSvc:   jwt.NewTokenService(jwtSecret, 24*time.Hour),
+		userRepo:    userRepo,
+		roleRepo:    roleRepo,
+		accessRepo:  accessRepo,
+		auditRepo:   auditRepo,
+		revocations: revocations,
+		tokenSvc:    tokenSvc,
+		pages:       pages,
 	}
 }
 
+// PageLimit is the page size ListUsers applies for limit
+func (uc *AuthUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
 // Register creates a new user
 func (uc *AuthUseCase) Register(ctx context.Context, username, email, password, role string) (*entity.User, string, error) {
 	// Check if user exists
@@ -66,6 +84,7 @@ func (uc *AuthUseCase) Register(ctx context.Context, username, email, password,
 	if err := uc.userRepo.Create(ctx, user); err != nil {
 		return nil, "", err
 	}
+	recordAudit(ctx, uc.auditRepo, user.ID, entity.AuditRegister, userTarget(user.ID), "role "+user.Role)
 
 	// Generate token
 	token, err := uc.tokenSvc.GenerateToken(user.ID, user.Username, user.Email, user.Role)
@@ -80,10 +99,12 @@ func (uc *AuthUseCase) Register(ctx context.Context, username, email, password,
 func (uc *AuthUseCase) Login(ctx context.Context, email, password string) (*entity.User, string, error) {
 	user, err := uc.userRepo.GetByEmail(ctx, email)
 	if err != nil {
+		recordAudit(ctx, uc.auditRepo, 0, entity.AuditLoginFailed, "email:"+email, "unknown email")
 		return nil, "", ErrInvalidCredentials
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		recordAudit(ctx, uc.auditRepo, 0, entity.AuditLoginFailed, userTarget(user.ID), "wrong password")
 		return nil, "", ErrInvalidCredentials
 	}
 
@@ -91,15 +112,16 @@ func (uc *AuthUseCase) Login(ctx context.Context, email, password string) (*enti
 	if err != nil {
 		return nil, "", err
 	}
+	recordAudit(ctx, uc.auditRepo, user.ID, entity.AuditLogin, userTarget(user.ID), "")
 
 	return user, token, nil
 }
 
 // ValidateToken validates a JWT token
 func (uc *AuthUseCase) ValidateToken(ctx context.Context, token string) (*entity.User, error) {
-	claims, err := uc.tokenSvc.ValidateToken(token)
+	claims, err := uc.claims(ctx, token)
 	if err != nil {
-		return nil, ErrInvalidToken
+		return nil, err
 	}
 
 	user, err := uc.userRepo.GetByID(ctx, claims.UserID)
@@ -110,6 +132,48 @@ func (uc *AuthUseCase) ValidateToken(ctx context.Context, token string) (*entity
 	return user, nil
 }
 
+// Logout revokes token until it would have expired. Tokens issued before
+// they carried an id can't be revoked one by one, so all of the user's
+// tokens up to now are revoked instead.
+func (uc *AuthUseCase) Logout(ctx context.Context, token string) error {
+	claims, err := uc.claims(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := claims.ExpiresAt.Time
+	if claims.ID != "" {
+		err = uc.revocations.RevokeToken(ctx, claims.ID, claims.UserID, expiresAt)
+	} else {
+		err = uc.revocations.RevokeUserTokens(ctx, claims.UserID, time.Now(), expiresAt)
+	}
+	if err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.auditRepo, claims.UserID, entity.AuditLogout, userTarget(claims.UserID), "")
+	return nil
+}
+
+// claims parses token and checks that it hasn't been revoked
+func (uc *AuthUseCase) claims(ctx context.Context, token string) (*jwt.Claims, error) {
+	claims, err := uc.tokenSvc.ValidateToken(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	issuedAt := time.Time{}
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+	revoked, err := uc.revocations.IsRevoked(ctx, claims.ID, claims.UserID, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
 // GetUser retrieves a user by ID
 func (uc *AuthUseCase) GetUser(ctx context.Context, id int64) (*entity.User, error) {
 	user, err := uc.userRepo.GetByID(ctx, id)
@@ -119,12 +183,13 @@ func (uc *AuthUseCase) GetUser(ctx context.Context, id int64) (*entity.User, err
 	return user, nil
 }
 
-// UpdateUser updates a user
-func (uc *AuthUseCase) UpdateUser(ctx context.Context, id int64, username, email, role string) (*entity.User, error) {
+// UpdateUser updates a user on behalf of caller, who may be nil if unknown
+func (uc *AuthUseCase) UpdateUser(ctx context.Context, caller *entity.User, id int64, username, email, role string) (*entity.User, error) {
 	user, err := uc.userRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrUserNotFound
 	}
+	oldRole := user.Role
 
 	if username != "" {
 		user.Username = username
@@ -140,23 +205,31 @@ func (uc *AuthUseCase) UpdateUser(ctx context.Context, id int64, username, email
 	if err := uc.userRepo.Update(ctx, user); err != nil {
 		return nil, err
 	}
+	if user.Role != oldRole {
+		recordAudit(ctx, uc.auditRepo, actorID(caller), entity.AuditRoleChange, userTarget(id), oldRole+" -> "+user.Role)
+	}
 
 	return user, nil
 }
 
-// DeleteUser deletes a user
-func (uc *AuthUseCase) DeleteUser(ctx context.Context, id int64) error {
-	return uc.userRepo.Delete(ctx, id)
+// DeleteUser deletes a user on behalf of caller, who may be nil if unknown
+func (uc *AuthUseCase) DeleteUser(ctx context.Context, caller *entity.User, id int64) error {
+	if err := uc.userRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	// Tokens issued from now on can't be for this user, and older ones
+	// expire within the token lifetime
+	now := time.Now()
+	if err := uc.revocations.RevokeUserTokens(ctx, id, now, now.Add(uc.tokenSvc.Lifetime())); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.auditRepo, actorID(caller), entity.AuditUserDelete, userTarget(id), "")
+	return nil
 }
 
 // ListUsers lists users with pagination
 func (uc *AuthUseCase) ListUsers(ctx context.Context, page, limit int) ([]*entity.User, int, error) {
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 10
-	}
+	page, limit = uc.pages.Normalize(page, limit)
 	return uc.userRepo.List(ctx, page, limit)
 }
 
@@ -187,15 +260,54 @@ func (uc *RoleUseCase) ListRoles(ctx context.Context) ([]*entity.Role, error) {
 // AccessUseCase handles project access business logic
 type AccessUseCase struct {
 	accessRepo repository.UserProjectAccessRepository
+	auditRepo  repository.AuditRepository
+	projects   repository.ProjectDirectory
+	pages      pagination.Sizes
 }
 
-// NewAccessUseCase creates a new AccessUseCase
-func NewAccessUseCase(accessRepo repository.UserProjectAccessRepository) *AccessUseCase {
-	return &AccessUseCase{accessRepo: accessRepo}
+// NewAccessUseCase creates a new AccessUseCase listing projects in pages of
+// the given sizes
+func NewAccessUseCase(
+	accessRepo repository.UserProjectAccessRepository,
+	auditRepo repository.AuditRepository,
+	projects repository.ProjectDirectory,
+	pages pagination.Sizes,
+) *AccessUseCase {
+	return &AccessUseCase{accessRepo: accessRepo, auditRepo: auditRepo, projects: projects, pages: pages}
 }
 
-// SetAccess sets user's access to a project
-func (uc *AccessUseCase) SetAccess(ctx context.Context, userID, projectID int64, accessLevel string) error {
+// PageLimit is the page size ListUserProjects applies for limit
+func (uc *AccessUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
+// canManage returns ErrAccessDenied unless caller is a global admin or has
+// admin access to the project
+func (uc *AccessUseCase) canManage(ctx context.Context, caller *entity.User, projectID int64) error {
+	if caller == nil {
+		return ErrAccessDenied
+	}
+	if caller.Role == entity.RoleAdmin {
+		return nil
+	}
+	access, err := uc.accessRepo.Get(ctx, caller.ID, projectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAccessDenied
+	}
+	if err != nil {
+		return err
+	}
+	if !access.HasAdminAccess() {
+		return ErrAccessDenied
+	}
+	return nil
+}
+
+// SetAccess sets user's access to a project on behalf of caller
+func (uc *AccessUseCase) SetAccess(ctx context.Context, caller *entity.User, userID, projectID int64, accessLevel string) error {
+	if err := uc.canManage(ctx, caller, projectID); err != nil {
+		return err
+	}
 	if !entity.IsValidAccessLevel(accessLevel) {
 		return ErrInvalidAccessLevel
 	}
@@ -205,7 +317,11 @@ func (uc *AccessUseCase) SetAccess(ctx context.Context, userID, projectID int64,
 		ProjectID:   projectID,
 		AccessLevel: accessLevel,
 	}
-	return uc.accessRepo.Set(ctx, access)
+	if err := uc.accessRepo.Set(ctx, access); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.auditRepo, caller.ID, entity.AuditAccessSet, accessTarget(userID, projectID), accessLevel)
+	return nil
 }
 
 // GetUserAccess gets all project accesses for a user
@@ -213,7 +329,63 @@ func (uc *AccessUseCase) GetUserAccess(ctx context.Context, userID int64) ([]*en
 	return uc.accessRepo.GetByUserID(ctx, userID)
 }
 
-// RemoveAccess removes user's access to a project
-func (uc *AccessUseCase) RemoveAccess(ctx context.Context, userID, projectID int64) error {
-	return uc.accessRepo.Remove(ctx, userID, projectID)
+// ListUserProjects returns a page of the projects userID has access to,
+// ordered by project id, with their details from the project service, and
+// the total number of accessible projects
+func (uc *AccessUseCase) ListUserProjects(ctx context.Context, userID int64, page, limit int) ([]*entity.AccessibleProject, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+
+	accesses, err := uc.accessRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := len(accesses)
+	start := (page - 1) * limit
+	if start >= total {
+		return []*entity.AccessibleProject{}, total, nil
+	}
+	accesses = accesses[start:min(start+limit, total)]
+
+	ids := make([]int64, len(accesses))
+	for i, access := range accesses {
+		ids[i] = access.ProjectID
+	}
+	projects, err := uc.projects.GetProjects(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*entity.AccessibleProject, len(accesses))
+	for i, access := range accesses {
+		result[i] = &entity.AccessibleProject{Access: access, Project: projects[access.ProjectID]}
+	}
+	return result, total, nil
+}
+
+// CheckAccess returns the user's access level on a project, or "" for none,
+// and whether it is at least minLevel. An empty minLevel accepts any level.
+func (uc *AccessUseCase) CheckAccess(ctx context.Context, userID, projectID int64, minLevel string) (string, bool, error) {
+	if minLevel != "" && !entity.IsValidAccessLevel(minLevel) {
+		return "", false, ErrInvalidAccessLevel
+	}
+	access, err := uc.accessRepo.Get(ctx, userID, projectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return access.AccessLevel, access.HasAccessLevel(minLevel), nil
+}
+
+// RemoveAccess removes user's access to a project on behalf of caller
+func (uc *AccessUseCase) RemoveAccess(ctx context.Context, caller *entity.User, userID, projectID int64) error {
+	if err := uc.canManage(ctx, caller, projectID); err != nil {
+		return err
+	}
+	if err := uc.accessRepo.Remove(ctx, userID, projectID); err != nil {
+		return err
+	}
+	recordAudit(ctx, uc.auditRepo, caller.ID, entity.AuditAccessRemove, accessTarget(userID, projectID), "")
+	return nil
 }
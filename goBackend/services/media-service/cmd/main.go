@@ -1,31 +1,61 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 
 	"github.com/portfolio/media-service/internal/config"
+	domain "github.com/portfolio/media-service/internal/domain/repository"
+	"github.com/portfolio/media-service/internal/handler"
 	"github.com/portfolio/media-service/internal/infrastructure/repository"
+	"github.com/portfolio/media-service/internal/infrastructure/scanner"
 	"github.com/portfolio/media-service/internal/infrastructure/storage"
 	"github.com/portfolio/media-service/internal/usecase"
+	pb "github.com/portfolio/proto/media"
 	"github.com/portfolio/shared/database"
+	"github.com/portfolio/shared/grpctls"
+	"github.com/portfolio/shared/logging"
+	"github.com/portfolio/shared/metrics"
 	"github.com/portfolio/shared/middleware"
+	"github.com/portfolio/shared/migrations"
+	"github.com/portfolio/shared/pagination"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply database migrations and exit")
+	flag.Parse()
+
+	logging.Setup("media-service")
+
 	// Load configuration
 	cfg := config.Load()
+	tlsConfig := grpctls.Config{
+		Enabled:  cfg.GRPCTLSEnabled,
+		CertFile: cfg.GRPCTLSCertFile,
+		KeyFile:  cfg.GRPCTLSKeyFile,
+		CAFile:   cfg.GRPCTLSCAFile,
+	}
 
 	// Initialize database connection
 	dbConfig := database.Config{
-		Host:     cfg.DBHost,
-		Port:     cfg.DBPort,
-		User:     cfg.DBUser,
-		Password: cfg.DBPassword,
-		DBName:   cfg.DBName,
-		SSLMode:  cfg.DBSSLMode,
+		Host:        cfg.DBHost,
+		Port:        cfg.DBPort,
+		User:        cfg.DBUser,
+		Password:    cfg.DBPassword,
+		DBName:      cfg.DBName,
+		SSLMode:     cfg.DBSSLMode,
+		SSLRootCert: cfg.DBSSLRootCert,
+		SSLCert:     cfg.DBSSLCert,
+		SSLKey:      cfg.DBSSLKey,
+
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
 	}
 
 	pool, err := database.NewPool(dbConfig)
@@ -36,8 +66,17 @@ func main() {
 
 	db := pool.GetDB()
 
+	// Apply pending schema migrations
+	if _, err := migrations.Run(context.Background(), db); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if *migrateOnly {
+		log.Println("Migrations applied")
+		return
+	}
+
 	// Initialize storage
-	localStorage, err := storage.NewLocalStorage(cfg.StoragePath, cfg.StorageURL)
+	localStorage, err := storage.NewLocalStorage(cfg.StoragePath, cfg.StorageURL, cfg.StoragePublicURL)
 	if err != nil {
 		log.Fatalf("Failed to initialize storage: %v", err)
 	}
@@ -45,18 +84,54 @@ func main() {
 	// Initialize repositories
 	fileRepo := repository.NewPostgresMediaFileRepository(db)
 
+	// Metrics
+	registry := metrics.NewRegistry()
+	grpcMetrics := metrics.NewGRPCServerMetrics(registry)
+	metrics.Serve(cfg.MetricsPort, registry)
+
 	// Initialize use cases
-	_ = usecase.NewMediaUseCase(fileRepo, localStorage)
+	var fileScanner domain.FileScanner
+	if cfg.ScanUploads {
+		fileScanner = scanner.Noop{}
+	}
+	quota := usecase.StorageQuota{Default: cfg.UploadQuotaBytes, ByRole: cfg.UploadQuotaByRole}
+	mediaUC := usecase.NewMediaUseCase(fileRepo, localStorage, fileScanner, cfg.ThumbnailSize, quota, usecase.NewUploadMetrics(registry), pagination.New(cfg.DefaultPageSize, cfg.MaxPageSize))
+
+	// Files stored before sizes were recorded count towards quotas once sized
+	go func() {
+		n, err := mediaUC.BackfillFileSizes(context.Background())
+		if err != nil {
+			log.Printf("Failed to backfill file sizes: %v", err)
+			return
+		}
+		if n > 0 {
+			log.Printf("Recorded the size of %d files stored without one", n)
+		}
+	}()
+
+	// Plaintext unless GRPC_TLS_ENABLED is set
+	serverCreds, err := grpctls.ServerOption(tlsConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up gRPC TLS: %v", err)
+	}
 
 	// Create gRPC server with middleware
 	grpcServer := grpc.NewServer(
+		serverCreds,
 		grpc.ChainUnaryInterceptor(
 			middleware.RecoveryInterceptor(),
+			grpcMetrics.UnaryInterceptor(),
 			middleware.LoggingInterceptor(),
+			middleware.IdentityInterceptor(),
+			middleware.ValidationInterceptor(),
 		),
+		// Uploads take the uploader's quota from the caller identity
+		grpc.ChainStreamInterceptor(middleware.IdentityStreamInterceptor()),
 	)
 
-	// TODO: Register media service handler
+	// Register media service handler
+	mediaHandler := handler.NewMediaHandler(mediaUC)
+	pb.RegisterMediaServiceServer(grpcServer, mediaHandler)
 
 	// Start server
 	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.GRPCPort))
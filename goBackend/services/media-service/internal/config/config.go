@@ -3,33 +3,83 @@ package config
 import (
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds the application configuration
 type Config struct {
-	GRPCPort    int
-	DBHost      string
-	DBPort      int
-	DBUser      string
-	DBPassword  string
-	DBName      string
-	DBSSLMode   string
-	StoragePath string
-	StorageURL  string
+	GRPCPort          int
+	GRPCTLSEnabled    bool
+	GRPCTLSCertFile   string
+	GRPCTLSKeyFile    string
+	GRPCTLSCAFile     string
+	MetricsPort       int
+	DBHost            string
+	DBPort            int
+	DBUser            string
+	DBPassword        string
+	DBName            string
+	DBSSLMode         string
+	DBSSLRootCert     string
+	DBSSLCert         string
+	DBSSLKey          string
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+	StoragePath       string
+	StorageURL        string
+	// StoragePublicURL is a URL template with a {file} placeholder, e.g. a CDN
+	StoragePublicURL string
+	// ScanUploads runs every upload through the file scanner before it is
+	// stored
+	ScanUploads bool
+	// ThumbnailSize bounds image thumbnails in pixels; 0 disables them
+	ThumbnailSize int
+
+	// List pages hold DefaultPageSize items unless a request asks for
+	// another size, up to MaxPageSize
+	DefaultPageSize int
+	MaxPageSize     int
+
+	// UploadQuotaBytes caps the combined size of each user's files;
+	// UploadQuotaByRole overrides it per role. 0 means unlimited.
+	UploadQuotaBytes  int64
+	UploadQuotaByRole map[string]int64
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
-		GRPCPort:    getEnvInt("GRPC_PORT", 50055),
-		DBHost:      getEnv("DB_HOST", "localhost"),
-		DBPort:      getEnvInt("DB_PORT", 5432),
-		DBUser:      getEnv("DB_USER", "postgres"),
-		DBPassword:  getEnv("DB_PASSWORD", "postgres"),
-		DBName:      getEnv("DB_NAME", "portfolio"),
-		DBSSLMode:   getEnv("DB_SSL_MODE", "disable"),
-		StoragePath: getEnv("STORAGE_PATH", "./uploads"),
-		StorageURL:  getEnv("STORAGE_URL", "http://localhost:50055/files"),
+		GRPCPort:          getEnvInt("GRPC_PORT", 50055),
+		GRPCTLSEnabled:    getEnvBool("GRPC_TLS_ENABLED", false),
+		GRPCTLSCertFile:   getEnv("GRPC_TLS_CERT_FILE", ""),
+		GRPCTLSKeyFile:    getEnv("GRPC_TLS_KEY_FILE", ""),
+		GRPCTLSCAFile:     getEnv("GRPC_TLS_CA_FILE", ""),
+		MetricsPort:       getEnvInt("METRICS_PORT", 9105),
+		DBHost:            getEnv("DB_HOST", "localhost"),
+		DBPort:            getEnvInt("DB_PORT", 5432),
+		DBUser:            getEnv("DB_USER", "postgres"),
+		DBPassword:        getEnv("DB_PASSWORD", "postgres"),
+		DBName:            getEnv("DB_NAME", "portfolio"),
+		DBSSLMode:         getEnv("DB_SSL_MODE", "disable"),
+		DBSSLRootCert:     getEnv("DB_SSL_ROOT_CERT", ""),
+		DBSSLCert:         getEnv("DB_SSL_CERT", ""),
+		DBSSLKey:          getEnv("DB_SSL_KEY", ""),
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		DBConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 0),
+		DefaultPageSize:   getEnvInt("DEFAULT_PAGE_SIZE", 20),
+		MaxPageSize:       getEnvInt("MAX_PAGE_SIZE", 100),
+		StoragePath:       getEnv("STORAGE_PATH", "./uploads"),
+		StorageURL:        getEnv("STORAGE_URL", "http://localhost:50055/files"),
+		StoragePublicURL:  getEnv("STORAGE_PUBLIC_URL", ""),
+		ScanUploads:       getEnvBool("SCAN_UPLOADS", true),
+		ThumbnailSize:     getEnvInt("THUMBNAIL_SIZE", 256),
+		UploadQuotaBytes:  int64(getEnvInt("UPLOAD_QUOTA_BYTES", 1<<30)),
+		UploadQuotaByRole: getEnvQuotas("UPLOAD_QUOTA_BY_ROLE", "admin=0"),
 	}
 }
 
@@ -48,3 +98,36 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+// getEnvQuotas reads comma-separated role=bytes pairs, skipping malformed ones
+func getEnvQuotas(key, defaultValue string) map[string]int64 {
+	quotas := make(map[string]int64)
+	for _, pair := range strings.Split(getEnv(key, defaultValue), ",") {
+		role, raw, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || role == "" {
+			continue
+		}
+		if limit, err := strconv.ParseInt(raw, 10, 64); err == nil && limit >= 0 {
+			quotas[role] = limit
+		}
+	}
+	return quotas
+}
@@ -11,6 +11,14 @@ type MediaFile struct {
 	UploadedAt time.Time `json:"uploaded_at"`
 	FileType   string    `json:"file_type"` // image, document, resume
 	FileSize   int64     `json:"file_size"`
+	// ThumbnailURL is a scaled-down PNG copy of an image; empty for other files
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+	// ProjectID and TaskID are what the file was uploaded for; 0 for none
+	ProjectID int64 `json:"project_id,omitempty"`
+	TaskID    int64 `json:"task_id,omitempty"`
+	// ContentHash is the hex SHA-256 of the content; empty for files
+	// uploaded before it was recorded
+	ContentHash string `json:"content_hash,omitempty"`
 }
 
 // NewMediaFile creates a new media file entity
@@ -25,6 +33,13 @@ func NewMediaFile(fileName, fileURL, fileType string, uploadedBy, fileSize int64
 	}
 }
 
+// FileAssociation is the project, and optionally the task within it, that a
+// file is uploaded for. The zero value leaves the file unassociated.
+type FileAssociation struct {
+	ProjectID int64
+	TaskID    int64
+}
+
 // File type constants
 const (
 	FileTypeImage    = "image"
@@ -2,17 +2,47 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/portfolio/media-service/internal/domain/entity"
 )
 
+// FileFilter restricts a file list. Empty fields match every file; the
+// upload time range includes UploadedAfter and excludes UploadedBefore.
+type FileFilter struct {
+	FileType       string
+	UploadedAfter  *time.Time
+	UploadedBefore *time.Time
+	ProjectID      int64
+	TaskID         int64
+}
+
+// ErrFileNotFound is returned by MediaFileRepository.GetByID for unknown IDs
+var ErrFileNotFound = errors.New("media file not found")
+
+// ErrQuotaExceeded is returned by MediaFileRepository.CreateWithinQuota for
+// files that would take their uploader past the limit
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
 // MediaFileRepository defines the interface for media file data access
 type MediaFileRepository interface {
 	Create(ctx context.Context, file *entity.MediaFile) error
 	GetByID(ctx context.Context, id int64) (*entity.MediaFile, error)
+	GetByIDs(ctx context.Context, ids []int64) ([]*entity.MediaFile, error)
 	Delete(ctx context.Context, id int64) error
-	List(ctx context.Context, page, limit int, fileType string) ([]*entity.MediaFile, int, error)
+	List(ctx context.Context, page, limit int, filter FileFilter) ([]*entity.MediaFile, int, error)
 	GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.MediaFile, int, error)
+	// TotalSizeByUser returns the combined size of a user's files in bytes
+	TotalSizeByUser(ctx context.Context, userID int64) (int64, error)
+	// CreateWithinQuota creates file unless its uploader's files would then
+	// take more than limit bytes. Calls for the same uploader are serialized,
+	// so concurrent uploads can't each fit under the limit on their own.
+	CreateWithinQuota(ctx context.Context, file *entity.MediaFile, limit int64) error
+	// ListUnsized lists up to limit files recorded without a size, by
+	// ascending ID from after afterID
+	ListUnsized(ctx context.Context, afterID int64, limit int) ([]*entity.MediaFile, error)
+	SetFileSize(ctx context.Context, id, size int64) error
 }
 
 // FileStorage defines the interface for file storage operations
@@ -21,3 +51,10 @@ type FileStorage interface {
 	Delete(ctx context.Context, fileURL string) error
 	Get(ctx context.Context, fileURL string) ([]byte, error)
 }
+
+// FileScanner checks uploads for viruses and other malware. Scan returns the
+// name of the threat found, or "" for a clean file; err is only set when the
+// file could not be scanned.
+type FileScanner interface {
+	Scan(ctx context.Context, fileName string, data []byte) (threat string, err error)
+}
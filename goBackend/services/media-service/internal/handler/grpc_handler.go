@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"github.com/portfolio/media-service/internal/domain/entity"
+	"github.com/portfolio/media-service/internal/domain/repository"
+	"github.com/portfolio/media-service/internal/usecase"
+	pb "github.com/portfolio/proto/media"
+	"github.com/portfolio/shared/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MediaHandler handles gRPC requests for media service
+type MediaHandler struct {
+	pb.UnimplementedMediaServiceServer
+	mediaUC *usecase.MediaUseCase
+}
+
+// NewMediaHandler creates a new MediaHandler
+func NewMediaHandler(mediaUC *usecase.MediaUseCase) *MediaHandler {
+	return &MediaHandler{mediaUC: mediaUC}
+}
+
+// UploadFile receives the file metadata followed by its content in chunks.
+// The caller's role picks the storage quota, so they may only upload as
+// themselves.
+func (h *MediaHandler) UploadFile(stream pb.MediaService_UploadFileServer) error {
+	caller, ok := middleware.CallerFromContext(stream.Context())
+	if !ok {
+		return status.Error(codes.Unauthenticated, "caller identity required")
+	}
+
+	var metadata *pb.FileMetadata
+	var data bytes.Buffer
+
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch d := req.Data.(type) {
+		case *pb.UploadFileRequest_Metadata:
+			metadata = d.Metadata
+		case *pb.UploadFileRequest_Chunk:
+			if metadata == nil {
+				return status.Error(codes.InvalidArgument, "metadata must be sent before file content")
+			}
+			// Stop reading as soon as the upload is too large instead of
+			// buffering the rest of the stream
+			if data.Len()+len(d.Chunk) > usecase.MaxFileSize {
+				return status.Error(codes.InvalidArgument, usecase.ErrFileTooLarge.Error())
+			}
+			data.Write(d.Chunk)
+		}
+	}
+
+	if metadata == nil {
+		return status.Error(codes.InvalidArgument, "missing file metadata")
+	}
+	if metadata.UploadedBy != caller.UserID {
+		return status.Error(codes.PermissionDenied, "cannot upload on behalf of another user")
+	}
+
+	assoc := entity.FileAssociation{ProjectID: metadata.ProjectId, TaskID: metadata.TaskId}
+	file, err := h.mediaUC.UploadFile(stream.Context(), metadata.FileName, metadata.FileType, metadata.UploadedBy, caller.Role, assoc, data.Bytes())
+	if err != nil {
+		if errors.Is(err, usecase.ErrQuotaExceeded) {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		if err == usecase.ErrInvalidFileType || err == usecase.ErrInvalidAssociation || err == usecase.ErrFileTooLarge || errors.Is(err, usecase.ErrFileRejected) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	return stream.SendAndClose(&pb.UploadFileResponse{File: mapFileToProto(file)})
+}
+
+func (h *MediaHandler) GetFile(ctx context.Context, req *pb.GetFileRequest) (*pb.MediaFileResponse, error) {
+	file, err := h.mediaUC.GetFile(ctx, req.Id)
+	if err != nil {
+		if err == usecase.ErrFileNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.MediaFileResponse{File: mapFileToProto(file)}, nil
+}
+
+// downloadChunkSize is the size of the content chunks DownloadFile sends
+const downloadChunkSize = 64 * 1024
+
+// DownloadFile sends the file record, then its content in chunks
+func (h *MediaHandler) DownloadFile(req *pb.DownloadFileRequest, stream pb.MediaService_DownloadFileServer) error {
+	file, data, err := h.mediaUC.GetFileContent(stream.Context(), req.Id, req.CachedHashes)
+	if err != nil {
+		if err == usecase.ErrFileNotFound {
+			return status.Error(codes.NotFound, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	if err := stream.Send(&pb.DownloadFileResponse{Data: &pb.DownloadFileResponse_File{File: mapFileToProto(file)}}); err != nil {
+		return err
+	}
+	for len(data) > 0 {
+		n := min(len(data), downloadChunkSize)
+		if err := stream.Send(&pb.DownloadFileResponse{Data: &pb.DownloadFileResponse_Chunk{Chunk: data[:n]}}); err != nil {
+			return err
+		}
+		data = data[n:]
+	}
+	return nil
+}
+
+func (h *MediaHandler) GetFilesByIDs(ctx context.Context, req *pb.GetFilesByIDsRequest) (*pb.ListFilesResponse, error) {
+	files, err := h.mediaUC.GetFilesByIDs(ctx, req.Ids)
+	if err != nil {
+		if err == usecase.ErrTooManyFiles {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListFilesResponse{Files: mapFilesToProto(files), Total: int32(len(files))}, nil
+}
+
+func (h *MediaHandler) DeleteFile(ctx context.Context, req *pb.DeleteFileRequest) (*pb.Empty, error) {
+	if err := h.mediaUC.DeleteFile(ctx, req.Id); err != nil {
+		if err == usecase.ErrFileNotFound {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *MediaHandler) ListFiles(ctx context.Context, req *pb.ListFilesRequest) (*pb.ListFilesResponse, error) {
+	filter := repository.FileFilter{FileType: req.FileType, ProjectID: req.ProjectId, TaskID: req.TaskId}
+	if req.UploadedAfter != nil {
+		t := req.UploadedAfter.AsTime()
+		filter.UploadedAfter = &t
+	}
+	if req.UploadedBefore != nil {
+		t := req.UploadedBefore.AsTime()
+		filter.UploadedBefore = &t
+	}
+
+	files, total, err := h.mediaUC.ListFiles(ctx, int(req.Page), int(req.Limit), filter)
+	if err != nil {
+		if err == usecase.ErrInvalidFileType || err == usecase.ErrInvalidRange {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListFilesResponse{
+		Files: mapFilesToProto(files),
+		Total: int32(total),
+		Limit: int32(h.mediaUC.PageLimit(int(req.Limit))),
+	}, nil
+}
+
+func (h *MediaHandler) GetFilesByUser(ctx context.Context, req *pb.GetFilesByUserRequest) (*pb.ListFilesResponse, error) {
+	files, total, err := h.mediaUC.GetFilesByUser(ctx, req.UserId, int(req.Page), int(req.Limit))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.ListFilesResponse{
+		Files: mapFilesToProto(files),
+		Total: int32(total),
+		Limit: int32(h.mediaUC.PageLimit(int(req.Limit))),
+	}, nil
+}
+
+// --- Helpers ---
+
+func mapFileToProto(f *entity.MediaFile) *pb.MediaFile {
+	return &pb.MediaFile{
+		Id:         f.ID,
+		FileName:   f.FileName,
+		FileUrl:    f.FileURL,
+		UploadedBy: f.UploadedBy,
+		UploadedAt: timestamppb.New(f.UploadedAt),
+		FileType:   f.FileType,
+		FileSize:   f.FileSize,
+
+		ThumbnailUrl: f.ThumbnailURL,
+		ProjectId:    f.ProjectID,
+		TaskId:       f.TaskID,
+		ContentHash:  f.ContentHash,
+	}
+}
+
+func mapFilesToProto(files []*entity.MediaFile) []*pb.MediaFile {
+	result := make([]*pb.MediaFile, len(files))
+	for i, f := range files {
+		result[i] = mapFileToProto(f)
+	}
+	return result
+}
@@ -3,10 +3,31 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
 
+	"github.com/lib/pq"
 	"github.com/portfolio/media-service/internal/domain/entity"
+	domain "github.com/portfolio/media-service/internal/domain/repository"
 )
 
+// mediaFileColumns are the media_files columns scanMediaFile reads
+const mediaFileColumns = `id, file_name, file_url, uploaded_by, uploaded_at, file_type, file_size,
+	COALESCE(thumbnail_url, ''), COALESCE(project_id, 0), COALESCE(task_id, 0), COALESCE(content_hash, '')`
+
+// scanMediaFile reads a row selected with mediaFileColumns
+func scanMediaFile(row interface{ Scan(...any) error }) (*entity.MediaFile, error) {
+	file := &entity.MediaFile{}
+	err := row.Scan(&file.ID, &file.FileName, &file.FileURL, &file.UploadedBy, &file.UploadedAt, &file.FileType, &file.FileSize,
+		&file.ThumbnailURL, &file.ProjectID, &file.TaskID, &file.ContentHash)
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
 // PostgresMediaFileRepository implements MediaFileRepository
 type PostgresMediaFileRepository struct {
 	db *sql.DB
@@ -19,27 +40,125 @@ func NewPostgresMediaFileRepository(db *sql.DB) *PostgresMediaFileRepository {
 
 // Create creates a new media file record
 func (r *PostgresMediaFileRepository) Create(ctx context.Context, file *entity.MediaFile) error {
+	return insertMediaFile(ctx, r.db, file)
+}
+
+// insertMediaFile inserts file through db or a transaction and sets its ID
+func insertMediaFile(ctx context.Context, q interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}, file *entity.MediaFile) error {
 	query := `
-		INSERT INTO media_files (file_name, file_url, uploaded_by, uploaded_at, file_type)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO media_files (file_name, file_url, uploaded_by, uploaded_at, file_type, thumbnail_url, project_id, task_id, content_hash, file_size)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NULLIF($7, 0), NULLIF($8, 0), NULLIF($9, ''), $10)
 		RETURNING id
 	`
-	return r.db.QueryRowContext(ctx, query,
-		file.FileName, file.FileURL, file.UploadedBy, file.UploadedAt, file.FileType,
+	return q.QueryRowContext(ctx, query,
+		file.FileName, file.FileURL, file.UploadedBy, file.UploadedAt, file.FileType, file.ThumbnailURL,
+		file.ProjectID, file.TaskID, file.ContentHash, file.FileSize,
 	).Scan(&file.ID)
 }
 
-// GetByID gets a media file by ID
+// uploaderLockKey returns the advisory lock key serializing a user's
+// quota-checked uploads
+func uploaderLockKey(userID int64) int64 {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "media_files:quota:%d", userID)
+	return int64(h.Sum64())
+}
+
+// CreateWithinQuota creates file unless its uploader's files would then
+// take more than limit bytes. The uploader's advisory lock is held until
+// the insert commits, so the total each upload checks includes every one
+// that went before it.
+func (r *PostgresMediaFileRepository) CreateWithinQuota(ctx context.Context, file *entity.MediaFile, limit int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, uploaderLockKey(file.UploadedBy)); err != nil {
+		return err
+	}
+	var used int64
+	if err := tx.QueryRowContext(ctx, totalSizeQuery, file.UploadedBy).Scan(&used); err != nil {
+		return err
+	}
+	if used+file.FileSize > limit {
+		return fmt.Errorf("%w: %d of %d bytes used, upload is %d bytes", domain.ErrQuotaExceeded, used, limit, file.FileSize)
+	}
+	if err := insertMediaFile(ctx, tx, file); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// GetByID gets a media file by ID, or domain.ErrFileNotFound
 func (r *PostgresMediaFileRepository) GetByID(ctx context.Context, id int64) (*entity.MediaFile, error) {
-	query := `SELECT id, file_name, file_url, uploaded_by, uploaded_at, file_type FROM media_files WHERE id = $1`
-	file := &entity.MediaFile{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&file.ID, &file.FileName, &file.FileURL, &file.UploadedBy, &file.UploadedAt, &file.FileType,
-	)
+	query := `SELECT ` + mediaFileColumns + ` FROM media_files WHERE id = $1`
+	file, err := scanMediaFile(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrFileNotFound
+	}
+	return file, err
+}
+
+// GetByIDs gets the media files with the given IDs in no particular order.
+// IDs without a file are skipped.
+func (r *PostgresMediaFileRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.MediaFile, error) {
+	query := `SELECT ` + mediaFileColumns + ` FROM media_files WHERE id = ANY($1)`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
 	if err != nil {
 		return nil, err
 	}
-	return file, nil
+	defer rows.Close()
+
+	var files []*entity.MediaFile
+	for rows.Next() {
+		file, err := scanMediaFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, rows.Err()
+}
+
+// totalSizeQuery sums the sizes of the files uploaded by $1
+const totalSizeQuery = `SELECT COALESCE(SUM(file_size), 0) FROM media_files WHERE uploaded_by = $1`
+
+// TotalSizeByUser returns the combined size of the files a user uploaded
+func (r *PostgresMediaFileRepository) TotalSizeByUser(ctx context.Context, userID int64) (int64, error) {
+	var total int64
+	err := r.db.QueryRowContext(ctx, totalSizeQuery, userID).Scan(&total)
+	return total, err
+}
+
+// ListUnsized lists up to limit files recorded without a size, by ascending
+// ID from after afterID
+func (r *PostgresMediaFileRepository) ListUnsized(ctx context.Context, afterID int64, limit int) ([]*entity.MediaFile, error) {
+	query := `SELECT ` + mediaFileColumns + ` FROM media_files WHERE file_size = 0 AND id > $1 ORDER BY id LIMIT $2`
+	rows, err := r.db.QueryContext(ctx, query, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var files []*entity.MediaFile
+	for rows.Next() {
+		file, err := scanMediaFile(rows)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, rows.Err()
+}
+
+// SetFileSize records the size of a file
+func (r *PostgresMediaFileRepository) SetFileSize(ctx context.Context, id, size int64) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE media_files SET file_size = $2 WHERE id = $1`, id, size)
+	return err
 }
 
 // Delete deletes a media file record
@@ -49,38 +168,47 @@ func (r *PostgresMediaFileRepository) Delete(ctx context.Context, id int64) erro
 	return err
 }
 
-// List lists media files with pagination
-func (r *PostgresMediaFileRepository) List(ctx context.Context, page, limit int, fileType string) ([]*entity.MediaFile, int, error) {
+// List lists media files with pagination, newest first
+func (r *PostgresMediaFileRepository) List(ctx context.Context, page, limit int, filter domain.FileFilter) ([]*entity.MediaFile, int, error) {
 	offset := (page - 1) * limit
 
 	// Build query
-	var countQuery, query string
+	var conditions []string
 	var args []interface{}
-
-	if fileType != "" {
-		countQuery = `SELECT COUNT(*) FROM media_files WHERE file_type = $1`
-		query = `SELECT id, file_name, file_url, uploaded_by, uploaded_at, file_type FROM media_files WHERE file_type = $1 ORDER BY uploaded_at DESC LIMIT $2 OFFSET $3`
-		args = []interface{}{fileType, limit, offset}
-	} else {
-		countQuery = `SELECT COUNT(*) FROM media_files`
-		query = `SELECT id, file_name, file_url, uploaded_by, uploaded_at, file_type FROM media_files ORDER BY uploaded_at DESC LIMIT $1 OFFSET $2`
-		args = []interface{}{limit, offset}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.FileType != "" {
+		addCondition("file_type = $%d", filter.FileType)
+	}
+	if filter.UploadedAfter != nil {
+		addCondition("uploaded_at >= $%d", *filter.UploadedAfter)
+	}
+	if filter.UploadedBefore != nil {
+		addCondition("uploaded_at < $%d", *filter.UploadedBefore)
+	}
+	if filter.ProjectID != 0 {
+		addCondition("project_id = $%d", filter.ProjectID)
+	}
+	if filter.TaskID != 0 {
+		addCondition("task_id = $%d", filter.TaskID)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
 	}
 
 	// Get total
 	var total int
-	if fileType != "" {
-		if err := r.db.QueryRowContext(ctx, countQuery, fileType).Scan(&total); err != nil {
-			return nil, 0, err
-		}
-	} else {
-		if err := r.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
-			return nil, 0, err
-		}
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM media_files`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
 	}
 
 	// Get files
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	query := fmt.Sprintf(`SELECT %s FROM media_files%s ORDER BY uploaded_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		mediaFileColumns, where, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -88,8 +216,8 @@ func (r *PostgresMediaFileRepository) List(ctx context.Context, page, limit int,
 
 	var files []*entity.MediaFile
 	for rows.Next() {
-		file := &entity.MediaFile{}
-		if err := rows.Scan(&file.ID, &file.FileName, &file.FileURL, &file.UploadedBy, &file.UploadedAt, &file.FileType); err != nil {
+		file, err := scanMediaFile(rows)
+		if err != nil {
 			return nil, 0, err
 		}
 		files = append(files, file)
@@ -110,7 +238,7 @@ func (r *PostgresMediaFileRepository) GetByUserID(ctx context.Context, userID in
 	}
 
 	// Get files
-	query := `SELECT id, file_name, file_url, uploaded_by, uploaded_at, file_type FROM media_files WHERE uploaded_by = $1 ORDER BY uploaded_at DESC LIMIT $2 OFFSET $3`
+	query := `SELECT ` + mediaFileColumns + ` FROM media_files WHERE uploaded_by = $1 ORDER BY uploaded_at DESC, id DESC LIMIT $2 OFFSET $3`
 	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
 	if err != nil {
 		return nil, 0, err
@@ -119,8 +247,8 @@ func (r *PostgresMediaFileRepository) GetByUserID(ctx context.Context, userID in
 
 	var files []*entity.MediaFile
 	for rows.Next() {
-		file := &entity.MediaFile{}
-		if err := rows.Scan(&file.ID, &file.FileName, &file.FileURL, &file.UploadedBy, &file.UploadedAt, &file.FileType); err != nil {
+		file, err := scanMediaFile(rows)
+		if err != nil {
 			return nil, 0, err
 		}
 		files = append(files, file)
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/portfolio/media-service/internal/domain/entity"
+	domain "github.com/portfolio/media-service/internal/domain/repository"
+)
+
+// fileColumns are the columns of a selected media file
+var fileColumns = []string{"id", "file_name", "file_url", "uploaded_by", "uploaded_at", "file_type", "file_size", "thumbnail_url", "project_id", "task_id", "content_hash"}
+
+func TestPostgresMediaFileRepository_GetByIDNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM media_files WHERE id = $1")).
+		WithArgs(int64(9)).
+		WillReturnRows(sqlmock.NewRows(fileColumns))
+
+	if _, err := NewPostgresMediaFileRepository(db).GetByID(context.Background(), 9); err != domain.ErrFileNotFound {
+		t.Errorf("GetByID() error = %v, want ErrFileNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresMediaFileRepository_GetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// Files 1-4 are seeded; the database only hands back those asked for
+	uploadedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	seeded := map[int64][]driver.Value{
+		1: {int64(1), "a.png", "/files/a.png", int64(7), uploadedAt, "image", int64(2048), "/files/a_thumb.png", int64(0), int64(0), "9f86d081"},
+		2: {int64(2), "b.pdf", "/files/b.pdf", int64(7), uploadedAt, "document", int64(0), "", int64(0), int64(0), ""},
+		3: {int64(3), "c.png", "/files/c.png", int64(8), uploadedAt, "image", int64(0), "/files/c_thumb.png", int64(2), int64(5), ""},
+		4: {int64(4), "cv.pdf", "/files/cv.pdf", int64(8), uploadedAt, "resume", int64(0), "", int64(0), int64(0), ""},
+	}
+	requested := []int64{3, 1, 99}
+	rows := sqlmock.NewRows(fileColumns)
+	for _, id := range requested {
+		if values, ok := seeded[id]; ok {
+			rows.AddRow(values...)
+		}
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM media_files WHERE id = ANY($1)")).
+		WithArgs(pq.Array(requested)).
+		WillReturnRows(rows)
+
+	repo := NewPostgresMediaFileRepository(db)
+	files, err := repo.GetByIDs(context.Background(), requested)
+	if err != nil {
+		t.Fatalf("GetByIDs() error = %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("GetByIDs() returned %d files, want 2", len(files))
+	}
+	got := map[int64]string{}
+	for _, f := range files {
+		got[f.ID] = f.FileName
+		if f.ID == 1 && (f.ContentHash != "9f86d081" || f.FileSize != 2048) {
+			t.Errorf("file 1 content_hash = %q, file_size = %d; want %q and 2048", f.ContentHash, f.FileSize, "9f86d081")
+		}
+	}
+	if got[1] != "a.png" || got[3] != "c.png" {
+		t.Errorf("GetByIDs() files = %v, want only 1 (a.png) and 3 (c.png)", got)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresMediaFileRepository_List_Filters(t *testing.T) {
+	after := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		filter domain.FileFilter
+		where  string
+		args   []driver.Value
+	}{
+		{
+			name:   "no filter",
+			filter: domain.FileFilter{},
+			where:  "FROM media_files ORDER BY",
+		},
+		{
+			name:   "date range",
+			filter: domain.FileFilter{UploadedAfter: &after, UploadedBefore: &before},
+			where:  "FROM media_files WHERE uploaded_at >= $1 AND uploaded_at < $2 ORDER BY",
+			args:   []driver.Value{after, before},
+		},
+		{
+			name:   "type and start date",
+			filter: domain.FileFilter{FileType: "image", UploadedAfter: &after},
+			where:  "FROM media_files WHERE file_type = $1 AND uploaded_at >= $2 ORDER BY",
+			args:   []driver.Value{"image", after},
+		},
+		{
+			name:   "project and task",
+			filter: domain.FileFilter{ProjectID: 2, TaskID: 5},
+			where:  "FROM media_files WHERE project_id = $1 AND task_id = $2 ORDER BY",
+			args:   []driver.Value{int64(2), int64(5)},
+		},
+		{
+			name:   "end date only",
+			filter: domain.FileFilter{UploadedBefore: &before},
+			where:  "FROM media_files WHERE uploaded_at < $1 ORDER BY",
+			args:   []driver.Value{before},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			countQuery := "SELECT COUNT(*) FROM media_files"
+			if len(tt.args) > 0 {
+				countQuery += " WHERE"
+			}
+			mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+			n := len(tt.args)
+			mock.ExpectQuery(regexp.QuoteMeta(tt.where) + ".*" + regexp.QuoteMeta(fmt.Sprintf("LIMIT $%d OFFSET $%d", n+1, n+2))).
+				WithArgs(append(tt.args, 2, 2)...).
+				WillReturnRows(sqlmock.NewRows(fileColumns).
+					AddRow(int64(5), "a.png", "/files/a.png", int64(7), after, "image", int64(0), "", int64(0), int64(0), ""))
+
+			repo := NewPostgresMediaFileRepository(db)
+			files, total, err := repo.List(context.Background(), 2, 2, tt.filter)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if total != 3 || len(files) != 1 {
+				t.Errorf("List() = %d files of %d, want 1 of 3", len(files), total)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresMediaFileRepository_List_ByProject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// Files 1-4 are seeded: 1 and 3 belong to project 2 (3 to its task 5),
+	// 2 to project 9 and 4 to nothing. Only project 2's come back.
+	uploadedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	seeded := [][]driver.Value{
+		{int64(4), "cv.pdf", "/files/cv.pdf", int64(8), uploadedAt, "resume", int64(0), "", int64(0), int64(0), ""},
+		{int64(3), "c.png", "/files/c.png", int64(8), uploadedAt, "image", int64(0), "", int64(2), int64(5), ""},
+		{int64(2), "b.pdf", "/files/b.pdf", int64(7), uploadedAt, "document", int64(0), "", int64(9), int64(0), ""},
+		{int64(1), "a.png", "/files/a.png", int64(7), uploadedAt, "image", int64(0), "", int64(2), int64(0), ""},
+	}
+	rows := sqlmock.NewRows(fileColumns)
+	total := 0
+	for _, values := range seeded {
+		if values[8] == int64(2) {
+			rows.AddRow(values...)
+			total++
+		}
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM media_files WHERE project_id = $1")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(total))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM media_files WHERE project_id = $1 ORDER BY uploaded_at DESC, id DESC LIMIT $2 OFFSET $3")).
+		WithArgs(int64(2), 10, 0).
+		WillReturnRows(rows)
+
+	files, n, err := NewPostgresMediaFileRepository(db).List(context.Background(), 1, 10, domain.FileFilter{ProjectID: 2})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if n != 2 || len(files) != 2 {
+		t.Fatalf("List() = %d files of %d, want 2 of 2", len(files), n)
+	}
+	if files[0].ID != 3 || files[0].ProjectID != 2 || files[0].TaskID != 5 || files[1].ID != 1 || files[1].TaskID != 0 {
+		t.Errorf("List() = %+v, %+v; want file 3 on task 5 and file 1 on the project itself", files[0], files[1])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresMediaFileRepository_FileSize(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+	repo := NewPostgresMediaFileRepository(db)
+	ctx := context.Background()
+
+	file := &entity.MediaFile{FileName: "a.png", FileURL: "/files/a.png", UploadedBy: 7, UploadedAt: time.Now(), FileType: "image", FileSize: 2048}
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO media_files")).
+		WithArgs(file.FileName, file.FileURL, file.UploadedBy, file.UploadedAt, file.FileType, "", int64(0), int64(0), "", int64(2048)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	if err := repo.Create(ctx, file); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(file_size), 0) FROM media_files WHERE uploaded_by = $1")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(int64(5120)))
+	total, err := repo.TotalSizeByUser(ctx, 7)
+	if err != nil || total != 5120 {
+		t.Errorf("TotalSizeByUser() = %d, %v; want 5120", total, err)
+	}
+
+	// Files from before sizes were recorded are listed for the backfill
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM media_files WHERE file_size = 0 AND id > $1 ORDER BY id LIMIT $2")).
+		WithArgs(int64(10), 100).
+		WillReturnRows(sqlmock.NewRows(fileColumns).AddRow(11, "old.pdf", "/files/old.pdf", 7, now, "document", 0, "", 0, 0, ""))
+	unsized, err := repo.ListUnsized(ctx, 10, 100)
+	if err != nil || len(unsized) != 1 || unsized[0].ID != 11 {
+		t.Errorf("ListUnsized() = %v, %v; want file 11", unsized, err)
+	}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE media_files SET file_size = $2 WHERE id = $1")).
+		WithArgs(int64(11), int64(4096)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	if err := repo.SetFileSize(ctx, 11, 4096); err != nil {
+		t.Errorf("SetFileSize() error = %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresMediaFileRepository_CreateWithinQuota(t *testing.T) {
+	tests := []struct {
+		name    string
+		used    int64
+		wantErr error
+	}{
+		{"fits", 3000, nil},
+		{"over the limit", 3500, domain.ErrQuotaExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			file := &entity.MediaFile{FileName: "a.png", FileURL: "/files/a.png", UploadedBy: 7, UploadedAt: time.Now(), FileType: "image", FileSize: 1000}
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_xact_lock($1)")).
+				WithArgs(uploaderLockKey(7)).
+				WillReturnResult(sqlmock.NewResult(0, 0))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(SUM(file_size), 0) FROM media_files WHERE uploaded_by = $1")).
+				WithArgs(int64(7)).
+				WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow(tt.used))
+			if tt.wantErr == nil {
+				mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO media_files")).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			err = NewPostgresMediaFileRepository(db).CreateWithinQuota(context.Background(), file, 4000)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && file.ID != 5) {
+				t.Errorf("CreateWithinQuota() = %v with id %d, want %v", err, file.ID, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
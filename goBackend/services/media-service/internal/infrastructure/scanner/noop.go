@@ -0,0 +1,12 @@
+package scanner
+
+import "context"
+
+// Noop implements FileScanner by accepting every file. It stands in until a
+// real scanner such as ClamAV is configured.
+type Noop struct{}
+
+// Scan reports no threat
+func (Noop) Scan(ctx context.Context, fileName string, data []byte) (string, error) {
+	return "", nil
+}
@@ -1,57 +1,189 @@
 package storage
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 )
 
+// FileNamePlaceholder is replaced with the stored file name in a public URL template
+const FileNamePlaceholder = "{file}"
+
+// ErrInvalidFileName is returned for names that are empty, contain path
+// separators or would resolve outside the storage directory, and for new
+// names that are hidden or use characters outside [A-Za-z0-9._-]
+var ErrInvalidFileName = errors.New("invalid file name")
+
 // LocalStorage implements FileStorage for local filesystem
 type LocalStorage struct {
-	basePath string
-	baseURL  string
+	basePath  string
+	baseURL   string
+	publicURL string
 }
 
-// NewLocalStorage creates a new LocalStorage
-func NewLocalStorage(basePath, baseURL string) (*LocalStorage, error) {
+// NewLocalStorage creates a new LocalStorage.
+// publicURL is an optional template such as "https://cdn.example.com/media/{file}"
+// used for the URLs handed out to clients; when empty, baseURL + "/" + fileName is used.
+func NewLocalStorage(basePath, baseURL, publicURL string) (*LocalStorage, error) {
+	if basePath == "" {
+		return nil, errors.New("storage path must be set")
+	}
+	if err := validateURL(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid storage URL: %w", err)
+	}
+	if publicURL != "" {
+		if !strings.Contains(publicURL, FileNamePlaceholder) {
+			return nil, fmt.Errorf("public URL template must contain %s", FileNamePlaceholder)
+		}
+		if err := validateURL(strings.Replace(publicURL, FileNamePlaceholder, "file", 1)); err != nil {
+			return nil, fmt.Errorf("invalid public URL template: %w", err)
+		}
+	}
+
+	absPath, err := filepath.Abs(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve storage path: %w", err)
+	}
+
 	// Ensure directory exists
-	if err := os.MkdirAll(basePath, 0755); err != nil {
+	if err := os.MkdirAll(absPath, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create storage directory: %w", err)
 	}
 
 	return &LocalStorage{
-		basePath: basePath,
-		baseURL:  baseURL,
+		basePath:  absPath,
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
+		publicURL: publicURL,
 	}, nil
 }
 
+// validateURL checks that rawURL is an absolute http(s) URL
+func validateURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q is not an absolute http(s) URL", rawURL)
+	}
+	return nil
+}
+
+// URL returns the public URL for a stored file
+func (s *LocalStorage) URL(fileName string) string {
+	if s.publicURL == "" {
+		return s.baseURL + "/" + fileName
+	}
+	return strings.Replace(s.publicURL, FileNamePlaceholder, fileName, 1)
+}
+
+// fileName extracts the stored file name from a URL returned by URL.
+// URLs built from either the public template or the base URL are accepted;
+// for anything else the last path element is used.
+func (s *LocalStorage) fileName(fileURL string) string {
+	if s.publicURL != "" {
+		prefix, suffix, _ := strings.Cut(s.publicURL, FileNamePlaceholder)
+		if strings.HasPrefix(fileURL, prefix) && strings.HasSuffix(fileURL, suffix) &&
+			len(fileURL) > len(prefix)+len(suffix) {
+			return fileURL[len(prefix) : len(fileURL)-len(suffix)]
+		}
+	}
+	if name, ok := strings.CutPrefix(fileURL, s.baseURL+"/"); ok {
+		return name
+	}
+	return path.Base(fileURL)
+}
+
+// path returns where fileName is stored, rejecting names that could
+// address anything but a file directly inside basePath. Files stored under
+// an older naming scheme stay reachable, so only separators and dot
+// directories are refused here; Save holds new names to validFileName.
+func (s *LocalStorage) path(fileName string) (string, error) {
+	if fileName == "" || fileName == "." || fileName == ".." || strings.ContainsAny(fileName, `/\`) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+	}
+	filePath := filepath.Join(s.basePath, fileName)
+	if rel, err := filepath.Rel(s.basePath, filePath); err != nil || rel != fileName {
+		return "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+	}
+	return filePath, nil
+}
+
+// validFileName reports whether name is fit for a new file: non-empty, not
+// starting with a dot (which also rules out "." and "..") and only using
+// [A-Za-z0-9._-]
+func validFileName(name string) bool {
+	if name == "" || name[0] == '.' {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '.', r == '_', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 // Save saves a file to local storage
 func (s *LocalStorage) Save(ctx context.Context, fileName string, data []byte) (string, error) {
-	filePath := filepath.Join(s.basePath, fileName)
+	return s.SaveStream(ctx, fileName, bytes.NewReader(data))
+}
+
+// SaveStream writes r to a temp file next to the destination and renames it
+// into place once complete, so readers never observe a partially written file.
+func (s *LocalStorage) SaveStream(ctx context.Context, fileName string, r io.Reader) (string, error) {
+	if !validFileName(fileName) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidFileName, fileName)
+	}
+	filePath, err := s.path(fileName)
+	if err != nil {
+		return "", err
+	}
 
-	// Create file
-	file, err := os.Create(filePath)
+	// Create temp file in the same directory so the rename stays atomic
+	tmp, err := os.CreateTemp(s.basePath, "."+fileName+".*.tmp")
 	if err != nil {
 		return "", fmt.Errorf("failed to create file: %w", err)
 	}
-	defer file.Close()
+	tmpPath := tmp.Name()
 
 	// Write data
-	if _, err := file.Write(data); err != nil {
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
 		return "", fmt.Errorf("failed to write file: %w", err)
 	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return "", fmt.Errorf("failed to move file into place: %w", err)
+	}
 
 	// Return URL
-	return s.baseURL + "/" + fileName, nil
+	return s.URL(fileName), nil
 }
 
 // Delete deletes a file from local storage
 func (s *LocalStorage) Delete(ctx context.Context, fileURL string) error {
 	// Extract filename from URL
-	fileName := filepath.Base(fileURL)
-	filePath := filepath.Join(s.basePath, fileName)
+	filePath, err := s.path(s.fileName(fileURL))
+	if err != nil {
+		return err
+	}
 
 	if err := os.Remove(filePath); err != nil {
 		if os.IsNotExist(err) {
@@ -66,8 +198,10 @@ func (s *LocalStorage) Delete(ctx context.Context, fileURL string) error {
 // Get retrieves a file from local storage
 func (s *LocalStorage) Get(ctx context.Context, fileURL string) ([]byte, error) {
 	// Extract filename from URL
-	fileName := filepath.Base(fileURL)
-	filePath := filepath.Join(s.basePath, fileName)
+	filePath, err := s.path(s.fileName(fileURL))
+	if err != nil {
+		return nil, err
+	}
 
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalStorage_PublicURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		publicURL string
+		want      string
+	}{
+		{"base url", "", "http://media:50055/files/photo.png"},
+		{"cdn template", "https://cdn.example.com/media/{file}", "https://cdn.example.com/media/photo.png"},
+		{"template with query", "https://cdn.example.com/{file}?v=1", "https://cdn.example.com/photo.png?v=1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			s, err := NewLocalStorage(dir, "http://media:50055/files", tt.publicURL)
+			if err != nil {
+				t.Fatalf("NewLocalStorage() error = %v", err)
+			}
+			ctx := context.Background()
+
+			url, err := s.Save(ctx, "photo.png", []byte("data"))
+			if err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+			if url != tt.want {
+				t.Errorf("Save() url = %q, want %q", url, tt.want)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "photo.png")); err != nil {
+				t.Errorf("file not stored under base path: %v", err)
+			}
+
+			data, err := s.Get(ctx, url)
+			if err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if string(data) != "data" {
+				t.Errorf("Get() = %q, want %q", data, "data")
+			}
+
+			if err := s.Delete(ctx, url); err != nil {
+				t.Fatalf("Delete() error = %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "photo.png")); !os.IsNotExist(err) {
+				t.Errorf("file still present after Delete(), stat error = %v", err)
+			}
+		})
+	}
+}
+
+func TestNewLocalStorage_InvalidPublicURL(t *testing.T) {
+	if _, err := NewLocalStorage(t.TempDir(), "http://media/files", "https://cdn.example.com/media"); err == nil {
+		t.Error("NewLocalStorage() expected error for template without placeholder")
+	}
+}
+
+// failingReader returns some data and then an error, like a dropped upload stream
+type failingReader struct {
+	sent bool
+}
+
+func (r *failingReader) Read(p []byte) (int, error) {
+	if r.sent {
+		return 0, errors.New("connection reset")
+	}
+	r.sent = true
+	return copy(p, "partial"), nil
+}
+
+func TestLocalStorage_SaveStreamFailureLeavesNoFile(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir, "http://media/files", "")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+
+	if _, err := s.SaveStream(context.Background(), "upload.bin", &failingReader{}); err == nil {
+		t.Fatal("SaveStream() expected error")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	for _, e := range entries {
+		t.Errorf("unexpected file left behind: %s", e.Name())
+	}
+}
+
+func TestLocalStorage_SaveReplacesExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir, "http://media/files", "")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := s.Save(ctx, "doc.txt", []byte("v1")); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	// A failed overwrite must keep the previous content intact
+	if _, err := s.SaveStream(ctx, "doc.txt", &failingReader{}); err == nil {
+		t.Fatal("SaveStream() expected error")
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "doc.txt"))
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(data) != "v1" {
+		t.Errorf("file content = %q, want %q", data, "v1")
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("directory has %d entries, want 1", len(entries))
+	}
+}
+
+func TestNewLocalStorage_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseURL   string
+		publicURL string
+	}{
+		{"relative base url", "/files", ""},
+		{"base url without scheme", "media:50055/files", ""},
+		{"unsupported scheme", "ftp://media/files", ""},
+		{"relative public url", "http://media/files", "/media/{file}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewLocalStorage(t.TempDir(), tt.baseURL, tt.publicURL); err == nil {
+				t.Error("NewLocalStorage() expected error")
+			}
+		})
+	}
+}
+
+func TestLocalStorage_RejectsTraversal(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "uploads")
+	s, err := NewLocalStorage(dir, "http://media/files", "https://cdn.example.com/media/{file}")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+	secret := filepath.Join(root, "secret.txt")
+	if err := os.WriteFile(secret, []byte("secret"), 0600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	names := []string{
+		"../../etc/passwd",
+		"../secret.txt",
+		"..",
+		".",
+		"",
+		"sub/file.txt",
+		`..\secret.txt`,
+		".hidden",
+		"name with spaces.txt",
+		"/etc/passwd",
+	}
+	for _, name := range names {
+		if _, err := s.Save(ctx, name, []byte("x")); !errors.Is(err, ErrInvalidFileName) {
+			t.Errorf("Save(%q) error = %v, want ErrInvalidFileName", name, err)
+		}
+	}
+	if data, _ := os.ReadFile(secret); string(data) != "secret" {
+		t.Errorf("file outside the storage directory was overwritten: %q", data)
+	}
+	entries, _ := os.ReadDir(dir)
+	for _, e := range entries {
+		t.Errorf("unexpected file stored: %s", e.Name())
+	}
+
+	urls := []string{
+		"https://cdn.example.com/media/../secret.txt",
+		"https://cdn.example.com/media/..",
+		"http://media/files/../secret.txt",
+		"http://media/files/sub/../../secret.txt",
+	}
+	for _, u := range urls {
+		if _, err := s.Get(ctx, u); !errors.Is(err, ErrInvalidFileName) {
+			t.Errorf("Get(%q) error = %v, want ErrInvalidFileName", u, err)
+		}
+		if err := s.Delete(ctx, u); !errors.Is(err, ErrInvalidFileName) {
+			t.Errorf("Delete(%q) error = %v, want ErrInvalidFileName", u, err)
+		}
+	}
+	if _, err := os.Stat(secret); err != nil {
+		t.Errorf("file outside the storage directory was deleted: %v", err)
+	}
+}
+
+func TestLocalStorage_LegacyNamesStayReachable(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewLocalStorage(dir, "http://media/files", "")
+	if err != nil {
+		t.Fatalf("NewLocalStorage() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// Saved before new names were limited to [A-Za-z0-9._-]
+	const name = "report (final).pdf"
+	if err := os.WriteFile(filepath.Join(dir, name), []byte("legacy"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Save(ctx, name, []byte("x")); !errors.Is(err, ErrInvalidFileName) {
+		t.Errorf("Save(%q) error = %v, want ErrInvalidFileName", name, err)
+	}
+
+	data, err := s.Get(ctx, s.URL(name))
+	if err != nil || string(data) != "legacy" {
+		t.Fatalf("Get() = %q, %v, want the legacy file", data, err)
+	}
+	if err := s.Delete(ctx, s.URL(name)); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, name)); !os.IsNotExist(err) {
+		t.Errorf("legacy file still stored: %v", err)
+	}
+}
@@ -0,0 +1,239 @@
+// Package testutil provides in-memory implementations of the media service
+// repository and file storage so usecases can be tested without a database
+// or disk. They follow the Postgres repository: missing files are
+// repository.ErrFileNotFound and lists use the same ordering and pagination.
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/portfolio/media-service/internal/domain/entity"
+	"github.com/portfolio/media-service/internal/domain/repository"
+)
+
+// paginate returns the given page of items, or nil when it is empty
+func paginate[T any](items []T, page, limit int) []T {
+	offset := (page - 1) * limit
+	if offset < 0 || offset >= len(items) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// MediaFileRepository is an in-memory repository.MediaFileRepository
+type MediaFileRepository struct {
+	mu     sync.Mutex
+	lastID int64
+	files  map[int64]*entity.MediaFile
+}
+
+// NewMediaFileRepository creates an empty MediaFileRepository
+func NewMediaFileRepository() *MediaFileRepository {
+	return &MediaFileRepository{files: make(map[int64]*entity.MediaFile)}
+}
+
+var _ repository.MediaFileRepository = (*MediaFileRepository)(nil)
+
+// Create stores file and assigns its ID
+func (r *MediaFileRepository) Create(ctx context.Context, file *entity.MediaFile) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.create(file)
+	return nil
+}
+
+// CreateWithinQuota stores file unless its uploader's files would then take
+// more than limit bytes
+func (r *MediaFileRepository) CreateWithinQuota(ctx context.Context, file *entity.MediaFile, limit int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if used := r.totalSize(file.UploadedBy); used+file.FileSize > limit {
+		return fmt.Errorf("%w: %d of %d bytes used, upload is %d bytes", repository.ErrQuotaExceeded, used, limit, file.FileSize)
+	}
+	r.create(file)
+	return nil
+}
+
+// create stores file under the next ID; r.mu must be held
+func (r *MediaFileRepository) create(file *entity.MediaFile) {
+	r.lastID++
+	file.ID = r.lastID
+	stored := *file
+	r.files[file.ID] = &stored
+}
+
+// GetByID gets a copy of a media file by ID
+func (r *MediaFileRepository) GetByID(ctx context.Context, id int64) (*entity.MediaFile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, ok := r.files[id]
+	if !ok {
+		return nil, repository.ErrFileNotFound
+	}
+	found := *file
+	return &found, nil
+}
+
+// GetByIDs gets copies of the media files with the given IDs, skipping
+// unknown IDs
+func (r *MediaFileRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.MediaFile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var files []*entity.MediaFile
+	for _, id := range ids {
+		if file, ok := r.files[id]; ok {
+			found := *file
+			files = append(files, &found)
+		}
+	}
+	return files, nil
+}
+
+// ListUnsized lists up to limit files recorded without a size, by ascending
+// ID from after afterID
+func (r *MediaFileRepository) ListUnsized(ctx context.Context, afterID int64, limit int) ([]*entity.MediaFile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var files []*entity.MediaFile
+	for _, f := range r.files {
+		if f.FileSize == 0 && f.ID > afterID {
+			found := *f
+			files = append(files, &found)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].ID < files[j].ID })
+	if len(files) > limit {
+		files = files[:limit]
+	}
+	return files, nil
+}
+
+// SetFileSize records the size of a file
+func (r *MediaFileRepository) SetFileSize(ctx context.Context, id, size int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.files[id]; ok {
+		f.FileSize = size
+	}
+	return nil
+}
+
+// Delete deletes a media file record
+func (r *MediaFileRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.files, id)
+	return nil
+}
+
+// List lists media files, newest first, optionally filtered by type, upload
+// time, project and task
+func (r *MediaFileRepository) List(ctx context.Context, page, limit int, filter repository.FileFilter) ([]*entity.MediaFile, int, error) {
+	return r.filter(func(f *entity.MediaFile) bool {
+		if filter.FileType != "" && f.FileType != filter.FileType {
+			return false
+		}
+		if filter.UploadedAfter != nil && f.UploadedAt.Before(*filter.UploadedAfter) {
+			return false
+		}
+		if filter.ProjectID != 0 && f.ProjectID != filter.ProjectID {
+			return false
+		}
+		if filter.TaskID != 0 && f.TaskID != filter.TaskID {
+			return false
+		}
+		return filter.UploadedBefore == nil || f.UploadedAt.Before(*filter.UploadedBefore)
+	}, page, limit)
+}
+
+// GetByUserID lists the files a user uploaded, newest first
+func (r *MediaFileRepository) GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.MediaFile, int, error) {
+	return r.filter(func(f *entity.MediaFile) bool { return f.UploadedBy == userID }, page, limit)
+}
+
+// TotalSizeByUser returns the combined size of the files a user uploaded
+func (r *MediaFileRepository) TotalSizeByUser(ctx context.Context, userID int64) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.totalSize(userID), nil
+}
+
+// totalSize sums the sizes of userID's files; r.mu must be held
+func (r *MediaFileRepository) totalSize(userID int64) int64 {
+	var total int64
+	for _, f := range r.files {
+		if f.UploadedBy == userID {
+			total += f.FileSize
+		}
+	}
+	return total
+}
+
+func (r *MediaFileRepository) filter(keep func(*entity.MediaFile) bool, page, limit int) ([]*entity.MediaFile, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matched []*entity.MediaFile
+	for _, f := range r.files {
+		if keep(f) {
+			found := *f
+			matched = append(matched, &found)
+		}
+	}
+	// Postgres only orders by uploaded_at; ties are broken by ID to keep pages stable
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].UploadedAt.Equal(matched[j].UploadedAt) {
+			return matched[i].UploadedAt.After(matched[j].UploadedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// FileStorage is an in-memory repository.FileStorage
+type FileStorage struct {
+	mu    sync.Mutex
+	files map[string][]byte
+}
+
+// NewFileStorage creates an empty FileStorage
+func NewFileStorage() *FileStorage {
+	return &FileStorage{files: make(map[string][]byte)}
+}
+
+var _ repository.FileStorage = (*FileStorage)(nil)
+
+// Save stores data and returns a memory:// URL for it
+func (s *FileStorage) Save(ctx context.Context, fileName string, data []byte) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	url := "memory://" + fileName
+	s.files[url] = append([]byte(nil), data...)
+	return url, nil
+}
+
+// Delete removes a stored file; deleting a missing file is not an error
+func (s *FileStorage) Delete(ctx context.Context, fileURL string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.files, fileURL)
+	return nil
+}
+
+// Get returns a copy of a stored file
+func (s *FileStorage) Get(ctx context.Context, fileURL string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, ok := s.files[fileURL]
+	if !ok {
+		return nil, fmt.Errorf("file %s not found", fileURL)
+	}
+	return append([]byte(nil), data...), nil
+}
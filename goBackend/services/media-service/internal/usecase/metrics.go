@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// UploadMetrics tracks upload volume and latency to watch storage growth
+type UploadMetrics struct {
+	uploads  *prometheus.CounterVec
+	size     *prometheus.HistogramVec
+	duration *prometheus.HistogramVec
+}
+
+// NewUploadMetrics creates the upload collectors and registers them with reg
+func NewUploadMetrics(reg prometheus.Registerer) *UploadMetrics {
+	m := &UploadMetrics{
+		uploads: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "media_uploads_total",
+			Help: "Total number of uploads, by file type and result (success, rejected, over_quota or error).",
+		}, []string{"file_type", "result"}),
+		size: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "media_upload_size_bytes",
+			Help: "Size of stored uploads in bytes, by file type.",
+			// 1 KiB up to 256 MiB
+			Buckets: prometheus.ExponentialBuckets(1024, 4, 10),
+		}, []string{"file_type"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "media_upload_duration_seconds",
+			Help:    "Time to store an upload and record it, in seconds, by file type.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"file_type"}),
+	}
+	reg.MustRegister(m.uploads, m.size, m.duration)
+	return m
+}
+
+// observe records one upload of a valid file type. Sizes are only recorded
+// for uploads that were kept, since failed ones are cleaned up.
+func (m *UploadMetrics) observe(fileType string, size int, elapsed time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	result := "success"
+	switch {
+	case errors.Is(err, ErrFileRejected):
+		result = "rejected"
+	case errors.Is(err, ErrQuotaExceeded):
+		result = "over_quota"
+	case err != nil:
+		result = "error"
+	default:
+		m.size.WithLabelValues(fileType).Observe(float64(size))
+	}
+	m.uploads.WithLabelValues(fileType, result).Inc()
+	m.duration.WithLabelValues(fileType).Observe(elapsed.Seconds())
+}
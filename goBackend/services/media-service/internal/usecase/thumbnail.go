@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif" // register decoders for image.Decode
+	_ "image/jpeg"
+	"image/png"
+)
+
+// maxThumbnailPixels caps the size of images that get a thumbnail. Decoding
+// allocates the full image, so a small file that claims huge dimensions
+// could otherwise use gigabytes of memory.
+const maxThumbnailPixels = 40_000_000
+
+var errImageTooLarge = errors.New("image dimensions too large for a thumbnail")
+
+// makeThumbnail decodes a GIF, JPEG or PNG image and scales it down to fit
+// within size×size, keeping its aspect ratio. Smaller images are not
+// enlarged. The thumbnail is encoded as PNG. Images over maxThumbnailPixels
+// are rejected from their header, before they are decoded.
+func makeThumbnail(data []byte, size int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > maxThumbnailPixels {
+		return nil, errImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/b.Dx())
+		} else {
+			w, h = max(1, w*size/b.Dy()), size
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, scaleDown(src, w, h)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// scaleDown resizes src to w×h by averaging the source pixels that fall
+// into each destination pixel
+func scaleDown(src image.Image, w, h int) *image.NRGBA {
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		y0 := b.Min.Y + y*b.Dy()/h
+		y1 := max(y0+1, b.Min.Y+(y+1)*b.Dy()/h)
+		for x := 0; x < w; x++ {
+			x0 := b.Min.X + x*b.Dx()/w
+			x1 := max(x0+1, b.Min.X+(x+1)*b.Dx()/w)
+
+			// Sum premultiplied colors so transparent pixels don't bleed
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a = r+uint64(cr), g+uint64(cg), bl+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(bl / n), A: uint16(a / n),
+			})
+		}
+	}
+	return dst
+}
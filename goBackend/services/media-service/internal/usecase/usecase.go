@@ -2,43 +2,131 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log/slog"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/portfolio/media-service/internal/domain/entity"
 	"github.com/portfolio/media-service/internal/domain/repository"
+	"github.com/portfolio/shared/pagination"
 )
 
 var (
 	ErrFileNotFound    = errors.New("file not found")
 	ErrInvalidFileType = errors.New("invalid file type")
 	ErrUploadFailed    = errors.New("upload failed")
+	ErrFileRejected    = errors.New("file rejected by virus scan")
+	ErrInvalidRange    = errors.New("uploaded_after must be before uploaded_before")
+	ErrTooManyFiles    = fmt.Errorf("at most %d files can be fetched at once", MaxBatchFiles)
+	// ErrInvalidAssociation is returned for negative IDs, or a task without its project
+	ErrInvalidAssociation = errors.New("task_id needs the task's project_id, and ids must be positive")
+	// ErrQuotaExceeded is returned for uploads that would take the uploader
+	// past their storage quota
+	ErrQuotaExceeded = repository.ErrQuotaExceeded
+	ErrFileTooLarge  = fmt.Errorf("files can be at most %d bytes", MaxFileSize)
 )
 
+// StorageQuota bounds the combined size in bytes of the files each user
+// uploads. ByRole overrides Default for users with that role; a limit of 0
+// means unlimited, so the zero value allows everything.
+type StorageQuota struct {
+	Default int64
+	ByRole  map[string]int64
+}
+
+// Limit returns the quota of a user with role
+func (q StorageQuota) Limit(role string) int64 {
+	if limit, ok := q.ByRole[role]; ok {
+		return limit
+	}
+	return q.Default
+}
+
+// MaxFileSize caps the size of an uploaded file in bytes, matching the gateway
+const MaxFileSize = 10 << 20
+
+// MaxBatchFiles caps how many files GetFilesByIDs returns in one call
+const MaxBatchFiles = 100
+
 // MediaUseCase handles media business logic
 type MediaUseCase struct {
 	fileRepo repository.MediaFileRepository
 	storage  repository.FileStorage
+	scanner  repository.FileScanner
+	metrics  *UploadMetrics
+
+	thumbnailSize int
+	quota         StorageQuota
+	pages         pagination.Sizes
 }
 
-// NewMediaUseCase creates a new MediaUseCase
-func NewMediaUseCase(fileRepo repository.MediaFileRepository, storage repository.FileStorage) *MediaUseCase {
+// NewMediaUseCase creates a new MediaUseCase. scanner may be nil to store
+// uploads unscanned, and metrics may be nil to skip recording uploads.
+// Images get a thumbnail fitting within thumbnailSize pixels square; 0
+// disables thumbnails. Uploads past the uploader's quota are refused. Lists
+// come in pages of the given sizes.
+func NewMediaUseCase(fileRepo repository.MediaFileRepository, storage repository.FileStorage, scanner repository.FileScanner, thumbnailSize int, quota StorageQuota, metrics *UploadMetrics, pages pagination.Sizes) *MediaUseCase {
 	return &MediaUseCase{
-		fileRepo: fileRepo,
-		storage:  storage,
+		fileRepo:      fileRepo,
+		storage:       storage,
+		scanner:       scanner,
+		metrics:       metrics,
+		thumbnailSize: thumbnailSize,
+		quota:         quota,
+		pages:         pages,
 	}
 }
 
-// UploadFile uploads a file
-func (uc *MediaUseCase) UploadFile(ctx context.Context, fileName, fileType string, uploadedBy int64, data []byte) (*entity.MediaFile, error) {
+// PageLimit is the page size the list methods apply for limit
+func (uc *MediaUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
+// UploadFile uploads a file, associated with the project and task in assoc
+// if they are set. uploaderRole picks the uploader's storage quota.
+func (uc *MediaUseCase) UploadFile(ctx context.Context, fileName, fileType string, uploadedBy int64, uploaderRole string, assoc entity.FileAssociation, data []byte) (*entity.MediaFile, error) {
 	if !entity.IsValidFileType(fileType) {
 		return nil, ErrInvalidFileType
 	}
+	if assoc.ProjectID < 0 || assoc.TaskID < 0 || (assoc.TaskID != 0 && assoc.ProjectID == 0) {
+		return nil, ErrInvalidAssociation
+	}
+	if len(data) > MaxFileSize {
+		return nil, ErrFileTooLarge
+	}
+
+	start := time.Now()
+	file, err := uc.storeFile(ctx, fileName, fileType, uploadedBy, uploaderRole, assoc, data)
+	uc.metrics.observe(fileType, len(data), time.Since(start), err)
+	return file, err
+}
+
+// storeFile checks the quota and scans the data, then saves and records it,
+// removing the stored copy again if the record can't be created. Rejected
+// files are never saved, and the quota is checked again as the record is
+// created.
+func (uc *MediaUseCase) storeFile(ctx context.Context, fileName, fileType string, uploadedBy int64, uploaderRole string, assoc entity.FileAssociation, data []byte) (*entity.MediaFile, error) {
+	limit := uc.quota.Limit(uploaderRole)
+	if err := uc.checkQuota(ctx, uploadedBy, limit, int64(len(data))); err != nil {
+		return nil, err
+	}
+	if err := uc.scan(ctx, fileName, data); err != nil {
+		return nil, err
+	}
 
 	// Generate unique filename
 	ext := filepath.Ext(fileName)
-	uniqueName := time.Now().Format("20060102150405") + "_" + fileName
+	uniqueName, err := storageName(fileName)
+	if err != nil {
+		return nil, ErrUploadFailed
+	}
 
 	// Save to storage
 	fileURL, err := uc.storage.Save(ctx, uniqueName, data)
@@ -51,33 +139,223 @@ func (uc *MediaUseCase) UploadFile(ctx context.Context, fileName, fileType strin
 	if ext != "" {
 		file.FileName = fileName
 	}
+	file.ProjectID, file.TaskID = assoc.ProjectID, assoc.TaskID
+	file.ContentHash = contentHash(data)
+	file.ThumbnailURL = uc.storeThumbnail(ctx, strings.TrimSuffix(uniqueName, filepath.Ext(uniqueName)), fileType, data)
 
-	if err := uc.fileRepo.Create(ctx, file); err != nil {
-		// Cleanup uploaded file on error
+	if limit > 0 {
+		err = uc.fileRepo.CreateWithinQuota(ctx, file, limit)
+	} else {
+		err = uc.fileRepo.Create(ctx, file)
+	}
+	if err != nil {
+		// Cleanup uploaded files on error
 		_ = uc.storage.Delete(ctx, fileURL)
+		if file.ThumbnailURL != "" {
+			_ = uc.storage.Delete(ctx, file.ThumbnailURL)
+		}
 		return nil, err
 	}
 
 	return file, nil
 }
 
+// storageName returns a random name for storing fileName, keeping its
+// extension when that only uses letters and digits. The client supplied
+// name never reaches storage; it is kept on the file record.
+func storageName(fileName string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b) + storageExt(fileName), nil
+}
+
+// storageExt returns the lowercased extension of fileName, or "" when it
+// is missing, too long or uses anything but letters and digits
+func storageExt(fileName string) string {
+	ext := filepath.Ext(strings.ReplaceAll(fileName, "\\", "/"))
+	if len(ext) < 2 || len(ext) > 10 {
+		return ""
+	}
+	for _, r := range ext[1:] {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9') {
+			return ""
+		}
+	}
+	return strings.ToLower(ext)
+}
+
+// storeThumbnail saves a thumbnail of an image and returns its URL. Images
+// that can't be decoded still upload, just without a thumbnail.
+func (uc *MediaUseCase) storeThumbnail(ctx context.Context, baseName, fileType string, data []byte) string {
+	if fileType != entity.FileTypeImage || uc.thumbnailSize <= 0 {
+		return ""
+	}
+	thumb, err := makeThumbnail(data, uc.thumbnailSize)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to create thumbnail", "file", baseName, "error", err)
+		return ""
+	}
+	url, err := uc.storage.Save(ctx, baseName+"_thumb.png", thumb)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to store thumbnail", "file", baseName, "error", err)
+		return ""
+	}
+	return url
+}
+
+// checkQuota returns an error wrapping ErrQuotaExceeded when size more
+// bytes would take the user past limit, so uploads already over it are
+// refused before being scanned and saved. Concurrent uploads can all pass
+// it; CreateWithinQuota makes the final check one upload at a time.
+func (uc *MediaUseCase) checkQuota(ctx context.Context, userID, limit, size int64) error {
+	if limit <= 0 {
+		return nil
+	}
+	used, err := uc.fileRepo.TotalSizeByUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get storage used: %w", err)
+	}
+	if used+size > limit {
+		return fmt.Errorf("%w: %d of %d bytes used, upload is %d bytes", ErrQuotaExceeded, used, limit, size)
+	}
+	return nil
+}
+
+// scan returns an error wrapping ErrFileRejected when the scanner finds a
+// threat in data
+func (uc *MediaUseCase) scan(ctx context.Context, fileName string, data []byte) error {
+	if uc.scanner == nil {
+		return nil
+	}
+	threat, err := uc.scanner.Scan(ctx, fileName, data)
+	if err != nil {
+		return fmt.Errorf("failed to scan %s: %w", fileName, err)
+	}
+	if threat != "" {
+		return fmt.Errorf("%w: %s", ErrFileRejected, threat)
+	}
+	return nil
+}
+
 // GetFile retrieves a file by ID
 func (uc *MediaUseCase) GetFile(ctx context.Context, id int64) (*entity.MediaFile, error) {
 	file, err := uc.fileRepo.GetByID(ctx, id)
-	if err != nil {
+	if errors.Is(err, repository.ErrFileNotFound) {
 		return nil, ErrFileNotFound
 	}
-	return file, nil
+	return file, err
+}
+
+// backfillBatchSize is how many unsized files BackfillFileSizes loads at once
+const backfillBatchSize = 100
+
+// BackfillFileSizes records the size of the files stored before sizes were,
+// reading each from storage, so they count towards their uploader's quota.
+// Files that can't be read are logged and skipped. It returns how many
+// sizes were recorded.
+func (uc *MediaUseCase) BackfillFileSizes(ctx context.Context) (int, error) {
+	recorded := 0
+	var after int64
+	for {
+		files, err := uc.fileRepo.ListUnsized(ctx, after, backfillBatchSize)
+		if err != nil {
+			return recorded, err
+		}
+		for _, file := range files {
+			after = file.ID
+			data, err := uc.storage.Get(ctx, file.FileURL)
+			if err != nil {
+				slog.WarnContext(ctx, "failed to read file to record its size", "file_id", file.ID, "error", err)
+				continue
+			}
+			// Empty files are sized correctly already
+			if len(data) == 0 {
+				continue
+			}
+			if err := uc.fileRepo.SetFileSize(ctx, file.ID, int64(len(data))); err != nil {
+				return recorded, err
+			}
+			recorded++
+		}
+		if len(files) < backfillBatchSize {
+			return recorded, nil
+		}
+	}
+}
+
+// GetFileContent retrieves a file and its content. When the file's hash is
+// among cached the content isn't read and nil is returned in its place.
+// Files uploaded before content hashes and sizes were recorded get theirs
+// from the content.
+func (uc *MediaUseCase) GetFileContent(ctx context.Context, id int64, cached []string) (*entity.MediaFile, []byte, error) {
+	file, err := uc.GetFile(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if file.ContentHash != "" && slices.Contains(cached, file.ContentHash) {
+		return file, nil, nil
+	}
+	data, err := uc.storage.Get(ctx, file.FileURL)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file %d: %w", id, err)
+	}
+	if file.ContentHash == "" {
+		file.ContentHash = contentHash(data)
+	}
+	file.FileSize = int64(len(data))
+	return file, data, nil
+}
+
+// contentHash returns the hex SHA-256 of data
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// GetFilesByIDs retrieves several files in one lookup. The result follows the
+// order of ids with duplicates dropped; IDs without a file are left out.
+func (uc *MediaUseCase) GetFilesByIDs(ctx context.Context, ids []int64) ([]*entity.MediaFile, error) {
+	if len(ids) > MaxBatchFiles {
+		return nil, ErrTooManyFiles
+	}
+	if len(ids) == 0 {
+		return []*entity.MediaFile{}, nil
+	}
+
+	found, err := uc.fileRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*entity.MediaFile, len(found))
+	for _, f := range found {
+		byID[f.ID] = f
+	}
+
+	files := make([]*entity.MediaFile, 0, len(found))
+	for _, id := range ids {
+		if f, ok := byID[id]; ok {
+			files = append(files, f)
+			delete(byID, id)
+		}
+	}
+	return files, nil
 }
 
 // DeleteFile deletes a file
 func (uc *MediaUseCase) DeleteFile(ctx context.Context, id int64) error {
-	file, err := uc.fileRepo.GetByID(ctx, id)
+	file, err := uc.GetFile(ctx, id)
 	if err != nil {
-		return ErrFileNotFound
+		return err
 	}
 
 	// Delete from storage
+	if file.ThumbnailURL != "" {
+		if err := uc.storage.Delete(ctx, file.ThumbnailURL); err != nil {
+			return err
+		}
+	}
 	if err := uc.storage.Delete(ctx, file.FileURL); err != nil {
 		return err
 	}
@@ -87,23 +365,19 @@ func (uc *MediaUseCase) DeleteFile(ctx context.Context, id int64) error {
 }
 
 // ListFiles lists files with pagination
-func (uc *MediaUseCase) ListFiles(ctx context.Context, page, limit int, fileType string) ([]*entity.MediaFile, int, error) {
-	if page < 1 {
-		page = 1
+func (uc *MediaUseCase) ListFiles(ctx context.Context, page, limit int, filter repository.FileFilter) ([]*entity.MediaFile, int, error) {
+	if filter.FileType != "" && !entity.IsValidFileType(filter.FileType) {
+		return nil, 0, ErrInvalidFileType
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	if filter.UploadedAfter != nil && filter.UploadedBefore != nil && !filter.UploadedAfter.Before(*filter.UploadedBefore) {
+		return nil, 0, ErrInvalidRange
 	}
-	return uc.fileRepo.List(ctx, page, limit, fileType)
+	page, limit = uc.pages.Normalize(page, limit)
+	return uc.fileRepo.List(ctx, page, limit, filter)
 }
 
 // GetFilesByUser gets files by user
 func (uc *MediaUseCase) GetFilesByUser(ctx context.Context, userID int64, page, limit int) ([]*entity.MediaFile, int, error) {
-	if page < 1 {
-		page = 1
-	}
-	if limit < 1 || limit > 100 {
-		limit = 10
-	}
+	page, limit = uc.pages.Normalize(page, limit)
 	return uc.fileRepo.GetByUserID(ctx, userID, page, limit)
 }
@@ -0,0 +1,658 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/portfolio/media-service/internal/domain/entity"
+	"github.com/portfolio/media-service/internal/domain/repository"
+	"github.com/portfolio/media-service/internal/testutil"
+	"github.com/portfolio/shared/pagination"
+	"github.com/prometheus/client_golang/prometheus"
+	promtest "github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// failingStorage is a FileStorage whose saves always fail
+type failingStorage struct {
+	*testutil.FileStorage
+}
+
+func (failingStorage) Save(ctx context.Context, fileName string, data []byte) (string, error) {
+	return "", errors.New("disk full")
+}
+
+// eicar is the standard antivirus test file
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// stubScanner flags the EICAR test file and fails on empty files
+type stubScanner struct{}
+
+func (stubScanner) Scan(ctx context.Context, fileName string, data []byte) (string, error) {
+	if len(data) == 0 {
+		return "", errors.New("scanner unavailable")
+	}
+	if bytes.Contains(data, []byte(eicar)) {
+		return "Eicar-Test-Signature", nil
+	}
+	return "", nil
+}
+
+// countingStorage counts saved files
+type countingStorage struct {
+	*testutil.FileStorage
+	saves int
+}
+
+func (s *countingStorage) Save(ctx context.Context, fileName string, data []byte) (string, error) {
+	s.saves++
+	return s.FileStorage.Save(ctx, fileName, data)
+}
+
+// histogram returns the sample count and sum of a histogram series by file type
+func histogram(t *testing.T, reg *prometheus.Registry, name, fileType string) (uint64, float64) {
+	t.Helper()
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	for _, mf := range families {
+		if mf.GetName() != name {
+			continue
+		}
+		for _, m := range mf.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "file_type" && l.GetValue() == fileType {
+					return m.GetHistogram().GetSampleCount(), m.GetHistogram().GetSampleSum()
+				}
+			}
+		}
+	}
+	return 0, 0
+}
+
+func TestMediaUseCase_UploadFileMetrics(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	metrics := NewUploadMetrics(reg)
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), testutil.NewFileStorage(), nil, 0, StorageQuota{}, metrics, pagination.Sizes{})
+
+	if _, err := uc.UploadFile(ctx, "a.png", "image", 1, "user", entity.FileAssociation{}, make([]byte, 2048)); err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	uc.UploadFile(ctx, "b.png", "image", 1, "user", entity.FileAssociation{}, make([]byte, 10))
+	uc.UploadFile(ctx, "cv.pdf", "resume", 1, "user", entity.FileAssociation{}, make([]byte, 500))
+	if _, err := uc.UploadFile(ctx, "x.exe", "binary", 1, "user", entity.FileAssociation{}, []byte("x")); err != ErrInvalidFileType {
+		t.Fatalf("UploadFile(binary) error = %v, want ErrInvalidFileType", err)
+	}
+
+	broken := NewMediaUseCase(testutil.NewMediaFileRepository(), failingStorage{testutil.NewFileStorage()}, nil, 0, StorageQuota{}, metrics, pagination.Sizes{})
+	if _, err := broken.UploadFile(ctx, "c.png", "image", 1, "user", entity.FileAssociation{}, make([]byte, 64)); err != ErrUploadFailed {
+		t.Fatalf("UploadFile(failing storage) error = %v, want ErrUploadFailed", err)
+	}
+
+	uploads := []struct {
+		fileType, result string
+		want             float64
+	}{
+		{"image", "success", 2},
+		{"image", "error", 1},
+		{"resume", "success", 1},
+		{"binary", "error", 0},
+	}
+	for _, u := range uploads {
+		if got := promtest.ToFloat64(metrics.uploads.WithLabelValues(u.fileType, u.result)); got != u.want {
+			t.Errorf("uploads{%s,%s} = %v, want %v", u.fileType, u.result, got, u.want)
+		}
+	}
+
+	// Only stored uploads count towards size, but every attempt is timed
+	if count, sum := histogram(t, reg, "media_upload_size_bytes", "image"); count != 2 || sum != 2058 {
+		t.Errorf("image sizes = %d samples totalling %v, want 2 totalling 2058", count, sum)
+	}
+	if count, _ := histogram(t, reg, "media_upload_duration_seconds", "image"); count != 3 {
+		t.Errorf("image durations = %d samples, want 3", count)
+	}
+	if count, sum := histogram(t, reg, "media_upload_size_bytes", "resume"); count != 1 || sum != 500 {
+		t.Errorf("resume sizes = %d samples totalling %v, want 1 totalling 500", count, sum)
+	}
+}
+
+func TestMediaUseCase_GetFilesByIDs(t *testing.T) {
+	ctx := context.Background()
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), testutil.NewFileStorage(), nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+	for _, name := range []string{"a.png", "b.png", "c.png"} {
+		if _, err := uc.UploadFile(ctx, name, "image", 1, "user", entity.FileAssociation{}, []byte(name)); err != nil {
+			t.Fatalf("UploadFile(%s) error = %v", name, err)
+		}
+	}
+
+	files, err := uc.GetFilesByIDs(ctx, []int64{3, 99, 1, 3})
+	if err != nil {
+		t.Fatalf("GetFilesByIDs() error = %v", err)
+	}
+	var got []int64
+	for _, f := range files {
+		got = append(got, f.ID)
+	}
+	if len(got) != 2 || got[0] != 3 || got[1] != 1 {
+		t.Errorf("GetFilesByIDs() ids = %v, want [3 1]", got)
+	}
+
+	if files, err := uc.GetFilesByIDs(ctx, nil); err != nil || len(files) != 0 {
+		t.Errorf("GetFilesByIDs(nil) = %v, %v, want no files", files, err)
+	}
+	if _, err := uc.GetFilesByIDs(ctx, make([]int64, MaxBatchFiles+1)); err != ErrTooManyFiles {
+		t.Errorf("GetFilesByIDs() over the cap error = %v, want ErrTooManyFiles", err)
+	}
+}
+
+func TestMediaUseCase_ListFilesFilters(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewMediaFileRepository()
+	uc := NewMediaUseCase(repo, testutil.NewFileStorage(), nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+	may := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+	june := time.Date(2024, 6, 10, 0, 0, 0, 0, time.UTC)
+	for _, f := range []*entity.MediaFile{
+		{FileName: "may.png", FileType: "image", UploadedAt: may},
+		{FileName: "may.pdf", FileType: "document", UploadedAt: may},
+		{FileName: "june.png", FileType: "image", UploadedAt: june},
+	} {
+		repo.Create(ctx, f)
+	}
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	files, total, err := uc.ListFiles(ctx, 1, 10, repository.FileFilter{FileType: "image", UploadedAfter: &start, UploadedBefore: &end})
+	if err != nil {
+		t.Fatalf("ListFiles() error = %v", err)
+	}
+	if total != 1 || len(files) != 1 || files[0].FileName != "may.png" {
+		t.Errorf("ListFiles() = %v (total %d), want only may.png", files, total)
+	}
+
+	if _, _, err := uc.ListFiles(ctx, 1, 10, repository.FileFilter{FileType: "video"}); err != ErrInvalidFileType {
+		t.Errorf("ListFiles(video) error = %v, want ErrInvalidFileType", err)
+	}
+	if _, _, err := uc.ListFiles(ctx, 1, 10, repository.FileFilter{UploadedAfter: &end, UploadedBefore: &start}); err != ErrInvalidRange {
+		t.Errorf("ListFiles(reversed range) error = %v, want ErrInvalidRange", err)
+	}
+}
+
+func TestMediaUseCase_UploadFileScan(t *testing.T) {
+	ctx := context.Background()
+	reg := prometheus.NewRegistry()
+	metrics := NewUploadMetrics(reg)
+	repo := testutil.NewMediaFileRepository()
+	storage := &countingStorage{FileStorage: testutil.NewFileStorage()}
+	uc := NewMediaUseCase(repo, storage, stubScanner{}, 0, StorageQuota{}, metrics, pagination.Sizes{})
+
+	_, err := uc.UploadFile(ctx, "invoice.pdf", "document", 1, "user", entity.FileAssociation{}, []byte("%PDF-1.4 "+eicar))
+	if !errors.Is(err, ErrFileRejected) {
+		t.Fatalf("UploadFile(infected) error = %v, want ErrFileRejected", err)
+	}
+	if _, err := uc.UploadFile(ctx, "empty.pdf", "document", 1, "user", entity.FileAssociation{}, nil); err == nil || errors.Is(err, ErrFileRejected) {
+		t.Fatalf("UploadFile(scan failure) error = %v, want a scan error", err)
+	}
+	if storage.saves != 0 {
+		t.Errorf("storage saved %d files, want none", storage.saves)
+	}
+	if _, total, _ := repo.List(ctx, 1, 10, repository.FileFilter{}); total != 0 {
+		t.Errorf("repository has %d files, want none", total)
+	}
+
+	if _, err := uc.UploadFile(ctx, "notes.pdf", "document", 1, "user", entity.FileAssociation{}, []byte("%PDF-1.4 clean")); err != nil {
+		t.Fatalf("UploadFile(clean) error = %v", err)
+	}
+	if storage.saves != 1 {
+		t.Errorf("storage saved %d files, want 1", storage.saves)
+	}
+
+	for result, want := range map[string]float64{"rejected": 1, "error": 1, "success": 1} {
+		if got := promtest.ToFloat64(metrics.uploads.WithLabelValues("document", result)); got != want {
+			t.Errorf("uploads{document,%s} = %v, want %v", result, got, want)
+		}
+	}
+}
+
+// pngImage encodes a w×h image
+func pngImage(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestMediaUseCase_UploadFileThumbnail(t *testing.T) {
+	ctx := context.Background()
+	storage := testutil.NewFileStorage()
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), storage, nil, 64, StorageQuota{}, nil, pagination.Sizes{})
+
+	photo, err := uc.UploadFile(ctx, "photo.png", "image", 1, "user", entity.FileAssociation{}, pngImage(t, 200, 100))
+	if err != nil {
+		t.Fatalf("UploadFile(image) error = %v", err)
+	}
+	stored, err := uc.GetFile(ctx, photo.ID)
+	if err != nil {
+		t.Fatalf("GetFile() error = %v", err)
+	}
+	if stored.ThumbnailURL == "" || stored.ThumbnailURL == stored.FileURL {
+		t.Fatalf("thumbnail_url = %q, want a separate thumbnail", stored.ThumbnailURL)
+	}
+	data, err := storage.Get(ctx, stored.ThumbnailURL)
+	if err != nil {
+		t.Fatalf("thumbnail was not stored: %v", err)
+	}
+	thumb, err := png.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("thumbnail is not a PNG: %v", err)
+	}
+	if thumb.Width != 64 || thumb.Height != 32 {
+		t.Errorf("thumbnail is %dx%d, want 64x32", thumb.Width, thumb.Height)
+	}
+
+	doc, err := uc.UploadFile(ctx, "notes.pdf", "document", 1, "user", entity.FileAssociation{}, []byte("%PDF-1.4"))
+	if err != nil {
+		t.Fatalf("UploadFile(document) error = %v", err)
+	}
+	if stored, _ := uc.GetFile(ctx, doc.ID); stored.ThumbnailURL != "" {
+		t.Errorf("document thumbnail_url = %q, want none", stored.ThumbnailURL)
+	}
+
+	// An image that doesn't decode is still stored, without a thumbnail
+	broken, err := uc.UploadFile(ctx, "broken.png", "image", 1, "user", entity.FileAssociation{}, []byte("not a png"))
+	if err != nil {
+		t.Fatalf("UploadFile(undecodable image) error = %v", err)
+	}
+	if broken.ThumbnailURL != "" {
+		t.Errorf("undecodable image thumbnail_url = %q, want none", broken.ThumbnailURL)
+	}
+
+	if err := uc.DeleteFile(ctx, photo.ID); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	if _, err := storage.Get(ctx, stored.ThumbnailURL); err == nil {
+		t.Error("thumbnail was kept after deleting the image")
+	}
+}
+
+// pngHeader is the start of a PNG claiming to be w×h, enough for
+// image.DecodeConfig but not for decoding
+func pngHeader(w, h uint32) []byte {
+	chunk := []byte("IHDR")
+	chunk = binary.BigEndian.AppendUint32(chunk, w)
+	chunk = binary.BigEndian.AppendUint32(chunk, h)
+	chunk = append(chunk, 8, 6, 0, 0, 0) // 8-bit RGBA, no interlace
+
+	data := []byte("\x89PNG\r\n\x1a\n")
+	data = binary.BigEndian.AppendUint32(data, 13)
+	data = append(data, chunk...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(chunk))
+}
+
+func TestMakeThumbnail_TooLarge(t *testing.T) {
+	if _, err := makeThumbnail(pngHeader(100_000, 100_000), 64); !errors.Is(err, errImageTooLarge) {
+		t.Errorf("makeThumbnail(100000x100000) error = %v, want errImageTooLarge", err)
+	}
+	if _, err := makeThumbnail(pngImage(t, 20, 10), 64); err != nil {
+		t.Errorf("makeThumbnail(20x10) error = %v", err)
+	}
+}
+
+func TestMediaUseCase_UploadFileAssociation(t *testing.T) {
+	ctx := context.Background()
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), testutil.NewFileStorage(), nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+
+	onTask, err := uc.UploadFile(ctx, "spec.pdf", "document", 1, "user", entity.FileAssociation{ProjectID: 2, TaskID: 5}, []byte("spec"))
+	if err != nil {
+		t.Fatalf("UploadFile(task) error = %v", err)
+	}
+	if onTask.ProjectID != 2 || onTask.TaskID != 5 {
+		t.Errorf("file = project %d, task %d; want project 2, task 5", onTask.ProjectID, onTask.TaskID)
+	}
+	uc.UploadFile(ctx, "logo.png", "image", 1, "user", entity.FileAssociation{ProjectID: 2}, []byte("logo"))
+	uc.UploadFile(ctx, "other.pdf", "document", 1, "user", entity.FileAssociation{ProjectID: 3}, []byte("other"))
+	loose, err := uc.UploadFile(ctx, "cv.pdf", "resume", 1, "user", entity.FileAssociation{}, []byte("cv"))
+	if err != nil || loose.ProjectID != 0 || loose.TaskID != 0 {
+		t.Fatalf("UploadFile(unassociated) = %+v, %v; want no project or task", loose, err)
+	}
+
+	files, total, err := uc.ListFiles(ctx, 1, 10, repository.FileFilter{ProjectID: 2})
+	if err != nil || total != 2 || len(files) != 2 {
+		t.Errorf("ListFiles(project 2) = %d files of %d, %v; want 2", len(files), total, err)
+	}
+	files, _, _ = uc.ListFiles(ctx, 1, 10, repository.FileFilter{TaskID: 5})
+	if len(files) != 1 || files[0].ID != onTask.ID {
+		t.Errorf("ListFiles(task 5) = %v, want only spec.pdf", files)
+	}
+
+	for _, assoc := range []entity.FileAssociation{{TaskID: 5}, {ProjectID: -1}} {
+		if _, err := uc.UploadFile(ctx, "x.pdf", "document", 1, "user", assoc, []byte("x")); err != ErrInvalidAssociation {
+			t.Errorf("UploadFile(%+v) error = %v, want ErrInvalidAssociation", assoc, err)
+		}
+	}
+}
+
+func TestMediaUseCase_UploadFileStorageName(t *testing.T) {
+	ctx := context.Background()
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), testutil.NewFileStorage(), nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+
+	tests := []struct {
+		fileName string
+		wantExt  string
+	}{
+		{"report.pdf", ".pdf"},
+		{"Photo.JPG", ".jpg"},
+		{"../../etc/passwd", ""},
+		{`..\..\windows\win.ini`, ".ini"},
+		{"archive.tar.gz", ".gz"},
+		{"odd.p g", ""},
+		{"..", ""},
+	}
+	for _, tt := range tests {
+		file, err := uc.UploadFile(ctx, tt.fileName, "document", 1, "user", entity.FileAssociation{}, []byte("x"))
+		if err != nil {
+			t.Fatalf("UploadFile(%q) error = %v", tt.fileName, err)
+		}
+		stored := strings.TrimPrefix(file.FileURL, "memory://")
+		name := strings.TrimSuffix(stored, tt.wantExt)
+		if len(name) != 32 || strings.Trim(name, "0123456789abcdef") != "" {
+			t.Errorf("UploadFile(%q) stored as %q, want a random hex name with extension %q", tt.fileName, stored, tt.wantExt)
+		}
+		if file.FileName != tt.fileName {
+			t.Errorf("UploadFile(%q) file_name = %q, want the original name", tt.fileName, file.FileName)
+		}
+	}
+}
+
+func TestMediaUseCase_UploadFileConcurrentSameName(t *testing.T) {
+	ctx := context.Background()
+	storage := testutil.NewFileStorage()
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), storage, nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+
+	const uploads = 10
+	files := make([]*entity.MediaFile, uploads)
+	errs := make([]error, uploads)
+	var wg sync.WaitGroup
+	for i := 0; i < uploads; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			files[i], errs[i] = uc.UploadFile(ctx, "report.pdf", "document", 1, "user", entity.FileAssociation{}, []byte(fmt.Sprint("version ", i)))
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool)
+	for i, file := range files {
+		if errs[i] != nil {
+			t.Fatalf("UploadFile() error = %v", errs[i])
+		}
+		if seen[file.FileURL] {
+			t.Errorf("upload %d stored at %q, which another upload used", i, file.FileURL)
+		}
+		seen[file.FileURL] = true
+		data, err := storage.Get(ctx, file.FileURL)
+		if err != nil || string(data) != fmt.Sprint("version ", i) {
+			t.Errorf("upload %d content = %q, %v; want its own data", i, data, err)
+		}
+	}
+}
+
+func TestMediaUseCase_GetFileContent(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewMediaFileRepository()
+	storage := testutil.NewFileStorage()
+	uc := NewMediaUseCase(repo, storage, nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+
+	file, err := uc.UploadFile(ctx, "notes.txt", "document", 1, "user", entity.FileAssociation{}, []byte("test"))
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	// SHA-256 of "test"
+	const want = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	if file.ContentHash != want {
+		t.Errorf("content_hash = %q, want %q", file.ContentHash, want)
+	}
+	got, data, err := uc.GetFileContent(ctx, file.ID, []string{"stale"})
+	if err != nil {
+		t.Fatalf("GetFileContent() error = %v", err)
+	}
+	if string(data) != "test" || got.ContentHash != want {
+		t.Errorf("GetFileContent() = %q, hash %q; want the content and its hash", data, got.ContentHash)
+	}
+
+	// Files from before content hashes and sizes were recorded get them on read
+	url, _ := storage.Save(ctx, "legacy.txt", []byte("test"))
+	legacy := entity.NewMediaFile("legacy.txt", url, "document", 1, 0)
+	repo.Create(ctx, legacy)
+	got, _, err = uc.GetFileContent(ctx, legacy.ID, []string{want})
+	if err != nil {
+		t.Fatalf("GetFileContent(legacy) error = %v", err)
+	}
+	if got.ContentHash != want || got.FileSize != 4 {
+		t.Errorf("GetFileContent(legacy) hash = %q, size %d; want %q, 4", got.ContentHash, got.FileSize, want)
+	}
+
+	// A client holding the content gets only the record, without a read
+	storage.Delete(ctx, file.FileURL)
+	got, data, err = uc.GetFileContent(ctx, file.ID, []string{"stale", want})
+	if err != nil || data != nil || got.ContentHash != want {
+		t.Errorf("GetFileContent(cached) = %q, hash %q, %v; want only the record", data, got.ContentHash, err)
+	}
+
+	if _, _, err := uc.GetFileContent(ctx, 999, nil); err != ErrFileNotFound {
+		t.Errorf("GetFileContent(missing) error = %v, want ErrFileNotFound", err)
+	}
+}
+
+// brokenRepository is a MediaFileRepository whose reads fail
+type brokenRepository struct {
+	*testutil.MediaFileRepository
+}
+
+func (brokenRepository) GetByID(ctx context.Context, id int64) (*entity.MediaFile, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestMediaUseCase_GetFileErrors(t *testing.T) {
+	ctx := context.Background()
+
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), testutil.NewFileStorage(), nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+	if _, err := uc.GetFile(ctx, 999); err != ErrFileNotFound {
+		t.Errorf("GetFile(missing) error = %v, want ErrFileNotFound", err)
+	}
+
+	// Database failures are not reported as missing files
+	uc = NewMediaUseCase(brokenRepository{testutil.NewMediaFileRepository()}, testutil.NewFileStorage(), nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+	if _, err := uc.GetFile(ctx, 1); err == nil || err == ErrFileNotFound {
+		t.Errorf("GetFile(broken repo) error = %v, want the repository error", err)
+	}
+	if err := uc.DeleteFile(ctx, 1); err == nil || err == ErrFileNotFound {
+		t.Errorf("DeleteFile(broken repo) error = %v, want the repository error", err)
+	}
+}
+
+func TestMediaUseCase_UploadFileTooLarge(t *testing.T) {
+	storage := &countingStorage{FileStorage: testutil.NewFileStorage()}
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), storage, nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+
+	_, err := uc.UploadFile(context.Background(), "big.bin", "document", 1, "user", entity.FileAssociation{}, make([]byte, MaxFileSize+1))
+	if err != ErrFileTooLarge {
+		t.Fatalf("UploadFile() error = %v, want ErrFileTooLarge", err)
+	}
+	if storage.saves != 0 {
+		t.Errorf("saves = %d, want 0", storage.saves)
+	}
+}
+
+func TestMediaUseCase_UploadFileQuota(t *testing.T) {
+	ctx := context.Background()
+	storage := &countingStorage{FileStorage: testutil.NewFileStorage()}
+	registry := prometheus.NewRegistry()
+	quota := StorageQuota{Default: 1000, ByRole: map[string]int64{"admin": 0, "manager": 3000}}
+	uc := NewMediaUseCase(testutil.NewMediaFileRepository(), storage, nil, 0, quota, NewUploadMetrics(registry), pagination.Sizes{})
+
+	// Within quota, up to exactly the limit
+	if _, err := uc.UploadFile(ctx, "a.pdf", "document", 1, "user", entity.FileAssociation{}, make([]byte, 600)); err != nil {
+		t.Fatalf("UploadFile(within quota) error = %v", err)
+	}
+	if _, err := uc.UploadFile(ctx, "b.pdf", "document", 1, "user", entity.FileAssociation{}, make([]byte, 400)); err != nil {
+		t.Fatalf("UploadFile(up to the quota) error = %v", err)
+	}
+
+	// Beyond quota
+	saves := storage.saves
+	if _, err := uc.UploadFile(ctx, "c.pdf", "document", 1, "user", entity.FileAssociation{}, make([]byte, 1)); !errors.Is(err, ErrQuotaExceeded) {
+		t.Fatalf("UploadFile(beyond quota) error = %v, want ErrQuotaExceeded", err)
+	}
+	if storage.saves != saves {
+		t.Error("an upload beyond the quota was stored")
+	}
+	if got := promtest.ToFloat64(uc.metrics.uploads.WithLabelValues("document", "over_quota")); got != 1 {
+		t.Errorf("over_quota uploads = %v, want 1", got)
+	}
+
+	// Quotas are per user, and roles override the default
+	if _, err := uc.UploadFile(ctx, "d.pdf", "document", 2, "user", entity.FileAssociation{}, make([]byte, 1000)); err != nil {
+		t.Errorf("UploadFile(other user) error = %v", err)
+	}
+	if _, err := uc.UploadFile(ctx, "e.pdf", "document", 3, "manager", entity.FileAssociation{}, make([]byte, 2500)); err != nil {
+		t.Errorf("UploadFile(manager within role quota) error = %v", err)
+	}
+	if _, err := uc.UploadFile(ctx, "f.pdf", "document", 3, "manager", entity.FileAssociation{}, make([]byte, 501)); !errors.Is(err, ErrQuotaExceeded) {
+		t.Errorf("UploadFile(manager beyond role quota) error = %v, want ErrQuotaExceeded", err)
+	}
+	if _, err := uc.UploadFile(ctx, "g.pdf", "document", 4, "admin", entity.FileAssociation{}, make([]byte, 5000)); err != nil {
+		t.Errorf("UploadFile(admin, unlimited) error = %v", err)
+	}
+
+	// Deleting files frees their space
+	files, _, _ := uc.GetFilesByUser(ctx, 1, 1, 10)
+	if err := uc.DeleteFile(ctx, files[0].ID); err != nil {
+		t.Fatalf("DeleteFile() error = %v", err)
+	}
+	if _, err := uc.UploadFile(ctx, "c.pdf", "document", 1, "user", entity.FileAssociation{}, make([]byte, 1)); err != nil {
+		t.Errorf("UploadFile(after freeing space) error = %v", err)
+	}
+}
+
+// barrierScanner holds every scan until all n uploads have reached it, so
+// they all pass the early quota check before any is recorded
+type barrierScanner struct{ wg *sync.WaitGroup }
+
+func (s barrierScanner) Scan(ctx context.Context, fileName string, data []byte) (string, error) {
+	s.wg.Done()
+	s.wg.Wait()
+	return "", nil
+}
+
+// liveStorage counts the files currently stored
+type liveStorage struct {
+	*testutil.FileStorage
+	live atomic.Int64
+}
+
+func (s *liveStorage) Save(ctx context.Context, fileName string, data []byte) (string, error) {
+	s.live.Add(1)
+	return s.FileStorage.Save(ctx, fileName, data)
+}
+
+func (s *liveStorage) Delete(ctx context.Context, fileURL string) error {
+	s.live.Add(-1)
+	return s.FileStorage.Delete(ctx, fileURL)
+}
+
+func TestMediaUseCase_UploadFileQuotaConcurrent(t *testing.T) {
+	ctx := context.Background()
+	const uploads = 10
+	var wg sync.WaitGroup
+	wg.Add(uploads)
+	repo := testutil.NewMediaFileRepository()
+	storage := &liveStorage{FileStorage: testutil.NewFileStorage()}
+	uc := NewMediaUseCase(repo, storage, barrierScanner{&wg}, 0, StorageQuota{Default: 1000}, nil, pagination.Sizes{})
+
+	// Each upload fits on its own, but only three fit together
+	errs := make(chan error, uploads)
+	for i := 0; i < uploads; i++ {
+		go func() {
+			_, err := uc.UploadFile(ctx, "a.pdf", "document", 1, "user", entity.FileAssociation{}, make([]byte, 300))
+			errs <- err
+		}()
+	}
+	stored := 0
+	for i := 0; i < uploads; i++ {
+		switch err := <-errs; {
+		case err == nil:
+			stored++
+		case !errors.Is(err, ErrQuotaExceeded):
+			t.Errorf("UploadFile() error = %v, want nil or ErrQuotaExceeded", err)
+		}
+	}
+
+	if used, _ := repo.TotalSizeByUser(ctx, 1); stored != 3 || used != 900 {
+		t.Errorf("stored %d uploads using %d bytes, want 3 using 900", stored, used)
+	}
+	if live := storage.live.Load(); live != 3 {
+		t.Errorf("%d files left in storage, want only the 3 recorded", live)
+	}
+}
+
+func TestMediaUseCase_BackfillFileSizes(t *testing.T) {
+	ctx := context.Background()
+	repo := testutil.NewMediaFileRepository()
+	storage := testutil.NewFileStorage()
+	uc := NewMediaUseCase(repo, storage, nil, 0, StorageQuota{}, nil, pagination.Sizes{})
+
+	// More unsized files than fit in one batch, one of them gone from storage
+	var ids []int64
+	for i := 0; i < backfillBatchSize+5; i++ {
+		name := fmt.Sprintf("legacy-%d.txt", i)
+		url, _ := storage.Save(ctx, name, make([]byte, i+1))
+		file := entity.NewMediaFile(name, url, "document", 1, 0)
+		repo.Create(ctx, file)
+		ids = append(ids, file.ID)
+	}
+	storage.Delete(ctx, "memory://legacy-3.txt")
+	sized, _ := uc.UploadFile(ctx, "new.txt", "document", 1, "user", entity.FileAssociation{}, []byte("new"))
+
+	n, err := uc.BackfillFileSizes(ctx)
+	if err != nil {
+		t.Fatalf("BackfillFileSizes() error = %v", err)
+	}
+	if n != backfillBatchSize+4 {
+		t.Errorf("BackfillFileSizes() = %d, want %d", n, backfillBatchSize+4)
+	}
+	for i, id := range ids {
+		file, _ := repo.GetByID(ctx, id)
+		want := int64(i + 1)
+		if i == 3 {
+			want = 0
+		}
+		if file.FileSize != want {
+			t.Errorf("file %d size = %d, want %d", id, file.FileSize, want)
+		}
+	}
+	if file, _ := repo.GetByID(ctx, sized.ID); file.FileSize != 3 {
+		t.Errorf("uploaded file size = %d, want it left at 3", file.FileSize)
+	}
+}
@@ -1,32 +1,61 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 
 	"github.com/portfolio/project-service/internal/config"
+	"github.com/portfolio/project-service/internal/domain/entity"
 	"github.com/portfolio/project-service/internal/handler"
 	"github.com/portfolio/project-service/internal/infrastructure/repository"
 	"github.com/portfolio/project-service/internal/usecase"
 	pb "github.com/portfolio/proto/project"
 	"github.com/portfolio/shared/database"
+	"github.com/portfolio/shared/grpctls"
+	"github.com/portfolio/shared/logging"
+	"github.com/portfolio/shared/metrics"
 	"github.com/portfolio/shared/middleware"
+	"github.com/portfolio/shared/migrations"
+	"github.com/portfolio/shared/outbox"
+	"github.com/portfolio/shared/pagination"
+	"github.com/portfolio/shared/webhook"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply database migrations and exit")
+	flag.Parse()
+
+	logging.Setup("project-service")
+
 	// Load configuration
 	cfg := config.Load()
+	tlsConfig := grpctls.Config{
+		Enabled:  cfg.GRPCTLSEnabled,
+		CertFile: cfg.GRPCTLSCertFile,
+		KeyFile:  cfg.GRPCTLSKeyFile,
+		CAFile:   cfg.GRPCTLSCAFile,
+	}
 
 	// Initialize database connection
 	dbConfig := database.Config{
-		Host:     cfg.DBHost,
-		Port:     cfg.DBPort,
-		User:     cfg.DBUser,
-		Password: cfg.DBPassword,
-		DBName:   cfg.DBName,
-		SSLMode:  cfg.DBSSLMode,
+		Host:        cfg.DBHost,
+		Port:        cfg.DBPort,
+		User:        cfg.DBUser,
+		Password:    cfg.DBPassword,
+		DBName:      cfg.DBName,
+		SSLMode:     cfg.DBSSLMode,
+		SSLRootCert: cfg.DBSSLRootCert,
+		SSLCert:     cfg.DBSSLCert,
+		SSLKey:      cfg.DBSSLKey,
+
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
 	}
 
 	pool, err := database.NewPool(dbConfig)
@@ -37,6 +66,15 @@ func main() {
 
 	db := pool.GetDB()
 
+	// Apply pending schema migrations
+	if _, err := migrations.Run(context.Background(), db); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if *migrateOnly {
+		log.Println("Migrations applied")
+		return
+	}
+
 	// Initialize repositories
 	projectRepo := repository.NewPostgresProjectRepository(db)
 	skillRepo := repository.NewPostgresSkillRepository(db)
@@ -44,25 +82,59 @@ func main() {
 	techRepo := repository.NewPostgresProjectTechRepository(db)
 	imageRepo := repository.NewPostgresProjectImageRepository(db)
 	linkRepo := repository.NewPostgresProjectLinkRepository(db)
+	settingsRepo := repository.NewPostgresProjectSettingsRepository(db)
 
 	// Initialize use cases
-	projectUC := usecase.NewProjectUseCase(projectRepo, skillRepo, projectSkillRepo, techRepo, imageRepo, linkRepo)
+	if !entity.IsValidStatus(cfg.DefaultProjectStatus) {
+		log.Fatalf("Invalid DEFAULT_PROJECT_STATUS %q", cfg.DefaultProjectStatus)
+	}
+	// The project repository writes its events to the outbox; the relay
+	// delivers them to the subscribed webhooks
+	webhookStore := webhook.NewPostgres(db)
+	webhooks := webhook.NewDispatcher(webhookStore, webhook.Config{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		Timeout:     cfg.WebhookTimeout,
+	})
+	relay := outbox.NewRelay(outbox.NewPostgres(db, repository.OutboxSource), webhooks.HandleOutbox, outbox.RelayConfig{
+		PollInterval: cfg.OutboxPollInterval,
+		MaxAttempts:  cfg.OutboxMaxAttempts,
+	})
+	go relay.Run(context.Background())
+	pages := pagination.New(cfg.DefaultPageSize, cfg.MaxPageSize)
+	projectUC := usecase.NewProjectUseCase(projectRepo, skillRepo, projectSkillRepo, techRepo, imageRepo, linkRepo, cfg.DefaultProjectStatus, pages)
 	skillUC := usecase.NewSkillUseCase(skillRepo)
 	projectSkillUC := usecase.NewProjectSkillUseCase(projectSkillRepo)
 	techUC := usecase.NewTechUseCase(techRepo)
 	imageUC := usecase.NewImageUseCase(imageRepo)
 	linkUC := usecase.NewLinkUseCase(linkRepo)
+	settingsUC := usecase.NewSettingsUseCase(projectRepo, settingsRepo)
+	webhookUC := usecase.NewWebhookUseCase(webhookStore, pages)
+
+	// Metrics
+	registry := metrics.NewRegistry()
+	grpcMetrics := metrics.NewGRPCServerMetrics(registry)
+	metrics.Serve(cfg.MetricsPort, registry)
+
+	// Plaintext unless GRPC_TLS_ENABLED is set
+	serverCreds, err := grpctls.ServerOption(tlsConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up gRPC TLS: %v", err)
+	}
 
 	// Create gRPC server with middleware
 	grpcServer := grpc.NewServer(
+		serverCreds,
 		grpc.ChainUnaryInterceptor(
 			middleware.RecoveryInterceptor(),
+			grpcMetrics.UnaryInterceptor(),
 			middleware.LoggingInterceptor(),
+			middleware.IdentityInterceptor(),
+			middleware.ValidationInterceptor(),
 		),
 	)
 
 	// Register project service handler
-	projectHandler := handler.NewProjectHandler(projectUC, skillUC, projectSkillUC, techUC, imageUC, linkUC)
+	projectHandler := handler.NewProjectHandler(projectUC, skillUC, projectSkillUC, techUC, imageUC, linkUC, settingsUC, webhookUC)
 	pb.RegisterProjectServiceServer(grpcServer, projectHandler)
 
 	// Start server
@@ -3,6 +3,8 @@ module github.com/portfolio/project-service
 go 1.21
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/lib/pq v1.10.9
 	github.com/portfolio/proto v0.0.0
 	github.com/portfolio/shared v0.0.0
 	google.golang.org/grpc v1.64.0
@@ -3,29 +3,81 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config holds the application configuration
 type Config struct {
-	GRPCPort   int
-	DBHost     string
-	DBPort     int
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
+	GRPCPort          int
+	GRPCTLSEnabled    bool
+	GRPCTLSCertFile   string
+	GRPCTLSKeyFile    string
+	GRPCTLSCAFile     string
+	MetricsPort       int
+	DBHost            string
+	DBPort            int
+	DBUser            string
+	DBPassword        string
+	DBName            string
+	DBSSLMode         string
+	DBSSLRootCert     string
+	DBSSLCert         string
+	DBSSLKey          string
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+
+	// List pages hold DefaultPageSize items unless a request asks for
+	// another size, up to MaxPageSize
+	DefaultPageSize int
+	MaxPageSize     int
+
+	// DefaultProjectStatus is given to projects created without a status
+	DefaultProjectStatus string
+
+	// Webhook deliveries are tried up to WebhookMaxAttempts times, each
+	// bounded by WebhookTimeout
+	WebhookMaxAttempts int
+	WebhookTimeout     time.Duration
+
+	// The outbox relay checks for events every OutboxPollInterval and sets
+	// an event aside after OutboxMaxAttempts failed deliveries
+	OutboxPollInterval time.Duration
+	OutboxMaxAttempts  int
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
-		GRPCPort:   getEnvInt("GRPC_PORT", 50052),
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnvInt("DB_PORT", 5432),
-		DBUser:     getEnv("DB_USER", "postgres"),
-		DBPassword: getEnv("DB_PASSWORD", "postgres"),
-		DBName:     getEnv("DB_NAME", "portfolio"),
-		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
+		GRPCPort:          getEnvInt("GRPC_PORT", 50052),
+		GRPCTLSEnabled:    getEnvBool("GRPC_TLS_ENABLED", false),
+		GRPCTLSCertFile:   getEnv("GRPC_TLS_CERT_FILE", ""),
+		GRPCTLSKeyFile:    getEnv("GRPC_TLS_KEY_FILE", ""),
+		GRPCTLSCAFile:     getEnv("GRPC_TLS_CA_FILE", ""),
+		MetricsPort:       getEnvInt("METRICS_PORT", 9102),
+		DBHost:            getEnv("DB_HOST", "localhost"),
+		DBPort:            getEnvInt("DB_PORT", 5432),
+		DBUser:            getEnv("DB_USER", "postgres"),
+		DBPassword:        getEnv("DB_PASSWORD", "postgres"),
+		DBName:            getEnv("DB_NAME", "portfolio"),
+		DBSSLMode:         getEnv("DB_SSL_MODE", "disable"),
+		DBSSLRootCert:     getEnv("DB_SSL_ROOT_CERT", ""),
+		DBSSLCert:         getEnv("DB_SSL_CERT", ""),
+		DBSSLKey:          getEnv("DB_SSL_KEY", ""),
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		DBConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 0),
+		DefaultPageSize:   getEnvInt("DEFAULT_PAGE_SIZE", 20),
+		MaxPageSize:       getEnvInt("MAX_PAGE_SIZE", 100),
+
+		DefaultProjectStatus: getEnv("DEFAULT_PROJECT_STATUS", "active"),
+
+		WebhookMaxAttempts: getEnvInt("WEBHOOK_MAX_ATTEMPTS", 5),
+		WebhookTimeout:     getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		OutboxPollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		OutboxMaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
 	}
 }
 
@@ -44,3 +96,21 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
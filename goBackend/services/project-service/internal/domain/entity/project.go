@@ -72,6 +72,26 @@ const (
 	StatusOnHold    = "on_hold"
 )
 
+// Relations of a project that project lists can load
+const (
+	RelationSkills = "skills"
+	RelationTech   = "tech"
+	RelationImages = "images"
+	RelationLinks  = "links"
+)
+
+// Relations returns all relations a project list can load
+func Relations() []string {
+	return []string{RelationSkills, RelationTech, RelationImages, RelationLinks}
+}
+
+// Project event types
+const (
+	ProjectCreated = "project.created"
+	ProjectUpdated = "project.updated"
+	ProjectDeleted = "project.deleted"
+)
+
 // ValidStatuses returns all valid project statuses
 func ValidStatuses() []string {
 	return []string{StatusActive, StatusCompleted, StatusArchived, StatusOnHold}
@@ -98,3 +118,44 @@ const (
 func ValidLinkTypes() []string {
 	return []string{LinkTypeGitHub, LinkTypeLive, LinkTypeDocument}
 }
+
+// IsValidLinkType checks if link type is valid
+func IsValidLinkType(linkType string) bool {
+	for _, t := range ValidLinkTypes() {
+		if t == linkType {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectSettings holds per-project preferences. An empty DefaultTaskStatus
+// or a zero DefaultTaskPriority leaves the task service's global default.
+type ProjectSettings struct {
+	ProjectID           int64     `json:"project_id"`
+	DefaultTaskStatus   string    `json:"default_task_status"`
+	DefaultTaskPriority int       `json:"default_task_priority"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+// Task priorities a project may default to; they mirror the task service's,
+// from 1 (high) to 4 (none)
+const (
+	MinTaskPriority = 1
+	MaxTaskPriority = 4
+)
+
+// ValidTaskStatuses returns the task statuses a project may default to
+func ValidTaskStatuses() []string {
+	return []string{"Todo", "InProgress", "Done"}
+}
+
+// IsValidTaskStatus checks if status is a task status
+func IsValidTaskStatus(status string) bool {
+	for _, s := range ValidTaskStatuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -10,9 +10,25 @@ import (
 type ProjectRepository interface {
 	Create(ctx context.Context, project *entity.Project) error
 	GetByID(ctx context.Context, id int64) (*entity.Project, error)
+	// GetByIDs gets the projects that exist among ids, ordered by ID
+	GetByIDs(ctx context.Context, ids []int64) ([]*entity.Project, error)
 	Update(ctx context.Context, project *entity.Project) error
 	Delete(ctx context.Context, id int64) error
-	List(ctx context.Context, page, limit int, status string) ([]*entity.Project, int, error)
+	// List lists projects in ID order. An empty status matches any status but
+	// archived, unless includeArchived is set.
+	List(ctx context.Context, page, limit int, status string, includeArchived bool) ([]*entity.Project, int, error)
+	// CountOpenTasks counts the project's tasks that are not done
+	CountOpenTasks(ctx context.Context, projectID int64) (int, error)
+	// Clone creates project with the skills, tech and links of the project with sourceID
+	Clone(ctx context.Context, sourceID int64, project *entity.Project) error
+}
+
+// ProjectSettingsRepository defines the interface for per-project settings
+type ProjectSettingsRepository interface {
+	// Get gets a project's settings; a project without any gets empty settings
+	Get(ctx context.Context, projectID int64) (*entity.ProjectSettings, error)
+	// Set creates or replaces a project's settings
+	Set(ctx context.Context, settings *entity.ProjectSettings) error
 }
 
 // SkillRepository defines the interface for skill data access
@@ -28,6 +44,8 @@ type ProjectSkillRepository interface {
 	Add(ctx context.Context, projectID, skillID int64) error
 	Remove(ctx context.Context, projectID, skillID int64) error
 	GetByProjectID(ctx context.Context, projectID int64) ([]*entity.Skill, error)
+	// GetByProjectIDs gets the skills of several projects, keyed by project
+	GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.Skill, error)
 }
 
 // ProjectTechRepository defines the interface for project tech stack
@@ -35,6 +53,8 @@ type ProjectTechRepository interface {
 	Add(ctx context.Context, projectID int64, techName string) error
 	Remove(ctx context.Context, projectID int64, techName string) error
 	GetByProjectID(ctx context.Context, projectID int64) ([]string, error)
+	// GetByProjectIDs gets the technologies of several projects, keyed by project
+	GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]string, error)
 }
 
 // ProjectImageRepository defines the interface for project images
@@ -43,6 +63,8 @@ type ProjectImageRepository interface {
 	GetByID(ctx context.Context, id int64) (*entity.ProjectImage, error)
 	Remove(ctx context.Context, id int64) error
 	GetByProjectID(ctx context.Context, projectID int64) ([]*entity.ProjectImage, error)
+	// GetByProjectIDs gets the images of several projects, keyed by project
+	GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectImage, error)
 }
 
 // ProjectLinkRepository defines the interface for project links
@@ -50,5 +72,7 @@ type ProjectLinkRepository interface {
 	Add(ctx context.Context, link *entity.ProjectLink) error
 	GetByID(ctx context.Context, id int64) (*entity.ProjectLink, error)
 	Remove(ctx context.Context, id int64) error
-	GetByProjectID(ctx context.Context, projectID int64) ([]*entity.ProjectLink, error)
+	GetByProjectID(ctx context.Context, projectID int64, linkType string) ([]*entity.ProjectLink, error)
+	// GetByProjectIDs gets the links of several projects, keyed by project
+	GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectLink, error)
 }
@@ -2,12 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/portfolio/project-service/internal/domain/entity"
 	"github.com/portfolio/project-service/internal/usecase"
 	pb "github.com/portfolio/proto/project"
+	"github.com/portfolio/shared/webhook"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -20,6 +25,8 @@ type ProjectHandler struct {
 	techUC         *usecase.TechUseCase
 	imageUC        *usecase.ImageUseCase
 	linkUC         *usecase.LinkUseCase
+	settingsUC     *usecase.SettingsUseCase
+	webhookUC      *usecase.WebhookUseCase
 }
 
 // NewProjectHandler creates a new ProjectHandler
@@ -30,6 +37,8 @@ func NewProjectHandler(
 	techUC *usecase.TechUseCase,
 	imageUC *usecase.ImageUseCase,
 	linkUC *usecase.LinkUseCase,
+	settingsUC *usecase.SettingsUseCase,
+	webhookUC *usecase.WebhookUseCase,
 ) *ProjectHandler {
 	return &ProjectHandler{
 		projectUC:      projectUC,
@@ -38,27 +47,28 @@ func NewProjectHandler(
 		techUC:         techUC,
 		imageUC:        imageUC,
 		linkUC:         linkUC,
+		settingsUC:     settingsUC,
+		webhookUC:      webhookUC,
 	}
 }
 
 // --- Project CRUD ---
 
 func (h *ProjectHandler) CreateProject(ctx context.Context, req *pb.CreateProjectRequest) (*pb.ProjectResponse, error) {
-	startDate := req.StartDate.AsTime()
-	endDate := req.EndDate.AsTime()
-
-	project, err := h.projectUC.CreateProject(ctx, req.Name, req.Description, req.Status, &startDate, &endDate)
+	project, err := h.projectUC.CreateProject(ctx, req.Name, req.Description, req.Status, optionalTime(req.StartDate), optionalTime(req.EndDate))
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.ProjectResponse{Project: mapProjectToProto(project)}, nil
 }
 
 func (h *ProjectHandler) GetProject(ctx context.Context, req *pb.GetProjectRequest) (*pb.ProjectResponse, error) {
-	fmt.Println("GetProject")
-	fmt.Println(req.Id)
-	project, err := h.projectUC.GetProject(ctx, req.Id)
+	get := h.projectUC.GetProject
+	if req.Basic {
+		get = h.projectUC.GetProjectBasic
+	}
+	project, err := get(ctx, req.Id)
 	if err != nil {
 		return nil, err
 	}
@@ -66,19 +76,9 @@ func (h *ProjectHandler) GetProject(ctx context.Context, req *pb.GetProjectReque
 }
 
 func (h *ProjectHandler) UpdateProject(ctx context.Context, req *pb.UpdateProjectRequest) (*pb.ProjectResponse, error) {
-	var startDate, endDate *time.Time
-	if req.StartDate != nil {
-		t := req.StartDate.AsTime()
-		startDate = &t
-	}
-	if req.EndDate != nil {
-		t := req.EndDate.AsTime()
-		endDate = &t
-	}
-
-	project, err := h.projectUC.UpdateProject(ctx, req.Id, req.Name, req.Description, req.Status, startDate, endDate)
+	project, err := h.projectUC.UpdateProject(ctx, req.Id, req.Name, req.Description, req.Status, optionalTime(req.StartDate), optionalTime(req.EndDate))
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.ProjectResponse{Project: mapProjectToProto(project)}, nil
@@ -93,9 +93,9 @@ func (h *ProjectHandler) DeleteProject(ctx context.Context, req *pb.DeleteProjec
 }
 
 func (h *ProjectHandler) ListProjects(ctx context.Context, req *pb.ListProjectsRequest) (*pb.ListProjectsResponse, error) {
-	projects, total, err := h.projectUC.ListProjects(ctx, int(req.Page), int(req.Limit), req.Status)
+	projects, total, err := h.projectUC.ListProjects(ctx, int(req.Page), int(req.Limit), req.Status, req.IncludeArchived, req.Include)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	var protoProjects []*pb.Project
@@ -106,15 +106,83 @@ func (h *ProjectHandler) ListProjects(ctx context.Context, req *pb.ListProjectsR
 	return &pb.ListProjectsResponse{
 		Projects: protoProjects,
 		Total:    int32(total),
+		Limit:    int32(h.projectUC.PageLimit(int(req.Limit))),
 	}, nil
 }
 
+func (h *ProjectHandler) BatchGetProjects(ctx context.Context, req *pb.BatchGetProjectsRequest) (*pb.BatchGetProjectsResponse, error) {
+	projects, err := h.projectUC.GetProjects(ctx, req.Ids)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	protoProjects := make([]*pb.Project, 0, len(projects))
+	for _, p := range projects {
+		protoProjects = append(protoProjects, mapProjectToProto(p))
+	}
+	return &pb.BatchGetProjectsResponse{Projects: protoProjects}, nil
+}
+
+func (h *ProjectHandler) CompleteProject(ctx context.Context, req *pb.CompleteProjectRequest) (*pb.ProjectResponse, error) {
+	project, err := h.projectUC.Complete(ctx, req.Id, req.Force)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ProjectResponse{Project: mapProjectToProto(project)}, nil
+}
+
+func (h *ProjectHandler) CloneProject(ctx context.Context, req *pb.CloneProjectRequest) (*pb.ProjectResponse, error) {
+	project, err := h.projectUC.CloneProject(ctx, req.Id, req.Name)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ProjectResponse{Project: mapProjectToProto(project)}, nil
+}
+
+func (h *ProjectHandler) ArchiveProject(ctx context.Context, req *pb.ArchiveProjectRequest) (*pb.ProjectResponse, error) {
+	project, err := h.projectUC.Archive(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ProjectResponse{Project: mapProjectToProto(project)}, nil
+}
+
+func (h *ProjectHandler) UnarchiveProject(ctx context.Context, req *pb.UnarchiveProjectRequest) (*pb.ProjectResponse, error) {
+	project, err := h.projectUC.Unarchive(ctx, req.Id)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	return &pb.ProjectResponse{Project: mapProjectToProto(project)}, nil
+}
+
+// --- Settings ---
+
+func (h *ProjectHandler) GetProjectSettings(ctx context.Context, req *pb.GetProjectSettingsRequest) (*pb.ProjectSettingsResponse, error) {
+	settings, err := h.settingsUC.GetSettings(ctx, req.ProjectId)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ProjectSettingsResponse{Settings: mapSettingsToProto(settings)}, nil
+}
+
+func (h *ProjectHandler) UpdateProjectSettings(ctx context.Context, req *pb.UpdateProjectSettingsRequest) (*pb.ProjectSettingsResponse, error) {
+	settings, err := h.settingsUC.UpdateSettings(ctx, req.ProjectId, req.DefaultTaskStatus, int(req.DefaultTaskPriority))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.ProjectSettingsResponse{Settings: mapSettingsToProto(settings)}, nil
+}
+
 // --- Skills ---
 
 func (h *ProjectHandler) CreateSkill(ctx context.Context, req *pb.CreateSkillRequest) (*pb.SkillResponse, error) {
 	skill, err := h.skillUC.CreateSkill(ctx, req.Name)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &pb.SkillResponse{Skill: &pb.Skill{Id: skill.ID, Name: skill.Name}}, nil
 }
@@ -154,7 +222,7 @@ func (h *ProjectHandler) RemoveProjectSkill(ctx context.Context, req *pb.RemoveP
 func (h *ProjectHandler) AddProjectTech(ctx context.Context, req *pb.AddProjectTechRequest) (*pb.Empty, error) {
 	err := h.techUC.AddTech(ctx, req.ProjectId, req.TechName)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 	return &pb.Empty{}, nil
 }
@@ -172,7 +240,7 @@ func (h *ProjectHandler) RemoveProjectTech(ctx context.Context, req *pb.RemovePr
 func (h *ProjectHandler) AddProjectImage(ctx context.Context, req *pb.AddProjectImageRequest) (*pb.ProjectImageResponse, error) {
 	image, err := h.imageUC.AddImage(ctx, req.ProjectId, req.ImageUrl, req.Description)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.ProjectImageResponse{
@@ -219,7 +287,7 @@ func (h *ProjectHandler) ListProjectImages(ctx context.Context, req *pb.ListProj
 func (h *ProjectHandler) AddProjectLink(ctx context.Context, req *pb.AddProjectLinkRequest) (*pb.ProjectLinkResponse, error) {
 	link, err := h.linkUC.AddLink(ctx, req.ProjectId, req.LinkUrl, req.LinkType)
 	if err != nil {
-		return nil, err
+		return nil, toStatus(err)
 	}
 
 	return &pb.ProjectLinkResponse{
@@ -241,9 +309,12 @@ func (h *ProjectHandler) RemoveProjectLink(ctx context.Context, req *pb.RemovePr
 }
 
 func (h *ProjectHandler) ListProjectLinks(ctx context.Context, req *pb.ListProjectLinksRequest) (*pb.ListProjectLinksResponse, error) {
-	links, err := h.linkUC.GetLinks(ctx, req.ProjectId)
+	links, err := h.linkUC.GetLinks(ctx, req.ProjectId, req.LinkType)
 	if err != nil {
-		return nil, err
+		if err == usecase.ErrInvalidLinkType {
+			return nil, toStatus(err)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	var protoLinks []*pb.ProjectLink
@@ -259,8 +330,127 @@ func (h *ProjectHandler) ListProjectLinks(ctx context.Context, req *pb.ListProje
 	return &pb.ListProjectLinksResponse{Links: protoLinks}, nil
 }
 
+// --- Webhooks ---
+
+func (h *ProjectHandler) CreateWebhook(ctx context.Context, req *pb.CreateWebhookRequest) (*pb.WebhookResponse, error) {
+	sub, err := h.webhookUC.CreateWebhook(ctx, req.Url, req.Secret, req.EventTypes)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	hook := mapWebhookToProto(sub)
+	hook.Secret = sub.Secret
+	return &pb.WebhookResponse{Webhook: hook}, nil
+}
+
+func (h *ProjectHandler) ListWebhooks(ctx context.Context, req *pb.Empty) (*pb.ListWebhooksResponse, error) {
+	subs, err := h.webhookUC.ListWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	hooks := make([]*pb.Webhook, len(subs))
+	for i, sub := range subs {
+		hooks[i] = mapWebhookToProto(sub)
+	}
+	return &pb.ListWebhooksResponse{Webhooks: hooks}, nil
+}
+
+func (h *ProjectHandler) DeleteWebhook(ctx context.Context, req *pb.DeleteWebhookRequest) (*pb.Empty, error) {
+	if err := h.webhookUC.DeleteWebhook(ctx, req.Id); err != nil {
+		return nil, toStatus(err)
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *ProjectHandler) ListWebhookDeliveries(ctx context.Context, req *pb.ListWebhookDeliveriesRequest) (*pb.ListWebhookDeliveriesResponse, error) {
+	deliveries, err := h.webhookUC.ListDeliveries(ctx, req.WebhookId, int(req.Limit))
+	if err != nil {
+		return nil, err
+	}
+	resp := &pb.ListWebhookDeliveriesResponse{Deliveries: make([]*pb.WebhookDelivery, len(deliveries))}
+	for i, d := range deliveries {
+		resp.Deliveries[i] = &pb.WebhookDelivery{
+			Id:         d.ID,
+			WebhookId:  d.WebhookID,
+			EventId:    d.EventID,
+			EventType:  d.EventType,
+			Attempt:    int32(d.Attempt),
+			StatusCode: int32(d.StatusCode),
+			Error:      d.Error,
+			Succeeded:  d.Succeeded,
+			CreatedAt:  timestamppb.New(d.CreatedAt),
+		}
+	}
+	return resp, nil
+}
+
 // --- Helpers ---
 
+// optionalTime converts an unset timestamp to nil instead of the Unix epoch
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// toStatus maps usecase errors to gRPC status errors; unknown errors are returned as is
+func toStatus(err error) error {
+	var openErr *usecase.OpenTasksError
+	if errors.As(err, &openErr) {
+		return status.Error(codes.FailedPrecondition, openErr.Error())
+	}
+	switch err {
+	case usecase.ErrNameRequired, usecase.ErrTechNameRequired, usecase.ErrInvalidURL, usecase.ErrInvalidDateRange,
+		usecase.ErrNoProjectIDs:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case usecase.ErrInvalidInclude:
+		return status.Error(codes.InvalidArgument, "include must be among "+strings.Join(entity.Relations(), ", "))
+	case usecase.ErrTooManyIDs:
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("at most %d project ids may be requested", usecase.MaxBatchProjects))
+	case usecase.ErrInvalidLinkType:
+		return status.Error(codes.InvalidArgument, "link type must be one of github, live, document")
+	case usecase.ErrInvalidStatus:
+		return status.Error(codes.InvalidArgument, "status must be one of active, completed, archived, on_hold")
+	case usecase.ErrInvalidTaskStatus:
+		return status.Error(codes.InvalidArgument, "default task status must be one of Todo, InProgress, Done")
+	case usecase.ErrInvalidTaskPriority:
+		return status.Error(codes.InvalidArgument, fmt.Sprintf("default task priority must be between %d and %d", entity.MinTaskPriority, entity.MaxTaskPriority))
+	case usecase.ErrNotArchived:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case usecase.ErrEventTypesRequired, usecase.ErrInternalWebhookURL:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case usecase.ErrInvalidEventType:
+		return status.Error(codes.InvalidArgument, "event types must be among "+strings.Join(webhook.EventTypes(), ", "))
+	case usecase.ErrProjectNotFound, usecase.ErrWebhookNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+// mapWebhookToProto leaves out the secret
+func mapWebhookToProto(sub *webhook.Subscription) *pb.Webhook {
+	return &pb.Webhook{
+		Id:         sub.ID,
+		Url:        sub.URL,
+		EventTypes: sub.EventTypes,
+		CreatedAt:  timestamppb.New(sub.CreatedAt),
+	}
+}
+
+// mapSettingsToProto omits updated_at for settings that were never saved
+func mapSettingsToProto(s *entity.ProjectSettings) *pb.ProjectSettings {
+	settings := &pb.ProjectSettings{
+		ProjectId:           s.ProjectID,
+		DefaultTaskStatus:   s.DefaultTaskStatus,
+		DefaultTaskPriority: int32(s.DefaultTaskPriority),
+	}
+	if !s.UpdatedAt.IsZero() {
+		settings.UpdatedAt = timestamppb.New(s.UpdatedAt)
+	}
+	return settings
+}
+
 func mapProjectToProto(p *entity.Project) *pb.Project {
 	var skills []*pb.Skill
 	for _, s := range p.Skills {
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/portfolio/project-service/internal/domain/entity"
+	"github.com/portfolio/project-service/internal/usecase"
+	pb "github.com/portfolio/proto/project"
+	"github.com/portfolio/shared/pagination"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeProjectRepository keeps the last created project
+type fakeProjectRepository struct {
+	created   *entity.Project
+	openTasks int
+	listLimit int
+}
+
+func (f *fakeProjectRepository) Create(ctx context.Context, project *entity.Project) error {
+	project.ID = 1
+	f.created = project
+	return nil
+}
+
+func (f *fakeProjectRepository) GetByID(ctx context.Context, id int64) (*entity.Project, error) {
+	return f.created, nil
+}
+
+func (f *fakeProjectRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Project, error) {
+	return nil, nil
+}
+
+func (f *fakeProjectRepository) Update(ctx context.Context, project *entity.Project) error {
+	return nil
+}
+
+func (f *fakeProjectRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (f *fakeProjectRepository) List(ctx context.Context, page, limit int, status string, includeArchived bool) ([]*entity.Project, int, error) {
+	f.listLimit = limit
+	return nil, 0, nil
+}
+
+func (f *fakeProjectRepository) CountOpenTasks(ctx context.Context, projectID int64) (int, error) {
+	return f.openTasks, nil
+}
+
+func (f *fakeProjectRepository) Clone(ctx context.Context, sourceID int64, project *entity.Project) error {
+	return f.Create(ctx, project)
+}
+
+func newTestHandler(repo *fakeProjectRepository) *ProjectHandler {
+	return NewProjectHandler(usecase.NewProjectUseCase(repo, nil, nil, nil, nil, nil, "", pagination.Sizes{}), nil, nil, nil, nil, nil, nil, nil)
+}
+
+func TestProjectHandler_CreateProject_WithoutDates(t *testing.T) {
+	repo := &fakeProjectRepository{}
+	h := newTestHandler(repo)
+
+	resp, err := h.CreateProject(context.Background(), &pb.CreateProjectRequest{Name: "Portfolio"})
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	if repo.created.StartDate != nil || repo.created.EndDate != nil {
+		t.Errorf("stored dates = %v / %v, want nil so the columns stay NULL", repo.created.StartDate, repo.created.EndDate)
+	}
+	if resp.Project.StartDate != nil || resp.Project.EndDate != nil {
+		t.Errorf("response dates = %v / %v, want them absent", resp.Project.StartDate, resp.Project.EndDate)
+	}
+}
+
+func TestProjectHandler_CreateProject_WithDates(t *testing.T) {
+	repo := &fakeProjectRepository{}
+	h := newTestHandler(repo)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	resp, err := h.CreateProject(context.Background(), &pb.CreateProjectRequest{
+		Name:      "Portfolio",
+		StartDate: timestamppb.New(start),
+	})
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	if repo.created.StartDate == nil || !repo.created.StartDate.Equal(start) {
+		t.Errorf("stored start date = %v, want %v", repo.created.StartDate, start)
+	}
+	if repo.created.EndDate != nil {
+		t.Errorf("stored end date = %v, want nil", repo.created.EndDate)
+	}
+	if !resp.Project.StartDate.AsTime().Equal(start) || resp.Project.EndDate != nil {
+		t.Errorf("response dates = %v / %v, want %v / absent", resp.Project.StartDate, resp.Project.EndDate, start)
+	}
+}
+
+func TestProjectHandler_ListProjects_ReportsAppliedLimit(t *testing.T) {
+	repo := &fakeProjectRepository{}
+	h := NewProjectHandler(usecase.NewProjectUseCase(repo, nil, nil, nil, nil, nil, "", pagination.New(10, 30)), nil, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name      string
+		limit     int32
+		wantLimit int
+	}{
+		{"Unset is the default", 0, 10},
+		{"In range", 25, 25},
+		{"Above max is clamped", 500, 30},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := h.ListProjects(context.Background(), &pb.ListProjectsRequest{Page: 1, Limit: tt.limit})
+			if err != nil {
+				t.Fatalf("ListProjects() error = %v", err)
+			}
+			if repo.listLimit != tt.wantLimit || resp.Limit != int32(tt.wantLimit) {
+				t.Errorf("listed %d and reported limit %d, want %d", repo.listLimit, resp.Limit, tt.wantLimit)
+			}
+		})
+	}
+}
+
+func TestProjectHandler_CompleteProject_OpenTasks(t *testing.T) {
+	repo := &fakeProjectRepository{created: &entity.Project{ID: 1, Name: "Portfolio", Status: entity.StatusActive}, openTasks: 2}
+	h := newTestHandler(repo)
+
+	_, err := h.CompleteProject(context.Background(), &pb.CompleteProjectRequest{Id: 1})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("CompleteProject() error = %v, want FailedPrecondition", err)
+	}
+	if repo.created.Status != entity.StatusActive {
+		t.Errorf("status = %s, want the project left active", repo.created.Status)
+	}
+}
+
+func TestProjectHandler_CreateProject_InvalidStatus(t *testing.T) {
+	repo := &fakeProjectRepository{}
+	h := newTestHandler(repo)
+
+	_, err := h.CreateProject(context.Background(), &pb.CreateProjectRequest{Name: "Portfolio", Status: "finished"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("CreateProject() error = %v, want InvalidArgument", err)
+	}
+	if repo.created != nil {
+		t.Error("CreateProject() stored a project with an invalid status")
+	}
+}
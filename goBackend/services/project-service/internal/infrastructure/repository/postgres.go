@@ -3,11 +3,17 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/portfolio/project-service/internal/domain/entity"
+	"github.com/portfolio/shared/outbox"
 )
 
+// OutboxSource names the project service's events in the outbox
+const OutboxSource = "project-service"
+
 // PostgresProjectRepository implements ProjectRepository
 type PostgresProjectRepository struct {
 	db *sql.DB
@@ -18,18 +24,31 @@ func NewPostgresProjectRepository(db *sql.DB) *PostgresProjectRepository {
 	return &PostgresProjectRepository{db: db}
 }
 
-// Create creates a new project
+// Create creates a new project and writes the ProjectCreated event to the
+// outbox in the same transaction
 func (r *PostgresProjectRepository) Create(ctx context.Context, project *entity.Project) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		INSERT INTO projects (name, description, start_date, end_date, status, created_at, updated_at)
 		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id
 	`
-	return r.db.QueryRowContext(
+	if err := tx.QueryRowContext(
 		ctx, query,
 		project.Name, project.Description, project.StartDate, project.EndDate,
 		project.Status, project.CreatedAt, project.UpdatedAt,
-	).Scan(&project.ID)
+	).Scan(&project.ID); err != nil {
+		return err
+	}
+	if err := outbox.Add(ctx, tx, OutboxSource, entity.ProjectCreated, project); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // GetByID gets a project by ID
@@ -50,65 +69,165 @@ func (r *PostgresProjectRepository) GetByID(ctx context.Context, id int64) (*ent
 	return project, nil
 }
 
-// Update updates a project
+// GetByIDs gets the projects with the given IDs ordered by ID; missing IDs are skipped
+func (r *PostgresProjectRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Project, error) {
+	query := `
+		SELECT id, name, description, start_date, end_date, status, created_at, updated_at
+		FROM projects WHERE id = ANY($1) ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var projects []*entity.Project
+	for rows.Next() {
+		project := &entity.Project{}
+		if err := rows.Scan(
+			&project.ID, &project.Name, &project.Description,
+			&project.StartDate, &project.EndDate, &project.Status,
+			&project.CreatedAt, &project.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+	return projects, rows.Err()
+}
+
+// Update updates a project and writes the ProjectUpdated event to the
+// outbox in the same transaction
 func (r *PostgresProjectRepository) Update(ctx context.Context, project *entity.Project) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
 		UPDATE projects SET name = $1, description = $2, start_date = $3,
 		end_date = $4, status = $5, updated_at = $6 WHERE id = $7
 	`
 	project.UpdatedAt = time.Now()
-	_, err := r.db.ExecContext(ctx, query,
+	if _, err := tx.ExecContext(ctx, query,
 		project.Name, project.Description, project.StartDate,
 		project.EndDate, project.Status, project.UpdatedAt, project.ID,
-	)
-	return err
+	); err != nil {
+		return err
+	}
+	if err := outbox.Add(ctx, tx, OutboxSource, entity.ProjectUpdated, project); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// Delete deletes a project
+// Delete deletes a project and its skills, tech, images, links and settings
+// in one transaction. Rows owned by other services (tasks and their children,
+// views, stats, access grants) go with it through ON DELETE CASCADE. The
+// ProjectDeleted event is written to the outbox if the project existed.
 func (r *PostgresProjectRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM projects WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Children are removed explicitly so the cascade does not depend on FK options
+	childQueries := []string{
+		`DELETE FROM project_skills WHERE project_id = $1`,
+		`DELETE FROM project_tech WHERE project_id = $1`,
+		`DELETE FROM project_images WHERE project_id = $1`,
+		`DELETE FROM project_links WHERE project_id = $1`,
+		`DELETE FROM project_settings WHERE project_id = $1`,
+	}
+	for _, query := range childQueries {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return err
+		}
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM projects WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n > 0 {
+		if err := outbox.Add(ctx, tx, OutboxSource, entity.ProjectDeleted, map[string]int64{"id": id}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Clone creates project and copies the skills, tech and links of the
+// project with sourceID to it in one transaction, together with the
+// ProjectCreated event. Images are not copied.
+func (r *PostgresProjectRepository) Clone(ctx context.Context, sourceID int64, project *entity.Project) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO projects (name, description, start_date, end_date, status, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	if err := tx.QueryRowContext(
+		ctx, query,
+		project.Name, project.Description, project.StartDate, project.EndDate,
+		project.Status, project.CreatedAt, project.UpdatedAt,
+	).Scan(&project.ID); err != nil {
+		return err
+	}
+
+	copyQueries := []string{
+		`INSERT INTO project_skills (project_id, skill_id) SELECT $1, skill_id FROM project_skills WHERE project_id = $2`,
+		`INSERT INTO project_tech (project_id, tech_name) SELECT $1, tech_name FROM project_tech WHERE project_id = $2`,
+		`INSERT INTO project_links (project_id, link_url, link_type) SELECT $1, link_url, link_type FROM project_links WHERE project_id = $2 ORDER BY id`,
+	}
+	for _, query := range copyQueries {
+		if _, err := tx.ExecContext(ctx, query, project.ID, sourceID); err != nil {
+			return err
+		}
+	}
+	if err := outbox.Add(ctx, tx, OutboxSource, entity.ProjectCreated, project); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // List lists projects with pagination
-func (r *PostgresProjectRepository) List(ctx context.Context, page, limit int, status string) ([]*entity.Project, int, error) {
+func (r *PostgresProjectRepository) List(ctx context.Context, page, limit int, status string, includeArchived bool) ([]*entity.Project, int, error) {
 	offset := (page - 1) * limit
 
-	// Build query based on status filter
-	var countQuery, query string
+	// Build the filter shared by the count and the page
+	var where string
 	var args []interface{}
-
-	if status != "" {
-		countQuery = `SELECT COUNT(*) FROM projects WHERE status = $1`
-		query = `
-			SELECT id, name, description, start_date, end_date, status, created_at, updated_at
-			FROM projects WHERE status = $1 ORDER BY id LIMIT $2 OFFSET $3
-		`
-		args = []interface{}{status, limit, offset}
-	} else {
-		countQuery = `SELECT COUNT(*) FROM projects`
-		query = `
-			SELECT id, name, description, start_date, end_date, status, created_at, updated_at
-			FROM projects ORDER BY id LIMIT $1 OFFSET $2
-		`
-		args = []interface{}{limit, offset}
+	switch {
+	case status != "":
+		where = ` WHERE status = $1`
+		args = append(args, status)
+	case !includeArchived:
+		where = ` WHERE status <> $1`
+		args = append(args, entity.StatusArchived)
 	}
 
 	// Get total count
 	var total int
-	if status != "" {
-		if err := r.db.QueryRowContext(ctx, countQuery, status).Scan(&total); err != nil {
-			return nil, 0, err
-		}
-	} else {
-		if err := r.db.QueryRowContext(ctx, countQuery).Scan(&total); err != nil {
-			return nil, 0, err
-		}
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM projects`+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
 	}
 
 	// Get projects
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	query := fmt.Sprintf(`
+		SELECT id, name, description, start_date, end_date, status, created_at, updated_at
+		FROM projects%s ORDER BY id LIMIT $%d OFFSET $%d
+	`, where, len(args)+1, len(args)+2)
+	rows, err := r.db.QueryContext(ctx, query, append(args, limit, offset)...)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -130,6 +249,62 @@ func (r *PostgresProjectRepository) List(ctx context.Context, page, limit int, s
 	return projects, total, nil
 }
 
+// CountOpenTasks counts the project's tasks whose status is not Done.
+// The tasks table belongs to the task service but shares this database.
+func (r *PostgresProjectRepository) CountOpenTasks(ctx context.Context, projectID int64) (int, error) {
+	query := `SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND status IS DISTINCT FROM 'Done'`
+	var count int
+	if err := r.db.QueryRowContext(ctx, query, projectID).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// PostgresProjectSettingsRepository implements ProjectSettingsRepository
+type PostgresProjectSettingsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresProjectSettingsRepository creates a new repository
+func NewPostgresProjectSettingsRepository(db *sql.DB) *PostgresProjectSettingsRepository {
+	return &PostgresProjectSettingsRepository{db: db}
+}
+
+// Get gets a project's settings; unset columns come back empty
+func (r *PostgresProjectSettingsRepository) Get(ctx context.Context, projectID int64) (*entity.ProjectSettings, error) {
+	query := `
+		SELECT COALESCE(default_task_status, ''), COALESCE(default_task_priority, 0), updated_at
+		FROM project_settings WHERE project_id = $1
+	`
+	settings := &entity.ProjectSettings{ProjectID: projectID}
+	err := r.db.QueryRowContext(ctx, query, projectID).Scan(
+		&settings.DefaultTaskStatus, &settings.DefaultTaskPriority, &settings.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// Set creates or replaces a project's settings; empty values are stored as NULL
+func (r *PostgresProjectSettingsRepository) Set(ctx context.Context, settings *entity.ProjectSettings) error {
+	query := `
+		INSERT INTO project_settings (project_id, default_task_status, default_task_priority, updated_at)
+		VALUES ($1, NULLIF($2, ''), NULLIF($3, 0), $4)
+		ON CONFLICT (project_id) DO UPDATE SET
+			default_task_status = EXCLUDED.default_task_status,
+			default_task_priority = EXCLUDED.default_task_priority,
+			updated_at = EXCLUDED.updated_at
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		settings.ProjectID, settings.DefaultTaskStatus, settings.DefaultTaskPriority, settings.UpdatedAt,
+	)
+	return err
+}
+
 // PostgresSkillRepository implements SkillRepository
 type PostgresSkillRepository struct {
 	db *sql.DB
@@ -217,7 +392,7 @@ func (r *PostgresProjectSkillRepository) GetByProjectID(ctx context.Context, pro
 	query := `
 		SELECT s.id, s.name FROM skills s
 		INNER JOIN project_skills ps ON s.id = ps.skill_id
-		WHERE ps.project_id = $1
+		WHERE ps.project_id = $1 ORDER BY s.name
 	`
 	rows, err := r.db.QueryContext(ctx, query, projectID)
 	if err != nil {
@@ -236,6 +411,31 @@ func (r *PostgresProjectSkillRepository) GetByProjectID(ctx context.Context, pro
 	return skills, nil
 }
 
+// GetByProjectIDs gets the skills of several projects in one query
+func (r *PostgresProjectSkillRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.Skill, error) {
+	query := `
+		SELECT ps.project_id, s.id, s.name FROM skills s
+		INNER JOIN project_skills ps ON s.id = ps.skill_id
+		WHERE ps.project_id = ANY($1) ORDER BY s.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(projectIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	skills := make(map[int64][]*entity.Skill)
+	for rows.Next() {
+		var projectID int64
+		skill := &entity.Skill{}
+		if err := rows.Scan(&projectID, &skill.ID, &skill.Name); err != nil {
+			return nil, err
+		}
+		skills[projectID] = append(skills[projectID], skill)
+	}
+	return skills, rows.Err()
+}
+
 // PostgresProjectTechRepository implements ProjectTechRepository
 type PostgresProjectTechRepository struct {
 	db *sql.DB
@@ -262,7 +462,7 @@ func (r *PostgresProjectTechRepository) Remove(ctx context.Context, projectID in
 
 // GetByProjectID gets all technologies for a project
 func (r *PostgresProjectTechRepository) GetByProjectID(ctx context.Context, projectID int64) ([]string, error) {
-	query := `SELECT tech_name FROM project_tech WHERE project_id = $1`
+	query := `SELECT tech_name FROM project_tech WHERE project_id = $1 ORDER BY tech_name`
 	rows, err := r.db.QueryContext(ctx, query, projectID)
 	if err != nil {
 		return nil, err
@@ -280,6 +480,27 @@ func (r *PostgresProjectTechRepository) GetByProjectID(ctx context.Context, proj
 	return techs, nil
 }
 
+// GetByProjectIDs gets the technologies of several projects in one query
+func (r *PostgresProjectTechRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]string, error) {
+	query := `SELECT project_id, tech_name FROM project_tech WHERE project_id = ANY($1) ORDER BY tech_name`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(projectIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	techs := make(map[int64][]string)
+	for rows.Next() {
+		var projectID int64
+		var tech string
+		if err := rows.Scan(&projectID, &tech); err != nil {
+			return nil, err
+		}
+		techs[projectID] = append(techs[projectID], tech)
+	}
+	return techs, rows.Err()
+}
+
 // PostgresProjectImageRepository implements ProjectImageRepository
 type PostgresProjectImageRepository struct {
 	db *sql.DB
@@ -323,7 +544,7 @@ func (r *PostgresProjectImageRepository) Remove(ctx context.Context, id int64) e
 
 // GetByProjectID gets all images for a project
 func (r *PostgresProjectImageRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.ProjectImage, error) {
-	query := `SELECT id, project_id, image_url, description, uploaded_at FROM project_images WHERE project_id = $1`
+	query := `SELECT id, project_id, image_url, description, uploaded_at FROM project_images WHERE project_id = $1 ORDER BY id`
 	rows, err := r.db.QueryContext(ctx, query, projectID)
 	if err != nil {
 		return nil, err
@@ -341,6 +562,26 @@ func (r *PostgresProjectImageRepository) GetByProjectID(ctx context.Context, pro
 	return images, nil
 }
 
+// GetByProjectIDs gets the images of several projects in one query
+func (r *PostgresProjectImageRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectImage, error) {
+	query := `SELECT id, project_id, image_url, description, uploaded_at FROM project_images WHERE project_id = ANY($1) ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(projectIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images := make(map[int64][]*entity.ProjectImage)
+	for rows.Next() {
+		image := &entity.ProjectImage{}
+		if err := rows.Scan(&image.ID, &image.ProjectID, &image.ImageURL, &image.Description, &image.UploadedAt); err != nil {
+			return nil, err
+		}
+		images[image.ProjectID] = append(images[image.ProjectID], image)
+	}
+	return images, rows.Err()
+}
+
 // PostgresProjectLinkRepository implements ProjectLinkRepository
 type PostgresProjectLinkRepository struct {
 	db *sql.DB
@@ -378,10 +619,17 @@ func (r *PostgresProjectLinkRepository) Remove(ctx context.Context, id int64) er
 	return err
 }
 
-// GetByProjectID gets all links for a project
-func (r *PostgresProjectLinkRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.ProjectLink, error) {
+// GetByProjectID gets the links for a project, optionally only those of linkType
+func (r *PostgresProjectLinkRepository) GetByProjectID(ctx context.Context, projectID int64, linkType string) ([]*entity.ProjectLink, error) {
 	query := `SELECT id, project_id, link_url, link_type FROM project_links WHERE project_id = $1`
-	rows, err := r.db.QueryContext(ctx, query, projectID)
+	args := []interface{}{projectID}
+	if linkType != "" {
+		query += ` AND link_type = $2`
+		args = append(args, linkType)
+	}
+	query += ` ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -397,3 +645,23 @@ func (r *PostgresProjectLinkRepository) GetByProjectID(ctx context.Context, proj
 	}
 	return links, nil
 }
+
+// GetByProjectIDs gets the links of several projects in one query
+func (r *PostgresProjectLinkRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectLink, error) {
+	query := `SELECT id, project_id, link_url, link_type FROM project_links WHERE project_id = ANY($1) ORDER BY id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(projectIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make(map[int64][]*entity.ProjectLink)
+	for rows.Next() {
+		link := &entity.ProjectLink{}
+		if err := rows.Scan(&link.ID, &link.ProjectID, &link.LinkURL, &link.LinkType); err != nil {
+			return nil, err
+		}
+		links[link.ProjectID] = append(links[link.ProjectID], link)
+	}
+	return links, rows.Err()
+}
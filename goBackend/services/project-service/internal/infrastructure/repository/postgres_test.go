@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/portfolio/project-service/internal/domain/entity"
+)
+
+func TestPostgresProjectLinkRepository_GetByProjectID(t *testing.T) {
+	base := `SELECT id, project_id, link_url, link_type FROM project_links WHERE project_id = $1`
+
+	tests := []struct {
+		name     string
+		linkType string
+		query    string
+		args     []driver.Value
+	}{
+		{"all", "", base + ` ORDER BY id`, []driver.Value{int64(7)}},
+		{"github", entity.LinkTypeGitHub, base + ` AND link_type = $2 ORDER BY id`, []driver.Value{int64(7), entity.LinkTypeGitHub}},
+		{"live", entity.LinkTypeLive, base + ` AND link_type = $2 ORDER BY id`, []driver.Value{int64(7), entity.LinkTypeLive}},
+		{"document", entity.LinkTypeDocument, base + ` AND link_type = $2 ORDER BY id`, []driver.Value{int64(7), entity.LinkTypeDocument}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			linkType := tt.linkType
+			if linkType == "" {
+				linkType = entity.LinkTypeGitHub
+			}
+			rows := sqlmock.NewRows([]string{"id", "project_id", "link_url", "link_type"}).
+				AddRow(1, 7, "https://example.com", linkType)
+			mock.ExpectQuery("^" + regexp.QuoteMeta(tt.query) + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(rows)
+
+			repo := NewPostgresProjectLinkRepository(db)
+			links, err := repo.GetByProjectID(context.Background(), 7, tt.linkType)
+			if err != nil {
+				t.Fatalf("GetByProjectID() error = %v", err)
+			}
+			if len(links) != 1 || links[0].LinkType != linkType {
+				t.Errorf("GetByProjectID() = %+v, want one %q link", links, linkType)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+// expectOutbox expects an event of eventType to be written to the outbox
+func expectOutbox(mock sqlmock.Sqlmock, eventType string) *sqlmock.ExpectedExec {
+	return mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox (source, event_type, payload)")).
+		WithArgs(OutboxSource, eventType, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+func TestPostgresProjectRepository_Update_WritesOutbox(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE projects SET name = $1")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectOutbox(mock, entity.ProjectUpdated).WillReturnError(errors.New("disk full"))
+	mock.ExpectRollback()
+
+	// The update is not kept without its event
+	project := &entity.Project{ID: 7, Name: "Portfolio", Status: entity.StatusActive}
+	if err := NewPostgresProjectRepository(db).Update(context.Background(), project); err == nil {
+		t.Fatal("Update() error = nil, want the outbox error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresProjectRepository_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	for _, table := range []string{"project_skills", "project_tech", "project_images", "project_links", "project_settings"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE project_id = $1")).
+			WithArgs(int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM projects WHERE id = $1")).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectOutbox(mock, entity.ProjectDeleted)
+	mock.ExpectCommit()
+	// Deleting a missing project announces nothing
+	mock.ExpectBegin()
+	for i := 0; i < 5; i++ {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM")).WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM projects WHERE id = $1")).
+		WithArgs(int64(8)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := NewPostgresProjectRepository(db)
+	if err := repo.Delete(context.Background(), 7); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete(context.Background(), 8); err != nil {
+		t.Fatalf("Delete(missing) error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresProjectRepository_Delete_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM project_skills")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM project_tech")).
+		WillReturnError(errors.New("connection reset"))
+	mock.ExpectRollback()
+
+	if err := NewPostgresProjectRepository(db).Delete(context.Background(), 7); err == nil {
+		t.Fatal("Delete() error = nil, want the failed statement's error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresProjectRepository_Clone(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO projects")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(12)))
+	for _, table := range []string{"project_skills", "project_tech", "project_links"} {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO "+table)).
+			WithArgs(int64(12), int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+	}
+	expectOutbox(mock, entity.ProjectCreated)
+	mock.ExpectCommit()
+
+	project := entity.NewProject("Portfolio (copy)", "", entity.StatusActive, nil, nil)
+	if err := NewPostgresProjectRepository(db).Clone(context.Background(), 7, project); err != nil {
+		t.Fatalf("Clone() error = %v", err)
+	}
+	if project.ID != 12 {
+		t.Errorf("project.ID = %d, want 12", project.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresProjectRepository_CountOpenTasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT COUNT(*) FROM tasks WHERE project_id = $1 AND status IS DISTINCT FROM 'Done'`)).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	open, err := NewPostgresProjectRepository(db).CountOpenTasks(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("CountOpenTasks() error = %v", err)
+	}
+	if open != 3 {
+		t.Errorf("CountOpenTasks() = %d, want 3", open)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresProjectRepository_List(t *testing.T) {
+	columns := []string{"id", "name", "description", "start_date", "end_date", "status", "created_at", "updated_at"}
+
+	tests := []struct {
+		name            string
+		status          string
+		includeArchived bool
+		where           string
+		args            []driver.Value
+	}{
+		{"Hides archived", "", false, ` WHERE status <> $1`, []driver.Value{entity.StatusArchived}},
+		{"Includes archived", "", true, ``, nil},
+		{"By status", entity.StatusArchived, false, ` WHERE status = $1`, []driver.Value{entity.StatusArchived}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			n := len(tt.args)
+			mock.ExpectQuery("^" + regexp.QuoteMeta("SELECT COUNT(*) FROM projects"+tt.where) + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+			mock.ExpectQuery(regexp.QuoteMeta(fmt.Sprintf("FROM projects%s ORDER BY id LIMIT $%d OFFSET $%d", tt.where, n+1, n+2))).
+				WithArgs(append(tt.args, 10, 10)...).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(11, "Eleventh", "", nil, nil, entity.StatusActive, time.Now(), time.Now()))
+
+			projects, total, err := NewPostgresProjectRepository(db).List(context.Background(), 2, 10, tt.status, tt.includeArchived)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if total != 1 || len(projects) != 1 || projects[0].ID != 11 {
+				t.Errorf("List() = %v of %d, want project 11 of 1", projects, total)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresProjectSettingsRepository_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("FROM project_settings WHERE project_id = $1")
+	now := time.Now()
+	mock.ExpectQuery(query).WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"default_task_status", "default_task_priority", "updated_at"}).
+			AddRow("InProgress", 1, now))
+	mock.ExpectQuery(query).WithArgs(int64(4)).WillReturnError(sql.ErrNoRows)
+
+	repo := NewPostgresProjectSettingsRepository(db)
+	settings, err := repo.Get(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Get(3) error = %v", err)
+	}
+	if settings.DefaultTaskStatus != "InProgress" || settings.DefaultTaskPriority != 1 {
+		t.Errorf("Get(3) = %+v, want InProgress at priority 1", settings)
+	}
+	settings, err = repo.Get(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("Get(4) error = %v", err)
+	}
+	if settings.ProjectID != 4 || settings.DefaultTaskStatus != "" || settings.DefaultTaskPriority != 0 {
+		t.Errorf("Get(4) = %+v, want empty settings", settings)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresProjectSettingsRepository_Set(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("ON CONFLICT (project_id) DO UPDATE")).
+		WithArgs(int64(3), "", 2, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	settings := &entity.ProjectSettings{ProjectID: 3, DefaultTaskPriority: 2, UpdatedAt: now}
+	if err := NewPostgresProjectSettingsRepository(db).Set(context.Background(), settings); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresProjectRepository_GetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	columns := []string{"id", "name", "description", "start_date", "end_date", "status", "created_at", "updated_at"}
+	now := time.Now()
+	// Only the requested projects are selected, in one query; project 2
+	// doesn't exist and has no row
+	mock.ExpectQuery(`SELECT id, name, description, start_date, end_date, status, created_at, updated_at\s+FROM projects WHERE id = ANY\(\$1\) ORDER BY id`).
+		WithArgs(pq.Array([]int64{3, 2, 1})).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(1, "First", "", nil, nil, entity.StatusActive, now, now).
+			AddRow(3, "Third", "", nil, nil, entity.StatusArchived, now, now))
+
+	projects, err := NewPostgresProjectRepository(db).GetByIDs(context.Background(), []int64{3, 2, 1})
+	if err != nil {
+		t.Fatalf("GetByIDs() error = %v", err)
+	}
+	if len(projects) != 2 || projects[0].ID != 1 || projects[1].Name != "Third" || projects[1].Status != entity.StatusArchived {
+		t.Errorf("GetByIDs() = %v, want projects 1 and 3 without the missing one", projects)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresProjectRelations_GetByProjectIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	ids := []int64{1, 2, 3}
+	mock.ExpectQuery(`FROM skills s\s+INNER JOIN project_skills ps ON s.id = ps.skill_id\s+WHERE ps.project_id = ANY\(\$1\)`).
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id", "id", "name"}).
+			AddRow(2, 5, "Docker").
+			AddRow(1, 4, "Go").
+			AddRow(2, 4, "Go"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT project_id, tech_name FROM project_tech WHERE project_id = ANY($1)`)).
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id", "tech_name"}).
+			AddRow(3, "PostgreSQL").
+			AddRow(1, "React"))
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT id, project_id, link_url, link_type FROM project_links WHERE project_id = ANY($1)`)).
+		WithArgs(pq.Array(ids)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "link_url", "link_type"}).
+			AddRow(7, 3, "https://github.com/a/b", entity.LinkTypeGitHub).
+			AddRow(8, 3, "https://b.example.com", entity.LinkTypeLive))
+
+	ctx := context.Background()
+	skills, err := NewPostgresProjectSkillRepository(db).GetByProjectIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("skills GetByProjectIDs() error = %v", err)
+	}
+	skillNames := func(projectID int64) []string {
+		var names []string
+		for _, s := range skills[projectID] {
+			names = append(names, s.Name)
+		}
+		return names
+	}
+	if got := skillNames(1); fmt.Sprint(got) != "[Go]" {
+		t.Errorf("skills of project 1 = %v, want [Go]", got)
+	}
+	if got := skillNames(2); fmt.Sprint(got) != "[Docker Go]" {
+		t.Errorf("skills of project 2 = %v, want [Docker Go]", got)
+	}
+	if _, ok := skills[3]; ok {
+		t.Errorf("project 3 has skills %v, want none", skills[3])
+	}
+
+	techs, err := NewPostgresProjectTechRepository(db).GetByProjectIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("tech GetByProjectIDs() error = %v", err)
+	}
+	if fmt.Sprint(techs[1]) != "[React]" || fmt.Sprint(techs[3]) != "[PostgreSQL]" || len(techs[2]) != 0 {
+		t.Errorf("tech = %v, want React for 1 and PostgreSQL for 3", techs)
+	}
+
+	links, err := NewPostgresProjectLinkRepository(db).GetByProjectIDs(ctx, ids)
+	if err != nil {
+		t.Fatalf("links GetByProjectIDs() error = %v", err)
+	}
+	if len(links) != 1 || len(links[3]) != 2 || links[3][0].ID != 7 || links[3][1].ID != 8 {
+		t.Errorf("links = %v, want links 7 and 8 of project 3", links)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
@@ -0,0 +1,594 @@
+// Package testutil provides in-memory implementations of the project service
+// repositories so usecases can be tested without a database. They follow the
+// Postgres repositories: missing rows are sql.ErrNoRows, lists use the same
+// ordering and pagination, and deleting a project removes its children.
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/portfolio/project-service/internal/domain/entity"
+	"github.com/portfolio/project-service/internal/domain/repository"
+)
+
+type projectTech struct {
+	projectID int64
+	techName  string
+}
+
+// Store holds the rows shared by the in-memory repositories, so that joins
+// and cascades on delete work across them
+type Store struct {
+	mu            sync.Mutex
+	lastID        map[string]int64
+	projects      map[int64]*entity.Project
+	skills        map[int64]*entity.Skill
+	projectSkills map[int64][]int64 // project id -> skill ids
+	tech          []projectTech
+	images        map[int64]*entity.ProjectImage
+	links         map[int64]*entity.ProjectLink
+	settings      map[int64]*entity.ProjectSettings
+	openTasks     map[int64]int // stands in for the task service's tasks table
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{
+		lastID:        make(map[string]int64),
+		projects:      make(map[int64]*entity.Project),
+		skills:        make(map[int64]*entity.Skill),
+		projectSkills: make(map[int64][]int64),
+		images:        make(map[int64]*entity.ProjectImage),
+		links:         make(map[int64]*entity.ProjectLink),
+		settings:      make(map[int64]*entity.ProjectSettings),
+		openTasks:     make(map[int64]int),
+	}
+}
+
+// SetOpenTasks sets how many of a project's tasks are not done, which
+// ProjectRepository.CountOpenTasks reports
+func (s *Store) SetOpenTasks(projectID int64, n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.openTasks[projectID] = n
+}
+
+// nextID emulates a SERIAL column; the caller holds mu
+func (s *Store) nextID(table string) int64 {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// paginate returns the given page of items, or nil when it is empty
+func paginate[T any](items []T, page, limit int) []T {
+	offset := (page - 1) * limit
+	if offset < 0 || offset >= len(items) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// ProjectRepository is an in-memory repository.ProjectRepository
+type ProjectRepository struct{ s *Store }
+
+// NewProjectRepository creates a ProjectRepository backed by s
+func NewProjectRepository(s *Store) *ProjectRepository { return &ProjectRepository{s: s} }
+
+var _ repository.ProjectRepository = (*ProjectRepository)(nil)
+
+// Create stores project and assigns its ID
+func (r *ProjectRepository) Create(ctx context.Context, project *entity.Project) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	project.ID = r.s.nextID("projects")
+	stored := *project
+	r.s.projects[project.ID] = &stored
+	return nil
+}
+
+// GetByID gets a copy of a project by ID without its related data
+func (r *ProjectRepository) GetByID(ctx context.Context, id int64) (*entity.Project, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	project, ok := r.s.projects[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *project
+	found.Skills, found.TechStack, found.Images, found.Links = nil, nil, nil, nil
+	return &found, nil
+}
+
+// GetByIDs gets copies of the projects that exist among ids, ordered by ID
+// rather than in the order asked for
+func (r *ProjectRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Project, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var found []*entity.Project
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		project, ok := r.s.projects[id]
+		if !ok || seen[id] {
+			continue
+		}
+		seen[id] = true
+		p := *project
+		p.Skills, p.TechStack, p.Images, p.Links = nil, nil, nil, nil
+		found = append(found, &p)
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	return found, nil
+}
+
+// Update overwrites the stored project; unknown IDs are ignored like an UPDATE matching no rows
+func (r *ProjectRepository) Update(ctx context.Context, project *entity.Project) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	project.UpdatedAt = time.Now()
+	if current, ok := r.s.projects[project.ID]; ok {
+		stored := *project
+		stored.CreatedAt = current.CreatedAt
+		r.s.projects[project.ID] = &stored
+	}
+	return nil
+}
+
+// Delete deletes a project along with its skills, tech, images and links
+func (r *ProjectRepository) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.projects, id)
+	delete(r.s.projectSkills, id)
+	delete(r.s.settings, id)
+	delete(r.s.openTasks, id)
+	tech := r.s.tech[:0]
+	for _, t := range r.s.tech {
+		if t.projectID != id {
+			tech = append(tech, t)
+		}
+	}
+	r.s.tech = tech
+	for imageID, image := range r.s.images {
+		if image.ProjectID == id {
+			delete(r.s.images, imageID)
+		}
+	}
+	for linkID, link := range r.s.links {
+		if link.ProjectID == id {
+			delete(r.s.links, linkID)
+		}
+	}
+	return nil
+}
+
+// List lists projects ordered by ID, optionally filtered by status. Archived
+// projects are only listed when asked for.
+func (r *ProjectRepository) List(ctx context.Context, page, limit int, status string, includeArchived bool) ([]*entity.Project, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var matched []*entity.Project
+	for _, p := range r.s.projects {
+		if status != "" && p.Status != status {
+			continue
+		}
+		if status == "" && !includeArchived && p.Status == entity.StatusArchived {
+			continue
+		}
+		found := *p
+		matched = append(matched, &found)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].ID < matched[j].ID })
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// CountOpenTasks reports the count set with Store.SetOpenTasks
+func (r *ProjectRepository) CountOpenTasks(ctx context.Context, projectID int64) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.openTasks[projectID], nil
+}
+
+// Clone stores project with copies of the skills, tech and links of the
+// project with sourceID
+func (r *ProjectRepository) Clone(ctx context.Context, sourceID int64, project *entity.Project) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	project.ID = r.s.nextID("projects")
+	stored := *project
+	stored.Skills, stored.TechStack, stored.Images, stored.Links = nil, nil, nil, nil
+	r.s.projects[project.ID] = &stored
+
+	if skills := r.s.projectSkills[sourceID]; len(skills) > 0 {
+		r.s.projectSkills[project.ID] = append([]int64(nil), skills...)
+	}
+	for _, t := range r.s.tech {
+		if t.projectID == sourceID {
+			r.s.tech = append(r.s.tech, projectTech{projectID: project.ID, techName: t.techName})
+		}
+	}
+	var links []*entity.ProjectLink
+	for _, link := range r.s.links {
+		if link.ProjectID == sourceID {
+			links = append(links, link)
+		}
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+	for _, link := range links {
+		copied := *link
+		copied.ID = r.s.nextID("project_links")
+		copied.ProjectID = project.ID
+		r.s.links[copied.ID] = &copied
+	}
+	return nil
+}
+
+// SkillRepository is an in-memory repository.SkillRepository
+type SkillRepository struct{ s *Store }
+
+// NewSkillRepository creates a SkillRepository backed by s
+func NewSkillRepository(s *Store) *SkillRepository { return &SkillRepository{s: s} }
+
+var _ repository.SkillRepository = (*SkillRepository)(nil)
+
+// Create stores skill; names are unique like the skills.name constraint
+func (r *SkillRepository) Create(ctx context.Context, skill *entity.Skill) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, s := range r.s.skills {
+		if s.Name == skill.Name {
+			return fmt.Errorf("skill %q already exists", skill.Name)
+		}
+	}
+	skill.ID = r.s.nextID("skills")
+	stored := *skill
+	r.s.skills[skill.ID] = &stored
+	return nil
+}
+
+// GetByID gets a skill by ID
+func (r *SkillRepository) GetByID(ctx context.Context, id int64) (*entity.Skill, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	skill, ok := r.s.skills[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *skill
+	return &found, nil
+}
+
+// GetByName gets a skill by its exact name
+func (r *SkillRepository) GetByName(ctx context.Context, name string) (*entity.Skill, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, skill := range r.s.skills {
+		if skill.Name == name {
+			found := *skill
+			return &found, nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+// List lists all skills ordered by name
+func (r *SkillRepository) List(ctx context.Context) ([]*entity.Skill, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var skills []*entity.Skill
+	for _, skill := range r.s.skills {
+		found := *skill
+		skills = append(skills, &found)
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Name < skills[j].Name })
+	return skills, nil
+}
+
+// ProjectSkillRepository is an in-memory repository.ProjectSkillRepository
+type ProjectSkillRepository struct{ s *Store }
+
+// NewProjectSkillRepository creates a ProjectSkillRepository backed by s
+func NewProjectSkillRepository(s *Store) *ProjectSkillRepository {
+	return &ProjectSkillRepository{s: s}
+}
+
+var _ repository.ProjectSkillRepository = (*ProjectSkillRepository)(nil)
+
+// Add adds a skill to a project; adding it twice does nothing
+func (r *ProjectSkillRepository) Add(ctx context.Context, projectID, skillID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, id := range r.s.projectSkills[projectID] {
+		if id == skillID {
+			return nil
+		}
+	}
+	r.s.projectSkills[projectID] = append(r.s.projectSkills[projectID], skillID)
+	return nil
+}
+
+// Remove removes a skill from a project
+func (r *ProjectSkillRepository) Remove(ctx context.Context, projectID, skillID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	ids := r.s.projectSkills[projectID]
+	for i, id := range ids {
+		if id == skillID {
+			r.s.projectSkills[projectID] = append(ids[:i:i], ids[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// GetByProjectID gets all skills of a project ordered by name
+func (r *ProjectSkillRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.Skill, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var skills []*entity.Skill
+	for _, id := range r.s.projectSkills[projectID] {
+		if skill, ok := r.s.skills[id]; ok {
+			found := *skill
+			skills = append(skills, &found)
+		}
+	}
+	sort.Slice(skills, func(i, j int) bool { return skills[i].Name < skills[j].Name })
+	return skills, nil
+}
+
+// GetByProjectIDs gets the skills of several projects, keyed by project
+func (r *ProjectSkillRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.Skill, error) {
+	skills := make(map[int64][]*entity.Skill)
+	for _, id := range projectIDs {
+		found, _ := r.GetByProjectID(ctx, id)
+		if len(found) > 0 {
+			skills[id] = found
+		}
+	}
+	return skills, nil
+}
+
+// ProjectTechRepository is an in-memory repository.ProjectTechRepository
+type ProjectTechRepository struct{ s *Store }
+
+// NewProjectTechRepository creates a ProjectTechRepository backed by s
+func NewProjectTechRepository(s *Store) *ProjectTechRepository {
+	return &ProjectTechRepository{s: s}
+}
+
+var _ repository.ProjectTechRepository = (*ProjectTechRepository)(nil)
+
+// Add adds a technology to a project; adding it twice does nothing
+func (r *ProjectTechRepository) Add(ctx context.Context, projectID int64, techName string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	entry := projectTech{projectID: projectID, techName: techName}
+	for _, t := range r.s.tech {
+		if t == entry {
+			return nil
+		}
+	}
+	r.s.tech = append(r.s.tech, entry)
+	return nil
+}
+
+// Remove removes a technology from a project
+func (r *ProjectTechRepository) Remove(ctx context.Context, projectID int64, techName string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	entry := projectTech{projectID: projectID, techName: techName}
+	for i, t := range r.s.tech {
+		if t == entry {
+			r.s.tech = append(r.s.tech[:i:i], r.s.tech[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// GetByProjectID gets all technologies of a project ordered by name
+func (r *ProjectTechRepository) GetByProjectID(ctx context.Context, projectID int64) ([]string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var techs []string
+	for _, t := range r.s.tech {
+		if t.projectID == projectID {
+			techs = append(techs, t.techName)
+		}
+	}
+	sort.Strings(techs)
+	return techs, nil
+}
+
+// GetByProjectIDs gets the technologies of several projects, keyed by project
+func (r *ProjectTechRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]string, error) {
+	techs := make(map[int64][]string)
+	for _, id := range projectIDs {
+		found, _ := r.GetByProjectID(ctx, id)
+		if len(found) > 0 {
+			techs[id] = found
+		}
+	}
+	return techs, nil
+}
+
+// ProjectImageRepository is an in-memory repository.ProjectImageRepository
+type ProjectImageRepository struct{ s *Store }
+
+// NewProjectImageRepository creates a ProjectImageRepository backed by s
+func NewProjectImageRepository(s *Store) *ProjectImageRepository {
+	return &ProjectImageRepository{s: s}
+}
+
+var _ repository.ProjectImageRepository = (*ProjectImageRepository)(nil)
+
+// Add stores image and assigns its ID
+func (r *ProjectImageRepository) Add(ctx context.Context, image *entity.ProjectImage) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	image.ID = r.s.nextID("project_images")
+	stored := *image
+	r.s.images[image.ID] = &stored
+	return nil
+}
+
+// GetByID gets an image by ID
+func (r *ProjectImageRepository) GetByID(ctx context.Context, id int64) (*entity.ProjectImage, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	image, ok := r.s.images[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *image
+	return &found, nil
+}
+
+// Remove removes an image
+func (r *ProjectImageRepository) Remove(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.images, id)
+	return nil
+}
+
+// GetByProjectID gets all images of a project ordered by ID
+func (r *ProjectImageRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.ProjectImage, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var images []*entity.ProjectImage
+	for _, image := range r.s.images {
+		if image.ProjectID == projectID {
+			found := *image
+			images = append(images, &found)
+		}
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].ID < images[j].ID })
+	return images, nil
+}
+
+// GetByProjectIDs gets the images of several projects, keyed by project
+func (r *ProjectImageRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectImage, error) {
+	images := make(map[int64][]*entity.ProjectImage)
+	for _, id := range projectIDs {
+		found, _ := r.GetByProjectID(ctx, id)
+		if len(found) > 0 {
+			images[id] = found
+		}
+	}
+	return images, nil
+}
+
+// ProjectLinkRepository is an in-memory repository.ProjectLinkRepository
+type ProjectLinkRepository struct{ s *Store }
+
+// NewProjectLinkRepository creates a ProjectLinkRepository backed by s
+func NewProjectLinkRepository(s *Store) *ProjectLinkRepository {
+	return &ProjectLinkRepository{s: s}
+}
+
+var _ repository.ProjectLinkRepository = (*ProjectLinkRepository)(nil)
+
+// Add stores link and assigns its ID
+func (r *ProjectLinkRepository) Add(ctx context.Context, link *entity.ProjectLink) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	link.ID = r.s.nextID("project_links")
+	stored := *link
+	r.s.links[link.ID] = &stored
+	return nil
+}
+
+// GetByID gets a link by ID
+func (r *ProjectLinkRepository) GetByID(ctx context.Context, id int64) (*entity.ProjectLink, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	link, ok := r.s.links[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *link
+	return &found, nil
+}
+
+// Remove removes a link
+func (r *ProjectLinkRepository) Remove(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.links, id)
+	return nil
+}
+
+// GetByProjectID gets a project's links ordered by ID, optionally filtered by type
+func (r *ProjectLinkRepository) GetByProjectID(ctx context.Context, projectID int64, linkType string) ([]*entity.ProjectLink, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var links []*entity.ProjectLink
+	for _, link := range r.s.links {
+		if link.ProjectID != projectID || (linkType != "" && link.LinkType != linkType) {
+			continue
+		}
+		found := *link
+		links = append(links, &found)
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+	return links, nil
+}
+
+// GetByProjectIDs gets the links of several projects, keyed by project
+func (r *ProjectLinkRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectLink, error) {
+	links := make(map[int64][]*entity.ProjectLink)
+	for _, id := range projectIDs {
+		found, _ := r.GetByProjectID(ctx, id, "")
+		if len(found) > 0 {
+			links[id] = found
+		}
+	}
+	return links, nil
+}
+
+// ProjectSettingsRepository is an in-memory repository.ProjectSettingsRepository
+type ProjectSettingsRepository struct{ s *Store }
+
+// NewProjectSettingsRepository creates a ProjectSettingsRepository backed by s
+func NewProjectSettingsRepository(s *Store) *ProjectSettingsRepository {
+	return &ProjectSettingsRepository{s: s}
+}
+
+var _ repository.ProjectSettingsRepository = (*ProjectSettingsRepository)(nil)
+
+// Get gets a copy of a project's settings, or empty settings if it has none
+func (r *ProjectSettingsRepository) Get(ctx context.Context, projectID int64) (*entity.ProjectSettings, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if settings, ok := r.s.settings[projectID]; ok {
+		found := *settings
+		return &found, nil
+	}
+	return &entity.ProjectSettings{ProjectID: projectID}, nil
+}
+
+// Set stores a copy of settings, replacing the project's previous settings
+func (r *ProjectSettingsRepository) Set(ctx context.Context, settings *entity.ProjectSettings) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored := *settings
+	r.s.settings[settings.ProjectID] = &stored
+	return nil
+}
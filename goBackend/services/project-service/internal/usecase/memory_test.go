@@ -0,0 +1,420 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/portfolio/project-service/internal/domain/entity"
+	"github.com/portfolio/project-service/internal/testutil"
+	"github.com/portfolio/shared/pagination"
+	"github.com/portfolio/shared/webhook"
+)
+
+// newMemoryProjectUseCase wires a ProjectUseCase to in-memory repositories sharing one store
+func newMemoryProjectUseCase(store *testutil.Store) *ProjectUseCase {
+	return NewProjectUseCase(
+		testutil.NewProjectRepository(store),
+		testutil.NewSkillRepository(store),
+		testutil.NewProjectSkillRepository(store),
+		testutil.NewProjectTechRepository(store),
+		testutil.NewProjectImageRepository(store),
+		testutil.NewProjectLinkRepository(store),
+		"",
+		pagination.Sizes{},
+	)
+}
+
+func TestProjectUseCase_Memory_GetProjectLoadsRelations(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryProjectUseCase(store)
+
+	project, err := uc.CreateProject(ctx, " Portfolio ", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	skill, _ := NewSkillUseCase(testutil.NewSkillRepository(store)).CreateSkill(ctx, "Go")
+	NewProjectSkillUseCase(testutil.NewProjectSkillRepository(store)).AddSkill(ctx, project.ID, skill.ID)
+	tech := NewTechUseCase(testutil.NewProjectTechRepository(store))
+	tech.AddTech(ctx, project.ID, "gRPC")
+	tech.AddTech(ctx, project.ID, "gRPC")
+	if _, err := NewLinkUseCase(testutil.NewProjectLinkRepository(store)).AddLink(ctx, project.ID, "https://github.com/example/portfolio", entity.LinkTypeGitHub); err != nil {
+		t.Fatalf("AddLink() error = %v", err)
+	}
+
+	got, err := uc.GetProject(ctx, project.ID)
+	if err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	if got.Name != "Portfolio" || got.Status != entity.StatusActive {
+		t.Errorf("project = %q (%s), want Portfolio (active)", got.Name, got.Status)
+	}
+	if len(got.Skills) != 1 || got.Skills[0].Name != "Go" {
+		t.Errorf("skills = %v, want [Go]", got.Skills)
+	}
+	if len(got.TechStack) != 1 || got.TechStack[0] != "gRPC" {
+		t.Errorf("tech stack = %v, want [gRPC] once", got.TechStack)
+	}
+	if len(got.Links) != 1 || got.Links[0].LinkType != entity.LinkTypeGitHub {
+		t.Errorf("links = %v, want one github link", got.Links)
+	}
+}
+
+func TestProjectUseCase_Memory_ListAndDelete(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryProjectUseCase(store)
+	links := NewLinkUseCase(testutil.NewProjectLinkRepository(store))
+
+	first, _ := uc.CreateProject(ctx, "First", "", "", nil, nil)
+	uc.CreateProject(ctx, "Second", "", entity.StatusArchived, nil, nil)
+	third, _ := uc.CreateProject(ctx, "Third", "", "", nil, nil)
+	links.AddLink(ctx, first.ID, "https://example.com", entity.LinkTypeLive)
+
+	projects, total, err := uc.ListProjects(ctx, 1, 10, entity.StatusActive, false, nil)
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if total != 2 || len(projects) != 2 || projects[0].ID != first.ID || projects[1].ID != third.ID {
+		t.Errorf("active projects = %d of %d, want First and Third in id order", len(projects), total)
+	}
+	if projects, total, _ := uc.ListProjects(ctx, 2, 2, "", true, nil); total != 3 || len(projects) != 1 || projects[0].ID != third.ID {
+		t.Errorf("page 2 = %d projects of %d, want only Third", len(projects), total)
+	}
+
+	if err := uc.DeleteProject(ctx, first.ID); err != nil {
+		t.Fatalf("DeleteProject() error = %v", err)
+	}
+	if _, err := uc.GetProject(ctx, first.ID); err != ErrProjectNotFound {
+		t.Errorf("GetProject(deleted) error = %v, want ErrProjectNotFound", err)
+	}
+	if remaining, _ := links.GetLinks(ctx, first.ID, ""); len(remaining) != 0 {
+		t.Errorf("deleted project still has %d links", len(remaining))
+	}
+}
+
+func TestProjectUseCase_Memory_ArchiveHidesFromList(t *testing.T) {
+	ctx := context.Background()
+	uc := newMemoryProjectUseCase(testutil.NewStore())
+
+	kept, _ := uc.CreateProject(ctx, "Kept", "", "", nil, nil)
+	old, _ := uc.CreateProject(ctx, "Old", "", "", nil, nil)
+	done, _ := uc.CreateProject(ctx, "Done", "", entity.StatusCompleted, nil, nil)
+
+	archived, err := uc.Archive(ctx, old.ID)
+	if err != nil {
+		t.Fatalf("Archive() error = %v", err)
+	}
+	if archived.Status != entity.StatusArchived {
+		t.Errorf("archived status = %s, want %s", archived.Status, entity.StatusArchived)
+	}
+	if _, err := uc.Archive(ctx, old.ID); err != nil {
+		t.Errorf("Archive(archived) error = %v, want nil", err)
+	}
+
+	projects, total, _ := uc.ListProjects(ctx, 1, 10, "", false, nil)
+	if total != 2 || len(projects) != 2 || projects[0].ID != kept.ID || projects[1].ID != done.ID {
+		t.Errorf("default list = %d projects of %d, want Kept and Done", len(projects), total)
+	}
+	if projects, total, _ := uc.ListProjects(ctx, 1, 10, "", true, nil); total != 3 || projects[1].ID != old.ID {
+		t.Errorf("list with archived = %d projects of %d, want all three", len(projects), total)
+	}
+	if projects, total, _ := uc.ListProjects(ctx, 1, 10, entity.StatusArchived, false, nil); total != 1 || projects[0].ID != old.ID {
+		t.Errorf("archived list = %d projects of %d, want only Old", len(projects), total)
+	}
+
+	restored, err := uc.Unarchive(ctx, old.ID)
+	if err != nil {
+		t.Fatalf("Unarchive() error = %v", err)
+	}
+	if restored.Status != entity.StatusActive {
+		t.Errorf("restored status = %s, want %s", restored.Status, entity.StatusActive)
+	}
+	if _, total, _ := uc.ListProjects(ctx, 1, 10, "", false, nil); total != 3 {
+		t.Errorf("default list after unarchive has %d projects, want 3", total)
+	}
+
+	if _, err := uc.Unarchive(ctx, done.ID); err != ErrNotArchived {
+		t.Errorf("Unarchive(completed) error = %v, want ErrNotArchived", err)
+	}
+	if _, err := uc.Archive(ctx, 999); err != ErrProjectNotFound {
+		t.Errorf("Archive(missing) error = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func TestProjectUseCase_Memory_ListIncludesRelations(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryProjectUseCase(store)
+
+	first, _ := uc.CreateProject(ctx, "First", "", "", nil, nil)
+	second, _ := uc.CreateProject(ctx, "Second", "", "", nil, nil)
+	skill, _ := NewSkillUseCase(testutil.NewSkillRepository(store)).CreateSkill(ctx, "Go")
+	NewProjectSkillUseCase(testutil.NewProjectSkillRepository(store)).AddSkill(ctx, second.ID, skill.ID)
+	tech := NewTechUseCase(testutil.NewProjectTechRepository(store))
+	tech.AddTech(ctx, first.ID, "React")
+	tech.AddTech(ctx, second.ID, "gRPC")
+	NewLinkUseCase(testutil.NewProjectLinkRepository(store)).AddLink(ctx, first.ID, "https://github.com/example/first", entity.LinkTypeGitHub)
+
+	// Projects are lean by default
+	projects, _, err := uc.ListProjects(ctx, 1, 10, "", false, nil)
+	if err != nil || len(projects) != 2 || projects[0].TechStack != nil || projects[1].Skills != nil {
+		t.Fatalf("ListProjects() = %+v, %v; want both projects without relations", projects, err)
+	}
+
+	projects, _, err = uc.ListProjects(ctx, 1, 10, "", false, []string{entity.RelationSkills, entity.RelationTech})
+	if err != nil {
+		t.Fatalf("ListProjects(include) error = %v", err)
+	}
+	if got := projects[0]; len(got.Skills) != 0 || !reflect.DeepEqual(got.TechStack, []string{"React"}) || got.Links != nil {
+		t.Errorf("first = skills %v, tech %v, links %v; want only React", got.Skills, got.TechStack, got.Links)
+	}
+	if got := projects[1]; len(got.Skills) != 1 || got.Skills[0].Name != "Go" || !reflect.DeepEqual(got.TechStack, []string{"gRPC"}) {
+		t.Errorf("second = skills %v, tech %v; want Go and gRPC", got.Skills, got.TechStack)
+	}
+
+	if _, _, err := uc.ListProjects(ctx, 1, 10, "", false, []string{"tasks"}); err != ErrInvalidInclude {
+		t.Errorf("ListProjects(include=tasks) error = %v, want ErrInvalidInclude", err)
+	}
+}
+
+func TestProjectUseCase_Memory_CompleteBlockedByOpenTasks(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryProjectUseCase(store)
+
+	project, _ := uc.CreateProject(ctx, "Portfolio", "", "", nil, nil)
+	store.SetOpenTasks(project.ID, 2)
+
+	_, err := uc.Complete(ctx, project.ID, false)
+	var openErr *OpenTasksError
+	if !errors.As(err, &openErr) || openErr.OpenTasks != 2 {
+		t.Fatalf("Complete() error = %v, want OpenTasksError with 2 open tasks", err)
+	}
+	got, _ := uc.GetProject(ctx, project.ID)
+	if got.Status != entity.StatusActive || got.EndDate != nil {
+		t.Errorf("project = %s ending %v, want it left active", got.Status, got.EndDate)
+	}
+
+	store.SetOpenTasks(project.ID, 0)
+	completed, err := uc.Complete(ctx, project.ID, false)
+	if err != nil {
+		t.Fatalf("Complete() with all tasks done error = %v", err)
+	}
+	if completed.Status != entity.StatusCompleted || completed.EndDate == nil {
+		t.Errorf("project = %s ending %v, want completed with an end date", completed.Status, completed.EndDate)
+	}
+
+	if _, err := uc.Complete(ctx, 999, false); err != ErrProjectNotFound {
+		t.Errorf("Complete(missing) error = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func TestProjectUseCase_Memory_CompleteForced(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryProjectUseCase(store)
+
+	planned := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	project, _ := uc.CreateProject(ctx, "Portfolio", "", "", nil, &planned)
+	store.SetOpenTasks(project.ID, 3)
+
+	completed, err := uc.Complete(ctx, project.ID, true)
+	if err != nil {
+		t.Fatalf("Complete(force) error = %v", err)
+	}
+	if completed.Status != entity.StatusCompleted {
+		t.Errorf("status = %s, want completed", completed.Status)
+	}
+	if completed.EndDate == nil || !completed.EndDate.Equal(planned) {
+		t.Errorf("end date = %v, want the existing %v kept", completed.EndDate, planned)
+	}
+}
+
+func TestProjectUseCase_Memory_GetProjects(t *testing.T) {
+	ctx := context.Background()
+	uc := newMemoryProjectUseCase(testutil.NewStore())
+
+	first, _ := uc.CreateProject(ctx, "First", "", "", nil, nil)
+	second, _ := uc.CreateProject(ctx, "Second", "", "", nil, nil)
+	third, _ := uc.CreateProject(ctx, "Third", "", "", nil, nil)
+
+	projects, err := uc.GetProjects(ctx, []int64{third.ID, 999, first.ID, third.ID, second.ID})
+	if err != nil {
+		t.Fatalf("GetProjects() error = %v", err)
+	}
+	var got []int64
+	for _, p := range projects {
+		got = append(got, p.ID)
+	}
+	if len(got) != 3 || got[0] != third.ID || got[1] != first.ID || got[2] != second.ID {
+		t.Errorf("ids = %v, want %d, %d, %d in the order asked for", got, third.ID, first.ID, second.ID)
+	}
+
+	if projects, err := uc.GetProjects(ctx, []int64{998, 999}); err != nil || len(projects) != 0 {
+		t.Errorf("GetProjects(missing) = %d projects, %v; want none and no error", len(projects), err)
+	}
+	if _, err := uc.GetProjects(ctx, []int64{0, -1}); err != ErrNoProjectIDs {
+		t.Errorf("GetProjects(invalid) error = %v, want ErrNoProjectIDs", err)
+	}
+	tooMany := make([]int64, MaxBatchProjects+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	if _, err := uc.GetProjects(ctx, tooMany); err != ErrTooManyIDs {
+		t.Errorf("GetProjects(%d ids) error = %v, want ErrTooManyIDs", len(tooMany), err)
+	}
+}
+
+func TestProjectUseCase_Memory_CloneProject(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryProjectUseCase(store)
+	start := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	source, err := uc.CreateProject(ctx, "Portfolio", "Personal site", entity.StatusOnHold, &start, nil)
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	skill, _ := NewSkillUseCase(testutil.NewSkillRepository(store)).CreateSkill(ctx, "Go")
+	NewProjectSkillUseCase(testutil.NewProjectSkillRepository(store)).AddSkill(ctx, source.ID, skill.ID)
+	tech := NewTechUseCase(testutil.NewProjectTechRepository(store))
+	tech.AddTech(ctx, source.ID, "gRPC")
+	tech.AddTech(ctx, source.ID, "PostgreSQL")
+	links := NewLinkUseCase(testutil.NewProjectLinkRepository(store))
+	links.AddLink(ctx, source.ID, "https://github.com/example/portfolio", entity.LinkTypeGitHub)
+	links.AddLink(ctx, source.ID, "https://example.com", entity.LinkTypeLive)
+	NewImageUseCase(testutil.NewProjectImageRepository(store)).AddImage(ctx, source.ID, "https://example.com/shot.png", "")
+
+	clone, err := uc.CloneProject(ctx, source.ID, "")
+	if err != nil {
+		t.Fatalf("CloneProject() error = %v", err)
+	}
+	if clone.ID == source.ID || clone.Name != "Portfolio (copy)" || clone.Description != "Personal site" {
+		t.Errorf("clone = %d %q %q, want a new project named Portfolio (copy)", clone.ID, clone.Name, clone.Description)
+	}
+	if clone.Status != entity.StatusActive || clone.StartDate != nil {
+		t.Errorf("clone status = %s, start date = %v, want active without dates", clone.Status, clone.StartDate)
+	}
+	if len(clone.Skills) != 1 || clone.Skills[0].ID != skill.ID {
+		t.Errorf("clone skills = %v, want [Go]", clone.Skills)
+	}
+	if len(clone.TechStack) != 2 || clone.TechStack[0] != "PostgreSQL" || clone.TechStack[1] != "gRPC" {
+		t.Errorf("clone tech stack = %v, want [PostgreSQL gRPC]", clone.TechStack)
+	}
+	if len(clone.Links) != 2 || clone.Links[0].ProjectID != clone.ID || clone.Links[0].LinkType != entity.LinkTypeGitHub {
+		t.Errorf("clone links = %v, want copies of both links", clone.Links)
+	}
+	if len(clone.Images) != 0 {
+		t.Errorf("clone images = %v, want none", clone.Images)
+	}
+
+	// Changing the clone leaves the original alone
+	tech.RemoveTech(ctx, clone.ID, "gRPC")
+	links.RemoveLink(ctx, clone.Links[0].ID)
+	got, err := uc.GetProject(ctx, source.ID)
+	if err != nil {
+		t.Fatalf("GetProject(original) error = %v", err)
+	}
+	if got.Name != "Portfolio" || got.Status != entity.StatusOnHold || got.StartDate == nil {
+		t.Errorf("original = %q (%s), start %v, want it unchanged", got.Name, got.Status, got.StartDate)
+	}
+	if len(got.Skills) != 1 || len(got.TechStack) != 2 || len(got.Links) != 2 || len(got.Images) != 1 {
+		t.Errorf("original relations = %d skills, %d tech, %d links, %d images, want 1, 2, 2, 1",
+			len(got.Skills), len(got.TechStack), len(got.Links), len(got.Images))
+	}
+
+	named, err := uc.CloneProject(ctx, source.ID, "  Portfolio v2 ")
+	if err != nil || named.Name != "Portfolio v2" {
+		t.Errorf("CloneProject(named) = %v, %v, want Portfolio v2", named, err)
+	}
+	if _, err := uc.CloneProject(ctx, 999, ""); !errors.Is(err, ErrProjectNotFound) {
+		t.Errorf("CloneProject(missing) error = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func TestSettingsUseCase_Memory_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	projects := newMemoryProjectUseCase(store)
+	uc := NewSettingsUseCase(testutil.NewProjectRepository(store), testutil.NewProjectSettingsRepository(store))
+
+	project, _ := projects.CreateProject(ctx, "Portfolio", "", "", nil, nil)
+	if settings, err := uc.GetSettings(ctx, project.ID); err != nil || settings.DefaultTaskStatus != "" || settings.DefaultTaskPriority != 0 {
+		t.Errorf("GetSettings() = %+v, %v, want empty settings", settings, err)
+	}
+
+	if _, err := uc.UpdateSettings(ctx, project.ID, "InProgress", 1); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+	settings, err := uc.GetSettings(ctx, project.ID)
+	if err != nil || settings.DefaultTaskStatus != "InProgress" || settings.DefaultTaskPriority != 1 {
+		t.Errorf("GetSettings() = %+v, %v, want InProgress at priority 1", settings, err)
+	}
+
+	tests := []struct {
+		name      string
+		projectID int64
+		status    string
+		priority  int
+		want      error
+	}{
+		{"Unknown status", project.ID, "Blocked", 0, ErrInvalidTaskStatus},
+		{"Priority out of range", project.ID, "", 5, ErrInvalidTaskPriority},
+		{"Missing project", 999, "", 0, ErrProjectNotFound},
+	}
+	for _, tt := range tests {
+		if _, err := uc.UpdateSettings(ctx, tt.projectID, tt.status, tt.priority); err != tt.want {
+			t.Errorf("%s: UpdateSettings() error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+
+	// Deleting the project drops its settings
+	projects.DeleteProject(ctx, project.ID)
+	if _, err := uc.GetSettings(ctx, project.ID); err != ErrProjectNotFound {
+		t.Errorf("GetSettings(deleted) error = %v, want ErrProjectNotFound", err)
+	}
+}
+
+func TestWebhookUseCase_Memory_CreateWebhook(t *testing.T) {
+	ctx := context.Background()
+	uc := NewWebhookUseCase(webhook.NewMemory(), pagination.Sizes{})
+
+	tests := []struct {
+		name       string
+		url        string
+		eventTypes []string
+		wantErr    error
+	}{
+		{"Valid", "https://hooks.example.com/tasks", []string{webhook.TaskCreated, webhook.TaskCreated, webhook.ProjectDeleted}, nil},
+		{"Not http", "ftp://hooks.example.com", []string{webhook.TaskCreated}, ErrInvalidURL},
+		{"Loopback", "http://127.0.0.1:8080/hook", []string{webhook.TaskCreated}, ErrInternalWebhookURL},
+		{"Metadata service", "http://169.254.169.254/latest", []string{webhook.TaskCreated}, ErrInternalWebhookURL},
+		{"No event types", "https://hooks.example.com", nil, ErrEventTypesRequired},
+		{"Unknown event type", "https://hooks.example.com", []string{"task.exploded"}, ErrInvalidEventType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, err := uc.CreateWebhook(ctx, tt.url, "", tt.eventTypes)
+			if err != tt.wantErr {
+				t.Fatalf("CreateWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(sub.Secret) != 64 {
+				t.Errorf("generated secret %q, want 64 hex characters", sub.Secret)
+			}
+			if want := []string{webhook.TaskCreated, webhook.ProjectDeleted}; !reflect.DeepEqual(sub.EventTypes, want) {
+				t.Errorf("EventTypes = %v, want %v", sub.EventTypes, want)
+			}
+		})
+	}
+
+	if err := uc.DeleteWebhook(ctx, 42); err != ErrWebhookNotFound {
+		t.Errorf("DeleteWebhook(missing) error = %v, want ErrWebhookNotFound", err)
+	}
+}
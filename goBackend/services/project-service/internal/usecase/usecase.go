@@ -3,10 +3,14 @@ package usecase
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/portfolio/project-service/internal/domain/entity"
 	"github.com/portfolio/project-service/internal/domain/repository"
+	"github.com/portfolio/shared/pagination"
 )
 
 var (
@@ -14,8 +18,50 @@ var (
 	ErrSkillNotFound   = errors.New("skill not found")
 	ErrImageNotFound   = errors.New("image not found")
 	ErrLinkNotFound    = errors.New("link not found")
+	ErrInvalidLinkType = errors.New("invalid link type")
+	ErrInvalidStatus   = errors.New("invalid status")
+	ErrNotArchived     = errors.New("project is not archived")
+
+	ErrInvalidTaskStatus   = errors.New("invalid default task status")
+	ErrInvalidTaskPriority = errors.New("invalid default task priority")
+
+	ErrNoProjectIDs   = errors.New("no project ids given")
+	ErrTooManyIDs     = errors.New("too many project ids")
+	ErrInvalidInclude = errors.New("invalid relation to include")
+
+	ErrNameRequired     = errors.New("name is required")
+	ErrTechNameRequired = errors.New("tech name is required")
+	ErrInvalidURL       = errors.New("url must be an absolute http or https URL")
+	ErrInvalidDateRange = errors.New("end date must not be before start date")
 )
 
+// OpenTasksError is returned when completing a project that still has tasks not done
+type OpenTasksError struct {
+	OpenTasks int
+}
+
+func (e *OpenTasksError) Error() string {
+	return fmt.Sprintf("project has %d open tasks", e.OpenTasks)
+}
+
+// checkDateRange rejects an end date before the start date; either may be nil
+func checkDateRange(startDate, endDate *time.Time) error {
+	if startDate != nil && endDate != nil && endDate.Before(*startDate) {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// normalizeURL trims raw and checks that it is an absolute http(s) URL with a host
+func normalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrInvalidURL
+	}
+	return raw, nil
+}
+
 // ProjectUseCase handles project business logic
 type ProjectUseCase struct {
 	projectRepo      repository.ProjectRepository
@@ -24,9 +70,13 @@ type ProjectUseCase struct {
 	techRepo         repository.ProjectTechRepository
 	imageRepo        repository.ProjectImageRepository
 	linkRepo         repository.ProjectLinkRepository
+	defaultStatus    string
+	pages            pagination.Sizes
 }
 
-// NewProjectUseCase creates a new ProjectUseCase
+// NewProjectUseCase creates a new ProjectUseCase. Projects created without a
+// status get defaultStatus, or active when it is empty, and are listed in
+// pages of the given sizes.
 func NewProjectUseCase(
 	projectRepo repository.ProjectRepository,
 	skillRepo repository.SkillRepository,
@@ -34,7 +84,12 @@ func NewProjectUseCase(
 	techRepo repository.ProjectTechRepository,
 	imageRepo repository.ProjectImageRepository,
 	linkRepo repository.ProjectLinkRepository,
+	defaultStatus string,
+	pages pagination.Sizes,
 ) *ProjectUseCase {
+	if defaultStatus == "" {
+		defaultStatus = entity.StatusActive
+	}
 	return &ProjectUseCase{
 		projectRepo:      projectRepo,
 		skillRepo:        skillRepo,
@@ -42,24 +97,55 @@ func NewProjectUseCase(
 		techRepo:         techRepo,
 		imageRepo:        imageRepo,
 		linkRepo:         linkRepo,
+		defaultStatus:    defaultStatus,
+		pages:            pages,
 	}
 }
 
+// PageLimit is the page size ListProjects applies for limit
+func (uc *ProjectUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
 // CreateProject creates a new project
 func (uc *ProjectUseCase) CreateProject(ctx context.Context, name, description, status string, startDate, endDate *time.Time) (*entity.Project, error) {
-	project := entity.NewProject(name, description, status, startDate, endDate)
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+	if status == "" {
+		status = uc.defaultStatus
+	}
+	if !entity.IsValidStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+	if err := checkDateRange(startDate, endDate); err != nil {
+		return nil, err
+	}
+
+	project := entity.NewProject(name, strings.TrimSpace(description), status, startDate, endDate)
 	if err := uc.projectRepo.Create(ctx, project); err != nil {
 		return nil, err
 	}
 	return project, nil
 }
 
-// GetProject retrieves a project by ID with all related data
-func (uc *ProjectUseCase) GetProject(ctx context.Context, id int64) (*entity.Project, error) {
+// GetProjectBasic retrieves a project by ID without its related data, in a
+// single query
+func (uc *ProjectUseCase) GetProjectBasic(ctx context.Context, id int64) (*entity.Project, error) {
 	project, err := uc.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrProjectNotFound
 	}
+	return project, nil
+}
+
+// GetProject retrieves a project by ID with all related data
+func (uc *ProjectUseCase) GetProject(ctx context.Context, id int64) (*entity.Project, error) {
+	project, err := uc.GetProjectBasic(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
 	// Load related data
 	skills, _ := uc.projectSkillRepo.GetByProjectID(ctx, id)
@@ -71,24 +157,69 @@ func (uc *ProjectUseCase) GetProject(ctx context.Context, id int64) (*entity.Pro
 	images, _ := uc.imageRepo.GetByProjectID(ctx, id)
 	project.Images = images
 
-	links, _ := uc.linkRepo.GetByProjectID(ctx, id)
+	links, _ := uc.linkRepo.GetByProjectID(ctx, id, "")
 	project.Links = links
 
 	return project, nil
 }
 
+// MaxBatchProjects is the most projects GetProjects fetches at once
+const MaxBatchProjects = 100
+
+// GetProjects gets several projects by ID without their related data. They
+// come back in the order asked for; duplicates and missing IDs are skipped.
+func (uc *ProjectUseCase) GetProjects(ctx context.Context, ids []int64) ([]*entity.Project, error) {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		return nil, ErrNoProjectIDs
+	}
+	if len(unique) > MaxBatchProjects {
+		return nil, ErrTooManyIDs
+	}
+
+	found, err := uc.projectRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*entity.Project, len(found))
+	for _, p := range found {
+		byID[p.ID] = p
+	}
+	projects := make([]*entity.Project, 0, len(found))
+	for _, id := range unique {
+		if p, ok := byID[id]; ok {
+			projects = append(projects, p)
+		}
+	}
+	return projects, nil
+}
+
 // UpdateProject updates a project
+// An empty name leaves the current one; a name of only whitespace is rejected.
+// The date range is checked against the stored dates a request doesn't change.
 func (uc *ProjectUseCase) UpdateProject(ctx context.Context, id int64, name, description, status string, startDate, endDate *time.Time) (*entity.Project, error) {
+	if name != "" && strings.TrimSpace(name) == "" {
+		return nil, ErrNameRequired
+	}
+
 	project, err := uc.projectRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrProjectNotFound
 	}
 
 	if name != "" {
-		project.Name = name
+		project.Name = strings.TrimSpace(name)
 	}
 	if description != "" {
-		project.Description = description
+		project.Description = strings.TrimSpace(description)
 	}
 	if status != "" {
 		project.Status = status
@@ -99,6 +230,9 @@ func (uc *ProjectUseCase) UpdateProject(ctx context.Context, id int64, name, des
 	if endDate != nil {
 		project.EndDate = endDate
 	}
+	if err := checkDateRange(project.StartDate, project.EndDate); err != nil {
+		return nil, err
+	}
 	project.UpdatedAt = time.Now()
 
 	if err := uc.projectRepo.Update(ctx, project); err != nil {
@@ -108,20 +242,231 @@ func (uc *ProjectUseCase) UpdateProject(ctx context.Context, id int64, name, des
 	return uc.GetProject(ctx, id)
 }
 
+// Complete marks a project completed. It fails with an OpenTasksError
+// while any of its tasks is not done, unless force is set. The end date
+// records when it was completed unless one was already set.
+func (uc *ProjectUseCase) Complete(ctx context.Context, id int64, force bool) (*entity.Project, error) {
+	project, err := uc.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+
+	if !force {
+		open, err := uc.projectRepo.CountOpenTasks(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if open > 0 {
+			return nil, &OpenTasksError{OpenTasks: open}
+		}
+	}
+
+	now := time.Now()
+	project.Status = entity.StatusCompleted
+	if project.EndDate == nil {
+		project.EndDate = &now
+	}
+	project.UpdatedAt = now
+
+	if err := uc.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return uc.GetProject(ctx, id)
+}
+
+// Archive archives a project, hiding it from default project lists while
+// keeping its tasks and history. Archiving an archived project changes nothing.
+func (uc *ProjectUseCase) Archive(ctx context.Context, id int64) (*entity.Project, error) {
+	project, err := uc.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+	if project.Status == entity.StatusArchived {
+		return uc.GetProject(ctx, id)
+	}
+
+	project.Status = entity.StatusArchived
+	project.UpdatedAt = time.Now()
+	if err := uc.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return uc.GetProject(ctx, id)
+}
+
+// Unarchive makes an archived project active again. Projects that aren't
+// archived fail with ErrNotArchived, so a completed or paused project isn't
+// reopened by mistake.
+func (uc *ProjectUseCase) Unarchive(ctx context.Context, id int64) (*entity.Project, error) {
+	project, err := uc.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+	if project.Status != entity.StatusArchived {
+		return nil, ErrNotArchived
+	}
+
+	project.Status = entity.StatusActive
+	project.UpdatedAt = time.Now()
+	if err := uc.projectRepo.Update(ctx, project); err != nil {
+		return nil, err
+	}
+	return uc.GetProject(ctx, id)
+}
+
+// cloneSuffix is appended to the name of a cloned project when no name is given
+const cloneSuffix = " (copy)"
+
+// CloneProject creates an active project from an existing one, copying its
+// description, skills, tech stack and links. Images, tasks and dates are not
+// copied. An empty name names the clone after the original.
+func (uc *ProjectUseCase) CloneProject(ctx context.Context, id int64, name string) (*entity.Project, error) {
+	source, err := uc.projectRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrProjectNotFound
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = source.Name + cloneSuffix
+	}
+	project := entity.NewProject(name, source.Description, entity.StatusActive, nil, nil)
+	if err := uc.projectRepo.Clone(ctx, source.ID, project); err != nil {
+		return nil, err
+	}
+	return uc.GetProject(ctx, project.ID)
+}
+
 // DeleteProject deletes a project
 func (uc *ProjectUseCase) DeleteProject(ctx context.Context, id int64) error {
 	return uc.projectRepo.Delete(ctx, id)
 }
 
-// ListProjects lists projects with pagination
-func (uc *ProjectUseCase) ListProjects(ctx context.Context, page, limit int, status string) ([]*entity.Project, int, error) {
-	if page < 1 {
-		page = 1
+// ListProjects lists projects with pagination. Archived projects are left
+// out unless includeArchived is set or they are asked for by status. The
+// relations in include (see entity.Relations) are loaded with one query each
+// for the whole page; others are left empty.
+func (uc *ProjectUseCase) ListProjects(ctx context.Context, page, limit int, status string, includeArchived bool, include []string) ([]*entity.Project, int, error) {
+	for _, relation := range include {
+		if !isValidRelation(relation) {
+			return nil, 0, ErrInvalidInclude
+		}
+	}
+	page, limit = uc.pages.Normalize(page, limit)
+	projects, total, err := uc.projectRepo.List(ctx, page, limit, status, includeArchived)
+	if err != nil || len(projects) == 0 || len(include) == 0 {
+		return projects, total, err
+	}
+	if err := uc.loadRelations(ctx, projects, include); err != nil {
+		return nil, 0, err
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	return projects, total, nil
+}
+
+// isValidRelation reports whether relation can be included in project lists
+func isValidRelation(relation string) bool {
+	for _, r := range entity.Relations() {
+		if r == relation {
+			return true
+		}
 	}
-	return uc.projectRepo.List(ctx, page, limit, status)
+	return false
+}
+
+// loadRelations fills in the given relations of projects, batching each one
+// into a single query
+func (uc *ProjectUseCase) loadRelations(ctx context.Context, projects []*entity.Project, include []string) error {
+	ids := make([]int64, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+
+	loaded := make(map[string]bool, len(include))
+	for _, relation := range include {
+		if loaded[relation] {
+			continue
+		}
+		loaded[relation] = true
+
+		switch relation {
+		case entity.RelationSkills:
+			skills, err := uc.projectSkillRepo.GetByProjectIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, p := range projects {
+				p.Skills = skills[p.ID]
+			}
+		case entity.RelationTech:
+			techs, err := uc.techRepo.GetByProjectIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, p := range projects {
+				p.TechStack = techs[p.ID]
+			}
+		case entity.RelationImages:
+			images, err := uc.imageRepo.GetByProjectIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, p := range projects {
+				p.Images = images[p.ID]
+			}
+		case entity.RelationLinks:
+			links, err := uc.linkRepo.GetByProjectIDs(ctx, ids)
+			if err != nil {
+				return err
+			}
+			for _, p := range projects {
+				p.Links = links[p.ID]
+			}
+		}
+	}
+	return nil
+}
+
+// SettingsUseCase handles per-project settings
+type SettingsUseCase struct {
+	projectRepo  repository.ProjectRepository
+	settingsRepo repository.ProjectSettingsRepository
+}
+
+// NewSettingsUseCase creates a new SettingsUseCase
+func NewSettingsUseCase(projectRepo repository.ProjectRepository, settingsRepo repository.ProjectSettingsRepository) *SettingsUseCase {
+	return &SettingsUseCase{projectRepo: projectRepo, settingsRepo: settingsRepo}
+}
+
+// GetSettings gets a project's settings; unset fields are empty
+func (uc *SettingsUseCase) GetSettings(ctx context.Context, projectID int64) (*entity.ProjectSettings, error) {
+	if _, err := uc.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, ErrProjectNotFound
+	}
+	return uc.settingsRepo.Get(ctx, projectID)
+}
+
+// UpdateSettings replaces a project's task defaults. An empty status or a
+// zero priority unsets that default, so new tasks get the global one.
+func (uc *SettingsUseCase) UpdateSettings(ctx context.Context, projectID int64, defaultTaskStatus string, defaultTaskPriority int) (*entity.ProjectSettings, error) {
+	if defaultTaskStatus != "" && !entity.IsValidTaskStatus(defaultTaskStatus) {
+		return nil, ErrInvalidTaskStatus
+	}
+	if defaultTaskPriority != 0 && (defaultTaskPriority < entity.MinTaskPriority || defaultTaskPriority > entity.MaxTaskPriority) {
+		return nil, ErrInvalidTaskPriority
+	}
+	if _, err := uc.projectRepo.GetByID(ctx, projectID); err != nil {
+		return nil, ErrProjectNotFound
+	}
+
+	settings := &entity.ProjectSettings{
+		ProjectID:           projectID,
+		DefaultTaskStatus:   defaultTaskStatus,
+		DefaultTaskPriority: defaultTaskPriority,
+		UpdatedAt:           time.Now(),
+	}
+	if err := uc.settingsRepo.Set(ctx, settings); err != nil {
+		return nil, err
+	}
+	return settings, nil
 }
 
 // SkillUseCase handles skill business logic
@@ -136,6 +481,11 @@ func NewSkillUseCase(skillRepo repository.SkillRepository) *SkillUseCase {
 
 // CreateSkill creates a new skill
 func (uc *SkillUseCase) CreateSkill(ctx context.Context, name string) (*entity.Skill, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrNameRequired
+	}
+
 	skill := &entity.Skill{Name: name}
 	if err := uc.skillRepo.Create(ctx, skill); err != nil {
 		return nil, err
@@ -180,12 +530,16 @@ func NewTechUseCase(techRepo repository.ProjectTechRepository) *TechUseCase {
 
 // AddTech adds a technology to a project
 func (uc *TechUseCase) AddTech(ctx context.Context, projectID int64, techName string) error {
+	techName = strings.TrimSpace(techName)
+	if techName == "" {
+		return ErrTechNameRequired
+	}
 	return uc.techRepo.Add(ctx, projectID, techName)
 }
 
 // RemoveTech removes a technology from a project
 func (uc *TechUseCase) RemoveTech(ctx context.Context, projectID int64, techName string) error {
-	return uc.techRepo.Remove(ctx, projectID, techName)
+	return uc.techRepo.Remove(ctx, projectID, strings.TrimSpace(techName))
 }
 
 // ImageUseCase handles project images
@@ -200,10 +554,15 @@ func NewImageUseCase(imageRepo repository.ProjectImageRepository) *ImageUseCase
 
 // AddImage adds an image to a project
 func (uc *ImageUseCase) AddImage(ctx context.Context, projectID int64, imageURL, description string) (*entity.ProjectImage, error) {
+	imageURL, err := normalizeURL(imageURL)
+	if err != nil {
+		return nil, err
+	}
+
 	image := &entity.ProjectImage{
 		ProjectID:   projectID,
 		ImageURL:    imageURL,
-		Description: description,
+		Description: strings.TrimSpace(description),
 		UploadedAt:  time.Now(),
 	}
 	if err := uc.imageRepo.Add(ctx, image); err != nil {
@@ -234,6 +593,14 @@ func NewLinkUseCase(linkRepo repository.ProjectLinkRepository) *LinkUseCase {
 
 // AddLink adds a link to a project
 func (uc *LinkUseCase) AddLink(ctx context.Context, projectID int64, linkURL, linkType string) (*entity.ProjectLink, error) {
+	if !entity.IsValidLinkType(linkType) {
+		return nil, ErrInvalidLinkType
+	}
+	linkURL, err := normalizeURL(linkURL)
+	if err != nil {
+		return nil, err
+	}
+
 	link := &entity.ProjectLink{
 		ProjectID: projectID,
 		LinkURL:   linkURL,
@@ -250,7 +617,10 @@ func (uc *LinkUseCase) RemoveLink(ctx context.Context, id int64) error {
 	return uc.linkRepo.Remove(ctx, id)
 }
 
-// GetLinks gets all links for a project
-func (uc *LinkUseCase) GetLinks(ctx context.Context, projectID int64) ([]*entity.ProjectLink, error) {
-	return uc.linkRepo.GetByProjectID(ctx, projectID)
+// GetLinks gets the links for a project. An empty linkType returns all of them.
+func (uc *LinkUseCase) GetLinks(ctx context.Context, projectID int64, linkType string) ([]*entity.ProjectLink, error) {
+	if linkType != "" && !entity.IsValidLinkType(linkType) {
+		return nil, ErrInvalidLinkType
+	}
+	return uc.linkRepo.GetByProjectID(ctx, projectID, linkType)
 }
@@ -0,0 +1,500 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/portfolio/project-service/internal/domain/entity"
+	"github.com/portfolio/shared/pagination"
+)
+
+// MockProjectLinkRepository is an in-memory ProjectLinkRepository
+type MockProjectLinkRepository struct {
+	links   []*entity.ProjectLink
+	queries int
+}
+
+func (m *MockProjectLinkRepository) Add(ctx context.Context, link *entity.ProjectLink) error {
+	link.ID = int64(len(m.links) + 1)
+	m.links = append(m.links, link)
+	return nil
+}
+
+func (m *MockProjectLinkRepository) GetByID(ctx context.Context, id int64) (*entity.ProjectLink, error) {
+	for _, link := range m.links {
+		if link.ID == id {
+			return link, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *MockProjectLinkRepository) Remove(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *MockProjectLinkRepository) GetByProjectID(ctx context.Context, projectID int64, linkType string) ([]*entity.ProjectLink, error) {
+	m.queries++
+	var result []*entity.ProjectLink
+	for _, link := range m.links {
+		if link.ProjectID == projectID && (linkType == "" || link.LinkType == linkType) {
+			result = append(result, link)
+		}
+	}
+	return result, nil
+}
+
+func (m *MockProjectLinkRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectLink, error) {
+	m.queries++
+	result := make(map[int64][]*entity.ProjectLink)
+	for _, link := range m.links {
+		result[link.ProjectID] = append(result[link.ProjectID], link)
+	}
+	return result, nil
+}
+
+// MockProjectRepository is an in-memory ProjectRepository
+type MockProjectRepository struct {
+	projects  []*entity.Project
+	openTasks int
+	queries   int
+}
+
+func (m *MockProjectRepository) Create(ctx context.Context, project *entity.Project) error {
+	project.ID = int64(len(m.projects) + 1)
+	m.projects = append(m.projects, project)
+	return nil
+}
+
+func (m *MockProjectRepository) GetByID(ctx context.Context, id int64) (*entity.Project, error) {
+	m.queries++
+	for _, p := range m.projects {
+		if p.ID == id {
+			return p, nil
+		}
+	}
+	return nil, ErrProjectNotFound
+}
+
+func (m *MockProjectRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Project, error) {
+	var found []*entity.Project
+	for _, p := range m.projects {
+		for _, id := range ids {
+			if p.ID == id {
+				found = append(found, p)
+			}
+		}
+	}
+	return found, nil
+}
+
+func (m *MockProjectRepository) Update(ctx context.Context, project *entity.Project) error {
+	return nil
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *MockProjectRepository) List(ctx context.Context, page, limit int, status string, includeArchived bool) ([]*entity.Project, int, error) {
+	return m.projects, len(m.projects), nil
+}
+
+func (m *MockProjectRepository) CountOpenTasks(ctx context.Context, projectID int64) (int, error) {
+	return m.openTasks, nil
+}
+
+func (m *MockProjectRepository) Clone(ctx context.Context, sourceID int64, project *entity.Project) error {
+	return m.Create(ctx, project)
+}
+
+// MockProjectTechRepository is an in-memory ProjectTechRepository
+type MockProjectTechRepository struct {
+	techs   []string
+	queries int
+}
+
+func (m *MockProjectTechRepository) Add(ctx context.Context, projectID int64, techName string) error {
+	m.techs = append(m.techs, techName)
+	return nil
+}
+
+func (m *MockProjectTechRepository) Remove(ctx context.Context, projectID int64, techName string) error {
+	return nil
+}
+
+func (m *MockProjectTechRepository) GetByProjectID(ctx context.Context, projectID int64) ([]string, error) {
+	m.queries++
+	return m.techs, nil
+}
+
+func (m *MockProjectTechRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]string, error) {
+	m.queries++
+	result := make(map[int64][]string)
+	for _, id := range projectIDs {
+		result[id] = m.techs
+	}
+	return result, nil
+}
+
+// MockProjectImageRepository is an in-memory ProjectImageRepository
+type MockProjectImageRepository struct {
+	images  []*entity.ProjectImage
+	queries int
+}
+
+func (m *MockProjectImageRepository) Add(ctx context.Context, image *entity.ProjectImage) error {
+	image.ID = int64(len(m.images) + 1)
+	m.images = append(m.images, image)
+	return nil
+}
+
+func (m *MockProjectImageRepository) GetByID(ctx context.Context, id int64) (*entity.ProjectImage, error) {
+	return nil, nil
+}
+
+func (m *MockProjectImageRepository) Remove(ctx context.Context, id int64) error {
+	return nil
+}
+
+func (m *MockProjectImageRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.ProjectImage, error) {
+	m.queries++
+	return m.images, nil
+}
+
+func (m *MockProjectImageRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.ProjectImage, error) {
+	m.queries++
+	result := make(map[int64][]*entity.ProjectImage)
+	for _, image := range m.images {
+		result[image.ProjectID] = append(result[image.ProjectID], image)
+	}
+	return result, nil
+}
+
+// MockProjectSkillRepository is an in-memory ProjectSkillRepository
+type MockProjectSkillRepository struct {
+	skills  []*entity.Skill
+	queries int
+}
+
+func (m *MockProjectSkillRepository) Add(ctx context.Context, projectID, skillID int64) error {
+	m.skills = append(m.skills, &entity.Skill{ID: skillID})
+	return nil
+}
+
+func (m *MockProjectSkillRepository) Remove(ctx context.Context, projectID, skillID int64) error {
+	return nil
+}
+
+func (m *MockProjectSkillRepository) GetByProjectID(ctx context.Context, projectID int64) ([]*entity.Skill, error) {
+	m.queries++
+	return m.skills, nil
+}
+
+func (m *MockProjectSkillRepository) GetByProjectIDs(ctx context.Context, projectIDs []int64) (map[int64][]*entity.Skill, error) {
+	m.queries++
+	result := make(map[int64][]*entity.Skill)
+	for _, id := range projectIDs {
+		result[id] = m.skills
+	}
+	return result, nil
+}
+
+func TestProjectUseCase_GetProjectBasic(t *testing.T) {
+	repo := &MockProjectRepository{projects: []*entity.Project{{ID: 1, Name: "Portfolio"}}}
+	skills := &MockProjectSkillRepository{skills: []*entity.Skill{{ID: 2, Name: "Go"}}}
+	techs := &MockProjectTechRepository{techs: []string{"Go"}}
+	images := &MockProjectImageRepository{}
+	links := &MockProjectLinkRepository{}
+	uc := NewProjectUseCase(repo, nil, skills, techs, images, links, "", pagination.Sizes{})
+	queries := func() int { return repo.queries + skills.queries + techs.queries + images.queries + links.queries }
+
+	project, err := uc.GetProjectBasic(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetProjectBasic() error = %v", err)
+	}
+	if n := queries(); n != 1 {
+		t.Errorf("GetProjectBasic() made %d queries, want only the project's", n)
+	}
+	if project.Name != "Portfolio" || project.Skills != nil || project.TechStack != nil {
+		t.Errorf("GetProjectBasic() = %+v, want the project without relations", project)
+	}
+	if _, err := uc.GetProjectBasic(context.Background(), 9); err != ErrProjectNotFound {
+		t.Errorf("GetProjectBasic(missing) error = %v, want %v", err, ErrProjectNotFound)
+	}
+
+	// The full load still fans out to every relation
+	before := queries()
+	project, err = uc.GetProject(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetProject() error = %v", err)
+	}
+	if n := queries() - before; n != 5 || len(project.Skills) != 1 || len(project.TechStack) != 1 {
+		t.Errorf("GetProject() made %d queries and loaded %+v, want 5 queries and the relations", n, project)
+	}
+}
+
+func TestProjectUseCase_CreateProject(t *testing.T) {
+	repo := &MockProjectRepository{}
+	uc := NewProjectUseCase(repo, nil, nil, nil, nil, nil, "", pagination.Sizes{})
+	ctx := context.Background()
+
+	project, err := uc.CreateProject(ctx, "  Portfolio  ", " Personal site ", "", nil, nil)
+	if err != nil {
+		t.Fatalf("CreateProject() error = %v", err)
+	}
+	if project.Name != "Portfolio" || project.Description != "Personal site" {
+		t.Errorf("CreateProject() = %q / %q, want trimmed name and description", project.Name, project.Description)
+	}
+
+	for _, name := range []string{"", "   ", "\t\n"} {
+		if _, err := uc.CreateProject(ctx, name, "", "", nil, nil); err != ErrNameRequired {
+			t.Errorf("CreateProject(%q) error = %v, want %v", name, err, ErrNameRequired)
+		}
+	}
+	if len(repo.projects) != 1 {
+		t.Errorf("repository has %d projects, want only the valid one", len(repo.projects))
+	}
+}
+
+func TestProjectUseCase_CreateProject_Status(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultStatus string
+		status        string
+		want          string
+		wantErr       error
+	}{
+		{"built-in default", "", "", entity.StatusActive, nil},
+		{"configured default", entity.StatusOnHold, "", entity.StatusOnHold, nil},
+		{"explicit status wins", entity.StatusOnHold, entity.StatusCompleted, entity.StatusCompleted, nil},
+		{"unknown status", "", "finished", "", ErrInvalidStatus},
+		{"wrong case", "", "Active", "", ErrInvalidStatus},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockProjectRepository{}
+			uc := NewProjectUseCase(repo, nil, nil, nil, nil, nil, tt.defaultStatus, pagination.Sizes{})
+			project, err := uc.CreateProject(context.Background(), "Portfolio", "", tt.status, nil, nil)
+			if err != tt.wantErr {
+				t.Fatalf("CreateProject(%q) error = %v, want %v", tt.status, err, tt.wantErr)
+			}
+			if err != nil {
+				if len(repo.projects) != 0 {
+					t.Error("CreateProject() stored a project with an invalid status")
+				}
+				return
+			}
+			if project.Status != tt.want {
+				t.Errorf("status = %q, want %q", project.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectUseCase_UpdateProject_BlankName(t *testing.T) {
+	now := time.Now()
+	repo := &MockProjectRepository{projects: []*entity.Project{{ID: 1, Name: "Portfolio", UpdatedAt: now}}}
+	uc := NewProjectUseCase(repo, nil, nil, nil, nil, nil, "", pagination.Sizes{})
+
+	if _, err := uc.UpdateProject(context.Background(), 1, "   ", "", "", nil, nil); err != ErrNameRequired {
+		t.Fatalf("UpdateProject() error = %v, want %v", err, ErrNameRequired)
+	}
+	if repo.projects[0].Name != "Portfolio" || !repo.projects[0].UpdatedAt.Equal(now) {
+		t.Error("UpdateProject() modified the project despite the invalid name")
+	}
+}
+
+func TestProjectUseCase_CreateProject_DateRange(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := start.AddDate(0, 0, -1)
+	after := start.AddDate(0, 2, 0)
+
+	tests := []struct {
+		name      string
+		startDate *time.Time
+		endDate   *time.Time
+		wantErr   error
+	}{
+		{"ordered", &start, &after, nil},
+		{"same day", &start, &start, nil},
+		{"inverted", &start, &before, ErrInvalidDateRange},
+		{"no dates", nil, nil, nil},
+		{"only start", &start, nil, nil},
+		{"only end", nil, &before, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockProjectRepository{}
+			uc := NewProjectUseCase(repo, nil, nil, nil, nil, nil, "", pagination.Sizes{})
+			project, err := uc.CreateProject(context.Background(), "Portfolio", "", "", tt.startDate, tt.endDate)
+			if err != tt.wantErr {
+				t.Fatalf("CreateProject() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(repo.projects) != 0 {
+					t.Error("CreateProject() stored a project with an invalid date range")
+				}
+				return
+			}
+			if project.StartDate != tt.startDate || project.EndDate != tt.endDate {
+				t.Errorf("CreateProject() dates = %v / %v, want %v / %v", project.StartDate, project.EndDate, tt.startDate, tt.endDate)
+			}
+		})
+	}
+}
+
+func TestProjectUseCase_UpdateProject_DateRange(t *testing.T) {
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	afterEnd := end.AddDate(0, 0, 1)
+	beforeStart := start.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name      string
+		startDate *time.Time
+		endDate   *time.Time
+	}{
+		{"new start after stored end", &afterEnd, nil},
+		{"new end before stored start", nil, &beforeStart},
+		{"both given inverted", &end, &start},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, e := start, end
+			repo := &MockProjectRepository{projects: []*entity.Project{{ID: 1, Name: "Portfolio", StartDate: &s, EndDate: &e}}}
+			uc := NewProjectUseCase(repo, nil, nil, nil, nil, nil, "", pagination.Sizes{})
+
+			if _, err := uc.UpdateProject(context.Background(), 1, "", "", "", tt.startDate, tt.endDate); err != ErrInvalidDateRange {
+				t.Fatalf("UpdateProject() error = %v, want %v", err, ErrInvalidDateRange)
+			}
+		})
+	}
+}
+
+func TestSkillUseCase_CreateSkill_BlankName(t *testing.T) {
+	uc := NewSkillUseCase(nil)
+	if _, err := uc.CreateSkill(context.Background(), "  "); err != ErrNameRequired {
+		t.Errorf("CreateSkill() error = %v, want %v", err, ErrNameRequired)
+	}
+}
+
+func TestTechUseCase_AddTech(t *testing.T) {
+	repo := &MockProjectTechRepository{}
+	uc := NewTechUseCase(repo)
+	ctx := context.Background()
+
+	if err := uc.AddTech(ctx, 1, " Go "); err != nil {
+		t.Fatalf("AddTech() error = %v", err)
+	}
+	if err := uc.AddTech(ctx, 1, "   "); err != ErrTechNameRequired {
+		t.Errorf("AddTech() error = %v, want %v", err, ErrTechNameRequired)
+	}
+	if len(repo.techs) != 1 || repo.techs[0] != "Go" {
+		t.Errorf("stored techs = %v, want [Go]", repo.techs)
+	}
+}
+
+func TestImageUseCase_AddImage(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    string
+		wantErr error
+	}{
+		{"https", "https://cdn.example.com/shot.png", "https://cdn.example.com/shot.png", nil},
+		{"trimmed", "  http://localhost:50055/files/a.png ", "http://localhost:50055/files/a.png", nil},
+		{"empty", "", "", ErrInvalidURL},
+		{"relative", "images/shot.png", "", ErrInvalidURL},
+		{"no host", "https:///shot.png", "", ErrInvalidURL},
+		{"javascript scheme", "javascript:alert(1)", "", ErrInvalidURL},
+		{"unparseable", "http://[::1", "", ErrInvalidURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewImageUseCase(&MockProjectImageRepository{})
+			image, err := uc.AddImage(context.Background(), 1, tt.url, "")
+			if err != tt.wantErr {
+				t.Fatalf("AddImage(%q) error = %v, want %v", tt.url, err, tt.wantErr)
+			}
+			if err == nil && image.ImageURL != tt.want {
+				t.Errorf("AddImage(%q) stored %q, want %q", tt.url, image.ImageURL, tt.want)
+			}
+		})
+	}
+}
+
+func TestLinkUseCase_AddLink_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		linkType string
+		wantErr  error
+	}{
+		{"unknown type", "https://example.com", "video", ErrInvalidLinkType},
+		{"empty type", "https://example.com", "", ErrInvalidLinkType},
+		{"empty url", "", entity.LinkTypeLive, ErrInvalidURL},
+		{"ftp url", "ftp://example.com/spec.pdf", entity.LinkTypeDocument, ErrInvalidURL},
+		{"not a url", "github repo", entity.LinkTypeGitHub, ErrInvalidURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockProjectLinkRepository{}
+			uc := NewLinkUseCase(repo)
+			if _, err := uc.AddLink(context.Background(), 1, tt.url, tt.linkType); err != tt.wantErr {
+				t.Fatalf("AddLink() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(repo.links) != 0 {
+				t.Error("AddLink() stored an invalid link")
+			}
+		})
+	}
+}
+
+func TestLinkUseCase_GetLinks(t *testing.T) {
+	repo := &MockProjectLinkRepository{}
+	uc := NewLinkUseCase(repo)
+	ctx := context.Background()
+
+	uc.AddLink(ctx, 1, "https://github.com/example/repo", entity.LinkTypeGitHub)
+	uc.AddLink(ctx, 1, "https://example.com", entity.LinkTypeLive)
+	uc.AddLink(ctx, 1, "https://example.com/spec.pdf", entity.LinkTypeDocument)
+	uc.AddLink(ctx, 1, "https://example.com/guide.pdf", entity.LinkTypeDocument)
+	uc.AddLink(ctx, 2, "https://github.com/example/other", entity.LinkTypeGitHub)
+
+	tests := []struct {
+		name     string
+		linkType string
+		want     int
+		wantErr  error
+	}{
+		{"all", "", 4, nil},
+		{"github", entity.LinkTypeGitHub, 1, nil},
+		{"live", entity.LinkTypeLive, 1, nil},
+		{"document", entity.LinkTypeDocument, 2, nil},
+		{"invalid", "video", 0, ErrInvalidLinkType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			links, err := uc.GetLinks(ctx, 1, tt.linkType)
+			if err != tt.wantErr {
+				t.Fatalf("GetLinks() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(links) != tt.want {
+				t.Errorf("GetLinks() returned %d links, want %d", len(links), tt.want)
+			}
+			for _, link := range links {
+				if tt.linkType != "" && link.LinkType != tt.linkType {
+					t.Errorf("GetLinks() returned %q link, want only %q", link.LinkType, tt.linkType)
+				}
+			}
+		})
+	}
+}
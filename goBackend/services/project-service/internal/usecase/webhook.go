@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+
+	"github.com/portfolio/shared/pagination"
+	"github.com/portfolio/shared/webhook"
+)
+
+var (
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrEventTypesRequired = errors.New("at least one event type is required")
+	ErrInvalidEventType   = errors.New("invalid event type")
+	ErrInternalWebhookURL = webhook.ErrForbiddenAddress
+)
+
+// WebhookUseCase manages the webhook subscriptions that the task and project
+// services deliver their changes to
+type WebhookUseCase struct {
+	store webhook.Store
+	pages pagination.Sizes
+}
+
+// NewWebhookUseCase creates a new WebhookUseCase listing deliveries in pages
+// of the given sizes
+func NewWebhookUseCase(store webhook.Store, pages pagination.Sizes) *WebhookUseCase {
+	return &WebhookUseCase{store: store, pages: pages}
+}
+
+// CreateWebhook subscribes rawURL to eventTypes. URLs naming a loopback,
+// link-local or private host are refused. An empty secret is generated;
+// either way the returned subscription is the only place the secret is
+// shown.
+func (uc *WebhookUseCase) CreateWebhook(ctx context.Context, rawURL, secret string, eventTypes []string) (*webhook.Subscription, error) {
+	target, err := normalizeURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := webhook.ValidateURL(target); err != nil {
+		if err == webhook.ErrForbiddenAddress {
+			return nil, ErrInternalWebhookURL
+		}
+		return nil, ErrInvalidURL
+	}
+	if len(eventTypes) == 0 {
+		return nil, ErrEventTypesRequired
+	}
+	seen := make(map[string]bool, len(eventTypes))
+	var types []string
+	for _, t := range eventTypes {
+		if !webhook.IsValidEventType(t) {
+			return nil, ErrInvalidEventType
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	if secret == "" {
+		if secret, err = newWebhookSecret(); err != nil {
+			return nil, err
+		}
+	}
+
+	sub := &webhook.Subscription{URL: target, Secret: secret, EventTypes: types}
+	if err := uc.store.Create(ctx, sub); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// ListWebhooks lists every subscription
+func (uc *WebhookUseCase) ListWebhooks(ctx context.Context) ([]*webhook.Subscription, error) {
+	return uc.store.List(ctx)
+}
+
+// DeleteWebhook removes a subscription along with its delivery history
+func (uc *WebhookUseCase) DeleteWebhook(ctx context.Context, id int64) error {
+	if err := uc.store.Delete(ctx, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrWebhookNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// ListDeliveries returns a subscription's latest delivery attempts, newest
+// first; limit defaults to the default page size and is capped at the max
+func (uc *WebhookUseCase) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*webhook.Delivery, error) {
+	return uc.store.ListDeliveries(ctx, webhookID, uc.pages.Limit(limit))
+}
+
+// newWebhookSecret returns a random 256-bit secret
+func newWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -1,32 +1,63 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net"
 
 	pb "github.com/portfolio/proto/task"
 	"github.com/portfolio/shared/database"
+	"github.com/portfolio/shared/grpctls"
+	"github.com/portfolio/shared/logging"
+	"github.com/portfolio/shared/metrics"
 	"github.com/portfolio/shared/middleware"
+	"github.com/portfolio/shared/migrations"
+	"github.com/portfolio/shared/outbox"
+	"github.com/portfolio/shared/pagination"
+	"github.com/portfolio/shared/webhook"
 	"github.com/portfolio/task-service/internal/config"
 	"github.com/portfolio/task-service/internal/handler"
+	"github.com/portfolio/task-service/internal/infrastructure/auth"
+	"github.com/portfolio/task-service/internal/infrastructure/events"
+	"github.com/portfolio/task-service/internal/infrastructure/media"
 	"github.com/portfolio/task-service/internal/infrastructure/repository"
 	"github.com/portfolio/task-service/internal/usecase"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "apply database migrations and exit")
+	flag.Parse()
+
+	logging.Setup("task-service")
+
 	// Load configuration
 	cfg := config.Load()
+	tlsConfig := grpctls.Config{
+		Enabled:  cfg.GRPCTLSEnabled,
+		CertFile: cfg.GRPCTLSCertFile,
+		KeyFile:  cfg.GRPCTLSKeyFile,
+		CAFile:   cfg.GRPCTLSCAFile,
+	}
 
 	// Initialize database connection
 	dbConfig := database.Config{
-		Host:     cfg.DBHost,
-		Port:     cfg.DBPort,
-		User:     cfg.DBUser,
-		Password: cfg.DBPassword,
-		DBName:   cfg.DBName,
-		SSLMode:  cfg.DBSSLMode,
+		Host:        cfg.DBHost,
+		Port:        cfg.DBPort,
+		User:        cfg.DBUser,
+		Password:    cfg.DBPassword,
+		DBName:      cfg.DBName,
+		SSLMode:     cfg.DBSSLMode,
+		SSLRootCert: cfg.DBSSLRootCert,
+		SSLCert:     cfg.DBSSLCert,
+		SSLKey:      cfg.DBSSLKey,
+
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		ConnMaxIdleTime: cfg.DBConnMaxIdleTime,
 	}
 
 	pool, err := database.NewPool(dbConfig)
@@ -37,6 +68,35 @@ func main() {
 
 	db := pool.GetDB()
 
+	// Apply pending schema migrations
+	if _, err := migrations.Run(context.Background(), db); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+	if *migrateOnly {
+		log.Println("Migrations applied")
+		return
+	}
+
+	// Calls to other services use the same TLS settings as the server
+	dialCreds, err := grpctls.DialOption(tlsConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up gRPC client TLS: %v", err)
+	}
+
+	// Media service resolves uploaded files for attachments
+	mediaConn, err := grpc.Dial(cfg.MediaServiceURL, dialCreds)
+	if err != nil {
+		log.Fatalf("Failed to connect to media service: %v", err)
+	}
+	defer mediaConn.Close()
+
+	// Auth service confirms that assignees exist
+	authConn, err := grpc.Dial(cfg.AuthServiceURL, dialCreds)
+	if err != nil {
+		log.Fatalf("Failed to connect to auth service: %v", err)
+	}
+	defer authConn.Close()
+
 	// Initialize repositories
 	taskRepo := repository.NewPostgresTaskRepository(db)
 	subtaskRepo := repository.NewPostgresSubtaskRepository(db)
@@ -44,24 +104,75 @@ func main() {
 	attachmentRepo := repository.NewPostgresAttachmentRepository(db)
 	tagRepo := repository.NewPostgresTagRepository(db)
 	taskTagRepo := repository.NewPostgresTaskTagRepository(db)
+	watcherRepo := repository.NewPostgresWatcherRepository(db)
+	activityRepo := repository.NewPostgresActivityRepository(db)
+	defaultsRepo := repository.NewPostgresTaskDefaultsRepository(db)
 
 	// Initialize use cases
-	taskUC := usecase.NewTaskUseCase(taskRepo, subtaskRepo, commentRepo, attachmentRepo, tagRepo, taskTagRepo)
-	subtaskUC := usecase.NewSubtaskUseCase(subtaskRepo)
-	commentUC := usecase.NewCommentUseCase(commentRepo)
-	attachmentUC := usecase.NewAttachmentUseCase(attachmentRepo)
-	tagUC := usecase.NewTagUseCase(tagRepo, taskTagRepo)
+	limits := usecase.Limits{
+		MaxSubtasksPerTask: cfg.MaxSubtasksPerTask,
+		MaxTagsPerTask:     cfg.MaxTagsPerTask,
+	}
+	dueDates := usecase.AllowPastDueDates
+	if cfg.RejectPastDueDates {
+		dueDates = usecase.RejectPastDueDates
+	}
+	tagDeletes := usecase.DetachDeletedTags
+	if cfg.RejectDeletingTagsInUse {
+		tagDeletes = usecase.RejectTagsInUse
+	}
+	assignees := usecase.AssigneeCheck{RequireAccess: cfg.RequireAssigneeAccess}
+	if cfg.VerifyAssignees {
+		assignees.Users = auth.NewClient(authConn)
+	}
+	// Task changes are fanned out to the gateway's project event streams
+	taskEvents := events.NewHub(events.DefaultBuffer)
+	mediaClient := media.NewClient(mediaConn)
+	pages := pagination.New(cfg.DefaultPageSize, cfg.MaxPageSize)
+	taskUC := usecase.NewTaskUseCase(taskRepo, subtaskRepo, commentRepo, attachmentRepo, mediaClient, tagRepo, taskTagRepo, activityRepo, defaultsRepo, limits, dueDates, assignees, taskEvents, pages)
+	subtaskUC := usecase.NewSubtaskUseCase(subtaskRepo, taskRepo, activityRepo, limits, assignees, taskEvents, pages)
+	commentUC := usecase.NewCommentUseCase(commentRepo, pages)
+	attachmentUC := usecase.NewAttachmentUseCase(attachmentRepo, mediaClient, pages)
+	tagUC := usecase.NewTagUseCase(tagRepo, taskTagRepo, limits, tagDeletes)
+	watcherUC := usecase.NewWatcherUseCase(watcherRepo, taskRepo, auth.NewClient(authConn))
+
+	// The task repository writes its events to the outbox; the relay
+	// delivers them to the subscribed webhooks
+	webhooks := webhook.NewDispatcher(webhook.NewPostgres(db), webhook.Config{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		Timeout:     cfg.WebhookTimeout,
+	})
+	relay := outbox.NewRelay(outbox.NewPostgres(db, repository.OutboxSource), webhooks.HandleOutbox, outbox.RelayConfig{
+		PollInterval: cfg.OutboxPollInterval,
+		MaxAttempts:  cfg.OutboxMaxAttempts,
+	})
+	go relay.Run(context.Background())
+
+	// Metrics
+	registry := metrics.NewRegistry()
+	grpcMetrics := metrics.NewGRPCServerMetrics(registry)
+	metrics.Serve(cfg.MetricsPort, registry)
+
+	// Plaintext unless GRPC_TLS_ENABLED is set
+	serverCreds, err := grpctls.ServerOption(tlsConfig)
+	if err != nil {
+		log.Fatalf("Failed to set up gRPC TLS: %v", err)
+	}
 
 	// Create gRPC server with middleware
 	grpcServer := grpc.NewServer(
+		serverCreds,
 		grpc.ChainUnaryInterceptor(
 			middleware.RecoveryInterceptor(),
+			grpcMetrics.UnaryInterceptor(),
 			middleware.LoggingInterceptor(),
+			middleware.IdentityInterceptor(),
+			middleware.ValidationInterceptor(),
 		),
 	)
 
 	// Register task service handler
-	taskHandler := handler.NewTaskHandler(taskUC, subtaskUC, commentUC, attachmentUC, tagUC)
+	taskHandler := handler.NewTaskHandler(taskUC, subtaskUC, commentUC, attachmentUC, tagUC, watcherUC)
 	pb.RegisterTaskServiceServer(grpcServer, taskHandler)
 
 	// Start server
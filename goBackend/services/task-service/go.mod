@@ -3,12 +3,21 @@ module github.com/portfolio/task-service
 go 1.21
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.2
+	github.com/lib/pq v1.10.9
 	github.com/portfolio/proto v0.0.0
 	github.com/portfolio/shared v0.0.0
 	google.golang.org/grpc v1.64.0
 	google.golang.org/protobuf v1.34.0
 )
 
+require (
+	golang.org/x/net v0.22.0 // indirect
+	golang.org/x/sys v0.18.0 // indirect
+	golang.org/x/text v0.14.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240318140521-94a12d6c2237 // indirect
+)
+
 replace github.com/portfolio/shared => ../../shared
 
 replace github.com/portfolio/proto => ../../proto
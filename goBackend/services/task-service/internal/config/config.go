@@ -3,29 +3,106 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
 )
 
 // Config holds the application configuration
 type Config struct {
-	GRPCPort   int
-	DBHost     string
-	DBPort     int
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
+	GRPCPort          int
+	GRPCTLSEnabled    bool
+	GRPCTLSCertFile   string
+	GRPCTLSKeyFile    string
+	GRPCTLSCAFile     string
+	MetricsPort       int
+	DBHost            string
+	DBPort            int
+	DBUser            string
+	DBPassword        string
+	DBName            string
+	DBSSLMode         string
+	DBSSLRootCert     string
+	DBSSLCert         string
+	DBSSLKey          string
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+	MediaServiceURL   string
+	AuthServiceURL    string
+
+	// Per-task caps; 0 disables a cap
+	MaxSubtasksPerTask int
+	MaxTagsPerTask     int
+
+	// RejectPastDueDates refuses due dates before today on task create/update
+	RejectPastDueDates bool
+
+	// RejectDeletingTagsInUse refuses to delete tags that tasks still have
+	// instead of removing them from those tasks
+	RejectDeletingTagsInUse bool
+
+	// VerifyAssignees looks assignees up in the auth service before a task
+	// or subtask is assigned; RequireAssigneeAccess also demands that they
+	// can read the task's project
+	VerifyAssignees       bool
+	RequireAssigneeAccess bool
+
+	// Webhook deliveries are tried up to WebhookMaxAttempts times, each
+	// bounded by WebhookTimeout
+	WebhookMaxAttempts int
+	WebhookTimeout     time.Duration
+
+	// The outbox relay checks for events every OutboxPollInterval and sets
+	// an event aside after OutboxMaxAttempts failed deliveries
+	OutboxPollInterval time.Duration
+	OutboxMaxAttempts  int
+
+	// List pages hold DefaultPageSize items unless a request asks for
+	// another size, up to MaxPageSize
+	DefaultPageSize int
+	MaxPageSize     int
 }
 
 // Load loads configuration from environment variables
 func Load() *Config {
 	return &Config{
-		GRPCPort:   getEnvInt("GRPC_PORT", 50053),
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnvInt("DB_PORT", 5432),
-		DBUser:     getEnv("DB_USER", "postgres"),
-		DBPassword: getEnv("DB_PASSWORD", "postgres"),
-		DBName:     getEnv("DB_NAME", "portfolio"),
-		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
+		GRPCPort:          getEnvInt("GRPC_PORT", 50053),
+		GRPCTLSEnabled:    getEnvBool("GRPC_TLS_ENABLED", false),
+		GRPCTLSCertFile:   getEnv("GRPC_TLS_CERT_FILE", ""),
+		GRPCTLSKeyFile:    getEnv("GRPC_TLS_KEY_FILE", ""),
+		GRPCTLSCAFile:     getEnv("GRPC_TLS_CA_FILE", ""),
+		MetricsPort:       getEnvInt("METRICS_PORT", 9103),
+		DBHost:            getEnv("DB_HOST", "localhost"),
+		DBPort:            getEnvInt("DB_PORT", 5432),
+		DBUser:            getEnv("DB_USER", "postgres"),
+		DBPassword:        getEnv("DB_PASSWORD", "postgres"),
+		DBName:            getEnv("DB_NAME", "portfolio"),
+		DBSSLMode:         getEnv("DB_SSL_MODE", "disable"),
+		DBSSLRootCert:     getEnv("DB_SSL_ROOT_CERT", ""),
+		DBSSLCert:         getEnv("DB_SSL_CERT", ""),
+		DBSSLKey:          getEnv("DB_SSL_KEY", ""),
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 5),
+		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		DBConnMaxIdleTime: getEnvDuration("DB_CONN_MAX_IDLE_TIME", 0),
+		DefaultPageSize:   getEnvInt("DEFAULT_PAGE_SIZE", 20),
+		MaxPageSize:       getEnvInt("MAX_PAGE_SIZE", 100),
+		MediaServiceURL:   getEnv("MEDIA_SERVICE_URL", "localhost:50055"),
+		AuthServiceURL:    getEnv("AUTH_SERVICE_URL", "localhost:50051"),
+
+		MaxSubtasksPerTask: getEnvInt("MAX_SUBTASKS_PER_TASK", 100),
+		MaxTagsPerTask:     getEnvInt("MAX_TAGS_PER_TASK", 20),
+		RejectPastDueDates: getEnvBool("REJECT_PAST_DUE_DATES", false),
+
+		RejectDeletingTagsInUse: getEnvBool("REJECT_DELETING_TAGS_IN_USE", false),
+
+		VerifyAssignees:       getEnvBool("VERIFY_ASSIGNEES", true),
+		RequireAssigneeAccess: getEnvBool("REQUIRE_ASSIGNEE_ACCESS", false),
+
+		WebhookMaxAttempts: getEnvInt("WEBHOOK_MAX_ATTEMPTS", 5),
+		WebhookTimeout:     getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		OutboxPollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+		OutboxMaxAttempts:  getEnvInt("OUTBOX_MAX_ATTEMPTS", 10),
 	}
 }
 
@@ -44,3 +121,21 @@ func getEnvInt(key string, defaultValue int) int {
 	}
 	return defaultValue
 }
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
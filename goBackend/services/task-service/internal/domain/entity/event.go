@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// Task event types
+const (
+	TaskCreated = "task.created"
+	TaskUpdated = "task.updated"
+	TaskDeleted = "task.deleted"
+	// TaskMoved is announced in both projects; Task.ProjectID is the new one
+	TaskMoved = "task.moved"
+)
+
+// TaskEvent describes a change to a task in a project
+type TaskEvent struct {
+	Type      string
+	ProjectID int64
+	TaskID    int64
+	// Task is the task after the change; nil for TaskDeleted
+	Task       *Task
+	OccurredAt time.Time
+}
+
+// NewTaskEvent creates a TaskEvent for task
+func NewTaskEvent(eventType string, task *Task) TaskEvent {
+	event := TaskEvent{
+		Type:       eventType,
+		ProjectID:  task.ProjectID,
+		TaskID:     task.ID,
+		OccurredAt: time.Now(),
+	}
+	if eventType != TaskDeleted {
+		event.Task = task
+	}
+	return event
+}
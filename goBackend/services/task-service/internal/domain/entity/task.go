@@ -18,6 +18,53 @@ type Task struct {
 	Tags        []*TaskTag  `json:"tags,omitempty"`
 	CreatedAt   time.Time   `json:"created_at"`
 	UpdatedAt   time.Time   `json:"updated_at"`
+	Progress    float64     `json:"progress"`
+}
+
+// SubtaskProgress counts a task's subtasks and how many of them are done
+type SubtaskProgress struct {
+	Total     int
+	Completed int
+}
+
+// Ratio returns the share of completed subtasks; tasks without subtasks report 0
+func (p SubtaskProgress) Ratio() float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Completed) / float64(p.Total)
+}
+
+// TaskVersion is the minimal view of a task used by clients syncing a local cache
+type TaskVersion struct {
+	ID        int64     `json:"id"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProjectTaskCount counts a project's tasks and how many of them are done
+type ProjectTaskCount struct {
+	ProjectID int64
+	Total     int
+	Completed int
+}
+
+// TaskSummary counts a project's tasks per status, and those that are overdue
+// (past their due date and not done) or unassigned
+type TaskSummary struct {
+	ProjectID    int64
+	Total        int
+	StatusCounts map[string]int
+	Overdue      int
+	Unassigned   int
+}
+
+// NewTaskSummary creates an empty summary with every status at zero
+func NewTaskSummary(projectID int64) *TaskSummary {
+	counts := make(map[string]int)
+	for _, status := range ValidTaskStatuses() {
+		counts[status] = 0
+	}
+	return &TaskSummary{ProjectID: projectID, StatusCounts: counts}
 }
 
 // NewTask creates a new task entity
@@ -27,7 +74,7 @@ func NewTask(projectID int64, title, description, status string, priority int, a
 		status = StatusTodo
 	}
 	if priority == 0 {
-		priority = 3
+		priority = DefaultPriority
 	}
 
 	var assignedToPtr *int64
@@ -55,6 +102,41 @@ const (
 	StatusDone       = "Done"
 )
 
+// Task priorities. Lower values are more urgent, and task lists sort by
+// priority ascending, so high priority tasks come first and those without a
+// priority last.
+const (
+	PriorityHigh   = 1
+	PriorityMedium = 2
+	PriorityLow    = 3
+	PriorityNone   = 4
+
+	// DefaultPriority is given to tasks created without a priority
+	DefaultPriority = PriorityLow
+)
+
+// TaskDefaults are a project's defaults for tasks created without a status or
+// priority. Empty fields fall back to StatusTodo and DefaultPriority.
+type TaskDefaults struct {
+	Status   string
+	Priority int
+}
+
+// IsValidTaskStatus reports whether status is one of the task statuses
+func IsValidTaskStatus(status string) bool {
+	for _, s := range ValidTaskStatuses() {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidPriority reports whether p is one of the task priorities
+func IsValidPriority(p int) bool {
+	return p >= PriorityHigh && p <= PriorityNone
+}
+
 // ValidTaskStatuses returns all valid task statuses
 func ValidTaskStatuses() []string {
 	return []string{StatusTodo, StatusInProgress, StatusDone}
@@ -66,8 +148,9 @@ type Subtask struct {
 	TaskID     int64      `json:"task_id"`
 	Title      string     `json:"title"`
 	Status     string     `json:"status"`
-	AssignedTo int64      `json:"assigned_to"`
+	AssignedTo *int64     `json:"assigned_to,omitempty"`
 	DueDate    *time.Time `json:"due_date,omitempty"`
+	Position   int        `json:"position"` // order within the task, starting at 1
 	CreatedAt  time.Time  `json:"created_at"`
 	UpdatedAt  time.Time  `json:"updated_at"`
 }
@@ -75,11 +158,17 @@ type Subtask struct {
 // NewSubtask creates a new subtask entity
 func NewSubtask(taskID int64, title string, assignedTo int64, dueDate *time.Time) *Subtask {
 	now := time.Now()
+
+	var assignedToPtr *int64
+	if assignedTo != 0 {
+		assignedToPtr = &assignedTo
+	}
+
 	return &Subtask{
 		TaskID:     taskID,
 		Title:      title,
 		Status:     StatusTodo,
-		AssignedTo: assignedTo,
+		AssignedTo: assignedToPtr,
 		DueDate:    dueDate,
 		CreatedAt:  now,
 		UpdatedAt:  now,
@@ -107,21 +196,49 @@ func NewTaskComment(taskID, userID int64, comment string) *TaskComment {
 
 // TaskAttachment represents a task attachment
 type TaskAttachment struct {
-	ID         int64     `json:"id"`
-	TaskID     int64     `json:"task_id"`
-	FileURL    string    `json:"file_url"`
-	UploadedAt time.Time `json:"uploaded_at"`
+	ID          int64     `json:"id"`
+	TaskID      int64     `json:"task_id"`
+	FileURL     string    `json:"file_url"`
+	Type        string    `json:"type"`
+	MediaFileID int64     `json:"media_file_id,omitempty"`
+	UploadedAt  time.Time `json:"uploaded_at"`
 }
 
-// NewTaskAttachment creates a new task attachment
+// Attachment type constants
+const (
+	AttachmentTypeLink  = "link"
+	AttachmentTypeMedia = "media"
+)
+
+// NewTaskAttachment creates a new attachment linking to an external URL
 func NewTaskAttachment(taskID int64, fileURL string) *TaskAttachment {
 	return &TaskAttachment{
 		TaskID:     taskID,
 		FileURL:    fileURL,
+		Type:       AttachmentTypeLink,
 		UploadedAt: time.Now(),
 	}
 }
 
+// NewMediaAttachment creates a new attachment for a file stored in the media service
+func NewMediaAttachment(taskID, mediaFileID int64, fileURL string) *TaskAttachment {
+	return &TaskAttachment{
+		TaskID:      taskID,
+		FileURL:     fileURL,
+		Type:        AttachmentTypeMedia,
+		MediaFileID: mediaFileID,
+		UploadedAt:  time.Now(),
+	}
+}
+
+// MediaFile is a file uploaded to the media service, as far as attachments
+// need to know it
+type MediaFile struct {
+	ID         int64
+	URL        string
+	UploadedBy int64
+}
+
 // TaskTag represents a task tag
 type TaskTag struct {
 	ID   int64  `json:"id"`
@@ -133,3 +250,34 @@ type TaskTagMapping struct {
 	TaskID int64 `json:"task_id"`
 	TagID  int64 `json:"tag_id"`
 }
+
+// TaskWatcher is a user following a task's changes
+type TaskWatcher struct {
+	TaskID    int64     `json:"task_id"`
+	UserID    int64     `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Task activity actions
+const (
+	ActionSubtaskMoved = "subtask_moved"
+	ActionTaskMoved    = "task_moved"
+)
+
+// TaskActivity represents an entry in the task activity log
+type TaskActivity struct {
+	ID        int64     `json:"id"`
+	TaskID    int64     `json:"task_id"`
+	UserID    *int64    `json:"user_id,omitempty"`
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewTaskActivity creates a new task activity entry
+func NewTaskActivity(taskID int64, action string) *TaskActivity {
+	return &TaskActivity{
+		TaskID:    taskID,
+		Action:    action,
+		CreatedAt: time.Now(),
+	}
+}
@@ -2,26 +2,72 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/portfolio/task-service/internal/domain/entity"
 )
 
+// TagFilter restricts a task list to tagged tasks. A task must carry every
+// tag in IDs, or at least one of them when MatchAny is set. IDs must be
+// distinct; an empty filter matches every task.
+type TagFilter struct {
+	IDs      []int64
+	MatchAny bool
+}
+
 // TaskRepository defines the interface for task data access
 type TaskRepository interface {
 	Create(ctx context.Context, task *entity.Task) error
 	GetByID(ctx context.Context, id int64) (*entity.Task, error)
+	// GetByIDs gets the tasks that exist among ids, in id order
+	GetByIDs(ctx context.Context, ids []int64) ([]*entity.Task, error)
 	Update(ctx context.Context, task *entity.Task) error
 	Delete(ctx context.Context, id int64) error
-	List(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64) ([]*entity.Task, int, error)
+	DeleteMany(ctx context.Context, ids []int64) (int64, error)
+	CreateFromSubtask(ctx context.Context, task *entity.Task, subtaskID int64) error
+	// ConvertToSubtask stores subtask under its parent and deletes the task,
+	// whose own subtasks move to the parent too. With max > 0 it fails with
+	// ErrLimitExceeded when the parent would end up with more than max subtasks.
+	ConvertToSubtask(ctx context.Context, taskID int64, subtask *entity.Subtask, max int) error
+	Duplicate(ctx context.Context, sourceID int64, task *entity.Task, keepDueDates bool) error
+	// Move moves a task to another project; its subtasks, comments and tags
+	// go with it. clearAssignees also unassigns the task and its subtasks.
+	// Unknown tasks are sql.ErrNoRows and unknown projects ErrProjectNotFound.
+	Move(ctx context.Context, taskID, projectID int64, clearAssignees bool) error
+	List(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64, tags TagFilter) ([]*entity.Task, int, error)
+	ListAfter(ctx context.Context, projectID, afterID int64, limit int, status string, assignedTo int64, tags TagFilter) ([]*entity.Task, error)
+	ListVersions(ctx context.Context, projectID int64) ([]*entity.TaskVersion, error)
+	// CountByProject counts the tasks of every project that has any, in
+	// project order
+	CountByProject(ctx context.Context) ([]*entity.ProjectTaskCount, error)
+	// Summarize counts a project's tasks per status, with every status
+	// present, and those overdue as of now or unassigned
+	Summarize(ctx context.Context, projectID int64, now time.Time) (*entity.TaskSummary, error)
 }
 
 // SubtaskRepository defines the interface for subtask data access
 type SubtaskRepository interface {
 	Create(ctx context.Context, subtask *entity.Subtask) error
+	// CreateMany appends subtasks to a task in one transaction. With max > 0
+	// it fails with ErrLimitExceeded, creating nothing, when the task would
+	// end up with more than max subtasks. Unknown tasks are ErrTaskNotFound.
+	CreateMany(ctx context.Context, taskID int64, subtasks []*entity.Subtask, max int) error
 	GetByID(ctx context.Context, id int64) (*entity.Subtask, error)
 	Update(ctx context.Context, subtask *entity.Subtask) error
+	// MoveToTask moves subtask under taskID, after that task's existing
+	// subtasks. With max > 0 it fails with ErrLimitExceeded when taskID
+	// already has max subtasks. Unknown tasks are ErrTaskNotFound, and
+	// subtasks no longer under subtask.TaskID sql.ErrNoRows.
+	MoveToTask(ctx context.Context, subtask *entity.Subtask, taskID int64, max int) error
 	Delete(ctx context.Context, id int64) error
-	GetByTaskID(ctx context.Context, taskID int64) ([]*entity.Subtask, error)
+	GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.Subtask, int, error)
+	// ProgressByTaskIDs returns subtask counts for the given tasks in one query;
+	// tasks without subtasks are absent from the map
+	ProgressByTaskIDs(ctx context.Context, taskIDs []int64) (map[int64]entity.SubtaskProgress, error)
+	// AssigneesByTaskID returns the distinct users assigned to any of a
+	// task's subtasks
+	AssigneesByTaskID(ctx context.Context, taskID int64) ([]int64, error)
 }
 
 // CommentRepository defines the interface for comment data access
@@ -29,7 +75,12 @@ type CommentRepository interface {
 	Create(ctx context.Context, comment *entity.TaskComment) error
 	GetByID(ctx context.Context, id int64) (*entity.TaskComment, error)
 	Delete(ctx context.Context, id int64) error
-	GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskComment, error)
+	// DeleteByTaskID deletes all comments on a task and returns how many there
+	// were, or ErrTaskNotFound for unknown tasks
+	DeleteByTaskID(ctx context.Context, taskID int64) (int64, error)
+	GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskComment, int, error)
+	// GetByUserID gets a page of a user's comments on any task, newest first
+	GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.TaskComment, int, error)
 }
 
 // AttachmentRepository defines the interface for attachment data access
@@ -37,19 +88,109 @@ type AttachmentRepository interface {
 	Create(ctx context.Context, attachment *entity.TaskAttachment) error
 	GetByID(ctx context.Context, id int64) (*entity.TaskAttachment, error)
 	Delete(ctx context.Context, id int64) error
-	GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskAttachment, error)
+	// DeleteByTaskID deletes all attachments on a task and returns how many
+	// there were, or ErrTaskNotFound for unknown tasks
+	DeleteByTaskID(ctx context.Context, taskID int64) (int64, error)
+	GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskAttachment, int, error)
+	// CountByMediaFileID counts the attachments, on any task, that reference a media file
+	CountByMediaFileID(ctx context.Context, mediaFileID int64) (int, error)
+	// MediaFileIDsByTaskIDs returns the distinct media files attached to any
+	// of the tasks
+	MediaFileIDsByTaskIDs(ctx context.Context, taskIDs []int64) ([]int64, error)
+}
+
+// ErrTaskNotFound is returned by SubtaskRepository.CreateMany and the
+// DeleteByTaskID methods for unknown tasks
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrProjectNotFound is returned by TaskRepository.Move for unknown projects
+var ErrProjectNotFound = errors.New("project not found")
+
+// ErrTagNotFound is returned by TagRepository.GetByName for unknown names and
+// by TagRepository.Delete for unknown IDs
+var ErrTagNotFound = errors.New("tag not found")
+
+// ErrTaskTagNotFound is returned by TaskTagRepository.Remove when the task
+// doesn't have the tag
+var ErrTaskTagNotFound = errors.New("task does not have this tag")
+
+// ErrLimitExceeded is returned by SubtaskRepository.CreateMany and MoveToTask,
+// TaskRepository.ConvertToSubtask and TaskTagRepository.Add when a task is
+// at its limit
+var ErrLimitExceeded = errors.New("task limit exceeded")
+
+// ErrTagInUse is returned by TagRepository.DeleteUnused when tasks still have the tag
+var ErrTagInUse = errors.New("tag is still used by tasks")
+
+// ErrMediaFileNotFound is returned by MediaFileResolver for unknown file IDs
+var ErrMediaFileNotFound = errors.New("media file not found")
+
+// ErrAttachmentNotFound is returned by AttachmentRepository.GetByID for unknown IDs
+var ErrAttachmentNotFound = errors.New("attachment not found")
+
+// MediaFileResolver looks up and deletes files uploaded to the media service
+type MediaFileResolver interface {
+	GetFile(ctx context.Context, id int64) (*entity.MediaFile, error)
+	DeleteFile(ctx context.Context, id int64) error
+}
+
+// ErrUserNotFound is returned by UserDirectory for unknown user IDs
+var ErrUserNotFound = errors.New("user not found")
+
+// UserDirectory looks up users and their project access in the auth service
+type UserDirectory interface {
+	UserRole(ctx context.Context, id int64) (string, error)
+	HasProjectAccess(ctx context.Context, userID, projectID int64) (bool, error)
 }
 
 // TagRepository defines the interface for tag data access
 type TagRepository interface {
 	Create(ctx context.Context, tag *entity.TaskTag) error
 	GetByID(ctx context.Context, id int64) (*entity.TaskTag, error)
+	GetByName(ctx context.Context, name string) (*entity.TaskTag, error)
 	List(ctx context.Context) ([]*entity.TaskTag, error)
+	// Delete deletes a tag and removes it from every task
+	Delete(ctx context.Context, id int64) error
+	// DeleteUnused deletes a tag only if no task has it
+	DeleteUnused(ctx context.Context, id int64) error
 }
 
 // TaskTagRepository defines the interface for task-tag relationship
 type TaskTagRepository interface {
-	Add(ctx context.Context, taskID, tagID int64) error
+	// Add tags a task; adding a tag the task has does nothing. With max > 0
+	// it fails with ErrLimitExceeded when the task already has max other tags.
+	Add(ctx context.Context, taskID, tagID int64, max int) error
 	Remove(ctx context.Context, taskID, tagID int64) error
 	GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskTag, error)
 }
+
+// WatcherRepository defines the interface for task watchers
+type WatcherRepository interface {
+	// Add makes userID a watcher of taskID; adding a watcher again does nothing
+	Add(ctx context.Context, taskID, userID int64) error
+	// Remove stops userID watching taskID; removing a non-watcher does nothing
+	Remove(ctx context.Context, taskID, userID int64) error
+	// GetByTaskID gets a task's watchers in the order they started watching
+	GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskWatcher, error)
+}
+
+// TaskDefaultsRepository reads the task defaults projects set in the project
+// service's settings
+type TaskDefaultsRepository interface {
+	// Get gets a project's task defaults; empty when the project set none
+	Get(ctx context.Context, projectID int64) (*entity.TaskDefaults, error)
+}
+
+// ActivityRepository defines the interface for the task activity log
+type ActivityRepository interface {
+	Record(ctx context.Context, activity *entity.TaskActivity) error
+}
+
+// TaskEventBus fans task changes out to the watchers of a project
+type TaskEventBus interface {
+	Publish(event entity.TaskEvent)
+	// Subscribe returns the events for projectID and a func that ends the
+	// subscription. The channel is closed when the subscription ends,
+	// including when the subscriber falls too far behind.
+	Subscribe(projectID int64) (<-chan entity.TaskEvent, func())
+}
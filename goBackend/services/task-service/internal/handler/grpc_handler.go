@@ -2,11 +2,15 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	pb "github.com/portfolio/proto/task"
+	"github.com/portfolio/shared/middleware"
 	"github.com/portfolio/task-service/internal/domain/entity"
 	"github.com/portfolio/task-service/internal/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -18,6 +22,7 @@ type TaskHandler struct {
 	commentUC    *usecase.CommentUseCase
 	attachmentUC *usecase.AttachmentUseCase
 	tagUC        *usecase.TagUseCase
+	watcherUC    *usecase.WatcherUseCase
 }
 
 // NewTaskHandler creates a new TaskHandler
@@ -27,6 +32,7 @@ func NewTaskHandler(
 	commentUC *usecase.CommentUseCase,
 	attachmentUC *usecase.AttachmentUseCase,
 	tagUC *usecase.TagUseCase,
+	watcherUC *usecase.WatcherUseCase,
 ) *TaskHandler {
 	return &TaskHandler{
 		taskUC:       taskUC,
@@ -34,6 +40,7 @@ func NewTaskHandler(
 		commentUC:    commentUC,
 		attachmentUC: attachmentUC,
 		tagUC:        tagUC,
+		watcherUC:    watcherUC,
 	}
 }
 
@@ -48,6 +55,10 @@ func (h *TaskHandler) CreateTask(ctx context.Context, req *pb.CreateTaskRequest)
 
 	task, err := h.taskUC.CreateTask(ctx, req.ProjectId, req.Title, req.Description, req.Status, int(req.Priority), req.AssignedTo, dueDate)
 	if err != nil {
+		switch err {
+		case usecase.ErrDueDateInPast, usecase.ErrInvalidPriority, usecase.ErrUnknownAssignee, usecase.ErrAssigneeNoAccess:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
 
@@ -57,7 +68,10 @@ func (h *TaskHandler) CreateTask(ctx context.Context, req *pb.CreateTaskRequest)
 func (h *TaskHandler) GetTask(ctx context.Context, req *pb.GetTaskRequest) (*pb.TaskResponse, error) {
 	task, err := h.taskUC.GetTask(ctx, req.Id)
 	if err != nil {
-		return nil, err
+		if err == usecase.ErrTaskNotFound {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
 	}
 	return &pb.TaskResponse{Task: mapTaskToProto(task)}, nil
 }
@@ -69,8 +83,15 @@ func (h *TaskHandler) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest)
 		dueDate = &t
 	}
 
-	task, err := h.taskUC.UpdateTask(ctx, req.Id, req.Title, req.Description, req.Status, int(req.Priority), req.AssignedTo, dueDate)
+	clears := usecase.TaskClears{AssignedTo: req.ClearAssignedTo, DueDate: req.ClearDueDate, Description: req.ClearDescription}
+	task, err := h.taskUC.UpdateTask(ctx, req.Id, req.Title, req.Description, req.Status, int(req.Priority), req.AssignedTo, dueDate, clears)
 	if err != nil {
+		switch err {
+		case usecase.ErrDueDateInPast, usecase.ErrConflictingUpdate, usecase.ErrInvalidPriority, usecase.ErrUnknownAssignee, usecase.ErrAssigneeNoAccess:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
 		return nil, err
 	}
 
@@ -78,7 +99,7 @@ func (h *TaskHandler) UpdateTask(ctx context.Context, req *pb.UpdateTaskRequest)
 }
 
 func (h *TaskHandler) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest) (*pb.Empty, error) {
-	err := h.taskUC.DeleteTask(ctx, req.Id)
+	err := h.taskUC.DeleteTask(ctx, req.Id, callerID(ctx))
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +107,10 @@ func (h *TaskHandler) DeleteTask(ctx context.Context, req *pb.DeleteTaskRequest)
 }
 
 func (h *TaskHandler) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
-	tasks, total, err := h.taskUC.ListTasks(ctx, req.ProjectId, int(req.Page), int(req.Limit), req.Status, req.AssignedTo)
+	if req.Cursor != nil {
+		return h.listTasksAfter(ctx, req)
+	}
+	tasks, total, err := h.taskUC.ListTasks(ctx, req.ProjectId, int(req.Page), int(req.Limit), req.Status, req.AssignedTo, tagQuery(req))
 	if err != nil {
 		return nil, err
 	}
@@ -99,9 +123,203 @@ func (h *TaskHandler) ListTasks(ctx context.Context, req *pb.ListTasksRequest) (
 	return &pb.ListTasksResponse{
 		Tasks: protoTasks,
 		Total: int32(total),
+		Limit: int32(h.taskUC.PageLimit(int(req.Limit))),
 	}, nil
 }
 
+func (h *TaskHandler) listTasksAfter(ctx context.Context, req *pb.ListTasksRequest) (*pb.ListTasksResponse, error) {
+	tasks, next, err := h.taskUC.ListTasksAfter(ctx, req.ProjectId, req.GetCursor(), int(req.Limit), req.Status, req.AssignedTo, tagQuery(req))
+	if err != nil {
+		if err == usecase.ErrInvalidCursor {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+
+	var protoTasks []*pb.Task
+	for _, t := range tasks {
+		protoTasks = append(protoTasks, mapTaskToProto(t))
+	}
+
+	return &pb.ListTasksResponse{
+		Tasks:      protoTasks,
+		NextCursor: next,
+		Limit:      int32(h.taskUC.PageLimit(int(req.Limit))),
+	}, nil
+}
+
+// tagQuery combines the single tag_id and the tag_ids list of a list request
+func tagQuery(req *pb.ListTasksRequest) usecase.TagQuery {
+	ids := req.TagIds
+	if req.TagId > 0 {
+		ids = append([]int64{req.TagId}, ids...)
+	}
+	return usecase.TagQuery{IDs: ids, Name: req.Tag, MatchAny: req.MatchAnyTag}
+}
+
+// StreamTasks sends the matching tasks of a project in batches for exports
+func (h *TaskHandler) StreamTasks(req *pb.StreamTasksRequest, stream pb.TaskService_StreamTasksServer) error {
+	tags := usecase.TagQuery{IDs: req.TagIds, Name: req.Tag, MatchAny: req.MatchAnyTag}
+	err := h.taskUC.StreamTasks(stream.Context(), req.ProjectId, req.Status, req.AssignedTo, tags, int(req.BatchSize), func(tasks []*entity.Task) error {
+		batch := &pb.TaskBatch{Tasks: make([]*pb.Task, len(tasks))}
+		for i, t := range tasks {
+			batch.Tasks[i] = mapTaskToProto(t)
+		}
+		return stream.Send(batch)
+	})
+	if err == usecase.ErrProjectRequired {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return err
+}
+
+func (h *TaskHandler) ListTaskIDs(ctx context.Context, req *pb.ListTaskIDsRequest) (*pb.ListTaskIDsResponse, error) {
+	versions, err := h.taskUC.ListTaskVersions(ctx, req.ProjectId)
+	if err != nil {
+		if err == usecase.ErrProjectRequired {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoVersions := make([]*pb.TaskVersion, 0, len(versions))
+	for _, v := range versions {
+		protoVersions = append(protoVersions, &pb.TaskVersion{
+			Id:        v.ID,
+			UpdatedAt: timestamppb.New(v.UpdatedAt),
+		})
+	}
+	return &pb.ListTaskIDsResponse{Tasks: protoVersions}, nil
+}
+
+// CountProjectTasks counts the tasks of every project that has any
+func (h *TaskHandler) CountProjectTasks(ctx context.Context, req *pb.Empty) (*pb.CountProjectTasksResponse, error) {
+	counts, err := h.taskUC.CountTasksByProject(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.CountProjectTasksResponse{}
+	for _, c := range counts {
+		resp.Projects = append(resp.Projects, &pb.ProjectTaskCount{
+			ProjectId:      c.ProjectID,
+			TotalTasks:     int32(c.Total),
+			CompletedTasks: int32(c.Completed),
+		})
+	}
+	return resp, nil
+}
+
+// GetTaskSummary counts a project's tasks per status, and those overdue or unassigned
+func (h *TaskHandler) GetTaskSummary(ctx context.Context, req *pb.GetTaskSummaryRequest) (*pb.TaskSummaryResponse, error) {
+	summary, err := h.taskUC.GetTaskSummary(ctx, req.ProjectId)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	resp := &pb.TaskSummaryResponse{
+		ProjectId:    summary.ProjectID,
+		Total:        int32(summary.Total),
+		StatusCounts: make(map[string]int32, len(summary.StatusCounts)),
+		Overdue:      int32(summary.Overdue),
+		Unassigned:   int32(summary.Unassigned),
+	}
+	for s, n := range summary.StatusCounts {
+		resp.StatusCounts[s] = int32(n)
+	}
+	return resp, nil
+}
+
+func (h *TaskHandler) BulkDeleteTasks(ctx context.Context, req *pb.BulkDeleteTasksRequest) (*pb.BulkDeleteTasksResponse, error) {
+	deleted, err := h.taskUC.BulkDelete(ctx, req.Ids, callerID(ctx))
+	if err != nil {
+		if err == usecase.ErrNoTaskIDs {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	return &pb.BulkDeleteTasksResponse{Deleted: int32(deleted)}, nil
+}
+
+func (h *TaskHandler) DemoteTask(ctx context.Context, req *pb.DemoteTaskRequest) (*pb.SubtaskResponse, error) {
+	subtask, err := h.taskUC.Demote(ctx, req.Id, req.ParentTaskId, callerID(ctx))
+	if err != nil {
+		if st := limitStatus(err); st != nil {
+			return nil, st
+		}
+		switch err {
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "task not found")
+		case usecase.ErrInvalidParent:
+			return nil, status.Error(codes.InvalidArgument, "parent must be another task in the same project")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SubtaskResponse{Subtask: mapSubtaskToProto(subtask)}, nil
+}
+
+func (h *TaskHandler) DuplicateTask(ctx context.Context, req *pb.DuplicateTaskRequest) (*pb.TaskResponse, error) {
+	task, err := h.taskUC.DuplicateTask(ctx, req.Id, req.KeepDueDate)
+	if err != nil {
+		switch err {
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "task not found")
+		case usecase.ErrDueDateInPast:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.TaskResponse{Task: mapTaskToProto(task)}, nil
+}
+
+// MoveTask moves a task to another project
+func (h *TaskHandler) MoveTask(ctx context.Context, req *pb.MoveTaskRequest) (*pb.TaskResponse, error) {
+	task, err := h.taskUC.MoveTask(ctx, req.Id, req.ProjectId, req.ClearAssignees)
+	if err != nil {
+		switch err {
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "task not found")
+		case usecase.ErrProjectNotFound:
+			return nil, status.Error(codes.NotFound, err.Error())
+		case usecase.ErrProjectRequired, usecase.ErrSameProject, usecase.ErrUnknownAssignee, usecase.ErrAssigneeNoAccess:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.TaskResponse{Task: mapTaskToProto(task)}, nil
+}
+
+// WatchProjectTasks streams the task changes in a project until the client
+// goes away. The stream ends when the client falls too far behind; clients
+// should reload the project and watch again.
+func (h *TaskHandler) WatchProjectTasks(req *pb.WatchProjectTasksRequest, stream pb.TaskService_WatchProjectTasksServer) error {
+	events, cancel, err := h.taskUC.WatchProject(req.ProjectId)
+	if err != nil {
+		switch err {
+		case usecase.ErrProjectRequired:
+			return status.Error(codes.InvalidArgument, err.Error())
+		case usecase.ErrEventsUnavailable:
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer cancel()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if err := stream.Send(mapTaskEventToProto(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // --- Subtasks ---
 
 func (h *TaskHandler) CreateSubtask(ctx context.Context, req *pb.CreateSubtaskRequest) (*pb.SubtaskResponse, error) {
@@ -113,11 +331,42 @@ func (h *TaskHandler) CreateSubtask(ctx context.Context, req *pb.CreateSubtaskRe
 
 	subtask, err := h.subtaskUC.CreateSubtask(ctx, req.TaskId, req.Title, req.AssignedTo, dueDate)
 	if err != nil {
+		if st := limitStatus(err); st != nil {
+			return nil, st
+		}
+		if st := assigneeStatus(err); st != nil {
+			return nil, st
+		}
+		if err == usecase.ErrTaskNotFound {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
 		return nil, err
 	}
 	return &pb.SubtaskResponse{Subtask: mapSubtaskToProto(subtask)}, nil
 }
 
+func (h *TaskHandler) CreateSubtasks(ctx context.Context, req *pb.CreateSubtasksRequest) (*pb.ListSubtasksResponse, error) {
+	subtasks, err := h.subtaskUC.CreateMany(ctx, req.TaskId, req.Titles)
+	if err != nil {
+		if st := limitStatus(err); st != nil {
+			return nil, st
+		}
+		switch err {
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "task not found")
+		case usecase.ErrNoSubtaskTitles, usecase.ErrTooManySubtasks, usecase.ErrEmptySubtaskTitle:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	protoSubtasks := make([]*pb.Subtask, len(subtasks))
+	for i, s := range subtasks {
+		protoSubtasks[i] = mapSubtaskToProto(s)
+	}
+	return &pb.ListSubtasksResponse{Subtasks: protoSubtasks, Total: int32(len(subtasks))}, nil
+}
+
 func (h *TaskHandler) UpdateSubtask(ctx context.Context, req *pb.UpdateSubtaskRequest) (*pb.SubtaskResponse, error) {
 	var dueDate *time.Time
 	if req.DueDate != nil {
@@ -127,6 +376,9 @@ func (h *TaskHandler) UpdateSubtask(ctx context.Context, req *pb.UpdateSubtaskRe
 
 	subtask, err := h.subtaskUC.UpdateSubtask(ctx, req.Id, req.Title, req.Status, req.AssignedTo, dueDate)
 	if err != nil {
+		if st := assigneeStatus(err); st != nil {
+			return nil, st
+		}
 		return nil, err
 	}
 	return &pb.SubtaskResponse{Subtask: mapSubtaskToProto(subtask)}, nil
@@ -140,8 +392,42 @@ func (h *TaskHandler) DeleteSubtask(ctx context.Context, req *pb.DeleteSubtaskRe
 	return &pb.Empty{}, nil
 }
 
+func (h *TaskHandler) MoveSubtask(ctx context.Context, req *pb.MoveSubtaskRequest) (*pb.SubtaskResponse, error) {
+	subtask, err := h.subtaskUC.MoveToTask(ctx, req.Id, req.FromTaskId, req.TaskId)
+	if err != nil {
+		if st := limitStatus(err); st != nil {
+			return nil, st
+		}
+		if st := assigneeStatus(err); st != nil {
+			return nil, st
+		}
+		switch err {
+		case usecase.ErrSubtaskNotFound:
+			return nil, status.Error(codes.NotFound, "subtask not found")
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "destination task not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.SubtaskResponse{Subtask: mapSubtaskToProto(subtask)}, nil
+}
+
+func (h *TaskHandler) PromoteSubtask(ctx context.Context, req *pb.PromoteSubtaskRequest) (*pb.TaskResponse, error) {
+	task, err := h.subtaskUC.Promote(ctx, req.Id, req.TaskId)
+	if err != nil {
+		switch err {
+		case usecase.ErrSubtaskNotFound:
+			return nil, status.Error(codes.NotFound, "subtask not found")
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "parent task not found")
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.TaskResponse{Task: mapTaskToProto(task)}, nil
+}
+
 func (h *TaskHandler) ListSubtasks(ctx context.Context, req *pb.ListSubtasksRequest) (*pb.ListSubtasksResponse, error) {
-	subtasks, err := h.subtaskUC.GetSubtasks(ctx, req.TaskId)
+	subtasks, total, err := h.subtaskUC.GetSubtasks(ctx, req.TaskId, int(req.Page), int(req.Limit))
 	if err != nil {
 		return nil, err
 	}
@@ -151,13 +437,31 @@ func (h *TaskHandler) ListSubtasks(ctx context.Context, req *pb.ListSubtasksRequ
 		protoSubtasks = append(protoSubtasks, mapSubtaskToProto(s))
 	}
 
-	return &pb.ListSubtasksResponse{Subtasks: protoSubtasks}, nil
+	return &pb.ListSubtasksResponse{
+		Subtasks: protoSubtasks,
+		Total:    int32(total),
+		Limit:    int32(h.subtaskUC.PageLimit(int(req.Limit))),
+	}, nil
+}
+
+// callerID is the forwarded caller's user ID, or 0 when there is none. Media
+// files are only deleted on behalf of the user who uploaded them, so deletes
+// without a caller leave them in place.
+func callerID(ctx context.Context) int64 {
+	caller, _ := middleware.CallerFromContext(ctx)
+	return caller.UserID
 }
 
 // --- Comments ---
 
+// AddComment adds a comment written by the caller forwarded in the metadata;
+// the request's user_id is ignored so authors can't be spoofed
 func (h *TaskHandler) AddComment(ctx context.Context, req *pb.AddCommentRequest) (*pb.CommentResponse, error) {
-	comment, err := h.commentUC.AddComment(ctx, req.TaskId, req.UserId, req.Comment)
+	caller, ok := middleware.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "caller identity required")
+	}
+	comment, err := h.commentUC.AddComment(ctx, req.TaskId, caller.UserID, req.Comment)
 	if err != nil {
 		return nil, err
 	}
@@ -180,8 +484,40 @@ func (h *TaskHandler) DeleteComment(ctx context.Context, req *pb.DeleteCommentRe
 	return &pb.Empty{}, nil
 }
 
+func (h *TaskHandler) DeleteTaskComments(ctx context.Context, req *pb.DeleteTaskCommentsRequest) (*pb.DeleteTaskCommentsResponse, error) {
+	deleted, err := h.commentUC.DeleteByTaskID(ctx, req.TaskId)
+	if err != nil {
+		if err == usecase.ErrTaskNotFound {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		return nil, err
+	}
+	return &pb.DeleteTaskCommentsResponse{Deleted: int32(deleted)}, nil
+}
+
+// ListComments lists a task's comments or, with user_id, a user's comments
+// on every task. Only the user themselves and admins may list the latter.
 func (h *TaskHandler) ListComments(ctx context.Context, req *pb.ListCommentsRequest) (*pb.ListCommentsResponse, error) {
-	comments, err := h.commentUC.GetComments(ctx, req.TaskId)
+	var (
+		comments []*entity.TaskComment
+		total    int
+		err      error
+	)
+	if req.UserId != 0 {
+		if req.TaskId != 0 {
+			return nil, status.Error(codes.InvalidArgument, "only one of task_id or user_id may be set")
+		}
+		caller, ok := middleware.CallerFromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "caller identity required")
+		}
+		if caller.UserID != req.UserId && caller.Role != "admin" {
+			return nil, status.Error(codes.PermissionDenied, "cannot list another user's comments")
+		}
+		comments, total, err = h.commentUC.GetUserComments(ctx, req.UserId, int(req.Page), int(req.Limit))
+	} else {
+		comments, total, err = h.commentUC.GetComments(ctx, req.TaskId, int(req.Page), int(req.Limit))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -197,51 +533,84 @@ func (h *TaskHandler) ListComments(ctx context.Context, req *pb.ListCommentsRequ
 		})
 	}
 
-	return &pb.ListCommentsResponse{Comments: protoComments}, nil
+	return &pb.ListCommentsResponse{
+		Comments: protoComments,
+		Total:    int32(total),
+		Limit:    int32(h.commentUC.PageLimit(int(req.Limit))),
+	}, nil
 }
 
 // --- Attachments ---
 
 func (h *TaskHandler) AddAttachment(ctx context.Context, req *pb.AddAttachmentRequest) (*pb.AttachmentResponse, error) {
-	attachment, err := h.attachmentUC.AddAttachment(ctx, req.TaskId, req.FileUrl)
+	var attachment *entity.TaskAttachment
+	var err error
+	switch {
+	case req.MediaFileId != 0 && req.FileUrl != "":
+		return nil, status.Error(codes.InvalidArgument, "only one of file_url or media_file_id may be set")
+	case req.MediaFileId != 0:
+		caller, ok := middleware.CallerFromContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.Unauthenticated, "caller identity required")
+		}
+		// Admins may attach anyone's upload
+		uploaderID := caller.UserID
+		if caller.Role == "admin" {
+			uploaderID = 0
+		}
+		attachment, err = h.attachmentUC.AddMediaAttachment(ctx, req.TaskId, req.MediaFileId, uploaderID)
+	default:
+		attachment, err = h.attachmentUC.AddAttachment(ctx, req.TaskId, req.FileUrl)
+	}
 	if err != nil {
+		switch err {
+		case usecase.ErrMediaFileNotFound:
+			return nil, status.Error(codes.NotFound, err.Error())
+		case usecase.ErrMediaFileNotOwned:
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		case usecase.ErrFileURLRequired:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, err
 	}
-	return &pb.AttachmentResponse{
-		Attachment: &pb.Attachment{
-			Id:         attachment.ID,
-			TaskId:     attachment.TaskID,
-			FileUrl:    attachment.FileURL,
-			UploadedAt: timestamppb.New(attachment.UploadedAt),
-		},
-	}, nil
+	return &pb.AttachmentResponse{Attachment: mapAttachmentToProto(attachment)}, nil
 }
 
 func (h *TaskHandler) DeleteAttachment(ctx context.Context, req *pb.DeleteAttachmentRequest) (*pb.Empty, error) {
-	err := h.attachmentUC.DeleteAttachment(ctx, req.Id)
+	err := h.attachmentUC.DeleteAttachment(ctx, req.Id, callerID(ctx))
 	if err != nil {
 		return nil, err
 	}
 	return &pb.Empty{}, nil
 }
 
+func (h *TaskHandler) DeleteTaskAttachments(ctx context.Context, req *pb.DeleteTaskAttachmentsRequest) (*pb.DeleteTaskAttachmentsResponse, error) {
+	deleted, err := h.attachmentUC.DeleteByTaskID(ctx, req.TaskId, callerID(ctx))
+	if err != nil {
+		if err == usecase.ErrTaskNotFound {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		return nil, err
+	}
+	return &pb.DeleteTaskAttachmentsResponse{Deleted: int32(deleted)}, nil
+}
+
 func (h *TaskHandler) ListAttachments(ctx context.Context, req *pb.ListAttachmentsRequest) (*pb.ListAttachmentsResponse, error) {
-	attachments, err := h.attachmentUC.GetAttachments(ctx, req.TaskId)
+	attachments, total, err := h.attachmentUC.GetAttachments(ctx, req.TaskId, int(req.Page), int(req.Limit))
 	if err != nil {
 		return nil, err
 	}
 
 	var protoAttachments []*pb.Attachment
 	for _, a := range attachments {
-		protoAttachments = append(protoAttachments, &pb.Attachment{
-			Id:         a.ID,
-			TaskId:     a.TaskID,
-			FileUrl:    a.FileURL,
-			UploadedAt: timestamppb.New(a.UploadedAt),
-		})
+		protoAttachments = append(protoAttachments, mapAttachmentToProto(a))
 	}
 
-	return &pb.ListAttachmentsResponse{Attachments: protoAttachments}, nil
+	return &pb.ListAttachmentsResponse{
+		Attachments: protoAttachments,
+		Total:       int32(total),
+		Limit:       int32(h.attachmentUC.PageLimit(int(req.Limit))),
+	}, nil
 }
 
 // --- Tags ---
@@ -271,6 +640,9 @@ func (h *TaskHandler) ListTags(ctx context.Context, req *pb.Empty) (*pb.ListTags
 func (h *TaskHandler) AddTaskTag(ctx context.Context, req *pb.AddTaskTagRequest) (*pb.Empty, error) {
 	err := h.tagUC.AddTaskTag(ctx, req.TaskId, req.TagId)
 	if err != nil {
+		if st := limitStatus(err); st != nil {
+			return nil, st
+		}
 		return nil, err
 	}
 	return &pb.Empty{}, nil
@@ -279,13 +651,127 @@ func (h *TaskHandler) AddTaskTag(ctx context.Context, req *pb.AddTaskTagRequest)
 func (h *TaskHandler) RemoveTaskTag(ctx context.Context, req *pb.RemoveTaskTagRequest) (*pb.Empty, error) {
 	err := h.tagUC.RemoveTaskTag(ctx, req.TaskId, req.TagId)
 	if err != nil {
+		if err == usecase.ErrTaskTagNotFound {
+			return nil, status.Error(codes.NotFound, "task does not have this tag")
+		}
 		return nil, err
 	}
 	return &pb.Empty{}, nil
 }
 
+func (h *TaskHandler) DeleteTag(ctx context.Context, req *pb.DeleteTagRequest) (*pb.Empty, error) {
+	err := h.tagUC.DeleteTag(ctx, req.Id)
+	if err != nil {
+		switch err {
+		case usecase.ErrTagNotFound:
+			return nil, status.Error(codes.NotFound, "tag not found")
+		case usecase.ErrTagInUse:
+			return nil, status.Error(codes.FailedPrecondition, "tag is still used by tasks")
+		}
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+// --- Watchers ---
+
+// watcherTarget is the user a watcher request is about: the caller unless
+// userID names someone else, which only admins may when others is false
+func watcherTarget(ctx context.Context, userID int64, others bool) (int64, error) {
+	caller, ok := middleware.CallerFromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, "caller identity required")
+	}
+	if userID == 0 {
+		return caller.UserID, nil
+	}
+	if userID != caller.UserID && !others && caller.Role != "admin" {
+		return 0, status.Error(codes.PermissionDenied, "cannot change another user's watches")
+	}
+	return userID, nil
+}
+
+func (h *TaskHandler) AddWatcher(ctx context.Context, req *pb.AddWatcherRequest) (*pb.Empty, error) {
+	userID, err := watcherTarget(ctx, req.UserId, false)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.watcherUC.AddWatcher(ctx, req.TaskId, userID); err != nil {
+		switch err {
+		case usecase.ErrTaskNotFound:
+			return nil, status.Error(codes.NotFound, "task not found")
+		case usecase.ErrUnknownWatcher, usecase.ErrWatcherNoAccess:
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *TaskHandler) RemoveWatcher(ctx context.Context, req *pb.RemoveWatcherRequest) (*pb.Empty, error) {
+	// The gateway lets users with write access to the project remove others
+	userID, err := watcherTarget(ctx, req.UserId, true)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.watcherUC.RemoveWatcher(ctx, req.TaskId, userID); err != nil {
+		return nil, err
+	}
+	return &pb.Empty{}, nil
+}
+
+func (h *TaskHandler) ListWatchers(ctx context.Context, req *pb.ListWatchersRequest) (*pb.ListWatchersResponse, error) {
+	watchers, err := h.watcherUC.ListWatchers(ctx, req.TaskId)
+	if err != nil {
+		if err == usecase.ErrTaskNotFound {
+			return nil, status.Error(codes.NotFound, "task not found")
+		}
+		return nil, err
+	}
+
+	resp := &pb.ListWatchersResponse{}
+	for _, w := range watchers {
+		resp.Watchers = append(resp.Watchers, &pb.Watcher{
+			TaskId:    w.TaskID,
+			UserId:    w.UserID,
+			CreatedAt: timestamppb.New(w.CreatedAt),
+		})
+	}
+	return resp, nil
+}
+
 // --- Helpers ---
 
+// limitStatus maps a per-task limit violation to FailedPrecondition, or returns nil
+func limitStatus(err error) error {
+	var limitErr *usecase.LimitExceededError
+	if errors.As(err, &limitErr) {
+		return status.Error(codes.FailedPrecondition, limitErr.Error())
+	}
+	return nil
+}
+
+// assigneeStatus maps a rejected assignee to InvalidArgument, or returns nil
+func assigneeStatus(err error) error {
+	if err == usecase.ErrUnknownAssignee || err == usecase.ErrAssigneeNoAccess {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
+
+func mapTaskEventToProto(e entity.TaskEvent) *pb.TaskEvent {
+	event := &pb.TaskEvent{
+		Type:       e.Type,
+		ProjectId:  e.ProjectID,
+		TaskId:     e.TaskID,
+		OccurredAt: timestamppb.New(e.OccurredAt),
+	}
+	if e.Task != nil {
+		event.Task = mapTaskToProto(e.Task)
+	}
+	return event
+}
+
 func mapTaskToProto(t *entity.Task) *pb.Task {
 	var subtasks []*pb.Subtask
 	for _, s := range t.Subtasks {
@@ -320,6 +806,7 @@ func mapTaskToProto(t *entity.Task) *pb.Task {
 		Tags:        tags,
 		CreatedAt:   timestamppb.New(t.CreatedAt),
 		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+		Progress:    t.Progress,
 	}
 }
 
@@ -328,15 +815,31 @@ func mapSubtaskToProto(s *entity.Subtask) *pb.Subtask {
 	if s.DueDate != nil {
 		dueDate = timestamppb.New(*s.DueDate)
 	}
+	var assignedTo int64
+	if s.AssignedTo != nil {
+		assignedTo = *s.AssignedTo
+	}
 
 	return &pb.Subtask{
 		Id:         s.ID,
 		TaskId:     s.TaskID,
 		Title:      s.Title,
 		Status:     s.Status,
-		AssignedTo: s.AssignedTo,
+		AssignedTo: assignedTo,
 		DueDate:    dueDate,
 		CreatedAt:  timestamppb.New(s.CreatedAt),
 		UpdatedAt:  timestamppb.New(s.UpdatedAt),
+		Position:   int32(s.Position),
+	}
+}
+
+func mapAttachmentToProto(a *entity.TaskAttachment) *pb.Attachment {
+	return &pb.Attachment{
+		Id:          a.ID,
+		TaskId:      a.TaskID,
+		FileUrl:     a.FileURL,
+		UploadedAt:  timestamppb.New(a.UploadedAt),
+		Type:        a.Type,
+		MediaFileId: a.MediaFileID,
 	}
 }
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	pb "github.com/portfolio/proto/task"
+	"github.com/portfolio/shared/pagination"
+	"github.com/portfolio/task-service/internal/testutil"
+	"github.com/portfolio/task-service/internal/usecase"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestHandler wires a TaskHandler to in-memory repositories sharing store
+func newTestHandler(store *testutil.Store) *TaskHandler {
+	taskUC := usecase.NewTaskUseCase(
+		testutil.NewTaskRepository(store),
+		testutil.NewSubtaskRepository(store),
+		testutil.NewCommentRepository(store),
+		testutil.NewAttachmentRepository(store),
+		nil,
+		testutil.NewTagRepository(store),
+		testutil.NewTaskTagRepository(store),
+		testutil.NewActivityRepository(store),
+		testutil.NewTaskDefaultsRepository(store),
+		usecase.Limits{},
+		usecase.AllowPastDueDates,
+		usecase.AssigneeCheck{},
+		nil,
+		pagination.Sizes{},
+	)
+	return NewTaskHandler(taskUC, nil, nil, nil, nil, nil)
+}
+
+func TestTaskHandler_BulkDeleteTasks(t *testing.T) {
+	ctx := context.Background()
+	h := newTestHandler(testutil.NewStore())
+
+	var ids []int64
+	for _, title := range []string{"Draft", "Review"} {
+		resp, err := h.CreateTask(ctx, &pb.CreateTaskRequest{ProjectId: 1, Title: title})
+		if err != nil {
+			t.Fatalf("CreateTask() error = %v", err)
+		}
+		ids = append(ids, resp.Task.Id)
+	}
+
+	resp, err := h.BulkDeleteTasks(ctx, &pb.BulkDeleteTasksRequest{Ids: append(ids, ids[0], 99)})
+	if err != nil {
+		t.Fatalf("BulkDeleteTasks() error = %v", err)
+	}
+	if resp.Deleted != 2 {
+		t.Errorf("deleted = %d, want 2", resp.Deleted)
+	}
+	if _, err := h.GetTask(ctx, &pb.GetTaskRequest{Id: ids[0]}); status.Code(err) != codes.NotFound {
+		t.Errorf("GetTask(deleted) code = %v, want NotFound", status.Code(err))
+	}
+
+	for _, req := range []*pb.BulkDeleteTasksRequest{{}, {Ids: []int64{0, -1}}} {
+		if _, err := h.BulkDeleteTasks(ctx, req); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("BulkDeleteTasks(%v) error = %v, want InvalidArgument", req.Ids, err)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	authpb "github.com/portfolio/proto/auth"
+	"github.com/portfolio/task-service/internal/domain/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client implements UserDirectory on top of the auth service
+type Client struct {
+	authClient authpb.AuthServiceClient
+}
+
+// NewClient creates a new auth service client
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{authClient: authpb.NewAuthServiceClient(conn)}
+}
+
+// UserRole returns the role of a user, or ErrUserNotFound
+func (c *Client) UserRole(ctx context.Context, id int64) (string, error) {
+	resp, err := c.authClient.GetUser(ctx, &authpb.GetUserRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return "", repository.ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user %d: %w", id, err)
+	}
+	return resp.User.GetRole(), nil
+}
+
+// HasProjectAccess reports whether the user holds any access level on the
+// project. Every level includes read access.
+func (c *Client) HasProjectAccess(ctx context.Context, userID, projectID int64) (bool, error) {
+	resp, err := c.authClient.CheckAccess(ctx, &authpb.CheckAccessRequest{UserId: userID, ProjectId: projectID})
+	if err != nil {
+		return false, fmt.Errorf("failed to check project access of user %d: %w", userID, err)
+	}
+	return resp.Allowed, nil
+}
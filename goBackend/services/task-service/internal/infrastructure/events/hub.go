@@ -0,0 +1,86 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/portfolio/task-service/internal/domain/entity"
+	domain "github.com/portfolio/task-service/internal/domain/repository"
+)
+
+// DefaultBuffer is how many events a subscriber may lag behind before it
+// is dropped
+const DefaultBuffer = 64
+
+// Hub is an in-process TaskEventBus. It only sees changes made by this task
+// service instance.
+type Hub struct {
+	buffer int
+
+	mu   sync.Mutex
+	subs map[int64]map[*subscription]struct{}
+}
+
+type subscription struct {
+	ch     chan entity.TaskEvent
+	closed bool
+}
+
+var _ domain.TaskEventBus = (*Hub)(nil)
+
+// NewHub creates a Hub whose subscribers buffer up to buffer events
+func NewHub(buffer int) *Hub {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Hub{buffer: buffer, subs: make(map[int64]map[*subscription]struct{})}
+}
+
+// Subscribe returns the events published for projectID
+func (h *Hub) Subscribe(projectID int64) (<-chan entity.TaskEvent, func()) {
+	sub := &subscription{ch: make(chan entity.TaskEvent, h.buffer)}
+
+	h.mu.Lock()
+	if h.subs[projectID] == nil {
+		h.subs[projectID] = make(map[*subscription]struct{})
+	}
+	h.subs[projectID][sub] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			h.remove(projectID, sub)
+		})
+	}
+	return sub.ch, cancel
+}
+
+// Publish delivers event to the subscribers of its project without blocking.
+// A subscriber whose buffer is full is dropped, so it can reconnect and
+// reload instead of silently missing changes.
+func (h *Hub) Publish(event entity.TaskEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs[event.ProjectID] {
+		select {
+		case sub.ch <- event:
+		default:
+			h.remove(event.ProjectID, sub)
+		}
+	}
+}
+
+// remove ends sub; h.mu must be held
+func (h *Hub) remove(projectID int64, sub *subscription) {
+	if sub.closed {
+		return
+	}
+	sub.closed = true
+	close(sub.ch)
+	delete(h.subs[projectID], sub)
+	if len(h.subs[projectID]) == 0 {
+		delete(h.subs, projectID)
+	}
+}
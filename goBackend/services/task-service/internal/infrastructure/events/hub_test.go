@@ -0,0 +1,64 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/portfolio/task-service/internal/domain/entity"
+)
+
+func TestHub_DeliversToProjectSubscribers(t *testing.T) {
+	hub := NewHub(4)
+	board, cancel := hub.Subscribe(10)
+	defer cancel()
+	other, cancelOther := hub.Subscribe(20)
+	defer cancelOther()
+
+	hub.Publish(entity.NewTaskEvent(entity.TaskUpdated, &entity.Task{ID: 1, ProjectID: 10, Title: "Plan"}))
+
+	select {
+	case event := <-board:
+		if event.Type != entity.TaskUpdated || event.TaskID != 1 || event.Task == nil || event.Task.Title != "Plan" {
+			t.Errorf("event = %+v, want task.updated for task 1", event)
+		}
+	default:
+		t.Fatal("subscriber of project 10 got no event")
+	}
+	select {
+	case event := <-other:
+		t.Errorf("subscriber of project 20 got %+v", event)
+	default:
+	}
+}
+
+func TestHub_Cancel(t *testing.T) {
+	hub := NewHub(4)
+	events, cancel := hub.Subscribe(10)
+	cancel()
+	cancel() // safe to call twice
+
+	if _, ok := <-events; ok {
+		t.Fatal("channel still open after cancel")
+	}
+	hub.Publish(entity.NewTaskEvent(entity.TaskDeleted, &entity.Task{ID: 1, ProjectID: 10}))
+	if len(hub.subs) != 0 {
+		t.Errorf("hub kept %d projects after the last subscriber left", len(hub.subs))
+	}
+}
+
+func TestHub_DropsSlowSubscribers(t *testing.T) {
+	hub := NewHub(1)
+	events, cancel := hub.Subscribe(10)
+	defer cancel()
+
+	for id := int64(1); id <= 3; id++ {
+		hub.Publish(entity.NewTaskEvent(entity.TaskCreated, &entity.Task{ID: id, ProjectID: 10}))
+	}
+
+	var got []int64
+	for event := range events {
+		got = append(got, event.TaskID)
+	}
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("slow subscriber got %v before being dropped, want [1]", got)
+	}
+}
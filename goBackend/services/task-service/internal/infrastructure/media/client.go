@@ -0,0 +1,46 @@
+package media
+
+import (
+	"context"
+	"fmt"
+
+	mediapb "github.com/portfolio/proto/media"
+	"github.com/portfolio/task-service/internal/domain/entity"
+	"github.com/portfolio/task-service/internal/domain/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Client implements MediaFileResolver on top of the media service
+type Client struct {
+	mediaClient mediapb.MediaServiceClient
+}
+
+// NewClient creates a new media service client
+func NewClient(conn grpc.ClientConnInterface) *Client {
+	return &Client{mediaClient: mediapb.NewMediaServiceClient(conn)}
+}
+
+// GetFile returns an uploaded file, or ErrMediaFileNotFound
+func (c *Client) GetFile(ctx context.Context, id int64) (*entity.MediaFile, error) {
+	resp, err := c.mediaClient.GetFile(ctx, &mediapb.GetFileRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, repository.ErrMediaFileNotFound
+		}
+		return nil, fmt.Errorf("failed to get media file %d: %w", id, err)
+	}
+	return &entity.MediaFile{ID: id, URL: resp.File.GetFileUrl(), UploadedBy: resp.File.GetUploadedBy()}, nil
+}
+
+// DeleteFile deletes an uploaded file. A file that is already gone is not an error.
+func (c *Client) DeleteFile(ctx context.Context, id int64) error {
+	if _, err := c.mediaClient.DeleteFile(ctx, &mediapb.DeleteFileRequest{Id: id}); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil
+		}
+		return fmt.Errorf("failed to delete media file %d: %w", id, err)
+	}
+	return nil
+}
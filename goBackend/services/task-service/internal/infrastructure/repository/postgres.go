@@ -3,11 +3,72 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"time"
 
+	"github.com/lib/pq"
+	"github.com/portfolio/shared/outbox"
 	"github.com/portfolio/task-service/internal/domain/entity"
+	domain "github.com/portfolio/task-service/internal/domain/repository"
 )
 
+// foreignKeyViolation is the Postgres error code for a reference to a missing row
+const foreignKeyViolation = "23503"
+
+// OutboxSource names the task service's events in the outbox
+const OutboxSource = "task-service"
+
+// taskEventData is the data of a task event; Task is left out once deleted.
+// Watchers are the users to notify of the change besides the assignee.
+type taskEventData struct {
+	ID        int64        `json:"id"`
+	ProjectID int64        `json:"project_id"`
+	Task      *entity.Task `json:"task,omitempty"`
+	Watchers  []int64      `json:"watchers,omitempty"`
+}
+
+// recordTaskEvent adds an event about task to the outbox in tx. A new task
+// has no watchers yet; any other event names the task's current ones.
+func recordTaskEvent(ctx context.Context, tx *sql.Tx, eventType string, task *entity.Task) error {
+	data := taskEventData{ID: task.ID, ProjectID: task.ProjectID, Task: task}
+	if eventType != entity.TaskCreated {
+		watchers, err := taskWatchers(ctx, tx, []int64{task.ID})
+		if err != nil {
+			return err
+		}
+		data.Watchers = watchers[task.ID]
+	}
+	return outbox.Add(ctx, tx, OutboxSource, eventType, data)
+}
+
+// recordTaskDeleted adds a TaskDeleted event to the outbox in tx. The
+// watchers must be read before the delete cascades to them.
+func recordTaskDeleted(ctx context.Context, tx *sql.Tx, id, projectID int64, watchers []int64) error {
+	return outbox.Add(ctx, tx, OutboxSource, entity.TaskDeleted, taskEventData{ID: id, ProjectID: projectID, Watchers: watchers})
+}
+
+// taskWatchers reads the IDs of the users watching each of taskIDs in tx;
+// tasks without watchers are absent from the map
+func taskWatchers(ctx context.Context, tx *sql.Tx, taskIDs []int64) (map[int64][]int64, error) {
+	rows, err := tx.QueryContext(ctx,
+		`SELECT task_id, user_id FROM task_watchers WHERE task_id = ANY($1) ORDER BY task_id, user_id`,
+		pq.Array(taskIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	watchers := make(map[int64][]int64)
+	for rows.Next() {
+		var taskID, userID int64
+		if err := rows.Scan(&taskID, &userID); err != nil {
+			return nil, err
+		}
+		watchers[taskID] = append(watchers[taskID], userID)
+	}
+	return watchers, rows.Err()
+}
+
 // PostgresTaskRepository implements TaskRepository
 type PostgresTaskRepository struct {
 	db *sql.DB
@@ -18,29 +79,61 @@ func NewPostgresTaskRepository(db *sql.DB) *PostgresTaskRepository {
 	return &PostgresTaskRepository{db: db}
 }
 
-// Create creates a new task
+// Create creates a new task; one created as Done is completed when created.
+// The TaskCreated event is written to the outbox in the same transaction.
 func (r *PostgresTaskRepository) Create(ctx context.Context, task *entity.Task) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
 	query := `
-		INSERT INTO tasks (project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, DATE($7), $8, $9)
+		INSERT INTO tasks (project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, DATE($7), $8, $9, CASE WHEN $10 THEN $8::timestamp END)
 		RETURNING id
 	`
-	return r.db.QueryRowContext(
+	if err := tx.QueryRowContext(
 		ctx, query,
 		task.ProjectID, task.Title, task.Description, task.Status,
 		task.Priority, task.AssignedTo, task.DueDate, task.CreatedAt, task.UpdatedAt,
-	).Scan(&task.ID)
+		task.Status == entity.StatusDone,
+	).Scan(&task.ID); err != nil {
+		return err
+	}
+	if err := recordTaskEvent(ctx, tx, entity.TaskCreated, task); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // GetByID gets a task by ID
 func (r *PostgresTaskRepository) GetByID(ctx context.Context, id int64) (*entity.Task, error) {
+	return getTask(ctx, r.db, id)
+}
+
+// GetByIDs gets the tasks that exist among ids, in id order
+func (r *PostgresTaskRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Task, error) {
+	query := `
+		SELECT id, project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at
+		FROM tasks WHERE id = ANY($1) ORDER BY id
+	`
+	return r.queryTasks(ctx, query, pq.Array(ids))
+}
+
+// rowQuerier is a *sql.DB or *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func getTask(ctx context.Context, q rowQuerier, id int64) (*entity.Task, error) {
 	query := `
 		SELECT id, project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at
 		FROM tasks WHERE id = $1
 	`
 	var description sql.NullString
 	task := &entity.Task{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
+	err := q.QueryRowContext(ctx, query, id).Scan(
 		&task.ID, &task.ProjectID, &task.Title, &description,
 		&task.Status, &task.Priority, &task.AssignedTo, &task.DueDate,
 		&task.CreatedAt, &task.UpdatedAt,
@@ -54,32 +147,327 @@ func (r *PostgresTaskRepository) GetByID(ctx context.Context, id int64) (*entity
 	return task, nil
 }
 
-// Update updates a task
+// Update updates a task and writes the TaskUpdated event to the outbox in
+// the same transaction
 func (r *PostgresTaskRepository) Update(ctx context.Context, task *entity.Task) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// completed_at is when the task last moved to Done, kept while it stays
+	// Done and cleared if it is reopened
 	query := `
 		UPDATE tasks SET title = $1, description = $2, status = $3, priority = $4,
-		assigned_to = $5, due_date = $6, updated_at = $7 WHERE id = $8
+		assigned_to = $5, due_date = $6, updated_at = $7,
+		completed_at = CASE WHEN $9 THEN COALESCE(completed_at, $7) END
+		WHERE id = $8
 	`
 	task.UpdatedAt = time.Now()
-	_, err := r.db.ExecContext(ctx, query,
+	if _, err := tx.ExecContext(ctx, query,
 		task.Title, task.Description, task.Status, task.Priority,
-		task.AssignedTo, task.DueDate, task.UpdatedAt, task.ID,
-	)
-	return err
+		task.AssignedTo, task.DueDate, task.UpdatedAt, task.ID, task.Status == entity.StatusDone,
+	); err != nil {
+		return err
+	}
+	if err := recordTaskEvent(ctx, tx, entity.TaskUpdated, task); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// Delete deletes a task
+// Delete deletes a task and writes the TaskDeleted event to the outbox in
+// the same transaction. Deleting a missing task does nothing.
 func (r *PostgresTaskRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM tasks WHERE id = $1`
-	_, err := r.db.ExecContext(ctx, query, id)
-	return err
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	watchers, err := taskWatchers(ctx, tx, []int64{id})
+	if err != nil {
+		return err
+	}
+	var projectID int64
+	err = tx.QueryRowContext(ctx, `DELETE FROM tasks WHERE id = $1 RETURNING project_id`, id).Scan(&projectID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := recordTaskDeleted(ctx, tx, id, projectID, watchers[id]); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
-// List lists tasks with filters
-func (r *PostgresTaskRepository) List(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64) ([]*entity.Task, int, error) {
-	offset := (page - 1) * limit
+// CreateFromSubtask creates task and deletes the subtask it was built from
+// in one transaction
+func (r *PostgresTaskRepository) CreateFromSubtask(ctx context.Context, task *entity.Task, subtaskID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO tasks (project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, DATE($7), $8, $9, CASE WHEN $10 THEN $8::timestamp END)
+		RETURNING id
+	`
+	if err := tx.QueryRowContext(
+		ctx, query,
+		task.ProjectID, task.Title, task.Description, task.Status,
+		task.Priority, task.AssignedTo, task.DueDate, task.CreatedAt, task.UpdatedAt,
+		task.Status == entity.StatusDone,
+	).Scan(&task.ID); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM subtasks WHERE id = $1`, subtaskID)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	if err := recordTaskEvent(ctx, tx, entity.TaskCreated, task); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ConvertToSubtask creates subtask under its parent task and deletes the task
+// it was built from in one transaction. The task's own subtasks and comments
+// are moved to the parent first so they survive the delete. Under the
+// parent's row lock, as in CreateMany, the limit is checked counting the
+// moved subtasks, and subtask then the moved subtasks in their order are
+// placed after the parent's existing subtasks.
+func (r *PostgresTaskRepository) ConvertToSubtask(ctx context.Context, taskID int64, subtask *entity.Subtask, max int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var parentID int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM tasks WHERE id = $1 FOR UPDATE`, subtask.TaskID).Scan(&parentID); err != nil {
+		return err
+	}
+	var last, count int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COALESCE(MAX(position) FILTER (WHERE task_id = $1), 0), COUNT(*) FROM subtasks WHERE task_id = $1 OR task_id = $2`,
+		subtask.TaskID, taskID,
+	).Scan(&last, &count); err != nil {
+		return err
+	}
+	if max > 0 && count+1 > max {
+		return domain.ErrLimitExceeded
+	}
+
+	query := `
+		INSERT INTO subtasks (task_id, title, status, assigned_to, due_date, created_at, updated_at, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
+	`
+	subtask.Position = last + 1
+	if err := tx.QueryRowContext(ctx, query,
+		subtask.TaskID, subtask.Title, subtask.Status, subtask.AssignedTo,
+		subtask.DueDate, subtask.CreatedAt, subtask.UpdatedAt, subtask.Position,
+	).Scan(&subtask.ID); err != nil {
+		return err
+	}
+
+	moveSubtasks := `
+		UPDATE subtasks s SET task_id = $1, position = $3 + ordered.rn
+		FROM (SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS rn FROM subtasks WHERE task_id = $2) ordered
+		WHERE s.id = ordered.id
+	`
+	if _, err := tx.ExecContext(ctx, moveSubtasks, subtask.TaskID, taskID, subtask.Position); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE task_comments SET task_id = $1 WHERE task_id = $2`, subtask.TaskID, taskID); err != nil {
+		return err
+	}
+
+	watchers, err := taskWatchers(ctx, tx, []int64{taskID})
+	if err != nil {
+		return err
+	}
+	var projectID int64
+	if err := tx.QueryRowContext(ctx, `DELETE FROM tasks WHERE id = $1 RETURNING project_id`, taskID).Scan(&projectID); err != nil {
+		return err
+	}
+
+	if err := recordTaskDeleted(ctx, tx, taskID, projectID, watchers[taskID]); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
 
-	// Build dynamic query
+// Duplicate creates task as a copy of the task with sourceID in one
+// transaction. The source's subtasks are copied as Todo in the same order and
+// its tags are linked to the copy; subtask due dates are kept only when
+// keepDueDates is set. Comments and attachments are not copied.
+func (r *PostgresTaskRepository) Duplicate(ctx context.Context, sourceID int64, task *entity.Task, keepDueDates bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO tasks (project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, DATE($7), $8, $9, CASE WHEN $10 THEN $8::timestamp END)
+		RETURNING id
+	`
+	if err := tx.QueryRowContext(
+		ctx, query,
+		task.ProjectID, task.Title, task.Description, task.Status,
+		task.Priority, task.AssignedTo, task.DueDate, task.CreatedAt, task.UpdatedAt,
+		task.Status == entity.StatusDone,
+	).Scan(&task.ID); err != nil {
+		return err
+	}
+
+	subtasksQuery := `
+		INSERT INTO subtasks (task_id, title, status, assigned_to, due_date, created_at, updated_at, position)
+		SELECT $1, title, $2, assigned_to, CASE WHEN $3::boolean THEN due_date END, $4, $4, position
+		FROM subtasks WHERE task_id = $5
+		ORDER BY position, id
+	`
+	if _, err := tx.ExecContext(ctx, subtasksQuery,
+		task.ID, entity.StatusTodo, keepDueDates, task.CreatedAt, sourceID,
+	); err != nil {
+		return err
+	}
+
+	tagsQuery := `
+		INSERT INTO task_tag_mapping (task_id, tag_id)
+		SELECT $1, tag_id FROM task_tag_mapping WHERE task_id = $2
+	`
+	if _, err := tx.ExecContext(ctx, tagsQuery, task.ID, sourceID); err != nil {
+		return err
+	}
+
+	if err := recordTaskEvent(ctx, tx, entity.TaskCreated, task); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Move moves a task to another project in one transaction. Subtasks,
+// comments and tags reference the task, so they move with it; with
+// clearAssignees the task and its subtasks are unassigned as well.
+func (r *PostgresTaskRepository) Move(ctx context.Context, taskID, projectID int64, clearAssignees bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE tasks SET project_id = $1, updated_at = $2,
+		assigned_to = CASE WHEN $3 THEN NULL ELSE assigned_to END
+		WHERE id = $4
+	`
+	result, err := tx.ExecContext(ctx, query, projectID, time.Now(), clearAssignees, taskID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolation {
+			return domain.ErrProjectNotFound
+		}
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+
+	if clearAssignees {
+		if _, err := tx.ExecContext(ctx, `UPDATE subtasks SET assigned_to = NULL WHERE task_id = $1`, taskID); err != nil {
+			return err
+		}
+	}
+
+	// Outside the service a move is an update of the task's project
+	moved, err := getTask(ctx, tx, taskID)
+	if err != nil {
+		return err
+	}
+	if err := recordTaskEvent(ctx, tx, entity.TaskUpdated, moved); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteMany deletes several tasks and their children in one transaction.
+// It returns the number of tasks actually removed.
+func (r *PostgresTaskRepository) DeleteMany(ctx context.Context, ids []int64) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	watchers, err := taskWatchers(ctx, tx, ids)
+	if err != nil {
+		return 0, err
+	}
+
+	// Children are removed explicitly so the cascade does not depend on FK options
+	childQueries := []string{
+		`DELETE FROM task_watchers WHERE task_id = ANY($1)`,
+		`DELETE FROM task_tag_mapping WHERE task_id = ANY($1)`,
+		`DELETE FROM task_attachments WHERE task_id = ANY($1)`,
+		`DELETE FROM task_comments WHERE task_id = ANY($1)`,
+		`DELETE FROM subtasks WHERE task_id = ANY($1)`,
+	}
+	for _, query := range childQueries {
+		if _, err := tx.ExecContext(ctx, query, pq.Array(ids)); err != nil {
+			return 0, err
+		}
+	}
+
+	rows, err := tx.QueryContext(ctx, `DELETE FROM tasks WHERE id = ANY($1) RETURNING id, project_id`, pq.Array(ids))
+	if err != nil {
+		return 0, err
+	}
+	var removed []taskEventData
+	for rows.Next() {
+		var d taskEventData
+		if err := rows.Scan(&d.ID, &d.ProjectID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		removed = append(removed, d)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	for _, d := range removed {
+		if err := recordTaskDeleted(ctx, tx, d.ID, d.ProjectID, watchers[d.ID]); err != nil {
+			return 0, err
+		}
+	}
+	deleted := int64(len(removed))
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// taskFilter builds the WHERE clause shared by List and ListAfter. It returns
+// the clause, its args and the next free placeholder index.
+func taskFilter(projectID int64, status string, assignedTo int64, tags domain.TagFilter) (string, []interface{}, int) {
 	baseQuery := `FROM tasks WHERE project_id = $1`
 	args := []interface{}{projectID}
 	argIndex := 2
@@ -94,6 +482,29 @@ func (r *PostgresTaskRepository) List(ctx context.Context, projectID int64, page
 		args = append(args, assignedTo)
 		argIndex++
 	}
+	switch {
+	case len(tags.IDs) == 1:
+		baseQuery += ` AND id IN (SELECT task_id FROM task_tag_mapping WHERE tag_id = $` + string(rune('0'+argIndex)) + `)`
+		args = append(args, tags.IDs[0])
+		argIndex++
+	case len(tags.IDs) > 1 && tags.MatchAny:
+		baseQuery += ` AND id IN (SELECT task_id FROM task_tag_mapping WHERE tag_id = ANY($` + string(rune('0'+argIndex)) + `))`
+		args = append(args, pq.Array(tags.IDs))
+		argIndex++
+	case len(tags.IDs) > 1:
+		// (task_id, tag_id) is the primary key, so a task with every tag has one row per tag
+		baseQuery += ` AND id IN (SELECT task_id FROM task_tag_mapping WHERE tag_id = ANY($` + string(rune('0'+argIndex)) + `) GROUP BY task_id HAVING COUNT(*) = $` + string(rune('0'+argIndex+1)) + `)`
+		args = append(args, pq.Array(tags.IDs), len(tags.IDs))
+		argIndex += 2
+	}
+	return baseQuery, args, argIndex
+}
+
+// List lists tasks with filters, most urgent first: by priority ascending
+// (1 = high to 4 = none), then due date with undated tasks last, then id
+func (r *PostgresTaskRepository) List(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64, tags domain.TagFilter) ([]*entity.Task, int, error) {
+	offset := (page - 1) * limit
+	baseQuery, args, argIndex := taskFilter(projectID, status, assignedTo, tags)
 
 	// Get total count
 	var total int
@@ -103,13 +514,32 @@ func (r *PostgresTaskRepository) List(ctx context.Context, projectID int64, page
 	}
 
 	// Get tasks
-	selectQuery := `SELECT id, project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at ` + baseQuery + ` ORDER BY priority, due_date LIMIT $` + string(rune('0'+argIndex)) + ` OFFSET $` + string(rune('0'+argIndex+1))
+	selectQuery := `SELECT id, project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at ` + baseQuery + ` ORDER BY priority, due_date, id LIMIT $` + string(rune('0'+argIndex)) + ` OFFSET $` + string(rune('0'+argIndex+1))
 	args = append(args, limit, offset)
 
-	rows, err := r.db.QueryContext(ctx, selectQuery, args...)
+	tasks, err := r.queryTasks(ctx, selectQuery, args...)
 	if err != nil {
 		return nil, 0, err
 	}
+	return tasks, total, nil
+}
+
+// ListAfter lists up to limit tasks with an id greater than afterID, in id
+// order. Unlike List it seeks on the primary key instead of skipping rows,
+// so pages stay cheap deep into large projects and rows inserted between
+// calls are neither skipped nor repeated.
+func (r *PostgresTaskRepository) ListAfter(ctx context.Context, projectID, afterID int64, limit int, status string, assignedTo int64, tags domain.TagFilter) ([]*entity.Task, error) {
+	baseQuery, args, argIndex := taskFilter(projectID, status, assignedTo, tags)
+	selectQuery := `SELECT id, project_id, title, description, status, priority, assigned_to, due_date, created_at, updated_at ` + baseQuery + ` AND id > $` + string(rune('0'+argIndex)) + ` ORDER BY id LIMIT $` + string(rune('0'+argIndex+1))
+	args = append(args, afterID, limit)
+	return r.queryTasks(ctx, selectQuery, args...)
+}
+
+func (r *PostgresTaskRepository) queryTasks(ctx context.Context, query string, args ...interface{}) ([]*entity.Task, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
 	var tasks []*entity.Task
@@ -121,15 +551,101 @@ func (r *PostgresTaskRepository) List(ctx context.Context, projectID int64, page
 			&task.Status, &task.Priority, &task.AssignedTo, &task.DueDate,
 			&task.CreatedAt, &task.UpdatedAt,
 		); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 		if description.Valid {
 			task.Description = description.String
 		}
 		tasks = append(tasks, task)
 	}
+	return tasks, rows.Err()
+}
 
-	return tasks, total, nil
+// CountByProject counts the tasks of every project that has any, and how
+// many of them are done
+func (r *PostgresTaskRepository) CountByProject(ctx context.Context) ([]*entity.ProjectTaskCount, error) {
+	query := `
+		SELECT project_id, COUNT(*), COUNT(*) FILTER (WHERE status = $1)
+		FROM tasks GROUP BY project_id ORDER BY project_id
+	`
+	rows, err := r.db.QueryContext(ctx, query, entity.StatusDone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var counts []*entity.ProjectTaskCount
+	for rows.Next() {
+		count := &entity.ProjectTaskCount{}
+		if err := rows.Scan(&count.ProjectID, &count.Total, &count.Completed); err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+// Summarize counts a project's tasks per status in one grouped query, along
+// with how many in each status are overdue or unassigned
+func (r *PostgresTaskRepository) Summarize(ctx context.Context, projectID int64, now time.Time) (*entity.TaskSummary, error) {
+	query := `
+		SELECT status, COUNT(*),
+			COUNT(*) FILTER (WHERE due_date < $2::date AND status <> $3),
+			COUNT(*) FILTER (WHERE assigned_to IS NULL)
+		FROM tasks WHERE project_id = $1 GROUP BY status
+	`
+	rows, err := r.db.QueryContext(ctx, query, projectID, now, entity.StatusDone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summary := entity.NewTaskSummary(projectID)
+	for rows.Next() {
+		var status string
+		var count, overdue, unassigned int
+		if err := rows.Scan(&status, &count, &overdue, &unassigned); err != nil {
+			return nil, err
+		}
+		summary.StatusCounts[status] = count
+		summary.Total += count
+		summary.Overdue += overdue
+		summary.Unassigned += unassigned
+	}
+	return summary, rows.Err()
+}
+
+// ListVersions returns the id and updated_at of every task in a project
+func (r *PostgresTaskRepository) ListVersions(ctx context.Context, projectID int64) ([]*entity.TaskVersion, error) {
+	query := `SELECT id, updated_at FROM tasks WHERE project_id = $1 ORDER BY id`
+
+	rows, err := r.db.QueryContext(ctx, query, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []*entity.TaskVersion
+	for rows.Next() {
+		v := &entity.TaskVersion{}
+		if err := rows.Scan(&v.ID, &v.UpdatedAt); err != nil {
+			return nil, err
+		}
+		versions = append(versions, v)
+	}
+	return versions, rows.Err()
+}
+
+// checkTaskExists returns domain.ErrTaskNotFound unless task id exists
+func checkTaskExists(ctx context.Context, db *sql.DB, id int64) error {
+	var exists bool
+	if err := db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)`, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrTaskNotFound
+	}
+	return nil
 }
 
 // PostgresSubtaskRepository implements SubtaskRepository
@@ -142,25 +658,63 @@ func NewPostgresSubtaskRepository(db *sql.DB) *PostgresSubtaskRepository {
 	return &PostgresSubtaskRepository{db: db}
 }
 
-// Create creates a new subtask
+// Create creates a new subtask after the task's existing subtasks. It goes
+// through CreateMany so the position is taken under the same task row lock.
 func (r *PostgresSubtaskRepository) Create(ctx context.Context, subtask *entity.Subtask) error {
+	return r.CreateMany(ctx, subtask.TaskID, []*entity.Subtask{subtask}, 0)
+}
+
+// CreateMany inserts subtasks in one transaction, positioned in the given
+// order after the task's existing subtasks. The limit is checked under the
+// same lock, so concurrent inserts can't overshoot it.
+func (r *PostgresSubtaskRepository) CreateMany(ctx context.Context, taskID int64, subtasks []*entity.Subtask, max int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the parent task so concurrent inserts can't take the same positions
+	var locked int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM tasks WHERE id = $1 FOR UPDATE`, taskID).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrTaskNotFound
+		}
+		return err
+	}
+	var last, count int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(position), 0), COUNT(*) FROM subtasks WHERE task_id = $1`, taskID).Scan(&last, &count); err != nil {
+		return err
+	}
+	if max > 0 && count+len(subtasks) > max {
+		return domain.ErrLimitExceeded
+	}
+
 	query := `
-		INSERT INTO subtasks (task_id, title, status, assigned_to, due_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id
+		INSERT INTO subtasks (task_id, title, status, assigned_to, due_date, created_at, updated_at, position)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id
 	`
-	return r.db.QueryRowContext(ctx, query,
-		subtask.TaskID, subtask.Title, subtask.Status, subtask.AssignedTo,
-		subtask.DueDate, subtask.CreatedAt, subtask.UpdatedAt,
-	).Scan(&subtask.ID)
+	for i, subtask := range subtasks {
+		subtask.TaskID = taskID
+		subtask.Position = last + i + 1
+		if err := tx.QueryRowContext(ctx, query,
+			subtask.TaskID, subtask.Title, subtask.Status, subtask.AssignedTo,
+			subtask.DueDate, subtask.CreatedAt, subtask.UpdatedAt, subtask.Position,
+		).Scan(&subtask.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
 }
 
 // GetByID gets a subtask by ID
 func (r *PostgresSubtaskRepository) GetByID(ctx context.Context, id int64) (*entity.Subtask, error) {
-	query := `SELECT id, task_id, title, status, assigned_to, due_date, created_at, updated_at FROM subtasks WHERE id = $1`
+	query := `SELECT id, task_id, title, status, assigned_to, due_date, position, created_at, updated_at FROM subtasks WHERE id = $1`
 	subtask := &entity.Subtask{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&subtask.ID, &subtask.TaskID, &subtask.Title, &subtask.Status,
-		&subtask.AssignedTo, &subtask.DueDate, &subtask.CreatedAt, &subtask.UpdatedAt,
+		&subtask.AssignedTo, &subtask.DueDate, &subtask.Position, &subtask.CreatedAt, &subtask.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -170,12 +724,58 @@ func (r *PostgresSubtaskRepository) GetByID(ctx context.Context, id int64) (*ent
 
 // Update updates a subtask
 func (r *PostgresSubtaskRepository) Update(ctx context.Context, subtask *entity.Subtask) error {
-	query := `UPDATE subtasks SET title = $1, status = $2, assigned_to = $3, due_date = $4, updated_at = $5 WHERE id = $6`
+	query := `UPDATE subtasks SET task_id = $1, title = $2, status = $3, assigned_to = $4, due_date = $5, updated_at = $6 WHERE id = $7`
 	subtask.UpdatedAt = time.Now()
-	_, err := r.db.ExecContext(ctx, query, subtask.Title, subtask.Status, subtask.AssignedTo, subtask.DueDate, subtask.UpdatedAt, subtask.ID)
+	_, err := r.db.ExecContext(ctx, query, subtask.TaskID, subtask.Title, subtask.Status, subtask.AssignedTo, subtask.DueDate, subtask.UpdatedAt, subtask.ID)
 	return err
 }
 
+// MoveToTask moves subtask under task taskID in one transaction. The limit is
+// checked and the subtask placed after the task's existing subtasks under the
+// task's row lock, as in CreateMany. The subtask must still be under the task
+// it was read from.
+func (r *PostgresSubtaskRepository) MoveToTask(ctx context.Context, subtask *entity.Subtask, taskID int64, max int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var locked int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM tasks WHERE id = $1 FOR UPDATE`, taskID).Scan(&locked); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrTaskNotFound
+		}
+		return err
+	}
+	var last, count int
+	if err := tx.QueryRowContext(ctx, `SELECT COALESCE(MAX(position), 0), COUNT(*) FROM subtasks WHERE task_id = $1`, taskID).Scan(&last, &count); err != nil {
+		return err
+	}
+	if max > 0 && count+1 > max {
+		return domain.ErrLimitExceeded
+	}
+
+	updatedAt := time.Now()
+	result, err := tx.ExecContext(ctx,
+		`UPDATE subtasks SET task_id = $1, position = $2, updated_at = $3 WHERE id = $4 AND task_id = $5`,
+		taskID, last+1, updatedAt, subtask.ID, subtask.TaskID,
+	)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return sql.ErrNoRows
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	subtask.TaskID, subtask.Position, subtask.UpdatedAt = taskID, last+1, updatedAt
+	return nil
+}
+
 // Delete deletes a subtask
 func (r *PostgresSubtaskRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM subtasks WHERE id = $1`
@@ -183,24 +783,71 @@ func (r *PostgresSubtaskRepository) Delete(ctx context.Context, id int64) error
 	return err
 }
 
-// GetByTaskID gets all subtasks for a task
-func (r *PostgresSubtaskRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.Subtask, error) {
-	query := `SELECT id, task_id, title, status, assigned_to, due_date, created_at, updated_at FROM subtasks WHERE task_id = $1`
-	rows, err := r.db.QueryContext(ctx, query, taskID)
+// GetByTaskID gets a page of subtasks for a task along with the total count
+func (r *PostgresSubtaskRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.Subtask, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM subtasks WHERE task_id = $1`, taskID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query := `SELECT id, task_id, title, status, assigned_to, due_date, position, created_at, updated_at FROM subtasks WHERE task_id = $1 ORDER BY position, id LIMIT $2 OFFSET $3`
+	rows, err := r.db.QueryContext(ctx, query, taskID, limit, offset)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	defer rows.Close()
 
 	var subtasks []*entity.Subtask
 	for rows.Next() {
 		subtask := &entity.Subtask{}
-		if err := rows.Scan(&subtask.ID, &subtask.TaskID, &subtask.Title, &subtask.Status, &subtask.AssignedTo, &subtask.DueDate, &subtask.CreatedAt, &subtask.UpdatedAt); err != nil {
-			return nil, err
+		if err := rows.Scan(&subtask.ID, &subtask.TaskID, &subtask.Title, &subtask.Status, &subtask.AssignedTo, &subtask.DueDate, &subtask.Position, &subtask.CreatedAt, &subtask.UpdatedAt); err != nil {
+			return nil, 0, err
 		}
 		subtasks = append(subtasks, subtask)
 	}
-	return subtasks, nil
+	return subtasks, total, nil
+}
+
+// ProgressByTaskIDs counts all and completed subtasks per task
+func (r *PostgresSubtaskRepository) ProgressByTaskIDs(ctx context.Context, taskIDs []int64) (map[int64]entity.SubtaskProgress, error) {
+	query := `SELECT task_id, COUNT(*), COUNT(*) FILTER (WHERE status = $2) FROM subtasks WHERE task_id = ANY($1) GROUP BY task_id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(taskIDs), entity.StatusDone)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	progress := make(map[int64]entity.SubtaskProgress, len(taskIDs))
+	for rows.Next() {
+		var taskID int64
+		var p entity.SubtaskProgress
+		if err := rows.Scan(&taskID, &p.Total, &p.Completed); err != nil {
+			return nil, err
+		}
+		progress[taskID] = p
+	}
+	return progress, rows.Err()
+}
+
+// AssigneesByTaskID returns the distinct users assigned to a task's subtasks
+func (r *PostgresSubtaskRepository) AssigneesByTaskID(ctx context.Context, taskID int64) ([]int64, error) {
+	query := `SELECT DISTINCT assigned_to FROM subtasks WHERE task_id = $1 AND assigned_to <> 0 ORDER BY assigned_to`
+	rows, err := r.db.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
 }
 
 // PostgresCommentRepository implements CommentRepository
@@ -237,12 +884,31 @@ func (r *PostgresCommentRepository) Delete(ctx context.Context, id int64) error
 	return err
 }
 
-// GetByTaskID gets all comments for a task
-func (r *PostgresCommentRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskComment, error) {
-	query := `SELECT id, task_id, user_id, comment, created_at FROM task_comments WHERE task_id = $1 ORDER BY created_at`
-	rows, err := r.db.QueryContext(ctx, query, taskID)
+// DeleteByTaskID deletes all comments on a task in a single statement
+func (r *PostgresCommentRepository) DeleteByTaskID(ctx context.Context, taskID int64) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM task_comments WHERE task_id = $1`, taskID)
 	if err != nil {
-		return nil, err
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil || deleted > 0 {
+		return deleted, err
+	}
+	return 0, checkTaskExists(ctx, r.db, taskID)
+}
+
+// GetByTaskID gets a page of comments for a task along with the total count
+func (r *PostgresCommentRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskComment, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM task_comments WHERE task_id = $1`, taskID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query := `SELECT id, task_id, user_id, comment, created_at FROM task_comments WHERE task_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3`
+	rows, err := r.db.QueryContext(ctx, query, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, err
 	}
 	defer rows.Close()
 
@@ -250,11 +916,37 @@ func (r *PostgresCommentRepository) GetByTaskID(ctx context.Context, taskID int6
 	for rows.Next() {
 		comment := &entity.TaskComment{}
 		if err := rows.Scan(&comment.ID, &comment.TaskID, &comment.UserID, &comment.Comment, &comment.CreatedAt); err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, total, nil
+}
+
+// GetByUserID gets a page of a user's comments, newest first
+func (r *PostgresCommentRepository) GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.TaskComment, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM task_comments WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query := `SELECT id, task_id, user_id, comment, created_at FROM task_comments WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2 OFFSET $3`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var comments []*entity.TaskComment
+	for rows.Next() {
+		comment := &entity.TaskComment{}
+		if err := rows.Scan(&comment.ID, &comment.TaskID, &comment.UserID, &comment.Comment, &comment.CreatedAt); err != nil {
+			return nil, 0, err
 		}
 		comments = append(comments, comment)
 	}
-	return comments, nil
+	return comments, total, nil
 }
 
 // PostgresAttachmentRepository implements AttachmentRepository
@@ -269,21 +961,34 @@ func NewPostgresAttachmentRepository(db *sql.DB) *PostgresAttachmentRepository {
 
 // Create creates a new attachment
 func (r *PostgresAttachmentRepository) Create(ctx context.Context, attachment *entity.TaskAttachment) error {
-	query := `INSERT INTO task_attachments (task_id, file_url, uploaded_at) VALUES ($1, $2, $3) RETURNING id`
-	return r.db.QueryRowContext(ctx, query, attachment.TaskID, attachment.FileURL, attachment.UploadedAt).Scan(&attachment.ID)
+	query := `INSERT INTO task_attachments (task_id, file_url, attachment_type, media_file_id, uploaded_at) VALUES ($1, $2, $3, $4, $5) RETURNING id`
+	mediaFileID := sql.NullInt64{Int64: attachment.MediaFileID, Valid: attachment.MediaFileID != 0}
+	return r.db.QueryRowContext(ctx, query, attachment.TaskID, attachment.FileURL, attachment.Type, mediaFileID, attachment.UploadedAt).Scan(&attachment.ID)
 }
 
-// GetByID gets an attachment by ID
-func (r *PostgresAttachmentRepository) GetByID(ctx context.Context, id int64) (*entity.TaskAttachment, error) {
-	query := `SELECT id, task_id, file_url, uploaded_at FROM task_attachments WHERE id = $1`
+const attachmentColumns = `id, task_id, file_url, attachment_type, media_file_id, uploaded_at`
+
+// scanAttachment reads a row selected with attachmentColumns
+func scanAttachment(row interface{ Scan(...interface{}) error }) (*entity.TaskAttachment, error) {
 	attachment := &entity.TaskAttachment{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&attachment.ID, &attachment.TaskID, &attachment.FileURL, &attachment.UploadedAt)
-	if err != nil {
+	var mediaFileID sql.NullInt64
+	if err := row.Scan(&attachment.ID, &attachment.TaskID, &attachment.FileURL, &attachment.Type, &mediaFileID, &attachment.UploadedAt); err != nil {
 		return nil, err
 	}
+	attachment.MediaFileID = mediaFileID.Int64
 	return attachment, nil
 }
 
+// GetByID gets an attachment by ID
+func (r *PostgresAttachmentRepository) GetByID(ctx context.Context, id int64) (*entity.TaskAttachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM task_attachments WHERE id = $1`
+	attachment, err := scanAttachment(r.db.QueryRowContext(ctx, query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return attachment, err
+}
+
 // Delete deletes an attachment
 func (r *PostgresAttachmentRepository) Delete(ctx context.Context, id int64) error {
 	query := `DELETE FROM task_attachments WHERE id = $1`
@@ -291,24 +996,70 @@ func (r *PostgresAttachmentRepository) Delete(ctx context.Context, id int64) err
 	return err
 }
 
-// GetByTaskID gets all attachments for a task
-func (r *PostgresAttachmentRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskAttachment, error) {
-	query := `SELECT id, task_id, file_url, uploaded_at FROM task_attachments WHERE task_id = $1`
-	rows, err := r.db.QueryContext(ctx, query, taskID)
+// DeleteByTaskID deletes all attachments on a task in a single statement
+func (r *PostgresAttachmentRepository) DeleteByTaskID(ctx context.Context, taskID int64) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM task_attachments WHERE task_id = $1`, taskID)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil || deleted > 0 {
+		return deleted, err
+	}
+	return 0, checkTaskExists(ctx, r.db, taskID)
+}
+
+// CountByMediaFileID counts the attachments that reference a media file
+func (r *PostgresAttachmentRepository) CountByMediaFileID(ctx context.Context, mediaFileID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM task_attachments WHERE media_file_id = $1`, mediaFileID).Scan(&count)
+	return count, err
+}
+
+// MediaFileIDsByTaskIDs returns the distinct media files attached to the tasks
+func (r *PostgresAttachmentRepository) MediaFileIDsByTaskIDs(ctx context.Context, taskIDs []int64) ([]int64, error) {
+	query := `SELECT DISTINCT media_file_id FROM task_attachments WHERE task_id = ANY($1) AND media_file_id IS NOT NULL ORDER BY media_file_id`
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(taskIDs))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var attachments []*entity.TaskAttachment
+	var ids []int64
 	for rows.Next() {
-		attachment := &entity.TaskAttachment{}
-		if err := rows.Scan(&attachment.ID, &attachment.TaskID, &attachment.FileURL, &attachment.UploadedAt); err != nil {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// GetByTaskID gets a page of attachments for a task along with the total count
+func (r *PostgresAttachmentRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskAttachment, int, error) {
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM task_attachments WHERE task_id = $1`, taskID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * limit
+	query := `SELECT ` + attachmentColumns + ` FROM task_attachments WHERE task_id = $1 ORDER BY uploaded_at, id LIMIT $2 OFFSET $3`
+	rows, err := r.db.QueryContext(ctx, query, taskID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var attachments []*entity.TaskAttachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, 0, err
+		}
 		attachments = append(attachments, attachment)
 	}
-	return attachments, nil
+	return attachments, total, nil
 }
 
 // PostgresTagRepository implements TagRepository
@@ -338,6 +1089,20 @@ func (r *PostgresTagRepository) GetByID(ctx context.Context, id int64) (*entity.
 	return tag, nil
 }
 
+// GetByName gets a tag by its exact name
+func (r *PostgresTagRepository) GetByName(ctx context.Context, name string) (*entity.TaskTag, error) {
+	query := `SELECT id, name FROM task_tags WHERE name = $1`
+	tag := &entity.TaskTag{}
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&tag.ID, &tag.Name)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrTagNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return tag, nil
+}
+
 // List lists all tags
 func (r *PostgresTagRepository) List(ctx context.Context) ([]*entity.TaskTag, error) {
 	query := `SELECT id, name FROM task_tags ORDER BY name`
@@ -358,6 +1123,56 @@ func (r *PostgresTagRepository) List(ctx context.Context) ([]*entity.TaskTag, er
 	return tags, nil
 }
 
+// Delete deletes a tag and its task_tag_mapping rows in one transaction
+func (r *PostgresTagRepository) Delete(ctx context.Context, id int64) error {
+	return r.delete(ctx, id, true)
+}
+
+// DeleteUnused deletes a tag, or fails with ErrTagInUse if any task has it
+func (r *PostgresTagRepository) DeleteUnused(ctx context.Context, id int64) error {
+	return r.delete(ctx, id, false)
+}
+
+func (r *PostgresTagRepository) delete(ctx context.Context, id int64, detach bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Locking the tag blocks concurrent AddTaskTag calls, whose foreign key
+	// check needs a share lock on it, until the delete has committed
+	var locked int64
+	err = tx.QueryRowContext(ctx, `SELECT id FROM task_tags WHERE id = $1 FOR UPDATE`, id).Scan(&locked)
+	if err == sql.ErrNoRows {
+		return domain.ErrTagNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if detach {
+		// Mappings are removed explicitly so the delete does not depend on FK options
+		if _, err := tx.ExecContext(ctx, `DELETE FROM task_tag_mapping WHERE tag_id = $1`, id); err != nil {
+			return err
+		}
+	} else {
+		var inUse bool
+		query := `SELECT EXISTS (SELECT 1 FROM task_tag_mapping WHERE tag_id = $1)`
+		if err := tx.QueryRowContext(ctx, query, id).Scan(&inUse); err != nil {
+			return err
+		}
+		if inUse {
+			return domain.ErrTagInUse
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM task_tags WHERE id = $1`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
 // PostgresTaskTagRepository implements TaskTagRepository
 type PostgresTaskTagRepository struct {
 	db *sql.DB
@@ -369,22 +1184,62 @@ func NewPostgresTaskTagRepository(db *sql.DB) *PostgresTaskTagRepository {
 }
 
 // Add adds a tag to a task
-func (r *PostgresTaskTagRepository) Add(ctx context.Context, taskID, tagID int64) error {
+func (r *PostgresTaskTagRepository) Add(ctx context.Context, taskID, tagID int64, max int) error {
 	query := `INSERT INTO task_tag_mapping (task_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
-	_, err := r.db.ExecContext(ctx, query, taskID, tagID)
-	return err
+	if max <= 0 {
+		_, err := r.db.ExecContext(ctx, query, taskID, tagID)
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// Lock the task so concurrent adds are counted one after another
+	if _, err := tx.ExecContext(ctx, `SELECT id FROM tasks WHERE id = $1 FOR UPDATE`, taskID); err != nil {
+		return err
+	}
+	var count, has int
+	if err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*), COUNT(*) FILTER (WHERE tag_id = $2) FROM task_tag_mapping WHERE task_id = $1`,
+		taskID, tagID,
+	).Scan(&count, &has); err != nil {
+		return err
+	}
+	if has > 0 {
+		return nil
+	}
+	if count >= max {
+		return domain.ErrLimitExceeded
+	}
+	if _, err := tx.ExecContext(ctx, query, taskID, tagID); err != nil {
+		return err
+	}
+	return tx.Commit()
 }
 
 // Remove removes a tag from a task
 func (r *PostgresTaskTagRepository) Remove(ctx context.Context, taskID, tagID int64) error {
 	query := `DELETE FROM task_tag_mapping WHERE task_id = $1 AND tag_id = $2`
-	_, err := r.db.ExecContext(ctx, query, taskID, tagID)
-	return err
+	result, err := r.db.ExecContext(ctx, query, taskID, tagID)
+	if err != nil {
+		return err
+	}
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if removed == 0 {
+		return domain.ErrTaskTagNotFound
+	}
+	return nil
 }
 
 // GetByTaskID gets all tags for a task
 func (r *PostgresTaskTagRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskTag, error) {
-	query := `SELECT t.id, t.name FROM task_tags t INNER JOIN task_tag_mapping m ON t.id = m.tag_id WHERE m.task_id = $1`
+	query := `SELECT t.id, t.name FROM task_tags t INNER JOIN task_tag_mapping m ON t.id = m.tag_id WHERE m.task_id = $1 ORDER BY t.id`
 	rows, err := r.db.QueryContext(ctx, query, taskID)
 	if err != nil {
 		return nil, err
@@ -401,3 +1256,89 @@ func (r *PostgresTaskTagRepository) GetByTaskID(ctx context.Context, taskID int6
 	}
 	return tags, nil
 }
+
+// PostgresWatcherRepository implements WatcherRepository
+type PostgresWatcherRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresWatcherRepository creates a new repository
+func NewPostgresWatcherRepository(db *sql.DB) *PostgresWatcherRepository {
+	return &PostgresWatcherRepository{db: db}
+}
+
+// Add makes a user a watcher of a task; the primary key keeps it to one row
+func (r *PostgresWatcherRepository) Add(ctx context.Context, taskID, userID int64) error {
+	query := `INSERT INTO task_watchers (task_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, taskID, userID)
+	return err
+}
+
+// Remove stops a user watching a task
+func (r *PostgresWatcherRepository) Remove(ctx context.Context, taskID, userID int64) error {
+	query := `DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2`
+	_, err := r.db.ExecContext(ctx, query, taskID, userID)
+	return err
+}
+
+// GetByTaskID gets a task's watchers, longest watching first
+func (r *PostgresWatcherRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskWatcher, error) {
+	query := `SELECT task_id, user_id, created_at FROM task_watchers WHERE task_id = $1 ORDER BY created_at, user_id`
+	rows, err := r.db.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var watchers []*entity.TaskWatcher
+	for rows.Next() {
+		w := &entity.TaskWatcher{}
+		if err := rows.Scan(&w.TaskID, &w.UserID, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		watchers = append(watchers, w)
+	}
+	return watchers, rows.Err()
+}
+
+// PostgresTaskDefaultsRepository implements TaskDefaultsRepository. The
+// project_settings table belongs to the project service but shares this
+// database.
+type PostgresTaskDefaultsRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresTaskDefaultsRepository creates a new repository
+func NewPostgresTaskDefaultsRepository(db *sql.DB) *PostgresTaskDefaultsRepository {
+	return &PostgresTaskDefaultsRepository{db: db}
+}
+
+// Get gets a project's task defaults; projects without settings have none
+func (r *PostgresTaskDefaultsRepository) Get(ctx context.Context, projectID int64) (*entity.TaskDefaults, error) {
+	query := `
+		SELECT COALESCE(default_task_status, ''), COALESCE(default_task_priority, 0)
+		FROM project_settings WHERE project_id = $1
+	`
+	defaults := &entity.TaskDefaults{}
+	err := r.db.QueryRowContext(ctx, query, projectID).Scan(&defaults.Status, &defaults.Priority)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return defaults, nil
+}
+
+// PostgresActivityRepository implements ActivityRepository
+type PostgresActivityRepository struct {
+	db *sql.DB
+}
+
+// NewPostgresActivityRepository creates a new repository
+func NewPostgresActivityRepository(db *sql.DB) *PostgresActivityRepository {
+	return &PostgresActivityRepository{db: db}
+}
+
+// Record adds an entry to the task activity log
+func (r *PostgresActivityRepository) Record(ctx context.Context, activity *entity.TaskActivity) error {
+	query := `INSERT INTO task_activity (task_id, user_id, action, created_at) VALUES ($1, $2, $3, $4) RETURNING id`
+	return r.db.QueryRowContext(ctx, query, activity.TaskID, activity.UserID, activity.Action, activity.CreatedAt).Scan(&activity.ID)
+}
@@ -0,0 +1,1494 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/portfolio/task-service/internal/domain/entity"
+	domain "github.com/portfolio/task-service/internal/domain/repository"
+)
+
+// expectOutbox expects an event of eventType to be written to the outbox
+func expectOutbox(mock sqlmock.Sqlmock, eventType string) *sqlmock.ExpectedExec {
+	return mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox (source, event_type, payload)")).
+		WithArgs(OutboxSource, eventType, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+}
+
+// expectWatchers expects the watchers to be read for a task event; each
+// watcher is a task ID and a user ID
+func expectWatchers(mock sqlmock.Sqlmock, watchers ...[2]int64) *sqlmock.ExpectedQuery {
+	rows := sqlmock.NewRows([]string{"task_id", "user_id"})
+	for _, w := range watchers {
+		rows.AddRow(w[0], w[1])
+	}
+	return mock.ExpectQuery(regexp.QuoteMeta("SELECT task_id, user_id FROM task_watchers WHERE task_id = ANY($1)")).
+		WillReturnRows(rows)
+}
+
+// taskColumns are the columns GetByID reads
+var taskColumns = []string{"id", "project_id", "title", "description", "status", "priority", "assigned_to", "due_date", "created_at", "updated_at"}
+
+func TestPostgresTaskRepository_Create_WritesOutbox(t *testing.T) {
+	t.Run("Committed together", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New() error = %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tasks")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(12)))
+		expectOutbox(mock, entity.TaskCreated)
+		mock.ExpectCommit()
+
+		task := &entity.Task{ProjectID: 3, Title: "Ship", Status: entity.StatusTodo, Priority: 2}
+		if err := NewPostgresTaskRepository(db).Create(context.Background(), task); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("No task without its event", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New() error = %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tasks")).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(12)))
+		expectOutbox(mock, entity.TaskCreated).WillReturnError(errors.New("disk full"))
+		mock.ExpectRollback()
+
+		task := &entity.Task{ProjectID: 3, Title: "Ship"}
+		if err := NewPostgresTaskRepository(db).Create(context.Background(), task); err == nil {
+			t.Fatal("Create() expected error")
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+}
+
+func TestPostgresTaskRepository_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	expectWatchers(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM tasks WHERE id = $1 RETURNING project_id")).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(int64(2)))
+	expectOutbox(mock, entity.TaskDeleted)
+	mock.ExpectCommit()
+	// A task that is already gone announces nothing
+	mock.ExpectBegin()
+	expectWatchers(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM tasks WHERE id = $1 RETURNING project_id")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}))
+	mock.ExpectRollback()
+
+	repo := NewPostgresTaskRepository(db)
+	if err := repo.Delete(context.Background(), 4); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := repo.Delete(context.Background(), 5); err != nil {
+		t.Fatalf("Delete(missing) error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_GetByIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	columns := []string{"id", "project_id", "title", "description", "status", "priority", "assigned_to", "due_date", "created_at", "updated_at"}
+	mock.ExpectQuery(regexp.QuoteMeta("FROM tasks WHERE id = ANY($1) ORDER BY id")).
+		WithArgs(pq.Array([]int64{3, 1, 9})).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(int64(1), int64(5), "First", nil, entity.StatusTodo, 2, nil, nil, now, now).
+			AddRow(int64(3), int64(6), "Third", "notes", entity.StatusDone, 1, nil, nil, now, now))
+
+	tasks, err := NewPostgresTaskRepository(db).GetByIDs(context.Background(), []int64{3, 1, 9})
+	if err != nil {
+		t.Fatalf("GetByIDs() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != 1 || tasks[1].ProjectID != 6 || tasks[1].Description != "notes" {
+		t.Errorf("GetByIDs() = %+v, want tasks 1 and 3", tasks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_DeleteMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	expectWatchers(mock)
+	for _, table := range []string{"task_watchers", "task_tag_mapping", "task_attachments", "task_comments", "subtasks"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE task_id = ANY($1)")).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM tasks WHERE id = ANY($1) RETURNING id, project_id")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id"}).AddRow(int64(1), int64(5)).AddRow(int64(3), int64(5)))
+	expectOutbox(mock, entity.TaskDeleted)
+	expectOutbox(mock, entity.TaskDeleted)
+	mock.ExpectCommit()
+
+	repo := NewPostgresTaskRepository(db)
+	deleted, err := repo.DeleteMany(context.Background(), []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("DeleteMany() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("DeleteMany() deleted = %d, want 2", deleted)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// eventWatchers matches an outbox payload naming exactly these watchers
+type eventWatchers []int64
+
+func (w eventWatchers) Match(v driver.Value) bool {
+	payload, ok := v.([]byte)
+	if !ok {
+		return false
+	}
+	var data struct {
+		Watchers []int64 `json:"watchers"`
+	}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return false
+	}
+	if len(data.Watchers) != len(w) {
+		return false
+	}
+	for i := range w {
+		if data.Watchers[i] != w[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPostgresTaskRepository_DeleteMany_NamesWatchers(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// The watchers are read before the delete takes them with the tasks
+	mock.ExpectBegin()
+	expectWatchers(mock, [2]int64{1, 8}, [2]int64{1, 9}, [2]int64{2, 8})
+	for _, table := range []string{"task_watchers", "task_tag_mapping", "task_attachments", "task_comments", "subtasks"} {
+		mock.ExpectExec(regexp.QuoteMeta("DELETE FROM " + table + " WHERE task_id = ANY($1)")).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+	}
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM tasks WHERE id = ANY($1) RETURNING id, project_id")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id"}).AddRow(int64(1), int64(5)).AddRow(int64(3), int64(5)))
+	for _, watchers := range []eventWatchers{{8, 9}, {}} {
+		mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox (source, event_type, payload)")).
+			WithArgs(OutboxSource, entity.TaskDeleted, watchers).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	mock.ExpectCommit()
+
+	if _, err := NewPostgresTaskRepository(db).DeleteMany(context.Background(), []int64{1, 3}); err != nil {
+		t.Fatalf("DeleteMany() error = %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_DeleteMany_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	expectWatchers(mock)
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_watchers")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_tag_mapping")).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_attachments")).
+		WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+
+	repo := NewPostgresTaskRepository(db)
+	if _, err := repo.DeleteMany(context.Background(), []int64{1}); err == nil {
+		t.Fatal("DeleteMany() expected error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_CreateFromSubtask(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tasks")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(55)))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM subtasks WHERE id = $1")).
+		WithArgs(int64(10)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectOutbox(mock, entity.TaskCreated)
+	mock.ExpectCommit()
+
+	task := &entity.Task{ProjectID: 1, Title: "Promoted", Status: entity.StatusTodo, Priority: 3}
+	if err := NewPostgresTaskRepository(db).CreateFromSubtask(context.Background(), task, 10); err != nil {
+		t.Fatalf("CreateFromSubtask() error = %v", err)
+	}
+	if task.ID != 55 {
+		t.Errorf("CreateFromSubtask() task.ID = %d, want 55", task.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_CreateFromSubtask_MissingSubtaskRollsBack(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tasks")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(55)))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM subtasks WHERE id = $1")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+
+	task := &entity.Task{ProjectID: 1, Title: "Promoted"}
+	if err := NewPostgresTaskRepository(db).CreateFromSubtask(context.Background(), task, 10); err != sql.ErrNoRows {
+		t.Fatalf("CreateFromSubtask() error = %v, want sql.ErrNoRows", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_Update_TracksCompletion(t *testing.T) {
+	for _, status := range []string{entity.StatusDone, entity.StatusInProgress} {
+		t.Run(status, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			// Moving to Done stamps completed_at unless already set; any
+			// other status clears it
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("completed_at = CASE WHEN $9 THEN COALESCE(completed_at, $7) END")).
+				WithArgs("Ship", "", status, 2, nil, nil, sqlmock.AnyArg(), int64(4), status == entity.StatusDone).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			expectWatchers(mock)
+			expectOutbox(mock, entity.TaskUpdated)
+			mock.ExpectCommit()
+
+			task := &entity.Task{ID: 4, Title: "Ship", Status: status, Priority: 2}
+			if err := NewPostgresTaskRepository(db).Update(context.Background(), task); err != nil {
+				t.Fatalf("Update() error = %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresTaskRepository_ConvertToSubtask(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+		WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM subtasks WHERE task_id = $1 OR task_id = $2")).
+		WithArgs(int64(1), int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(3, 3))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO subtasks")).
+		WithArgs(int64(1), "Demoted", entity.StatusTodo, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), 4).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(30)))
+	// The task's own subtasks are renumbered after the new subtask
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE subtasks s SET task_id = $1, position = $3 + ordered.rn")).
+		WithArgs(int64(1), int64(2), 4).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE task_comments SET task_id = $1 WHERE task_id = $2")).
+		WithArgs(int64(1), int64(2)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	expectWatchers(mock)
+	mock.ExpectQuery(regexp.QuoteMeta("DELETE FROM tasks WHERE id = $1 RETURNING project_id")).
+		WithArgs(int64(2)).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id"}).AddRow(int64(6)))
+	expectOutbox(mock, entity.TaskDeleted)
+	mock.ExpectCommit()
+
+	subtask := &entity.Subtask{TaskID: 1, Title: "Demoted", Status: entity.StatusTodo}
+	if err := NewPostgresTaskRepository(db).ConvertToSubtask(context.Background(), 2, subtask, 4); err != nil {
+		t.Fatalf("ConvertToSubtask() error = %v", err)
+	}
+	if subtask.ID != 30 || subtask.Position != 4 {
+		t.Errorf("ConvertToSubtask() subtask = %+v, want id 30 at position 4", subtask)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_ConvertToSubtask_Limit(t *testing.T) {
+	tests := []struct {
+		name    string
+		lock    *sqlmock.Rows
+		count   int
+		wantErr error
+	}{
+		{"Moved subtasks count", sqlmock.NewRows([]string{"id"}).AddRow(int64(1)), 3, domain.ErrLimitExceeded},
+		{"Missing parent", sqlmock.NewRows([]string{"id"}), 0, sql.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+				WithArgs(int64(1)).
+				WillReturnRows(tt.lock)
+			if tt.count > 0 {
+				mock.ExpectQuery(regexp.QuoteMeta("FROM subtasks WHERE task_id = $1 OR task_id = $2")).
+					WithArgs(int64(1), int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(2, tt.count))
+			}
+			mock.ExpectRollback()
+
+			subtask := &entity.Subtask{TaskID: 1, Title: "Demoted", Status: entity.StatusTodo}
+			if err := NewPostgresTaskRepository(db).ConvertToSubtask(context.Background(), 2, subtask, 3); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ConvertToSubtask() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresSubtaskRepository_CreateMany(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0), COUNT(*) FROM subtasks WHERE task_id = $1")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(2, 2))
+	for i, title := range []string{"Design", "Build", "Ship"} {
+		mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO subtasks")).
+			WithArgs(int64(5), title, entity.StatusTodo, nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), 3+i).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(100 + i)))
+	}
+	mock.ExpectCommit()
+
+	subtasks := []*entity.Subtask{
+		entity.NewSubtask(5, "Design", 0, nil),
+		entity.NewSubtask(5, "Build", 0, nil),
+		entity.NewSubtask(5, "Ship", 0, nil),
+	}
+	if err := NewPostgresSubtaskRepository(db).CreateMany(context.Background(), 5, subtasks, 0); err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+	for i, s := range subtasks {
+		if s.ID != int64(100+i) || s.Position != 3+i {
+			t.Errorf("subtasks[%d] = id %d position %d, want id %d position %d", i, s.ID, s.Position, 100+i, 3+i)
+		}
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresSubtaskRepository_Create_LocksTask(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0), COUNT(*) FROM subtasks WHERE task_id = $1")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(4, 3))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO subtasks")).
+		WithArgs(int64(5), "Review", entity.StatusTodo, int64(7), nil, sqlmock.AnyArg(), sqlmock.AnyArg(), 5).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(42)))
+	mock.ExpectCommit()
+
+	subtask := entity.NewSubtask(5, "Review", 7, nil)
+	if err := NewPostgresSubtaskRepository(db).Create(context.Background(), subtask); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if subtask.ID != 42 || subtask.Position != 5 {
+		t.Errorf("subtask = id %d position %d, want id 42 position 5", subtask.ID, subtask.Position)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresSubtaskRepository_CreateMany_Limit(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// The count is read under the task row lock, and nothing is inserted
+	// once it would go over the limit
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0), COUNT(*) FROM subtasks")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(9, 2))
+	mock.ExpectRollback()
+
+	subtasks := []*entity.Subtask{entity.NewSubtask(5, "A", 0, nil), entity.NewSubtask(5, "B", 0, nil)}
+	if err := NewPostgresSubtaskRepository(db).CreateMany(context.Background(), 5, subtasks, 3); !errors.Is(err, domain.ErrLimitExceeded) {
+		t.Fatalf("CreateMany() error = %v, want ErrLimitExceeded", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresSubtaskRepository_CreateMany_MissingTask(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+		WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectRollback()
+
+	subtasks := []*entity.Subtask{entity.NewSubtask(5, "A", 0, nil)}
+	if err := NewPostgresSubtaskRepository(db).CreateMany(context.Background(), 5, subtasks, 0); !errors.Is(err, domain.ErrTaskNotFound) {
+		t.Fatalf("CreateMany() error = %v, want ErrTaskNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresSubtaskRepository_MoveToTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		count      int
+		wantUpdate bool
+		updated    int64
+		wantErr    error
+	}{
+		{name: "Placed after the destination's subtasks", count: 6, wantUpdate: true, updated: 1},
+		{name: "Destination full", count: 7, wantErr: domain.ErrLimitExceeded},
+		{name: "Moved away meanwhile", count: 6, wantUpdate: true, updated: 0, wantErr: sql.ErrNoRows},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			// The count and position are read under the destination's row lock
+			mock.ExpectBegin()
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+				WithArgs(int64(8)).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(8)))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0), COUNT(*) FROM subtasks WHERE task_id = $1")).
+				WithArgs(int64(8)).
+				WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(6, tt.count))
+			if tt.wantUpdate {
+				mock.ExpectExec(regexp.QuoteMeta("UPDATE subtasks SET task_id = $1, position = $2, updated_at = $3 WHERE id = $4 AND task_id = $5")).
+					WithArgs(int64(8), 7, sqlmock.AnyArg(), int64(3), int64(5)).
+					WillReturnResult(sqlmock.NewResult(0, tt.updated))
+			}
+			if tt.wantErr == nil {
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			subtask := &entity.Subtask{ID: 3, TaskID: 5, Position: 1}
+			err = NewPostgresSubtaskRepository(db).MoveToTask(context.Background(), subtask, 8, 7)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MoveToTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (subtask.TaskID != 8 || subtask.Position != 7) {
+				t.Errorf("subtask = task %d position %d, want task 8 position 7", subtask.TaskID, subtask.Position)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresTaskTagRepository_AddLimit(t *testing.T) {
+	tests := []struct {
+		name       string
+		count, has int
+		wantInsert bool
+		wantErr    error
+	}{
+		{"Below the limit", 1, 0, true, nil},
+		{"At the limit", 2, 0, false, domain.ErrLimitExceeded},
+		{"Already tagged", 2, 1, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectBegin()
+			mock.ExpectExec(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+				WithArgs(int64(4)).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+			mock.ExpectQuery(regexp.QuoteMeta("FROM task_tag_mapping WHERE task_id = $1")).
+				WithArgs(int64(4), int64(7)).
+				WillReturnRows(sqlmock.NewRows([]string{"count", "has"}).AddRow(tt.count, tt.has))
+			if tt.wantInsert {
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO task_tag_mapping")).
+					WithArgs(int64(4), int64(7)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			if err := NewPostgresTaskTagRepository(db).Add(context.Background(), 4, 7, 2); err != tt.wantErr {
+				t.Fatalf("Add() error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresSubtaskRepository_CreateMany_RollsBackOnError(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT id FROM tasks WHERE id = $1 FOR UPDATE")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(5)))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COALESCE(MAX(position), 0)")).
+		WillReturnRows(sqlmock.NewRows([]string{"max", "count"}).AddRow(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO subtasks")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO subtasks")).
+		WillReturnError(errors.New("insert failed"))
+	mock.ExpectRollback()
+
+	subtasks := []*entity.Subtask{entity.NewSubtask(5, "A", 0, nil), entity.NewSubtask(5, "B", 0, nil)}
+	if err := NewPostgresSubtaskRepository(db).CreateMany(context.Background(), 5, subtasks, 0); err == nil {
+		t.Fatal("CreateMany() expected error")
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresAttachmentRepository_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	link := entity.NewTaskAttachment(3, "https://example.com/spec")
+	media := entity.NewMediaAttachment(3, 12, "https://cdn.example.com/files/a.png")
+
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO task_attachments (task_id, file_url, attachment_type, media_file_id, uploaded_at)")).
+		WithArgs(int64(3), link.FileURL, entity.AttachmentTypeLink, nil, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(1)))
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO task_attachments")).
+		WithArgs(int64(3), media.FileURL, entity.AttachmentTypeMedia, int64(12), sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(2)))
+
+	repo := NewPostgresAttachmentRepository(db)
+	for _, a := range []*entity.TaskAttachment{link, media} {
+		if err := repo.Create(context.Background(), a); err != nil {
+			t.Fatalf("Create(%s) error = %v", a.Type, err)
+		}
+	}
+	if link.ID != 1 || media.ID != 2 {
+		t.Errorf("ids = %d, %d, want 1, 2", link.ID, media.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresAttachmentRepository_MediaFileIDsByTaskIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT media_file_id FROM task_attachments WHERE task_id = ANY($1) AND media_file_id IS NOT NULL")).
+		WithArgs(pq.Array([]int64{1, 2})).
+		WillReturnRows(sqlmock.NewRows([]string{"media_file_id"}).AddRow(int64(7)).AddRow(int64(9)))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM task_attachments WHERE id = $1")).
+		WithArgs(int64(4)).
+		WillReturnError(sql.ErrNoRows)
+
+	repo := NewPostgresAttachmentRepository(db)
+	ids, err := repo.MediaFileIDsByTaskIDs(context.Background(), []int64{1, 2})
+	if err != nil {
+		t.Fatalf("MediaFileIDsByTaskIDs() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 7 || ids[1] != 9 {
+		t.Errorf("ids = %v, want [7 9]", ids)
+	}
+	if _, err := repo.GetByID(context.Background(), 4); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Errorf("GetByID(missing) error = %v, want ErrAttachmentNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresSubtaskRepository_ProgressByTaskIDs(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT task_id, COUNT(*), COUNT(*) FILTER (WHERE status = $2) FROM subtasks WHERE task_id = ANY($1) GROUP BY task_id")).
+		WithArgs(pq.Array([]int64{1, 2, 3}), entity.StatusDone).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "count", "count"}).
+			AddRow(int64(1), 4, 2).
+			AddRow(int64(2), 1, 1))
+
+	progress, err := NewPostgresSubtaskRepository(db).ProgressByTaskIDs(context.Background(), []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("ProgressByTaskIDs() error = %v", err)
+	}
+	if progress[1] != (entity.SubtaskProgress{Total: 4, Completed: 2}) || progress[2] != (entity.SubtaskProgress{Total: 1, Completed: 1}) {
+		t.Errorf("ProgressByTaskIDs() = %+v", progress)
+	}
+	if _, ok := progress[3]; ok {
+		t.Errorf("task without subtasks should be absent, got %+v", progress[3])
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_CountByProject(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT project_id, COUNT(*), COUNT(*) FILTER (WHERE status = $1)")).
+		WithArgs(entity.StatusDone).
+		WillReturnRows(sqlmock.NewRows([]string{"project_id", "count", "count"}).
+			AddRow(int64(1), 5, 2).
+			AddRow(int64(4), 1, 0))
+
+	counts, err := NewPostgresTaskRepository(db).CountByProject(context.Background())
+	if err != nil {
+		t.Fatalf("CountByProject() error = %v", err)
+	}
+	if len(counts) != 2 || *counts[0] != (entity.ProjectTaskCount{ProjectID: 1, Total: 5, Completed: 2}) ||
+		*counts[1] != (entity.ProjectTaskCount{ProjectID: 4, Total: 1}) {
+		t.Errorf("CountByProject() = %+v", counts)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_Summarize(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	// Seeded: 3 Todo (2 overdue, 1 unassigned), 1 InProgress (overdue,
+	// unassigned), 2 Done (unassigned); done tasks are never overdue, nor
+	// are tasks due today
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT status, COUNT(*),")+`\s+`+regexp.QuoteMeta("COUNT(*) FILTER (WHERE due_date < $2::date AND status <> $3)")).
+		WithArgs(int64(7), now, entity.StatusDone).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count", "count", "count"}).
+			AddRow(entity.StatusTodo, 3, 2, 1).
+			AddRow(entity.StatusInProgress, 1, 1, 1).
+			AddRow(entity.StatusDone, 2, 0, 2))
+
+	summary, err := NewPostgresTaskRepository(db).Summarize(context.Background(), 7, now)
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	want := &entity.TaskSummary{
+		ProjectID:    7,
+		Total:        6,
+		StatusCounts: map[string]int{entity.StatusTodo: 3, entity.StatusInProgress: 1, entity.StatusDone: 2},
+		Overdue:      3,
+		Unassigned:   4,
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("Summarize() = %+v, want %+v", summary, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_Summarize_NoTasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT status, COUNT(*),")).
+		WillReturnRows(sqlmock.NewRows([]string{"status", "count", "count", "count"}))
+
+	summary, err := NewPostgresTaskRepository(db).Summarize(context.Background(), 7, time.Now())
+	if err != nil {
+		t.Fatalf("Summarize() error = %v", err)
+	}
+	want := map[string]int{entity.StatusTodo: 0, entity.StatusInProgress: 0, entity.StatusDone: 0}
+	if summary.Total != 0 || !reflect.DeepEqual(summary.StatusCounts, want) {
+		t.Errorf("Summarize() = %+v, want every status at zero", summary)
+	}
+}
+
+func TestPostgresTaskRepository_ListVersions(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	// Only the two columns a syncing client needs are selected
+	mock.ExpectQuery("^" + regexp.QuoteMeta("SELECT id, updated_at FROM tasks WHERE project_id = $1 ORDER BY id") + "$").
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "updated_at"}).
+			AddRow(int64(1), now).
+			AddRow(int64(4), now.Add(time.Minute)))
+
+	versions, err := NewPostgresTaskRepository(db).ListVersions(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("ListVersions() error = %v", err)
+	}
+	if len(versions) != 2 || versions[0].ID != 1 || versions[1].ID != 4 {
+		t.Errorf("ListVersions() = %+v, want ids 1 and 4", versions)
+	}
+	if !versions[1].UpdatedAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("ListVersions() updated_at = %v, want %v", versions[1].UpdatedAt, now.Add(time.Minute))
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_List_TagFilter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	base := "FROM tasks WHERE project_id = $1 AND status = $2 AND id IN (SELECT task_id FROM task_tag_mapping WHERE tag_id = $3)"
+	mock.ExpectQuery("^"+regexp.QuoteMeta("SELECT COUNT(*) "+base)+"$").
+		WithArgs(int64(1), "Todo", int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+	mock.ExpectQuery(regexp.QuoteMeta(base+" ORDER BY priority, due_date, id LIMIT $4 OFFSET $5")).
+		WithArgs(int64(1), "Todo", int64(7), 20, 0).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "title", "description", "status", "priority", "assigned_to", "due_date", "created_at", "updated_at"}).
+			AddRow(int64(4), int64(1), "Tagged", nil, "Todo", 1, int64(2), nil, time.Now(), time.Now()))
+
+	tasks, total, err := NewPostgresTaskRepository(db).List(context.Background(), 1, 1, 20, "Todo", 0, domain.TagFilter{IDs: []int64{7}})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if total != 1 || len(tasks) != 1 || tasks[0].ID != 4 {
+		t.Errorf("List() = %+v, total %d, want task 4", tasks, total)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_ListAfter(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("FROM tasks WHERE project_id = $1 AND assigned_to = $2 AND id > $3 ORDER BY id LIMIT $4")).
+		WithArgs(int64(1), int64(5), int64(40), 3).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "title", "description", "status", "priority", "assigned_to", "due_date", "created_at", "updated_at"}).
+			AddRow(int64(41), int64(1), "Next", nil, "Todo", 1, int64(5), nil, time.Now(), time.Now()).
+			AddRow(int64(57), int64(1), "Later", "notes", "Todo", 2, int64(5), nil, time.Now(), time.Now()))
+
+	tasks, err := NewPostgresTaskRepository(db).ListAfter(context.Background(), 1, 40, 3, "", 5, domain.TagFilter{})
+	if err != nil {
+		t.Fatalf("ListAfter() error = %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != 41 || tasks[1].ID != 57 || tasks[1].Description != "notes" {
+		t.Errorf("ListAfter() = %+v, want tasks 41 and 57", tasks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_List_MultipleTags(t *testing.T) {
+	tests := []struct {
+		name  string
+		tags  domain.TagFilter
+		where string
+		args  []driver.Value
+	}{
+		{
+			name:  "all",
+			tags:  domain.TagFilter{IDs: []int64{3, 7}},
+			where: "AND id IN (SELECT task_id FROM task_tag_mapping WHERE tag_id = ANY($2) GROUP BY task_id HAVING COUNT(*) = $3)",
+			args:  []driver.Value{int64(1), pq.Array([]int64{3, 7}), 2},
+		},
+		{
+			name:  "any",
+			tags:  domain.TagFilter{IDs: []int64{3, 7}, MatchAny: true},
+			where: "AND id IN (SELECT task_id FROM task_tag_mapping WHERE tag_id = ANY($2))",
+			args:  []driver.Value{int64(1), pq.Array([]int64{3, 7})},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			base := "FROM tasks WHERE project_id = $1 " + tt.where
+			mock.ExpectQuery("^" + regexp.QuoteMeta("SELECT COUNT(*) "+base) + "$").
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+			mock.ExpectQuery(regexp.QuoteMeta(base + " ORDER BY priority, due_date, id")).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "project_id", "title", "description", "status", "priority", "assigned_to", "due_date", "created_at", "updated_at"}))
+
+			if _, _, err := NewPostgresTaskRepository(db).List(context.Background(), 1, 1, 20, "", 0, tt.tags); err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresTagRepository_GetByName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	query := "^" + regexp.QuoteMeta("SELECT id, name FROM task_tags WHERE name = $1") + "$"
+	mock.ExpectQuery(query).WithArgs("backend").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(int64(3), "backend"))
+	mock.ExpectQuery(query).WithArgs("missing").WillReturnError(sql.ErrNoRows)
+
+	repo := NewPostgresTagRepository(db)
+	tag, err := repo.GetByName(context.Background(), "backend")
+	if err != nil || tag.ID != 3 {
+		t.Fatalf("GetByName(backend) = %+v, %v, want tag 3", tag, err)
+	}
+	if _, err := repo.GetByName(context.Background(), "missing"); !errors.Is(err, domain.ErrTagNotFound) {
+		t.Errorf("GetByName(missing) error = %v, want ErrTagNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_Duplicate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	task := &entity.Task{ProjectID: 1, Title: "Copy", Status: entity.StatusTodo, Priority: 2, CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("INSERT INTO tasks")).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(8)))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO subtasks (task_id, title, status, assigned_to, due_date, created_at, updated_at, position)")).
+		WithArgs(int64(8), entity.StatusTodo, false, now, int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO task_tag_mapping (task_id, tag_id)")).
+		WithArgs(int64(8), int64(3)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectOutbox(mock, entity.TaskCreated)
+	mock.ExpectCommit()
+
+	if err := NewPostgresTaskRepository(db).Duplicate(context.Background(), 3, task, false); err != nil {
+		t.Fatalf("Duplicate() error = %v", err)
+	}
+	if task.ID != 8 {
+		t.Errorf("task.ID = %d, want 8", task.ID)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskRepository_Move(t *testing.T) {
+	move := regexp.QuoteMeta("UPDATE tasks SET project_id = $1")
+	unassign := regexp.QuoteMeta("UPDATE subtasks SET assigned_to = NULL WHERE task_id = $1")
+	// The moved task is reloaded for its TaskUpdated event
+	reload := regexp.QuoteMeta("FROM tasks WHERE id = $1")
+	moved := func() *sqlmock.Rows {
+		now := time.Now()
+		return sqlmock.NewRows(taskColumns).
+			AddRow(int64(7), int64(2), "Moved", "", entity.StatusTodo, 2, nil, nil, now, now)
+	}
+
+	t.Run("Keeping assignees", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New() error = %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(move).WithArgs(int64(2), sqlmock.AnyArg(), false, int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(reload).WithArgs(int64(7)).WillReturnRows(moved())
+		expectWatchers(mock)
+		expectOutbox(mock, entity.TaskUpdated)
+		mock.ExpectCommit()
+
+		if err := NewPostgresTaskRepository(db).Move(context.Background(), 7, 2, false); err != nil {
+			t.Fatalf("Move() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("Clearing assignees", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New() error = %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(move).WithArgs(int64(2), sqlmock.AnyArg(), true, int64(7)).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(unassign).WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectQuery(reload).WithArgs(int64(7)).WillReturnRows(moved())
+		expectWatchers(mock)
+		expectOutbox(mock, entity.TaskUpdated)
+		mock.ExpectCommit()
+
+		if err := NewPostgresTaskRepository(db).Move(context.Background(), 7, 2, true); err != nil {
+			t.Fatalf("Move() error = %v", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("Unknown project", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New() error = %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(move).WillReturnError(&pq.Error{Code: "23503"})
+		mock.ExpectRollback()
+
+		if err := NewPostgresTaskRepository(db).Move(context.Background(), 7, 99, false); !errors.Is(err, domain.ErrProjectNotFound) {
+			t.Errorf("Move() error = %v, want ErrProjectNotFound", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+
+	t.Run("Unknown task", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		if err != nil {
+			t.Fatalf("sqlmock.New() error = %v", err)
+		}
+		defer db.Close()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(move).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectRollback()
+
+		if err := NewPostgresTaskRepository(db).Move(context.Background(), 7, 2, false); !errors.Is(err, sql.ErrNoRows) {
+			t.Errorf("Move() error = %v, want sql.ErrNoRows", err)
+		}
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("unmet expectations: %v", err)
+		}
+	})
+}
+
+func TestPostgresTaskDefaultsRepository_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	query := regexp.QuoteMeta("FROM project_settings WHERE project_id = $1")
+	mock.ExpectQuery(query).WithArgs(int64(1)).
+		WillReturnRows(sqlmock.NewRows([]string{"default_task_status", "default_task_priority"}).AddRow(entity.StatusInProgress, 1))
+	mock.ExpectQuery(query).WithArgs(int64(2)).WillReturnError(sql.ErrNoRows)
+
+	repo := NewPostgresTaskDefaultsRepository(db)
+	defaults, err := repo.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Get(1) error = %v", err)
+	}
+	if *defaults != (entity.TaskDefaults{Status: entity.StatusInProgress, Priority: 1}) {
+		t.Errorf("Get(1) = %+v, want InProgress at priority 1", defaults)
+	}
+	defaults, err = repo.Get(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Get(2) error = %v", err)
+	}
+	if *defaults != (entity.TaskDefaults{}) {
+		t.Errorf("Get(2) = %+v, want no defaults", defaults)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTaskTagRepository_RemoveMissing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	remove := "^" + regexp.QuoteMeta("DELETE FROM task_tag_mapping WHERE task_id = $1 AND tag_id = $2") + "$"
+	mock.ExpectExec(remove).WithArgs(int64(1), int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(remove).WithArgs(int64(1), int64(4)).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ctx := context.Background()
+	taskTags := NewPostgresTaskTagRepository(db)
+	if err := taskTags.Remove(ctx, 1, 3); err != nil {
+		t.Fatalf("Remove(1, 3) error = %v", err)
+	}
+	if err := taskTags.Remove(ctx, 1, 4); !errors.Is(err, domain.ErrTaskTagNotFound) {
+		t.Errorf("Remove(1, 4) error = %v, want ErrTaskTagNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresTagRepository_Delete(t *testing.T) {
+	lock := "^" + regexp.QuoteMeta("SELECT id FROM task_tags WHERE id = $1 FOR UPDATE") + "$"
+	inUse := "^" + regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM task_tag_mapping WHERE tag_id = $1)") + "$"
+	detach := "^" + regexp.QuoteMeta("DELETE FROM task_tag_mapping WHERE tag_id = $1") + "$"
+	deleteTag := "^" + regexp.QuoteMeta("DELETE FROM task_tags WHERE id = $1") + "$"
+	locked := func(mock sqlmock.Sqlmock) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(lock).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(int64(3)))
+	}
+
+	tests := []struct {
+		name    string
+		unused  bool
+		expect  func(mock sqlmock.Sqlmock)
+		wantErr error
+	}{
+		{
+			name: "Detaches tasks",
+			expect: func(mock sqlmock.Sqlmock) {
+				locked(mock)
+				mock.ExpectExec(detach).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(deleteTag).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Unused tag",
+			unused: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				locked(mock)
+				mock.ExpectQuery(inUse).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+				mock.ExpectExec(deleteTag).WithArgs(int64(3)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Tag in use",
+			unused: true,
+			expect: func(mock sqlmock.Sqlmock) {
+				locked(mock)
+				mock.ExpectQuery(inUse).WithArgs(int64(3)).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrTagInUse,
+		},
+		{
+			name: "Missing tag",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(lock).WithArgs(int64(3)).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: domain.ErrTagNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+			tt.expect(mock)
+
+			repo := NewPostgresTagRepository(db)
+			if tt.unused {
+				err = repo.DeleteUnused(context.Background(), 3)
+			} else {
+				err = repo.Delete(context.Background(), 3)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("delete error = %v, want %v", err, tt.wantErr)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresChildRepositories_GetByTaskIDPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		page       int
+		limit      int
+		wantOffset int
+	}{
+		{name: "First page", page: 1, limit: 20, wantOffset: 0},
+		{name: "Third page", page: 3, limit: 10, wantOffset: 20},
+		{name: "Single row pages", page: 5, limit: 1, wantOffset: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New() error = %v", err)
+			}
+			defer db.Close()
+
+			now := time.Now()
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM subtasks WHERE task_id = $1")).
+				WithArgs(int64(7)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(45))
+			mock.ExpectQuery(regexp.QuoteMeta("FROM subtasks WHERE task_id = $1 ORDER BY position, id LIMIT $2 OFFSET $3")).
+				WithArgs(int64(7), tt.limit, tt.wantOffset).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "title", "status", "assigned_to", "due_date", "position", "created_at", "updated_at"}).
+					AddRow(int64(tt.wantOffset+1), int64(7), "Subtask", "Todo", int64(0), nil, tt.wantOffset+1, now, now))
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM task_comments WHERE task_id = $1")).
+				WithArgs(int64(7)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(45))
+			mock.ExpectQuery(regexp.QuoteMeta("FROM task_comments WHERE task_id = $1 ORDER BY created_at, id LIMIT $2 OFFSET $3")).
+				WithArgs(int64(7), tt.limit, tt.wantOffset).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "user_id", "comment", "created_at"}).
+					AddRow(int64(tt.wantOffset+1), int64(7), int64(1), "Comment", now))
+
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM task_attachments WHERE task_id = $1")).
+				WithArgs(int64(7)).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(45))
+			mock.ExpectQuery(regexp.QuoteMeta("FROM task_attachments WHERE task_id = $1 ORDER BY uploaded_at, id LIMIT $2 OFFSET $3")).
+				WithArgs(int64(7), tt.limit, tt.wantOffset).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "file_url", "attachment_type", "media_file_id", "uploaded_at"}).
+					AddRow(int64(tt.wantOffset+1), int64(7), "/uploads/a.png", "media", int64(3), now))
+
+			ctx := context.Background()
+
+			subtasks, total, err := NewPostgresSubtaskRepository(db).GetByTaskID(ctx, 7, tt.page, tt.limit)
+			if err != nil || total != 45 || len(subtasks) != 1 {
+				t.Errorf("subtasks GetByTaskID() = %d rows, total %d, err %v", len(subtasks), total, err)
+			}
+			comments, total, err := NewPostgresCommentRepository(db).GetByTaskID(ctx, 7, tt.page, tt.limit)
+			if err != nil || total != 45 || len(comments) != 1 {
+				t.Errorf("comments GetByTaskID() = %d rows, total %d, err %v", len(comments), total, err)
+			}
+			attachments, total, err := NewPostgresAttachmentRepository(db).GetByTaskID(ctx, 7, tt.page, tt.limit)
+			if err != nil || total != 45 || len(attachments) != 1 {
+				t.Errorf("attachments GetByTaskID() = %d rows, total %d, err %v", len(attachments), total, err)
+			}
+
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("unmet expectations: %v", err)
+			}
+		})
+	}
+}
+
+func TestPostgresRepositories_DeleteByTaskID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_comments WHERE task_id = $1")).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_attachments WHERE task_id = $1")).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	comments, err := NewPostgresCommentRepository(db).DeleteByTaskID(context.Background(), 7)
+	if err != nil || comments != 3 {
+		t.Errorf("comments DeleteByTaskID() = %d, %v; want 3, nil", comments, err)
+	}
+	attachments, err := NewPostgresAttachmentRepository(db).DeleteByTaskID(context.Background(), 7)
+	if err != nil || attachments != 2 {
+		t.Errorf("attachments DeleteByTaskID() = %d, %v; want 2, nil", attachments, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresRepositories_DeleteByTaskID_NoRows(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// Nothing deleted: the task is looked up to tell an empty task from a missing one
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_comments WHERE task_id = $1")).
+		WithArgs(int64(7)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)")).
+		WithArgs(int64(7)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM task_attachments WHERE task_id = $1")).
+		WithArgs(int64(8)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT EXISTS (SELECT 1 FROM tasks WHERE id = $1)")).
+		WithArgs(int64(8)).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	if n, err := NewPostgresCommentRepository(db).DeleteByTaskID(context.Background(), 7); n != 0 || err != nil {
+		t.Errorf("comments DeleteByTaskID() = %d, %v; want 0, nil", n, err)
+	}
+	if _, err := NewPostgresAttachmentRepository(db).DeleteByTaskID(context.Background(), 8); err != domain.ErrTaskNotFound {
+		t.Errorf("attachments DeleteByTaskID() error = %v, want ErrTaskNotFound", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgresWatcherRepository(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// Adding twice leaves one row; removing a non-watcher is no error
+	add := "^" + regexp.QuoteMeta("INSERT INTO task_watchers (task_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING") + "$"
+	remove := "^" + regexp.QuoteMeta("DELETE FROM task_watchers WHERE task_id = $1 AND user_id = $2") + "$"
+	mock.ExpectExec(add).WithArgs(int64(4), int64(8)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(add).WithArgs(int64(4), int64(8)).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(remove).WithArgs(int64(4), int64(9)).WillReturnResult(sqlmock.NewResult(0, 0))
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM task_watchers WHERE task_id = $1 ORDER BY created_at, user_id")).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"task_id", "user_id", "created_at"}).AddRow(int64(4), int64(8), now))
+
+	ctx := context.Background()
+	watchers := NewPostgresWatcherRepository(db)
+	for i := 0; i < 2; i++ {
+		if err := watchers.Add(ctx, 4, 8); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+	}
+	if err := watchers.Remove(ctx, 4, 9); err != nil {
+		t.Fatalf("Remove(non-watcher) error = %v", err)
+	}
+	list, err := watchers.GetByTaskID(ctx, 4)
+	if err != nil {
+		t.Fatalf("GetByTaskID() error = %v", err)
+	}
+	if len(list) != 1 || list[0].UserID != 8 || !list[0].CreatedAt.Equal(now) {
+		t.Errorf("GetByTaskID() = %+v, want user 8", list)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresSubtaskRepository_UnassignedSubtasks(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	// Move with clearAssignees leaves subtasks with a NULL assignee
+	columns := []string{"id", "task_id", "title", "status", "assigned_to", "due_date", "position", "created_at", "updated_at"}
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM subtasks WHERE id = $1")).
+		WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(int64(3), int64(4), "Cleared", entity.StatusTodo, nil, nil, 1, now, now))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT COUNT(*) FROM subtasks WHERE task_id = $1")).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM subtasks WHERE task_id = $1 ORDER BY position, id")).
+		WithArgs(int64(4), 10, 0).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(int64(3), int64(4), "Cleared", entity.StatusTodo, nil, nil, 1, now, now).
+			AddRow(int64(5), int64(4), "Assigned", entity.StatusTodo, int64(6), nil, 2, now, now))
+
+	repo := NewPostgresSubtaskRepository(db)
+	subtask, err := repo.GetByID(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("GetByID() error = %v", err)
+	}
+	if subtask.AssignedTo != nil {
+		t.Errorf("GetByID() assignee = %d, want nil", *subtask.AssignedTo)
+	}
+	subtasks, _, err := repo.GetByTaskID(context.Background(), 4, 1, 10)
+	if err != nil {
+		t.Fatalf("GetByTaskID() error = %v", err)
+	}
+	if len(subtasks) != 2 || subtasks[0].AssignedTo != nil || subtasks[1].AssignedTo == nil || *subtasks[1].AssignedTo != 6 {
+		t.Errorf("GetByTaskID() = %+v, want the first unassigned and the second assigned to 6", subtasks)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+func TestPostgresSubtaskRepository_AssigneesByTaskID(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT assigned_to FROM subtasks WHERE task_id = $1 AND assigned_to <> 0")).
+		WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"assigned_to"}).AddRow(int64(5)).AddRow(int64(6)))
+
+	ids, err := NewPostgresSubtaskRepository(db).AssigneesByTaskID(context.Background(), 4)
+	if err != nil {
+		t.Fatalf("AssigneesByTaskID() error = %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 5 || ids[1] != 6 {
+		t.Errorf("AssigneesByTaskID() = %v, want [5 6]", ids)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
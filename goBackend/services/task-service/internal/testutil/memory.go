@@ -0,0 +1,1093 @@
+// Package testutil provides in-memory implementations of the task service
+// repositories so usecases can be tested without a database. They follow the
+// Postgres repositories: missing rows are sql.ErrNoRows, lists use the same
+// ordering and pagination, and deleting a task removes its children.
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/portfolio/task-service/internal/domain/entity"
+	"github.com/portfolio/task-service/internal/domain/repository"
+)
+
+// Store holds the rows shared by the in-memory repositories, so that joins
+// such as the tag filter and cascades on delete work across them
+type Store struct {
+	mu          sync.Mutex
+	lastID      map[string]int64
+	tasks       map[int64]*entity.Task
+	subtasks    map[int64]*entity.Subtask
+	comments    map[int64]*entity.TaskComment
+	attachments map[int64]*entity.TaskAttachment
+	tags        map[int64]*entity.TaskTag
+	taskTags    map[int64]map[int64]bool // task id -> tag ids
+	watchers    map[int64][]*entity.TaskWatcher
+	activities  []*entity.TaskActivity
+	defaults    map[int64]entity.TaskDefaults // stands in for the project service's settings
+}
+
+// NewStore creates an empty Store
+func NewStore() *Store {
+	return &Store{
+		lastID:      make(map[string]int64),
+		tasks:       make(map[int64]*entity.Task),
+		subtasks:    make(map[int64]*entity.Subtask),
+		comments:    make(map[int64]*entity.TaskComment),
+		attachments: make(map[int64]*entity.TaskAttachment),
+		tags:        make(map[int64]*entity.TaskTag),
+		taskTags:    make(map[int64]map[int64]bool),
+		watchers:    make(map[int64][]*entity.TaskWatcher),
+		defaults:    make(map[int64]entity.TaskDefaults),
+	}
+}
+
+// Activities returns the recorded activity log
+func (s *Store) Activities() []*entity.TaskActivity {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*entity.TaskActivity(nil), s.activities...)
+}
+
+// SetTaskDefaults sets the task defaults of a project, which
+// TaskDefaultsRepository.Get reports
+func (s *Store) SetTaskDefaults(projectID int64, defaults entity.TaskDefaults) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.defaults[projectID] = defaults
+}
+
+// nextID emulates a SERIAL column; the caller holds mu
+func (s *Store) nextID(table string) int64 {
+	s.lastID[table]++
+	return s.lastID[table]
+}
+
+// deleteTasks removes tasks and everything that references them; the caller holds mu
+func (s *Store) deleteTasks(ids []int64) int64 {
+	var deleted int64
+	for _, id := range ids {
+		if _, ok := s.tasks[id]; !ok {
+			continue
+		}
+		delete(s.tasks, id)
+		delete(s.taskTags, id)
+		delete(s.watchers, id)
+		for sid, st := range s.subtasks {
+			if st.TaskID == id {
+				delete(s.subtasks, sid)
+			}
+		}
+		for cid, c := range s.comments {
+			if c.TaskID == id {
+				delete(s.comments, cid)
+			}
+		}
+		for aid, a := range s.attachments {
+			if a.TaskID == id {
+				delete(s.attachments, aid)
+			}
+		}
+		deleted++
+	}
+	return deleted
+}
+
+// nextPosition places a subtask after the task's existing subtasks; the caller holds mu
+func (s *Store) nextPosition(taskID int64) int {
+	last := 0
+	for _, st := range s.subtasks {
+		if st.TaskID == taskID && st.Position > last {
+			last = st.Position
+		}
+	}
+	return last + 1
+}
+
+// paginate returns the given page of items, or nil when it is empty
+func paginate[T any](items []T, page, limit int) []T {
+	offset := (page - 1) * limit
+	if offset < 0 || offset >= len(items) {
+		return nil
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[offset:end]
+}
+
+// TaskRepository is an in-memory repository.TaskRepository
+type TaskRepository struct{ s *Store }
+
+// NewTaskRepository creates a TaskRepository backed by s
+func NewTaskRepository(s *Store) *TaskRepository { return &TaskRepository{s: s} }
+
+var _ repository.TaskRepository = (*TaskRepository)(nil)
+
+// Create stores task and assigns its ID
+func (r *TaskRepository) Create(ctx context.Context, task *entity.Task) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	task.ID = r.s.nextID("tasks")
+	stored := *task
+	r.s.tasks[task.ID] = &stored
+	return nil
+}
+
+// GetByID gets a copy of a task by ID
+func (r *TaskRepository) GetByID(ctx context.Context, id int64) (*entity.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	task, ok := r.s.tasks[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *task
+	found.Subtasks, found.Tags = nil, nil
+	return &found, nil
+}
+
+// GetByIDs gets copies of the tasks that exist among ids, in id order
+func (r *TaskRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var found []*entity.Task
+	for _, id := range ids {
+		if task, ok := r.s.tasks[id]; ok {
+			copied := *task
+			copied.Subtasks, copied.Tags = nil, nil
+			found = append(found, &copied)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].ID < found[j].ID })
+	return found, nil
+}
+
+// Update overwrites the stored task; unknown IDs are ignored like an UPDATE matching no rows
+func (r *TaskRepository) Update(ctx context.Context, task *entity.Task) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	task.UpdatedAt = time.Now()
+	if _, ok := r.s.tasks[task.ID]; ok {
+		stored := *task
+		r.s.tasks[task.ID] = &stored
+	}
+	return nil
+}
+
+// Delete deletes a task and its children
+func (r *TaskRepository) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.deleteTasks([]int64{id})
+	return nil
+}
+
+// DeleteMany deletes several tasks and their children, returning how many tasks existed
+func (r *TaskRepository) DeleteMany(ctx context.Context, ids []int64) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.deleteTasks(ids), nil
+}
+
+// CreateFromSubtask stores task and deletes the subtask; nothing changes if the subtask is missing
+func (r *TaskRepository) CreateFromSubtask(ctx context.Context, task *entity.Task, subtaskID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.subtasks[subtaskID]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(r.s.subtasks, subtaskID)
+	task.ID = r.s.nextID("tasks")
+	stored := *task
+	r.s.tasks[task.ID] = &stored
+	return nil
+}
+
+// ConvertToSubtask stores subtask after its parent's subtasks, moves the
+// task's subtasks after it and its comments to the parent, and deletes the
+// task, unless the parent would end up with more than max subtasks
+func (r *TaskRepository) ConvertToSubtask(ctx context.Context, taskID int64, subtask *entity.Subtask, max int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tasks[taskID]; !ok {
+		return sql.ErrNoRows
+	}
+	if _, ok := r.s.tasks[subtask.TaskID]; !ok {
+		return sql.ErrNoRows
+	}
+	if max > 0 {
+		count := 0
+		for _, st := range r.s.subtasks {
+			if st.TaskID == subtask.TaskID || st.TaskID == taskID {
+				count++
+			}
+		}
+		if count+1 > max {
+			return repository.ErrLimitExceeded
+		}
+	}
+
+	subtask.ID = r.s.nextID("subtasks")
+	subtask.Position = r.s.nextPosition(subtask.TaskID)
+	stored := *subtask
+	r.s.subtasks[subtask.ID] = &stored
+
+	// The task's subtasks follow in their order
+	var moved []*entity.Subtask
+	for _, st := range r.s.subtasks {
+		if st.TaskID == taskID {
+			moved = append(moved, st)
+		}
+	}
+	sort.Slice(moved, func(i, j int) bool {
+		if moved[i].Position != moved[j].Position {
+			return moved[i].Position < moved[j].Position
+		}
+		return moved[i].ID < moved[j].ID
+	})
+	for i, st := range moved {
+		st.TaskID = subtask.TaskID
+		st.Position = subtask.Position + i + 1
+	}
+	for _, c := range r.s.comments {
+		if c.TaskID == taskID {
+			c.TaskID = subtask.TaskID
+		}
+	}
+	r.s.deleteTasks([]int64{taskID})
+	return nil
+}
+
+// Duplicate stores task as a copy of the task with sourceID, copying its
+// subtasks as Todo and its tags
+func (r *TaskRepository) Duplicate(ctx context.Context, sourceID int64, task *entity.Task, keepDueDates bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	task.ID = r.s.nextID("tasks")
+	stored := *task
+	stored.Subtasks, stored.Tags = nil, nil
+	r.s.tasks[task.ID] = &stored
+
+	var subtasks []*entity.Subtask
+	for _, st := range r.s.subtasks {
+		if st.TaskID == sourceID {
+			subtasks = append(subtasks, st)
+		}
+	}
+	sort.Slice(subtasks, func(i, j int) bool {
+		if subtasks[i].Position != subtasks[j].Position {
+			return subtasks[i].Position < subtasks[j].Position
+		}
+		return subtasks[i].ID < subtasks[j].ID
+	})
+	for _, st := range subtasks {
+		copied := *st
+		copied.ID = r.s.nextID("subtasks")
+		copied.TaskID = task.ID
+		copied.Status = entity.StatusTodo
+		copied.CreatedAt, copied.UpdatedAt = task.CreatedAt, task.CreatedAt
+		if !keepDueDates {
+			copied.DueDate = nil
+		}
+		r.s.subtasks[copied.ID] = &copied
+	}
+
+	if tags := r.s.taskTags[sourceID]; len(tags) > 0 {
+		r.s.taskTags[task.ID] = make(map[int64]bool, len(tags))
+		for id := range tags {
+			r.s.taskTags[task.ID][id] = true
+		}
+	}
+	return nil
+}
+
+// Move moves a task to another project, unassigning it and its subtasks
+// when clearAssignees is set. Projects aren't stored, so every project exists.
+func (r *TaskRepository) Move(ctx context.Context, taskID, projectID int64, clearAssignees bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	task, ok := r.s.tasks[taskID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	task.ProjectID = projectID
+	task.UpdatedAt = time.Now()
+	if clearAssignees {
+		task.AssignedTo = nil
+		for _, st := range r.s.subtasks {
+			if st.TaskID == taskID {
+				st.AssignedTo = nil
+			}
+		}
+	}
+	return nil
+}
+
+// List lists a project's tasks ordered by priority then due date, with
+// undated tasks last
+func (r *TaskRepository) List(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64, tags repository.TagFilter) ([]*entity.Task, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	matched := r.match(projectID, status, assignedTo, tags)
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := matched[i], matched[j]
+		if a.Priority != b.Priority {
+			return a.Priority < b.Priority
+		}
+		switch {
+		case a.DueDate == nil && b.DueDate == nil:
+		case a.DueDate == nil:
+			return false
+		case b.DueDate == nil:
+			return true
+		case !a.DueDate.Equal(*b.DueDate):
+			return a.DueDate.Before(*b.DueDate)
+		}
+		return a.ID < b.ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// ListAfter lists up to limit tasks with an id greater than afterID, in id order
+func (r *TaskRepository) ListAfter(ctx context.Context, projectID, afterID int64, limit int, status string, assignedTo int64, tags repository.TagFilter) ([]*entity.Task, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var after []*entity.Task
+	for _, t := range r.match(projectID, status, assignedTo, tags) {
+		if t.ID > afterID {
+			after = append(after, t)
+		}
+	}
+	sort.Slice(after, func(i, j int) bool { return after[i].ID < after[j].ID })
+	if len(after) > limit {
+		after = after[:limit]
+	}
+	return after, nil
+}
+
+// match copies the tasks that pass the List filters; the caller holds the lock
+func (r *TaskRepository) match(projectID int64, status string, assignedTo int64, tags repository.TagFilter) []*entity.Task {
+	var matched []*entity.Task
+	for _, t := range r.s.tasks {
+		if t.ProjectID != projectID {
+			continue
+		}
+		if status != "" && t.Status != status {
+			continue
+		}
+		if assignedTo > 0 && (t.AssignedTo == nil || *t.AssignedTo != assignedTo) {
+			continue
+		}
+		if !hasTags(r.s.taskTags[t.ID], tags) {
+			continue
+		}
+		found := *t
+		matched = append(matched, &found)
+	}
+	return matched
+}
+
+// hasTags reports whether a task with the given tags passes filter
+func hasTags(taskTags map[int64]bool, filter repository.TagFilter) bool {
+	if len(filter.IDs) == 0 {
+		return true
+	}
+	for _, id := range filter.IDs {
+		has := taskTags[id]
+		if filter.MatchAny && has {
+			return true
+		}
+		if !filter.MatchAny && !has {
+			return false
+		}
+	}
+	return !filter.MatchAny
+}
+
+// ListVersions returns the id and updated_at of every task in a project
+func (r *TaskRepository) ListVersions(ctx context.Context, projectID int64) ([]*entity.TaskVersion, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var versions []*entity.TaskVersion
+	for _, t := range r.s.tasks {
+		if t.ProjectID == projectID {
+			versions = append(versions, &entity.TaskVersion{ID: t.ID, UpdatedAt: t.UpdatedAt})
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].ID < versions[j].ID })
+	return versions, nil
+}
+
+// Summarize counts a project's tasks per status, and those overdue as of now
+// or unassigned
+func (r *TaskRepository) Summarize(ctx context.Context, projectID int64, now time.Time) (*entity.TaskSummary, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	summary := entity.NewTaskSummary(projectID)
+	for _, t := range r.s.tasks {
+		if t.ProjectID != projectID {
+			continue
+		}
+		summary.Total++
+		summary.StatusCounts[t.Status]++
+		// Due dates are whole days, so a task due today isn't overdue yet
+		if t.DueDate != nil && calendarDay(*t.DueDate).Before(calendarDay(now)) && t.Status != entity.StatusDone {
+			summary.Overdue++
+		}
+		if t.AssignedTo == nil {
+			summary.Unassigned++
+		}
+	}
+	return summary, nil
+}
+
+// calendarDay is the UTC date of t, like DATE() on the Postgres side
+func calendarDay(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// CountByProject counts the tasks of every project that has any, in project order
+func (r *TaskRepository) CountByProject(ctx context.Context) ([]*entity.ProjectTaskCount, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	byProject := make(map[int64]*entity.ProjectTaskCount)
+	for _, t := range r.s.tasks {
+		count, ok := byProject[t.ProjectID]
+		if !ok {
+			count = &entity.ProjectTaskCount{ProjectID: t.ProjectID}
+			byProject[t.ProjectID] = count
+		}
+		count.Total++
+		if t.Status == entity.StatusDone {
+			count.Completed++
+		}
+	}
+	counts := make([]*entity.ProjectTaskCount, 0, len(byProject))
+	for _, count := range byProject {
+		counts = append(counts, count)
+	}
+	sort.Slice(counts, func(i, j int) bool { return counts[i].ProjectID < counts[j].ProjectID })
+	return counts, nil
+}
+
+// SubtaskRepository is an in-memory repository.SubtaskRepository
+type SubtaskRepository struct{ s *Store }
+
+// NewSubtaskRepository creates a SubtaskRepository backed by s
+func NewSubtaskRepository(s *Store) *SubtaskRepository { return &SubtaskRepository{s: s} }
+
+var _ repository.SubtaskRepository = (*SubtaskRepository)(nil)
+
+// Create stores subtask after the task's existing subtasks
+func (r *SubtaskRepository) Create(ctx context.Context, subtask *entity.Subtask) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	subtask.ID = r.s.nextID("subtasks")
+	subtask.Position = r.s.nextPosition(subtask.TaskID)
+	stored := *subtask
+	r.s.subtasks[subtask.ID] = &stored
+	return nil
+}
+
+// CreateMany stores subtasks in the given order after the task's existing
+// subtasks, unless the task is missing or that would take it past max
+func (r *SubtaskRepository) CreateMany(ctx context.Context, taskID int64, subtasks []*entity.Subtask, max int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tasks[taskID]; !ok {
+		return repository.ErrTaskNotFound
+	}
+	if max > 0 {
+		count := 0
+		for _, s := range r.s.subtasks {
+			if s.TaskID == taskID {
+				count++
+			}
+		}
+		if count+len(subtasks) > max {
+			return repository.ErrLimitExceeded
+		}
+	}
+	last := r.s.nextPosition(taskID) - 1
+	for i, subtask := range subtasks {
+		subtask.TaskID = taskID
+		subtask.Position = last + i + 1
+		subtask.ID = r.s.nextID("subtasks")
+		stored := *subtask
+		r.s.subtasks[subtask.ID] = &stored
+	}
+	return nil
+}
+
+// GetByID gets a copy of a subtask by ID
+func (r *SubtaskRepository) GetByID(ctx context.Context, id int64) (*entity.Subtask, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	subtask, ok := r.s.subtasks[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *subtask
+	return &found, nil
+}
+
+// Update overwrites a subtask but keeps its position, like the Postgres UPDATE
+func (r *SubtaskRepository) Update(ctx context.Context, subtask *entity.Subtask) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	subtask.UpdatedAt = time.Now()
+	if current, ok := r.s.subtasks[subtask.ID]; ok {
+		stored := *subtask
+		stored.Position = current.Position
+		stored.CreatedAt = current.CreatedAt
+		r.s.subtasks[subtask.ID] = &stored
+	}
+	return nil
+}
+
+// MoveToTask moves subtask under taskID after that task's existing subtasks,
+// unless taskID already has max subtasks
+func (r *SubtaskRepository) MoveToTask(ctx context.Context, subtask *entity.Subtask, taskID int64, max int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tasks[taskID]; !ok {
+		return repository.ErrTaskNotFound
+	}
+	if max > 0 {
+		count := 0
+		for _, st := range r.s.subtasks {
+			if st.TaskID == taskID {
+				count++
+			}
+		}
+		if count+1 > max {
+			return repository.ErrLimitExceeded
+		}
+	}
+	stored, ok := r.s.subtasks[subtask.ID]
+	if !ok || stored.TaskID != subtask.TaskID {
+		return sql.ErrNoRows
+	}
+	stored.Position = r.s.nextPosition(taskID)
+	stored.TaskID = taskID
+	stored.UpdatedAt = time.Now()
+	subtask.TaskID, subtask.Position, subtask.UpdatedAt = stored.TaskID, stored.Position, stored.UpdatedAt
+	return nil
+}
+
+// Delete deletes a subtask
+func (r *SubtaskRepository) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.subtasks, id)
+	return nil
+}
+
+// GetByTaskID gets a page of a task's subtasks in position order along with the total count
+func (r *SubtaskRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.Subtask, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var matched []*entity.Subtask
+	for _, st := range r.s.subtasks {
+		if st.TaskID == taskID {
+			found := *st
+			matched = append(matched, &found)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].Position != matched[j].Position {
+			return matched[i].Position < matched[j].Position
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// ProgressByTaskIDs counts all and completed subtasks per task
+func (r *SubtaskRepository) ProgressByTaskIDs(ctx context.Context, taskIDs []int64) (map[int64]entity.SubtaskProgress, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	wanted := make(map[int64]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		wanted[id] = true
+	}
+	progress := make(map[int64]entity.SubtaskProgress, len(taskIDs))
+	for _, st := range r.s.subtasks {
+		if !wanted[st.TaskID] {
+			continue
+		}
+		p := progress[st.TaskID]
+		p.Total++
+		if st.Status == entity.StatusDone {
+			p.Completed++
+		}
+		progress[st.TaskID] = p
+	}
+	return progress, nil
+}
+
+// AssigneesByTaskID returns the distinct users assigned to a task's subtasks
+func (r *SubtaskRepository) AssigneesByTaskID(ctx context.Context, taskID int64) ([]int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, st := range r.s.subtasks {
+		if st.TaskID == taskID && st.AssignedTo != nil && !seen[*st.AssignedTo] {
+			seen[*st.AssignedTo] = true
+			ids = append(ids, *st.AssignedTo)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// CommentRepository is an in-memory repository.CommentRepository
+type CommentRepository struct{ s *Store }
+
+// NewCommentRepository creates a CommentRepository backed by s
+func NewCommentRepository(s *Store) *CommentRepository { return &CommentRepository{s: s} }
+
+var _ repository.CommentRepository = (*CommentRepository)(nil)
+
+// Create stores comment and assigns its ID
+func (r *CommentRepository) Create(ctx context.Context, comment *entity.TaskComment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	comment.ID = r.s.nextID("task_comments")
+	stored := *comment
+	r.s.comments[comment.ID] = &stored
+	return nil
+}
+
+// GetByID gets a copy of a comment by ID
+func (r *CommentRepository) GetByID(ctx context.Context, id int64) (*entity.TaskComment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	comment, ok := r.s.comments[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *comment
+	return &found, nil
+}
+
+// Delete deletes a comment
+func (r *CommentRepository) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.comments, id)
+	return nil
+}
+
+// DeleteByTaskID deletes all comments on a task
+func (r *CommentRepository) DeleteByTaskID(ctx context.Context, taskID int64) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if r.s.tasks[taskID] == nil {
+		return 0, repository.ErrTaskNotFound
+	}
+	var deleted int64
+	for id, c := range r.s.comments {
+		if c.TaskID == taskID {
+			delete(r.s.comments, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// GetByTaskID gets a page of a task's comments, oldest first, along with the total count
+func (r *CommentRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskComment, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var matched []*entity.TaskComment
+	for _, c := range r.s.comments {
+		if c.TaskID == taskID {
+			found := *c
+			matched = append(matched, &found)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.Before(matched[j].CreatedAt)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// GetByUserID gets a page of a user's comments, newest first
+func (r *CommentRepository) GetByUserID(ctx context.Context, userID int64, page, limit int) ([]*entity.TaskComment, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var matched []*entity.TaskComment
+	for _, c := range r.s.comments {
+		if c.UserID == userID {
+			found := *c
+			matched = append(matched, &found)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].CreatedAt.Equal(matched[j].CreatedAt) {
+			return matched[i].CreatedAt.After(matched[j].CreatedAt)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// AttachmentRepository is an in-memory repository.AttachmentRepository
+type AttachmentRepository struct{ s *Store }
+
+// NewAttachmentRepository creates an AttachmentRepository backed by s
+func NewAttachmentRepository(s *Store) *AttachmentRepository { return &AttachmentRepository{s: s} }
+
+var _ repository.AttachmentRepository = (*AttachmentRepository)(nil)
+
+// Create stores attachment and assigns its ID
+func (r *AttachmentRepository) Create(ctx context.Context, attachment *entity.TaskAttachment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	attachment.ID = r.s.nextID("task_attachments")
+	stored := *attachment
+	r.s.attachments[attachment.ID] = &stored
+	return nil
+}
+
+// GetByID gets a copy of an attachment by ID
+func (r *AttachmentRepository) GetByID(ctx context.Context, id int64) (*entity.TaskAttachment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	attachment, ok := r.s.attachments[id]
+	if !ok {
+		return nil, repository.ErrAttachmentNotFound
+	}
+	found := *attachment
+	return &found, nil
+}
+
+// Delete deletes an attachment
+func (r *AttachmentRepository) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.attachments, id)
+	return nil
+}
+
+// DeleteByTaskID deletes all attachments on a task
+func (r *AttachmentRepository) DeleteByTaskID(ctx context.Context, taskID int64) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if r.s.tasks[taskID] == nil {
+		return 0, repository.ErrTaskNotFound
+	}
+	var deleted int64
+	for id, a := range r.s.attachments {
+		if a.TaskID == taskID {
+			delete(r.s.attachments, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// CountByMediaFileID counts the attachments that reference a media file
+func (r *AttachmentRepository) CountByMediaFileID(ctx context.Context, mediaFileID int64) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var count int
+	for _, a := range r.s.attachments {
+		if a.MediaFileID == mediaFileID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// MediaFileIDsByTaskIDs returns the distinct media files attached to the tasks, in ID order
+func (r *AttachmentRepository) MediaFileIDsByTaskIDs(ctx context.Context, taskIDs []int64) ([]int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	wanted := make(map[int64]bool, len(taskIDs))
+	for _, id := range taskIDs {
+		wanted[id] = true
+	}
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, a := range r.s.attachments {
+		if wanted[a.TaskID] && a.MediaFileID != 0 && !seen[a.MediaFileID] {
+			seen[a.MediaFileID] = true
+			ids = append(ids, a.MediaFileID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+// GetByTaskID gets a page of a task's attachments, oldest first, along with the total count
+func (r *AttachmentRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskAttachment, int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var matched []*entity.TaskAttachment
+	for _, a := range r.s.attachments {
+		if a.TaskID == taskID {
+			found := *a
+			matched = append(matched, &found)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if !matched[i].UploadedAt.Equal(matched[j].UploadedAt) {
+			return matched[i].UploadedAt.Before(matched[j].UploadedAt)
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	return paginate(matched, page, limit), len(matched), nil
+}
+
+// TagRepository is an in-memory repository.TagRepository
+type TagRepository struct{ s *Store }
+
+// NewTagRepository creates a TagRepository backed by s
+func NewTagRepository(s *Store) *TagRepository { return &TagRepository{s: s} }
+
+var _ repository.TagRepository = (*TagRepository)(nil)
+
+// Create stores tag; names are unique like the task_tags.name constraint
+func (r *TagRepository) Create(ctx context.Context, tag *entity.TaskTag) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, t := range r.s.tags {
+		if t.Name == tag.Name {
+			return fmt.Errorf("tag %q already exists", tag.Name)
+		}
+	}
+	tag.ID = r.s.nextID("task_tags")
+	stored := *tag
+	r.s.tags[tag.ID] = &stored
+	return nil
+}
+
+// GetByID gets a tag by ID
+func (r *TagRepository) GetByID(ctx context.Context, id int64) (*entity.TaskTag, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	tag, ok := r.s.tags[id]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	found := *tag
+	return &found, nil
+}
+
+// GetByName gets a tag by its exact name
+func (r *TagRepository) GetByName(ctx context.Context, name string) (*entity.TaskTag, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, tag := range r.s.tags {
+		if tag.Name == name {
+			found := *tag
+			return &found, nil
+		}
+	}
+	return nil, repository.ErrTagNotFound
+}
+
+// List lists all tags ordered by name
+func (r *TagRepository) List(ctx context.Context) ([]*entity.TaskTag, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var tags []*entity.TaskTag
+	for _, tag := range r.s.tags {
+		found := *tag
+		tags = append(tags, &found)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+	return tags, nil
+}
+
+// Delete deletes a tag and removes it from every task
+func (r *TagRepository) Delete(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tags[id]; !ok {
+		return repository.ErrTagNotFound
+	}
+	delete(r.s.tags, id)
+	for _, tags := range r.s.taskTags {
+		delete(tags, id)
+	}
+	return nil
+}
+
+// DeleteUnused deletes a tag, or fails with ErrTagInUse if any task has it
+func (r *TagRepository) DeleteUnused(ctx context.Context, id int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tags[id]; !ok {
+		return repository.ErrTagNotFound
+	}
+	for _, tags := range r.s.taskTags {
+		if tags[id] {
+			return repository.ErrTagInUse
+		}
+	}
+	delete(r.s.tags, id)
+	return nil
+}
+
+// TaskTagRepository is an in-memory repository.TaskTagRepository
+type TaskTagRepository struct{ s *Store }
+
+// NewTaskTagRepository creates a TaskTagRepository backed by s
+func NewTaskTagRepository(s *Store) *TaskTagRepository { return &TaskTagRepository{s: s} }
+
+var _ repository.TaskTagRepository = (*TaskTagRepository)(nil)
+
+// Add tags a task; adding an existing tag does nothing
+func (r *TaskTagRepository) Add(ctx context.Context, taskID, tagID int64, max int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if r.s.taskTags[taskID] == nil {
+		r.s.taskTags[taskID] = make(map[int64]bool)
+	}
+	if tags := r.s.taskTags[taskID]; max > 0 && !tags[tagID] && len(tags) >= max {
+		return repository.ErrLimitExceeded
+	}
+	r.s.taskTags[taskID][tagID] = true
+	return nil
+}
+
+// Remove removes a tag from a task
+func (r *TaskTagRepository) Remove(ctx context.Context, taskID, tagID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if !r.s.taskTags[taskID][tagID] {
+		return repository.ErrTaskTagNotFound
+	}
+	delete(r.s.taskTags[taskID], tagID)
+	return nil
+}
+
+// GetByTaskID gets all tags of a task ordered by ID
+func (r *TaskTagRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskTag, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var tags []*entity.TaskTag
+	for tagID := range r.s.taskTags[taskID] {
+		if tag, ok := r.s.tags[tagID]; ok {
+			found := *tag
+			tags = append(tags, &found)
+		}
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].ID < tags[j].ID })
+	return tags, nil
+}
+
+// WatcherRepository is an in-memory repository.WatcherRepository
+type WatcherRepository struct{ s *Store }
+
+// NewWatcherRepository creates a WatcherRepository backed by s
+func NewWatcherRepository(s *Store) *WatcherRepository { return &WatcherRepository{s: s} }
+
+var _ repository.WatcherRepository = (*WatcherRepository)(nil)
+
+// Add makes a user a watcher of a task; adding a watcher again does nothing
+func (r *WatcherRepository) Add(ctx context.Context, taskID, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.tasks[taskID]; !ok {
+		return fmt.Errorf("task %d: foreign key violation", taskID)
+	}
+	for _, w := range r.s.watchers[taskID] {
+		if w.UserID == userID {
+			return nil
+		}
+	}
+	r.s.watchers[taskID] = append(r.s.watchers[taskID], &entity.TaskWatcher{TaskID: taskID, UserID: userID, CreatedAt: time.Now()})
+	return nil
+}
+
+// Remove stops a user watching a task
+func (r *WatcherRepository) Remove(ctx context.Context, taskID, userID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	watchers := r.s.watchers[taskID]
+	for i, w := range watchers {
+		if w.UserID == userID {
+			r.s.watchers[taskID] = append(watchers[:i:i], watchers[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// GetByTaskID gets a task's watchers in the order they were added
+func (r *WatcherRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskWatcher, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var watchers []*entity.TaskWatcher
+	for _, w := range r.s.watchers[taskID] {
+		found := *w
+		watchers = append(watchers, &found)
+	}
+	return watchers, nil
+}
+
+// TaskDefaultsRepository is an in-memory repository.TaskDefaultsRepository
+type TaskDefaultsRepository struct{ s *Store }
+
+// NewTaskDefaultsRepository creates a TaskDefaultsRepository backed by s
+func NewTaskDefaultsRepository(s *Store) *TaskDefaultsRepository {
+	return &TaskDefaultsRepository{s: s}
+}
+
+var _ repository.TaskDefaultsRepository = (*TaskDefaultsRepository)(nil)
+
+// Get gets the defaults set with Store.SetTaskDefaults
+func (r *TaskDefaultsRepository) Get(ctx context.Context, projectID int64) (*entity.TaskDefaults, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	defaults := r.s.defaults[projectID]
+	return &defaults, nil
+}
+
+// ActivityRepository is an in-memory repository.ActivityRepository
+type ActivityRepository struct{ s *Store }
+
+// NewActivityRepository creates an ActivityRepository backed by s
+func NewActivityRepository(s *Store) *ActivityRepository { return &ActivityRepository{s: s} }
+
+var _ repository.ActivityRepository = (*ActivityRepository)(nil)
+
+// Record appends activity to the log and assigns its ID
+func (r *ActivityRepository) Record(ctx context.Context, activity *entity.TaskActivity) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	activity.ID = r.s.nextID("task_activity")
+	stored := *activity
+	r.s.activities = append(r.s.activities, &stored)
+	return nil
+}
@@ -0,0 +1,942 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/portfolio/shared/pagination"
+	"github.com/portfolio/task-service/internal/domain/entity"
+	"github.com/portfolio/task-service/internal/domain/repository"
+	"github.com/portfolio/task-service/internal/testutil"
+)
+
+// newMemoryTaskUseCase wires a TaskUseCase to in-memory repositories sharing one store
+func newMemoryTaskUseCase(store *testutil.Store) *TaskUseCase {
+	return NewTaskUseCase(
+		testutil.NewTaskRepository(store),
+		testutil.NewSubtaskRepository(store),
+		testutil.NewCommentRepository(store),
+		testutil.NewAttachmentRepository(store),
+		nil,
+		testutil.NewTagRepository(store),
+		testutil.NewTaskTagRepository(store),
+		testutil.NewActivityRepository(store),
+		testutil.NewTaskDefaultsRepository(store),
+		Limits{},
+		AllowPastDueDates,
+		AssigneeCheck{},
+		nil,
+		pagination.Sizes{},
+	)
+}
+
+func TestTaskUseCase_Memory_ListTasks(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	due := time.Now().Add(24 * time.Hour)
+
+	undated, _ := uc.CreateTask(ctx, 1, "Undated", "", "", 2, 0, nil)
+	dated, _ := uc.CreateTask(ctx, 1, "Dated", "", "", 2, 0, &due)
+	urgent, _ := uc.CreateTask(ctx, 1, "Urgent", "", "", 1, 7, nil)
+	uc.CreateTask(ctx, 2, "Other project", "", "", 1, 0, nil)
+
+	tag := &entity.TaskTag{Name: "backend"}
+	if err := testutil.NewTagRepository(store).Create(ctx, tag); err != nil {
+		t.Fatalf("Create tag: %v", err)
+	}
+	testutil.NewTaskTagRepository(store).Add(ctx, dated.ID, tag.ID, 0)
+	subtasks := testutil.NewSubtaskRepository(store)
+	done := entity.NewSubtask(dated.ID, "Done", 0, nil)
+	done.Status = entity.StatusDone
+	subtasks.Create(ctx, done)
+	subtasks.Create(ctx, entity.NewSubtask(dated.ID, "Open", 0, nil))
+
+	tasks, total, err := uc.ListTasks(ctx, 1, 1, 10, "", 0, TagQuery{})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if total != 3 || len(tasks) != 3 {
+		t.Fatalf("got %d tasks of %d, want 3 of 3", len(tasks), total)
+	}
+	if tasks[0].ID != urgent.ID || tasks[1].ID != dated.ID || tasks[2].ID != undated.ID {
+		t.Errorf("order = %d, %d, %d; want priority first, then dated before undated", tasks[0].ID, tasks[1].ID, tasks[2].ID)
+	}
+	if tasks[1].Progress != 0.5 {
+		t.Errorf("progress = %v, want 0.5", tasks[1].Progress)
+	}
+
+	tasks, total, _ = uc.ListTasks(ctx, 1, 2, 2, "", 0, TagQuery{})
+	if total != 3 || len(tasks) != 1 || tasks[0].ID != undated.ID {
+		t.Errorf("page 2 = %d tasks of %d, want only the undated task", len(tasks), total)
+	}
+
+	tasks, _, _ = uc.ListTasks(ctx, 1, 1, 10, "", 7, TagQuery{})
+	if len(tasks) != 1 || tasks[0].ID != urgent.ID {
+		t.Errorf("assigned_to filter returned %d tasks, want the urgent task", len(tasks))
+	}
+
+	tasks, _, _ = uc.ListTasks(ctx, 1, 1, 10, "", 0, TagQuery{Name: "backend"})
+	if len(tasks) != 1 || tasks[0].ID != dated.ID {
+		t.Errorf("tag filter returned %d tasks, want the dated task", len(tasks))
+	}
+}
+
+func TestTaskUseCase_Memory_CreateTaskProjectDefaults(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	store.SetTaskDefaults(1, entity.TaskDefaults{Status: entity.StatusInProgress, Priority: entity.PriorityHigh})
+	store.SetTaskDefaults(2, entity.TaskDefaults{Priority: entity.PriorityMedium})
+
+	tests := []struct {
+		name         string
+		projectID    int64
+		status       string
+		priority     int
+		wantStatus   string
+		wantPriority int
+	}{
+		{"Project defaults", 1, "", 0, entity.StatusInProgress, entity.PriorityHigh},
+		{"Given values win", 1, entity.StatusDone, entity.PriorityNone, entity.StatusDone, entity.PriorityNone},
+		{"Partial defaults", 2, "", 0, entity.StatusTodo, entity.PriorityMedium},
+		{"No settings", 3, "", 0, entity.StatusTodo, entity.DefaultPriority},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task, err := uc.CreateTask(ctx, tt.projectID, "Task", "", tt.status, tt.priority, 0, nil)
+			if err != nil {
+				t.Fatalf("CreateTask() error = %v", err)
+			}
+			if task.Status != tt.wantStatus || task.Priority != tt.wantPriority {
+				t.Errorf("task status = %s, priority = %d, want %s and %d", task.Status, task.Priority, tt.wantStatus, tt.wantPriority)
+			}
+		})
+	}
+}
+
+func TestTaskUseCase_Memory_DemoteMovesChildren(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	subtasks := testutil.NewSubtaskRepository(store)
+	comments := testutil.NewCommentRepository(store)
+
+	parent, _ := uc.CreateTask(ctx, 1, "Parent", "", "", 0, 0, nil)
+	subtasks.Create(ctx, entity.NewSubtask(parent.ID, "Existing", 0, nil))
+	task, _ := uc.CreateTask(ctx, 1, "Child", "", "", 0, 0, nil)
+	subtasks.Create(ctx, entity.NewSubtask(task.ID, "Nested", 0, nil))
+	comments.Create(ctx, entity.NewTaskComment(task.ID, 1, "note"))
+
+	subtask, err := uc.Demote(ctx, task.ID, parent.ID, 0)
+	if err != nil {
+		t.Fatalf("Demote() error = %v", err)
+	}
+	if subtask.Position != 2 {
+		t.Errorf("position = %d, want 2", subtask.Position)
+	}
+	if _, err := uc.GetTask(ctx, task.ID); err != ErrTaskNotFound {
+		t.Errorf("GetTask(demoted) error = %v, want ErrTaskNotFound", err)
+	}
+
+	// The task's subtasks follow it after the parent's existing ones
+	moved, total, _ := subtasks.GetByTaskID(ctx, parent.ID, 1, 10)
+	if total != 3 {
+		t.Fatalf("parent has %d subtasks, want 3", total)
+	}
+	if moved[0].Title != "Existing" || moved[1].Title != "Child" || moved[2].Title != "Nested" {
+		t.Errorf("subtasks = %q, %q, %q; want Existing, Child, Nested", moved[0].Title, moved[1].Title, moved[2].Title)
+	}
+	for i, st := range moved {
+		if st.Position != i+1 {
+			t.Errorf("subtask %q position = %d, want %d", st.Title, st.Position, i+1)
+		}
+	}
+	if _, n, _ := comments.GetByTaskID(ctx, parent.ID, 1, 10); n != 1 {
+		t.Errorf("parent has %d comments, want the moved comment", n)
+	}
+}
+
+func TestTaskUseCase_Memory_BulkDeleteCascades(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	subtasks := testutil.NewSubtaskRepository(store)
+
+	first, _ := uc.CreateTask(ctx, 1, "First", "", "", 0, 0, nil)
+	second, _ := uc.CreateTask(ctx, 1, "Second", "", "", 0, 0, nil)
+	kept, _ := uc.CreateTask(ctx, 1, "Kept", "", "", 0, 0, nil)
+	subtasks.Create(ctx, entity.NewSubtask(first.ID, "Gone", 0, nil))
+	subtasks.Create(ctx, entity.NewSubtask(kept.ID, "Stays", 0, nil))
+
+	deleted, err := uc.BulkDelete(ctx, []int64{first.ID, second.ID, 999}, 0)
+	if err != nil {
+		t.Fatalf("BulkDelete() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted = %d, want 2", deleted)
+	}
+	if _, n, _ := subtasks.GetByTaskID(ctx, first.ID, 1, 10); n != 0 {
+		t.Errorf("deleted task still has %d subtasks", n)
+	}
+	if _, n, _ := subtasks.GetByTaskID(ctx, kept.ID, 1, 10); n != 1 {
+		t.Errorf("kept task has %d subtasks, want 1", n)
+	}
+}
+
+func TestTaskUseCase_Memory_DeletesReleaseMediaFiles(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	media := MockMediaFileResolver{
+		7: {URL: "https://cdn.example.com/a.png", UploadedBy: 5},
+		8: {URL: "https://cdn.example.com/b.png", UploadedBy: 5},
+		9: {URL: "https://cdn.example.com/c.png", UploadedBy: 6},
+	}
+	attachmentRepo := testutil.NewAttachmentRepository(store)
+	uc := NewTaskUseCase(
+		testutil.NewTaskRepository(store),
+		testutil.NewSubtaskRepository(store),
+		testutil.NewCommentRepository(store),
+		attachmentRepo,
+		media,
+		testutil.NewTagRepository(store),
+		testutil.NewTaskTagRepository(store),
+		testutil.NewActivityRepository(store),
+		testutil.NewTaskDefaultsRepository(store),
+		Limits{},
+		AllowPastDueDates,
+		AssigneeCheck{},
+		nil,
+		pagination.Sizes{},
+	)
+	attachments := NewAttachmentUseCase(attachmentRepo, media, pagination.Sizes{})
+
+	first, _ := uc.CreateTask(ctx, 1, "First", "", "", 0, 0, nil)
+	second, _ := uc.CreateTask(ctx, 1, "Second", "", "", 0, 0, nil)
+	kept, _ := uc.CreateTask(ctx, 1, "Kept", "", "", 0, 0, nil)
+	attachments.AddMediaAttachment(ctx, first.ID, 7, 0)
+	attachments.AddMediaAttachment(ctx, second.ID, 8, 0)
+	attachments.AddMediaAttachment(ctx, kept.ID, 8, 0)
+	attachments.AddMediaAttachment(ctx, second.ID, 9, 0)
+
+	if err := uc.DeleteTask(ctx, first.ID, 5); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	if _, ok := media[7]; ok {
+		t.Error("media file 7 was not released with its task")
+	}
+
+	// File 8 is still attached to the kept task and file 9 is user 6's
+	if _, err := uc.BulkDelete(ctx, []int64{second.ID}, 5); err != nil {
+		t.Fatalf("BulkDelete() error = %v", err)
+	}
+	if _, ok := media[8]; !ok {
+		t.Error("media file 8 was deleted while another task references it")
+	}
+	if _, ok := media[9]; !ok {
+		t.Error("media file 9 was deleted by a user who did not upload it")
+	}
+}
+
+func TestCommentUseCase_Memory_DeleteByTaskID(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := NewCommentUseCase(testutil.NewCommentRepository(store), pagination.Sizes{})
+	tasks := testutil.NewTaskRepository(store)
+	tasks.Create(ctx, entity.NewTask(1, "First", "", "", 1, 0, nil))
+	tasks.Create(ctx, entity.NewTask(1, "Second", "", "", 1, 0, nil))
+
+	for i := 0; i < 3; i++ {
+		uc.AddComment(ctx, 1, 1, "note")
+	}
+	uc.AddComment(ctx, 2, 1, "other task")
+
+	deleted, err := uc.DeleteByTaskID(ctx, 1)
+	if err != nil {
+		t.Fatalf("DeleteByTaskID() error = %v", err)
+	}
+	if deleted != 3 {
+		t.Errorf("deleted = %d, want 3", deleted)
+	}
+	if _, n, _ := uc.GetComments(ctx, 1, 1, 10); n != 0 {
+		t.Errorf("task still has %d comments", n)
+	}
+	if _, n, _ := uc.GetComments(ctx, 2, 1, 10); n != 1 {
+		t.Errorf("other task has %d comments, want 1", n)
+	}
+	if deleted, err := uc.DeleteByTaskID(ctx, 1); deleted != 0 || err != nil {
+		t.Errorf("second DeleteByTaskID() = %d, %v; want 0, nil", deleted, err)
+	}
+	if _, err := uc.DeleteByTaskID(ctx, 99); err != ErrTaskNotFound {
+		t.Errorf("DeleteByTaskID(missing task) error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestCommentUseCase_Memory_GetUserComments(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := NewCommentUseCase(testutil.NewCommentRepository(store), pagination.Sizes{})
+
+	uc.AddComment(ctx, 1, 7, "first")
+	uc.AddComment(ctx, 2, 8, "someone else")
+	uc.AddComment(ctx, 3, 7, "second")
+
+	comments, total, err := uc.GetUserComments(ctx, 7, 1, 10)
+	if err != nil {
+		t.Fatalf("GetUserComments() error = %v", err)
+	}
+	if total != 2 || len(comments) != 2 {
+		t.Fatalf("got %d of %d comments, want 2 of 2", len(comments), total)
+	}
+	if comments[0].Comment != "second" || comments[1].Comment != "first" {
+		t.Errorf("comments = %q, %q; want newest first", comments[0].Comment, comments[1].Comment)
+	}
+}
+
+func TestAttachmentUseCase_Memory_DeleteByTaskID(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	media := MockMediaFileResolver{9: {URL: "https://cdn.example.com/a.png", UploadedBy: 5}}
+	uc := NewAttachmentUseCase(testutil.NewAttachmentRepository(store), media, pagination.Sizes{})
+	tasks := testutil.NewTaskRepository(store)
+	tasks.Create(ctx, entity.NewTask(1, "First", "", "", 1, 0, nil))
+	tasks.Create(ctx, entity.NewTask(1, "Second", "", "", 1, 0, nil))
+
+	uc.AddAttachment(ctx, 1, "https://example.com/spec.pdf")
+	uc.AddMediaAttachment(ctx, 1, 9, 5)
+	uc.AddAttachment(ctx, 2, "https://example.com/other.pdf")
+
+	deleted, err := uc.DeleteByTaskID(ctx, 1, 5)
+	if err != nil {
+		t.Fatalf("DeleteByTaskID() error = %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("deleted = %d, want 2", deleted)
+	}
+	if _, n, _ := uc.GetAttachments(ctx, 1, 1, 10); n != 0 {
+		t.Errorf("task still has %d attachments", n)
+	}
+	if _, n, _ := uc.GetAttachments(ctx, 2, 1, 10); n != 1 {
+		t.Errorf("other task has %d attachments, want 1", n)
+	}
+	if _, ok := media[9]; ok {
+		t.Error("media file 9 was not released with the task's attachments")
+	}
+	if _, err := uc.DeleteByTaskID(ctx, 99, 5); err != ErrTaskNotFound {
+		t.Errorf("DeleteByTaskID(missing task) error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestTaskUseCase_Memory_ListTasksStableAcrossPages(t *testing.T) {
+	ctx := context.Background()
+	uc := newMemoryTaskUseCase(testutil.NewStore())
+	due := time.Now().Add(48 * time.Hour)
+
+	// Every task ties on priority and most on due date, so only the id tie-breaker orders them
+	var want []int64
+	dated, _ := uc.CreateTask(ctx, 1, "Dated", "", "", 2, 0, &due)
+	want = append(want, dated.ID)
+	for i := 0; i < 6; i++ {
+		task, _ := uc.CreateTask(ctx, 1, "Tied", "", "", 2, 0, nil)
+		want = append(want, task.ID)
+	}
+
+	for _, limit := range []int{1, 2, 3, 4} {
+		var got []int64
+		for page := 1; ; page++ {
+			tasks, total, err := uc.ListTasks(ctx, 1, page, limit, "", 0, TagQuery{})
+			if err != nil {
+				t.Fatalf("ListTasks(page %d) error = %v", page, err)
+			}
+			if total != len(want) {
+				t.Fatalf("total = %d, want %d", total, len(want))
+			}
+			if len(tasks) == 0 {
+				break
+			}
+			for _, task := range tasks {
+				got = append(got, task.ID)
+			}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("limit %d: paged through %v, want %v", limit, got, want)
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("limit %d: paged through %v, want %v", limit, got, want)
+				break
+			}
+		}
+	}
+}
+
+func TestTaskUseCase_Memory_ListTasksAfterCoversInsertedRows(t *testing.T) {
+	ctx := context.Background()
+	uc := newMemoryTaskUseCase(testutil.NewStore())
+
+	want := map[int64]bool{}
+	for i := 0; i < 7; i++ {
+		task, _ := uc.CreateTask(ctx, 1, "Task", "", "", i%3, 0, nil)
+		want[task.ID] = true
+	}
+	uc.CreateTask(ctx, 2, "Other project", "", "", 0, 0, nil)
+
+	seen := map[int64]bool{}
+	var lastID int64
+	cursor := ""
+	for pages := 0; ; pages++ {
+		if pages > 10 {
+			t.Fatal("cursor never reached the last page")
+		}
+		tasks, next, err := uc.ListTasksAfter(ctx, 1, cursor, 3, "", 0, TagQuery{})
+		if err != nil {
+			t.Fatalf("ListTasksAfter(%q) error = %v", cursor, err)
+		}
+		for _, task := range tasks {
+			if seen[task.ID] {
+				t.Errorf("task %d returned twice", task.ID)
+			}
+			if task.ID <= lastID {
+				t.Errorf("task %d after %d, want id order", task.ID, lastID)
+			}
+			seen[task.ID] = true
+			lastID = task.ID
+		}
+		// A task created mid-walk must not shift later pages or go missing
+		if pages == 0 {
+			inserted, _ := uc.CreateTask(ctx, 1, "Inserted", "", "", 0, 0, nil)
+			want[inserted.ID] = true
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+	if len(seen) != len(want) {
+		t.Errorf("walked %d tasks, want %d", len(seen), len(want))
+	}
+	for id := range want {
+		if !seen[id] {
+			t.Errorf("task %d was skipped", id)
+		}
+	}
+
+	if _, _, err := uc.ListTasksAfter(ctx, 1, "not a cursor", 3, "", 0, TagQuery{}); err != ErrInvalidCursor {
+		t.Errorf("ListTasksAfter(garbage) error = %v, want ErrInvalidCursor", err)
+	}
+}
+
+func TestTaskRepository_Memory_ListByOverlappingTags(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	tasks := testutil.NewTaskRepository(store)
+	tagRepo := testutil.NewTagRepository(store)
+	taskTags := testutil.NewTaskTagRepository(store)
+
+	backend := &entity.TaskTag{Name: "backend"}
+	urgent := &entity.TaskTag{Name: "urgent"}
+	docs := &entity.TaskTag{Name: "docs"}
+	for _, tag := range []*entity.TaskTag{backend, urgent, docs} {
+		if err := tagRepo.Create(ctx, tag); err != nil {
+			t.Fatalf("Create tag: %v", err)
+		}
+	}
+	api, _ := uc.CreateTask(ctx, 1, "API", "", "", 0, 0, nil)
+	outage, _ := uc.CreateTask(ctx, 1, "Outage", "", "", 0, 0, nil)
+	hotfix, _ := uc.CreateTask(ctx, 1, "Hotfix docs", "", "", 0, 0, nil)
+	uc.CreateTask(ctx, 1, "Untagged", "", "", 0, 0, nil)
+	taskTags.Add(ctx, api.ID, backend.ID, 0)
+	taskTags.Add(ctx, outage.ID, backend.ID, 0)
+	taskTags.Add(ctx, outage.ID, urgent.ID, 0)
+	taskTags.Add(ctx, hotfix.ID, urgent.ID, 0)
+	taskTags.Add(ctx, hotfix.ID, docs.ID, 0)
+
+	tests := []struct {
+		name string
+		tags repository.TagFilter
+		want []int64
+	}{
+		{"one tag", repository.TagFilter{IDs: []int64{urgent.ID}}, []int64{outage.ID, hotfix.ID}},
+		{"all of two", repository.TagFilter{IDs: []int64{backend.ID, urgent.ID}}, []int64{outage.ID}},
+		{"all of three", repository.TagFilter{IDs: []int64{backend.ID, urgent.ID, docs.ID}}, nil},
+		{"any of two", repository.TagFilter{IDs: []int64{backend.ID, docs.ID}, MatchAny: true}, []int64{api.ID, outage.ID, hotfix.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, total, err := tasks.List(ctx, 1, 1, 10, "", 0, tt.tags)
+			if err != nil {
+				t.Fatalf("List() error = %v", err)
+			}
+			after, _ := tasks.ListAfter(ctx, 1, 0, 10, "", 0, tt.tags)
+			if total != len(tt.want) || len(page) != len(tt.want) || len(after) != len(tt.want) {
+				t.Fatalf("List() = %d of %d, ListAfter() = %d; want %d", len(page), total, len(after), len(tt.want))
+			}
+			for i, id := range tt.want {
+				if page[i].ID != id || after[i].ID != id {
+					t.Errorf("task %d = %d / %d, want %d", i, page[i].ID, after[i].ID, id)
+				}
+			}
+		})
+	}
+}
+
+func TestTagUseCase_Memory_DeleteTag(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	tasks := newMemoryTaskUseCase(store)
+	tags := NewTagUseCase(testutil.NewTagRepository(store), testutil.NewTaskTagRepository(store), Limits{}, DetachDeletedTags)
+
+	task, _ := tasks.CreateTask(ctx, 1, "Tagged", "", "", 2, 0, nil)
+	backend, _ := tags.CreateTag(ctx, "backend")
+	urgent, _ := tags.CreateTag(ctx, "urgent")
+	tags.AddTaskTag(ctx, task.ID, backend.ID)
+	tags.AddTaskTag(ctx, task.ID, urgent.ID)
+
+	if err := tags.RemoveTaskTag(ctx, task.ID, urgent.ID); err != nil {
+		t.Fatalf("RemoveTaskTag() error = %v", err)
+	}
+	if err := tags.RemoveTaskTag(ctx, task.ID, urgent.ID); err != ErrTaskTagNotFound {
+		t.Errorf("RemoveTaskTag() twice error = %v, want ErrTaskTagNotFound", err)
+	}
+
+	if err := tags.DeleteTag(ctx, backend.ID); err != nil {
+		t.Fatalf("DeleteTag() error = %v", err)
+	}
+	if err := tags.DeleteTag(ctx, backend.ID); err != ErrTagNotFound {
+		t.Errorf("DeleteTag() twice error = %v, want ErrTagNotFound", err)
+	}
+	got, err := tasks.GetTask(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("GetTask() error = %v", err)
+	}
+	if len(got.Tags) != 0 {
+		t.Errorf("task tags = %v, want none after deleting the tag", got.Tags)
+	}
+	remaining, _ := tags.ListTags(ctx)
+	if len(remaining) != 1 || remaining[0].ID != urgent.ID {
+		t.Errorf("ListTags() = %v, want only urgent", remaining)
+	}
+}
+
+func TestTagUseCase_Memory_RejectTagsInUse(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	tasks := newMemoryTaskUseCase(store)
+	tags := NewTagUseCase(testutil.NewTagRepository(store), testutil.NewTaskTagRepository(store), Limits{}, RejectTagsInUse)
+
+	task, _ := tasks.CreateTask(ctx, 1, "Tagged", "", "", 2, 0, nil)
+	used, _ := tags.CreateTag(ctx, "backend")
+	unused, _ := tags.CreateTag(ctx, "stale")
+	tags.AddTaskTag(ctx, task.ID, used.ID)
+
+	if err := tags.DeleteTag(ctx, unused.ID); err != nil {
+		t.Fatalf("DeleteTag(unused) error = %v", err)
+	}
+	if err := tags.DeleteTag(ctx, used.ID); err != ErrTagInUse {
+		t.Fatalf("DeleteTag(in use) error = %v, want ErrTagInUse", err)
+	}
+	got, _ := tasks.GetTask(ctx, task.ID)
+	if len(got.Tags) != 1 || got.Tags[0].ID != used.ID {
+		t.Errorf("task tags = %v, want the tag kept", got.Tags)
+	}
+
+	// Once no task has it, the tag can go
+	tags.RemoveTaskTag(ctx, task.ID, used.ID)
+	if err := tags.DeleteTag(ctx, used.ID); err != nil {
+		t.Errorf("DeleteTag() after removing it from the task error = %v", err)
+	}
+	if remaining, _ := tags.ListTags(ctx); len(remaining) != 0 {
+		t.Errorf("ListTags() = %v, want none", remaining)
+	}
+}
+
+func TestTaskUseCase_Memory_DuplicateTask(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	tags := NewTagUseCase(testutil.NewTagRepository(store), testutil.NewTaskTagRepository(store), Limits{}, DetachDeletedTags)
+	comments := NewCommentUseCase(testutil.NewCommentRepository(store), pagination.Sizes{})
+	due := time.Now().AddDate(0, 0, 7)
+
+	source, _ := uc.CreateTask(ctx, 1, "Release checklist", "Steps", entity.StatusInProgress, 2, 5, &due)
+	subtasks := testutil.NewSubtaskRepository(store)
+	done := entity.NewSubtask(source.ID, "Tag build", 5, &due)
+	done.Status = entity.StatusDone
+	subtasks.Create(ctx, done)
+	subtasks.Create(ctx, entity.NewSubtask(source.ID, "Publish notes", 0, nil))
+	backend, _ := tags.CreateTag(ctx, "backend")
+	release, _ := tags.CreateTag(ctx, "release")
+	tags.AddTaskTag(ctx, source.ID, backend.ID)
+	tags.AddTaskTag(ctx, source.ID, release.ID)
+	comments.AddComment(ctx, source.ID, 5, "Shipped last time on Friday")
+	testutil.NewAttachmentRepository(store).Create(ctx, entity.NewTaskAttachment(source.ID, "https://example.com/runbook"))
+
+	dup, err := uc.DuplicateTask(ctx, source.ID, false)
+	if err != nil {
+		t.Fatalf("DuplicateTask() error = %v", err)
+	}
+	if dup.ID == source.ID || dup.ProjectID != 1 || dup.Title != "Release checklist" || dup.Description != "Steps" || dup.Priority != 2 {
+		t.Errorf("copy = %+v, want the source fields under a new id", dup)
+	}
+	if dup.Status != entity.StatusTodo || dup.DueDate != nil || dup.AssignedTo == nil || *dup.AssignedTo != 5 {
+		t.Errorf("copy status = %s, due date = %v, assignee = %v, want Todo, none and 5", dup.Status, dup.DueDate, dup.AssignedTo)
+	}
+
+	if len(dup.Subtasks) != 2 {
+		t.Fatalf("copy has %d subtasks, want 2", len(dup.Subtasks))
+	}
+	for i, want := range []string{"Tag build", "Publish notes"} {
+		st := dup.Subtasks[i]
+		if st.Title != want || st.TaskID != dup.ID || st.Status != entity.StatusTodo || st.DueDate != nil {
+			t.Errorf("subtask %d = %+v, want %q as a Todo without due date", i, st, want)
+		}
+	}
+	if len(dup.Tags) != 2 {
+		t.Errorf("copy has %d tags, want 2", len(dup.Tags))
+	}
+
+	if _, total, _ := comments.GetComments(ctx, dup.ID, 1, 10); total != 0 {
+		t.Errorf("copy has %d comments, want none", total)
+	}
+	if _, total, _ := testutil.NewAttachmentRepository(store).GetByTaskID(ctx, dup.ID, 1, 10); total != 0 {
+		t.Errorf("copy has %d attachments, want none", total)
+	}
+
+	// The source keeps everything it had
+	got, _ := uc.GetTask(ctx, source.ID)
+	if len(got.Subtasks) != 2 || len(got.Tags) != 2 || got.Subtasks[0].Status != entity.StatusDone {
+		t.Errorf("source = %+v, want it unchanged", got)
+	}
+
+	kept, err := uc.DuplicateTask(ctx, source.ID, true)
+	if err != nil {
+		t.Fatalf("DuplicateTask(keepDueDate) error = %v", err)
+	}
+	if kept.DueDate == nil || !kept.DueDate.Equal(due) || kept.Subtasks[0].DueDate == nil {
+		t.Errorf("copy due date = %v, subtask due date = %v, want both kept", kept.DueDate, kept.Subtasks[0].DueDate)
+	}
+
+	if _, err := uc.DuplicateTask(ctx, 999, false); err != ErrTaskNotFound {
+		t.Errorf("DuplicateTask(missing) error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestTaskUseCase_Memory_MoveTask(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	bus := &recordingBus{}
+	uc.events = bus
+	tags := NewTagUseCase(testutil.NewTagRepository(store), testutil.NewTaskTagRepository(store), Limits{}, DetachDeletedTags)
+	comments := NewCommentUseCase(testutil.NewCommentRepository(store), pagination.Sizes{})
+	subtasks := testutil.NewSubtaskRepository(store)
+
+	task, _ := uc.CreateTask(ctx, 1, "Migrate billing", "", "", 2, 5, nil)
+	subtasks.Create(ctx, entity.NewSubtask(task.ID, "Export invoices", 6, nil))
+	subtasks.Create(ctx, entity.NewSubtask(task.ID, "Import invoices", 0, nil))
+	backend, _ := tags.CreateTag(ctx, "backend")
+	tags.AddTaskTag(ctx, task.ID, backend.ID)
+	comments.AddComment(ctx, task.ID, 5, "Waiting on finance")
+	bus.events = nil
+
+	moved, err := uc.MoveTask(ctx, task.ID, 2, false)
+	if err != nil {
+		t.Fatalf("MoveTask() error = %v", err)
+	}
+	if moved.ID != task.ID || moved.ProjectID != 2 {
+		t.Errorf("moved task = %+v, want task %d in project 2", moved, task.ID)
+	}
+	if moved.AssignedTo == nil || *moved.AssignedTo != 5 {
+		t.Errorf("moved assignee = %v, want 5 kept", moved.AssignedTo)
+	}
+	if len(moved.Subtasks) != 2 || moved.Subtasks[0].TaskID != task.ID || (moved.Subtasks[0].AssignedTo == nil || *moved.Subtasks[0].AssignedTo != 6) {
+		t.Errorf("moved subtasks = %+v, want both kept with their assignees", moved.Subtasks)
+	}
+	if len(moved.Tags) != 1 || moved.Tags[0].ID != backend.ID {
+		t.Errorf("moved tags = %+v, want [backend]", moved.Tags)
+	}
+	if _, total, _ := comments.GetComments(ctx, task.ID, 1, 10); total != 1 {
+		t.Errorf("moved task has %d comments, want 1", total)
+	}
+	if left, total, _ := uc.ListTasks(ctx, 1, 1, 10, "", 0, TagQuery{}); total != 0 {
+		t.Errorf("project 1 still lists %+v", left)
+	}
+
+	// Watchers of both projects hear about the move
+	if len(bus.events) != 2 {
+		t.Fatalf("published %d events, want 2: %+v", len(bus.events), bus.events)
+	}
+	for i, projectID := range []int64{2, 1} {
+		event := bus.events[i]
+		if event.Type != entity.TaskMoved || event.ProjectID != projectID || event.Task == nil || event.Task.ProjectID != 2 {
+			t.Errorf("event %d = %+v, want %s in project %d carrying the moved task", i, event, entity.TaskMoved, projectID)
+		}
+	}
+	if activities := store.Activities(); len(activities) != 1 || activities[0].TaskID != task.ID || activities[0].Action != entity.ActionTaskMoved {
+		t.Errorf("activities = %+v, want one %s for task %d", activities, entity.ActionTaskMoved, task.ID)
+	}
+
+	cleared, err := uc.MoveTask(ctx, task.ID, 3, true)
+	if err != nil {
+		t.Fatalf("MoveTask(clearAssignees) error = %v", err)
+	}
+	if cleared.ProjectID != 3 || cleared.AssignedTo != nil || cleared.Subtasks[0].AssignedTo != nil {
+		t.Errorf("moved task = %+v, want it and its subtasks unassigned in project 3", cleared)
+	}
+
+	if _, err := uc.MoveTask(ctx, task.ID, 3, false); err != ErrSameProject {
+		t.Errorf("MoveTask(same project) error = %v, want ErrSameProject", err)
+	}
+	if _, err := uc.MoveTask(ctx, task.ID, 0, false); err != ErrProjectRequired {
+		t.Errorf("MoveTask(no project) error = %v, want ErrProjectRequired", err)
+	}
+	if _, err := uc.MoveTask(ctx, 999, 2, false); err != ErrTaskNotFound {
+		t.Errorf("MoveTask(missing) error = %v, want ErrTaskNotFound", err)
+	}
+}
+
+func TestTaskUseCase_Memory_MoveTaskChecksEverySubtask(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	uc.assignees = AssigneeCheck{
+		Users: &MockUserDirectory{
+			roles:  map[int64]string{5: "user", 6: "user"},
+			access: map[int64][]int64{5: {1, 2}, 6: {1}},
+		},
+		RequireAccess: true,
+	}
+	subtasks := testutil.NewSubtaskRepository(store)
+
+	task, _ := uc.CreateTask(ctx, 1, "Migrate billing", "", "", 2, 0, nil)
+	for i := 0; i < maxListLimit; i++ {
+		subtasks.Create(ctx, entity.NewSubtask(task.ID, "Step", 5, nil))
+	}
+	// Past the subtasks loaded with the task, assigned to a user without
+	// access to project 2
+	subtasks.Create(ctx, entity.NewSubtask(task.ID, "Last step", 6, nil))
+
+	if _, err := uc.MoveTask(ctx, task.ID, 2, false); err != ErrAssigneeNoAccess {
+		t.Errorf("MoveTask() error = %v, want ErrAssigneeNoAccess", err)
+	}
+}
+
+// recordingBus is a TaskEventBus that keeps every published event
+type recordingBus struct {
+	events []entity.TaskEvent
+}
+
+func (b *recordingBus) Publish(event entity.TaskEvent) {
+	b.events = append(b.events, event)
+}
+
+func (b *recordingBus) Subscribe(projectID int64) (<-chan entity.TaskEvent, func()) {
+	return make(chan entity.TaskEvent), func() {}
+}
+
+func TestTaskUseCase_Memory_PublishesEvents(t *testing.T) {
+	ctx := context.Background()
+	uc := newMemoryTaskUseCase(testutil.NewStore())
+	bus := &recordingBus{}
+	uc.events = bus
+
+	task, err := uc.CreateTask(ctx, 5, "Plan", "", "", 0, 0, nil)
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if _, err := uc.UpdateTask(ctx, task.ID, "Plan sprint", "", "", 0, 0, nil, TaskClears{}); err != nil {
+		t.Fatalf("UpdateTask() error = %v", err)
+	}
+	if err := uc.DeleteTask(ctx, task.ID, 0); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	// A failed update announces nothing
+	uc.UpdateTask(ctx, task.ID, "Gone", "", "", 0, 0, nil, TaskClears{})
+
+	want := []string{entity.TaskCreated, entity.TaskUpdated, entity.TaskDeleted}
+	if len(bus.events) != len(want) {
+		t.Fatalf("published %d events, want %d: %+v", len(bus.events), len(want), bus.events)
+	}
+	for i, event := range bus.events {
+		if event.Type != want[i] || event.ProjectID != 5 || event.TaskID != task.ID {
+			t.Errorf("event %d = %+v, want %s for task %d in project 5", i, event, want[i], task.ID)
+		}
+	}
+	if bus.events[1].Task == nil || bus.events[1].Task.Title != "Plan sprint" {
+		t.Errorf("update event task = %+v, want the updated task", bus.events[1].Task)
+	}
+	if bus.events[2].Task != nil {
+		t.Errorf("delete event carries task %+v", bus.events[2].Task)
+	}
+
+	if _, _, err := newMemoryTaskUseCase(testutil.NewStore()).WatchProject(5); err != ErrEventsUnavailable {
+		t.Errorf("WatchProject() without a bus error = %v, want ErrEventsUnavailable", err)
+	}
+}
+
+func TestTaskUseCase_Memory_StreamTasks(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	for i := 0; i < 1234; i++ {
+		status := entity.StatusTodo
+		if i%2 == 0 {
+			status = entity.StatusDone
+		}
+		uc.CreateTask(ctx, 1, "Task", "", status, 0, 0, nil)
+	}
+	uc.CreateTask(ctx, 2, "Other project", "", "", 0, 0, nil)
+
+	var rows, batches int
+	var lastID int64
+	err := uc.StreamTasks(ctx, 1, "", 0, TagQuery{}, 100, func(tasks []*entity.Task) error {
+		batches++
+		if len(tasks) > 100 {
+			t.Errorf("batch of %d tasks, want at most 100", len(tasks))
+		}
+		for _, task := range tasks {
+			if task.ProjectID != 1 || task.ID <= lastID {
+				t.Fatalf("task %d of project %d out of order or out of project", task.ID, task.ProjectID)
+			}
+			lastID = task.ID
+			rows++
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("StreamTasks() error = %v", err)
+	}
+	if rows != 1234 || batches != 13 {
+		t.Errorf("streamed %d tasks in %d batches, want 1234 in 13", rows, batches)
+	}
+
+	rows = 0
+	uc.StreamTasks(ctx, 1, entity.StatusDone, 0, TagQuery{}, 0, func(tasks []*entity.Task) error {
+		rows += len(tasks)
+		return nil
+	})
+	if rows != 617 {
+		t.Errorf("streamed %d done tasks, want 617", rows)
+	}
+
+	stop := errors.New("client went away")
+	calls := 0
+	err = uc.StreamTasks(ctx, 1, "", 0, TagQuery{}, 100, func(tasks []*entity.Task) error {
+		calls++
+		return stop
+	})
+	if err != stop || calls != 1 {
+		t.Errorf("StreamTasks() after send failed = %v with %d calls, want the send error after 1", err, calls)
+	}
+	if err := uc.StreamTasks(ctx, 0, "", 0, TagQuery{}, 0, nil); err != ErrProjectRequired {
+		t.Errorf("StreamTasks(no project) error = %v, want ErrProjectRequired", err)
+	}
+}
+
+func TestTaskUseCase_Memory_GetTaskSummary(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	uc := newMemoryTaskUseCase(store)
+	past := time.Now().Add(-48 * time.Hour)
+	future := time.Now().Add(48 * time.Hour)
+	y, m, d := time.Now().UTC().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	uc.CreateTask(ctx, 1, "Late", "", entity.StatusTodo, 2, 0, &past)
+	uc.CreateTask(ctx, 1, "Due today", "", entity.StatusTodo, 2, 3, &today)
+	uc.CreateTask(ctx, 1, "Upcoming", "", entity.StatusTodo, 2, 3, &future)
+	uc.CreateTask(ctx, 1, "Late, in progress", "", entity.StatusInProgress, 2, 3, &past)
+	uc.CreateTask(ctx, 1, "Finished late", "", entity.StatusDone, 2, 0, &past)
+	uc.CreateTask(ctx, 2, "Other project", "", entity.StatusTodo, 2, 0, &past)
+
+	summary, err := uc.GetTaskSummary(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetTaskSummary() error = %v", err)
+	}
+	want := &entity.TaskSummary{
+		ProjectID:    1,
+		Total:        5,
+		StatusCounts: map[string]int{entity.StatusTodo: 3, entity.StatusInProgress: 1, entity.StatusDone: 1},
+		Overdue:      2, // done tasks and tasks due today are never overdue
+		Unassigned:   2,
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("GetTaskSummary() = %+v, want %+v", summary, want)
+	}
+
+	empty, err := uc.GetTaskSummary(ctx, 3)
+	if err != nil || empty.Total != 0 || len(empty.StatusCounts) != 3 {
+		t.Errorf("GetTaskSummary(no tasks) = %+v, %v; want every status at zero", empty, err)
+	}
+}
+
+func TestWatcherUseCase_Memory(t *testing.T) {
+	ctx := context.Background()
+	store := testutil.NewStore()
+	tasks := newMemoryTaskUseCase(store)
+	users := &MockUserDirectory{
+		roles:  map[int64]string{5: "user", 6: "user", 7: "user", 9: "admin"},
+		access: map[int64][]int64{5: {1}, 6: {1}},
+	}
+	uc := NewWatcherUseCase(testutil.NewWatcherRepository(store), testutil.NewTaskRepository(store), users)
+
+	task, _ := tasks.CreateTask(ctx, 1, "Rotate keys", "", "", 2, 0, nil)
+	watchers := func() []int64 {
+		t.Helper()
+		list, err := uc.ListWatchers(ctx, task.ID)
+		if err != nil {
+			t.Fatalf("ListWatchers() error = %v", err)
+		}
+		var ids []int64
+		for _, w := range list {
+			ids = append(ids, w.UserID)
+		}
+		return ids
+	}
+
+	// Adding is idempotent and the list keeps the order users started watching
+	for _, userID := range []int64{6, 5, 6, 9} {
+		if err := uc.AddWatcher(ctx, task.ID, userID); err != nil {
+			t.Fatalf("AddWatcher(%d) error = %v", userID, err)
+		}
+	}
+	if got := watchers(); !reflect.DeepEqual(got, []int64{6, 5, 9}) {
+		t.Errorf("watchers = %v, want [6 5 9]", got)
+	}
+
+	// So is removing, including users who never watched
+	for _, userID := range []int64{5, 5, 8} {
+		if err := uc.RemoveWatcher(ctx, task.ID, userID); err != nil {
+			t.Fatalf("RemoveWatcher(%d) error = %v", userID, err)
+		}
+	}
+	if got := watchers(); !reflect.DeepEqual(got, []int64{6, 9}) {
+		t.Errorf("watchers after removal = %v, want [6 9]", got)
+	}
+
+	if err := uc.AddWatcher(ctx, task.ID, 7); err != ErrWatcherNoAccess {
+		t.Errorf("AddWatcher(no access) error = %v, want %v", err, ErrWatcherNoAccess)
+	}
+	if err := uc.AddWatcher(ctx, task.ID, 42); err != ErrUnknownWatcher {
+		t.Errorf("AddWatcher(unknown user) error = %v, want %v", err, ErrUnknownWatcher)
+	}
+	if err := uc.AddWatcher(ctx, 999, 5); err != ErrTaskNotFound {
+		t.Errorf("AddWatcher(missing task) error = %v, want %v", err, ErrTaskNotFound)
+	}
+
+	// Watchers go with a deleted task
+	if err := tasks.DeleteTask(ctx, task.ID, 0); err != nil {
+		t.Fatalf("DeleteTask() error = %v", err)
+	}
+	if _, err := uc.ListWatchers(ctx, task.ID); err != ErrTaskNotFound {
+		t.Errorf("ListWatchers(deleted task) error = %v, want %v", err, ErrTaskNotFound)
+	}
+	if list, _ := testutil.NewWatcherRepository(store).GetByTaskID(ctx, task.ID); len(list) != 0 {
+		t.Errorf("deleted task still has watchers %+v", list)
+	}
+}
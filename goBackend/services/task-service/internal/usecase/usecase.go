@@ -2,60 +2,278 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/portfolio/shared/pagination"
 	"github.com/portfolio/task-service/internal/domain/entity"
 	"github.com/portfolio/task-service/internal/domain/repository"
 )
 
+// maxListLimit caps the subtasks loaded with a task and created at once
+const maxListLimit = 100
+
+// Batch sizes for StreamTasks
+const (
+	DefaultStreamBatch = 500
+	MaxStreamBatch     = 1000
+)
+
 var (
 	ErrTaskNotFound    = errors.New("task not found")
 	ErrSubtaskNotFound = errors.New("subtask not found")
 	ErrCommentNotFound = errors.New("comment not found")
+	ErrNoTaskIDs       = errors.New("no task ids given")
+	ErrInvalidParent   = errors.New("invalid parent task")
+	ErrProjectRequired = errors.New("project id is required")
+	ErrInvalidCursor   = errors.New("invalid cursor")
+	ErrDueDateInPast   = errors.New("due date must not be in the past")
+	ErrInvalidPriority = errors.New("priority must be between 1 (high) and 4 (none)")
+	ErrSameProject     = errors.New("task is already in this project")
+	ErrProjectNotFound = repository.ErrProjectNotFound
+
+	ErrEventsUnavailable = errors.New("task events are not available")
+
+	ErrUnknownAssignee  = errors.New("assignee does not exist")
+	ErrAssigneeNoAccess = errors.New("assignee has no access to the project")
+
+	ErrConflictingUpdate = errors.New("a field cannot be both set and cleared")
+
+	ErrFileURLRequired   = errors.New("file url is required")
+	ErrMediaFileNotFound = repository.ErrMediaFileNotFound
+	ErrMediaFileNotOwned = errors.New("media file was uploaded by another user")
+
+	ErrTagNotFound     = repository.ErrTagNotFound
+	ErrTaskTagNotFound = repository.ErrTaskTagNotFound
+	ErrTagInUse        = repository.ErrTagInUse
+
+	ErrUnknownWatcher  = errors.New("watcher does not exist")
+	ErrWatcherNoAccess = errors.New("watcher has no access to the project")
+
+	ErrNoSubtaskTitles   = errors.New("no subtask titles given")
+	ErrTooManySubtasks   = errors.New("too many subtasks in one request")
+	ErrEmptySubtaskTitle = errors.New("subtask title must not be empty")
 )
 
+// Limits caps how much a single task may hold; a zero value disables that cap
+type Limits struct {
+	MaxSubtasksPerTask int
+	MaxTagsPerTask     int
+}
+
+// TaskClears names the optional task fields an update sets back to empty
+type TaskClears struct {
+	AssignedTo  bool
+	DueDate     bool
+	Description bool
+}
+
+// DueDatePolicy decides whether tasks may be given a due date that has already passed
+type DueDatePolicy int
+
+const (
+	AllowPastDueDates DueDatePolicy = iota
+	RejectPastDueDates
+)
+
+// check returns ErrDueDateInPast when the policy rejects dueDate. Dates
+// earlier today still count as today, so a due date of today is accepted.
+func (p DueDatePolicy) check(dueDate *time.Time, now time.Time) error {
+	if p != RejectPastDueDates || dueDate == nil {
+		return nil
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if dueDate.Before(today) {
+		return ErrDueDateInPast
+	}
+	return nil
+}
+
+// TagDeletePolicy decides what happens to tasks when a tag they have is deleted
+type TagDeletePolicy int
+
+const (
+	// DetachDeletedTags removes the tag from its tasks along with it
+	DetachDeletedTags TagDeletePolicy = iota
+	// RejectTagsInUse refuses to delete a tag while any task has it
+	RejectTagsInUse
+)
+
+// AssigneeCheck confirms with the auth service that users tasks and
+// subtasks are assigned to exist. The zero value checks nothing.
+type AssigneeCheck struct {
+	Users repository.UserDirectory
+	// RequireAccess also requires the assignee to have access to the
+	// project; admins have access to every project
+	RequireAccess bool
+}
+
+// check returns ErrUnknownAssignee or ErrAssigneeNoAccess when userID may
+// not be assigned work in the project. Unassigned (0) always passes.
+func (c AssigneeCheck) check(ctx context.Context, userID, projectID int64) error {
+	if c.Users == nil || userID == 0 {
+		return nil
+	}
+	role, err := c.Users.UserRole(ctx, userID)
+	if errors.Is(err, repository.ErrUserNotFound) {
+		return ErrUnknownAssignee
+	}
+	if err != nil {
+		return err
+	}
+	if !c.RequireAccess || role == "admin" {
+		return nil
+	}
+	ok, err := c.Users.HasProjectAccess(ctx, userID, projectID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrAssigneeNoAccess
+	}
+	return nil
+}
+
+// LimitExceededError is returned when adding to a task would go over one of its Limits
+type LimitExceededError struct {
+	Resource string // subtasks, tags
+	Limit    int
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("task cannot have more than %d %s", e.Limit, e.Resource)
+}
+
 // TaskUseCase handles task business logic
 type TaskUseCase struct {
 	taskRepo       repository.TaskRepository
 	subtaskRepo    repository.SubtaskRepository
 	commentRepo    repository.CommentRepository
 	attachmentRepo repository.AttachmentRepository
+	media          mediaReleaser
 	tagRepo        repository.TagRepository
 	taskTagRepo    repository.TaskTagRepository
+	activityRepo   repository.ActivityRepository
+	defaultsRepo   repository.TaskDefaultsRepository
+	limits         Limits
+	dueDates       DueDatePolicy
+	assignees      AssigneeCheck
+	events         repository.TaskEventBus
+	pages          pagination.Sizes
 }
 
-// NewTaskUseCase creates a new TaskUseCase
+// NewTaskUseCase creates a new TaskUseCase listing tasks in pages of the
+// given sizes
 func NewTaskUseCase(
 	taskRepo repository.TaskRepository,
 	subtaskRepo repository.SubtaskRepository,
 	commentRepo repository.CommentRepository,
 	attachmentRepo repository.AttachmentRepository,
+	mediaFiles repository.MediaFileResolver,
 	tagRepo repository.TagRepository,
 	taskTagRepo repository.TaskTagRepository,
+	activityRepo repository.ActivityRepository,
+	defaultsRepo repository.TaskDefaultsRepository,
+	limits Limits,
+	dueDates DueDatePolicy,
+	assignees AssigneeCheck,
+	events repository.TaskEventBus,
+	pages pagination.Sizes,
 ) *TaskUseCase {
 	return &TaskUseCase{
 		taskRepo:       taskRepo,
 		subtaskRepo:    subtaskRepo,
 		commentRepo:    commentRepo,
 		attachmentRepo: attachmentRepo,
+		media:          mediaReleaser{attachmentRepo: attachmentRepo, mediaFiles: mediaFiles},
 		tagRepo:        tagRepo,
 		taskTagRepo:    taskTagRepo,
+		activityRepo:   activityRepo,
+		defaultsRepo:   defaultsRepo,
+		limits:         limits,
+		dueDates:       dueDates,
+		assignees:      assignees,
+		events:         events,
+		pages:          pages,
 	}
 }
 
-// CreateTask creates a new task
+// PageLimit is the page size ListTasks and ListTasksAfter apply for limit
+func (uc *TaskUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
+// CreateTask creates a new task. An empty status or a zero priority takes the
+// project's default, or the global one when the project has none.
 func (uc *TaskUseCase) CreateTask(ctx context.Context, projectID int64, title, description, status string, priority int, assignedTo int64, dueDate *time.Time) (*entity.Task, error) {
-	fmt.Println("CreateTask")
-	fmt.Println(projectID, title, description, status, priority, assignedTo, dueDate)
+	if priority != 0 && !entity.IsValidPriority(priority) {
+		return nil, ErrInvalidPriority
+	}
+	status, priority, err := uc.projectDefaults(ctx, projectID, status, priority)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.dueDates.check(dueDate, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.assignees.check(ctx, assignedTo, projectID); err != nil {
+		return nil, err
+	}
 	task := entity.NewTask(projectID, title, description, status, priority, assignedTo, dueDate)
 	if err := uc.taskRepo.Create(ctx, task); err != nil {
 		return nil, err
 	}
+	slog.DebugContext(ctx, "task created", "task_id", task.ID, "project_id", projectID)
+	uc.publish(entity.TaskCreated, task)
 	return task, nil
 }
 
+// projectDefaults fills an omitted status or priority from the project's
+// settings. Whatever is still unset gets the global default in NewTask.
+func (uc *TaskUseCase) projectDefaults(ctx context.Context, projectID int64, status string, priority int) (string, int, error) {
+	if uc.defaultsRepo == nil || (status != "" && priority != 0) {
+		return status, priority, nil
+	}
+	defaults, err := uc.defaultsRepo.Get(ctx, projectID)
+	if err != nil {
+		return "", 0, err
+	}
+	// The project service validates settings; anything else is ignored
+	if status == "" && entity.IsValidTaskStatus(defaults.Status) {
+		status = defaults.Status
+	}
+	if priority == 0 && entity.IsValidPriority(defaults.Priority) {
+		priority = defaults.Priority
+	}
+	return status, priority, nil
+}
+
+// publish announces a task change to the project's watchers, if any
+func (uc *TaskUseCase) publish(eventType string, task *entity.Task) {
+	if uc.events != nil && task != nil {
+		uc.events.Publish(entity.NewTaskEvent(eventType, task))
+	}
+}
+
+// WatchProject returns the task changes in projectID as they happen and a
+// func that stops watching
+func (uc *TaskUseCase) WatchProject(projectID int64) (<-chan entity.TaskEvent, func(), error) {
+	if projectID <= 0 {
+		return nil, nil, ErrProjectRequired
+	}
+	if uc.events == nil {
+		return nil, nil, ErrEventsUnavailable
+	}
+	events, cancel := uc.events.Subscribe(projectID)
+	return events, cancel, nil
+}
+
 // GetTask retrieves a task by ID with all related data
 func (uc *TaskUseCase) GetTask(ctx context.Context, id int64) (*entity.Task, error) {
 	task, err := uc.taskRepo.GetByID(ctx, id)
@@ -64,7 +282,7 @@ func (uc *TaskUseCase) GetTask(ctx context.Context, id int64) (*entity.Task, err
 	}
 
 	// Load subtasks
-	subtasks, _ := uc.subtaskRepo.GetByTaskID(ctx, id)
+	subtasks, _, _ := uc.subtaskRepo.GetByTaskID(ctx, id, 1, maxListLimit)
 	task.Subtasks = subtasks
 
 	// Load tags
@@ -74,12 +292,64 @@ func (uc *TaskUseCase) GetTask(ctx context.Context, id int64) (*entity.Task, err
 	return task, nil
 }
 
-// UpdateTask updates a task
-func (uc *TaskUseCase) UpdateTask(ctx context.Context, id int64, title, description, status string, priority int, assignedTo int64, dueDate *time.Time) (*entity.Task, error) {
+// DuplicateTask copies a task into a new task in the same project, together
+// with its subtasks and tags. The copy and its subtasks start as Todo, and
+// due dates are only kept when keepDueDate is set. Comments and attachments
+// stay with the original.
+func (uc *TaskUseCase) DuplicateTask(ctx context.Context, id int64, keepDueDate bool) (*entity.Task, error) {
+	source, err := uc.taskRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+
+	var dueDate *time.Time
+	if keepDueDate {
+		dueDate = source.DueDate
+		if err := uc.dueDates.check(dueDate, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+	var assignedTo int64
+	if source.AssignedTo != nil {
+		assignedTo = *source.AssignedTo
+	}
+	task := entity.NewTask(source.ProjectID, source.Title, source.Description, entity.StatusTodo, source.Priority, assignedTo, dueDate)
+	if err := uc.taskRepo.Duplicate(ctx, source.ID, task, keepDueDate); err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "task duplicated", "task_id", task.ID, "source_id", source.ID)
+	duplicate, err := uc.GetTask(ctx, task.ID)
+	if err != nil {
+		return nil, err
+	}
+	uc.publish(entity.TaskCreated, duplicate)
+	return duplicate, nil
+}
+
+// UpdateTask updates a task. Empty values leave a field unchanged; clears
+// unset the assignee or due date, or empty the description.
+func (uc *TaskUseCase) UpdateTask(ctx context.Context, id int64, title, description, status string, priority int, assignedTo int64, dueDate *time.Time, clears TaskClears) (*entity.Task, error) {
+	if (clears.AssignedTo && assignedTo > 0) || (clears.DueDate && dueDate != nil) ||
+		(clears.Description && description != "") {
+		return nil, ErrConflictingUpdate
+	}
+	if priority != 0 && !entity.IsValidPriority(priority) {
+		return nil, ErrInvalidPriority
+	}
+	if err := uc.dueDates.check(dueDate, time.Now()); err != nil {
+		return nil, err
+	}
 	task, err := uc.taskRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrTaskNotFound
 	}
+	// Only a new assignee is checked, so keeping one who has since lost
+	// access doesn't block other changes
+	if assignedTo > 0 && (task.AssignedTo == nil || *task.AssignedTo != assignedTo) {
+		if err := uc.assignees.check(ctx, assignedTo, task.ProjectID); err != nil {
+			return nil, err
+		}
+	}
 
 	if title != "" {
 		task.Title = title
@@ -90,7 +360,7 @@ func (uc *TaskUseCase) UpdateTask(ctx context.Context, id int64, title, descript
 	if status != "" {
 		task.Status = status
 	}
-	if priority > 0 {
+	if priority != 0 {
 		task.Priority = priority
 	}
 	if assignedTo > 0 {
@@ -99,56 +369,506 @@ func (uc *TaskUseCase) UpdateTask(ctx context.Context, id int64, title, descript
 	if dueDate != nil {
 		task.DueDate = dueDate
 	}
+	if clears.AssignedTo {
+		task.AssignedTo = nil
+	}
+	if clears.DueDate {
+		task.DueDate = nil
+	}
+	if clears.Description {
+		task.Description = ""
+	}
 	task.UpdatedAt = time.Now()
 
 	if err := uc.taskRepo.Update(ctx, task); err != nil {
 		return nil, err
 	}
 
-	return uc.GetTask(ctx, id)
+	updated, err := uc.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	uc.publish(entity.TaskUpdated, updated)
+	return updated, nil
+}
+
+// MoveTask moves a task to another project together with its subtasks,
+// comments and tags. Assignees are kept if they may work in the new project,
+// or unassigned when clearAssignees is set. Watchers of both projects are
+// told about the move.
+func (uc *TaskUseCase) MoveTask(ctx context.Context, id, projectID int64, clearAssignees bool) (*entity.Task, error) {
+	if projectID <= 0 {
+		return nil, ErrProjectRequired
+	}
+	task, err := uc.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if task.ProjectID == projectID {
+		return nil, ErrSameProject
+	}
+	if !clearAssignees {
+		if err := uc.checkMovedAssignees(ctx, task, projectID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.taskRepo.Move(ctx, id, projectID, clearAssignees); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrTaskNotFound
+		}
+		return nil, err
+	}
+	slog.DebugContext(ctx, "task moved", "task_id", id, "from_project_id", task.ProjectID, "project_id", projectID)
+
+	// The move itself has succeeded, so a failed log write is not reported
+	if uc.activityRepo != nil {
+		_ = uc.activityRepo.Record(ctx, entity.NewTaskActivity(id, entity.ActionTaskMoved))
+	}
+
+	moved, err := uc.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if uc.events != nil {
+		event := entity.NewTaskEvent(entity.TaskMoved, moved)
+		uc.events.Publish(event)
+		event.ProjectID = task.ProjectID
+		uc.events.Publish(event)
+	}
+	return moved, nil
+}
+
+// checkMovedAssignees checks that the assignees of a task and all of its
+// subtasks, not just the ones loaded with the task, may be assigned work in
+// the project it moves to
+func (uc *TaskUseCase) checkMovedAssignees(ctx context.Context, task *entity.Task, projectID int64) error {
+	subtaskAssignees, err := uc.subtaskRepo.AssigneesByTaskID(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+	assignees := make(map[int64]bool)
+	if task.AssignedTo != nil {
+		assignees[*task.AssignedTo] = true
+	}
+	for _, userID := range subtaskAssignees {
+		assignees[userID] = true
+	}
+	for userID := range assignees {
+		if err := uc.assignees.check(ctx, userID, projectID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DeleteTask deletes a task. Media files attached to it that userID uploaded
+// are deleted from the media service once nothing else references them.
+func (uc *TaskUseCase) DeleteTask(ctx context.Context, id, userID int64) error {
+	// The task is only loaded to tell watchers which project it was in
+	var task *entity.Task
+	if uc.events != nil {
+		task, _ = uc.taskRepo.GetByID(ctx, id)
+	}
+	mediaFileIDs := uc.media.referenced(ctx, id)
+	if err := uc.taskRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.media.release(ctx, userID, mediaFileIDs...)
+	uc.publish(entity.TaskDeleted, task)
+	return nil
+}
+
+// Demote turns a task into a subtask of parentTaskID and deletes the task.
+// Subtasks can't be nested, so the task's own subtasks and its comments move
+// to the parent. The parent must be a different task in the same project.
+// Attachments go with the task, releasing media files as DeleteTask does.
+func (uc *TaskUseCase) Demote(ctx context.Context, taskID, parentTaskID, userID int64) (*entity.Subtask, error) {
+	if taskID == parentTaskID {
+		return nil, ErrInvalidParent
+	}
+	task, err := uc.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	parent, err := uc.taskRepo.GetByID(ctx, parentTaskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	if parent.ProjectID != task.ProjectID {
+		return nil, ErrInvalidParent
+	}
+
+	var assignedTo int64
+	if task.AssignedTo != nil {
+		assignedTo = *task.AssignedTo
+	}
+	subtask := entity.NewSubtask(parent.ID, task.Title, assignedTo, task.DueDate)
+	subtask.Status = task.Status
+
+	// The task's own subtasks move to the parent too, so they count toward its limit
+	max := uc.limits.MaxSubtasksPerTask
+	mediaFileIDs := uc.media.referenced(ctx, task.ID)
+	if err := uc.taskRepo.ConvertToSubtask(ctx, task.ID, subtask, max); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrTaskNotFound
+		case errors.Is(err, repository.ErrLimitExceeded):
+			return nil, &LimitExceededError{Resource: "subtasks", Limit: max}
+		}
+		return nil, err
+	}
+	uc.media.release(ctx, userID, mediaFileIDs...)
+	uc.publish(entity.TaskDeleted, task)
+	return subtask, nil
+}
+
+// BulkDelete deletes many tasks at once along with their subtasks, comments,
+// attachments and tag mappings. It returns the number of tasks deleted.
+// Media files are released as DeleteTask does.
+func (uc *TaskUseCase) BulkDelete(ctx context.Context, ids []int64, userID int64) (int, error) {
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	if len(unique) == 0 {
+		return 0, ErrNoTaskIDs
+	}
+
+	// The tasks are only loaded to tell watchers which projects they were in
+	var tasks []*entity.Task
+	if uc.events != nil {
+		tasks, _ = uc.taskRepo.GetByIDs(ctx, unique)
+	}
+
+	mediaFileIDs := uc.media.referenced(ctx, unique...)
+	deleted, err := uc.taskRepo.DeleteMany(ctx, unique)
+	if err != nil {
+		return 0, err
+	}
+	uc.media.release(ctx, userID, mediaFileIDs...)
+	for _, task := range tasks {
+		uc.publish(entity.TaskDeleted, task)
+	}
+	return int(deleted), nil
+}
+
+// TagQuery selects tasks by tag. Name is resolved to a tag id and added to
+// IDs; tasks must carry all of those tags, or any one of them with MatchAny.
+type TagQuery struct {
+	IDs      []int64
+	Name     string
+	MatchAny bool
+}
+
+// ListTasks lists tasks with filters. A tag name that doesn't exist matches no
+// tasks, unless MatchAny is set and other tags were given.
+func (uc *TaskUseCase) ListTasks(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64, tags TagQuery) ([]*entity.Task, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+	filter, ok, err := uc.resolveTags(ctx, tags)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !ok {
+		return []*entity.Task{}, 0, nil
+	}
+	tasks, total, err := uc.taskRepo.List(ctx, projectID, page, limit, status, assignedTo, filter)
+	if err != nil || len(tasks) == 0 {
+		return tasks, total, err
+	}
+	if err := uc.fillProgress(ctx, tasks); err != nil {
+		return nil, 0, err
+	}
+	return tasks, total, nil
+}
+
+// ListTasksAfter is the cursor-paginated form of ListTasks. Tasks come in id
+// order starting after cursor; an empty cursor starts from the first task.
+// The returned cursor fetches the next page and is empty on the last one.
+func (uc *TaskUseCase) ListTasksAfter(ctx context.Context, projectID int64, cursor string, limit int, status string, assignedTo int64, tags TagQuery) ([]*entity.Task, string, error) {
+	afterID, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+	limit = uc.pages.Limit(limit)
+	filter, ok, err := uc.resolveTags(ctx, tags)
+	if err != nil {
+		return nil, "", err
+	}
+	if !ok {
+		return []*entity.Task{}, "", nil
+	}
+
+	// One extra row tells whether another page follows without a count query
+	tasks, err := uc.taskRepo.ListAfter(ctx, projectID, afterID, limit+1, status, assignedTo, filter)
+	if err != nil {
+		return nil, "", err
+	}
+	var next string
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		next = encodeCursor(tasks[limit-1].ID)
+	}
+	if len(tasks) == 0 {
+		return tasks, "", nil
+	}
+	if err := uc.fillProgress(ctx, tasks); err != nil {
+		return nil, "", err
+	}
+	return tasks, next, nil
+}
+
+// StreamTasks passes every task in a project that matches the filters to
+// send, in id order and batchSize tasks at a time. Only one batch is held
+// in memory, so large exports don't load the whole result. Returning an
+// error from send stops the stream.
+func (uc *TaskUseCase) StreamTasks(ctx context.Context, projectID int64, status string, assignedTo int64, tags TagQuery, batchSize int, send func([]*entity.Task) error) error {
+	if projectID <= 0 {
+		return ErrProjectRequired
+	}
+	if batchSize < 1 {
+		batchSize = DefaultStreamBatch
+	}
+	if batchSize > MaxStreamBatch {
+		batchSize = MaxStreamBatch
+	}
+	filter, ok, err := uc.resolveTags(ctx, tags)
+	if err != nil || !ok {
+		return err
+	}
+
+	var afterID int64
+	for {
+		tasks, err := uc.taskRepo.ListAfter(ctx, projectID, afterID, batchSize, status, assignedTo, filter)
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return nil
+		}
+		if err := uc.fillProgress(ctx, tasks); err != nil {
+			return err
+		}
+		if err := send(tasks); err != nil {
+			return err
+		}
+		if len(tasks) < batchSize {
+			return nil
+		}
+		afterID = tasks[len(tasks)-1].ID
+	}
 }
 
-// DeleteTask deletes a task
-func (uc *TaskUseCase) DeleteTask(ctx context.Context, id int64) error {
-	return uc.taskRepo.Delete(ctx, id)
+// resolveTags turns a TagQuery into a repository filter with distinct, valid
+// ids. ok is false when the query can match no task.
+func (uc *TaskUseCase) resolveTags(ctx context.Context, q TagQuery) (repository.TagFilter, bool, error) {
+	filter := repository.TagFilter{MatchAny: q.MatchAny}
+	seen := make(map[int64]bool)
+	add := func(id int64) {
+		if id > 0 && !seen[id] {
+			seen[id] = true
+			filter.IDs = append(filter.IDs, id)
+		}
+	}
+	for _, id := range q.IDs {
+		add(id)
+	}
+
+	name := strings.TrimSpace(q.Name)
+	if name == "" {
+		return filter, true, nil
+	}
+	tag, err := uc.tagRepo.GetByName(ctx, name)
+	if errors.Is(err, repository.ErrTagNotFound) {
+		// No task has an unknown tag, which only rules out every task when all tags are required
+		return filter, q.MatchAny && len(filter.IDs) > 0, nil
+	}
+	if err != nil {
+		return filter, false, err
+	}
+	add(tag.ID)
+	return filter, true, nil
 }
 
-// ListTasks lists tasks with filters
-func (uc *TaskUseCase) ListTasks(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64) ([]*entity.Task, int, error) {
-	if page < 1 {
-		page = 1
+// fillProgress sets Progress on a page of tasks
+func (uc *TaskUseCase) fillProgress(ctx context.Context, tasks []*entity.Task) error {
+	// One count query for the whole page instead of one per task
+	ids := make([]int64, len(tasks))
+	for i, t := range tasks {
+		ids[i] = t.ID
+	}
+	progress, err := uc.subtaskRepo.ProgressByTaskIDs(ctx, ids)
+	if err != nil {
+		return err
 	}
-	if limit < 1 || limit > 100 {
-		limit = 10
+	for _, t := range tasks {
+		t.Progress = progress[t.ID].Ratio()
 	}
-	return uc.taskRepo.List(ctx, projectID, page, limit, status, assignedTo)
+	return nil
+}
+
+// encodeCursor turns the last task id of a page into an opaque cursor
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeCursor returns the task id a cursor points after; "" starts at the beginning
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id < 1 {
+		return 0, ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// ListTaskVersions returns the id and updated_at of every task in a project,
+// letting clients diff against their cache and fetch only changed tasks
+func (uc *TaskUseCase) ListTaskVersions(ctx context.Context, projectID int64) ([]*entity.TaskVersion, error) {
+	if projectID <= 0 {
+		return nil, ErrProjectRequired
+	}
+	return uc.taskRepo.ListVersions(ctx, projectID)
+}
+
+// CountTasksByProject counts the tasks of every project and how many are
+// done, for the analytics service to reconcile its project stats
+func (uc *TaskUseCase) CountTasksByProject(ctx context.Context) ([]*entity.ProjectTaskCount, error) {
+	return uc.taskRepo.CountByProject(ctx)
+}
+
+// GetTaskSummary counts a project's tasks per status, and those overdue or
+// unassigned, for the board
+func (uc *TaskUseCase) GetTaskSummary(ctx context.Context, projectID int64) (*entity.TaskSummary, error) {
+	return uc.taskRepo.Summarize(ctx, projectID, time.Now())
 }
 
 // SubtaskUseCase handles subtask business logic
 type SubtaskUseCase struct {
-	subtaskRepo repository.SubtaskRepository
+	subtaskRepo  repository.SubtaskRepository
+	taskRepo     repository.TaskRepository
+	activityRepo repository.ActivityRepository
+	limits       Limits
+	assignees    AssigneeCheck
+	events       repository.TaskEventBus
+	pages        pagination.Sizes
+}
+
+// NewSubtaskUseCase creates a new SubtaskUseCase listing subtasks in pages
+// of the given sizes
+func NewSubtaskUseCase(
+	subtaskRepo repository.SubtaskRepository,
+	taskRepo repository.TaskRepository,
+	activityRepo repository.ActivityRepository,
+	limits Limits,
+	assignees AssigneeCheck,
+	events repository.TaskEventBus,
+	pages pagination.Sizes,
+) *SubtaskUseCase {
+	return &SubtaskUseCase{
+		subtaskRepo:  subtaskRepo,
+		taskRepo:     taskRepo,
+		activityRepo: activityRepo,
+		limits:       limits,
+		assignees:    assignees,
+		events:       events,
+		pages:        pages,
+	}
 }
 
-// NewSubtaskUseCase creates a new SubtaskUseCase
-func NewSubtaskUseCase(subtaskRepo repository.SubtaskRepository) *SubtaskUseCase {
-	return &SubtaskUseCase{subtaskRepo: subtaskRepo}
+// PageLimit is the page size GetSubtasks applies for limit
+func (uc *SubtaskUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
+}
+
+// checkAssignee checks a subtask assignee against the project of its task
+func (uc *SubtaskUseCase) checkAssignee(ctx context.Context, taskID, assignedTo int64) error {
+	if uc.assignees.Users == nil || assignedTo == 0 {
+		return nil
+	}
+	task, err := uc.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	return uc.assignees.check(ctx, assignedTo, task.ProjectID)
 }
 
 // CreateSubtask creates a new subtask
 func (uc *SubtaskUseCase) CreateSubtask(ctx context.Context, taskID int64, title string, assignedTo int64, dueDate *time.Time) (*entity.Subtask, error) {
+	if err := uc.checkAssignee(ctx, taskID, assignedTo); err != nil {
+		return nil, err
+	}
+
 	subtask := entity.NewSubtask(taskID, title, assignedTo, dueDate)
-	if err := uc.subtaskRepo.Create(ctx, subtask); err != nil {
+	if err := uc.createSubtasks(ctx, taskID, []*entity.Subtask{subtask}); err != nil {
 		return nil, err
 	}
 	return subtask, nil
 }
 
+// createSubtasks stores subtasks under the task's subtask limit
+func (uc *SubtaskUseCase) createSubtasks(ctx context.Context, taskID int64, subtasks []*entity.Subtask) error {
+	max := uc.limits.MaxSubtasksPerTask
+	err := uc.subtaskRepo.CreateMany(ctx, taskID, subtasks, max)
+	switch {
+	case errors.Is(err, repository.ErrLimitExceeded):
+		return &LimitExceededError{Resource: "subtasks", Limit: max}
+	case errors.Is(err, repository.ErrTaskNotFound):
+		return ErrTaskNotFound
+	}
+	return err
+}
+
+// CreateMany creates one subtask per title in a single transaction. The
+// subtasks are appended after the task's existing ones in the given order.
+func (uc *SubtaskUseCase) CreateMany(ctx context.Context, taskID int64, titles []string) ([]*entity.Subtask, error) {
+	if len(titles) == 0 {
+		return nil, ErrNoSubtaskTitles
+	}
+	if len(titles) > maxListLimit {
+		return nil, ErrTooManySubtasks
+	}
+
+	subtasks := make([]*entity.Subtask, len(titles))
+	for i, title := range titles {
+		title = strings.TrimSpace(title)
+		if title == "" {
+			return nil, ErrEmptySubtaskTitle
+		}
+		subtasks[i] = entity.NewSubtask(taskID, title, 0, nil)
+	}
+
+	if _, err := uc.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	if err := uc.createSubtasks(ctx, taskID, subtasks); err != nil {
+		return nil, err
+	}
+	return subtasks, nil
+}
+
 // UpdateSubtask updates a subtask
 func (uc *SubtaskUseCase) UpdateSubtask(ctx context.Context, id int64, title, status string, assignedTo int64, dueDate *time.Time) (*entity.Subtask, error) {
 	subtask, err := uc.subtaskRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, ErrSubtaskNotFound
 	}
+	if assignedTo > 0 && (subtask.AssignedTo == nil || *subtask.AssignedTo != assignedTo) {
+		if err := uc.checkAssignee(ctx, subtask.TaskID, assignedTo); err != nil {
+			return nil, err
+		}
+	}
 
 	if title != "" {
 		subtask.Title = title
@@ -157,7 +877,7 @@ func (uc *SubtaskUseCase) UpdateSubtask(ctx context.Context, id int64, title, st
 		subtask.Status = status
 	}
 	if assignedTo > 0 {
-		subtask.AssignedTo = assignedTo
+		subtask.AssignedTo = &assignedTo
 	}
 	if dueDate != nil {
 		subtask.DueDate = dueDate
@@ -176,19 +896,101 @@ func (uc *SubtaskUseCase) DeleteSubtask(ctx context.Context, id int64) error {
 	return uc.subtaskRepo.Delete(ctx, id)
 }
 
-// GetSubtasks gets all subtasks for a task
-func (uc *SubtaskUseCase) GetSubtasks(ctx context.Context, taskID int64) ([]*entity.Subtask, error) {
-	return uc.subtaskRepo.GetByTaskID(ctx, taskID)
+// MoveToTask moves a subtask under another task, after its existing subtasks.
+// When fromTaskID is set the subtask must belong to that task, and is
+// reported as not found otherwise.
+func (uc *SubtaskUseCase) MoveToTask(ctx context.Context, subtaskID, fromTaskID, newTaskID int64) (*entity.Subtask, error) {
+	subtask, err := uc.subtaskRepo.GetByID(ctx, subtaskID)
+	if err != nil || (fromTaskID != 0 && subtask.TaskID != fromTaskID) {
+		return nil, ErrSubtaskNotFound
+	}
+	if subtask.TaskID == newTaskID {
+		return subtask, nil
+	}
+	task, err := uc.taskRepo.GetByID(ctx, newTaskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+	// As in MoveTask, the assignee must be allowed to work in the new project
+	if subtask.AssignedTo != nil {
+		if err := uc.assignees.check(ctx, *subtask.AssignedTo, task.ProjectID); err != nil {
+			return nil, err
+		}
+	}
+
+	max := uc.limits.MaxSubtasksPerTask
+	oldTaskID := subtask.TaskID
+	if err := uc.subtaskRepo.MoveToTask(ctx, subtask, newTaskID, max); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrLimitExceeded):
+			return nil, &LimitExceededError{Resource: "subtasks", Limit: max}
+		case errors.Is(err, repository.ErrTaskNotFound):
+			return nil, ErrTaskNotFound
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrSubtaskNotFound
+		}
+		return nil, err
+	}
+
+	// The move itself has succeeded, so a failed log write is not reported
+	for _, taskID := range []int64{oldTaskID, newTaskID} {
+		_ = uc.activityRepo.Record(ctx, entity.NewTaskActivity(taskID, entity.ActionSubtaskMoved))
+	}
+
+	return subtask, nil
+}
+
+// Promote turns a subtask into a task in the same project as its parent.
+// The subtask is removed once the task exists. When taskID is set the
+// subtask must belong to that task, and is reported as not found otherwise.
+func (uc *SubtaskUseCase) Promote(ctx context.Context, subtaskID, taskID int64) (*entity.Task, error) {
+	subtask, err := uc.subtaskRepo.GetByID(ctx, subtaskID)
+	if err != nil || (taskID != 0 && subtask.TaskID != taskID) {
+		return nil, ErrSubtaskNotFound
+	}
+	parent, err := uc.taskRepo.GetByID(ctx, subtask.TaskID)
+	if err != nil {
+		return nil, ErrTaskNotFound
+	}
+
+	var assignedTo int64
+	if subtask.AssignedTo != nil {
+		assignedTo = *subtask.AssignedTo
+	}
+	task := entity.NewTask(parent.ProjectID, subtask.Title, "", subtask.Status, 0, assignedTo, subtask.DueDate)
+	if err := uc.taskRepo.CreateFromSubtask(ctx, task, subtask.ID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSubtaskNotFound
+		}
+		return nil, err
+	}
+	if uc.events != nil {
+		uc.events.Publish(entity.NewTaskEvent(entity.TaskCreated, task))
+	}
+	return task, nil
+}
+
+// GetSubtasks gets a page of subtasks for a task
+func (uc *SubtaskUseCase) GetSubtasks(ctx context.Context, taskID int64, page, limit int) ([]*entity.Subtask, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+	return uc.subtaskRepo.GetByTaskID(ctx, taskID, page, limit)
 }
 
 // CommentUseCase handles comment business logic
 type CommentUseCase struct {
 	commentRepo repository.CommentRepository
+	pages       pagination.Sizes
 }
 
-// NewCommentUseCase creates a new CommentUseCase
-func NewCommentUseCase(commentRepo repository.CommentRepository) *CommentUseCase {
-	return &CommentUseCase{commentRepo: commentRepo}
+// NewCommentUseCase creates a new CommentUseCase listing comments in pages of
+// the given sizes
+func NewCommentUseCase(commentRepo repository.CommentRepository, pages pagination.Sizes) *CommentUseCase {
+	return &CommentUseCase{commentRepo: commentRepo, pages: pages}
+}
+
+// PageLimit is the page size GetComments and GetUserComments apply for limit
+func (uc *CommentUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
 }
 
 // AddComment adds a comment to a task
@@ -205,23 +1007,116 @@ func (uc *CommentUseCase) DeleteComment(ctx context.Context, id int64) error {
 	return uc.commentRepo.Delete(ctx, id)
 }
 
-// GetComments gets all comments for a task
-func (uc *CommentUseCase) GetComments(ctx context.Context, taskID int64) ([]*entity.TaskComment, error) {
-	return uc.commentRepo.GetByTaskID(ctx, taskID)
+// DeleteByTaskID deletes every comment on a task and returns how many were removed
+func (uc *CommentUseCase) DeleteByTaskID(ctx context.Context, taskID int64) (int, error) {
+	deleted, err := uc.commentRepo.DeleteByTaskID(ctx, taskID)
+	if errors.Is(err, repository.ErrTaskNotFound) {
+		return 0, ErrTaskNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return int(deleted), nil
+}
+
+// GetComments gets a page of comments for a task
+func (uc *CommentUseCase) GetComments(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskComment, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+	return uc.commentRepo.GetByTaskID(ctx, taskID, page, limit)
+}
+
+// GetUserComments gets a page of a user's comments on any task, newest first
+func (uc *CommentUseCase) GetUserComments(ctx context.Context, userID int64, page, limit int) ([]*entity.TaskComment, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+	return uc.commentRepo.GetByUserID(ctx, userID, page, limit)
+}
+
+// mediaReleaser deletes media files from the media service once no
+// attachment references them, so their storage doesn't leak. Only files the
+// acting user uploaded are deleted; anyone else's file may still be in use
+// outside this service. Releasing is best effort: failures are logged and
+// never undo the delete that dropped the references.
+type mediaReleaser struct {
+	attachmentRepo repository.AttachmentRepository
+	mediaFiles     repository.MediaFileResolver
+}
+
+// referenced returns the media files attached to the tasks, read before they
+// are deleted
+func (m mediaReleaser) referenced(ctx context.Context, taskIDs ...int64) []int64 {
+	if m.mediaFiles == nil || m.attachmentRepo == nil {
+		return nil
+	}
+	ids, err := m.attachmentRepo.MediaFileIDsByTaskIDs(ctx, taskIDs)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to list media files of deleted tasks", "task_ids", taskIDs, "error", err)
+		return nil
+	}
+	return ids
+}
+
+// release deletes each media file that userID uploaded and that no
+// attachment references any more
+func (m mediaReleaser) release(ctx context.Context, userID int64, mediaFileIDs ...int64) {
+	if m.mediaFiles == nil || userID == 0 {
+		return
+	}
+	for _, id := range mediaFileIDs {
+		remaining, err := m.attachmentRepo.CountByMediaFileID(ctx, id)
+		if err != nil {
+			slog.WarnContext(ctx, "failed to count media file references", "media_file_id", id, "error", err)
+			continue
+		}
+		if remaining > 0 {
+			continue
+		}
+		file, err := m.mediaFiles.GetFile(ctx, id)
+		if err != nil {
+			if !errors.Is(err, repository.ErrMediaFileNotFound) {
+				slog.WarnContext(ctx, "failed to look up media file of removed attachment", "media_file_id", id, "error", err)
+			}
+			continue
+		}
+		if file.UploadedBy != userID {
+			continue
+		}
+		if err := m.mediaFiles.DeleteFile(ctx, id); err != nil && !errors.Is(err, repository.ErrMediaFileNotFound) {
+			slog.WarnContext(ctx, "failed to delete media file of removed attachment", "media_file_id", id, "error", err)
+		}
+	}
 }
 
 // AttachmentUseCase handles attachment business logic
 type AttachmentUseCase struct {
 	attachmentRepo repository.AttachmentRepository
+	mediaFiles     repository.MediaFileResolver
+	media          mediaReleaser
+	pages          pagination.Sizes
+}
+
+// NewAttachmentUseCase creates a new AttachmentUseCase listing attachments
+// in pages of the given sizes
+func NewAttachmentUseCase(attachmentRepo repository.AttachmentRepository, mediaFiles repository.MediaFileResolver, pages pagination.Sizes) *AttachmentUseCase {
+	return &AttachmentUseCase{
+		attachmentRepo: attachmentRepo,
+		mediaFiles:     mediaFiles,
+		media:          mediaReleaser{attachmentRepo: attachmentRepo, mediaFiles: mediaFiles},
+		pages:          pages,
+	}
 }
 
-// NewAttachmentUseCase creates a new AttachmentUseCase
-func NewAttachmentUseCase(attachmentRepo repository.AttachmentRepository) *AttachmentUseCase {
-	return &AttachmentUseCase{attachmentRepo: attachmentRepo}
+// PageLimit is the page size GetAttachments applies for limit
+func (uc *AttachmentUseCase) PageLimit(limit int) int {
+	return uc.pages.Limit(limit)
 }
 
-// AddAttachment adds an attachment to a task
+// AddAttachment adds an external link attachment to a task
 func (uc *AttachmentUseCase) AddAttachment(ctx context.Context, taskID int64, fileURL string) (*entity.TaskAttachment, error) {
+	fileURL = strings.TrimSpace(fileURL)
+	if fileURL == "" {
+		return nil, ErrFileURLRequired
+	}
+
 	attachment := entity.NewTaskAttachment(taskID, fileURL)
 	if err := uc.attachmentRepo.Create(ctx, attachment); err != nil {
 		return nil, err
@@ -229,27 +1124,82 @@ func (uc *AttachmentUseCase) AddAttachment(ctx context.Context, taskID int64, fi
 	return attachment, nil
 }
 
-// DeleteAttachment deletes an attachment
-func (uc *AttachmentUseCase) DeleteAttachment(ctx context.Context, id int64) error {
-	return uc.attachmentRepo.Delete(ctx, id)
+// AddMediaAttachment attaches a file uploaded to the media service, storing
+// its ID along with the URL the media service resolves it to. A non-zero
+// uploaderID must be the user who uploaded the file, so nobody can attach,
+// and later delete, someone else's upload; admins pass 0.
+func (uc *AttachmentUseCase) AddMediaAttachment(ctx context.Context, taskID, mediaFileID, uploaderID int64) (*entity.TaskAttachment, error) {
+	file, err := uc.mediaFiles.GetFile(ctx, mediaFileID)
+	if err != nil {
+		return nil, err
+	}
+	if uploaderID != 0 && file.UploadedBy != uploaderID {
+		return nil, ErrMediaFileNotOwned
+	}
+
+	attachment := entity.NewMediaAttachment(taskID, mediaFileID, file.URL)
+	if err := uc.attachmentRepo.Create(ctx, attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// DeleteAttachment deletes an attachment. When it was the last attachment
+// referencing a media file that userID uploaded, the file is deleted from
+// the media service too.
+func (uc *AttachmentUseCase) DeleteAttachment(ctx context.Context, id, userID int64) error {
+	attachment, err := uc.attachmentRepo.GetByID(ctx, id)
+	if errors.Is(err, repository.ErrAttachmentNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if err := uc.attachmentRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	if attachment.MediaFileID != 0 {
+		uc.media.release(ctx, userID, attachment.MediaFileID)
+	}
+	return nil
+}
+
+// DeleteByTaskID deletes every attachment on a task and returns how many
+// were removed, releasing media files as DeleteAttachment does
+func (uc *AttachmentUseCase) DeleteByTaskID(ctx context.Context, taskID, userID int64) (int, error) {
+	mediaFileIDs := uc.media.referenced(ctx, taskID)
+	deleted, err := uc.attachmentRepo.DeleteByTaskID(ctx, taskID)
+	if errors.Is(err, repository.ErrTaskNotFound) {
+		return 0, ErrTaskNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	uc.media.release(ctx, userID, mediaFileIDs...)
+	return int(deleted), nil
 }
 
-// GetAttachments gets all attachments for a task
-func (uc *AttachmentUseCase) GetAttachments(ctx context.Context, taskID int64) ([]*entity.TaskAttachment, error) {
-	return uc.attachmentRepo.GetByTaskID(ctx, taskID)
+// GetAttachments gets a page of attachments for a task
+func (uc *AttachmentUseCase) GetAttachments(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskAttachment, int, error) {
+	page, limit = uc.pages.Normalize(page, limit)
+	return uc.attachmentRepo.GetByTaskID(ctx, taskID, page, limit)
 }
 
 // TagUseCase handles tag business logic
 type TagUseCase struct {
 	tagRepo     repository.TagRepository
 	taskTagRepo repository.TaskTagRepository
+	limits      Limits
+	deletes     TagDeletePolicy
 }
 
 // NewTagUseCase creates a new TagUseCase
-func NewTagUseCase(tagRepo repository.TagRepository, taskTagRepo repository.TaskTagRepository) *TagUseCase {
+func NewTagUseCase(tagRepo repository.TagRepository, taskTagRepo repository.TaskTagRepository, limits Limits, deletes TagDeletePolicy) *TagUseCase {
 	return &TagUseCase{
 		tagRepo:     tagRepo,
 		taskTagRepo: taskTagRepo,
+		limits:      limits,
+		deletes:     deletes,
 	}
 }
 
@@ -267,12 +1217,80 @@ func (uc *TagUseCase) ListTags(ctx context.Context) ([]*entity.TaskTag, error) {
 	return uc.tagRepo.List(ctx)
 }
 
-// AddTaskTag adds a tag to a task
+// AddTaskTag adds a tag to a task. Re-adding a tag the task already has is
+// allowed even when the task is at its tag limit.
 func (uc *TagUseCase) AddTaskTag(ctx context.Context, taskID, tagID int64) error {
-	return uc.taskTagRepo.Add(ctx, taskID, tagID)
+	max := uc.limits.MaxTagsPerTask
+	err := uc.taskTagRepo.Add(ctx, taskID, tagID, max)
+	if errors.Is(err, repository.ErrLimitExceeded) {
+		return &LimitExceededError{Resource: "tags", Limit: max}
+	}
+	return err
 }
 
 // RemoveTaskTag removes a tag from a task
 func (uc *TagUseCase) RemoveTaskTag(ctx context.Context, taskID, tagID int64) error {
 	return uc.taskTagRepo.Remove(ctx, taskID, tagID)
 }
+
+// DeleteTag deletes a tag. Under DetachDeletedTags the tag is removed from
+// every task that has it; under RejectTagsInUse it fails with ErrTagInUse
+// while any task still has it.
+func (uc *TagUseCase) DeleteTag(ctx context.Context, id int64) error {
+	if uc.deletes == RejectTagsInUse {
+		return uc.tagRepo.DeleteUnused(ctx, id)
+	}
+	return uc.tagRepo.Delete(ctx, id)
+}
+
+// WatcherUseCase handles the users following a task. Watchers are named in
+// the task's update and delete events so they can be notified.
+type WatcherUseCase struct {
+	watcherRepo repository.WatcherRepository
+	taskRepo    repository.TaskRepository
+	users       repository.UserDirectory
+}
+
+// NewWatcherUseCase creates a new WatcherUseCase. Watchers are checked
+// against users unless it is nil.
+func NewWatcherUseCase(watcherRepo repository.WatcherRepository, taskRepo repository.TaskRepository, users repository.UserDirectory) *WatcherUseCase {
+	return &WatcherUseCase{
+		watcherRepo: watcherRepo,
+		taskRepo:    taskRepo,
+		users:       users,
+	}
+}
+
+// AddWatcher makes userID a watcher of the task. The user must exist and
+// have access to the task's project. Adding a watcher again does nothing.
+func (uc *WatcherUseCase) AddWatcher(ctx context.Context, taskID, userID int64) error {
+	task, err := uc.taskRepo.GetByID(ctx, taskID)
+	if err != nil {
+		return ErrTaskNotFound
+	}
+	check := AssigneeCheck{Users: uc.users, RequireAccess: true}
+	switch err := check.check(ctx, userID, task.ProjectID); err {
+	case nil:
+	case ErrUnknownAssignee:
+		return ErrUnknownWatcher
+	case ErrAssigneeNoAccess:
+		return ErrWatcherNoAccess
+	default:
+		return err
+	}
+	return uc.watcherRepo.Add(ctx, taskID, userID)
+}
+
+// RemoveWatcher stops userID watching the task; removing a user who isn't
+// watching does nothing
+func (uc *WatcherUseCase) RemoveWatcher(ctx context.Context, taskID, userID int64) error {
+	return uc.watcherRepo.Remove(ctx, taskID, userID)
+}
+
+// ListWatchers lists the task's watchers in the order they started watching
+func (uc *WatcherUseCase) ListWatchers(ctx context.Context, taskID int64) ([]*entity.TaskWatcher, error) {
+	if _, err := uc.taskRepo.GetByID(ctx, taskID); err != nil {
+		return nil, ErrTaskNotFound
+	}
+	return uc.watcherRepo.GetByTaskID(ctx, taskID)
+}
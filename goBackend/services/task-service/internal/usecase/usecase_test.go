@@ -0,0 +1,1427 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/portfolio/shared/pagination"
+	"github.com/portfolio/task-service/internal/domain/entity"
+	"github.com/portfolio/task-service/internal/domain/repository"
+)
+
+// MockTaskRepository is a manual mock
+type MockTaskRepository struct {
+	tasks      map[int64]*entity.Task
+	deletedIDs []int64
+	// subtasks, when set, lets CreateFromSubtask remove the promoted subtask
+	subtasks *MockSubtaskRepository
+	// taskTags, when set, backs the tag filter of List
+	taskTags  *MockTaskTagRepository
+	listCalls int
+	// gotTags is the tag filter of the last List call
+	gotTags repository.TagFilter
+}
+
+func NewMockTaskRepository(ids ...int64) *MockTaskRepository {
+	m := &MockTaskRepository{tasks: make(map[int64]*entity.Task)}
+	for _, id := range ids {
+		m.tasks[id] = &entity.Task{ID: id}
+	}
+	return m
+}
+
+func (m *MockTaskRepository) Create(ctx context.Context, task *entity.Task) error {
+	task.ID = int64(len(m.tasks) + 1)
+	m.tasks[task.ID] = task
+	return nil
+}
+
+func (m *MockTaskRepository) GetByID(ctx context.Context, id int64) (*entity.Task, error) {
+	if task, ok := m.tasks[id]; ok {
+		return task, nil
+	}
+	return nil, errors.New("task not found")
+}
+
+func (m *MockTaskRepository) GetByIDs(ctx context.Context, ids []int64) ([]*entity.Task, error) {
+	var found []*entity.Task
+	for _, id := range ids {
+		if task, ok := m.tasks[id]; ok {
+			found = append(found, task)
+		}
+	}
+	return found, nil
+}
+
+func (m *MockTaskRepository) CreateFromSubtask(ctx context.Context, task *entity.Task, subtaskID int64) error {
+	if m.subtasks != nil {
+		if _, ok := m.subtasks.subtasks[subtaskID]; !ok {
+			return sql.ErrNoRows
+		}
+		delete(m.subtasks.subtasks, subtaskID)
+	}
+	task.ID = int64(100 + len(m.tasks))
+	m.tasks[task.ID] = task
+	return nil
+}
+
+func (m *MockTaskRepository) ConvertToSubtask(ctx context.Context, taskID int64, subtask *entity.Subtask, max int) error {
+	if _, ok := m.tasks[taskID]; !ok {
+		return sql.ErrNoRows
+	}
+	if m.subtasks != nil && max > 0 {
+		count := 0
+		for _, s := range m.subtasks.subtasks {
+			if s.TaskID == subtask.TaskID || s.TaskID == taskID {
+				count++
+			}
+		}
+		if count+1 > max {
+			return repository.ErrLimitExceeded
+		}
+	}
+	delete(m.tasks, taskID)
+	if m.subtasks != nil {
+		return m.subtasks.Create(ctx, subtask)
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) Duplicate(ctx context.Context, sourceID int64, task *entity.Task, keepDueDates bool) error {
+	return m.Create(ctx, task)
+}
+
+func (m *MockTaskRepository) Move(ctx context.Context, taskID, projectID int64, clearAssignees bool) error {
+	task, ok := m.tasks[taskID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	task.ProjectID = projectID
+	if clearAssignees {
+		task.AssignedTo = nil
+	}
+	return nil
+}
+
+func (m *MockTaskRepository) DeleteMany(ctx context.Context, ids []int64) (int64, error) {
+	m.deletedIDs = ids
+	var deleted int64
+	for _, id := range ids {
+		if _, ok := m.tasks[id]; ok {
+			delete(m.tasks, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *MockTaskRepository) Update(ctx context.Context, task *entity.Task) error { return nil }
+func (m *MockTaskRepository) Delete(ctx context.Context, id int64) error          { return nil }
+func (m *MockTaskRepository) List(ctx context.Context, projectID int64, page, limit int, status string, assignedTo int64, tags repository.TagFilter) ([]*entity.Task, int, error) {
+	m.listCalls++
+	m.gotTags = tags
+	var tasks []*entity.Task
+	for _, t := range m.tasks {
+		if !m.hasTags(t.ID, tags) {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	return tasks, len(tasks), nil
+}
+
+func (m *MockTaskRepository) hasTags(taskID int64, tags repository.TagFilter) bool {
+	for _, id := range tags.IDs {
+		has := m.taskTags.has(taskID, id)
+		if tags.MatchAny && has {
+			return true
+		}
+		if !tags.MatchAny && !has {
+			return false
+		}
+	}
+	return len(tags.IDs) == 0 || !tags.MatchAny
+}
+
+func (m *MockTaskRepository) ListAfter(ctx context.Context, projectID, afterID int64, limit int, status string, assignedTo int64, tags repository.TagFilter) ([]*entity.Task, error) {
+	m.listCalls++
+	var tasks []*entity.Task
+	for _, t := range m.tasks {
+		if t.ID > afterID {
+			tasks = append(tasks, t)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool { return tasks[i].ID < tasks[j].ID })
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+	}
+	return tasks, nil
+}
+
+func (m *MockTaskRepository) ListVersions(ctx context.Context, projectID int64) ([]*entity.TaskVersion, error) {
+	var versions []*entity.TaskVersion
+	for _, task := range m.tasks {
+		if task.ProjectID == projectID {
+			versions = append(versions, &entity.TaskVersion{ID: task.ID, UpdatedAt: task.UpdatedAt})
+		}
+	}
+	return versions, nil
+}
+
+func (m *MockTaskRepository) CountByProject(ctx context.Context) ([]*entity.ProjectTaskCount, error) {
+	return nil, nil
+}
+
+func (m *MockTaskRepository) Summarize(ctx context.Context, projectID int64, now time.Time) (*entity.TaskSummary, error) {
+	return entity.NewTaskSummary(projectID), nil
+}
+
+// MockSubtaskRepository is a manual mock
+type MockSubtaskRepository struct {
+	subtasks map[int64]*entity.Subtask
+	// progressCalls records the task ids of each ProgressByTaskIDs call
+	progressCalls [][]int64
+}
+
+func NewMockSubtaskRepository(subtasks ...*entity.Subtask) *MockSubtaskRepository {
+	m := &MockSubtaskRepository{subtasks: make(map[int64]*entity.Subtask)}
+	for _, s := range subtasks {
+		m.subtasks[s.ID] = s
+	}
+	return m
+}
+
+func (m *MockSubtaskRepository) Create(ctx context.Context, subtask *entity.Subtask) error {
+	subtask.ID = int64(len(m.subtasks) + 1)
+	m.subtasks[subtask.ID] = subtask
+	return nil
+}
+
+func (m *MockSubtaskRepository) CreateMany(ctx context.Context, taskID int64, subtasks []*entity.Subtask, max int) error {
+	last, count := 0, 0
+	for _, s := range m.subtasks {
+		if s.TaskID != taskID {
+			continue
+		}
+		count++
+		if s.Position > last {
+			last = s.Position
+		}
+	}
+	if max > 0 && count+len(subtasks) > max {
+		return repository.ErrLimitExceeded
+	}
+	for i, s := range subtasks {
+		s.TaskID = taskID
+		s.Position = last + i + 1
+		if err := m.Create(ctx, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *MockSubtaskRepository) GetByID(ctx context.Context, id int64) (*entity.Subtask, error) {
+	if subtask, ok := m.subtasks[id]; ok {
+		copied := *subtask
+		return &copied, nil
+	}
+	return nil, errors.New("subtask not found")
+}
+
+func (m *MockSubtaskRepository) Update(ctx context.Context, subtask *entity.Subtask) error {
+	copied := *subtask
+	m.subtasks[subtask.ID] = &copied
+	return nil
+}
+
+func (m *MockSubtaskRepository) MoveToTask(ctx context.Context, subtask *entity.Subtask, taskID int64, max int) error {
+	stored, ok := m.subtasks[subtask.ID]
+	if !ok {
+		return sql.ErrNoRows
+	}
+	last, count := 0, 0
+	for _, s := range m.subtasks {
+		if s.TaskID != taskID {
+			continue
+		}
+		count++
+		if s.Position > last {
+			last = s.Position
+		}
+	}
+	if max > 0 && count+1 > max {
+		return repository.ErrLimitExceeded
+	}
+	stored.TaskID, stored.Position = taskID, last+1
+	subtask.TaskID, subtask.Position = taskID, last+1
+	return nil
+}
+
+func (m *MockSubtaskRepository) Delete(ctx context.Context, id int64) error {
+	delete(m.subtasks, id)
+	return nil
+}
+
+func (m *MockSubtaskRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.Subtask, int, error) {
+	return nil, 0, nil
+}
+
+func (m *MockSubtaskRepository) AssigneesByTaskID(ctx context.Context, taskID int64) ([]int64, error) {
+	var ids []int64
+	for _, s := range m.subtasks {
+		if s.TaskID == taskID && s.AssignedTo != nil {
+			ids = append(ids, *s.AssignedTo)
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockSubtaskRepository) ProgressByTaskIDs(ctx context.Context, taskIDs []int64) (map[int64]entity.SubtaskProgress, error) {
+	m.progressCalls = append(m.progressCalls, taskIDs)
+	progress := make(map[int64]entity.SubtaskProgress)
+	for _, id := range taskIDs {
+		for _, s := range m.subtasks {
+			if s.TaskID != id {
+				continue
+			}
+			p := progress[id]
+			p.Total++
+			if s.Status == entity.StatusDone {
+				p.Completed++
+			}
+			progress[id] = p
+		}
+	}
+	return progress, nil
+}
+
+// MockTaskTagRepository keeps tag ids per task
+type MockTaskTagRepository struct {
+	tags map[int64][]int64
+}
+
+func (m *MockTaskTagRepository) Add(ctx context.Context, taskID, tagID int64, max int) error {
+	for _, id := range m.tags[taskID] {
+		if id == tagID {
+			return nil
+		}
+	}
+	if max > 0 && len(m.tags[taskID]) >= max {
+		return repository.ErrLimitExceeded
+	}
+	m.tags[taskID] = append(m.tags[taskID], tagID)
+	return nil
+}
+
+func (m *MockTaskTagRepository) has(taskID, tagID int64) bool {
+	for _, id := range m.tags[taskID] {
+		if id == tagID {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockTaskTagRepository) Remove(ctx context.Context, taskID, tagID int64) error {
+	if !m.has(taskID, tagID) {
+		return repository.ErrTaskTagNotFound
+	}
+	kept := m.tags[taskID][:0]
+	for _, id := range m.tags[taskID] {
+		if id != tagID {
+			kept = append(kept, id)
+		}
+	}
+	m.tags[taskID] = kept
+	return nil
+}
+
+func (m *MockTaskTagRepository) GetByTaskID(ctx context.Context, taskID int64) ([]*entity.TaskTag, error) {
+	var tags []*entity.TaskTag
+	for _, id := range m.tags[taskID] {
+		tags = append(tags, &entity.TaskTag{ID: id})
+	}
+	return tags, nil
+}
+
+// MockTagRepository holds tags by id
+type MockTagRepository map[int64]string
+
+func (m MockTagRepository) Create(ctx context.Context, tag *entity.TaskTag) error { return nil }
+
+func (m MockTagRepository) GetByID(ctx context.Context, id int64) (*entity.TaskTag, error) {
+	if name, ok := m[id]; ok {
+		return &entity.TaskTag{ID: id, Name: name}, nil
+	}
+	return nil, errors.New("tag not found")
+}
+
+func (m MockTagRepository) GetByName(ctx context.Context, name string) (*entity.TaskTag, error) {
+	for id, n := range m {
+		if n == name {
+			return &entity.TaskTag{ID: id, Name: n}, nil
+		}
+	}
+	return nil, repository.ErrTagNotFound
+}
+
+func (m MockTagRepository) List(ctx context.Context) ([]*entity.TaskTag, error) { return nil, nil }
+
+func (m MockTagRepository) Delete(ctx context.Context, id int64) error {
+	if _, ok := m[id]; !ok {
+		return repository.ErrTagNotFound
+	}
+	delete(m, id)
+	return nil
+}
+
+func (m MockTagRepository) DeleteUnused(ctx context.Context, id int64) error {
+	return m.Delete(ctx, id)
+}
+
+// MockActivityRepository records activities in memory
+type MockActivityRepository struct {
+	activities []*entity.TaskActivity
+}
+
+func (m *MockActivityRepository) Record(ctx context.Context, activity *entity.TaskActivity) error {
+	m.activities = append(m.activities, activity)
+	return nil
+}
+
+// MockAttachmentRepository stores attachments in memory
+type MockAttachmentRepository struct {
+	attachments []*entity.TaskAttachment
+}
+
+func (m *MockAttachmentRepository) Create(ctx context.Context, attachment *entity.TaskAttachment) error {
+	attachment.ID = int64(len(m.attachments) + 1)
+	m.attachments = append(m.attachments, attachment)
+	return nil
+}
+
+func (m *MockAttachmentRepository) GetByID(ctx context.Context, id int64) (*entity.TaskAttachment, error) {
+	for _, a := range m.attachments {
+		if a.ID == id {
+			return a, nil
+		}
+	}
+	return nil, repository.ErrAttachmentNotFound
+}
+
+func (m *MockAttachmentRepository) Delete(ctx context.Context, id int64) error {
+	for i, a := range m.attachments {
+		if a.ID == id {
+			m.attachments = append(m.attachments[:i], m.attachments[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (m *MockAttachmentRepository) CountByMediaFileID(ctx context.Context, mediaFileID int64) (int, error) {
+	var count int
+	for _, a := range m.attachments {
+		if a.MediaFileID == mediaFileID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockAttachmentRepository) MediaFileIDsByTaskIDs(ctx context.Context, taskIDs []int64) ([]int64, error) {
+	var ids []int64
+	seen := make(map[int64]bool)
+	for _, a := range m.attachments {
+		for _, taskID := range taskIDs {
+			if a.TaskID == taskID && a.MediaFileID != 0 && !seen[a.MediaFileID] {
+				seen[a.MediaFileID] = true
+				ids = append(ids, a.MediaFileID)
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (m *MockAttachmentRepository) DeleteByTaskID(ctx context.Context, taskID int64) (int64, error) {
+	kept := m.attachments[:0]
+	for _, a := range m.attachments {
+		if a.TaskID != taskID {
+			kept = append(kept, a)
+		}
+	}
+	deleted := int64(len(m.attachments) - len(kept))
+	m.attachments = kept
+	return deleted, nil
+}
+
+func (m *MockAttachmentRepository) GetByTaskID(ctx context.Context, taskID int64, page, limit int) ([]*entity.TaskAttachment, int, error) {
+	return m.attachments, len(m.attachments), nil
+}
+
+// MockMediaFileResolver serves media files from a map
+type MockMediaFileResolver map[int64]*entity.MediaFile
+
+func (m MockMediaFileResolver) GetFile(ctx context.Context, id int64) (*entity.MediaFile, error) {
+	file, ok := m[id]
+	if !ok {
+		return nil, repository.ErrMediaFileNotFound
+	}
+	return file, nil
+}
+
+// DeleteFile removes the file from the map
+func (m MockMediaFileResolver) DeleteFile(ctx context.Context, id int64) error {
+	if _, ok := m[id]; !ok {
+		return repository.ErrMediaFileNotFound
+	}
+	delete(m, id)
+	return nil
+}
+
+// failingMediaFiles resolves files but can't delete them
+type failingMediaFiles struct{ MockMediaFileResolver }
+
+func (failingMediaFiles) DeleteFile(ctx context.Context, id int64) error {
+	return errors.New("media service unavailable")
+}
+
+// MockUserDirectory knows users by role and the projects each can access
+type MockUserDirectory struct {
+	roles  map[int64]string
+	access map[int64][]int64
+	calls  int
+}
+
+func (m *MockUserDirectory) UserRole(ctx context.Context, id int64) (string, error) {
+	m.calls++
+	role, ok := m.roles[id]
+	if !ok {
+		return "", repository.ErrUserNotFound
+	}
+	return role, nil
+}
+
+func (m *MockUserDirectory) HasProjectAccess(ctx context.Context, userID, projectID int64) (bool, error) {
+	for _, id := range m.access[userID] {
+		if id == projectID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestTaskUseCase_BulkDelete(t *testing.T) {
+	tests := []struct {
+		name        string
+		ids         []int64
+		wantDeleted int
+		wantIDs     []int64
+		wantErr     error
+	}{
+		{name: "Deletes existing", ids: []int64{1, 2}, wantDeleted: 2, wantIDs: []int64{1, 2}},
+		{name: "Skips duplicates and invalid", ids: []int64{1, 1, 0, -4, 3}, wantDeleted: 2, wantIDs: []int64{1, 3}},
+		{name: "Missing ids are not counted", ids: []int64{1, 99}, wantDeleted: 1, wantIDs: []int64{1, 99}},
+		{name: "Empty", ids: nil, wantErr: ErrNoTaskIDs},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockTaskRepository(1, 2, 3)
+			uc := NewTaskUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+			deleted, err := uc.BulkDelete(context.Background(), tt.ids, 0)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("BulkDelete() error = %v, want %v", err, tt.wantErr)
+			}
+			if deleted != tt.wantDeleted {
+				t.Errorf("BulkDelete() deleted = %d, want %d", deleted, tt.wantDeleted)
+			}
+			if len(repo.deletedIDs) != len(tt.wantIDs) {
+				t.Fatalf("repository got ids %v, want %v", repo.deletedIDs, tt.wantIDs)
+			}
+			for i := range tt.wantIDs {
+				if repo.deletedIDs[i] != tt.wantIDs[i] {
+					t.Errorf("repository got ids %v, want %v", repo.deletedIDs, tt.wantIDs)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskUseCase_ListTasks_Progress(t *testing.T) {
+	subtaskRepo := NewMockSubtaskRepository(
+		&entity.Subtask{ID: 1, TaskID: 1, Status: entity.StatusDone},
+		&entity.Subtask{ID: 2, TaskID: 1, Status: entity.StatusTodo},
+		&entity.Subtask{ID: 3, TaskID: 1, Status: entity.StatusInProgress},
+		&entity.Subtask{ID: 4, TaskID: 1, Status: entity.StatusDone},
+		&entity.Subtask{ID: 5, TaskID: 2, Status: entity.StatusDone},
+	)
+	uc := NewTaskUseCase(NewMockTaskRepository(1, 2, 3), subtaskRepo, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+	tasks, total, err := uc.ListTasks(context.Background(), 0, 1, 20, "", 0, TagQuery{})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if total != 3 || len(tasks) != 3 {
+		t.Fatalf("ListTasks() = %d tasks, total %d, want 3", len(tasks), total)
+	}
+
+	want := map[int64]float64{1: 0.5, 2: 1, 3: 0}
+	for _, task := range tasks {
+		if task.Progress != want[task.ID] {
+			t.Errorf("task %d progress = %v, want %v", task.ID, task.Progress, want[task.ID])
+		}
+	}
+
+	if len(subtaskRepo.progressCalls) != 1 {
+		t.Fatalf("ProgressByTaskIDs called %d times, want once per page", len(subtaskRepo.progressCalls))
+	}
+	if got := subtaskRepo.progressCalls[0]; len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Errorf("ProgressByTaskIDs ids = %v, want [1 2 3]", got)
+	}
+}
+
+func TestTaskUseCase_ListTasks_TagName(t *testing.T) {
+	taskTags := &MockTaskTagRepository{tags: map[int64][]int64{1: {1}, 2: {1, 2}, 3: {2}}}
+	taskRepo := NewMockTaskRepository(1, 2, 3, 4)
+	taskRepo.taskTags = taskTags
+	tags := MockTagRepository{1: "backend", 2: "urgent"}
+	uc := NewTaskUseCase(taskRepo, NewMockSubtaskRepository(), nil, nil, nil, tags, taskTags, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		tagID   int64
+		tagName string
+		want    []int64
+	}{
+		{"by name", 0, "backend", []int64{1, 2}},
+		{"name is trimmed", 0, " urgent ", []int64{2, 3}},
+		{"by id", 2, "", []int64{2, 3}},
+		{"name and matching id", 1, "backend", []int64{1, 2}},
+		{"no tag filter", 0, "", []int64{1, 2, 3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, total, err := uc.ListTasks(ctx, 0, 1, 20, "", 0, TagQuery{IDs: []int64{tt.tagID}, Name: tt.tagName})
+			if err != nil {
+				t.Fatalf("ListTasks() error = %v", err)
+			}
+			if total != len(tt.want) || len(tasks) != len(tt.want) {
+				t.Fatalf("ListTasks() = %d tasks, total %d, want %d", len(tasks), total, len(tt.want))
+			}
+			for i, task := range tasks {
+				if task.ID != tt.want[i] {
+					t.Errorf("tasks[%d] = %d, want %d", i, task.ID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTaskUseCase_ListTasks_UnknownTag(t *testing.T) {
+	taskTags := &MockTaskTagRepository{tags: map[int64][]int64{1: {1}}}
+	taskRepo := NewMockTaskRepository(1, 2)
+	taskRepo.taskTags = taskTags
+	uc := NewTaskUseCase(taskRepo, NewMockSubtaskRepository(), nil, nil, nil, MockTagRepository{1: "backend", 2: "urgent"}, taskTags, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+	tests := []struct {
+		name    string
+		tagID   int64
+		tagName string
+	}{
+		{"unknown name", 0, "frontend"},
+		{"unknown name with an id", 1, "frontend"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo.listCalls = 0
+			tasks, total, err := uc.ListTasks(context.Background(), 0, 1, 20, "", 0, TagQuery{IDs: []int64{tt.tagID}, Name: tt.tagName})
+			if err != nil {
+				t.Fatalf("ListTasks() error = %v", err)
+			}
+			if tasks == nil || len(tasks) != 0 || total != 0 {
+				t.Errorf("ListTasks() = %v, total %d, want an empty list", tasks, total)
+			}
+			if taskRepo.listCalls != 0 {
+				t.Errorf("task repository queried %d times, want none", taskRepo.listCalls)
+			}
+		})
+	}
+}
+
+func TestTaskUseCase_ListTasks_MultipleTags(t *testing.T) {
+	taskTags := &MockTaskTagRepository{tags: map[int64][]int64{1: {1}, 2: {1, 2}, 3: {2}}}
+	taskRepo := NewMockTaskRepository(1, 2, 3, 4)
+	taskRepo.taskTags = taskTags
+	uc := NewTaskUseCase(taskRepo, NewMockSubtaskRepository(), nil, nil, nil, MockTagRepository{1: "backend", 2: "urgent"}, taskTags, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+	tests := []struct {
+		name     string
+		query    TagQuery
+		want     []int64
+		wantTags []int64
+	}{
+		{"all tags", TagQuery{IDs: []int64{1, 2}}, []int64{2}, []int64{1, 2}},
+		{"any tag", TagQuery{IDs: []int64{1, 2}, MatchAny: true}, []int64{1, 2, 3}, []int64{1, 2}},
+		{"id and name of another tag", TagQuery{IDs: []int64{1}, Name: "urgent"}, []int64{2}, []int64{1, 2}},
+		{"duplicates and zero dropped", TagQuery{IDs: []int64{2, 0, 2}, Name: "urgent"}, []int64{2, 3}, []int64{2}},
+		{"unknown name ignored in any mode", TagQuery{IDs: []int64{1}, Name: "frontend", MatchAny: true}, []int64{1, 2}, []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, _, err := uc.ListTasks(context.Background(), 0, 1, 20, "", 0, tt.query)
+			if err != nil {
+				t.Fatalf("ListTasks() error = %v", err)
+			}
+			var got []int64
+			for _, task := range tasks {
+				got = append(got, task.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("tasks = %v, want %v", got, tt.want)
+			}
+			if fmt.Sprint(taskRepo.gotTags.IDs) != fmt.Sprint(tt.wantTags) || taskRepo.gotTags.MatchAny != tt.query.MatchAny {
+				t.Errorf("repository filter = %+v, want ids %v", taskRepo.gotTags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestTaskUseCase_ListTasks_EmptyPageSkipsProgress(t *testing.T) {
+	subtaskRepo := NewMockSubtaskRepository()
+	uc := NewTaskUseCase(NewMockTaskRepository(), subtaskRepo, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+	if _, _, err := uc.ListTasks(context.Background(), 0, 1, 20, "", 0, TagQuery{}); err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(subtaskRepo.progressCalls) != 0 {
+		t.Errorf("ProgressByTaskIDs called for an empty page")
+	}
+}
+
+func TestTaskUseCase_ListTaskVersions(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewMockTaskRepository()
+	repo.tasks[1] = &entity.Task{ID: 1, ProjectID: 10, Title: "A", UpdatedAt: updated}
+	repo.tasks[2] = &entity.Task{ID: 2, ProjectID: 20, Title: "B", UpdatedAt: updated}
+	uc := NewTaskUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+	versions, err := uc.ListTaskVersions(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("ListTaskVersions() error = %v", err)
+	}
+	if len(versions) != 1 || versions[0].ID != 1 || !versions[0].UpdatedAt.Equal(updated) {
+		t.Errorf("ListTaskVersions() = %+v, want task 1 only", versions)
+	}
+
+	if _, err := uc.ListTaskVersions(context.Background(), 0); !errors.Is(err, ErrProjectRequired) {
+		t.Errorf("ListTaskVersions(0) error = %v, want %v", err, ErrProjectRequired)
+	}
+}
+
+func TestSubtaskUseCase_CreateMany(t *testing.T) {
+	taskRepo := NewMockTaskRepository(1)
+	subtaskRepo := NewMockSubtaskRepository(&entity.Subtask{ID: 1, TaskID: 1, Title: "Existing", Position: 1})
+	uc := NewSubtaskUseCase(subtaskRepo, taskRepo, nil, Limits{}, AssigneeCheck{}, nil, pagination.Sizes{})
+
+	titles := []string{"Design", " Build ", "Ship"}
+	subtasks, err := uc.CreateMany(context.Background(), 1, titles)
+	if err != nil {
+		t.Fatalf("CreateMany() error = %v", err)
+	}
+	if len(subtasks) != len(titles) {
+		t.Fatalf("CreateMany() created %d subtasks, want %d", len(subtasks), len(titles))
+	}
+	for i, s := range subtasks {
+		if s.Position != i+2 {
+			t.Errorf("subtasks[%d].Position = %d, want %d", i, s.Position, i+2)
+		}
+		if s.TaskID != 1 || s.ID == 0 {
+			t.Errorf("subtasks[%d] = %+v, want a stored subtask of task 1", i, s)
+		}
+	}
+	if subtasks[1].Title != "Build" {
+		t.Errorf("title = %q, want trimmed %q", subtasks[1].Title, "Build")
+	}
+	if len(subtaskRepo.subtasks) != 4 {
+		t.Errorf("repository has %d subtasks, want 4", len(subtaskRepo.subtasks))
+	}
+}
+
+func TestSubtaskUseCase_CreateMany_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		taskID  int64
+		titles  []string
+		wantErr error
+	}{
+		{name: "No titles", taskID: 1, wantErr: ErrNoSubtaskTitles},
+		{name: "Blank title", taskID: 1, titles: []string{"A", "  "}, wantErr: ErrEmptySubtaskTitle},
+		{name: "Too many", taskID: 1, titles: make([]string, maxListLimit+1), wantErr: ErrTooManySubtasks},
+		{name: "Missing task", taskID: 99, titles: []string{"A"}, wantErr: ErrTaskNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subtaskRepo := NewMockSubtaskRepository()
+			uc := NewSubtaskUseCase(subtaskRepo, NewMockTaskRepository(1), nil, Limits{}, AssigneeCheck{}, nil, pagination.Sizes{})
+
+			if _, err := uc.CreateMany(context.Background(), tt.taskID, tt.titles); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateMany() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(subtaskRepo.subtasks) != 0 {
+				t.Errorf("repository has %d subtasks, want none", len(subtaskRepo.subtasks))
+			}
+		})
+	}
+}
+
+func TestSubtaskUseCase_MoveToTask(t *testing.T) {
+	tests := []struct {
+		name           string
+		subtaskID      int64
+		fromTaskID     int64
+		newTaskID      int64
+		wantErr        error
+		wantTaskID     int64
+		wantActivities int
+	}{
+		{name: "Valid move", subtaskID: 10, newTaskID: 2, wantTaskID: 2, wantActivities: 2},
+		{name: "Same task is a no-op", subtaskID: 10, newTaskID: 1, wantTaskID: 1},
+		{name: "Invalid destination", subtaskID: 10, newTaskID: 99, wantErr: ErrTaskNotFound, wantTaskID: 1},
+		{name: "Unknown subtask", subtaskID: 11, newTaskID: 2, wantErr: ErrSubtaskNotFound, wantTaskID: 1},
+		{name: "From its own task", subtaskID: 10, fromTaskID: 1, newTaskID: 2, wantTaskID: 2, wantActivities: 2},
+		{name: "From another task", subtaskID: 10, fromTaskID: 2, newTaskID: 2, wantErr: ErrSubtaskNotFound, wantTaskID: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subtaskRepo := NewMockSubtaskRepository(
+				&entity.Subtask{ID: 10, TaskID: 1, Title: "Write docs", Position: 1},
+				&entity.Subtask{ID: 20, TaskID: 2, Title: "Review", Position: 4},
+			)
+			activityRepo := &MockActivityRepository{}
+			uc := NewSubtaskUseCase(subtaskRepo, NewMockTaskRepository(1, 2), activityRepo, Limits{}, AssigneeCheck{}, nil, pagination.Sizes{})
+
+			_, err := uc.MoveToTask(context.Background(), tt.subtaskID, tt.fromTaskID, tt.newTaskID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MoveToTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := subtaskRepo.subtasks[10].TaskID; got != tt.wantTaskID {
+				t.Errorf("stored task_id = %d, want %d", got, tt.wantTaskID)
+			}
+			if got := subtaskRepo.subtasks[10].Position; tt.wantTaskID == 2 && got != 5 {
+				t.Errorf("position = %d, want 5 after the destination's subtasks", got)
+			}
+			if len(activityRepo.activities) != tt.wantActivities {
+				t.Errorf("recorded %d activities, want %d", len(activityRepo.activities), tt.wantActivities)
+			}
+			for _, a := range activityRepo.activities {
+				if a.Action != entity.ActionSubtaskMoved {
+					t.Errorf("activity action = %q, want %q", a.Action, entity.ActionSubtaskMoved)
+				}
+			}
+		})
+	}
+}
+
+func TestSubtaskUseCase_MoveToTask_Assignee(t *testing.T) {
+	assignee := int64(7)
+	users := &MockUserDirectory{roles: map[int64]string{7: "user"}, access: map[int64][]int64{7: {10}}}
+	tests := []struct {
+		name       string
+		newTaskID  int64
+		wantErr    error
+		wantTaskID int64
+	}{
+		{name: "Assignee has access", newTaskID: 2, wantTaskID: 2},
+		{name: "Assignee without access", newTaskID: 3, wantErr: ErrAssigneeNoAccess, wantTaskID: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subtaskRepo := NewMockSubtaskRepository(&entity.Subtask{ID: 10, TaskID: 1, AssignedTo: &assignee})
+			taskRepo := NewMockTaskRepository()
+			taskRepo.tasks[1] = &entity.Task{ID: 1, ProjectID: 10}
+			taskRepo.tasks[2] = &entity.Task{ID: 2, ProjectID: 10}
+			taskRepo.tasks[3] = &entity.Task{ID: 3, ProjectID: 20}
+			uc := NewSubtaskUseCase(subtaskRepo, taskRepo, &MockActivityRepository{}, Limits{}, AssigneeCheck{Users: users, RequireAccess: true}, nil, pagination.Sizes{})
+
+			if _, err := uc.MoveToTask(context.Background(), 10, 0, tt.newTaskID); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("MoveToTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := subtaskRepo.subtasks[10].TaskID; got != tt.wantTaskID {
+				t.Errorf("stored task_id = %d, want %d", got, tt.wantTaskID)
+			}
+		})
+	}
+}
+
+func TestSubtaskUseCase_Promote(t *testing.T) {
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	assignee := int64(7)
+	subtaskRepo := NewMockSubtaskRepository(&entity.Subtask{
+		ID: 10, TaskID: 1, Title: "Write docs", Status: entity.StatusInProgress, AssignedTo: &assignee, DueDate: &due,
+	})
+	taskRepo := NewMockTaskRepository()
+	taskRepo.tasks[1] = &entity.Task{ID: 1, ProjectID: 42}
+	taskRepo.subtasks = subtaskRepo
+	bus := &recordingBus{}
+	uc := NewSubtaskUseCase(subtaskRepo, taskRepo, &MockActivityRepository{}, Limits{}, AssigneeCheck{}, bus, pagination.Sizes{})
+
+	if _, err := uc.Promote(context.Background(), 10, 2); !errors.Is(err, ErrSubtaskNotFound) {
+		t.Fatalf("Promote() under another task error = %v, want %v", err, ErrSubtaskNotFound)
+	}
+	if _, ok := subtaskRepo.subtasks[10]; !ok {
+		t.Fatal("Promote() under another task removed the subtask")
+	}
+
+	task, err := uc.Promote(context.Background(), 10, 1)
+	if err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+	if task.ProjectID != 42 || task.Title != "Write docs" || task.Status != entity.StatusInProgress {
+		t.Errorf("Promote() task = %+v, want subtask fields in project 42", task)
+	}
+	if task.AssignedTo == nil || *task.AssignedTo != 7 || task.DueDate == nil || !task.DueDate.Equal(due) {
+		t.Errorf("Promote() did not carry over assignee and due date: %+v", task)
+	}
+	if _, ok := taskRepo.tasks[task.ID]; !ok {
+		t.Error("Promote() did not store the new task")
+	}
+	if _, ok := subtaskRepo.subtasks[10]; ok {
+		t.Error("Promote() did not remove the subtask")
+	}
+	if len(bus.events) != 1 || bus.events[0].Type != entity.TaskCreated || bus.events[0].ProjectID != 42 {
+		t.Errorf("Promote() published %+v, want one TaskCreated in project 42", bus.events)
+	}
+
+	if _, err := uc.Promote(context.Background(), 10, 0); !errors.Is(err, ErrSubtaskNotFound) {
+		t.Errorf("Promote() again error = %v, want %v", err, ErrSubtaskNotFound)
+	}
+}
+
+func TestTaskUseCase_Demote(t *testing.T) {
+	assignee := int64(7)
+	newRepos := func() (*MockTaskRepository, *MockSubtaskRepository) {
+		taskRepo := NewMockTaskRepository()
+		taskRepo.tasks[1] = &entity.Task{ID: 1, ProjectID: 42, Title: "Parent"}
+		taskRepo.tasks[2] = &entity.Task{ID: 2, ProjectID: 42, Title: "Small task", Status: entity.StatusDone, AssignedTo: &assignee}
+		taskRepo.tasks[3] = &entity.Task{ID: 3, ProjectID: 99, Title: "Elsewhere"}
+		taskRepo.subtasks = NewMockSubtaskRepository()
+		return taskRepo, taskRepo.subtasks
+	}
+
+	t.Run("Converts task", func(t *testing.T) {
+		taskRepo, subtaskRepo := newRepos()
+		uc := NewTaskUseCase(taskRepo, subtaskRepo, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+		subtask, err := uc.Demote(context.Background(), 2, 1, 0)
+		if err != nil {
+			t.Fatalf("Demote() error = %v", err)
+		}
+		if subtask.TaskID != 1 || subtask.Title != "Small task" || subtask.Status != entity.StatusDone || subtask.AssignedTo == nil || *subtask.AssignedTo != 7 {
+			t.Errorf("Demote() subtask = %+v, want task fields under parent 1", subtask)
+		}
+		if _, ok := subtaskRepo.subtasks[subtask.ID]; !ok {
+			t.Error("Demote() did not store the subtask")
+		}
+		if _, ok := taskRepo.tasks[2]; ok {
+			t.Error("Demote() did not delete the task")
+		}
+	})
+
+	t.Run("Limit counts the task's subtasks", func(t *testing.T) {
+		taskRepo, subtaskRepo := newRepos()
+		subtaskRepo.subtasks[1] = &entity.Subtask{ID: 1, TaskID: 1, Position: 1}
+		subtaskRepo.subtasks[2] = &entity.Subtask{ID: 2, TaskID: 2, Position: 1}
+		uc := NewTaskUseCase(taskRepo, subtaskRepo, nil, nil, nil, nil, nil, nil, nil, Limits{MaxSubtasksPerTask: 2}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+		var limitErr *LimitExceededError
+		if _, err := uc.Demote(context.Background(), 2, 1, 0); !errors.As(err, &limitErr) || limitErr.Limit != 2 {
+			t.Fatalf("Demote() error = %v, want LimitExceededError", err)
+		}
+		if _, ok := taskRepo.tasks[2]; !ok {
+			t.Error("Demote() deleted the task despite the limit")
+		}
+	})
+
+	guards := []struct {
+		name     string
+		taskID   int64
+		parentID int64
+		wantErr  error
+	}{
+		{name: "Self reference", taskID: 2, parentID: 2, wantErr: ErrInvalidParent},
+		{name: "Other project", taskID: 2, parentID: 3, wantErr: ErrInvalidParent},
+		{name: "Missing parent", taskID: 2, parentID: 50, wantErr: ErrTaskNotFound},
+		{name: "Missing task", taskID: 50, parentID: 1, wantErr: ErrTaskNotFound},
+	}
+	for _, tt := range guards {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo, subtaskRepo := newRepos()
+			uc := NewTaskUseCase(taskRepo, subtaskRepo, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+
+			if _, err := uc.Demote(context.Background(), tt.taskID, tt.parentID, 0); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Demote() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(taskRepo.tasks) != 3 || len(subtaskRepo.subtasks) != 0 {
+				t.Error("Demote() changed data on a rejected call")
+			}
+		})
+	}
+}
+
+func TestAttachmentUseCase_AddMediaAttachment(t *testing.T) {
+	attachmentRepo := &MockAttachmentRepository{}
+	uc := NewAttachmentUseCase(attachmentRepo, MockMediaFileResolver{7: {URL: "https://cdn.example.com/files/report.pdf", UploadedBy: 5}}, pagination.Sizes{})
+
+	attachment, err := uc.AddMediaAttachment(context.Background(), 1, 7, 5)
+	if err != nil {
+		t.Fatalf("AddMediaAttachment() error = %v", err)
+	}
+	if attachment.Type != entity.AttachmentTypeMedia || attachment.MediaFileID != 7 {
+		t.Errorf("attachment = %+v, want a media attachment for file 7", attachment)
+	}
+	if attachment.FileURL != "https://cdn.example.com/files/report.pdf" {
+		t.Errorf("FileURL = %q, want the URL resolved by the media service", attachment.FileURL)
+	}
+	if len(attachmentRepo.attachments) != 1 {
+		t.Errorf("stored %d attachments, want 1", len(attachmentRepo.attachments))
+	}
+
+	if _, err := uc.AddMediaAttachment(context.Background(), 1, 7, 6); !errors.Is(err, ErrMediaFileNotOwned) {
+		t.Errorf("AddMediaAttachment() by another user error = %v, want ErrMediaFileNotOwned", err)
+	}
+	if _, err := uc.AddMediaAttachment(context.Background(), 2, 7, 0); err != nil {
+		t.Errorf("AddMediaAttachment() without an uploader check error = %v", err)
+	}
+}
+
+func TestAttachmentUseCase_AddMediaAttachment_NotFound(t *testing.T) {
+	attachmentRepo := &MockAttachmentRepository{}
+	uc := NewAttachmentUseCase(attachmentRepo, MockMediaFileResolver{}, pagination.Sizes{})
+
+	if _, err := uc.AddMediaAttachment(context.Background(), 1, 99, 5); !errors.Is(err, ErrMediaFileNotFound) {
+		t.Fatalf("AddMediaAttachment() error = %v, want ErrMediaFileNotFound", err)
+	}
+	if len(attachmentRepo.attachments) != 0 {
+		t.Errorf("stored %d attachments, want none", len(attachmentRepo.attachments))
+	}
+}
+
+func TestAttachmentUseCase_DeleteAttachment_ReleasesMediaFile(t *testing.T) {
+	ctx := context.Background()
+	attachmentRepo := &MockAttachmentRepository{}
+	media := MockMediaFileResolver{
+		7: {URL: "https://cdn.example.com/report.pdf", UploadedBy: 5},
+		8: {URL: "https://cdn.example.com/logo.png", UploadedBy: 5},
+	}
+	uc := NewAttachmentUseCase(attachmentRepo, media, pagination.Sizes{})
+
+	report, _ := uc.AddMediaAttachment(ctx, 1, 7, 5)
+	shared, _ := uc.AddMediaAttachment(ctx, 1, 8, 5)
+	uc.AddMediaAttachment(ctx, 2, 8, 5)
+	link, _ := uc.AddAttachment(ctx, 1, "https://example.com/spec")
+
+	if err := uc.DeleteAttachment(ctx, report.ID, 5); err != nil {
+		t.Fatalf("DeleteAttachment() error = %v", err)
+	}
+	if _, ok := media[7]; ok {
+		t.Error("media file 7 was not deleted with its only attachment")
+	}
+
+	// File 8 is still attached to task 2
+	if err := uc.DeleteAttachment(ctx, shared.ID, 5); err != nil {
+		t.Fatalf("DeleteAttachment(shared) error = %v", err)
+	}
+	if _, ok := media[8]; !ok {
+		t.Error("media file 8 was deleted while another attachment references it")
+	}
+
+	if err := uc.DeleteAttachment(ctx, link.ID, 5); err != nil {
+		t.Fatalf("DeleteAttachment(link) error = %v", err)
+	}
+	if len(media) != 1 || len(attachmentRepo.attachments) != 1 {
+		t.Errorf("%d media files and %d attachments left, want 1 and 1", len(media), len(attachmentRepo.attachments))
+	}
+}
+
+func TestAttachmentUseCase_DeleteAttachment_KeepsOthersFiles(t *testing.T) {
+	ctx := context.Background()
+	attachmentRepo := &MockAttachmentRepository{}
+	media := MockMediaFileResolver{7: {URL: "https://cdn.example.com/report.pdf", UploadedBy: 5}}
+	uc := NewAttachmentUseCase(attachmentRepo, media, pagination.Sizes{})
+
+	// An admin attached user 5's file; deleting it as anyone else keeps the file
+	attachment, _ := uc.AddMediaAttachment(ctx, 1, 7, 0)
+	if err := uc.DeleteAttachment(ctx, attachment.ID, 6); err != nil {
+		t.Fatalf("DeleteAttachment() error = %v", err)
+	}
+	if len(attachmentRepo.attachments) != 0 {
+		t.Error("attachment was not deleted")
+	}
+	if _, ok := media[7]; !ok {
+		t.Error("media file 7 was deleted by a user who did not upload it")
+	}
+}
+
+func TestAttachmentUseCase_DeleteAttachment_MediaServiceDown(t *testing.T) {
+	ctx := context.Background()
+	attachmentRepo := &MockAttachmentRepository{}
+	uc := NewAttachmentUseCase(attachmentRepo, failingMediaFiles{MockMediaFileResolver{7: {URL: "https://cdn.example.com/report.pdf", UploadedBy: 5}}}, pagination.Sizes{})
+
+	attachment, _ := uc.AddMediaAttachment(ctx, 1, 7, 5)
+	if err := uc.DeleteAttachment(ctx, attachment.ID, 5); err != nil {
+		t.Fatalf("DeleteAttachment() error = %v, want the media failure only logged", err)
+	}
+	if len(attachmentRepo.attachments) != 0 {
+		t.Error("attachment was kept because the media service failed")
+	}
+	if err := uc.DeleteAttachment(ctx, attachment.ID, 5); err != nil {
+		t.Errorf("DeleteAttachment(already deleted) error = %v, want nil", err)
+	}
+}
+
+func TestAttachmentUseCase_AddAttachment(t *testing.T) {
+	attachmentRepo := &MockAttachmentRepository{}
+	uc := NewAttachmentUseCase(attachmentRepo, MockMediaFileResolver{}, pagination.Sizes{})
+
+	attachment, err := uc.AddAttachment(context.Background(), 1, " https://example.com/spec ")
+	if err != nil {
+		t.Fatalf("AddAttachment() error = %v", err)
+	}
+	if attachment.Type != entity.AttachmentTypeLink || attachment.MediaFileID != 0 || attachment.FileURL != "https://example.com/spec" {
+		t.Errorf("attachment = %+v, want a link attachment", attachment)
+	}
+
+	if _, err := uc.AddAttachment(context.Background(), 1, "  "); !errors.Is(err, ErrFileURLRequired) {
+		t.Errorf("AddAttachment() with blank URL error = %v, want ErrFileURLRequired", err)
+	}
+}
+
+func TestSubtaskUseCase_SubtaskLimit(t *testing.T) {
+	limits := Limits{MaxSubtasksPerTask: 3}
+	existing := func() *MockSubtaskRepository {
+		return NewMockSubtaskRepository(
+			&entity.Subtask{ID: 1, TaskID: 1, Position: 1},
+			&entity.Subtask{ID: 2, TaskID: 1, Position: 2},
+		)
+	}
+
+	t.Run("Create up to the limit", func(t *testing.T) {
+		uc := NewSubtaskUseCase(existing(), NewMockTaskRepository(1), nil, limits, AssigneeCheck{}, nil, pagination.Sizes{})
+		if _, err := uc.CreateSubtask(context.Background(), 1, "Third", 0, nil); err != nil {
+			t.Fatalf("CreateSubtask() at the limit error = %v", err)
+		}
+		_, err := uc.CreateSubtask(context.Background(), 1, "Fourth", 0, nil)
+		var limitErr *LimitExceededError
+		if !errors.As(err, &limitErr) || limitErr.Resource != "subtasks" || limitErr.Limit != 3 {
+			t.Fatalf("CreateSubtask() over the limit error = %v, want LimitExceededError", err)
+		}
+	})
+
+	t.Run("Bulk create counts every title", func(t *testing.T) {
+		subtaskRepo := existing()
+		uc := NewSubtaskUseCase(subtaskRepo, NewMockTaskRepository(1), nil, limits, AssigneeCheck{}, nil, pagination.Sizes{})
+		var limitErr *LimitExceededError
+		if _, err := uc.CreateMany(context.Background(), 1, []string{"A", "B"}); !errors.As(err, &limitErr) {
+			t.Fatalf("CreateMany() over the limit error = %v, want LimitExceededError", err)
+		}
+		if len(subtaskRepo.subtasks) != 2 {
+			t.Errorf("repository has %d subtasks, want the original 2", len(subtaskRepo.subtasks))
+		}
+		if _, err := uc.CreateMany(context.Background(), 1, []string{"A"}); err != nil {
+			t.Fatalf("CreateMany() at the limit error = %v", err)
+		}
+	})
+
+	t.Run("Move into a full task", func(t *testing.T) {
+		subtaskRepo := existing()
+		subtaskRepo.subtasks[3] = &entity.Subtask{ID: 3, TaskID: 1}
+		subtaskRepo.subtasks[4] = &entity.Subtask{ID: 4, TaskID: 2}
+		uc := NewSubtaskUseCase(subtaskRepo, NewMockTaskRepository(1, 2), &MockActivityRepository{}, limits, AssigneeCheck{}, nil, pagination.Sizes{})
+		var limitErr *LimitExceededError
+		if _, err := uc.MoveToTask(context.Background(), 4, 0, 1); !errors.As(err, &limitErr) {
+			t.Fatalf("MoveToTask() into a full task error = %v, want LimitExceededError", err)
+		}
+		if subtaskRepo.subtasks[4].TaskID != 2 {
+			t.Errorf("subtask moved despite the limit")
+		}
+	})
+
+	t.Run("Zero disables the limit", func(t *testing.T) {
+		uc := NewSubtaskUseCase(existing(), NewMockTaskRepository(1), nil, Limits{}, AssigneeCheck{}, nil, pagination.Sizes{})
+		if _, err := uc.CreateMany(context.Background(), 1, []string{"A", "B", "C"}); err != nil {
+			t.Fatalf("CreateMany() without a limit error = %v", err)
+		}
+	})
+}
+
+func TestTagUseCase_TagLimit(t *testing.T) {
+	taskTagRepo := &MockTaskTagRepository{tags: map[int64][]int64{1: {10}}}
+	uc := NewTagUseCase(nil, taskTagRepo, Limits{MaxTagsPerTask: 2}, DetachDeletedTags)
+	ctx := context.Background()
+
+	if err := uc.AddTaskTag(ctx, 1, 11); err != nil {
+		t.Fatalf("AddTaskTag() at the limit error = %v", err)
+	}
+	var limitErr *LimitExceededError
+	if err := uc.AddTaskTag(ctx, 1, 12); !errors.As(err, &limitErr) || limitErr.Resource != "tags" {
+		t.Fatalf("AddTaskTag() over the limit error = %v, want LimitExceededError", err)
+	}
+	if err := uc.AddTaskTag(ctx, 1, 10); err != nil {
+		t.Errorf("AddTaskTag() for a tag the task already has error = %v", err)
+	}
+	if len(taskTagRepo.tags[1]) != 2 {
+		t.Errorf("task has %d tags, want 2", len(taskTagRepo.tags[1]))
+	}
+}
+
+func TestDueDatePolicy_Check(t *testing.T) {
+	now := time.Date(2024, 5, 10, 15, 30, 0, 0, time.UTC)
+	at := func(tm time.Time) *time.Time { return &tm }
+
+	tests := []struct {
+		name    string
+		policy  DueDatePolicy
+		dueDate *time.Time
+		wantErr error
+	}{
+		{"allow keeps past dates", AllowPastDueDates, at(now.AddDate(0, 0, -30)), nil},
+		{"reject past date", RejectPastDueDates, at(now.AddDate(0, 0, -1)), ErrDueDateInPast},
+		{"reject accepts earlier today", RejectPastDueDates, at(now.Add(-10 * time.Hour)), nil},
+		{"reject accepts future date", RejectPastDueDates, at(now.AddDate(0, 1, 0)), nil},
+		{"reject accepts no due date", RejectPastDueDates, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.check(tt.dueDate, now); err != tt.wantErr {
+				t.Errorf("check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTaskUseCase_RejectPastDueDates(t *testing.T) {
+	repo := NewMockTaskRepository(1)
+	uc := NewTaskUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, Limits{}, RejectPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+	ctx := context.Background()
+	past := time.Now().AddDate(0, 0, -2)
+
+	if _, err := uc.CreateTask(ctx, 1, "Late", "", "", 1, 0, &past); err != ErrDueDateInPast {
+		t.Errorf("CreateTask() error = %v, want %v", err, ErrDueDateInPast)
+	}
+	if _, err := uc.UpdateTask(ctx, 1, "", "", "", 0, 0, &past, TaskClears{}); err != ErrDueDateInPast {
+		t.Errorf("UpdateTask() error = %v, want %v", err, ErrDueDateInPast)
+	}
+	if len(repo.tasks) != 1 || repo.tasks[1].DueDate != nil {
+		t.Error("a rejected due date was stored")
+	}
+
+	if _, err := uc.CreateTask(ctx, 1, "Undated", "", "", 1, 0, nil); err != nil {
+		t.Errorf("CreateTask() without due date error = %v", err)
+	}
+}
+
+func TestTaskUseCase_Priority(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name     string
+		priority int
+		want     int
+		wantErr  error
+	}{
+		{"zero uses the default", 0, entity.DefaultPriority, nil},
+		{"high", entity.PriorityHigh, 1, nil},
+		{"medium", entity.PriorityMedium, 2, nil},
+		{"none", entity.PriorityNone, 4, nil},
+		{"above none", 5, 0, ErrInvalidPriority},
+		{"far out of range", 999, 0, ErrInvalidPriority},
+		{"negative", -1, 0, ErrInvalidPriority},
+	}
+
+	for _, tt := range tests {
+		t.Run("create "+tt.name, func(t *testing.T) {
+			repo := NewMockTaskRepository()
+			uc := NewTaskUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+			task, err := uc.CreateTask(ctx, 1, "Task", "", "", tt.priority, 0, nil)
+			if err != tt.wantErr {
+				t.Fatalf("CreateTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if len(repo.tasks) != 0 {
+					t.Errorf("task was stored despite the invalid priority")
+				}
+				return
+			}
+			if task.Priority != tt.want {
+				t.Errorf("priority = %d, want %d", task.Priority, tt.want)
+			}
+		})
+
+		t.Run("update "+tt.name, func(t *testing.T) {
+			repo := NewMockTaskRepository(1)
+			repo.tasks[1].Priority = entity.PriorityMedium
+			taskTags := &MockTaskTagRepository{tags: map[int64][]int64{}}
+			uc := NewTaskUseCase(repo, NewMockSubtaskRepository(), nil, nil, nil, nil, taskTags, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{})
+			task, err := uc.UpdateTask(ctx, 1, "", "", "", tt.priority, 0, nil, TaskClears{})
+			if err != tt.wantErr {
+				t.Fatalf("UpdateTask() error = %v, want %v", err, tt.wantErr)
+			}
+			want := tt.want
+			if tt.priority == 0 || err != nil {
+				want = entity.PriorityMedium // zero leaves the priority unchanged
+				task = repo.tasks[1]
+			}
+			if task.Priority != want {
+				t.Errorf("priority = %d, want %d", task.Priority, want)
+			}
+		})
+	}
+}
+
+func TestAssigneeCheck(t *testing.T) {
+	ctx := context.Background()
+	newUsers := func() *MockUserDirectory {
+		return &MockUserDirectory{
+			roles:  map[int64]string{5: "user", 6: "user", 9: "admin"},
+			access: map[int64][]int64{5: {1}},
+		}
+	}
+	tests := []struct {
+		name          string
+		assignedTo    int64
+		requireAccess bool
+		wantErr       error
+	}{
+		{"existing user", 6, false, nil},
+		{"nonexistent user", 42, false, ErrUnknownAssignee},
+		{"unassigned", 0, true, nil},
+		{"user with access", 5, true, nil},
+		{"user without access", 6, true, ErrAssigneeNoAccess},
+		{"admin without explicit access", 9, true, nil},
+	}
+
+	for _, tt := range tests {
+		check := func(users *MockUserDirectory) AssigneeCheck {
+			return AssigneeCheck{Users: users, RequireAccess: tt.requireAccess}
+		}
+
+		t.Run("CreateTask "+tt.name, func(t *testing.T) {
+			repo := NewMockTaskRepository()
+			uc := NewTaskUseCase(repo, nil, nil, nil, nil, nil, nil, nil, nil, Limits{}, AllowPastDueDates, check(newUsers()), nil, pagination.Sizes{})
+			if _, err := uc.CreateTask(ctx, 1, "Task", "", "", 0, tt.assignedTo, nil); err != tt.wantErr {
+				t.Fatalf("CreateTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && len(repo.tasks) != 0 {
+				t.Error("task was stored despite the rejected assignee")
+			}
+		})
+
+		t.Run("UpdateTask "+tt.name, func(t *testing.T) {
+			repo := NewMockTaskRepository(1)
+			repo.tasks[1].ProjectID = 1
+			taskTags := &MockTaskTagRepository{tags: map[int64][]int64{}}
+			uc := NewTaskUseCase(repo, NewMockSubtaskRepository(), nil, nil, nil, nil, taskTags, nil, nil, Limits{}, AllowPastDueDates, check(newUsers()), nil, pagination.Sizes{})
+			if _, err := uc.UpdateTask(ctx, 1, "", "", "", 0, tt.assignedTo, nil, TaskClears{}); err != tt.wantErr {
+				t.Fatalf("UpdateTask() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && repo.tasks[1].AssignedTo != nil {
+				t.Error("rejected assignee was stored")
+			}
+		})
+
+		t.Run("CreateSubtask "+tt.name, func(t *testing.T) {
+			taskRepo := NewMockTaskRepository(1)
+			taskRepo.tasks[1].ProjectID = 1
+			subtaskRepo := NewMockSubtaskRepository()
+			uc := NewSubtaskUseCase(subtaskRepo, taskRepo, nil, Limits{}, check(newUsers()), nil, pagination.Sizes{})
+			if _, err := uc.CreateSubtask(ctx, 1, "Subtask", tt.assignedTo, nil); err != tt.wantErr {
+				t.Fatalf("CreateSubtask() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil && len(subtaskRepo.subtasks) != 0 {
+				t.Error("subtask was stored despite the rejected assignee")
+			}
+		})
+	}
+
+	t.Run("keeping the current assignee skips the lookup", func(t *testing.T) {
+		repo := NewMockTaskRepository(1)
+		current := int64(42)
+		repo.tasks[1].AssignedTo = &current
+		users := newUsers()
+		taskTags := &MockTaskTagRepository{tags: map[int64][]int64{}}
+		uc := NewTaskUseCase(repo, NewMockSubtaskRepository(), nil, nil, nil, nil, taskTags, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{Users: users}, nil, pagination.Sizes{})
+		if _, err := uc.UpdateTask(ctx, 1, "Renamed", "", "", 0, 42, nil, TaskClears{}); err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+		if users.calls != 0 {
+			t.Errorf("auth service was asked %d times, want 0", users.calls)
+		}
+	})
+}
+
+func TestTaskUseCase_UpdateTask_Clears(t *testing.T) {
+	newUseCase := func() (*TaskUseCase, *entity.Task) {
+		assignee := int64(5)
+		due := time.Now().AddDate(0, 0, 7)
+		repo := NewMockTaskRepository(1)
+		repo.tasks[1] = &entity.Task{ID: 1, Title: "Write docs", Description: "Draft", AssignedTo: &assignee, DueDate: &due}
+		taskTags := &MockTaskTagRepository{tags: map[int64][]int64{}}
+		return NewTaskUseCase(repo, NewMockSubtaskRepository(), nil, nil, nil, nil, taskTags, nil, nil, Limits{}, AllowPastDueDates, AssigneeCheck{}, nil, pagination.Sizes{}), repo.tasks[1]
+	}
+	ctx := context.Background()
+
+	t.Run("omitted fields are kept", func(t *testing.T) {
+		uc, task := newUseCase()
+		if _, err := uc.UpdateTask(ctx, 1, "Write the docs", "", "", 0, 0, nil, TaskClears{}); err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+		if task.Title != "Write the docs" || task.Description != "Draft" || task.AssignedTo == nil || task.DueDate == nil {
+			t.Errorf("task = %+v, want a new title and the other fields kept", task)
+		}
+	})
+
+	t.Run("cleared fields are unset", func(t *testing.T) {
+		uc, task := newUseCase()
+		if _, err := uc.UpdateTask(ctx, 1, "", "", "", 0, 0, nil, TaskClears{AssignedTo: true, DueDate: true, Description: true}); err != nil {
+			t.Fatalf("UpdateTask() error = %v", err)
+		}
+		if task.AssignedTo != nil || task.DueDate != nil || task.Description != "" {
+			t.Errorf("assignee = %v, due date = %v, description = %q, want all cleared", task.AssignedTo, task.DueDate, task.Description)
+		}
+		if task.Title != "Write docs" {
+			t.Errorf("title = %q, want it unchanged", task.Title)
+		}
+	})
+
+	t.Run("set and clear together", func(t *testing.T) {
+		uc, task := newUseCase()
+		due := time.Now().AddDate(0, 1, 0)
+		if _, err := uc.UpdateTask(ctx, 1, "", "", "", 0, 9, nil, TaskClears{AssignedTo: true}); err != ErrConflictingUpdate {
+			t.Errorf("UpdateTask() assignee error = %v, want %v", err, ErrConflictingUpdate)
+		}
+		if _, err := uc.UpdateTask(ctx, 1, "", "", "", 0, 0, &due, TaskClears{DueDate: true}); err != ErrConflictingUpdate {
+			t.Errorf("UpdateTask() due date error = %v, want %v", err, ErrConflictingUpdate)
+		}
+		if _, err := uc.UpdateTask(ctx, 1, "", "New", "", 0, 0, nil, TaskClears{Description: true}); err != ErrConflictingUpdate {
+			t.Errorf("UpdateTask() description error = %v, want %v", err, ErrConflictingUpdate)
+		}
+		if *task.AssignedTo != 5 || task.DueDate.Equal(due) {
+			t.Error("a conflicting update changed the task")
+		}
+	})
+}
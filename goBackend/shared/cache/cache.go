@@ -0,0 +1,67 @@
+// Package cache provides the caches services keep in front of hot reads:
+// Redis when it is configured and reachable, otherwise an in-memory cache.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// Cache keeps encoded values for a limited time. Implementations treat their
+// own failures as misses rather than failing the read they sit in front of.
+// Delete is the exception: a failed invalidation would leave stale values
+// served until they expire, so it is reported for the write to fail with it.
+type Cache interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration)
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Config selects a service's cache
+type Config struct {
+	// RedisURL is a redis:// or rediss:// URL; empty uses the in-memory cache
+	RedisURL string
+	// Namespace prefixes every key, so services sharing a Redis don't collide
+	Namespace string
+}
+
+// New returns a Redis cache for cfg.RedisURL, or an in-memory cache when
+// no URL is set or Redis can't be reached, so a Redis outage at startup
+// costs the shared cache rather than the service
+func New(ctx context.Context, cfg Config) Cache {
+	if cfg.RedisURL == "" {
+		return NewMemory()
+	}
+	redisCache, err := NewRedis(ctx, cfg.RedisURL, cfg.Namespace)
+	if err != nil {
+		slog.WarnContext(ctx, "redis unavailable, falling back to the in-memory cache", "error", err)
+		return NewMemory()
+	}
+	return redisCache
+}
+
+// GetJSON decodes the value cached under key into v, reporting whether it
+// was there. A value that doesn't decode counts as a miss.
+func GetJSON(ctx context.Context, c Cache, key string, v interface{}) bool {
+	data, ok := c.Get(ctx, key)
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		slog.WarnContext(ctx, "discarding undecodable cache entry", "key", key, "error", err)
+		return false
+	}
+	return true
+}
+
+// SetJSON caches v encoded as JSON under key for ttl
+func SetJSON(ctx context.Context, c Cache, key string, v interface{}, ttl time.Duration) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to encode cache entry", "key", key, "error", err)
+		return
+	}
+	c.Set(ctx, key, data, ttl)
+}
@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMemoryEntries is how many entries NewMemory keeps
+const DefaultMemoryEntries = 10000
+
+// Memory is an in-process Cache holding at most a fixed number of entries.
+// Expired entries are dropped when they are next read; once the cache is
+// full, storing a new entry evicts the least recently used one, so keys that
+// are written once and never read again can't grow it without bound.
+type Memory struct {
+	mu         sync.Mutex
+	entries    map[string]*list.Element
+	recent     *list.List // of *memoryEntry, most recently used first
+	maxEntries int
+	now        func() time.Time
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewMemory creates an empty Memory cache holding up to DefaultMemoryEntries
+func NewMemory() *Memory {
+	return NewMemorySize(DefaultMemoryEntries)
+}
+
+// NewMemorySize creates an empty Memory cache holding up to maxEntries
+func NewMemorySize(maxEntries int) *Memory {
+	if maxEntries < 1 {
+		maxEntries = 1
+	}
+	return &Memory{
+		entries:    make(map[string]*list.Element),
+		recent:     list.New(),
+		maxEntries: maxEntries,
+		now:        time.Now,
+	}
+}
+
+var _ Cache = (*Memory)(nil)
+
+// Get returns the value stored under key unless it has expired
+func (m *Memory) Get(ctx context.Context, key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*memoryEntry)
+	if !m.now().Before(entry.expiresAt) {
+		m.remove(elem)
+		return nil, false
+	}
+	m.recent.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set stores value under key for ttl
+func (m *Memory) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	expiresAt := m.now().Add(ttl)
+	if elem, ok := m.entries[key]; ok {
+		entry := elem.Value.(*memoryEntry)
+		entry.value, entry.expiresAt = value, expiresAt
+		m.recent.MoveToFront(elem)
+		return
+	}
+	for m.recent.Len() >= m.maxEntries {
+		m.remove(m.recent.Back())
+	}
+	m.entries[key] = m.recent.PushFront(&memoryEntry{key: key, value: value, expiresAt: expiresAt})
+}
+
+// Delete removes the given keys; it never fails
+func (m *Memory) Delete(ctx context.Context, keys ...string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, key := range keys {
+		if elem, ok := m.entries[key]; ok {
+			m.remove(elem)
+		}
+	}
+	return nil
+}
+
+func (m *Memory) remove(elem *list.Element) {
+	m.recent.Remove(elem)
+	delete(m.entries, elem.Value.(*memoryEntry).key)
+}
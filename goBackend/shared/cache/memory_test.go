@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory_Expires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	m := NewMemory()
+	m.now = func() time.Time { return now }
+
+	m.Set(ctx, "a", []byte("1"), time.Minute)
+	m.Set(ctx, "b", []byte("2"), time.Hour)
+	if v, ok := m.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Errorf("Get(a) = %q, %v; want 1", v, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := m.Get(ctx, "a"); ok {
+		t.Error("Get(a) hit after its TTL")
+	}
+	if _, ok := m.Get(ctx, "b"); !ok {
+		t.Error("Get(b) missed within its TTL")
+	}
+
+	m.Delete(ctx, "b", "unknown")
+	if _, ok := m.Get(ctx, "b"); ok {
+		t.Error("Get(b) hit after Delete")
+	}
+}
+
+func TestMemory_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	m := NewMemorySize(2)
+
+	m.Set(ctx, "a", []byte("1"), time.Hour)
+	m.Set(ctx, "b", []byte("2"), time.Hour)
+	m.Get(ctx, "a") // b is now the least recently used
+	m.Set(ctx, "c", []byte("3"), time.Hour)
+
+	if _, ok := m.Get(ctx, "b"); ok {
+		t.Error("Get(b) hit after it was evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := m.Get(ctx, key); !ok {
+			t.Errorf("Get(%s) missed, want it kept", key)
+		}
+	}
+
+	// Overwriting a key doesn't take another slot
+	m.Set(ctx, "c", []byte("4"), time.Hour)
+	if v, ok := m.Get(ctx, "c"); !ok || string(v) != "4" {
+		t.Errorf("Get(c) = %q, %v; want 4", v, ok)
+	}
+	if _, ok := m.Get(ctx, "a"); !ok {
+		t.Error("Get(a) missed after overwriting c")
+	}
+	if len(m.entries) != 2 || m.recent.Len() != 2 {
+		t.Errorf("holding %d entries (%d in the list), want 2", len(m.entries), m.recent.Len())
+	}
+}
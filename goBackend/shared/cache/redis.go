@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisPingTimeout bounds the startup check that Redis is reachable
+const redisPingTimeout = 2 * time.Second
+
+// redisOpTimeout bounds each cache operation. A cache that is slower than
+// the database it sits in front of is no use, so a slow or unreachable Redis
+// costs a request this much before it falls through as a miss.
+const redisOpTimeout = 100 * time.Millisecond
+
+// Redis is a Cache shared by every replica of a service. Its keys are
+// prefixed with the service's namespace. When Redis fails, reads miss and
+// writes are dropped, so callers fall through to the database; failed
+// deletes are returned.
+type Redis struct {
+	client    *redis.Client
+	namespace string
+}
+
+var _ Cache = (*Redis)(nil)
+
+// NewRedis connects to the Redis at url and checks that it answers
+func NewRedis(ctx context.Context, url, namespace string) (*Redis, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis url: %w", err)
+	}
+	// Fail fast rather than retrying: every operation has a fallback
+	opts.DialTimeout = redisOpTimeout
+	opts.ReadTimeout = redisOpTimeout
+	opts.WriteTimeout = redisOpTimeout
+	opts.PoolTimeout = redisOpTimeout
+	opts.MaxRetries = -1 // go-redis takes 0 as the default of 3 retries
+	client := redis.NewClient(opts)
+
+	ctx, cancel := context.WithTimeout(ctx, redisPingTimeout)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to reach redis: %w", err)
+	}
+	return &Redis{client: client, namespace: namespace}, nil
+}
+
+func (r *Redis) key(key string) string {
+	if r.namespace == "" {
+		return key
+	}
+	return r.namespace + ":" + key
+}
+
+// Get returns the value stored under key
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool) {
+	value, err := r.client.Get(ctx, r.key(key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			slog.WarnContext(ctx, "redis get failed", "key", key, "error", err)
+		}
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores value under key for ttl
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) {
+	if err := r.client.Set(ctx, r.key(key), value, ttl).Err(); err != nil {
+		slog.WarnContext(ctx, "redis set failed", "key", key, "error", err)
+	}
+}
+
+// Delete removes the given keys
+func (r *Redis) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	namespaced := make([]string, len(keys))
+	for i, key := range keys {
+		namespaced[i] = r.key(key)
+	}
+	if err := r.client.Del(ctx, namespaced...).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate cache: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connection pool
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
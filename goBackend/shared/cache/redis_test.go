@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *Redis) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	r, err := NewRedis(context.Background(), "redis://"+mr.Addr(), "analytics")
+	if err != nil {
+		t.Fatalf("NewRedis() error = %v", err)
+	}
+	t.Cleanup(func() { r.Close() })
+	return mr, r
+}
+
+func TestRedis_SetGetExpire(t *testing.T) {
+	ctx := context.Background()
+	mr, r := newTestRedis(t)
+
+	type stats struct {
+		ProjectID  int64 `json:"project_id"`
+		TotalTasks int   `json:"total_tasks"`
+	}
+	SetJSON(ctx, r, "project_stats:1", stats{ProjectID: 1, TotalTasks: 4}, time.Minute)
+
+	// Keys are namespaced per service and values stored as JSON
+	if got, err := mr.Get("analytics:project_stats:1"); err != nil || got != `{"project_id":1,"total_tasks":4}` {
+		t.Errorf("stored %q, %v; want namespaced JSON", got, err)
+	}
+	var got stats
+	if !GetJSON(ctx, r, "project_stats:1", &got) || got != (stats{ProjectID: 1, TotalTasks: 4}) {
+		t.Errorf("GetJSON() = %+v, want the stored stats", got)
+	}
+
+	mr.FastForward(time.Minute)
+	if _, ok := r.Get(ctx, "project_stats:1"); ok {
+		t.Error("Get() hit after the TTL")
+	}
+
+	r.Set(ctx, "a", []byte("1"), time.Minute)
+	r.Set(ctx, "b", []byte("2"), time.Minute)
+	r.Delete(ctx, "a", "b")
+	if _, ok := r.Get(ctx, "a"); ok {
+		t.Error("Get(a) hit after Delete")
+	}
+	if mr.Exists("analytics:b") {
+		t.Error("b still stored after Delete")
+	}
+}
+
+func TestRedis_Outage(t *testing.T) {
+	ctx := context.Background()
+	mr, r := newTestRedis(t)
+	r.Set(ctx, "a", []byte("1"), time.Minute)
+
+	// Once Redis goes away reads miss and writes are dropped instead of failing
+	mr.Close()
+	if _, ok := r.Get(ctx, "a"); ok {
+		t.Error("Get() hit with Redis down")
+	}
+	r.Set(ctx, "a", []byte("2"), time.Minute)
+
+	// but a failed invalidation is reported, since the value may live on
+	if err := r.Delete(ctx, "a"); err == nil {
+		t.Error("Delete() with Redis down returned nil, want the error")
+	}
+}
+
+func TestNew_FallsBackToMemory(t *testing.T) {
+	ctx := context.Background()
+
+	if _, ok := New(ctx, Config{}).(*Memory); !ok {
+		t.Error("New() without a URL is not the in-memory cache")
+	}
+
+	mr := miniredis.RunT(t)
+	c := New(ctx, Config{RedisURL: "redis://" + mr.Addr(), Namespace: "analytics"})
+	if r, ok := c.(*Redis); !ok {
+		t.Errorf("New() = %T, want Redis while it is reachable", c)
+	} else {
+		r.Close()
+	}
+
+	addr := mr.Addr()
+	mr.Close()
+	c = New(ctx, Config{RedisURL: "redis://" + addr, Namespace: "analytics"})
+	if _, ok := c.(*Memory); !ok {
+		t.Fatalf("New() = %T, want the in-memory cache with Redis down", c)
+	}
+	c.Set(ctx, "a", []byte("1"), time.Minute)
+	if v, ok := c.Get(ctx, "a"); !ok || string(v) != "1" {
+		t.Errorf("fallback Get() = %q, %v; want 1", v, ok)
+	}
+
+	if _, ok := New(ctx, Config{RedisURL: "not a url"}).(*Memory); !ok {
+		t.Error("New() with an invalid URL is not the in-memory cache")
+	}
+}
@@ -1,9 +1,13 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -18,25 +22,141 @@ type Config struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// TLS files for managed Postgres; empty paths are left out. SSLRootCert
+	// verifies the server (with sslmode=verify-ca or verify-full), SSLCert
+	// and SSLKey authenticate the client.
+	SSLRootCert string
+	SSLCert     string
+	SSLKey      string
+
+	// Connection pool tuning; zero values fall back to the defaults below
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	ConnMaxIdleTime time.Duration
+
+	// Retry applies to Transaction; the zero value disables retries
+	Retry RetryConfig
+}
+
+// Default connection pool settings
+const (
+	DefaultMaxOpenConns    = 25
+	DefaultMaxIdleConns    = 5
+	DefaultConnMaxLifetime = 5 * time.Minute
+)
+
+// ErrInvalidPoolConfig is returned when MaxIdleConns exceeds MaxOpenConns
+var ErrInvalidPoolConfig = errors.New("max idle connections must not exceed max open connections")
+
+// withPoolDefaults fills in unset pool settings and validates them
+func (c Config) withPoolDefaults() (Config, error) {
+	if c.MaxOpenConns == 0 {
+		c.MaxOpenConns = DefaultMaxOpenConns
+	}
+	if c.MaxIdleConns == 0 {
+		c.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if c.ConnMaxLifetime == 0 {
+		c.ConnMaxLifetime = DefaultConnMaxLifetime
+	}
+	if c.MaxIdleConns > c.MaxOpenConns {
+		return c, fmt.Errorf("%w: %d > %d", ErrInvalidPoolConfig, c.MaxIdleConns, c.MaxOpenConns)
+	}
+	return c, nil
+}
+
+// connString builds the lib/pq connection string for cfg, checking that
+// any TLS files it names exist
+func (c Config) connString() (string, error) {
+	params := []string{
+		connParam("host", c.Host),
+		connParam("port", fmt.Sprint(c.Port)),
+		connParam("user", c.User),
+		connParam("password", c.Password),
+		connParam("dbname", c.DBName),
+		connParam("sslmode", c.SSLMode),
+	}
+	for _, file := range []struct{ key, path string }{
+		{"sslrootcert", c.SSLRootCert},
+		{"sslcert", c.SSLCert},
+		{"sslkey", c.SSLKey},
+	} {
+		if file.path == "" {
+			continue
+		}
+		if _, err := os.Stat(file.path); err != nil {
+			return "", fmt.Errorf("%s: %w", file.key, err)
+		}
+		params = append(params, connParam(file.key, file.path))
+	}
+	return strings.Join(params, " "), nil
+}
+
+// connParam formats key=value, quoting the value when it is empty or holds
+// spaces, quotes or backslashes
+func connParam(key, value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return key + "=" + value
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return key + "='" + escaped + "'"
+}
+
+// configurePool applies the pool settings from cfg to db
+func configurePool(db *sql.DB, cfg Config) {
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 }
 
 // Pool represents a database connection pool
 type Pool struct {
-	db   *sql.DB
-	once sync.Once
+	db    *sql.DB
+	retry RetryConfig
 }
 
+// Process-wide pool handed out by GetInstance; mu guards all three
 var (
+	mu       sync.Mutex
 	instance *Pool
 	once     sync.Once
+
+	// openPool is swapped out in tests
+	openPool = NewPool
 )
 
+// GetInstance returns the process-wide pool, opening it from cfg on first use.
+// Later calls return the same pool and ignore cfg. A failed open is not
+// cached, and closing the shared pool lets the next call open a fresh one.
+func GetInstance(cfg Config) (*Pool, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var err error
+	once.Do(func() {
+		instance, err = openPool(cfg)
+	})
+	if err != nil {
+		once = sync.Once{}
+		return nil, err
+	}
+	return instance, nil
+}
+
 // NewPool creates a new database connection pool
 func NewPool(cfg Config) (*Pool, error) {
-	connStr := fmt.Sprintf(
-		"host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
-		cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.DBName, cfg.SSLMode,
-	)
+	cfg, err := cfg.withPoolDefaults()
+	if err != nil {
+		return nil, err
+	}
+
+	connStr, err := cfg.connString()
+	if err != nil {
+		return nil, err
+	}
 
 	db, err := sql.Open("postgres", connStr)
 	if err != nil {
@@ -44,9 +164,7 @@ func NewPool(cfg Config) (*Pool, error) {
 	}
 
 	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	configurePool(db, cfg)
 
 	// Test connection
 	if err := db.Ping(); err != nil {
@@ -54,7 +172,7 @@ func NewPool(cfg Config) (*Pool, error) {
 	}
 
 	log.Println("Database connection established")
-	return &Pool{db: db}, nil
+	return &Pool{db: db, retry: cfg.Retry}, nil
 }
 
 // GetDB returns the database connection
@@ -62,16 +180,32 @@ func (p *Pool) GetDB() *sql.DB {
 	return p.db
 }
 
-// Close closes the database connection
+// Close closes the database connection. Closing the shared pool also resets
+// GetInstance.
 func (p *Pool) Close() error {
+	mu.Lock()
+	if p == instance {
+		instance = nil
+		once = sync.Once{}
+	}
+	mu.Unlock()
+
 	if p.db != nil {
 		return p.db.Close()
 	}
 	return nil
 }
 
-// Transaction executes a function within a database transaction
+// Transaction executes a function within a database transaction.
+// When the pool has a retry policy, the whole transaction is re-run on
+// transient errors, so fn must be safe to call more than once.
 func (p *Pool) Transaction(fn func(*sql.Tx) error) error {
+	return Retry(context.Background(), p.retry, func() error {
+		return p.transaction(fn)
+	})
+}
+
+func (p *Pool) transaction(fn func(*sql.Tx) error) error {
 	tx, err := p.db.Begin()
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -96,5 +230,9 @@ func DefaultConfig() Config {
 		Password: "123456789",
 		DBName:   "postgres",
 		SSLMode:  "disable",
+
+		MaxOpenConns:    DefaultMaxOpenConns,
+		MaxIdleConns:    DefaultMaxIdleConns,
+		ConnMaxLifetime: DefaultConnMaxLifetime,
 	}
 }
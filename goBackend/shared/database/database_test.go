@@ -0,0 +1,177 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_WithPoolDefaults(t *testing.T) {
+	cfg, err := Config{}.withPoolDefaults()
+	if err != nil {
+		t.Fatalf("withPoolDefaults() error = %v", err)
+	}
+	if cfg.MaxOpenConns != DefaultMaxOpenConns || cfg.MaxIdleConns != DefaultMaxIdleConns ||
+		cfg.ConnMaxLifetime != DefaultConnMaxLifetime || cfg.ConnMaxIdleTime != 0 {
+		t.Errorf("withPoolDefaults() = %+v, want defaults", cfg)
+	}
+
+	custom := Config{MaxOpenConns: 50, MaxIdleConns: 10, ConnMaxLifetime: time.Hour, ConnMaxIdleTime: time.Minute}
+	cfg, err = custom.withPoolDefaults()
+	if err != nil {
+		t.Fatalf("withPoolDefaults() error = %v", err)
+	}
+	if cfg != custom {
+		t.Errorf("withPoolDefaults() = %+v, want %+v", cfg, custom)
+	}
+}
+
+func TestNewPool_InvalidPoolConfig(t *testing.T) {
+	tests := []Config{
+		{MaxOpenConns: 5, MaxIdleConns: 10},
+		{MaxOpenConns: 2}, // default idle of 5 exceeds it
+	}
+	for _, cfg := range tests {
+		if _, err := NewPool(cfg); !errors.Is(err, ErrInvalidPoolConfig) {
+			t.Errorf("NewPool(%+v) error = %v, want ErrInvalidPoolConfig", cfg, err)
+		}
+	}
+}
+
+func TestConfig_ConnString(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"root.crt", "client.crt", "client.key"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("pem"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	local := Config{Host: "localhost", Port: 5432, User: "postgres", Password: "secret", DBName: "gobackend", SSLMode: "disable"}
+	got, err := local.connString()
+	if err != nil {
+		t.Fatalf("connString() error = %v", err)
+	}
+	if want := "host=localhost port=5432 user=postgres password=secret dbname=gobackend sslmode=disable"; got != want {
+		t.Errorf("connString() = %q, want %q", got, want)
+	}
+
+	managed := local
+	managed.SSLMode = "verify-full"
+	managed.SSLRootCert = filepath.Join(dir, "root.crt")
+	managed.SSLCert = filepath.Join(dir, "client.crt")
+	managed.SSLKey = filepath.Join(dir, "client.key")
+	got, err = managed.connString()
+	if err != nil {
+		t.Fatalf("connString() error = %v", err)
+	}
+	for _, want := range []string{
+		"sslmode=verify-full",
+		"sslrootcert=" + managed.SSLRootCert,
+		"sslcert=" + managed.SSLCert,
+		"sslkey=" + managed.SSLKey,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("connString() = %q, want it to contain %q", got, want)
+		}
+	}
+
+	missing := local
+	missing.SSLRootCert = filepath.Join(dir, "missing.crt")
+	if _, err := missing.connString(); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("connString() with a missing root cert error = %v, want %v", err, os.ErrNotExist)
+	}
+}
+
+func TestConnParam_Quoting(t *testing.T) {
+	tests := map[string]string{
+		"plain":      "password=plain",
+		"":           "password=''",
+		"with space": "password='with space'",
+		`it's \ odd`: `password='it\'s \\ odd'`,
+	}
+	for value, want := range tests {
+		if got := connParam("password", value); got != want {
+			t.Errorf("connParam(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestConfigurePool(t *testing.T) {
+	// sql.Open does not connect, so the pool can be inspected without a server
+	db, err := sql.Open("postgres", "host=localhost sslmode=disable")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer db.Close()
+
+	cfg, err := Config{MaxOpenConns: 42, MaxIdleConns: 7, ConnMaxLifetime: time.Hour}.withPoolDefaults()
+	if err != nil {
+		t.Fatalf("withPoolDefaults() error = %v", err)
+	}
+	configurePool(db, cfg)
+
+	if got := db.Stats().MaxOpenConnections; got != 42 {
+		t.Errorf("MaxOpenConnections = %d, want 42", got)
+	}
+}
+
+func TestGetInstance(t *testing.T) {
+	opened := 0
+	openPool = func(cfg Config) (*Pool, error) {
+		opened++
+		return &Pool{}, nil
+	}
+	defer func() { openPool = NewPool }()
+
+	first, err := GetInstance(DefaultConfig())
+	if err != nil {
+		t.Fatalf("GetInstance() error = %v", err)
+	}
+	second, err := GetInstance(Config{Host: "ignored"})
+	if err != nil {
+		t.Fatalf("GetInstance() error = %v", err)
+	}
+	if first != second {
+		t.Errorf("GetInstance() returned %p then %p, want the same pool", first, second)
+	}
+	if opened != 1 {
+		t.Errorf("opened %d pools, want 1", opened)
+	}
+
+	if err := first.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	third, err := GetInstance(DefaultConfig())
+	if err != nil {
+		t.Fatalf("GetInstance() error = %v", err)
+	}
+	if third == first || opened != 2 {
+		t.Errorf("GetInstance() after Close reused the closed pool")
+	}
+	third.Close()
+}
+
+func TestGetInstance_RetriesAfterError(t *testing.T) {
+	fail := true
+	openPool = func(cfg Config) (*Pool, error) {
+		if fail {
+			return nil, errors.New("connection refused")
+		}
+		return &Pool{}, nil
+	}
+	defer func() { openPool = NewPool }()
+
+	if _, err := GetInstance(DefaultConfig()); err == nil {
+		t.Fatal("GetInstance() expected error")
+	}
+	fail = false
+	pool, err := GetInstance(DefaultConfig())
+	if err != nil || pool == nil {
+		t.Fatalf("GetInstance() = %v, %v, want a pool after the error cleared", pool, err)
+	}
+	pool.Close()
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"syscall"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// RetryConfig controls how transient database errors are retried.
+// A zero value makes a single attempt.
+type RetryConfig struct {
+	MaxAttempts  int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+}
+
+// DefaultRetryConfig returns a retry policy suitable for short queries
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxAttempts:  3,
+		InitialDelay: 50 * time.Millisecond,
+		MaxDelay:     time.Second,
+	}
+}
+
+// Postgres error codes worth retrying
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+	pqAdminShutdown        = "57P01"
+	pqConnectionClass      = "08"
+)
+
+// IsRetryable reports whether err is transient: a serialization failure,
+// deadlock or dropped connection. Constraint violations and other errors
+// are returned to the caller as is.
+func IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == pqSerializationFailure, pqErr.Code == pqDeadlockDetected, pqErr.Code == pqAdminShutdown:
+			return true
+		case pqErr.Code.Class() == pqConnectionClass:
+			return true
+		}
+		return false
+	}
+
+	return errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// Retry calls fn until it succeeds, fails with a non-retryable error or runs
+// out of attempts, doubling the delay between attempts up to cfg.MaxDelay.
+func Retry(ctx context.Context, cfg RetryConfig, fn func() error) error {
+	attempts := cfg.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	delay := cfg.InitialDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || !IsRetryable(err) || attempt >= attempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if cfg.MaxDelay > 0 && delay > cfg.MaxDelay {
+			delay = cfg.MaxDelay
+		}
+	}
+}
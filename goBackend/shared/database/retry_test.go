@@ -0,0 +1,124 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+)
+
+var fastRetry = RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond, MaxDelay: 2 * time.Millisecond}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"serialization failure", &pq.Error{Code: "40001"}, true},
+		{"deadlock", &pq.Error{Code: "40P01"}, true},
+		{"connection failure", &pq.Error{Code: "08006"}, true},
+		{"wrapped deadlock", fmt.Errorf("update: %w", &pq.Error{Code: "40P01"}), true},
+		{"bad connection", driver.ErrBadConn, true},
+		{"unique violation", &pq.Error{Code: "23505"}, false},
+		{"foreign key violation", &pq.Error{Code: "23503"}, false},
+		{"no rows", sql.ErrNoRows, false},
+		{"canceled", context.Canceled, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// flaky fails with err for the first failures calls and then succeeds
+type flaky struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *flaky) call() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		fake      *flaky
+		wantCalls int
+		wantErr   bool
+	}{
+		{"fails twice then succeeds", &flaky{failures: 2, err: &pq.Error{Code: "40P01"}}, 3, false},
+		{"gives up after max attempts", &flaky{failures: 5, err: driver.ErrBadConn}, 3, true},
+		{"constraint violation is not retried", &flaky{failures: 2, err: &pq.Error{Code: "23505"}}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Retry(context.Background(), fastRetry, tt.fake.call)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Retry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.fake.calls != tt.wantCalls {
+				t.Errorf("fn called %d times, want %d", tt.fake.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetry_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fake := &flaky{failures: 5, err: driver.ErrBadConn}
+	err := Retry(ctx, RetryConfig{MaxAttempts: 5, InitialDelay: time.Hour}, fake.call)
+	if !errors.Is(err, driver.ErrBadConn) || fake.calls != 1 {
+		t.Errorf("Retry() = %v after %d calls, want ErrBadConn after 1", err, fake.calls)
+	}
+}
+
+func TestPool_TransactionRetriesDeadlock(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	p := &Pool{db: db, retry: fastRetry}
+	calls := 0
+	err = p.Transaction(func(tx *sql.Tx) error {
+		calls++
+		if calls == 1 {
+			return &pq.Error{Code: "40P01"}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times, want 2", calls)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
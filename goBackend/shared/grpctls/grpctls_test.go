@@ -0,0 +1,183 @@
+package grpctls
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testPKI writes a CA and a server and client certificate signed by it
+type testPKI struct {
+	caFile                string
+	serverCert, serverKey string
+	clientCert, clientKey string
+}
+
+func newTestPKI(t *testing.T) testPKI {
+	t.Helper()
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	pki := testPKI{caFile: writePEM(t, dir, "ca.crt", "CERTIFICATE", caDER)}
+	issue := func(name string, serial int64, usage x509.ExtKeyUsage) (string, string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: name},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return writePEM(t, dir, name+".crt", "CERTIFICATE", der), writePEM(t, dir, name+".key", "EC PRIVATE KEY", keyDER)
+	}
+	pki.serverCert, pki.serverKey = issue("server", 2, x509.ExtKeyUsageServerAuth)
+	pki.clientCert, pki.clientKey = issue("client", 3, x509.ExtKeyUsageClientAuth)
+	return pki
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCredentials_Selection(t *testing.T) {
+	pki := newTestPKI(t)
+
+	tests := []struct {
+		name         string
+		cfg          Config
+		wantProtocol string
+	}{
+		{"disabled", Config{}, "insecure"},
+		{"disabled ignores files", Config{CertFile: "missing.crt", KeyFile: "missing.key"}, "insecure"},
+		{"tls", Config{Enabled: true, CertFile: pki.serverCert, KeyFile: pki.serverKey, CAFile: pki.caFile}, "tls"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, err := ServerCredentials(tt.cfg)
+			if err != nil {
+				t.Fatalf("ServerCredentials() error = %v", err)
+			}
+			client, err := ClientCredentials(tt.cfg)
+			if err != nil {
+				t.Fatalf("ClientCredentials() error = %v", err)
+			}
+			if got := server.Info().SecurityProtocol; got != tt.wantProtocol {
+				t.Errorf("server protocol = %q, want %q", got, tt.wantProtocol)
+			}
+			if got := client.Info().SecurityProtocol; got != tt.wantProtocol {
+				t.Errorf("client protocol = %q, want %q", got, tt.wantProtocol)
+			}
+		})
+	}
+}
+
+func TestCredentials_InvalidConfig(t *testing.T) {
+	pki := newTestPKI(t)
+
+	if _, err := ServerCredentials(Config{Enabled: true}); err == nil {
+		t.Error("ServerCredentials() without a certificate expected error")
+	}
+	if _, err := ServerCredentials(Config{Enabled: true, CertFile: pki.serverCert, KeyFile: pki.serverKey, CAFile: "missing.crt"}); err == nil {
+		t.Error("ServerCredentials() with a missing CA expected error")
+	}
+	if _, err := ClientCredentials(Config{Enabled: true, CertFile: pki.clientCert}); err == nil {
+		t.Error("ClientCredentials() with a certificate but no key expected error")
+	}
+	if _, err := ClientCredentials(Config{Enabled: true, CAFile: pki.clientKey}); err == nil {
+		t.Error("ClientCredentials() with a CA file holding no certificates expected error")
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	pki := newTestPKI(t)
+
+	serverOpt, err := ServerOption(Config{Enabled: true, CertFile: pki.serverCert, KeyFile: pki.serverKey, CAFile: pki.caFile})
+	if err != nil {
+		t.Fatalf("ServerOption() error = %v", err)
+	}
+	server := grpc.NewServer(serverOpt)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve(lis)
+	defer server.Stop()
+
+	check := func(cfg Config) error {
+		dialOpt, err := DialOption(cfg)
+		if err != nil {
+			t.Fatalf("DialOption() error = %v", err)
+		}
+		conn, err := grpc.Dial(lis.Addr().String(), dialOpt)
+		if err != nil {
+			t.Fatalf("Dial() error = %v", err)
+		}
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	if err := check(Config{Enabled: true, CAFile: pki.caFile, CertFile: pki.clientCert, KeyFile: pki.clientKey}); err != nil {
+		t.Errorf("call with a client certificate error = %v", err)
+	}
+	if err := check(Config{Enabled: true, CAFile: pki.caFile}); err == nil {
+		t.Error("call without a client certificate succeeded, want the handshake to fail")
+	}
+	if err := check(Config{}); err == nil {
+		t.Error("plaintext call to a TLS server succeeded")
+	}
+}
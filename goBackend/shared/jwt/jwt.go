@@ -1,12 +1,30 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// Supported signing algorithms
+const (
+	// AlgHS256 signs and validates with one shared secret
+	AlgHS256 = "HS256"
+	// AlgRS256 signs with an RSA private key and validates with the public
+	// key, so only the issuer holds the signing key
+	AlgRS256 = "RS256"
+)
+
+// ErrNoSigningKey is returned when generating a token with a service that
+// only holds a public key
+var ErrNoSigningKey = errors.New("token service has no signing key")
+
 // Claims represents JWT claims
 type Claims struct {
 	UserID   int64  `json:"user_id"`
@@ -16,46 +34,148 @@ type Claims struct {
 	jwt.RegisteredClaims
 }
 
+// Config selects the signing algorithm and its key material
+type Config struct {
+	// Algorithm is AlgHS256 (the default) or AlgRS256
+	Algorithm string
+	// Secret is the shared HS256 key
+	Secret string
+	// PrivateKeyFile is a PEM RSA private key used to sign RS256 tokens.
+	// Only the issuer needs it.
+	PrivateKeyFile string
+	// PublicKeyFile is a PEM RSA public key used to validate RS256 tokens.
+	// It may be left empty when PrivateKeyFile is set.
+	PublicKeyFile string
+}
+
 // TokenService handles JWT operations
 type TokenService struct {
-	secretKey     []byte
+	method        jwt.SigningMethod
+	signKey       interface{}
+	verifyKey     interface{}
 	tokenDuration time.Duration
 }
 
-// NewTokenService creates a new TokenService
+// NewTokenService creates a new HS256 TokenService
 func NewTokenService(secretKey string, duration time.Duration) *TokenService {
 	return &TokenService{
-		secretKey:     []byte(secretKey),
+		method:        jwt.SigningMethodHS256,
+		signKey:       []byte(secretKey),
+		verifyKey:     []byte(secretKey),
 		tokenDuration: duration,
 	}
 }
 
+// NewTokenServiceFromConfig creates a TokenService for the algorithm in cfg,
+// reading any key files it names
+func NewTokenServiceFromConfig(cfg Config, duration time.Duration) (*TokenService, error) {
+	switch cfg.Algorithm {
+	case "", AlgHS256:
+		if cfg.Secret == "" {
+			return nil, errors.New("HS256 requires a secret")
+		}
+		return NewTokenService(cfg.Secret, duration), nil
+	case AlgRS256:
+		return newRS256TokenService(cfg, duration)
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm %q", cfg.Algorithm)
+	}
+}
+
+func newRS256TokenService(cfg Config, duration time.Duration) (*TokenService, error) {
+	s := &TokenService{method: jwt.SigningMethodRS256, tokenDuration: duration}
+
+	if cfg.PrivateKeyFile != "" {
+		data, err := os.ReadFile(cfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read private key: %w", err)
+		}
+		key, err := jwt.ParseRSAPrivateKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		s.signKey = key
+		s.verifyKey = &key.PublicKey
+	}
+
+	if cfg.PublicKeyFile != "" {
+		data, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read public key: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %w", err)
+		}
+		if signKey, ok := s.signKey.(*rsa.PrivateKey); ok && !signKey.PublicKey.Equal(key) {
+			return nil, errors.New("public key does not match private key")
+		}
+		s.verifyKey = key
+	}
+
+	if s.verifyKey == nil {
+		return nil, errors.New("RS256 requires a public or private key file")
+	}
+	return s, nil
+}
+
+// Lifetime returns how long generated tokens stay valid
+func (s *TokenService) Lifetime() time.Duration {
+	return s.tokenDuration
+}
+
+// Algorithm returns the signing algorithm, e.g. "HS256"
+func (s *TokenService) Algorithm() string {
+	return s.method.Alg()
+}
+
 // GenerateToken creates a new JWT token
 func (s *TokenService) GenerateToken(userID int64, username, email, role string) (string, error) {
+	if s.signKey == nil {
+		return "", ErrNoSigningKey
+	}
+	id, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	claims := Claims{
 		UserID:   userID,
 		Username: username,
 		Email:    email,
 		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// ID (jti) lets a single token be revoked
+			ID:        id,
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.tokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(s.secretKey)
+	token := jwt.NewWithClaims(s.method, claims)
+	return token.SignedString(s.signKey)
+}
+
+// newTokenID returns a random token ID
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
 }
 
-// ValidateToken validates a JWT token and returns claims
+// ValidateToken validates a JWT token and returns claims. Only tokens signed
+// with the service's own algorithm are accepted, so for example an HS256
+// token "signed" with the RS256 public key is rejected.
 func (s *TokenService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		if token.Method.Alg() != s.method.Alg() {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return s.secretKey, nil
-	})
+		return s.verifyKey, nil
+	}, jwt.WithValidMethods([]string{s.method.Alg()}))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -0,0 +1,171 @@
+package jwt
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// writeRSAKeys writes a fresh key pair as PEM files and returns their paths
+// and the public key's PEM bytes
+func writeRSAKeys(t *testing.T) (privatePath, publicPath string, publicPEM []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	pub, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	privatePEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	publicPEM = pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub})
+
+	dir := t.TempDir()
+	privatePath = filepath.Join(dir, "jwt.key")
+	publicPath = filepath.Join(dir, "jwt.pub")
+	if err := os.WriteFile(privatePath, privatePEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(publicPath, publicPEM, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return privatePath, publicPath, publicPEM
+}
+
+func TestTokenService_HS256Default(t *testing.T) {
+	s, err := NewTokenServiceFromConfig(Config{Secret: "secret"}, time.Hour)
+	if err != nil {
+		t.Fatalf("NewTokenServiceFromConfig() error = %v", err)
+	}
+	if s.Algorithm() != AlgHS256 {
+		t.Errorf("Algorithm() = %s, want %s", s.Algorithm(), AlgHS256)
+	}
+	token, err := s.GenerateToken(1, "alice", "alice@example.com", "user")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if _, err := NewTokenService("other-secret", time.Hour).ValidateToken(token); err == nil {
+		t.Error("token validated with the wrong secret")
+	}
+}
+
+func TestTokenService_TokenID(t *testing.T) {
+	s := NewTokenService("secret", time.Hour)
+	seen := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		token, err := s.GenerateToken(1, "alice", "alice@example.com", "user")
+		if err != nil {
+			t.Fatalf("GenerateToken() error = %v", err)
+		}
+		claims, err := s.ValidateToken(token)
+		if err != nil {
+			t.Fatalf("ValidateToken() error = %v", err)
+		}
+		if claims.ID == "" || seen[claims.ID] {
+			t.Fatalf("token id %q is empty or reused", claims.ID)
+		}
+		seen[claims.ID] = true
+	}
+}
+
+func TestTokenService_RS256(t *testing.T) {
+	privatePath, publicPath, _ := writeRSAKeys(t)
+
+	issuer, err := NewTokenServiceFromConfig(Config{Algorithm: AlgRS256, PrivateKeyFile: privatePath}, time.Hour)
+	if err != nil {
+		t.Fatalf("issuer: %v", err)
+	}
+	verifier, err := NewTokenServiceFromConfig(Config{Algorithm: AlgRS256, PublicKeyFile: publicPath}, time.Hour)
+	if err != nil {
+		t.Fatalf("verifier: %v", err)
+	}
+
+	token, err := issuer.GenerateToken(7, "bob", "bob@example.com", "admin")
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	for name, s := range map[string]*TokenService{"issuer": issuer, "verifier": verifier} {
+		claims, err := s.ValidateToken(token)
+		if err != nil {
+			t.Fatalf("%s ValidateToken() error = %v", name, err)
+		}
+		if claims.UserID != 7 || claims.Role != "admin" {
+			t.Errorf("%s claims = %+v, want user 7 with role admin", name, claims)
+		}
+	}
+
+	// Holders of the public key can't mint tokens
+	if _, err := verifier.GenerateToken(1, "eve", "eve@example.com", "admin"); !errors.Is(err, ErrNoSigningKey) {
+		t.Errorf("verifier GenerateToken() error = %v, want %v", err, ErrNoSigningKey)
+	}
+
+	// RS256 tokens aren't accepted where HS256 is expected
+	if _, err := NewTokenService("secret", time.Hour).ValidateToken(token); err == nil {
+		t.Error("HS256 service accepted an RS256 token")
+	}
+}
+
+func TestTokenService_RS256RejectsHS256(t *testing.T) {
+	_, publicPath, publicPEM := writeRSAKeys(t)
+	verifier, err := NewTokenServiceFromConfig(Config{Algorithm: AlgRS256, PublicKeyFile: publicPath}, time.Hour)
+	if err != nil {
+		t.Fatalf("verifier: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		secret []byte
+	}{
+		{"unrelated secret", []byte("secret")},
+		// Algorithm confusion: the public key is known to everyone, so a
+		// verifier that trusted the header would accept this token
+		{"public key as HMAC secret", publicPEM},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := Claims{UserID: 1, Role: "admin", RegisteredClaims: jwt.RegisteredClaims{
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			}}
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(tt.secret)
+			if err != nil {
+				t.Fatalf("SignedString: %v", err)
+			}
+			if _, err := verifier.ValidateToken(token); err == nil {
+				t.Error("RS256 service accepted an HS256 token")
+			}
+		})
+	}
+}
+
+func TestNewTokenServiceFromConfig_Invalid(t *testing.T) {
+	privatePath, _, _ := writeRSAKeys(t)
+	_, otherPublic, _ := writeRSAKeys(t)
+
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{"HS256 without secret", Config{Algorithm: AlgHS256}},
+		{"RS256 without keys", Config{Algorithm: AlgRS256}},
+		{"missing key file", Config{Algorithm: AlgRS256, PublicKeyFile: "/nonexistent.pub"}},
+		{"mismatched key pair", Config{Algorithm: AlgRS256, PrivateKeyFile: privatePath, PublicKeyFile: otherPublic}},
+		{"unsupported algorithm", Config{Algorithm: "none", Secret: "secret"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewTokenServiceFromConfig(tt.cfg, time.Hour); err == nil {
+				t.Error("NewTokenServiceFromConfig() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// New creates a JSON logger tagged with the service name. The minimum level
+// comes from LOG_LEVEL (debug, info, warn, error) and defaults to info.
+func New(service string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: ParseLevel(os.Getenv("LOG_LEVEL"))}
+	return slog.New(slog.NewJSONHandler(os.Stdout, opts)).With("service", service)
+}
+
+// ParseLevel maps a LOG_LEVEL value to a slog level; unknown values give info
+func ParseLevel(s string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	}
+	return slog.LevelInfo
+}
+
+// Setup installs a JSON logger for service as the process default. Output
+// from the standard log package goes through it as well.
+func Setup(service string) *slog.Logger {
+	logger := New(service)
+	slog.SetDefault(logger)
+	return logger
+}
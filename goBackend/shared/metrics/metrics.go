@@ -0,0 +1,96 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// NewRegistry creates a registry with the Go runtime and process collectors
+func NewRegistry() *prometheus.Registry {
+	reg := prometheus.NewRegistry()
+	reg.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return reg
+}
+
+// GRPCServerMetrics holds per-method counters and latencies for a gRPC server
+type GRPCServerMetrics struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// NewGRPCServerMetrics creates the gRPC server collectors and registers them with reg
+func NewGRPCServerMetrics(reg prometheus.Registerer) *GRPCServerMetrics {
+	m := &GRPCServerMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_requests_total",
+			Help: "Total number of gRPC requests handled, by method and status code.",
+		}, []string{"method", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "grpc_server_errors_total",
+			Help: "Total number of gRPC requests that returned an error, by method.",
+		}, []string{"method"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_server_request_duration_seconds",
+			Help:    "gRPC request latency in seconds, by method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method"}),
+	}
+	reg.MustRegister(m.requests, m.errors, m.latency)
+	return m
+}
+
+// UnaryInterceptor records every unary call
+func (m *GRPCServerMetrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		code := codes.OK
+		if err != nil {
+			code = status.Code(err)
+			m.errors.WithLabelValues(info.FullMethod).Inc()
+		}
+		m.requests.WithLabelValues(info.FullMethod, code.String()).Inc()
+		m.latency.WithLabelValues(info.FullMethod).Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
+
+// Serve exposes the metrics in g on /metrics at the given port in the
+// background. A port of 0 disables the endpoint.
+func Serve(port int, g prometheus.Gatherer) {
+	if port == 0 {
+		return
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(g, promhttp.HandlerOpts{}))
+
+	addr := fmt.Sprintf(":%d", port)
+	go func() {
+		slog.Info("metrics endpoint starting", "addr", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			slog.Error("metrics endpoint stopped", "error", err)
+		}
+	}()
+}
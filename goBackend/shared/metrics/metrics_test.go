@@ -0,0 +1,38 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+)
+
+func TestGRPCServerMetrics_UnaryInterceptor(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m := NewGRPCServerMetrics(reg)
+	interceptor := m.UnaryInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/GetTask"}
+
+	ok := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, nil }
+	fail := func(ctx context.Context, req interface{}) (interface{}, error) { return nil, errors.New("boom") }
+
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, ok)
+	interceptor(context.Background(), nil, info, fail)
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues(info.FullMethod, "OK")); got != 2 {
+		t.Errorf("OK requests = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues(info.FullMethod, "Unknown")); got != 1 {
+		t.Errorf("Unknown requests = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(m.errors.WithLabelValues(info.FullMethod)); got != 1 {
+		t.Errorf("errors = %v, want 1", got)
+	}
+	if got := testutil.CollectAndCount(m.latency); got != 1 {
+		t.Errorf("latency series = %d, want 1", got)
+	}
+}
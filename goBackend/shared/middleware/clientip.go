@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// ClientIPKey is the gRPC metadata key carrying the end user's IP address
+const ClientIPKey = "x-client-ip"
+
+// WithClientIP returns a context whose outgoing gRPC metadata carries the
+// end user's IP so downstream services can record it
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	if ip == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, ClientIPKey, ip)
+}
+
+// ClientIPFromContext returns the end user's IP from the incoming gRPC
+// metadata, falling back to the address of the calling peer
+func ClientIPFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(ClientIPKey); len(values) > 0 {
+			return values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC metadata keys carrying the authenticated end user
+const (
+	UserIDKey   = "x-user-id"
+	UserRoleKey = "x-user-role"
+)
+
+// Caller is the authenticated end user a request is made on behalf of
+type Caller struct {
+	UserID int64
+	Role   string
+}
+
+type callerContextKey struct{}
+
+// WithCaller returns a context carrying caller. IdentityClientInterceptor
+// forwards it to the service being called.
+func WithCaller(ctx context.Context, caller Caller) context.Context {
+	if caller.UserID == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// CallerFromContext returns the caller stored by WithCaller or by
+// IdentityInterceptor
+func CallerFromContext(ctx context.Context) (Caller, bool) {
+	caller, ok := ctx.Value(callerContextKey{}).(Caller)
+	return caller, ok
+}
+
+// IdentityClientInterceptor copies the caller in the context into the
+// outgoing metadata
+func IdentityClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(outgoingCaller(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// IdentityStreamClientInterceptor is IdentityClientInterceptor for streams
+func IdentityStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(outgoingCaller(ctx), desc, cc, method, opts...)
+	}
+}
+
+// outgoingCaller returns ctx with its caller, if any, added to the outgoing
+// metadata
+func outgoingCaller(ctx context.Context) context.Context {
+	caller, ok := CallerFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		UserIDKey, strconv.FormatInt(caller.UserID, 10),
+		UserRoleKey, caller.Role,
+	)
+}
+
+// IdentityInterceptor reads the caller forwarded by the gateway from the
+// incoming metadata into the context. Services trust it the same way they
+// trust the gateway, so they should only be reachable through it.
+func IdentityInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, err := incomingCaller(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// IdentityStreamInterceptor is IdentityInterceptor for streams
+func IdentityStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		ss grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := incomingCaller(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &callerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// callerStream is a ServerStream whose context carries the caller
+type callerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *callerStream) Context() context.Context {
+	return s.ctx
+}
+
+// incomingCaller returns ctx carrying the caller in its incoming metadata.
+// Calls without one are left anonymous; malformed ones are refused.
+func incomingCaller(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(UserIDKey)) == 0 {
+		return ctx, nil
+	}
+
+	userID, err := strconv.ParseInt(md.Get(UserIDKey)[0], 10, 64)
+	if err != nil || userID <= 0 {
+		return nil, status.Error(codes.Unauthenticated, "invalid caller identity")
+	}
+	caller := Caller{UserID: userID}
+	if roles := md.Get(UserRoleKey); len(roles) > 0 {
+		caller.Role = roles[0]
+	}
+	return WithCaller(ctx, caller), nil
+}
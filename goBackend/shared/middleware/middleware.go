@@ -2,7 +2,8 @@ package middleware
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
 	"time"
 
 	"google.golang.org/grpc"
@@ -11,8 +12,14 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-// LoggingInterceptor logs all gRPC requests
+// LoggingInterceptor logs all gRPC requests as structured entries, including
+// the request ID propagated by the gateway
 func LoggingInterceptor() grpc.UnaryServerInterceptor {
+	return loggingInterceptor(nil)
+}
+
+// loggingInterceptor logs with logger, or the default logger when nil
+func loggingInterceptor(logger *slog.Logger) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -21,6 +28,11 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 	) (interface{}, error) {
 		start := time.Now()
 
+		requestID := RequestIDFromContext(ctx)
+		if requestID != "" {
+			ctx = WithRequestID(ctx, requestID)
+		}
+
 		// Call the handler
 		resp, err := handler(ctx, req)
 
@@ -31,13 +43,21 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 			statusCode = status.Code(err)
 		}
 
-		log.Printf(
-			"gRPC | %s | %s | %v | %s",
-			info.FullMethod,
-			statusCode,
-			duration,
-			err,
-		)
+		attrs := []any{
+			"method", info.FullMethod,
+			"code", statusCode.String(),
+			"duration_ms", duration.Milliseconds(),
+			"request_id", requestID,
+		}
+		if err != nil {
+			attrs = append(attrs, "error", err.Error())
+		}
+
+		l := logger
+		if l == nil {
+			l = slog.Default()
+		}
+		l.InfoContext(ctx, "grpc request", attrs...)
 
 		return resp, err
 	}
@@ -53,7 +73,11 @@ func RecoveryInterceptor() grpc.UnaryServerInterceptor {
 	) (resp interface{}, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				log.Printf("Recovered from panic: %v", r)
+				slog.ErrorContext(ctx, "recovered from panic",
+					"method", info.FullMethod,
+					"panic", fmt.Sprint(r),
+					"request_id", RequestIDFromContext(ctx),
+				)
 				err = status.Errorf(codes.Internal, "internal server error")
 			}
 		}()
@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoggingInterceptor_LogsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	interceptor := loggingInterceptor(logger)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "req-123"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/GetTask"}
+
+	var handlerRequestID string
+	_, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerRequestID = RequestIDFromContext(ctx)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("interceptor error = %v", err)
+	}
+
+	if handlerRequestID != "req-123" {
+		t.Errorf("handler request ID = %q, want %q", handlerRequestID, "req-123")
+	}
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log output is not JSON: %v (%s)", err, buf.String())
+	}
+	if entry["request_id"] != "req-123" {
+		t.Errorf("logged request_id = %v, want %q", entry["request_id"], "req-123")
+	}
+	if entry["method"] != info.FullMethod {
+		t.Errorf("logged method = %v, want %q", entry["method"], info.FullMethod)
+	}
+	if entry["code"] != "OK" {
+		t.Errorf("logged code = %v, want OK", entry["code"])
+	}
+}
+
+func TestWithRequestID_SetsOutgoingMetadata(t *testing.T) {
+	ctx := WithRequestID(context.Background(), "req-456")
+
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok || len(md.Get(RequestIDKey)) == 0 || md.Get(RequestIDKey)[0] != "req-456" {
+		t.Errorf("outgoing metadata = %v, want %s=req-456", md, RequestIDKey)
+	}
+	if got := RequestIDFromContext(ctx); got != "req-456" {
+		t.Errorf("RequestIDFromContext() = %q, want %q", got, "req-456")
+	}
+}
+
+func TestValidRequestID(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"req-123", true},
+		{"0f1e2d3c4b5a69788796a5b4c3d2e1f0", true},
+		{"a.b_c-D", true},
+		{"", false},
+		{strings.Repeat("a", MaxRequestIDLength), true},
+		{strings.Repeat("a", MaxRequestIDLength+1), false},
+		{"req 123", false},
+		{"req\n123", false},
+		{"<script>", false},
+	}
+	for _, tt := range tests {
+		if got := ValidRequestID(tt.id); got != tt.want {
+			t.Errorf("ValidRequestID(%q) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(RequestIDKey, "bad\nid"))
+	if got := RequestIDFromContext(ctx); got != "" {
+		t.Errorf("RequestIDFromContext() = %q, want empty for an invalid ID", got)
+	}
+}
+
+func TestClientIPFromContext(t *testing.T) {
+	p := &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.2"), Port: 41000}}
+
+	// The gateway forwards the end user's IP in the metadata
+	out, _ := metadata.FromOutgoingContext(WithClientIP(context.Background(), "203.0.113.7"))
+	ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), out), p)
+	if got := ClientIPFromContext(ctx); got != "203.0.113.7" {
+		t.Errorf("ClientIPFromContext() = %q, want the forwarded IP", got)
+	}
+
+	// Direct callers are identified by their address
+	ctx = peer.NewContext(context.Background(), p)
+	if got := ClientIPFromContext(ctx); got != "10.0.0.2" {
+		t.Errorf("ClientIPFromContext() = %q, want the peer IP", got)
+	}
+}
+
+// titledRequest requires a title
+type titledRequest struct{ title string }
+
+func (r titledRequest) Validate() error {
+	if r.title == "" {
+		return errors.New("title is required")
+	}
+	return nil
+}
+
+func TestValidationInterceptor(t *testing.T) {
+	interceptor := ValidationInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/CreateTask"}
+
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return "ok", nil
+	}
+
+	_, err := interceptor(context.Background(), titledRequest{}, info, handler)
+	if status.Code(err) != codes.InvalidArgument || status.Convert(err).Message() != "title is required" {
+		t.Errorf("invalid request error = %v, want InvalidArgument: title is required", err)
+	}
+	if calls != 0 {
+		t.Fatalf("handler ran %d times for an invalid request, want 0", calls)
+	}
+
+	for _, req := range []interface{}{titledRequest{title: "Plan"}, "no Validate method"} {
+		if resp, err := interceptor(context.Background(), req, info, handler); err != nil || resp != "ok" {
+			t.Errorf("interceptor(%v) = %v, %v, want the handler's response", req, resp, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("handler ran %d times, want 2", calls)
+	}
+}
+
+func TestIdentity_RoundTrip(t *testing.T) {
+	ctx := WithCaller(context.Background(), Caller{UserID: 42, Role: "manager"})
+
+	// The gateway side puts the caller into the outgoing metadata
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	if err := IdentityClientInterceptor()(ctx, "/task.TaskService/AddComment", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("client interceptor error = %v", err)
+	}
+
+	// and the service reads it back out of the incoming metadata
+	var got Caller
+	var found bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, found = CallerFromContext(ctx)
+		return nil, nil
+	}
+	info := &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/AddComment"}
+	if _, err := IdentityInterceptor()(metadata.NewIncomingContext(context.Background(), sent), nil, info, handler); err != nil {
+		t.Fatalf("server interceptor error = %v", err)
+	}
+	if !found || got != (Caller{UserID: 42, Role: "manager"}) {
+		t.Errorf("caller = %+v (found %v), want user 42 with role manager", got, found)
+	}
+
+	// Anonymous calls carry no caller
+	found = true
+	IdentityInterceptor()(context.Background(), nil, info, handler)
+	if found {
+		t.Error("caller found on a call without identity metadata")
+	}
+
+	forged := metadata.NewIncomingContext(context.Background(), metadata.Pairs(UserIDKey, "admin"))
+	if _, err := IdentityInterceptor()(forged, nil, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("malformed user id error = %v, want Unauthenticated", err)
+	}
+}
+
+// contextStream is a ServerStream that only has a context
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s contextStream) Context() context.Context { return s.ctx }
+
+func TestIdentity_StreamRoundTrip(t *testing.T) {
+	ctx := WithCaller(context.Background(), Caller{UserID: 42, Role: "manager"})
+
+	var sent metadata.MD
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil, nil
+	}
+	if _, err := IdentityStreamClientInterceptor()(ctx, &grpc.StreamDesc{}, nil, "/media.MediaService/UploadFile", streamer); err != nil {
+		t.Fatalf("client interceptor error = %v", err)
+	}
+
+	var got Caller
+	var found bool
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		got, found = CallerFromContext(ss.Context())
+		return nil
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/media.MediaService/UploadFile", IsClientStream: true}
+	incoming := contextStream{ctx: metadata.NewIncomingContext(context.Background(), sent)}
+	if err := IdentityStreamInterceptor()(nil, incoming, info, handler); err != nil {
+		t.Fatalf("server interceptor error = %v", err)
+	}
+	if !found || got != (Caller{UserID: 42, Role: "manager"}) {
+		t.Errorf("caller = %+v (found %v), want user 42 with role manager", got, found)
+	}
+
+	forged := contextStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(UserIDKey, "admin"))}
+	if err := IdentityStreamInterceptor()(nil, forged, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("malformed user id error = %v, want Unauthenticated", err)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"google.golang.org/grpc/metadata"
+)
+
+// RequestIDKey is the gRPC metadata key carrying the request ID
+const RequestIDKey = "x-request-id"
+
+// MaxRequestIDLength caps client-supplied request IDs so they can't bloat
+// logs or downstream metadata
+const MaxRequestIDLength = 64
+
+type requestIDContextKey struct{}
+
+// NewRequestID generates a random request ID
+func NewRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// ValidRequestID reports whether id is safe to log and forward: non-empty, at
+// most MaxRequestIDLength bytes, and limited to letters, digits, '-', '_'
+// and '.'
+func ValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithRequestID returns a context carrying the request ID, both as a value and
+// in the outgoing gRPC metadata so it reaches downstream services
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, requestIDContextKey{}, requestID)
+	return metadata.AppendToOutgoingContext(ctx, RequestIDKey, requestID)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx, falling back to
+// the incoming gRPC metadata. IDs from metadata that fail ValidRequestID are
+// ignored
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDContextKey{}).(string); ok {
+		return id
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDKey); len(values) > 0 && ValidRequestID(values[0]) {
+			return values[0]
+		}
+	}
+	return ""
+}
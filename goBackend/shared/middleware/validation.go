@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Validator is implemented by request messages that declare their own rules
+type Validator interface {
+	Validate() error
+}
+
+// ValidationInterceptor rejects requests whose Validate method fails with
+// InvalidArgument before they reach the handler. Requests without a
+// Validate method pass through unchanged.
+func ValidationInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if v, ok := req.(Validator); ok {
+			if err := v.Validate(); err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+		}
+		return handler(ctx, req)
+	}
+}
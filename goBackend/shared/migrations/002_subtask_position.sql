@@ -0,0 +1,10 @@
+-- Order of subtasks within their task
+ALTER TABLE subtasks ADD COLUMN IF NOT EXISTS position INT NOT NULL DEFAULT 0;
+
+-- Keep the existing creation order for subtasks created before this column
+UPDATE subtasks s
+SET position = ordered.rn
+FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY task_id ORDER BY id) AS rn FROM subtasks) ordered
+WHERE s.id = ordered.id AND s.position = 0;
+
+CREATE INDEX IF NOT EXISTS idx_subtasks_task_position ON subtasks(task_id, position);
@@ -0,0 +1,7 @@
+-- Attachments are either external links or files uploaded to the media service
+ALTER TABLE task_attachments ADD COLUMN IF NOT EXISTS attachment_type VARCHAR(20) NOT NULL DEFAULT 'link';
+
+-- Removing a media file removes the attachments that point at it
+ALTER TABLE task_attachments ADD COLUMN IF NOT EXISTS media_file_id INT REFERENCES media_files(id) ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS idx_task_attachments_media_file ON task_attachments(media_file_id);
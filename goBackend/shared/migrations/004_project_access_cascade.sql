@@ -0,0 +1,6 @@
+-- Access grants were not tied to projects, so deleting a project left them behind
+DELETE FROM user_project_access WHERE project_id NOT IN (SELECT id FROM projects);
+
+ALTER TABLE user_project_access DROP CONSTRAINT IF EXISTS user_project_access_project_id_fkey;
+ALTER TABLE user_project_access ADD CONSTRAINT user_project_access_project_id_fkey
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE;
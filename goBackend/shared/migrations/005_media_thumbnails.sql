@@ -0,0 +1,2 @@
+-- Images get a scaled-down copy for galleries
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS thumbnail_url TEXT;
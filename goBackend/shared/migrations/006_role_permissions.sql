@@ -0,0 +1,8 @@
+-- Capabilities granted to each role, checked by the gateway. The admin role
+-- is a superuser and needs no rows here. Known permissions:
+--   users:manage, comments:delete, attachments:delete, tags:delete
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id INT NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL,
+    PRIMARY KEY (role_id, permission)
+);
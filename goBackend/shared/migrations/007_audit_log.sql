@@ -0,0 +1,14 @@
+-- Security-sensitive auth operations. actor_id has no foreign key so entries
+-- outlive the users they mention; it is NULL when nobody was signed in.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    actor_id BIGINT,
+    action VARCHAR(50) NOT NULL,
+    target VARCHAR(255) NOT NULL DEFAULT '',
+    details TEXT NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor_id);
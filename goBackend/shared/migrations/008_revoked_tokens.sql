@@ -0,0 +1,17 @@
+-- Revoked JWTs. Rows are only needed until the token would have expired
+-- anyway and are purged after expires_at.
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens(expires_at);
+
+-- Every token issued to user_id at or before revoked_before is revoked, e.g.
+-- when the user is deleted. expires_at is when the last of them expires.
+CREATE TABLE IF NOT EXISTS revoked_user_tokens (
+    user_id BIGINT PRIMARY KEY,
+    revoked_before TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
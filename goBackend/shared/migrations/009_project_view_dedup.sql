@@ -0,0 +1,2 @@
+-- Batched views are checked against each user's recent views of the project
+CREATE INDEX IF NOT EXISTS idx_project_views_viewer ON project_views(project_id, user_id, viewed_at);
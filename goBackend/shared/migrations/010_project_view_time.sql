@@ -0,0 +1,2 @@
+-- Trending projects aggregate every view in a recent time range
+CREATE INDEX IF NOT EXISTS idx_project_views_viewed_at ON project_views(viewed_at);
@@ -0,0 +1,6 @@
+-- When a task last moved to Done, for lead time metrics. Tasks already done
+-- are taken as completed at their last update, the closest record there is.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+UPDATE tasks SET completed_at = updated_at WHERE status = 'Done' AND completed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_project_completed ON tasks(project_id, completed_at);
@@ -0,0 +1,8 @@
+-- Files can belong to the project or task they were uploaded for. Uploads
+-- without one stay unassociated, and deleting the project or task keeps the
+-- file but drops the link.
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS project_id INT REFERENCES projects(id) ON DELETE SET NULL;
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS task_id INT REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_media_files_project ON media_files(project_id, uploaded_at);
+CREATE INDEX IF NOT EXISTS idx_media_files_task ON media_files(task_id);
@@ -0,0 +1,8 @@
+-- Per-project settings. Task defaults apply to tasks created without a status
+-- or priority; NULL falls back to the task service's global defaults.
+CREATE TABLE IF NOT EXISTS project_settings (
+    project_id INT PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    default_task_status VARCHAR(50),
+    default_task_priority INT,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
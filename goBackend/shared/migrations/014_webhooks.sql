@@ -0,0 +1,31 @@
+-- Endpoints notified of task and project changes. event_types holds names
+-- such as task.created or project.deleted.
+--
+-- The secret is stored in plaintext on purpose: each delivery is signed with
+-- an HMAC keyed with it, so the dispatcher needs the secret itself, and a
+-- hash would be useless to it. It is never returned after the webhook is
+-- created; access to this table should be treated like access to the
+-- secrets.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    event_types TEXT[] NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Every attempt to deliver an event; status_code is 0 when the endpoint
+-- couldn't be reached.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_id VARCHAR(64) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    attempt INT NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    succeeded BOOLEAN NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_at DESC);
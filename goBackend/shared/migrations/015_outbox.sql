@@ -0,0 +1,20 @@
+-- Events written in the same transaction as the change they announce and
+-- relayed to their consumers afterwards. source is the service that wrote
+-- the event and is the only one to relay it. A message is pending until
+-- sent_at is set; failed_at marks one that was given up on after too many
+-- attempts, kept with its last_error for inspection.
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    source VARCHAR(50) NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    sent_at TIMESTAMP,
+    failed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox(source, next_attempt_at)
+    WHERE sent_at IS NULL AND failed_at IS NULL;
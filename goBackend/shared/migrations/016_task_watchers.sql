@@ -0,0 +1,11 @@
+-- Users following a task they may not be assigned to. Their IDs go out
+-- with the task's update and delete events so they can be notified. The
+-- primary key keeps a user from watching a task twice.
+CREATE TABLE IF NOT EXISTS task_watchers (
+    task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_watchers_user ON task_watchers(user_id);
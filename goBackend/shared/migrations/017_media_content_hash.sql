@@ -0,0 +1,4 @@
+-- Hex SHA-256 of each file's content, used as its ETag and to build
+-- download URLs that change whenever the content does. Files uploaded
+-- before this have none and are hashed when downloaded.
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
@@ -0,0 +1,4 @@
+-- Size in bytes of each file, summed per uploader for storage quotas.
+-- Files uploaded before this start at 0; the media service reads them from
+-- storage and records their sizes when it starts.
+ALTER TABLE media_files ADD COLUMN IF NOT EXISTS file_size BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- Relays look up the earlier attempts at an event to skip the
+-- subscriptions that already took it.
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_event ON webhook_deliveries(event_id);
@@ -0,0 +1,2 @@
+-- Recently viewed projects take each project's latest view by one user
+CREATE INDEX IF NOT EXISTS idx_project_views_recent ON project_views(user_id, project_id, viewed_at DESC);
@@ -0,0 +1,241 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	_ "github.com/lib/pq"
+)
+
+func TestLoad(t *testing.T) {
+	fsys := fstest.MapFS{
+		"010_later.sql":  {Data: []byte("SELECT 10")},
+		"002_second.sql": {Data: []byte("SELECT 2")},
+		"001_first.sql":  {Data: []byte("SELECT 1")},
+		"README.md":      {Data: []byte("ignored")},
+	}
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	want := []int64{1, 2, 10}
+	if len(migrations) != len(want) {
+		t.Fatalf("Load() returned %d migrations, want %d", len(migrations), len(want))
+	}
+	for i, v := range want {
+		if migrations[i].Version != v {
+			t.Errorf("migrations[%d].Version = %d, want %d", i, migrations[i].Version, v)
+		}
+	}
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"no version": {"init.sql": {Data: []byte("SELECT 1")}},
+		"duplicate":  {"001_a.sql": {Data: []byte("SELECT 1")}, "001_b.sql": {Data: []byte("SELECT 1")}},
+	}
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Load(fsys); err == nil {
+				t.Error("Load() expected error")
+			}
+		})
+	}
+}
+
+func TestLoad_Embedded(t *testing.T) {
+	migrations, err := Load(files)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != 1 {
+		t.Errorf("embedded migrations = %+v, want to start at version 1", migrations)
+	}
+}
+
+func TestMigrator_UpSkipsApplied(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_lock($1)")).WithArgs(lockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT version FROM schema_migrations")).
+		WillReturnRows(sqlmock.NewRows([]string{"version"}).AddRow(1))
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("CREATE TABLE b (id INT)")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)")).
+		WithArgs(int64(2), "002_b.sql").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("SELECT pg_advisory_unlock($1)")).WithArgs(lockID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	m := New(db, []Migration{
+		{Version: 1, Name: "001_a.sql", SQL: "CREATE TABLE a (id INT)"},
+		{Version: 2, Name: "002_b.sql", SQL: "CREATE TABLE b (id INT)"},
+	})
+	applied, err := m.Up(context.Background())
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if applied != 1 {
+		t.Errorf("Up() applied = %d, want 1", applied)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("unmet expectations: %v", err)
+	}
+}
+
+// tempDatabase creates a throwaway database that is dropped when the test ends.
+// It needs TEST_DATABASE_URL pointing at a server where the user may create databases.
+func tempDatabase(t *testing.T) *sql.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	name := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE DATABASE " + name); err != nil {
+		t.Fatalf("CREATE DATABASE error = %v", err)
+	}
+	t.Cleanup(func() { admin.Exec("DROP DATABASE IF EXISTS " + name) })
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	u.Path = "/" + name
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	// Registered last so it runs before the database is dropped
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// TestRun_TempDatabase applies the bundled migrations to a throwaway database
+func TestRun_TempDatabase(t *testing.T) {
+	db := tempDatabase(t)
+
+	// Concurrent runners must serialize on the advisory lock and apply each version once
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	results := make([]int, 3)
+	errs := make([]error, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = Run(ctx, db)
+		}(i)
+	}
+	wg.Wait()
+
+	total := 0
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		total += results[i]
+	}
+	migrations, _ := Load(files)
+	if total != len(migrations) {
+		t.Errorf("applied %d migrations in total, want %d", total, len(migrations))
+	}
+
+	if n, err := Run(ctx, db); err != nil || n != 0 {
+		t.Errorf("second Run() = %d, %v, want 0, nil", n, err)
+	}
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count); err != nil {
+		t.Fatalf("count schema_migrations error = %v", err)
+	}
+	if count != len(migrations) {
+		t.Errorf("schema_migrations has %d rows, want %d", count, len(migrations))
+	}
+}
+
+// TestRun_ProjectDeleteCascades checks that deleting a project removes every
+// row that references it, including those owned by other services
+func TestRun_ProjectDeleteCascades(t *testing.T) {
+	db := tempDatabase(t)
+	if _, err := Run(context.Background(), db); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	seed := []string{
+		`INSERT INTO users (id, username, email, password_hash) VALUES (1, 'owner', 'owner@example.com', 'x')`,
+		`INSERT INTO projects (id, name) VALUES (1, 'Doomed'), (2, 'Kept')`,
+		`INSERT INTO skills (id, name) VALUES (1, 'Go')`,
+		`INSERT INTO project_skills (project_id, skill_id) VALUES (1, 1), (2, 1)`,
+		`INSERT INTO project_tech (project_id, tech_name) VALUES (1, 'gRPC')`,
+		`INSERT INTO project_images (project_id, image_url) VALUES (1, 'https://example.com/a.png')`,
+		`INSERT INTO project_links (project_id, link_url, link_type) VALUES (1, 'https://example.com', 'live')`,
+		`INSERT INTO user_project_access (user_id, project_id) VALUES (1, 1)`,
+		`INSERT INTO tasks (id, project_id, title) VALUES (1, 1, 'Task'), (2, 2, 'Other')`,
+		`INSERT INTO subtasks (task_id, title) VALUES (1, 'Subtask')`,
+		`INSERT INTO task_comments (task_id, user_id, comment) VALUES (1, 1, 'Comment')`,
+		`INSERT INTO project_views (project_id, user_id) VALUES (1, 1)`,
+		`INSERT INTO project_stats (project_id) VALUES (1)`,
+	}
+	for _, stmt := range seed {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	if _, err := db.Exec(`DELETE FROM projects WHERE id = 1`); err != nil {
+		t.Fatalf("DELETE project error = %v", err)
+	}
+
+	for table, where := range map[string]string{
+		"project_skills":      "project_id = 1",
+		"project_tech":        "project_id = 1",
+		"project_images":      "project_id = 1",
+		"project_links":       "project_id = 1",
+		"user_project_access": "project_id = 1",
+		"tasks":               "project_id = 1",
+		"subtasks":            "task_id = 1",
+		"task_comments":       "task_id = 1",
+		"project_views":       "project_id = 1",
+		"project_stats":       "project_id = 1",
+	} {
+		var count int
+		if err := db.QueryRow(`SELECT COUNT(*) FROM ` + table + ` WHERE ` + where).Scan(&count); err != nil {
+			t.Fatalf("count %s error = %v", table, err)
+		}
+		if count != 0 {
+			t.Errorf("%s still has %d rows for the deleted project", table, count)
+		}
+	}
+
+	var kept int
+	db.QueryRow(`SELECT COUNT(*) FROM tasks WHERE project_id = 2`).Scan(&kept)
+	if kept != 1 {
+		t.Errorf("other project has %d tasks, want 1", kept)
+	}
+}
@@ -0,0 +1,57 @@
+// Package outbox makes sure the events a change announces aren't lost. A
+// service records each event with Add in the same transaction as the change,
+// so the event exists exactly when the change does, and a Relay hands the
+// recorded events to their consumers until they are taken. Delivery is at
+// least once: a consumer may see an event again after a crash or a failed
+// attempt, and should use Message.ID to drop repeats.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+// Execer is the part of a *sql.Tx that Add writes through
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Add records an event of eventType from source in tx. data is stored as
+// JSON; the event is only relayed if tx commits.
+func Add(ctx context.Context, tx Execer, source, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO outbox (source, event_type, payload) VALUES ($1, $2, $3)`,
+		source, eventType, payload,
+	)
+	return err
+}
+
+// Message is an event waiting in the outbox
+type Message struct {
+	ID        int64
+	Source    string
+	EventType string
+	Payload   json.RawMessage
+	// Attempts counts the failed deliveries so far
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// Store is the outbox as the Relay sees it
+type Store interface {
+	// Claim returns up to limit messages that are due, hiding them from
+	// other claims until lease has passed so two relays don't both take one
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]*Message, error)
+	MarkSent(ctx context.Context, id int64) error
+	// MarkFailed records a failed attempt. The message is due again at
+	// retryAt, or never when giveUp is set.
+	MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time, giveUp bool) error
+	// Prune deletes messages sent before the given time
+	Prune(ctx context.Context, sentBefore time.Time) (int64, error)
+}
@@ -0,0 +1,85 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+)
+
+// Postgres is the Store over the outbox table for one source's events
+type Postgres struct {
+	db     *sql.DB
+	source string
+}
+
+// NewPostgres creates a Postgres store that only claims events from source
+func NewPostgres(db *sql.DB, source string) *Postgres {
+	return &Postgres{db: db, source: source}
+}
+
+var _ Store = (*Postgres)(nil)
+
+// Claim takes the oldest due messages, pushing their next attempt back by
+// lease. SKIP LOCKED lets several replicas claim side by side.
+func (p *Postgres) Claim(ctx context.Context, limit int, lease time.Duration) ([]*Message, error) {
+	query := `
+		UPDATE outbox SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE source = $2 AND sent_at IS NULL AND failed_at IS NULL AND next_attempt_at <= $3
+			ORDER BY id
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, source, event_type, payload, attempts, created_at
+	`
+	now := time.Now()
+	rows, err := p.db.QueryContext(ctx, query, now.Add(lease), p.source, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*Message
+	for rows.Next() {
+		m := &Message{}
+		if err := rows.Scan(&m.ID, &m.Source, &m.EventType, &m.Payload, &m.Attempts, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// RETURNING doesn't keep the subquery's order
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, nil
+}
+
+// MarkSent records that a message was delivered
+func (p *Postgres) MarkSent(ctx context.Context, id int64) error {
+	_, err := p.db.ExecContext(ctx, `UPDATE outbox SET sent_at = $1 WHERE id = $2`, time.Now(), id)
+	return err
+}
+
+// MarkFailed records a failed attempt
+func (p *Postgres) MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time, giveUp bool) error {
+	query := `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2,
+		failed_at = CASE WHEN $3 THEN $4::timestamp END
+		WHERE id = $5
+	`
+	_, err := p.db.ExecContext(ctx, query, lastError, retryAt, giveUp, time.Now(), id)
+	return err
+}
+
+// Prune deletes this source's messages sent before sentBefore
+func (p *Postgres) Prune(ctx context.Context, sentBefore time.Time) (int64, error) {
+	result, err := p.db.ExecContext(ctx,
+		`DELETE FROM outbox WHERE source = $1 AND sent_at < $2`, p.source, sentBefore)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
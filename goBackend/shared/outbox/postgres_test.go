@@ -0,0 +1,63 @@
+package outbox
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestAdd(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`INSERT INTO outbox \(source, event_type, payload\)`).
+		WithArgs("task-service", "task.created", []byte(`{"id":3}`)).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Add(context.Background(), tx, "task-service", "task.created", map[string]int{"id": 3}); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgres_Claim(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`UPDATE outbox SET next_attempt_at = \$1\s+WHERE id IN \(\s+SELECT id FROM outbox\s+WHERE source = \$2 .* FOR UPDATE SKIP LOCKED`).
+		WithArgs(sqlmock.AnyArg(), "project-service", sqlmock.AnyArg(), 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "source", "event_type", "payload", "attempts", "created_at"}).
+			AddRow(7, "project-service", "project.updated", []byte(`{}`), 1, now).
+			AddRow(4, "project-service", "project.created", []byte(`{}`), 0, now))
+
+	messages, err := NewPostgres(db, "project-service").Claim(context.Background(), 10, time.Minute)
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	if len(messages) != 2 || messages[0].ID != 4 || messages[1].ID != 7 {
+		t.Errorf("Claim() = %+v, want messages 4 and 7 in order", messages)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
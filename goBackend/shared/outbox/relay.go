@@ -0,0 +1,157 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Handler delivers one message; an error leaves it in the outbox for a retry
+type Handler func(ctx context.Context, msg *Message) error
+
+// RelayConfig tunes a Relay; zero fields take the defaults
+type RelayConfig struct {
+	// PollInterval is how often the outbox is checked (default 1s)
+	PollInterval time.Duration
+	// BatchSize is how many messages are claimed at once (default 50)
+	BatchSize int
+	// Lease is how long a claimed message is hidden from other relays; it
+	// must outlast the handler (default 5m)
+	Lease time.Duration
+	// Timeout bounds each handler call, so a slow consumer can't hold a
+	// message past its lease and have it relayed twice at once. It is kept
+	// below Lease (default half of it).
+	Timeout time.Duration
+	// MaxAttempts is how often a message is tried before it is set aside
+	// as failed (default 10)
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled for each one
+	// after up to MaxBackoff (defaults 5s and 1h)
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Retention is how long sent messages are kept (default 7 days)
+	Retention time.Duration
+}
+
+func (c RelayConfig) withDefaults() RelayConfig {
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = 50
+	}
+	if c.Lease <= 0 {
+		c.Lease = 5 * time.Minute
+	}
+	if c.Timeout <= 0 || c.Timeout >= c.Lease {
+		c.Timeout = c.Lease / 2
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 10
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = 5 * time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = time.Hour
+	}
+	if c.Retention <= 0 {
+		c.Retention = 7 * 24 * time.Hour
+	}
+	return c
+}
+
+// Relay moves messages from the outbox to a Handler, marking each one sent
+// once the handler took it
+type Relay struct {
+	store   Store
+	handler Handler
+	cfg     RelayConfig
+	now     func() time.Time
+}
+
+// NewRelay creates a Relay from store to handler
+func NewRelay(store Store, handler Handler, cfg RelayConfig) *Relay {
+	return &Relay{store: store, handler: handler, cfg: cfg.withDefaults(), now: time.Now}
+}
+
+// Run relays messages until ctx is done, pruning old sent ones once an hour
+func (r *Relay) Run(ctx context.Context) {
+	poll := time.NewTicker(r.cfg.PollInterval)
+	defer poll.Stop()
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			// Keep going while full batches come back, so a backlog drains
+			// without waiting a poll interval per batch
+			for {
+				n, err := r.Flush(ctx)
+				if err != nil {
+					slog.ErrorContext(ctx, "outbox relay failed", "error", err)
+				}
+				if err != nil || n < r.cfg.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		case <-prune.C:
+			if _, err := r.store.Prune(ctx, r.now().Add(-r.cfg.Retention)); err != nil {
+				slog.WarnContext(ctx, "failed to prune the outbox", "error", err)
+			}
+		}
+	}
+}
+
+// Flush relays one batch of due messages and returns how many it claimed
+func (r *Relay) Flush(ctx context.Context) (int, error) {
+	messages, err := r.store.Claim(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+	for _, msg := range messages {
+		if err := r.handle(ctx, msg); err != nil {
+			r.failed(ctx, msg, err)
+			continue
+		}
+		if err := r.store.MarkSent(ctx, msg.ID); err != nil {
+			// The lease runs out and the message is relayed again
+			slog.WarnContext(ctx, "failed to mark outbox message sent", "id", msg.ID, "error", err)
+		}
+	}
+	return len(messages), nil
+}
+
+// handle runs the handler on msg within the configured timeout
+func (r *Relay) handle(ctx context.Context, msg *Message) error {
+	ctx, cancel := context.WithTimeout(ctx, r.cfg.Timeout)
+	defer cancel()
+	return r.handler(ctx, msg)
+}
+
+// failed schedules the next attempt at msg, or gives up on it
+func (r *Relay) failed(ctx context.Context, msg *Message, cause error) {
+	attempts := msg.Attempts + 1
+	giveUp := attempts >= r.cfg.MaxAttempts
+	if giveUp {
+		slog.ErrorContext(ctx, "giving up on outbox message", "id", msg.ID, "event", msg.EventType, "attempts", attempts, "error", cause)
+	}
+	if err := r.store.MarkFailed(ctx, msg.ID, cause.Error(), r.now().Add(r.backoff(attempts)), giveUp); err != nil {
+		slog.WarnContext(ctx, "failed to record outbox failure", "id", msg.ID, "error", err)
+	}
+}
+
+// backoff is the wait after the given number of failed attempts
+func (r *Relay) backoff(attempts int) time.Duration {
+	wait := r.cfg.Backoff
+	for i := 1; i < attempts && wait < r.cfg.MaxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > r.cfg.MaxBackoff {
+		wait = r.cfg.MaxBackoff
+	}
+	return wait
+}
@@ -0,0 +1,173 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore is an in-process Store whose clock the test controls
+type memoryStore struct {
+	mu       sync.Mutex
+	now      time.Time
+	messages []*storedMessage
+}
+
+type storedMessage struct {
+	Message
+	lastError string
+	due       time.Time
+	sent      bool
+	failed    bool
+}
+
+func (s *memoryStore) add(eventType string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, &storedMessage{
+		Message: Message{ID: int64(len(s.messages) + 1), EventType: eventType, Payload: []byte(`{}`)},
+		due:     s.now,
+	})
+}
+
+func (s *memoryStore) find(id int64) *storedMessage {
+	for _, m := range s.messages {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]*Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var claimed []*Message
+	for _, m := range s.messages {
+		if len(claimed) == limit {
+			break
+		}
+		if !m.sent && !m.failed && !m.due.After(s.now) {
+			m.due = s.now.Add(lease)
+			copied := m.Message
+			claimed = append(claimed, &copied)
+		}
+	}
+	return claimed, nil
+}
+
+func (s *memoryStore) MarkSent(ctx context.Context, id int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.find(id).sent = true
+	return nil
+}
+
+func (s *memoryStore) MarkFailed(ctx context.Context, id int64, lastError string, retryAt time.Time, giveUp bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m := s.find(id)
+	m.Attempts++
+	m.lastError = lastError
+	m.due = retryAt
+	m.failed = giveUp
+	return nil
+}
+
+func (s *memoryStore) Prune(ctx context.Context, sentBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestRelay_RetriesFailedDelivery(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{now: time.Now()}
+	store.add("task.created")
+
+	var calls int
+	handler := func(ctx context.Context, msg *Message) error {
+		calls++
+		if calls == 1 {
+			return errors.New("consumer down")
+		}
+		return nil
+	}
+	relay := NewRelay(store, handler, RelayConfig{Backoff: time.Minute})
+	relay.now = func() time.Time { return store.now }
+
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("Flush() = %d, %v; want 1 message", n, err)
+	}
+	m := store.find(1)
+	if m.sent || m.failed || m.Attempts != 1 || m.lastError != "consumer down" {
+		t.Fatalf("after a failed delivery message = %+v, want it pending with 1 attempt", m)
+	}
+
+	// Not due again until the backoff has passed
+	if n, _ := relay.Flush(ctx); n != 0 {
+		t.Fatalf("Flush() during backoff claimed %d messages", n)
+	}
+	store.now = store.now.Add(time.Minute)
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("Flush() after backoff = %d, %v; want 1 message", n, err)
+	}
+	if !store.find(1).sent || calls != 2 {
+		t.Errorf("message sent = %v after %d deliveries, want sent after 2", store.find(1).sent, calls)
+	}
+}
+
+func TestRelay_GivesUp(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{now: time.Now()}
+	store.add("project.deleted")
+
+	relay := NewRelay(store, func(ctx context.Context, msg *Message) error {
+		return errors.New("still down")
+	}, RelayConfig{MaxAttempts: 3, Backoff: time.Second})
+	relay.now = func() time.Time { return store.now }
+
+	for i := 0; i < 5; i++ {
+		relay.Flush(ctx)
+		store.now = store.now.Add(time.Hour)
+	}
+	m := store.find(1)
+	if !m.failed || m.Attempts != 3 {
+		t.Errorf("message = %+v, want failed after 3 attempts", m)
+	}
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	relay := NewRelay(nil, nil, RelayConfig{Backoff: time.Second, MaxBackoff: 5 * time.Second})
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := relay.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, w)
+		}
+	}
+}
+
+func TestRelay_HandlerTimeout(t *testing.T) {
+	ctx := context.Background()
+	store := &memoryStore{now: time.Now()}
+	store.add("task.updated")
+
+	// A handler outliving its timeout fails instead of holding the message
+	// past the lease
+	relay := NewRelay(store, func(ctx context.Context, msg *Message) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, RelayConfig{Lease: time.Minute, Timeout: 10 * time.Millisecond})
+	relay.now = func() time.Time { return store.now }
+
+	if n, err := relay.Flush(ctx); err != nil || n != 1 {
+		t.Fatalf("Flush() = %d, %v; want 1 message", n, err)
+	}
+	if m := store.find(1); m.sent || m.Attempts != 1 || m.lastError != context.DeadlineExceeded.Error() {
+		t.Errorf("message = %+v, want a failed attempt past its deadline", m)
+	}
+
+	if cfg := (RelayConfig{Lease: time.Minute, Timeout: time.Hour}).withDefaults(); cfg.Timeout >= cfg.Lease {
+		t.Errorf("Timeout = %v, want it kept below the %v lease", cfg.Timeout, cfg.Lease)
+	}
+}
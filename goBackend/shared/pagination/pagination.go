@@ -0,0 +1,55 @@
+// Package pagination holds the page sizes of the list endpoints. Each
+// service reads them from its config and hands them to the usecases that
+// list, which report the page size they applied back to the gateway. A
+// limit above the maximum is lowered to it rather than rejected.
+package pagination
+
+// Built-in sizes, used for Sizes fields left unset
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Sizes are the default and maximum page size of a list. The zero value
+// uses the built-in sizes.
+type Sizes struct {
+	Default int
+	Max     int
+}
+
+// New returns the sizes for defaultPageSize and maxPageSize; values below 1
+// take the built-in ones, and a default above the maximum is lowered to it
+func New(defaultPageSize, maxPageSize int) Sizes {
+	s := Sizes{Default: defaultPageSize, Max: maxPageSize}
+	if s.Max < 1 {
+		s.Max = MaxPageSize
+	}
+	if s.Default < 1 {
+		s.Default = DefaultPageSize
+	}
+	if s.Default > s.Max {
+		s.Default = s.Max
+	}
+	return s
+}
+
+// Limit clamps a requested page size: an unset (below 1) limit is the
+// default and one above the maximum is the maximum
+func (s Sizes) Limit(limit int) int {
+	s = New(s.Default, s.Max)
+	if limit < 1 {
+		return s.Default
+	}
+	if limit > s.Max {
+		return s.Max
+	}
+	return limit
+}
+
+// Normalize clamps page to at least 1 and limit with Limit
+func (s Sizes) Normalize(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	return page, s.Limit(limit)
+}
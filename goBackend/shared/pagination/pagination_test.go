@@ -0,0 +1,28 @@
+package pagination
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name                string
+		sizes               Sizes
+		page, limit         int
+		wantPage, wantLimit int
+	}{
+		{"Unset", Sizes{}, 0, 0, 1, DefaultPageSize},
+		{"In range", Sizes{}, 3, 50, 3, 50},
+		{"Above max is clamped", Sizes{}, 1, 5000, 1, MaxPageSize},
+		{"Configured sizes", New(15, 40), 1, 0, 1, 15},
+		{"Above configured max", New(15, 40), 2, 41, 2, 40},
+		{"Default above max", New(80, 40), 1, 0, 1, 40},
+		{"Unchecked sizes", Sizes{Default: 80, Max: 40}, 1, 0, 1, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, limit := tt.sizes.Normalize(tt.page, tt.limit)
+			if page != tt.wantPage || limit != tt.wantLimit {
+				t.Errorf("Normalize(%d, %d) = %d, %d; want %d, %d", tt.page, tt.limit, page, limit, tt.wantPage, tt.wantLimit)
+			}
+		})
+	}
+}
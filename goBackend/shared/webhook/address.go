@@ -0,0 +1,55 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+// Errors for webhook URLs that must not be called
+var (
+	ErrInvalidURL       = errors.New("webhook url must be an absolute http or https url")
+	ErrForbiddenAddress = errors.New("webhook url must not point to a loopback, link-local or private address")
+)
+
+// ValidateURL checks that raw is an http(s) URL that doesn't name an
+// internal host outright. Names that only resolve to one are refused when
+// a delivery dials them, since DNS can change after the check.
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return ErrForbiddenAddress
+	}
+	if ip := net.ParseIP(host); ip != nil && !isPublicIP(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
+
+// isPublicIP reports whether ip may receive deliveries: anything but
+// loopback, link-local, private, unspecified and multicast addresses
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsPrivate() && !ip.IsUnspecified() && !ip.IsMulticast()
+}
+
+// publicOnly is a net.Dialer Control func refusing every connection to an
+// address that isn't public. It runs after name resolution, for each address
+// the dialer tries, so a hostname can't reach an internal service through
+// its DNS records.
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+		return ErrForbiddenAddress
+	}
+	return nil
+}
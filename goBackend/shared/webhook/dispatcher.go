@@ -0,0 +1,269 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/portfolio/shared/outbox"
+)
+
+// Config tunes a Dispatcher; zero fields take the defaults
+type Config struct {
+	// MaxAttempts is how often an event is tried on each subscription before
+	// giving up on it (default 5)
+	MaxAttempts int
+	// Backoff is the wait before the first retry, doubled for each one after
+	// (default 1s)
+	Backoff time.Duration
+	// Timeout bounds each attempt (default 10s)
+	Timeout time.Duration
+	// AllowPrivateAddresses lets deliveries reach loopback, link-local and
+	// private addresses, which are refused by default so a subscription
+	// can't probe internal services. Only for tests and local development.
+	AllowPrivateAddresses bool
+}
+
+func (c Config) withDefaults() Config {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 5
+	}
+	if c.Backoff <= 0 {
+		c.Backoff = time.Second
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 10 * time.Second
+	}
+	return c
+}
+
+// Dispatcher delivers events to the subscriptions that want them. It is fed
+// from the outbox, which keeps an event until every subscription took it or
+// was given up on.
+type Dispatcher struct {
+	store  Store
+	client *http.Client
+	cfg    Config
+}
+
+// NewDispatcher creates a Dispatcher for the subscriptions in store
+func NewDispatcher(store Store, cfg Config) *Dispatcher {
+	cfg = cfg.withDefaults()
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateAddresses {
+		dialer.Control = publicOnly
+	}
+	client := &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &http.Transport{
+			// No proxy: it would be dialed instead of the checked endpoint
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: cfg.Timeout,
+			MaxIdleConnsPerHost: 2,
+		},
+		// A redirect could lead anywhere, including internal addresses
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return &Dispatcher{store: store, client: client, cfg: cfg}
+}
+
+// HandleOutbox is an outbox.Handler delivering the outbox's events. The
+// outbox message ID is the event ID, so it stays the same across retries.
+// Each call makes a single attempt per subscription and leaves the waiting
+// to the relay's backoff, so it returns well within the relay's lease.
+// Subscriptions that already took the event, refused it or had MaxAttempts
+// tries are skipped; the call fails only while some subscription is still
+// owed another try.
+func (d *Dispatcher) HandleOutbox(ctx context.Context, msg *outbox.Message) error {
+	event := Event{
+		ID:         strconv.FormatInt(msg.ID, 10),
+		Type:       msg.EventType,
+		OccurredAt: msg.CreatedAt.UTC(),
+		Data:       msg.Payload,
+	}
+	subs, body, err := d.prepare(ctx, event)
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+	past, err := d.store.ListEventDeliveries(ctx, event.ID)
+	if err != nil {
+		return fmt.Errorf("load webhook deliveries: %w", err)
+	}
+	tries := make(map[int64]int)
+	done := make(map[int64]bool)
+	for _, p := range past {
+		tries[p.WebhookID]++
+		if p.Succeeded || (p.StatusCode != 0 && !retryable(p.StatusCode)) {
+			done[p.WebhookID] = true
+		}
+	}
+
+	var owed []*Subscription
+	for _, sub := range subs {
+		if !done[sub.ID] && tries[sub.ID] < d.cfg.MaxAttempts {
+			owed = append(owed, sub)
+		}
+	}
+	return d.fanOut(ctx, owed, func(sub *Subscription) bool {
+		attempt := tries[sub.ID] + 1
+		delivered, retry := d.deliver(ctx, sub, event, body, attempt, attempt)
+		return delivered || !retry || attempt == d.cfg.MaxAttempts
+	})
+}
+
+// Deliver sends event to every subscription that wants it, retrying each
+// one up to MaxAttempts with a backoff. It fails if any subscription didn't
+// take it.
+func (d *Dispatcher) Deliver(ctx context.Context, event Event) error {
+	subs, body, err := d.prepare(ctx, event)
+	if err != nil || len(subs) == 0 {
+		return err
+	}
+	return d.fanOut(ctx, subs, func(sub *Subscription) bool {
+		delivered, _ := d.deliver(ctx, sub, event, body, 1, d.cfg.MaxAttempts)
+		return delivered
+	})
+}
+
+// prepare loads the subscriptions that want event and encodes its body
+func (d *Dispatcher) prepare(ctx context.Context, event Event) ([]*Subscription, []byte, error) {
+	subs, err := d.store.ListForEvent(ctx, event.Type)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load webhooks: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil, nil, nil
+	}
+	body, err := json.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	return subs, body, nil
+}
+
+// fanOut runs send for every subscription side by side, so one slow
+// endpoint doesn't delay the others, and fails if any send reported false
+func (d *Dispatcher) fanOut(ctx context.Context, subs []*Subscription, send func(*Subscription) bool) error {
+	var (
+		wg     sync.WaitGroup
+		failed atomic.Int32
+	)
+	for _, sub := range subs {
+		wg.Add(1)
+		go func(sub *Subscription) {
+			defer wg.Done()
+			if !send(sub) {
+				failed.Add(1)
+			}
+		}(sub)
+	}
+	wg.Wait()
+	if n := failed.Load(); n > 0 {
+		return fmt.Errorf("%d of %d webhooks failed", n, len(subs))
+	}
+	return nil
+}
+
+// deliver POSTs body to sub as attempts first through last until it is
+// accepted or the failure is permanent, recording every attempt. It reports
+// whether sub took the event and, if not, whether another try could change
+// that.
+func (d *Dispatcher) deliver(ctx context.Context, sub *Subscription, event Event, body []byte, first, last int) (delivered, retry bool) {
+	wait := d.cfg.Backoff
+	for attempt := first; attempt <= last; attempt++ {
+		statusCode, err := d.post(ctx, sub, event, body)
+		delivery := &Delivery{
+			WebhookID:  sub.ID,
+			EventID:    event.ID,
+			EventType:  event.Type,
+			Attempt:    attempt,
+			StatusCode: statusCode,
+			Succeeded:  err == nil,
+			CreatedAt:  time.Now(),
+		}
+		if err != nil {
+			delivery.Error = err.Error()
+		}
+		if recordErr := d.store.RecordDelivery(ctx, delivery); recordErr != nil {
+			slog.WarnContext(ctx, "failed to record webhook delivery", "webhook_id", sub.ID, "error", recordErr)
+		}
+
+		if err == nil {
+			return true, false
+		}
+		if !retryable(statusCode) || refused(err) {
+			slog.WarnContext(ctx, "webhook delivery failed", "webhook_id", sub.ID, "event_id", event.ID, "attempts", attempt, "error", err)
+			return false, false
+		}
+		if attempt == last {
+			slog.WarnContext(ctx, "webhook delivery failed", "webhook_id", sub.ID, "event_id", event.ID, "attempts", attempt, "error", err)
+			return false, true
+		}
+		select {
+		case <-ctx.Done():
+			return false, true
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+	return false, true
+}
+
+// post makes one delivery attempt and returns the response status, failing
+// unless it is 2xx
+func (d *Dispatcher) post(ctx context.Context, sub *Subscription, event Event, body []byte) (int, error) {
+	// Subscriptions are checked when created too, but may predate the check
+	if err := ValidateURL(sub.URL); err == ErrInvalidURL || (err != nil && !d.cfg.AllowPrivateAddresses) {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, event.Type)
+	req.Header.Set(DeliveryHeader, event.ID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(sub.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// refused reports whether err is a subscription URL the dispatcher won't
+// call, which no retry changes
+func refused(err error) bool {
+	return errors.Is(err, ErrInvalidURL) || errors.Is(err, ErrForbiddenAddress)
+}
+
+// retryable reports whether an attempt that got statusCode is worth
+// repeating. Other client errors would be refused again, so only network
+// failures, timeouts, rate limits and server errors are retried.
+func retryable(statusCode int) bool {
+	return statusCode == 0 ||
+		statusCode == http.StatusRequestTimeout ||
+		statusCode == http.StatusTooManyRequests ||
+		statusCode >= 500
+}
@@ -0,0 +1,283 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/portfolio/shared/outbox"
+)
+
+func TestDispatcher_SignsDeliveries(t *testing.T) {
+	type received struct {
+		body      []byte
+		signature string
+		timestamp string
+		event     string
+		delivery  string
+	}
+	got := make(chan received, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- received{
+			body:      body,
+			signature: r.Header.Get(SignatureHeader),
+			timestamp: r.Header.Get(TimestampHeader),
+			event:     r.Header.Get(EventHeader),
+			delivery:  r.Header.Get(DeliveryHeader),
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store := NewMemory()
+	sub := &Subscription{URL: server.URL, Secret: "s3cret", EventTypes: []string{TaskCreated}}
+	store.Create(ctx, sub)
+	store.Create(ctx, &Subscription{URL: server.URL, Secret: "other", EventTypes: []string{TaskDeleted}})
+
+	msg := &outbox.Message{ID: 12, EventType: TaskCreated, Payload: []byte(`{"id":7,"project_id":3}`), CreatedAt: time.Now()}
+	if err := NewDispatcher(store, Config{AllowPrivateAddresses: true}).HandleOutbox(ctx, msg); err != nil {
+		t.Fatalf("HandleOutbox() error = %v", err)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("got %d deliveries, want 1", len(got))
+	}
+	r := <-got
+	if r.event != TaskCreated || r.delivery != "12" {
+		t.Errorf("headers event=%q delivery=%q, want %q and the outbox ID", r.event, r.delivery, TaskCreated)
+	}
+	if !Verify("s3cret", r.body, r.timestamp, r.signature, time.Now()) {
+		t.Errorf("signature %q does not match the body", r.signature)
+	}
+	var event struct {
+		ID   string `json:"id"`
+		Type string `json:"type"`
+		Data struct {
+			ID int64 `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(r.body, &event); err != nil {
+		t.Fatalf("body is not an event: %v", err)
+	}
+	if event.ID != "12" || event.Type != TaskCreated || event.Data.ID != 7 {
+		t.Errorf("event = %+v", event)
+	}
+
+	deliveries, _ := store.ListDeliveries(ctx, sub.ID, 0)
+	if len(deliveries) != 1 || !deliveries[0].Succeeded || deliveries[0].StatusCode != http.StatusOK {
+		t.Errorf("deliveries = %+v, want one successful 200", deliveries)
+	}
+}
+
+func TestDispatcher_Retries(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		failStatus   int
+		wantAttempts int
+		wantSuccess  bool
+	}{
+		{"Server errors are retried", 2, http.StatusInternalServerError, 3, true},
+		{"Gives up after max attempts", 10, http.StatusServiceUnavailable, 4, false},
+		{"Client errors are not retried", 10, http.StatusBadRequest, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if atomic.AddInt32(&calls, 1) <= tt.failures {
+					w.WriteHeader(tt.failStatus)
+				}
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			store := NewMemory()
+			sub := &Subscription{URL: server.URL, Secret: "s", EventTypes: []string{TaskUpdated}}
+			store.Create(ctx, sub)
+
+			d := NewDispatcher(store, Config{MaxAttempts: 4, Backoff: time.Millisecond, AllowPrivateAddresses: true})
+			err := d.Deliver(ctx, Event{ID: "1", Type: TaskUpdated, Data: []byte(`{}`)})
+			if (err == nil) != tt.wantSuccess {
+				t.Errorf("Deliver() error = %v, want success %v", err, tt.wantSuccess)
+			}
+
+			deliveries, _ := store.ListDeliveries(ctx, sub.ID, 0)
+			if len(deliveries) != tt.wantAttempts {
+				t.Fatalf("got %d attempts, want %d", len(deliveries), tt.wantAttempts)
+			}
+			last := deliveries[0]
+			if last.Attempt != tt.wantAttempts || last.Succeeded != tt.wantSuccess {
+				t.Errorf("last attempt = %+v, want attempt %d succeeded=%v", last, tt.wantAttempts, tt.wantSuccess)
+			}
+			if !tt.wantSuccess && last.Error == "" {
+				t.Error("failed attempt has no error recorded")
+			}
+		})
+	}
+}
+
+func TestDispatcher_HandleOutboxTracksSubscriptions(t *testing.T) {
+	var okCalls, flakyCalls, badCalls, downCalls atomic.Int32
+	handler := func(calls *atomic.Int32, status func(n int32) int) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status(calls.Add(1)))
+		}))
+	}
+	ok := handler(&okCalls, func(int32) int { return http.StatusOK })
+	defer ok.Close()
+	flaky := handler(&flakyCalls, func(n int32) int {
+		if n == 1 {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusOK
+	})
+	defer flaky.Close()
+	bad := handler(&badCalls, func(int32) int { return http.StatusBadRequest })
+	defer bad.Close()
+	down := handler(&downCalls, func(int32) int { return http.StatusBadGateway })
+	defer down.Close()
+
+	ctx := context.Background()
+	store := NewMemory()
+	for _, url := range []string{ok.URL, flaky.URL, bad.URL} {
+		store.Create(ctx, &Subscription{URL: url, Secret: "s", EventTypes: []string{TaskUpdated}})
+	}
+	d := NewDispatcher(store, Config{MaxAttempts: 3, Backoff: time.Hour, AllowPrivateAddresses: true})
+	msg := &outbox.Message{ID: 5, EventType: TaskUpdated, Payload: []byte(`{}`), CreatedAt: time.Now()}
+
+	// One attempt per subscription, without waiting out the backoff; the
+	// flaky one is still owed the event
+	if err := d.HandleOutbox(ctx, msg); err == nil {
+		t.Fatal("HandleOutbox() succeeded with a subscription still failing")
+	}
+	// The retry only reaches the subscription that didn't take it; the one
+	// answering 400 isn't tried again
+	if err := d.HandleOutbox(ctx, msg); err != nil {
+		t.Fatalf("second HandleOutbox() error = %v", err)
+	}
+	if okCalls.Load() != 1 || flakyCalls.Load() != 2 || badCalls.Load() != 1 {
+		t.Errorf("calls ok=%d flaky=%d bad=%d, want 1, 2 and 1", okCalls.Load(), flakyCalls.Load(), badCalls.Load())
+	}
+	deliveries, _ := store.ListEventDeliveries(ctx, "5")
+	if last := deliveries[len(deliveries)-1]; last.Attempt != 2 || !last.Succeeded {
+		t.Errorf("last delivery = %+v, want a successful second attempt", last)
+	}
+
+	// A subscription that keeps failing is given up on after MaxAttempts
+	store.Create(ctx, &Subscription{URL: down.URL, Secret: "s", EventTypes: []string{TaskDeleted}})
+	msg = &outbox.Message{ID: 6, EventType: TaskDeleted, Payload: []byte(`{}`), CreatedAt: time.Now()}
+	for i := 1; i <= 4; i++ {
+		err := d.HandleOutbox(ctx, msg)
+		if wantErr := i < 3; (err != nil) != wantErr {
+			t.Errorf("HandleOutbox() #%d error = %v, want error %v", i, err, wantErr)
+		}
+	}
+	if n := downCalls.Load(); n != 3 {
+		t.Errorf("failing endpoint called %d times, want 3", n)
+	}
+}
+
+func TestDispatcher_RefusesInternalAddresses(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	// A public name that redirects to the loopback server is refused too
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, server.URL, http.StatusFound)
+	}))
+	defer redirect.Close()
+
+	tests := []struct {
+		name  string
+		url   string
+		allow bool
+	}{
+		{"Loopback", server.URL, false},
+		{"Localhost name", strings.Replace(server.URL, "127.0.0.1", "localhost", 1), false},
+		{"Not http", "ftp://example.com/hook", true},
+		{"Redirect", redirect.URL, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			store := NewMemory()
+			sub := &Subscription{URL: tt.url, Secret: "s", EventTypes: []string{TaskCreated}}
+			store.Create(ctx, sub)
+
+			d := NewDispatcher(store, Config{MaxAttempts: 3, Backoff: time.Millisecond, AllowPrivateAddresses: tt.allow})
+			if err := d.Deliver(ctx, Event{ID: "1", Type: TaskCreated, Data: []byte(`{}`)}); err == nil {
+				t.Fatal("Deliver() succeeded, want it refused")
+			}
+			if deliveries, _ := store.ListDeliveries(ctx, sub.ID, 0); len(deliveries) != 1 {
+				t.Errorf("got %d attempts, want 1 without retries", len(deliveries))
+			}
+		})
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("the internal endpoint was called %d times", n)
+	}
+}
+
+func TestDispatcher_DialerChecksResolvedAddress(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1:80", "10.1.2.3:443", "169.254.169.254:80", "[::1]:80", "192.168.0.7:8080"} {
+		if err := publicOnly("tcp", addr, nil); !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("publicOnly(%s) = %v, want ErrForbiddenAddress", addr, err)
+		}
+	}
+	if err := publicOnly("tcp", "93.184.216.34:443", nil); err != nil {
+		t.Errorf("publicOnly(public) = %v, want nil", err)
+	}
+}
+
+func TestValidateURL(t *testing.T) {
+	tests := map[string]error{
+		"https://hooks.example.com/x": nil,
+		"http://93.184.216.34/hook":   nil,
+		"ftp://hooks.example.com":     ErrInvalidURL,
+		"https:///no-host":            ErrInvalidURL,
+		"http://localhost:8080/hook":  ErrForbiddenAddress,
+		"http://127.0.0.1/hook":       ErrForbiddenAddress,
+		"http://[::1]/hook":           ErrForbiddenAddress,
+		"http://10.0.0.5/hook":        ErrForbiddenAddress,
+		"http://169.254.169.254/meta": ErrForbiddenAddress,
+	}
+	for raw, want := range tests {
+		if err := ValidateURL(raw); err != want {
+			t.Errorf("ValidateURL(%q) = %v, want %v", raw, err, want)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"id":"1"}`)
+	now := time.Unix(1700000000, 0)
+	sig := Sign("s3cret", now.Unix(), body)
+	ts := strconv.FormatInt(now.Unix(), 10)
+
+	if !Verify("s3cret", body, ts, sig, now.Add(time.Minute)) {
+		t.Error("Verify() rejected a fresh delivery")
+	}
+	if Verify("s3cret", body, ts, sig, now.Add(SignatureTolerance+time.Second)) {
+		t.Error("Verify() accepted a replay past the tolerance")
+	}
+	if Verify("s3cret", body, strconv.FormatInt(now.Unix()+1, 10), sig, now) {
+		t.Error("Verify() accepted a signature under another timestamp")
+	}
+	if Verify("other", body, ts, sig, now) {
+		t.Error("Verify() accepted the wrong secret")
+	}
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process Store for tests and local runs
+type Memory struct {
+	mu         sync.Mutex
+	nextID     int64
+	subs       map[int64]*Subscription
+	deliveries []*Delivery
+}
+
+// NewMemory creates an empty Memory store
+func NewMemory() *Memory {
+	return &Memory{subs: make(map[int64]*Subscription)}
+}
+
+var _ Store = (*Memory)(nil)
+
+// Create stores sub and assigns its ID
+func (m *Memory) Create(ctx context.Context, sub *Subscription) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	sub.ID = m.nextID
+	if sub.CreatedAt.IsZero() {
+		sub.CreatedAt = time.Now()
+	}
+	stored := *sub
+	stored.EventTypes = append([]string(nil), sub.EventTypes...)
+	m.subs[sub.ID] = &stored
+	return nil
+}
+
+// List returns every subscription, oldest first
+func (m *Memory) List(ctx context.Context) ([]*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sorted(func(*Subscription) bool { return true }), nil
+}
+
+// Delete removes a subscription and its deliveries
+func (m *Memory) Delete(ctx context.Context, id int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.subs[id]; !ok {
+		return sql.ErrNoRows
+	}
+	delete(m.subs, id)
+	kept := m.deliveries[:0]
+	for _, d := range m.deliveries {
+		if d.WebhookID != id {
+			kept = append(kept, d)
+		}
+	}
+	m.deliveries = kept
+	return nil
+}
+
+// ListForEvent returns the subscriptions that want eventType
+func (m *Memory) ListForEvent(ctx context.Context, eventType string) ([]*Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sorted(func(s *Subscription) bool { return s.Wants(eventType) }), nil
+}
+
+// RecordDelivery stores a delivery attempt
+func (m *Memory) RecordDelivery(ctx context.Context, delivery *Delivery) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delivery.ID = int64(len(m.deliveries) + 1)
+	stored := *delivery
+	m.deliveries = append(m.deliveries, &stored)
+	return nil
+}
+
+// ListDeliveries returns a subscription's latest attempts, newest first
+func (m *Memory) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*Delivery
+	for i := len(m.deliveries) - 1; i >= 0 && (limit <= 0 || len(result) < limit); i-- {
+		if d := m.deliveries[i]; d.WebhookID == webhookID {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+// ListEventDeliveries returns every attempt at eventID, oldest first
+func (m *Memory) ListEventDeliveries(ctx context.Context, eventID string) ([]*Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var result []*Delivery
+	for _, d := range m.deliveries {
+		if d.EventID == eventID {
+			copied := *d
+			result = append(result, &copied)
+		}
+	}
+	return result, nil
+}
+
+// sorted returns copies of the subscriptions keep accepts by ID; m.mu must be held
+func (m *Memory) sorted(keep func(*Subscription) bool) []*Subscription {
+	var result []*Subscription
+	for _, s := range m.subs {
+		if keep(s) {
+			copied := *s
+			result = append(result, &copied)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
@@ -0,0 +1,136 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+)
+
+// Postgres is a Store over the webhooks and webhook_deliveries tables
+type Postgres struct {
+	db *sql.DB
+}
+
+// NewPostgres creates a Postgres store
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+var _ Store = (*Postgres)(nil)
+
+// Create stores sub and assigns its ID
+func (p *Postgres) Create(ctx context.Context, sub *Subscription) error {
+	query := `
+		INSERT INTO webhooks (url, secret, event_types)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+	return p.db.QueryRowContext(ctx, query, sub.URL, sub.Secret, pq.Array(sub.EventTypes)).
+		Scan(&sub.ID, &sub.CreatedAt)
+}
+
+// List returns every subscription, oldest first
+func (p *Postgres) List(ctx context.Context) ([]*Subscription, error) {
+	return p.query(ctx, `
+		SELECT id, url, secret, event_types, created_at
+		FROM webhooks
+		ORDER BY id
+	`)
+}
+
+// Delete removes a subscription; its deliveries go with it
+func (p *Postgres) Delete(ctx context.Context, id int64) error {
+	result, err := p.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+// ListForEvent returns the subscriptions that want eventType
+func (p *Postgres) ListForEvent(ctx context.Context, eventType string) ([]*Subscription, error) {
+	return p.query(ctx, `
+		SELECT id, url, secret, event_types, created_at
+		FROM webhooks
+		WHERE $1 = ANY(event_types)
+		ORDER BY id
+	`, eventType)
+}
+
+// RecordDelivery stores a delivery attempt
+func (p *Postgres) RecordDelivery(ctx context.Context, d *Delivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event_type, attempt, status_code, error, succeeded, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id
+	`
+	return p.db.QueryRowContext(ctx, query,
+		d.WebhookID, d.EventID, d.EventType, d.Attempt, d.StatusCode, d.Error, d.Succeeded, d.CreatedAt,
+	).Scan(&d.ID)
+}
+
+// ListDeliveries returns a subscription's latest attempts, newest first
+func (p *Postgres) ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*Delivery, error) {
+	return p.queryDeliveries(ctx, `
+		SELECT id, webhook_id, event_id, event_type, attempt, status_code, error, succeeded, created_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2
+	`, webhookID, limit)
+}
+
+// ListEventDeliveries returns every attempt at eventID, oldest first
+func (p *Postgres) ListEventDeliveries(ctx context.Context, eventID string) ([]*Delivery, error) {
+	return p.queryDeliveries(ctx, `
+		SELECT id, webhook_id, event_id, event_type, attempt, status_code, error, succeeded, created_at
+		FROM webhook_deliveries
+		WHERE event_id = $1
+		ORDER BY id
+	`, eventID)
+}
+
+func (p *Postgres) queryDeliveries(ctx context.Context, query string, args ...any) ([]*Delivery, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*Delivery
+	for rows.Next() {
+		d := &Delivery{}
+		if err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Attempt,
+			&d.StatusCode, &d.Error, &d.Succeeded, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+func (p *Postgres) query(ctx context.Context, query string, args ...any) ([]*Subscription, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []*Subscription
+	for rows.Next() {
+		s := &Subscription{}
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, pq.Array(&s.EventTypes), &s.CreatedAt); err != nil {
+			return nil, err
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
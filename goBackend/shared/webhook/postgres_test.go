@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+)
+
+func TestPostgres_ListForEvent(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`WHERE \$1 = ANY\(event_types\)`).
+		WithArgs(TaskCreated).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "url", "secret", "event_types", "created_at"}).
+			AddRow(1, "https://example.com/hook", "s", "{task.created,task.deleted}", now))
+
+	subs, err := NewPostgres(db).ListForEvent(context.Background(), TaskCreated)
+	if err != nil {
+		t.Fatalf("ListForEvent() error = %v", err)
+	}
+	if len(subs) != 1 {
+		t.Fatalf("ListForEvent() returned %d subscriptions, want 1", len(subs))
+	}
+	if want := []string{TaskCreated, TaskDeleted}; !reflect.DeepEqual(subs[0].EventTypes, want) {
+		t.Errorf("EventTypes = %v, want %v", subs[0].EventTypes, want)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgres_DeleteMissing(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec(`DELETE FROM webhooks WHERE id = \$1`).
+		WithArgs(int64(9)).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := NewPostgres(db).Delete(context.Background(), 9); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Delete() error = %v, want sql.ErrNoRows", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPostgres_ListEventDeliveries(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New() error = %v", err)
+	}
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery(`WHERE event_id = \$1\s+ORDER BY id`).
+		WithArgs("12").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event_type", "attempt", "status_code", "error", "succeeded", "created_at"}).
+			AddRow(1, 3, "12", TaskCreated, 1, 503, "endpoint responded 503", false, now).
+			AddRow(2, 3, "12", TaskCreated, 2, 200, "", true, now))
+
+	deliveries, err := NewPostgres(db).ListEventDeliveries(context.Background(), "12")
+	if err != nil {
+		t.Fatalf("ListEventDeliveries() error = %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[0].Succeeded || !deliveries[1].Succeeded || deliveries[1].Attempt != 2 {
+		t.Errorf("ListEventDeliveries() = %+v, want the failed and the successful attempt in order", deliveries)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
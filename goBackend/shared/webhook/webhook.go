@@ -0,0 +1,135 @@
+// Package webhook delivers task and project changes to external systems.
+// Subscriptions are kept in the webhooks table; every event is POSTed as
+// JSON to the subscriptions that asked for its type, signed with their
+// secret, and each attempt is recorded in webhook_deliveries.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Event types a webhook can subscribe to
+const (
+	TaskCreated    = "task.created"
+	TaskUpdated    = "task.updated"
+	TaskDeleted    = "task.deleted"
+	ProjectCreated = "project.created"
+	ProjectUpdated = "project.updated"
+	ProjectDeleted = "project.deleted"
+)
+
+// EventTypes returns every event type a webhook can subscribe to
+func EventTypes() []string {
+	return []string{TaskCreated, TaskUpdated, TaskDeleted, ProjectCreated, ProjectUpdated, ProjectDeleted}
+}
+
+// IsValidEventType reports whether eventType can be subscribed to
+func IsValidEventType(eventType string) bool {
+	for _, t := range EventTypes() {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Request headers of a delivery
+const (
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	TimestampHeader = "X-Webhook-Timestamp"
+	SignatureHeader = "X-Webhook-Signature"
+)
+
+// SignatureTolerance is how old a delivery's timestamp may be before
+// Verify rejects it as a replay
+const SignatureTolerance = 5 * time.Minute
+
+// Subscription is an endpoint that wants some event types
+type Subscription struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	Secret     string    `json:"-"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Wants reports whether the subscription asked for eventType
+func (s *Subscription) Wants(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the JSON body of a delivery. Data is the task or project after
+// the change, or just its IDs when it was deleted.
+type Event struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// Delivery is one attempt to deliver an event to a subscription.
+// StatusCode is 0 when no response was received.
+type Delivery struct {
+	ID         int64
+	WebhookID  int64
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int
+	Error      string
+	Succeeded  bool
+	CreatedAt  time.Time
+}
+
+// Store keeps subscriptions and their delivery attempts
+type Store interface {
+	Create(ctx context.Context, sub *Subscription) error
+	List(ctx context.Context) ([]*Subscription, error)
+	// Delete fails with sql.ErrNoRows when there is no such subscription
+	Delete(ctx context.Context, id int64) error
+	// ListForEvent returns the subscriptions that want eventType
+	ListForEvent(ctx context.Context, eventType string) ([]*Subscription, error)
+	RecordDelivery(ctx context.Context, delivery *Delivery) error
+	// ListDeliveries returns a subscription's latest attempts, newest first
+	ListDeliveries(ctx context.Context, webhookID int64, limit int) ([]*Delivery, error)
+	// ListEventDeliveries returns every attempt at eventID, oldest first
+	ListEventDeliveries(ctx context.Context, eventID string) ([]*Delivery, error)
+}
+
+// Sign returns the signature header value for body sent at timestamp, in
+// Unix seconds: "sha256=" followed by the hex HMAC-SHA256, keyed with
+// secret, of the timestamp, a dot and body. Receivers recompute it to check
+// that a delivery came from us and wasn't altered, and check the timestamp
+// so a captured delivery can't be replayed later.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of body sent at
+// timestamp, the TimestampHeader value, under secret, and timestamp is
+// within SignatureTolerance of now
+func Verify(secret string, body []byte, timestamp, signature string, now time.Time) bool {
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > SignatureTolerance || age < -SignatureTolerance {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, ts, body)), []byte(signature))
+}